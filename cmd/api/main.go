@@ -14,6 +14,10 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
+// @SecurityDefinitions.apikey AdminKey
+// @in header
+// @name X-Admin-Key
+// @description Admin API key set with ADMIN_API_KEY.
 //
 // @contact.name API Support
 // @contact.url http://www.example.com/support
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/vukieuhaihoa/user-service/internal/infrastructure"
+)
+
+func main() {
+	backfill := flag.Bool("backfill", false, "write the canonical forms of the users outside of a collision")
+	flag.Parse()
+
+	infrastructure.RunNormalizationScan(*backfill)
+}
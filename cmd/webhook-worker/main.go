@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/vukieuhaihoa/user-service/internal/infrastructure"
+)
+
+func main() {
+	infrastructure.RunWebhookWorker()
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+)
+
+// adminKeyHeader is the header carrying the admin API key.
+const adminKeyHeader = "X-Admin-Key"
+
+// requireAdmin restricts a route to callers presenting the admin API key.
+// The admin API is disabled, rejecting every request, while no key is configured.
+func requireAdmin(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := c.GetHeader(adminKeyHeader)
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(apiKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/redis/go-redis/v9"
 	swaggerFiles "github.com/swaggo/files"
@@ -18,20 +19,71 @@ import (
 
 	middleware "github.com/vukieuhaihoa/bookmark-libs/middlewares"
 
-	"github.com/vukieuhaihoa/bookmark-libs/ratelimit"
+	accessTokenHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/accesstoken"
+	accessTokenRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/accesstoken"
+	accessTokenService "github.com/vukieuhaihoa/user-service/internal/app/service/accesstoken"
+
+	emailChangeHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/emailchange"
+	emailChangeRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/emailchange"
+	emailChangeService "github.com/vukieuhaihoa/user-service/internal/app/service/emailchange"
 
 	healthCheckHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/healthcheck"
 	healthCheckRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/healthcheck"
 	healthCheckService "github.com/vukieuhaihoa/user-service/internal/app/service/healthcheck"
 
+	identityHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/identity"
+	identityRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/identity"
+	identityService "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+
+	invitationHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/invitation"
+	invitationRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/invitation"
+	invitationService "github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+
+	loginHistoryHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/loginhistory"
+	loginHistoryRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/loginhistory"
+	loginHistoryService "github.com/vukieuhaihoa/user-service/internal/app/service/loginhistory"
+
+	magicLinkHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/magiclink"
+	magicLinkRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/magiclink"
+	magicLinkService "github.com/vukieuhaihoa/user-service/internal/app/service/magiclink"
+
+	organizationHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/organization"
+	organizationRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/organization"
+	organizationService "github.com/vukieuhaihoa/user-service/internal/app/service/organization"
+
+	passkeyHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/passkey"
+	passkeyRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/passkey"
+	passkeyService "github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+
+	sessionHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/session"
+	sessionRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/session"
+	sessionService "github.com/vukieuhaihoa/user-service/internal/app/service/session"
+
 	userHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/user"
 	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
 	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
 
+	usernamePolicyHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/usernamepolicy"
+	usernamePolicyRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy"
+	usernamePolicyService "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+
+	webhookHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/webhook"
+	webhookRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook"
+	webhookService "github.com/vukieuhaihoa/user-service/internal/app/service/webhook"
+
 	nrgin "github.com/newrelic/go-agent/v3/integrations/nrgin"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/validators"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/passwordhash"
+	"github.com/vukieuhaihoa/user-service/internal/ratepolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
 )
 
 var registerValidationsOnce sync.Once
@@ -66,7 +118,8 @@ type api struct {
 	// randomCodeGen is the code generator used for generating random codes
 	randomCodeGen utils.CodeGenerator
 
-	passwordHashing utils.PasswordHashing
+	// passwordHashing hashes new passwords with the preferred algorithm and verifies any registered one
+	passwordHashing passwordhash.PasswordHashing
 
 	db *gorm.DB
 
@@ -75,6 +128,27 @@ type api struct {
 	jwtValidator jwtutils.JWTValidator
 
 	nrClient *newrelic.Application
+
+	// oidcProviders holds the configured upstream identity providers keyed by name
+	oidcProviders map[string]identityService.Provider
+
+	// mailer sends the emails of the service, such as login links
+	mailer mailer.Mailer
+
+	// notifier alerts users about logins from new devices
+	notifier notifier.Notifier
+
+	// webAuthn is the relying party running the passkey ceremonies
+	webAuthn *webauthn.WebAuthn
+
+	// breachChecker screens new passwords against breached passwords
+	breachChecker breach.Checker
+
+	// botGuard challenges registrations and logins that look scripted
+	botGuard botprotection.Guard
+
+	// emailPolicy rejects email addresses of disposable, denied or undeliverable domains
+	emailPolicy emailpolicy.Checker
 }
 
 type EngineOpts struct {
@@ -83,10 +157,17 @@ type EngineOpts struct {
 	RedisClient     *redis.Client
 	SqlDB           *gorm.DB
 	RandomCodeGen   utils.CodeGenerator
-	PasswordHashing utils.PasswordHashing
+	PasswordHashing passwordhash.PasswordHashing
 	JWTGenerator    jwtutils.JWTGenerator
 	JWTValidator    jwtutils.JWTValidator
 	NrClient        *newrelic.Application
+	OIDCProviders   map[string]identityService.Provider
+	Mailer          mailer.Mailer
+	Notifier        notifier.Notifier
+	WebAuthn        *webauthn.WebAuthn
+	BreachChecker   breach.Checker
+	BotGuard        botprotection.Guard
+	EmailPolicy     emailpolicy.Checker
 }
 
 // New creates a new instance of the API engine with the provided options.
@@ -108,6 +189,22 @@ func New(opts *EngineOpts) Engine {
 		jwtGenerator:    opts.JWTGenerator,
 		jwtValidator:    opts.JWTValidator,
 		nrClient:        opts.NrClient,
+		oidcProviders:   opts.OIDCProviders,
+		mailer:          opts.Mailer,
+		notifier:        opts.Notifier,
+		webAuthn:        opts.WebAuthn,
+		breachChecker:   opts.BreachChecker,
+		botGuard:        opts.BotGuard,
+		emailPolicy:     opts.EmailPolicy,
+	}
+	if a.breachChecker == nil {
+		a.breachChecker = breach.NewDisabledChecker()
+	}
+	if a.botGuard == nil {
+		a.botGuard = botprotection.NewDisabledGuard()
+	}
+	if a.emailPolicy == nil {
+		a.emailPolicy = emailpolicy.NewDisabledChecker()
 	}
 
 	a.registerValidations()
@@ -144,6 +241,9 @@ func (a *api) registerRoutes() {
 
 	a.app.Use(nrgin.Middleware(a.nrClient)) // Add New Relic middleware to capture performance metrics
 
+	// Queries of the tenant-owned models are scoped to the tenant of the request
+	a.app.Use(tenant.NewResolver(a.cfg.TenantHosts, a.cfg.TenantTrustHeader).Middleware())
+
 	// Swagger info setup
 	docs.SwaggerInfo.Host = a.cfg.AppHostName
 
@@ -154,20 +254,101 @@ func (a *api) registerRoutes() {
 
 	// API version group
 	v1 := a.app.Group("/v1")
-	v1.Use(allMiddlewares.rateLimitMiddleware.RateLimit(middleware.RateLimitIPKey)) // Apply rate limiting middleware to all /v1 routes
+	v1.Use(allMiddlewares.rateLimiter.Limit(ratepolicy.DefaultIPPolicy)) // Apply rate limiting middleware to all /v1 routes
 	{
 		v1.POST("/users/register", allHandler.userHandler.CreateUser)
 
 		v1.POST("/users/login", allHandler.userHandler.Login)
 
+		v1.GET("/users/login/oidc/:provider", allHandler.identityHandler.StartLogin)
+		v1.GET("/users/login/oidc/:provider/callback", allHandler.identityHandler.LoginCallback)
+
+		v1.POST("/users/login/magic-link", allHandler.magicLinkHandler.RequestLink)
+		v1.POST("/users/login/magic-link/verify", allHandler.magicLinkHandler.VerifyLink)
+
+		v1.POST("/users/login/passkey/options", allHandler.passkeyHandler.BeginLogin)
+		v1.POST("/users/login/passkey/verify", allHandler.passkeyHandler.FinishLogin)
+
+		v1.GET("/users/by-username/:username", allHandler.userHandler.GetUserByUsername)
+
+		v1.POST("/users/email-change/confirm", allHandler.emailChangeHandler.ConfirmChange)
+		v1.POST("/users/email-change/cancel", allHandler.emailChangeHandler.CancelChange)
+
 	}
 
 	v1Private := a.app.Group("/v1")
 	v1Private.Use(allMiddlewares.jwtAuth.JWTAuth())
-	v1Private.Use(allMiddlewares.rateLimitMiddleware.RateLimit(middleware.RateLimitUserIDKey)) // Apply rate limiting middleware to all /v1 routes for authenticated users
+	v1Private.Use(requireTenant())                                                  // Tokens are only valid in the tenant they were issued in
+	v1Private.Use(allMiddlewares.rateLimiter.Limit(ratepolicy.DefaultUserIDPolicy)) // Apply rate limiting middleware to all /v1 routes for authenticated users
+	v1Private.Use(rejectPasswordChangeToken())                                      // Tokens issued for an expired password can only change it
 	{
-		v1Private.GET("/self/info", allHandler.userHandler.GetProfile)
-		v1Private.PUT("/self/info", allHandler.userHandler.UpdateProfile)
+		v1Private.GET("/self/info", requireScope(accessTokenService.ScopeProfileRead), allHandler.userHandler.GetProfile)
+		v1Private.PUT("/self/info", requireScope(accessTokenService.ScopeProfileWrite), allHandler.userHandler.UpdateProfile)
+		v1Private.PATCH("/self/info", requireScope(accessTokenService.ScopeProfileWrite), allHandler.userHandler.PatchProfile)
+		v1Private.PUT("/self/username", requireScope(accessTokenService.ScopeProfileWrite), allHandler.userHandler.ChangeUsername)
+	}
+
+	v1Account := v1Private.Group("")
+	v1Account.Use(requireLogin()) // Personal access tokens cannot manage the credentials of the account
+	{
+		v1Account.GET("/self/identities", allHandler.identityHandler.ListIdentities)
+		v1Account.POST("/self/identities/:provider", allHandler.identityHandler.StartLink)
+		v1Account.DELETE("/self/identities/:id", allHandler.identityHandler.Unlink)
+
+		v1Account.POST("/self/passkeys/registration/options", allHandler.passkeyHandler.BeginRegistration)
+		v1Account.POST("/self/passkeys/registration/verify", allHandler.passkeyHandler.FinishRegistration)
+
+		v1Account.GET("/self/sessions", allHandler.sessionHandler.ListSessions)
+		v1Account.DELETE("/self/sessions/:id", allHandler.sessionHandler.RevokeSession)
+
+		v1Account.GET("/self/login-history", allHandler.loginHistoryHandler.ListHistory)
+
+		v1Account.POST("/self/tokens", allHandler.accessTokenHandler.CreateToken)
+		v1Account.GET("/self/tokens", allHandler.accessTokenHandler.ListTokens)
+		v1Account.DELETE("/self/tokens/:id", allHandler.accessTokenHandler.RevokeToken)
+
+		v1Account.POST("/organizations", allHandler.organizationHandler.CreateOrganization)
+		v1Account.GET("/organizations", allHandler.organizationHandler.ListOrganizations)
+		v1Account.POST("/organizations/invitations/accept", allHandler.organizationHandler.AcceptInvitation)
+		v1Account.GET("/organizations/:id", allHandler.organizationHandler.GetOrganization)
+		v1Account.PUT("/organizations/:id", allHandler.organizationHandler.UpdateOrganization)
+		v1Account.DELETE("/organizations/:id", allHandler.organizationHandler.DeleteOrganization)
+		v1Account.GET("/organizations/:id/members", allHandler.organizationHandler.ListMembers)
+		v1Account.PUT("/organizations/:id/members/:user_id", allHandler.organizationHandler.UpdateMemberRole)
+		v1Account.DELETE("/organizations/:id/members/:user_id", allHandler.organizationHandler.RemoveMember)
+		v1Account.POST("/organizations/:id/transfer-ownership", allHandler.organizationHandler.TransferOwnership)
+		v1Account.POST("/organizations/:id/invitations", allHandler.organizationHandler.CreateInvitation)
+		v1Account.GET("/organizations/:id/invitations", allHandler.organizationHandler.ListInvitations)
+		v1Account.DELETE("/organizations/:id/invitations/:invitation_id", allHandler.organizationHandler.RevokeInvitation)
+	}
+
+	// The password can also be changed with the token issued for an expired one
+	v1Password := a.app.Group("/v1")
+	v1Password.Use(allMiddlewares.jwtAuth.JWTAuth())
+	v1Password.Use(requireTenant())
+	v1Password.Use(allMiddlewares.rateLimiter.Limit(ratepolicy.DefaultUserIDPolicy))
+	v1Password.Use(requireLogin())
+	{
+		v1Password.PUT("/self/password", allHandler.userHandler.ChangePassword)
+	}
+
+	v1Admin := a.app.Group("/v1/admin")
+	v1Admin.Use(allMiddlewares.rateLimiter.Limit(ratepolicy.DefaultIPPolicy))
+	v1Admin.Use(requireAdmin(a.cfg.AdminAPIKey))
+	{
+		v1Admin.POST("/webhooks", allHandler.webhookHandler.CreateSubscription)
+		v1Admin.GET("/webhooks", allHandler.webhookHandler.ListSubscriptions)
+		v1Admin.DELETE("/webhooks/:id", allHandler.webhookHandler.DeleteSubscription)
+		v1Admin.GET("/webhooks/:id/deliveries", allHandler.webhookHandler.ListDeliveries)
+		v1Admin.POST("/webhooks/:id/deliveries/:delivery_id/replay", allHandler.webhookHandler.ReplayDelivery)
+
+		v1Admin.GET("/username-policy/entries", allHandler.usernamePolicyHandler.ListEntries)
+		v1Admin.POST("/username-policy/entries", allHandler.usernamePolicyHandler.AddEntry)
+		v1Admin.DELETE("/username-policy/entries/:id", allHandler.usernamePolicyHandler.DeleteEntry)
+
+		v1Admin.POST("/invitations", allHandler.invitationHandler.CreateInvitation)
+		v1Admin.GET("/invitations", allHandler.invitationHandler.ListInvitations)
+		v1Admin.DELETE("/invitations/:id", allHandler.invitationHandler.RevokeInvitation)
 	}
 }
 
@@ -176,14 +357,27 @@ func (a *api) registerValidations() {
 		if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 			// Register custom validation functions here
 			v.RegisterValidation("password_strength", validators.PasswordStrength)
+			// The validator is shared by the process, so it checks the active policy rather than one of this engine
+			v.RegisterValidation("username_policy", usernamePolicyService.Validate)
 		}
 	})
 }
 
 // handlers aggregates all HTTP handlers for different API endpoints.
 type handlers struct {
-	healthCheckHandler healthCheckHandler.Handler
-	userHandler        userHandler.Handler
+	healthCheckHandler    healthCheckHandler.Handler
+	userHandler           userHandler.Handler
+	identityHandler       identityHandler.Handler
+	magicLinkHandler      magicLinkHandler.Handler
+	passkeyHandler        passkeyHandler.Handler
+	accessTokenHandler    accessTokenHandler.Handler
+	sessionHandler        sessionHandler.Handler
+	loginHistoryHandler   loginHistoryHandler.Handler
+	webhookHandler        webhookHandler.Handler
+	emailChangeHandler    emailChangeHandler.Handler
+	usernamePolicyHandler usernamePolicyHandler.Handler
+	invitationHandler     invitationHandler.Handler
+	organizationHandler   organizationHandler.Handler
 }
 
 // registerHandlers initializes and returns all handler instances used in the API.
@@ -192,31 +386,116 @@ func (a *api) registerHandlers() *handlers {
 	healthCheckSvc := healthCheckService.NewHealthCheckService(a.cfg.ServiceName, a.cfg.InstanceID, healthCheckRepo)
 	healthCheckHandler := healthCheckHandler.NewHealthCheckHandler(healthCheckSvc)
 
+	sessionRepo := sessionRepository.NewSessionRepository(a.db)
+	sessionSvc := sessionService.NewSessionService(sessionRepo)
+	sessionHandler := sessionHandler.NewSessionHandler(sessionSvc)
+
+	loginHistoryRepo := loginHistoryRepository.NewLoginHistoryRepository(a.db)
+	loginHistorySvc := loginHistoryService.NewLoginHistoryService(loginHistoryRepo, a.notifier)
+	loginHistoryHandler := loginHistoryHandler.NewLoginHistoryHandler(loginHistorySvc)
+
 	userRepo := userRepository.NewUserRepository(a.db)
-	userSvc := userService.NewUserService(userRepo, a.passwordHashing, a.jwtGenerator)
-	userHandler := userHandler.NewUserHandler(userSvc)
+	if a.cfg.UserCacheTTL > 0 {
+		userRepo = userRepository.NewCachedUserRepository(userRepo, a.redisClient, a.cfg.UserCacheTTL, a.cfg.UserCacheNegativeTTL)
+	}
+	emailChangeRepo := emailChangeRepository.NewEmailChangeRepository(a.db, userRepo)
+	emailChangeSvc := emailChangeService.NewEmailChangeService(emailChangeRepo, userRepo, a.randomCodeGen, a.mailer, a.notifier, a.cfg.EmailChangeConfirmURL, a.cfg.EmailChangeCancelURL)
+	emailChangeHandler := emailChangeHandler.NewEmailChangeHandler(emailChangeSvc)
+
+	invitationRepo := invitationRepository.NewInvitationRepository(a.db, userRepo)
+	invitationSvc := invitationService.NewInvitationService(invitationRepo, userRepo, a.randomCodeGen, a.mailer, a.cfg.InvitationURL, a.cfg.InvitationTTL)
+	invitationHandler := invitationHandler.NewInvitationHandler(invitationSvc)
+
+	organizationRepo := organizationRepository.NewOrganizationRepository(a.db)
+	organizationSvc := organizationService.NewOrganizationService(organizationRepo, userRepo, a.randomCodeGen, a.mailer, a.cfg.OrganizationInvitationURL, a.cfg.OrganizationInvitationTTL)
+	organizationHandler := organizationHandler.NewOrganizationHandler(organizationSvc)
+
+	registrationPolicy := registration.Policy{
+		Mode:           a.cfg.RegistrationMode,
+		AllowedDomains: a.cfg.RegistrationAllowedDomains,
+	}
+
+	userSvc := userService.NewUserService(userService.Deps{
+		UserRepo:           userRepo,
+		PasswordHashing:    a.passwordHashing,
+		JWTGenerator:       a.jwtGenerator,
+		SessionSvc:         sessionSvc,
+		LoginHistorySvc:    loginHistorySvc,
+		EmailChangeSvc:     emailChangeSvc,
+		BreachChecker:      a.breachChecker,
+		EmailPolicy:        a.emailPolicy,
+		InvitationSvc:      invitationSvc,
+		OrganizationRepo:   organizationRepo,
+		RegistrationPolicy: registrationPolicy,
+		PasswordPolicy: userService.PasswordPolicy{
+			HistorySize: a.cfg.PasswordHistorySize,
+			MaxAge:      a.cfg.PasswordMaxAge,
+		},
+	})
+	userHandler := userHandler.NewUserHandler(userSvc, a.botGuard)
+
+	identityRepo := identityRepository.NewIdentityRepository(a.db, a.redisClient, userRepo)
+	identitySvc := identityService.NewIdentityService(identityRepo, userRepo, userSvc, a.randomCodeGen, a.oidcProviders, registrationPolicy, usernamePolicyService.Active(), a.emailPolicy)
+	identityHandler := identityHandler.NewIdentityHandler(identitySvc)
+
+	magicLinkRepo := magicLinkRepository.NewMagicLinkRepository(a.redisClient)
+	magicLinkSvc := magicLinkService.NewMagicLinkService(magicLinkRepo, userRepo, userSvc, a.randomCodeGen, a.mailer, a.cfg.MagicLinkSecret, a.cfg.MagicLinkURL)
+	magicLinkHandler := magicLinkHandler.NewMagicLinkHandler(magicLinkSvc)
+
+	passkeyRepo := passkeyRepository.NewPasskeyRepository(a.db, a.redisClient)
+	passkeySvc := passkeyService.NewPasskeyService(passkeyRepo, userRepo, userSvc, a.randomCodeGen, a.webAuthn)
+	passkeyHandler := passkeyHandler.NewPasskeyHandler(passkeySvc)
+
+	accessTokenRepo := accessTokenRepository.NewAccessTokenRepository(a.db)
+	accessTokenSvc := accessTokenService.NewAccessTokenService(accessTokenRepo, a.randomCodeGen)
+	accessTokenHandler := accessTokenHandler.NewAccessTokenHandler(accessTokenSvc)
+
+	webhookRepo := webhookRepository.NewWebhookRepository(a.db, a.redisClient)
+	webhookSvc := webhookService.NewWebhookService(webhookRepo, a.randomCodeGen)
+	webhookHandler := webhookHandler.NewWebhookHandler(webhookSvc)
+
+	usernamePolicyRepo := usernamePolicyRepository.NewUsernamePolicyRepository(a.db)
+	usernamePolicySvc := usernamePolicyService.NewUsernamePolicyService(usernamePolicyRepo, usernamePolicyService.Active())
+	usernamePolicyHandler := usernamePolicyHandler.NewUsernamePolicyHandler(usernamePolicySvc)
 
 	return &handlers{
-		healthCheckHandler: healthCheckHandler,
-		userHandler:        userHandler,
+		healthCheckHandler:    healthCheckHandler,
+		userHandler:           userHandler,
+		identityHandler:       identityHandler,
+		magicLinkHandler:      magicLinkHandler,
+		passkeyHandler:        passkeyHandler,
+		accessTokenHandler:    accessTokenHandler,
+		sessionHandler:        sessionHandler,
+		loginHistoryHandler:   loginHistoryHandler,
+		webhookHandler:        webhookHandler,
+		emailChangeHandler:    emailChangeHandler,
+		usernamePolicyHandler: usernamePolicyHandler,
+		invitationHandler:     invitationHandler,
+		organizationHandler:   organizationHandler,
 	}
 }
 
 // middlewares aggregates all middleware instances used in the API.
 type middlewares struct {
-	jwtAuth             middleware.JWTAuth
-	rateLimitMiddleware middleware.RateLimit
+	jwtAuth     middleware.JWTAuth
+	rateLimiter ratepolicy.Limiter
 }
 
 // registerMiddlewares configures and returns all middleware instances used in the API.
 func (a *api) registerMiddlewares() *middlewares {
-	jwtAuth := middleware.NewJWTAuth(a.jwtValidator)
+	// JWTs of a revoked session are rejected, and personal access tokens are accepted wherever a JWT is
+	sessionRepo := sessionRepository.NewSessionRepository(a.db)
+	sessionSvc := sessionService.NewSessionService(sessionRepo)
+	jwtValidator := sessionService.NewSessionValidator(sessionSvc, a.jwtValidator)
+
+	accessTokenRepo := accessTokenRepository.NewAccessTokenRepository(a.db)
+	accessTokenSvc := accessTokenService.NewAccessTokenService(accessTokenRepo, a.randomCodeGen)
+	jwtAuth := middleware.NewJWTAuth(accessTokenService.NewTokenValidator(accessTokenSvc, jwtValidator))
 
-	rateLimitRepo := ratelimit.NewRedisRepo(a.redisClient)
-	rateLimitMiddleware := middleware.NewRateLimit(rateLimitRepo)
+	rateLimiter := ratepolicy.NewLimiter(ratepolicy.NewRedisCounterStore(a.redisClient), a.cfg.RateLimitPolicies)
 
 	return &middlewares{
-		jwtAuth:             jwtAuth,
-		rateLimitMiddleware: rateLimitMiddleware,
+		jwtAuth:     jwtAuth,
+		rateLimiter: rateLimiter,
 	}
 }
@@ -1,8 +1,14 @@
 package api
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/vukieuhaihoa/user-service/internal/ratepolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
 )
 
 type Config struct {
@@ -10,6 +16,62 @@ type Config struct {
 	ServiceName string `envconfig:"SERVICE_NAME" default:"user-service"`
 	InstanceID  string `envconfig:"INSTANCE_ID" default:""`
 	AppHostName string `envconfig:"APP_HOST_NAME" default:"localhost:8080"`
+
+	// OIDCProviders lists the names of the enabled OpenID Connect providers, each configured via OIDC_<NAME>_* variables
+	OIDCProviders []string `envconfig:"OIDC_PROVIDERS" default:""`
+
+	// MagicLinkSecret signs the login links; all instances must share it for links to work across them
+	MagicLinkSecret string `envconfig:"MAGIC_LINK_SECRET" default:""`
+	// MagicLinkURL is the frontend page login links point to, receiving the token as the "token" query parameter
+	MagicLinkURL string `envconfig:"MAGIC_LINK_URL" default:"http://localhost:8080/login/magic-link"`
+
+	// EmailChangeConfirmURL is the frontend page the links confirming a new email address point to, receiving the token as the "token" query parameter
+	EmailChangeConfirmURL string `envconfig:"EMAIL_CHANGE_CONFIRM_URL" default:"http://localhost:8080/email-change/confirm"`
+	// EmailChangeCancelURL is the frontend page the links cancelling an email change point to, receiving the token as the "token" query parameter
+	EmailChangeCancelURL string `envconfig:"EMAIL_CHANGE_CANCEL_URL" default:"http://localhost:8080/email-change/cancel"`
+
+	// WebAuthnRPID is the relying party ID passkeys are bound to, the domain of the frontend without scheme or port
+	WebAuthnRPID string `envconfig:"WEBAUTHN_RP_ID" default:"localhost"`
+	// WebAuthnRPDisplayName is the service name authenticators show when creating a passkey
+	WebAuthnRPDisplayName string `envconfig:"WEBAUTHN_RP_DISPLAY_NAME" default:"User Service"`
+	// WebAuthnRPOrigins lists the frontend origins allowed to run passkey ceremonies
+	WebAuthnRPOrigins []string `envconfig:"WEBAUTHN_RP_ORIGINS" default:"http://localhost:8080"`
+
+	// UserCacheTTL is how long users looked up by ID or username stay cached in Redis; the cache is disabled when zero
+	UserCacheTTL time.Duration `envconfig:"USER_CACHE_TTL" default:"5m"`
+	// UserCacheNegativeTTL is how long a lookup that found no user stays cached
+	UserCacheNegativeTTL time.Duration `envconfig:"USER_CACHE_NEGATIVE_TTL" default:"30s"`
+
+	// PasswordHistorySize is the number of most recent passwords, the current one included, a new password must differ from; 0, the default, disables the check
+	PasswordHistorySize int `envconfig:"PASSWORD_HISTORY_SIZE" default:"0"`
+	// PasswordMaxAge is how long a password is valid before it must be changed; passwords never expire when zero
+	PasswordMaxAge time.Duration `envconfig:"PASSWORD_MAX_AGE" default:"0"`
+
+	// RateLimitPolicies are the rate limit policies of individual routes, see ratepolicy.Policies for the format;
+	// routes without their own policies share the default budget per IP address or per user
+	RateLimitPolicies ratepolicy.Policies `envconfig:"RATE_LIMIT_POLICIES" default:"POST /v1/users/login ip 30/1m; POST /v1/users/login username 5/1m 2; POST /v1/users/register ip 10/1h; POST /v1/users/login/magic-link ip 5/1m"`
+
+	// RegistrationMode decides who may register: open, invite_only, allowed_domains or closed
+	RegistrationMode registration.Mode `envconfig:"REGISTRATION_MODE" default:"open"`
+	// RegistrationAllowedDomains lists the email domains, subdomains included, that may register in the allowed_domains mode
+	RegistrationAllowedDomains []string `envconfig:"REGISTRATION_ALLOWED_DOMAINS" default:""`
+	// InvitationURL is the frontend registration page invitation links point to, receiving the token as the "token" query parameter
+	InvitationURL string `envconfig:"INVITATION_URL" default:"http://localhost:8080/register"`
+	// InvitationTTL is how long an invitation link works
+	InvitationTTL time.Duration `envconfig:"INVITATION_TTL" default:"168h"`
+	// OrganizationInvitationURL is the frontend page organization invitation links point to, receiving the token as the "token" query parameter
+	OrganizationInvitationURL string `envconfig:"ORGANIZATION_INVITATION_URL" default:"http://localhost:8080/organizations/join"`
+	// OrganizationInvitationTTL is how long an organization invitation link works
+	OrganizationInvitationTTL time.Duration `envconfig:"ORGANIZATION_INVITATION_TTL" default:"168h"`
+
+	// TenantHosts assigns the brands hosted by the deployment to tenants by hostname, e.g. "brand-a.example.com:brand_a";
+	// other hostnames belong to the default tenant
+	TenantHosts map[string]string `envconfig:"TENANT_HOSTS" default:""`
+	// TenantTrustHeader lets the X-Tenant-ID header choose the tenant of a request, for deployments behind a proxy setting it
+	TenantTrustHeader bool `envconfig:"TENANT_TRUST_HEADER" default:"false"`
+
+	// AdminAPIKey authenticates the admin API through the X-Admin-Key header; the admin API is disabled when empty
+	AdminAPIKey string `envconfig:"ADMIN_API_KEY" default:""`
 }
 
 func NewConfig() (*Config, error) {
@@ -23,5 +85,13 @@ func NewConfig() (*Config, error) {
 		cfg.InstanceID = uuid.New().String()
 	}
 
+	if cfg.MagicLinkSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		cfg.MagicLinkSecret = hex.EncodeToString(secret)
+	}
+
 	return cfg, nil
 }
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	accessTokenService "github.com/vukieuhaihoa/user-service/internal/app/service/accesstoken"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// requireTenant rejects the tokens issued in another tenant than the one of the request, so a user of one brand
+// cannot act in another with the same ID.
+func requireTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := utils.GetJWTClaimsFromRequest(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+			return
+		}
+
+		if tenant.FromClaims(claims) != tenant.FromContext(c) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.InvalidTokenResponse)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireScope restricts a route to personal access tokens granted the scope.
+// Login tokens are not scoped and always pass.
+func requireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := utils.GetJWTClaimsFromRequest(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+			return
+		}
+
+		if !accessTokenService.HasScope(claims, scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, common.Message{
+				Message: "access token is missing the " + scope + " scope",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireLogin restricts a route to login tokens, so a leaked personal access token
+// cannot be used to manage the credentials of the account.
+func requireLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := utils.GetJWTClaimsFromRequest(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+			return
+		}
+
+		if accessTokenService.IsAccessToken(claims) {
+			c.AbortWithStatusJSON(http.StatusForbidden, common.Message{
+				Message: "access tokens cannot manage account credentials",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rejectPasswordChangeToken rejects the tokens issued for an expired password, which can only change it.
+func rejectPasswordChangeToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := utils.GetJWTClaimsFromRequest(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+			return
+		}
+
+		if userService.IsPasswordChangeToken(claims) {
+			c.AbortWithStatusJSON(http.StatusForbidden, common.Message{
+				Message: "password expired, change it through PUT /v1/self/password",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+// Package accesstoken provides HTTP handlers for managing the personal access tokens
+// of the current user, using the Gin web framework.
+package accesstoken
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/accesstoken"
+)
+
+// Handler defines the interface for personal access token HTTP handlers.
+type Handler interface {
+	// CreateToken is a Gin framework handler that mints a personal access token for the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	CreateToken(c *gin.Context)
+
+	// ListTokens is a Gin framework handler that lists the personal access tokens of the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListTokens(c *gin.Context)
+
+	// RevokeToken is a Gin framework handler that revokes a personal access token of the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	RevokeToken(c *gin.Context)
+}
+
+// accessTokenHandler is the concrete implementation of the Handler interface.
+type accessTokenHandler struct {
+	accessTokenSvc accesstoken.Service
+}
+
+// NewAccessTokenHandler creates a new instance of the personal access token handler.
+//
+// Parameters:
+//   - accessTokenSvc: The service used for personal access token operations
+//
+// Returns:
+//   - Handler: A new personal access token handler instance
+func NewAccessTokenHandler(accessTokenSvc accesstoken.Service) Handler {
+	return &accessTokenHandler{accessTokenSvc: accessTokenSvc}
+}
@@ -0,0 +1,163 @@
+package accesstoken
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/accesstoken"
+)
+
+type createTokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=100" example:"backup script"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1" example:"bookmarks:read"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2030-01-01T00:00:00Z"`
+}
+
+type listTokensResponse struct {
+	Data    []*model.PersonalAccessToken `json:"data"`
+	Message string                       `json:"message"`
+}
+
+// CreateToken mints a personal access token for the authenticated user.
+// The plaintext token is only part of this response; it cannot be retrieved again.
+// @Summary      Create a personal access token
+// @Description  Mint a named, scoped and optionally expiring token for API automation
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        token  body      createTokenRequest  true  "Token to create"
+// @Success      201    {object}  object{data=accesstoken.CreatedToken,message=string}
+// @Failure      400    {object}  object{message=string}
+// @Failure      401    {object}  object{message=string}
+// @Failure      500    {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/tokens [post]
+func (h *accessTokenHandler) CreateToken(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_CreateToken")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &createTokenRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	token, err := h.accessTokenSvc.CreateToken(c, userID, input.Name, input.Scopes, input.ExpiresAt)
+	switch {
+	case errors.Is(err, service.ErrUnsupportedScope), errors.Is(err, service.ErrInvalidExpiry):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "CreateToken").
+			Err(err).
+			Msg("service return error when creating access token")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*service.CreatedToken]{
+		Data:    token,
+		Message: "Token created successfully, copy it now as it will not be shown again!",
+	})
+}
+
+// ListTokens lists the personal access tokens of the authenticated user.
+// @Summary      List personal access tokens
+// @Description  List the personal access tokens of the authenticated user, without their values
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  listTokensResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/tokens [get]
+func (h *accessTokenHandler) ListTokens(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListTokens")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	tokens, err := h.accessTokenSvc.ListTokens(c, userID)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListTokens").
+			Err(err).
+			Msg("service return error when listing access tokens")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listTokensResponse{
+		Data:    tokens,
+		Message: "Tokens retrieved successfully!",
+	})
+}
+
+// RevokeToken revokes a personal access token of the authenticated user.
+// @Summary      Revoke a personal access token
+// @Description  Revoke a personal access token, it is rejected from the next request on
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string  true  "Token ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/tokens/{id} [delete]
+func (h *accessTokenHandler) RevokeToken(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_RevokeToken")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = h.accessTokenSvc.RevokeToken(c, userID, c.Param("id"))
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: "token not found",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "RevokeToken").
+			Err(err).
+			Msg("service return error when revoking access token")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Token revoked successfully!",
+	})
+}
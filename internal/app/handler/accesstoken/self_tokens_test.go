@@ -0,0 +1,289 @@
+package accesstoken
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/accesstoken"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/accesstoken/mocks"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestAccessToken_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "create token successfully",
+			inputBody: `{"name":"backup script","scopes":["bookmarks:read"]}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateToken", mock.Anything, "user-001", "backup script", []string{"bookmarks:read"}, (*time.Time)(nil)).
+					Return(&service.CreatedToken{
+						PersonalAccessToken: &model.PersonalAccessToken{
+							Base:        model.Base{ID: "token-001", CreatedAt: testTime, UpdatedAt: testTime},
+							UserID:      "user-001",
+							Name:        "backup script",
+							TokenPrefix: "bmpat_abcdef",
+							TokenHash:   "hash-001",
+							Scopes:      []string{"bookmarks:read"},
+						},
+						Token: "bmpat_abcdef0123",
+					}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":{"id":"token-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","name":"backup script","token_prefix":"bmpat_abcdef","scopes":["bookmarks:read"],"expires_at":null,"last_used_at":null,"token":"bmpat_abcdef0123"},"message":"Token created successfully, copy it now as it will not be shown again!"}`,
+		},
+		{
+			name:         "missing claims",
+			inputBody:    `{"name":"backup script","scopes":["bookmarks:read"]}`,
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name:      "missing scopes",
+			inputBody: `{"name":"backup script","scopes":[]}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Scopes is invalid (min)"]}`,
+		},
+		{
+			name:      "unsupported scope",
+			inputBody: `{"name":"backup script","scopes":["admin"]}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateToken", mock.Anything, "user-001", "backup script", []string{"admin"}, (*time.Time)(nil)).
+					Return(nil, service.ErrUnsupportedScope)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"unsupported token scope"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"name":"backup script","scopes":["bookmarks:read"]}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateToken", mock.Anything, "user-001", "backup script", []string{"bookmarks:read"}, (*time.Time)(nil)).
+					Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/self/tokens", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			tc.setupRequest(ctx)
+
+			accessTokenHandler := NewAccessTokenHandler(tc.setupMockSvc())
+			accessTokenHandler.CreateToken(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestAccessToken_ListTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list tokens successfully",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListTokens", mock.Anything, "user-001").Return([]*model.PersonalAccessToken{
+					{
+						Base:        model.Base{ID: "token-001", CreatedAt: testTime, UpdatedAt: testTime},
+						UserID:      "user-001",
+						Name:        "backup script",
+						TokenPrefix: "bmpat_abcdef",
+						TokenHash:   "hash-001",
+						Scopes:      []string{"bookmarks:read"},
+						LastUsedAt:  &testTime,
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"token-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","name":"backup script","token_prefix":"bmpat_abcdef","scopes":["bookmarks:read"],"expires_at":null,"last_used_at":"2024-01-01T00:00:00Z"}],"message":"Tokens retrieved successfully!"}`,
+		},
+		{
+			name:         "missing claims",
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "service layer error",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListTokens", mock.Anything, "user-001").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/self/tokens", nil)
+			tc.setupRequest(ctx)
+
+			accessTokenHandler := NewAccessTokenHandler(tc.setupMockSvc())
+			accessTokenHandler.ListTokens(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestAccessToken_RevokeToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "revoke token successfully",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeToken", mock.Anything, "user-001", "token-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Token revoked successfully!"}`,
+		},
+		{
+			name:         "missing claims",
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "token not found",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeToken", mock.Anything, "user-001", "token-001").Return(dbutils.ErrRecordNotFoundType)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"token not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeToken", mock.Anything, "user-001", "token-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/self/tokens/token-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "token-001"}}
+			tc.setupRequest(ctx)
+
+			accessTokenHandler := NewAccessTokenHandler(tc.setupMockSvc())
+			accessTokenHandler.RevokeToken(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
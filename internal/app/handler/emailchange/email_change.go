@@ -0,0 +1,116 @@
+package emailchange
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/emailchange"
+)
+
+type emailChangeTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ConfirmChange applies an email change from the token of the link sent to the new address.
+// @Summary      Confirm an email change
+// @Description  Apply a pending email change with the token of the link sent to the new address
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      emailChangeTokenRequest  true  "Confirmation link token"
+// @Success      200      {object}  object{message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Router       /v1/users/email-change/confirm [post]
+func (e *emailChangeHandler) ConfirmChange(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ConfirmEmailChange")
+	defer s.End()
+
+	input := &emailChangeTokenRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	err := e.emailChangeSvc.Confirm(c, input.Token)
+	switch {
+	case errors.Is(err, service.ErrInvalidEmailChangeToken):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "email already exists",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "ConfirmEmailChange").
+			Err(err).
+			Msg("service return error when confirming email change")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Email changed successfully!",
+	})
+}
+
+// CancelChange cancels an email change from the token of the link sent to the old address.
+// A change already confirmed is reverted to the old address.
+// @Summary      Cancel an email change
+// @Description  Cancel an email change with the token of the link sent to the old address, reverting it if it was confirmed
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      emailChangeTokenRequest  true  "Cancel link token"
+// @Success      200      {object}  object{message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Router       /v1/users/email-change/cancel [post]
+func (e *emailChangeHandler) CancelChange(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_CancelEmailChange")
+	defer s.End()
+
+	input := &emailChangeTokenRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	err := e.emailChangeSvc.Cancel(c, input.Token)
+	switch {
+	case errors.Is(err, service.ErrInvalidEmailChangeToken):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "the previous email address now belongs to another account",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "CancelEmailChange").
+			Err(err).
+			Msg("service return error when cancelling email change")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Email change cancelled successfully!",
+	})
+}
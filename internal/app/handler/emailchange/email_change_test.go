@@ -0,0 +1,187 @@
+package emailchange
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/emailchange"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/emailchange/mocks"
+)
+
+func TestEmailChange_ConfirmChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "change confirmed",
+			inputBody: `{"token":"confirm-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Confirm", mock.Anything, "confirm-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Email changed successfully!"}`,
+		},
+		{
+			name:      "missing token",
+			inputBody: `{}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Token is invalid (required)"]}`,
+		},
+		{
+			name:      "invalid token",
+			inputBody: `{"token":"confirm-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Confirm", mock.Anything, "confirm-001").Return(service.ErrInvalidEmailChangeToken)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired email change link"}`,
+		},
+		{
+			name:      "email taken meanwhile",
+			inputBody: `{"token":"confirm-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Confirm", mock.Anything, "confirm-001").Return(dbutils.ErrDuplicationType)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"email already exists"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"token":"confirm-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Confirm", mock.Anything, "confirm-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/email-change/confirm", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			emailChangeHandler := NewEmailChangeHandler(tc.setupMockSvc())
+			emailChangeHandler.ConfirmChange(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestEmailChange_CancelChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "change cancelled",
+			inputBody: `{"token":"cancel-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Cancel", mock.Anything, "cancel-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Email change cancelled successfully!"}`,
+		},
+		{
+			name:      "missing token",
+			inputBody: `{}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Token is invalid (required)"]}`,
+		},
+		{
+			name:      "invalid token",
+			inputBody: `{"token":"cancel-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Cancel", mock.Anything, "cancel-001").Return(service.ErrInvalidEmailChangeToken)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired email change link"}`,
+		},
+		{
+			name:      "old email taken meanwhile",
+			inputBody: `{"token":"cancel-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Cancel", mock.Anything, "cancel-001").Return(dbutils.ErrDuplicationType)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"the previous email address now belongs to another account"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"token":"cancel-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Cancel", mock.Anything, "cancel-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/email-change/cancel", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			emailChangeHandler := NewEmailChangeHandler(tc.setupMockSvc())
+			emailChangeHandler.CancelChange(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Package emailchange provides HTTP handlers confirming and cancelling email address changes using the Gin web framework.
+package emailchange
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/emailchange"
+)
+
+// Handler defines the interface for email change HTTP handlers.
+type Handler interface {
+	// ConfirmChange is a Gin framework handler that applies an email change from its confirmation link.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ConfirmChange(c *gin.Context)
+
+	// CancelChange is a Gin framework handler that cancels or reverts an email change from the link sent to the
+	// old address.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	CancelChange(c *gin.Context)
+}
+
+// emailChangeHandler is the concrete implementation of the Handler interface.
+type emailChangeHandler struct {
+	emailChangeSvc emailchange.Service
+}
+
+// NewEmailChangeHandler creates a new instance of the email change handler.
+//
+// Parameters:
+//   - emailChangeSvc: The email change service confirming and cancelling changes
+//
+// Returns:
+//   - Handler: A new email change handler instance
+func NewEmailChangeHandler(emailChangeSvc emailchange.Service) Handler {
+	return &emailChangeHandler{emailChangeSvc: emailChangeSvc}
+}
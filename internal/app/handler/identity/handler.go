@@ -0,0 +1,58 @@
+// Package identity provides HTTP handlers for federated login through external
+// OpenID Connect providers and for managing the identities linked to the current user,
+// using the Gin web framework.
+package identity
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+)
+
+// Handler defines the interface for federated login HTTP handlers.
+type Handler interface {
+	// StartLogin is a Gin framework handler that redirects the user agent to the provider login page.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	StartLogin(c *gin.Context)
+
+	// LoginCallback is a Gin framework handler that completes the provider login and returns a JWT token.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	LoginCallback(c *gin.Context)
+
+	// ListIdentities is a Gin framework handler that lists the identities linked to the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListIdentities(c *gin.Context)
+
+	// StartLink is a Gin framework handler that starts linking a new identity to the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	StartLink(c *gin.Context)
+
+	// Unlink is a Gin framework handler that removes an identity from the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	Unlink(c *gin.Context)
+}
+
+// identityHandler is the concrete implementation of the Handler interface.
+type identityHandler struct {
+	identitySvc identity.Service
+}
+
+// NewIdentityHandler creates a new instance of the identity handler.
+//
+// Parameters:
+//   - identitySvc: The identity service used for federated login operations
+//
+// Returns:
+//   - Handler: A new identity handler instance
+func NewIdentityHandler(identitySvc identity.Service) Handler {
+	return &identityHandler{identitySvc: identitySvc}
+}
@@ -0,0 +1,117 @@
+package identity
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	sessionHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/session"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+type loginCallbackRequest struct {
+	Code  string `form:"code" binding:"required"`
+	State string `form:"state" binding:"required"`
+}
+
+// StartLogin redirects the user agent to the authorization endpoint of the requested provider.
+// @Summary      Start federated login
+// @Description  Redirect to the OpenID Connect provider to authenticate
+// @Tags         Users
+// @Param        provider  path  string  true  "Provider name"
+// @Success      302
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Router       /v1/users/login/oidc/{provider} [get]
+func (h *identityHandler) StartLogin(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_StartLogin")
+	defer s.End()
+
+	authURL, err := h.identitySvc.AuthCodeURL(c, c.Param("provider"))
+	switch {
+	case errors.Is(err, service.ErrUnknownProvider):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "StartLogin").
+			Err(err).
+			Msg("service return error when starting federated login")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// LoginCallback completes the federated login and returns a JWT token.
+// @Summary      Federated login callback
+// @Description  Exchange the provider authorization code and return a JWT token. A new user is only provisioned
+// @Description  when the registration mode and the email policy let its email register.
+// @Tags         Users
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Param        code      query     string  true  "Authorization code"
+// @Param        state     query     string  true  "State returned by the provider"
+// @Success      200       {object}  object{data=string,message=string}
+// @Failure      400       {object}  object{message=string}
+// @Failure      403       {object}  object{message=string}
+// @Failure      404       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Router       /v1/users/login/oidc/{provider}/callback [get]
+func (h *identityHandler) LoginCallback(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_LoginCallback")
+	defer s.End()
+
+	input := &loginCallbackRequest{}
+	if err := c.ShouldBindQuery(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	token, err := h.identitySvc.Login(sessionHandler.WithRequestClient(c), c.Param("provider"), input.Code, input.State)
+	switch {
+	case errors.Is(err, service.ErrUnknownProvider):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case registration.IsRejected(err):
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case emailpolicy.IsRejected(err),
+		errors.Is(err, service.ErrInvalidAuthState),
+		errors.Is(err, service.ErrIdentityEmailConflict),
+		errors.Is(err, service.ErrProviderEmailMissing),
+		errors.Is(err, service.ErrInvalidIDToken):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "LoginCallback").
+			Err(err).
+			Msg("service return error when completing federated login")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[string]{
+		Data:    token,
+		Message: "Logged in successfully!",
+	})
+}
@@ -0,0 +1,203 @@
+package identity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/identity/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+func TestIdentity_StartLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputProvider string
+
+		setupMockSvc func(inputProvider string) *svcMocks.Service
+
+		expectedCode     int
+		expectedLocation string
+		expectedResponse string
+	}{
+		{
+			name:          "redirect to provider",
+			inputProvider: "mockidp",
+			setupMockSvc: func(inputProvider string) *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AuthCodeURL", mock.Anything, inputProvider).
+					Return("https://idp.example.com/authorize?state=abc", nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusFound,
+			expectedLocation: "https://idp.example.com/authorize?state=abc",
+		},
+		{
+			name:          "unknown provider",
+			inputProvider: "unknown",
+			setupMockSvc: func(inputProvider string) *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AuthCodeURL", mock.Anything, inputProvider).
+					Return("", service.ErrUnknownProvider)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"unknown identity provider"}`,
+		},
+		{
+			name:          "service layer error",
+			inputProvider: "mockidp",
+			setupMockSvc: func(inputProvider string) *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AuthCodeURL", mock.Anything, inputProvider).
+					Return("", assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/login/oidc/"+tc.inputProvider, nil)
+			ctx.Params = gin.Params{{Key: "provider", Value: tc.inputProvider}}
+
+			identityHandler := NewIdentityHandler(tc.setupMockSvc(tc.inputProvider))
+			identityHandler.StartLogin(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedLocation, rec.Header().Get("Location"))
+			if tc.expectedResponse != "" {
+				assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func TestIdentity_LoginCallback(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputQuery string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:       "successful login",
+			inputQuery: "?code=code-001&state=state-001",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Login", mock.Anything, "mockidp", "code-001", "state-001").
+					Return("mocked-jwt-token", nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":"mocked-jwt-token","message":"Logged in successfully!"}`,
+		},
+		{
+			name:       "missing code and state",
+			inputQuery: "",
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Code is invalid (required)","State is invalid (required)"]}`,
+		},
+		{
+			name:       "invalid state",
+			inputQuery: "?code=code-001&state=state-001",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Login", mock.Anything, "mockidp", "code-001", "state-001").
+					Return("", service.ErrInvalidAuthState)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired login state"}`,
+		},
+		{
+			name:       "email owned by another account",
+			inputQuery: "?code=code-001&state=state-001",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Login", mock.Anything, "mockidp", "code-001", "state-001").
+					Return("", service.ErrIdentityEmailConflict)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"an account with this email already exists, log in and link the identity through POST /v1/self/identities/:provider"}`,
+		},
+		{
+			name:       "registration closed",
+			inputQuery: "?code=code-001&state=state-001",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Login", mock.Anything, "mockidp", "code-001", "state-001").
+					Return("", registration.ErrClosed)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"registration is closed"}`,
+		},
+		{
+			name:       "email rejected by the email policy",
+			inputQuery: "?code=code-001&state=state-001",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Login", mock.Anything, "mockidp", "code-001", "state-001").
+					Return("", emailpolicy.ErrDisposable)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"disposable email addresses are not accepted"}`,
+		},
+		{
+			name:       "service layer error",
+			inputQuery: "?code=code-001&state=state-001",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Login", mock.Anything, "mockidp", "code-001", "state-001").
+					Return("", assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/login/oidc/mockidp/callback"+tc.inputQuery, nil)
+			ctx.Params = gin.Params{{Key: "provider", Value: "mockidp"}}
+
+			identityHandler := NewIdentityHandler(tc.setupMockSvc())
+			identityHandler.LoginCallback(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
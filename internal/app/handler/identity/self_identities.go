@@ -0,0 +1,176 @@
+package identity
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+)
+
+type listIdentitiesResponse struct {
+	Data    []*model.UserIdentity `json:"data"`
+	Message string                `json:"message"`
+}
+
+// ListIdentities lists the identities linked to the authenticated user.
+// @Summary      List linked identities
+// @Description  List the external identities linked to the authenticated user
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  listIdentitiesResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/identities [get]
+func (h *identityHandler) ListIdentities(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListIdentities")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	identities, err := h.identitySvc.ListIdentities(c, userID)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListIdentities").
+			Err(err).
+			Msg("service return error when listing identities")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listIdentitiesResponse{
+		Data:    identities,
+		Message: "Identities retrieved successfully!",
+	})
+}
+
+// StartLink starts linking a new identity to the authenticated user.
+// The token must have been issued within the last few minutes, so the user has to log in again first.
+// The client then sends the user agent to the returned URL, and the provider calls back the login callback.
+// @Summary      Link an identity
+// @Description  Start linking an OpenID Connect identity to the authenticated user, requires a recent login
+// @Tags         Users
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200       {object}  object{data=string,message=string}
+// @Failure      401       {object}  object{message=string}
+// @Failure      403       {object}  object{message=string}
+// @Failure      404       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/identities/{provider} [post]
+func (h *identityHandler) StartLink(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_StartLink")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	claims, err := utils.GetJWTClaimsFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: service.ErrReauthenticationRequired.Error(),
+		})
+		return
+	}
+
+	authURL, err := h.identitySvc.StartLink(c, userID, c.Param("provider"), issuedAt.Time)
+	switch {
+	case errors.Is(err, service.ErrReauthenticationRequired):
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, service.ErrUnknownProvider):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "StartLink").
+			Err(err).
+			Msg("service return error when starting identity link")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[string]{
+		Data:    authURL,
+		Message: "Continue linking at the identity provider",
+	})
+}
+
+// Unlink removes an identity from the authenticated user.
+// @Summary      Unlink an identity
+// @Description  Remove a linked identity, unless it is the last way left to log in
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string  true  "Identity ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      409  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/identities/{id} [delete]
+func (h *identityHandler) Unlink(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_Unlink")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = h.identitySvc.Unlink(c, userID, c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrLastCredential):
+		c.JSON(http.StatusConflict, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: "identity not found",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "Unlink").
+			Err(err).
+			Msg("service return error when unlinking identity")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Identity unlinked successfully!",
+	})
+}
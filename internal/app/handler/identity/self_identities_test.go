@@ -0,0 +1,262 @@
+package identity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/identity/mocks"
+)
+
+func TestIdentity_ListIdentities(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list identities successfully",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListIdentities", mock.Anything, "user-001").Return([]*model.UserIdentity{
+					{
+						Base:     model.Base{ID: "identity-001", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+						UserID:   "user-001",
+						Provider: "mockidp",
+						Subject:  "subject-001",
+						Email:    "user001@example.com",
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"identity-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","provider":"mockidp","subject":"subject-001","email":"user001@example.com"}],"message":"Identities retrieved successfully!"}`,
+		},
+		{
+			name:         "missing claims",
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "service layer error",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListIdentities", mock.Anything, "user-001").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/self/identities", nil)
+			tc.setupRequest(ctx)
+
+			identityHandler := NewIdentityHandler(tc.setupMockSvc())
+			identityHandler.ListIdentities(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestIdentity_StartLink(t *testing.T) {
+	t.Parallel()
+
+	issuedAt := time.Now().Add(-time.Minute).Unix()
+
+	testCases := []struct {
+		name string
+
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "start linking successfully",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001", "iat": float64(issuedAt)})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("StartLink", mock.Anything, "user-001", "mockidp", time.Unix(issuedAt, 0)).
+					Return("https://idp.example.com/authorize?state=abc", nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":"https://idp.example.com/authorize?state=abc","message":"Continue linking at the identity provider"}`,
+		},
+		{
+			name: "token without issue time",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"recent login required, please log in again"}`,
+		},
+		{
+			name: "login is too old",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001", "iat": float64(issuedAt)})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("StartLink", mock.Anything, "user-001", "mockidp", mock.Anything).
+					Return("", service.ErrReauthenticationRequired)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"recent login required, please log in again"}`,
+		},
+		{
+			name: "unknown provider",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001", "iat": float64(issuedAt)})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("StartLink", mock.Anything, "user-001", "mockidp", mock.Anything).
+					Return("", service.ErrUnknownProvider)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"unknown identity provider"}`,
+		},
+		{
+			name:         "missing claims",
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/self/identities/mockidp", nil)
+			ctx.Params = gin.Params{{Key: "provider", Value: "mockidp"}}
+			tc.setupRequest(ctx)
+
+			identityHandler := NewIdentityHandler(tc.setupMockSvc())
+			identityHandler.StartLink(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestIdentity_Unlink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "unlink successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Unlink", mock.Anything, "user-001", "identity-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Identity unlinked successfully!"}`,
+		},
+		{
+			name: "last credential",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Unlink", mock.Anything, "user-001", "identity-001").Return(service.ErrLastCredential)
+				return mockSvc
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `{"message":"cannot remove the last remaining login method"}`,
+		},
+		{
+			name: "identity not found",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Unlink", mock.Anything, "user-001", "identity-001").Return(dbutils.ErrRecordNotFoundType)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"identity not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Unlink", mock.Anything, "user-001", "identity-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/self/identities/identity-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "identity-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			identityHandler := NewIdentityHandler(tc.setupMockSvc())
+			identityHandler.Unlink(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
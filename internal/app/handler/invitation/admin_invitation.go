@@ -0,0 +1,148 @@
+package invitation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+)
+
+type createInvitationRequest struct {
+	Email string `json:"email" binding:"required,email" example:"invitee@example.com"`
+	Role  string `json:"role" example:"member"`
+}
+
+type listInvitationsResponse struct {
+	Data    []*model.Invitation `json:"data"`
+	Message string              `json:"message"`
+}
+
+// CreateInvitation invites an email address.
+// @Summary      Create an invitation
+// @Description  Invite an email address to register and email it the invitation link; a pending invitation of the address is superseded.
+// @Description  The role, member or admin, is granted to the user registering with the invitation and defaults to member.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        invitation  body      createInvitationRequest  true  "Email address to invite"
+// @Success      201         {object}  object{data=model.Invitation,message=string}
+// @Failure      400         {object}  object{message=string}
+// @Failure      401         {object}  object{message=string}
+// @Failure      409         {object}  object{message=string}
+// @Failure      500         {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/invitations [post]
+func (h *invitationHandler) CreateInvitation(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_CreateInvitation")
+	defer s.End()
+
+	input := &createInvitationRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	invitation, err := h.invitationSvc.CreateInvitation(c, input.Email, input.Role)
+	switch {
+	case errors.Is(err, service.ErrUnsupportedRole):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusConflict, common.Message{
+			Message: "a user already has this email address",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "CreateInvitation").
+			Err(err).
+			Msg("service return error when creating invitation")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*model.Invitation]{
+		Data:    invitation,
+		Message: "Invitation sent successfully!",
+	})
+}
+
+// ListInvitations lists the invitations.
+// @Summary      List invitations
+// @Description  List the invitations, newest first, whatever their status
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  listInvitationsResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/invitations [get]
+func (h *invitationHandler) ListInvitations(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListInvitations")
+	defer s.End()
+
+	invitations, err := h.invitationSvc.ListInvitations(c)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListInvitations").
+			Err(err).
+			Msg("service return error when listing invitations")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listInvitationsResponse{
+		Data:    invitations,
+		Message: "Invitations retrieved successfully!",
+	})
+}
+
+// RevokeInvitation revokes a pending invitation.
+// @Summary      Revoke an invitation
+// @Description  Revoke a pending invitation so its link stops working
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "Invitation ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/invitations/{id} [delete]
+func (h *invitationHandler) RevokeInvitation(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_RevokeInvitation")
+	defer s.End()
+
+	err := h.invitationSvc.RevokeInvitation(c, c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "RevokeInvitation").
+			Err(err).
+			Msg("service return error when revoking invitation")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Invitation revoked successfully!",
+	})
+}
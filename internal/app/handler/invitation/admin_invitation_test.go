@@ -0,0 +1,226 @@
+package invitation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/invitation/mocks"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var testInvitation = &model.Invitation{
+	Base:      model.Base{ID: "invitation-001", CreatedAt: testTime, UpdatedAt: testTime},
+	Email:     "invitee@example.com",
+	Role:      model.RoleAdmin,
+	TokenHash: "hash",
+	Status:    model.InvitationPending,
+	ExpiresAt: testTime.Add(7 * 24 * time.Hour),
+}
+
+const testInvitationJSON = `{"id":"invitation-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","email":"invitee@example.com","role":"admin","status":"pending","expires_at":"2024-01-08T00:00:00Z","accepted_at":null,"user_id":null}`
+
+func TestInvitation_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "create invitation successfully",
+			inputBody: `{"email":"invitee@example.com","role":"admin"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "invitee@example.com", "admin").Return(testInvitation, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":` + testInvitationJSON + `,"message":"Invitation sent successfully!"}`,
+		},
+		{
+			name:      "invalid email",
+			inputBody: `{"email":"invitee"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Email is invalid (email)"]}`,
+		},
+		{
+			name:      "unsupported role",
+			inputBody: `{"email":"invitee@example.com","role":"owner"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "invitee@example.com", "owner").Return(nil, service.ErrUnsupportedRole)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"unsupported role"}`,
+		},
+		{
+			name:      "email already registered",
+			inputBody: `{"email":"invitee@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "invitee@example.com", "").Return(nil, dbutils.ErrDuplicationType)
+				return mockSvc
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `{"message":"a user already has this email address"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"email":"invitee@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "invitee@example.com", "").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/invitations", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			invitationHandler := NewInvitationHandler(tc.setupMockSvc())
+			invitationHandler.CreateInvitation(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestInvitation_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list invitations successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListInvitations", mock.Anything).Return([]*model.Invitation{testInvitation}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[` + testInvitationJSON + `],"message":"Invitations retrieved successfully!"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListInvitations", mock.Anything).Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/invitations", nil)
+
+			invitationHandler := NewInvitationHandler(tc.setupMockSvc())
+			invitationHandler.ListInvitations(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestInvitation_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "revoke invitation successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeInvitation", mock.Anything, "invitation-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Invitation revoked successfully!"}`,
+		},
+		{
+			name: "invitation not found",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeInvitation", mock.Anything, "invitation-001").Return(service.ErrInvitationNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"pending invitation not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeInvitation", mock.Anything, "invitation-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/admin/invitations/invitation-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "invitation-001"}}
+
+			invitationHandler := NewInvitationHandler(tc.setupMockSvc())
+			invitationHandler.RevokeInvitation(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
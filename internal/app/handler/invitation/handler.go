@@ -0,0 +1,45 @@
+// Package invitation provides HTTP handlers for the admin API managing the invitations to register,
+// using the Gin web framework.
+package invitation
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+)
+
+// Handler defines the interface for invitation admin HTTP handlers.
+type Handler interface {
+	// CreateInvitation is a Gin framework handler that invites an email address.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	CreateInvitation(c *gin.Context)
+
+	// ListInvitations is a Gin framework handler that lists the invitations.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListInvitations(c *gin.Context)
+
+	// RevokeInvitation is a Gin framework handler that revokes a pending invitation.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	RevokeInvitation(c *gin.Context)
+}
+
+// invitationHandler is the concrete implementation of the Handler interface.
+type invitationHandler struct {
+	invitationSvc invitation.Service
+}
+
+// NewInvitationHandler creates a new instance of the invitation admin handler.
+//
+// Parameters:
+//   - invitationSvc: The service used for invitation operations
+//
+// Returns:
+//   - Handler: A new invitation admin handler instance
+func NewInvitationHandler(invitationSvc invitation.Service) Handler {
+	return &invitationHandler{invitationSvc: invitationSvc}
+}
@@ -0,0 +1,33 @@
+// Package loginhistory provides the HTTP handler exposing the login history of the current user,
+// using the Gin web framework.
+package loginhistory
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/loginhistory"
+)
+
+// Handler defines the interface for login history HTTP handlers.
+type Handler interface {
+	// ListHistory is a Gin framework handler that lists the latest login attempts on the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListHistory(c *gin.Context)
+}
+
+// loginHistoryHandler is the concrete implementation of the Handler interface.
+type loginHistoryHandler struct {
+	loginHistorySvc loginhistory.Service
+}
+
+// NewLoginHistoryHandler creates a new instance of the login history handler.
+//
+// Parameters:
+//   - loginHistorySvc: The service used for login history operations
+//
+// Returns:
+//   - Handler: A new login history handler instance
+func NewLoginHistoryHandler(loginHistorySvc loginhistory.Service) Handler {
+	return &loginHistoryHandler{loginHistorySvc: loginHistorySvc}
+}
@@ -0,0 +1,54 @@
+package loginhistory
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+type listHistoryResponse struct {
+	Data    []*model.LoginEvent `json:"data"`
+	Message string              `json:"message"`
+}
+
+// ListHistory lists the latest login attempts on the authenticated user, newest first.
+// @Summary      List login history
+// @Description  List the latest login attempts on the authenticated user, successful or not, newest first
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  listHistoryResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/login-history [get]
+func (h *loginHistoryHandler) ListHistory(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListHistory")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	events, err := h.loginHistorySvc.ListHistory(c, userID)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListHistory").
+			Err(err).
+			Msg("service return error when listing login history")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listHistoryResponse{
+		Data:    events,
+		Message: "Login history retrieved successfully!",
+	})
+}
@@ -0,0 +1,94 @@
+package loginhistory
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/loginhistory/mocks"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestLoginHistory_ListHistory(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list login history successfully",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListHistory", mock.Anything, "user-001").Return([]*model.LoginEvent{
+					{
+						Base:              model.Base{ID: "event-001", CreatedAt: testTime, UpdatedAt: testTime},
+						UserID:            "user-001",
+						IPAddress:         "192.0.2.1",
+						UserAgent:         "curl/8.4.0",
+						DeviceFingerprint: "fingerprint",
+						Success:           false,
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"event-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","ip_address":"192.0.2.1","user_agent":"curl/8.4.0","success":false,"mfa_used":false}],"message":"Login history retrieved successfully!"}`,
+		},
+		{
+			name:         "missing claims",
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "service layer error",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListHistory", mock.Anything, "user-001").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/self/login-history", nil)
+			tc.setupRequest(ctx)
+
+			loginHistoryHandler := NewLoginHistoryHandler(tc.setupMockSvc())
+			loginHistoryHandler.ListHistory(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package magiclink provides HTTP handlers for passwordless login links using the Gin web framework.
+package magiclink
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/magiclink"
+)
+
+const (
+	// NonceCookieName is the cookie binding a login link to the device that requested it.
+	NonceCookieName = "magic_link_nonce"
+
+	// nonceCookiePath limits the nonce cookie to the magic link endpoints.
+	nonceCookiePath = "/v1/users/login/magic-link"
+)
+
+// Handler defines the interface for magic link HTTP handlers.
+type Handler interface {
+	// RequestLink is a Gin framework handler that emails a login link.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	RequestLink(c *gin.Context)
+
+	// VerifyLink is a Gin framework handler that exchanges a login link for a JWT token.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	VerifyLink(c *gin.Context)
+}
+
+// magicLinkHandler is the concrete implementation of the Handler interface.
+type magicLinkHandler struct {
+	magicLinkSvc magiclink.Service
+}
+
+// NewMagicLinkHandler creates a new instance of the magic link handler.
+//
+// Parameters:
+//   - magicLinkSvc: The magic link service used for passwordless login
+//
+// Returns:
+//   - Handler: A new magic link handler instance
+func NewMagicLinkHandler(magicLinkSvc magiclink.Service) Handler {
+	return &magicLinkHandler{magicLinkSvc: magicLinkSvc}
+}
@@ -0,0 +1,122 @@
+package magiclink
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	sessionHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/session"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/magiclink"
+)
+
+type requestLinkRequest struct {
+	Email string `json:"email" binding:"required,email" example:"testuser001@example.com"`
+}
+
+type verifyLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// RequestLink emails a single-use login link and binds it to the requesting device with a nonce cookie.
+// The response is the same whether or not the email belongs to an account.
+// @Summary      Request a login link
+// @Description  Email a single-use, short-lived login link that only works in this browser
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      requestLinkRequest  true  "Email address"
+// @Success      202      {object}  object{message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      429      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Router       /v1/users/login/magic-link [post]
+func (m *magicLinkHandler) RequestLink(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_RequestLink")
+	defer s.End()
+
+	input := &requestLinkRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	nonce, err := m.magicLinkSvc.Request(c, input.Email)
+	switch {
+	case errors.Is(err, service.ErrTooManyRequests):
+		c.JSON(http.StatusTooManyRequests, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "RequestLink").
+			Err(err).
+			Msg("service return error when requesting magic link")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(NonceCookieName, nonce, int(service.MagicLinkExpiration.Seconds()), nonceCookiePath, "", true, true)
+
+	c.JSON(http.StatusAccepted, common.Message{
+		Message: "If the email belongs to an account, a login link has been sent",
+	})
+}
+
+// VerifyLink exchanges a login link for a JWT token.
+// It must be called from the browser that requested the link, which holds the nonce cookie.
+// @Summary      Log in with a login link
+// @Description  Exchange the token of a login link for a JWT token
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      verifyLinkRequest  true  "Login link token"
+// @Success      200      {object}  object{data=string,message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Router       /v1/users/login/magic-link/verify [post]
+func (m *magicLinkHandler) VerifyLink(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_VerifyLink")
+	defer s.End()
+
+	input := &verifyLinkRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	// A missing cookie is handled by the service as a nonce mismatch.
+	nonce, _ := c.Cookie(NonceCookieName)
+
+	token, err := m.magicLinkSvc.Verify(sessionHandler.WithRequestClient(c), input.Token, nonce)
+	switch {
+	case errors.Is(err, service.ErrInvalidMagicLink):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "VerifyLink").
+			Err(err).
+			Msg("service return error when verifying magic link")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(NonceCookieName, "", -1, nonceCookiePath, "", true, true)
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[string]{
+		Data:    token,
+		Message: "Logged in successfully!",
+	})
+}
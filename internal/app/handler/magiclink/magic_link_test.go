@@ -0,0 +1,173 @@
+package magiclink
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/magiclink"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/magiclink/mocks"
+)
+
+func TestMagicLink_RequestLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedCookie   string
+		expectedResponse string
+	}{
+		{
+			name:      "link requested",
+			inputBody: `{"email":"testuser001@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Request", mock.Anything, "testuser001@example.com").Return("nonce-001", nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusAccepted,
+			expectedCookie:   "magic_link_nonce=nonce-001; Path=/v1/users/login/magic-link; Max-Age=900; HttpOnly; Secure; SameSite=Strict",
+			expectedResponse: `{"message":"If the email belongs to an account, a login link has been sent"}`,
+		},
+		{
+			name:      "invalid email",
+			inputBody: `{"email":"not-an-email"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Email is invalid (email)"]}`,
+		},
+		{
+			name:      "email throttled",
+			inputBody: `{"email":"testuser001@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Request", mock.Anything, "testuser001@example.com").Return("", service.ErrTooManyRequests)
+				return mockSvc
+			},
+			expectedCode:     http.StatusTooManyRequests,
+			expectedResponse: `{"message":"too many login links requested, please try again later"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"email":"testuser001@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Request", mock.Anything, "testuser001@example.com").Return("", assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login/magic-link", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			magicLinkHandler := NewMagicLinkHandler(tc.setupMockSvc())
+			magicLinkHandler.RequestLink(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedCookie, rec.Header().Get("Set-Cookie"))
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestMagicLink_VerifyLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody   string
+		inputCookie *http.Cookie
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:        "successful login",
+			inputBody:   `{"token":"link-001.signature"}`,
+			inputCookie: &http.Cookie{Name: NonceCookieName, Value: "nonce-001"},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Verify", mock.Anything, "link-001.signature", "nonce-001").Return("mocked-jwt-token", nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":"mocked-jwt-token","message":"Logged in successfully!"}`,
+		},
+		{
+			name:      "missing nonce cookie",
+			inputBody: `{"token":"link-001.signature"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Verify", mock.Anything, "link-001.signature", "").Return("", service.ErrInvalidMagicLink)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired login link"}`,
+		},
+		{
+			name:      "missing token",
+			inputBody: `{}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Token is invalid (required)"]}`,
+		},
+		{
+			name:        "service layer error",
+			inputBody:   `{"token":"link-001.signature"}`,
+			inputCookie: &http.Cookie{Name: NonceCookieName, Value: "nonce-001"},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("Verify", mock.Anything, "link-001.signature", "nonce-001").Return("", assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login/magic-link/verify", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			if tc.inputCookie != nil {
+				ctx.Request.AddCookie(tc.inputCookie)
+			}
+
+			magicLinkHandler := NewMagicLinkHandler(tc.setupMockSvc())
+			magicLinkHandler.VerifyLink(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Package organization provides HTTP handlers for the organizations of the current user,
+// their members and invitations, using the Gin web framework.
+package organization
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/organization"
+)
+
+// Handler defines the interface for organization HTTP handlers.
+type Handler interface {
+	// CreateOrganization is a Gin framework handler that creates an organization owned by the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	CreateOrganization(c *gin.Context)
+
+	// ListOrganizations is a Gin framework handler that lists the organizations of the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListOrganizations(c *gin.Context)
+
+	// GetOrganization is a Gin framework handler that retrieves an organization of the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	GetOrganization(c *gin.Context)
+
+	// UpdateOrganization is a Gin framework handler that renames an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	UpdateOrganization(c *gin.Context)
+
+	// DeleteOrganization is a Gin framework handler that deletes an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	DeleteOrganization(c *gin.Context)
+
+	// ListMembers is a Gin framework handler that lists the members of an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListMembers(c *gin.Context)
+
+	// UpdateMemberRole is a Gin framework handler that changes the role of a member.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	UpdateMemberRole(c *gin.Context)
+
+	// RemoveMember is a Gin framework handler that removes a member, or lets the authenticated user leave.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	RemoveMember(c *gin.Context)
+
+	// TransferOwnership is a Gin framework handler that makes another member the owner of an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	TransferOwnership(c *gin.Context)
+
+	// CreateInvitation is a Gin framework handler that invites an email address to join an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	CreateInvitation(c *gin.Context)
+
+	// ListInvitations is a Gin framework handler that lists the invitations to join an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListInvitations(c *gin.Context)
+
+	// RevokeInvitation is a Gin framework handler that revokes a pending invitation to join an organization.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	RevokeInvitation(c *gin.Context)
+
+	// AcceptInvitation is a Gin framework handler that adds the authenticated user to the organization of an
+	// invitation.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	AcceptInvitation(c *gin.Context)
+}
+
+// organizationHandler is the concrete implementation of the Handler interface.
+type organizationHandler struct {
+	organizationSvc organization.Service
+}
+
+// NewOrganizationHandler creates a new instance of the organization handler.
+//
+// Parameters:
+//   - organizationSvc: The service used for organization operations
+//
+// Returns:
+//   - Handler: A new organization handler instance
+func NewOrganizationHandler(organizationSvc organization.Service) Handler {
+	return &organizationHandler{organizationSvc: organizationSvc}
+}
+
+// respondError writes the response of a failed organization operation.
+// The errors of the service are mapped to their status, any other error is logged and hidden behind a 500.
+func respondError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, organization.ErrUnsupportedRole),
+		errors.Is(err, organization.ErrInvalidInvitation),
+		errors.Is(err, organization.ErrInvitationEmailMismatch):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+	case errors.Is(err, organization.ErrInsufficientRole):
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: err.Error(),
+		})
+	case errors.Is(err, organization.ErrOrganizationNotFound),
+		errors.Is(err, organization.ErrMemberNotFound),
+		errors.Is(err, organization.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+	case errors.Is(err, organization.ErrOwnerMembership),
+		errors.Is(err, organization.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, common.Message{
+			Message: err.Error(),
+		})
+	default:
+		log.Error().
+			Str("operation", operation).
+			Err(err).
+			Msg("service return error when handling organization request")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+	}
+}
@@ -0,0 +1,189 @@
+package organization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+type createInvitationRequest struct {
+	Email string `json:"email" binding:"required,email" example:"invitee@example.com"`
+	Role  string `json:"role" example:"member"`
+}
+
+type acceptInvitationRequest struct {
+	Token string `json:"token" binding:"required" example:"mJ3kX9pQ2rT5vW8yB1cD4fG7hK0nL6sZ"`
+}
+
+type listInvitationsResponse struct {
+	Data    []*model.OrganizationInvitation `json:"data"`
+	Message string                          `json:"message"`
+}
+
+// CreateInvitation invites an email address to join an organization.
+// @Summary      Invite to an organization
+// @Description  Invite an email address to join an organization and email it the invitation link; a pending invitation of
+// @Description  the address to the organization is superseded. The role, member or admin, defaults to member.
+// @Description  The authenticated user must be an admin or the owner.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                   true  "Organization ID"
+// @Param        invitation  body      createInvitationRequest  true  "Email address to invite"
+// @Success      201         {object}  object{data=model.OrganizationInvitation,message=string}
+// @Failure      400         {object}  object{message=string}
+// @Failure      401         {object}  object{message=string}
+// @Failure      403         {object}  object{message=string}
+// @Failure      404         {object}  object{message=string}
+// @Failure      409         {object}  object{message=string}
+// @Failure      500         {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/invitations [post]
+func (h *organizationHandler) CreateInvitation(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_CreateOrganizationInvitation")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &createInvitationRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	invitation, err := h.organizationSvc.CreateInvitation(c, userID, c.Param("id"), input.Email, input.Role)
+	if err != nil {
+		respondError(c, "CreateOrganizationInvitation", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*model.OrganizationInvitation]{
+		Data:    invitation,
+		Message: "Invitation sent successfully!",
+	})
+}
+
+// ListInvitations lists the invitations to join an organization.
+// @Summary      List organization invitations
+// @Description  List the invitations to join an organization, newest first, whatever their status; the authenticated user
+// @Description  must be an admin or the owner
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string  true  "Organization ID"
+// @Success      200  {object}  listInvitationsResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      403  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/invitations [get]
+func (h *organizationHandler) ListInvitations(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListOrganizationInvitations")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	invitations, err := h.organizationSvc.ListInvitations(c, userID, c.Param("id"))
+	if err != nil {
+		respondError(c, "ListOrganizationInvitations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listInvitationsResponse{
+		Data:    invitations,
+		Message: "Invitations retrieved successfully!",
+	})
+}
+
+// RevokeInvitation revokes a pending invitation to join an organization.
+// @Summary      Revoke an organization invitation
+// @Description  Revoke a pending invitation so its link stops working; the authenticated user must be an admin or the owner
+// @Tags         Organizations
+// @Produce      json
+// @Param        id             path      string  true  "Organization ID"
+// @Param        invitation_id  path      string  true  "Invitation ID"
+// @Success      200            {object}  object{message=string}
+// @Failure      401            {object}  object{message=string}
+// @Failure      403            {object}  object{message=string}
+// @Failure      404            {object}  object{message=string}
+// @Failure      500            {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/invitations/{invitation_id} [delete]
+func (h *organizationHandler) RevokeInvitation(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_RevokeOrganizationInvitation")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = h.organizationSvc.RevokeInvitation(c, userID, c.Param("id"), c.Param("invitation_id"))
+	if err != nil {
+		respondError(c, "RevokeOrganizationInvitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Invitation revoked successfully!",
+	})
+}
+
+// AcceptInvitation adds the authenticated user to the organization of an invitation.
+// @Summary      Accept an organization invitation
+// @Description  Join the organization of an invitation with its role; the authenticated user must own the invited email address
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        invitation  body      acceptInvitationRequest  true  "Token of the invitation link"
+// @Success      200         {object}  object{data=model.Membership,message=string}
+// @Failure      400         {object}  object{message=string}
+// @Failure      401         {object}  object{message=string}
+// @Failure      409         {object}  object{message=string}
+// @Failure      500         {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/invitations/accept [post]
+func (h *organizationHandler) AcceptInvitation(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_AcceptOrganizationInvitation")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &acceptInvitationRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	membership, err := h.organizationSvc.AcceptInvitation(c, userID, input.Token)
+	if err != nil {
+		respondError(c, "AcceptOrganizationInvitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[*model.Membership]{
+		Data:    membership,
+		Message: "Joined the organization successfully!",
+	})
+}
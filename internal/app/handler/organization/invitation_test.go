@@ -0,0 +1,289 @@
+package organization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/organization"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/organization/mocks"
+)
+
+var testInvitation = &model.OrganizationInvitation{
+	Base:           model.Base{ID: "invitation-001", CreatedAt: testTime, UpdatedAt: testTime},
+	OrganizationID: "org-001",
+	Email:          "invitee@example.com",
+	Role:           model.OrganizationMember,
+	TokenHash:      "hash",
+	Status:         model.InvitationPending,
+	ExpiresAt:      testTime.Add(7 * 24 * time.Hour),
+}
+
+const testInvitationJSON = `{"id":"invitation-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","organization_id":"org-001","email":"invitee@example.com","role":"member","status":"pending","expires_at":"2024-01-08T00:00:00Z","accepted_at":null,"user_id":null}`
+
+func TestOrganization_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "create invitation successfully",
+			inputBody: `{"email":"invitee@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "user-001", "org-001", "invitee@example.com", "").Return(testInvitation, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":` + testInvitationJSON + `,"message":"Invitation sent successfully!"}`,
+		},
+		{
+			name:      "invalid email",
+			inputBody: `{"email":"invitee"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Email is invalid (email)"]}`,
+		},
+		{
+			name:      "already a member",
+			inputBody: `{"email":"invitee@example.com","role":"admin"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "user-001", "org-001", "invitee@example.com", "admin").Return(nil, service.ErrAlreadyMember)
+				return mockSvc
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `{"message":"user already is a member of the organization"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"email":"invitee@example.com"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateInvitation", mock.Anything, "user-001", "org-001", "invitee@example.com", "").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/organizations/org-001/invitations", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.CreateInvitation(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list invitations successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListInvitations", mock.Anything, "user-001", "org-001").Return([]*model.OrganizationInvitation{testInvitation}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[` + testInvitationJSON + `],"message":"Invitations retrieved successfully!"}`,
+		},
+		{
+			name: "insufficient role",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListInvitations", mock.Anything, "user-001", "org-001").Return(nil, service.ErrInsufficientRole)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"your role in the organization does not allow this action"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/organizations/org-001/invitations", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.ListInvitations(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "revoke invitation successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeInvitation", mock.Anything, "user-001", "org-001", "invitation-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Invitation revoked successfully!"}`,
+		},
+		{
+			name: "no pending invitation",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RevokeInvitation", mock.Anything, "user-001", "org-001", "invitation-001").Return(service.ErrInvitationNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"pending invitation not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/organizations/org-001/invitations/invitation-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}, {Key: "invitation_id", Value: "invitation-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.RevokeInvitation(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "accept invitation successfully",
+			inputBody: `{"token":"invite-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AcceptInvitation", mock.Anything, "user-001", "invite-001").Return(&model.Membership{
+					Base:           model.Base{ID: "membership-001", CreatedAt: testTime, UpdatedAt: testTime},
+					OrganizationID: "org-001",
+					UserID:         "user-001",
+					Role:           model.OrganizationMember,
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":{"id":"membership-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","organization_id":"org-001","user_id":"user-001","role":"member"},"message":"Joined the organization successfully!"}`,
+		},
+		{
+			name:      "invalid invitation",
+			inputBody: `{"token":"invite-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AcceptInvitation", mock.Anything, "user-001", "invite-001").Return(nil, service.ErrInvalidInvitation)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired invitation"}`,
+		},
+		{
+			name:      "email mismatch",
+			inputBody: `{"token":"invite-001"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AcceptInvitation", mock.Anything, "user-001", "invite-001").Return(nil, service.ErrInvitationEmailMismatch)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invitation was sent to another email address"}`,
+		},
+		{
+			name:      "missing token",
+			inputBody: `{}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Token is invalid (required)"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/organizations/invitations/accept", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.AcceptInvitation(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
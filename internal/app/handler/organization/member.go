@@ -0,0 +1,188 @@
+package organization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+type updateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required" example:"admin"`
+}
+
+type transferOwnershipRequest struct {
+	UserID string `json:"user_id" binding:"required" example:"987e6543-e21b-12d3-a456-eb6b9e546002"`
+}
+
+type listMembersResponse struct {
+	Data    []*model.Membership `json:"data"`
+	Message string              `json:"message"`
+}
+
+// ListMembers lists the members of an organization.
+// @Summary      List members
+// @Description  List the memberships of an organization the authenticated user is a member of, with their users, oldest first
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string  true  "Organization ID"
+// @Success      200  {object}  listMembersResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/members [get]
+func (h *organizationHandler) ListMembers(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListMembers")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	memberships, err := h.organizationSvc.ListMembers(c, userID, c.Param("id"))
+	if err != nil {
+		respondError(c, "ListMembers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listMembersResponse{
+		Data:    memberships,
+		Message: "Members retrieved successfully!",
+	})
+}
+
+// UpdateMemberRole changes the role of a member.
+// @Summary      Update the role of a member
+// @Description  Make a member an admin or a member; the authenticated user must be an admin or the owner.
+// @Description  The role of the owner only changes by transferring the ownership.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "Organization ID"
+// @Param        user_id  path      string                   true  "User ID of the member"
+// @Param        role     body      updateMemberRoleRequest  true  "New role, admin or member"
+// @Success      200      {object}  object{message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      401      {object}  object{message=string}
+// @Failure      403      {object}  object{message=string}
+// @Failure      404      {object}  object{message=string}
+// @Failure      409      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/members/{user_id} [put]
+func (h *organizationHandler) UpdateMemberRole(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_UpdateMemberRole")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &updateMemberRoleRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	err = h.organizationSvc.UpdateMemberRole(c, userID, c.Param("id"), c.Param("user_id"), input.Role)
+	if err != nil {
+		respondError(c, "UpdateMemberRole", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Member role updated successfully!",
+	})
+}
+
+// RemoveMember removes a member from an organization.
+// @Summary      Remove a member
+// @Description  Remove a member from an organization; admins and the owner remove others, and any member may remove
+// @Description  themselves to leave. The owner must transfer the ownership before leaving.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id       path      string  true  "Organization ID"
+// @Param        user_id  path      string  true  "User ID of the member"
+// @Success      200      {object}  object{message=string}
+// @Failure      401      {object}  object{message=string}
+// @Failure      403      {object}  object{message=string}
+// @Failure      404      {object}  object{message=string}
+// @Failure      409      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/members/{user_id} [delete]
+func (h *organizationHandler) RemoveMember(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_RemoveMember")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = h.organizationSvc.RemoveMember(c, userID, c.Param("id"), c.Param("user_id"))
+	if err != nil {
+		respondError(c, "RemoveMember", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Member removed successfully!",
+	})
+}
+
+// TransferOwnership makes another member the owner of an organization.
+// @Summary      Transfer the ownership
+// @Description  Make another member the owner of an organization; the authenticated user must be the owner and becomes an admin
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                    true  "Organization ID"
+// @Param        transfer  body      transferOwnershipRequest  true  "User ID of the new owner"
+// @Success      200       {object}  object{message=string}
+// @Failure      400       {object}  object{message=string}
+// @Failure      401       {object}  object{message=string}
+// @Failure      403       {object}  object{message=string}
+// @Failure      404       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id}/transfer-ownership [post]
+func (h *organizationHandler) TransferOwnership(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_TransferOwnership")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &transferOwnershipRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	err = h.organizationSvc.TransferOwnership(c, userID, c.Param("id"), input.UserID)
+	if err != nil {
+		respondError(c, "TransferOwnership", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Ownership transferred successfully!",
+	})
+}
@@ -0,0 +1,279 @@
+package organization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/organization"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/organization/mocks"
+)
+
+func TestOrganization_ListMembers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list members successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListMembers", mock.Anything, "user-001", "org-001").Return([]*model.Membership{
+					{
+						Base:           model.Base{ID: "membership-001", CreatedAt: testTime, UpdatedAt: testTime},
+						OrganizationID: "org-001",
+						UserID:         "user-001",
+						Role:           model.OrganizationMember,
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"membership-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","organization_id":"org-001","user_id":"user-001","role":"member"}],"message":"Members retrieved successfully!"}`,
+		},
+		{
+			name: "not a member",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListMembers", mock.Anything, "user-001", "org-001").Return(nil, service.ErrOrganizationNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"organization not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/organizations/org-001/members", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.ListMembers(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_UpdateMemberRole(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "update member role successfully",
+			inputBody: `{"role":"admin"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("UpdateMemberRole", mock.Anything, "user-001", "org-001", "user-002", "admin").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Member role updated successfully!"}`,
+		},
+		{
+			name:      "unsupported role",
+			inputBody: `{"role":"owner"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("UpdateMemberRole", mock.Anything, "user-001", "org-001", "user-002", "owner").Return(service.ErrUnsupportedRole)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"unsupported role"}`,
+		},
+		{
+			name:      "member is the owner",
+			inputBody: `{"role":"member"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("UpdateMemberRole", mock.Anything, "user-001", "org-001", "user-002", "member").Return(service.ErrOwnerMembership)
+				return mockSvc
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `{"message":"the owner cannot leave or change role, transfer the ownership first"}`,
+		},
+		{
+			name:      "member not found",
+			inputBody: `{"role":"member"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("UpdateMemberRole", mock.Anything, "user-001", "org-001", "user-002", "member").Return(service.ErrMemberNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"member not found"}`,
+		},
+		{
+			name:      "missing role",
+			inputBody: `{}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Role is invalid (required)"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/organizations/org-001/members/user-002", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}, {Key: "user_id", Value: "user-002"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.UpdateMemberRole(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_RemoveMember(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "remove member successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RemoveMember", mock.Anything, "user-001", "org-001", "user-002").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Member removed successfully!"}`,
+		},
+		{
+			name: "insufficient role",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("RemoveMember", mock.Anything, "user-001", "org-001", "user-002").Return(service.ErrInsufficientRole)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"your role in the organization does not allow this action"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/organizations/org-001/members/user-002", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}, {Key: "user_id", Value: "user-002"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.RemoveMember(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_TransferOwnership(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "transfer ownership successfully",
+			inputBody: `{"user_id":"user-002"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("TransferOwnership", mock.Anything, "user-001", "org-001", "user-002").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Ownership transferred successfully!"}`,
+		},
+		{
+			name:      "new owner is not a member",
+			inputBody: `{"user_id":"user-002"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("TransferOwnership", mock.Anything, "user-001", "org-001", "user-002").Return(service.ErrMemberNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"member not found"}`,
+		},
+		{
+			name:      "missing user ID",
+			inputBody: `{}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["UserID is invalid (required)"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/organizations/org-001/transfer-ownership", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.TransferOwnership(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package organization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+type organizationRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Acme"`
+}
+
+type listOrganizationsResponse struct {
+	Data    []*model.Membership `json:"data"`
+	Message string              `json:"message"`
+}
+
+// CreateOrganization creates an organization owned by the authenticated user.
+// @Summary      Create an organization
+// @Description  Create an organization; the authenticated user becomes its owner
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        organization  body      organizationRequest  true  "Organization to create"
+// @Success      201           {object}  object{data=model.Organization,message=string}
+// @Failure      400           {object}  object{message=string}
+// @Failure      401           {object}  object{message=string}
+// @Failure      500           {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations [post]
+func (h *organizationHandler) CreateOrganization(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_CreateOrganization")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &organizationRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	organization, err := h.organizationSvc.CreateOrganization(c, userID, input.Name)
+	if err != nil {
+		respondError(c, "CreateOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*model.Organization]{
+		Data:    organization,
+		Message: "Organization created successfully!",
+	})
+}
+
+// ListOrganizations lists the organizations of the authenticated user.
+// @Summary      List organizations
+// @Description  List the memberships of the authenticated user with their organizations, oldest first
+// @Tags         Organizations
+// @Produce      json
+// @Success      200  {object}  listOrganizationsResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations [get]
+func (h *organizationHandler) ListOrganizations(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListOrganizations")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	memberships, err := h.organizationSvc.ListOrganizations(c, userID)
+	if err != nil {
+		respondError(c, "ListOrganizations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listOrganizationsResponse{
+		Data:    memberships,
+		Message: "Organizations retrieved successfully!",
+	})
+}
+
+// GetOrganization retrieves an organization of the authenticated user.
+// @Summary      Get an organization
+// @Description  Get an organization the authenticated user is a member of
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string  true  "Organization ID"
+// @Success      200  {object}  object{data=model.Organization,message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id} [get]
+func (h *organizationHandler) GetOrganization(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_GetOrganization")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	organization, err := h.organizationSvc.GetOrganization(c, userID, c.Param("id"))
+	if err != nil {
+		respondError(c, "GetOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[*model.Organization]{
+		Data:    organization,
+		Message: "Organization retrieved successfully!",
+	})
+}
+
+// UpdateOrganization renames an organization.
+// @Summary      Update an organization
+// @Description  Rename an organization; the authenticated user must be an admin or the owner
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id            path      string               true  "Organization ID"
+// @Param        organization  body      organizationRequest  true  "New name of the organization"
+// @Success      200           {object}  object{message=string}
+// @Failure      400           {object}  object{message=string}
+// @Failure      401           {object}  object{message=string}
+// @Failure      403           {object}  object{message=string}
+// @Failure      404           {object}  object{message=string}
+// @Failure      500           {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id} [put]
+func (h *organizationHandler) UpdateOrganization(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_UpdateOrganization")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &organizationRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	err = h.organizationSvc.UpdateOrganization(c, userID, c.Param("id"), input.Name)
+	if err != nil {
+		respondError(c, "UpdateOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Organization updated successfully!",
+	})
+}
+
+// DeleteOrganization deletes an organization.
+// @Summary      Delete an organization
+// @Description  Delete an organization with its memberships and invitations; the authenticated user must be the owner
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string  true  "Organization ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      403  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/organizations/{id} [delete]
+func (h *organizationHandler) DeleteOrganization(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_DeleteOrganization")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = h.organizationSvc.DeleteOrganization(c, userID, c.Param("id"))
+	if err != nil {
+		respondError(c, "DeleteOrganization", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Organization deleted successfully!",
+	})
+}
@@ -0,0 +1,348 @@
+package organization
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/organization"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/organization/mocks"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var testOrganization = &model.Organization{
+	Base: model.Base{ID: "org-001", CreatedAt: testTime, UpdatedAt: testTime},
+	Name: "Acme",
+}
+
+const testOrganizationJSON = `{"id":"org-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","name":"Acme"}`
+
+func TestOrganization_CreateOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "create organization successfully",
+			inputBody: `{"name":"Acme"}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateOrganization", mock.Anything, "user-001", "Acme").Return(testOrganization, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":` + testOrganizationJSON + `,"message":"Organization created successfully!"}`,
+		},
+		{
+			name:         "missing claims",
+			inputBody:    `{"name":"Acme"}`,
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name:      "missing name",
+			inputBody: `{}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Name is invalid (required)"]}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"name":"Acme"}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateOrganization", mock.Anything, "user-001", "Acme").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/organizations", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			tc.setupRequest(ctx)
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.CreateOrganization(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_ListOrganizations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list organizations successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListOrganizations", mock.Anything, "user-001").Return([]*model.Membership{
+					{
+						Base:           model.Base{ID: "membership-001", CreatedAt: testTime, UpdatedAt: testTime},
+						OrganizationID: "org-001",
+						UserID:         "user-001",
+						Role:           model.OrganizationOwner,
+						Organization:   testOrganization,
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"membership-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","organization_id":"org-001","user_id":"user-001","role":"owner","organization":` + testOrganizationJSON + `}],"message":"Organizations retrieved successfully!"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListOrganizations", mock.Anything, "user-001").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/organizations", nil)
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.ListOrganizations(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_GetOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "get organization successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("GetOrganization", mock.Anything, "user-001", "org-001").Return(testOrganization, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":` + testOrganizationJSON + `,"message":"Organization retrieved successfully!"}`,
+		},
+		{
+			name: "not a member",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("GetOrganization", mock.Anything, "user-001", "org-001").Return(nil, service.ErrOrganizationNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"organization not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/organizations/org-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.GetOrganization(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_UpdateOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "update organization successfully",
+			inputBody: `{"name":"Acme Corporation"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("UpdateOrganization", mock.Anything, "user-001", "org-001", "Acme Corporation").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Organization updated successfully!"}`,
+		},
+		{
+			name:      "insufficient role",
+			inputBody: `{"name":"Acme Corporation"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("UpdateOrganization", mock.Anything, "user-001", "org-001", "Acme Corporation").Return(service.ErrInsufficientRole)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"your role in the organization does not allow this action"}`,
+		},
+		{
+			name:      "missing name",
+			inputBody: `{"name":""}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Name is invalid (required)"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/organizations/org-001", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.UpdateOrganization(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganization_DeleteOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "delete organization successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteOrganization", mock.Anything, "user-001", "org-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Organization deleted successfully!"}`,
+		},
+		{
+			name: "insufficient role",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteOrganization", mock.Anything, "user-001", "org-001").Return(service.ErrInsufficientRole)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"your role in the organization does not allow this action"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteOrganization", mock.Anything, "user-001", "org-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/organizations/org-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "org-001"}}
+			ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+
+			organizationHandler := NewOrganizationHandler(tc.setupMockSvc())
+			organizationHandler.DeleteOrganization(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package passkey provides HTTP handlers for WebAuthn passkey registration and login
+// using the Gin web framework.
+package passkey
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+)
+
+// Handler defines the interface for passkey HTTP handlers.
+type Handler interface {
+	// BeginRegistration is a Gin framework handler that returns the options to create a passkey for the authenticated user.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	BeginRegistration(c *gin.Context)
+
+	// FinishRegistration is a Gin framework handler that verifies and stores the passkey created by the authenticator.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	FinishRegistration(c *gin.Context)
+
+	// BeginLogin is a Gin framework handler that returns the options to log in with a passkey.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	BeginLogin(c *gin.Context)
+
+	// FinishLogin is a Gin framework handler that verifies a passkey assertion and returns a JWT token.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	FinishLogin(c *gin.Context)
+}
+
+// verifyCredentialRequest carries the answer of the authenticator to a registration or login challenge.
+type verifyCredentialRequest struct {
+	SessionID  string          `json:"session_id" binding:"required"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+}
+
+// passkeyHandler is the concrete implementation of the Handler interface.
+type passkeyHandler struct {
+	passkeySvc passkey.Service
+}
+
+// NewPasskeyHandler creates a new instance of the passkey handler.
+//
+// Parameters:
+//   - passkeySvc: The passkey service running the WebAuthn ceremonies
+//
+// Returns:
+//   - Handler: A new passkey handler instance
+func NewPasskeyHandler(passkeySvc passkey.Service) Handler {
+	return &passkeyHandler{passkeySvc: passkeySvc}
+}
@@ -0,0 +1,89 @@
+package passkey
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	sessionHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/session"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+)
+
+// BeginLogin returns the options to log in with a passkey.
+// The client passes the options to navigator.credentials.get() and sends the result to FinishLogin.
+// @Summary      Start passkey login
+// @Description  Get WebAuthn options to log in with any passkey registered on the authenticator
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  object{data=passkey.LoginOptions,message=string}
+// @Failure      500  {object}  object{message=string}
+// @Router       /v1/users/login/passkey/options [post]
+func (h *passkeyHandler) BeginLogin(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_BeginPasskeyLogin")
+	defer s.End()
+
+	options, err := h.passkeySvc.BeginLogin(c)
+	if err != nil {
+		log.Error().
+			Str("operation", "BeginPasskeyLogin").
+			Err(err).
+			Msg("service return error when starting passkey login")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[*service.LoginOptions]{
+		Data:    options,
+		Message: "Sign in with your passkey",
+	})
+}
+
+// FinishLogin verifies a passkey assertion and returns a JWT token for the user owning the passkey.
+// @Summary      Log in with a passkey
+// @Description  Verify the assertion returned by navigator.credentials.get() and receive a JWT token
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      verifyCredentialRequest  true  "Session ID and signed assertion"
+// @Success      200      {object}  object{data=string,message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Router       /v1/users/login/passkey/verify [post]
+func (h *passkeyHandler) FinishLogin(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_FinishPasskeyLogin")
+	defer s.End()
+
+	input := &verifyCredentialRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	token, err := h.passkeySvc.FinishLogin(sessionHandler.WithRequestClient(c), input.SessionID, input.Credential)
+	switch {
+	case errors.Is(err, service.ErrInvalidSession),
+		errors.Is(err, service.ErrInvalidCredential):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "FinishPasskeyLogin").
+			Err(err).
+			Msg("service return error when finishing passkey login")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[string]{
+		Data:    token,
+		Message: "Logged in successfully!",
+	})
+}
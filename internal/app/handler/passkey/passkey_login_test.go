@@ -0,0 +1,151 @@
+package passkey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/passkey/mocks"
+)
+
+func TestPasskey_BeginLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "begin login successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("BeginLogin", mock.Anything).Return(&service.LoginOptions{SessionID: "session-001"}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":{"session_id":"session-001","options":null},"message":"Sign in with your passkey"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("BeginLogin", mock.Anything).Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login/passkey/options", nil)
+
+			passkeyHandler := NewPasskeyHandler(tc.setupMockSvc())
+			passkeyHandler.BeginLogin(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPasskey_FinishLogin(t *testing.T) {
+	t.Parallel()
+
+	credential := []byte(`{"id":"credential-001"}`)
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "login successfully",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishLogin", mock.Anything, "session-001", credential).Return("mocked_jwt_token", nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":"mocked_jwt_token","message":"Logged in successfully!"}`,
+		},
+		{
+			name:      "missing session id",
+			inputBody: `{"credential":{"id":"credential-001"}}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["SessionID is invalid (required)"]}`,
+		},
+		{
+			name:      "invalid session",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishLogin", mock.Anything, "session-001", credential).Return("", service.ErrInvalidSession)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired passkey session"}`,
+		},
+		{
+			name:      "invalid credential",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishLogin", mock.Anything, "session-001", credential).Return("", service.ErrInvalidCredential)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"passkey verification failed"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishLogin", mock.Anything, "session-001", credential).Return("", assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login/passkey/verify", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			passkeyHandler := NewPasskeyHandler(tc.setupMockSvc())
+			passkeyHandler.FinishLogin(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package passkey
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+)
+
+// BeginRegistration returns the options to create a passkey for the authenticated user.
+// The token must have been issued within the last few minutes, so the user has to log in again first.
+// The client passes the options to navigator.credentials.create() and sends the result to FinishRegistration.
+// @Summary      Start passkey registration
+// @Description  Get WebAuthn options to create a passkey for the authenticated user, requires a recent login
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  object{data=passkey.RegistrationOptions,message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      403  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/passkeys/registration/options [post]
+func (h *passkeyHandler) BeginRegistration(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_BeginPasskeyRegistration")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	claims, err := utils.GetJWTClaimsFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: service.ErrReauthenticationRequired.Error(),
+		})
+		return
+	}
+
+	options, err := h.passkeySvc.BeginRegistration(c, userID, issuedAt.Time)
+	switch {
+	case errors.Is(err, service.ErrReauthenticationRequired):
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "BeginPasskeyRegistration").
+			Err(err).
+			Msg("service return error when starting passkey registration")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &common.SuccessResponse[*service.RegistrationOptions]{
+		Data:    options,
+		Message: "Create the passkey on your authenticator",
+	})
+}
+
+// FinishRegistration verifies the passkey created by the authenticator and stores it for the authenticated user.
+// @Summary      Finish passkey registration
+// @Description  Verify the credential returned by navigator.credentials.create() and register the passkey
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      verifyCredentialRequest  true  "Session ID and created credential"
+// @Success      201      {object}  object{data=model.UserPasskey,message=string}
+// @Failure      400      {object}  object{message=string}
+// @Failure      401      {object}  object{message=string}
+// @Failure      409      {object}  object{message=string}
+// @Failure      500      {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/passkeys/registration/verify [post]
+func (h *passkeyHandler) FinishRegistration(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_FinishPasskeyRegistration")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	input := &verifyCredentialRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	passkey, err := h.passkeySvc.FinishRegistration(c, userID, input.SessionID, input.Credential)
+	switch {
+	case errors.Is(err, service.ErrInvalidSession),
+		errors.Is(err, service.ErrInvalidCredential):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, service.ErrPasskeyAlreadyRegistered):
+		c.JSON(http.StatusConflict, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "FinishPasskeyRegistration").
+			Err(err).
+			Msg("service return error when finishing passkey registration")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*model.UserPasskey]{
+		Data:    passkey,
+		Message: "Passkey registered successfully!",
+	})
+}
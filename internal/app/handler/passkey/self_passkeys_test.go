@@ -0,0 +1,244 @@
+package passkey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/passkey/mocks"
+)
+
+func TestPasskey_BeginRegistration(t *testing.T) {
+	t.Parallel()
+
+	issuedAt := time.Now().Add(-time.Minute).Unix()
+
+	testCases := []struct {
+		name string
+
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "begin registration successfully",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001", "iat": float64(issuedAt)})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("BeginRegistration", mock.Anything, "user-001", time.Unix(issuedAt, 0)).
+					Return(&service.RegistrationOptions{SessionID: "session-001"}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":{"session_id":"session-001","options":null},"message":"Create the passkey on your authenticator"}`,
+		},
+		{
+			name: "token without issue time",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"recent login required, please log in again"}`,
+		},
+		{
+			name: "login is too old",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001", "iat": float64(issuedAt)})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("BeginRegistration", mock.Anything, "user-001", mock.Anything).
+					Return(nil, service.ErrReauthenticationRequired)
+				return mockSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"recent login required, please log in again"}`,
+		},
+		{
+			name:         "missing claims",
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "service layer error",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001", "iat": float64(issuedAt)})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("BeginRegistration", mock.Anything, "user-001", mock.Anything).Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/self/passkeys/registration/options", nil)
+			tc.setupRequest(ctx)
+
+			passkeyHandler := NewPasskeyHandler(tc.setupMockSvc())
+			passkeyHandler.BeginRegistration(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPasskey_FinishRegistration(t *testing.T) {
+	t.Parallel()
+
+	credential := []byte(`{"id":"credential-001"}`)
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupRequest func(ctx *gin.Context)
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "finish registration successfully",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishRegistration", mock.Anything, "user-001", "session-001", credential).Return(&model.UserPasskey{
+					Base:       model.Base{ID: "passkey-001", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					UserID:     "user-001",
+					Transports: []string{"internal"},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":{"id":"passkey-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","transports":["internal"],"sign_count":0,"last_used_at":null},"message":"Passkey registered successfully!"}`,
+		},
+		{
+			name:      "missing credential",
+			inputBody: `{"session_id":"session-001"}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Credential is invalid (required)"]}`,
+		},
+		{
+			name:      "invalid session",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishRegistration", mock.Anything, "user-001", "session-001", credential).Return(nil, service.ErrInvalidSession)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired passkey session"}`,
+		},
+		{
+			name:      "invalid credential",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishRegistration", mock.Anything, "user-001", "session-001", credential).Return(nil, service.ErrInvalidCredential)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"passkey verification failed"}`,
+		},
+		{
+			name:      "passkey already registered",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishRegistration", mock.Anything, "user-001", "session-001", credential).Return(nil, service.ErrPasskeyAlreadyRegistered)
+				return mockSvc
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `{"message":"this passkey is already registered"}`,
+		},
+		{
+			name:         "missing claims",
+			inputBody:    `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupRequest: func(ctx *gin.Context) {},
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"session_id":"session-001","credential":{"id":"credential-001"}}`,
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Set("claims", jwt.MapClaims{"sub": "user-001"})
+			},
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("FinishRegistration", mock.Anything, "user-001", "session-001", credential).Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/self/passkeys/registration/verify", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			tc.setupRequest(ctx)
+
+			passkeyHandler := NewPasskeyHandler(tc.setupMockSvc())
+			passkeyHandler.FinishRegistration(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
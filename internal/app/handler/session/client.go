@@ -0,0 +1,23 @@
+package session
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+)
+
+// WithRequestClient returns the request context carrying the IP address and user-agent of the caller,
+// for login handlers to pass to the service so the issued token gets a session.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request
+//
+// Returns:
+//   - context.Context: The context to pass to the login service
+func WithRequestClient(c *gin.Context) context.Context {
+	return session.WithClient(c, session.Client{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+}
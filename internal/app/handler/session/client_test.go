@@ -0,0 +1,25 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+)
+
+func TestWithRequestClient(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", nil)
+	ctx.Request.RemoteAddr = "203.0.113.10:51234"
+	ctx.Request.Header.Set("User-Agent", "curl/8.4.0")
+
+	client := session.ClientFromContext(WithRequestClient(ctx))
+
+	assert.Equal(t, session.Client{IPAddress: "203.0.113.10", UserAgent: "curl/8.4.0"}, client)
+}
@@ -0,0 +1,39 @@
+// Package session provides HTTP handlers for listing and revoking the login sessions
+// of the current user, using the Gin web framework.
+package session
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+)
+
+// Handler defines the interface for login session HTTP handlers.
+type Handler interface {
+	// ListSessions is a Gin framework handler that lists the devices the authenticated user is logged in from.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListSessions(c *gin.Context)
+
+	// RevokeSession is a Gin framework handler that logs the authenticated user out of a single device.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	RevokeSession(c *gin.Context)
+}
+
+// sessionHandler is the concrete implementation of the Handler interface.
+type sessionHandler struct {
+	sessionSvc session.Service
+}
+
+// NewSessionHandler creates a new instance of the login session handler.
+//
+// Parameters:
+//   - sessionSvc: The service used for login session operations
+//
+// Returns:
+//   - Handler: A new login session handler instance
+func NewSessionHandler(sessionSvc session.Service) Handler {
+	return &sessionHandler{sessionSvc: sessionSvc}
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/session"
+)
+
+type listSessionsResponse struct {
+	Data    []*model.UserSession `json:"data"`
+	Message string               `json:"message"`
+}
+
+// ListSessions lists the devices the authenticated user is logged in from.
+// The session of the request is flagged as current.
+// @Summary      List sessions
+// @Description  List the devices the authenticated user is logged in from
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  listSessionsResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/sessions [get]
+func (h *sessionHandler) ListSessions(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListSessions")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	claims, err := utils.GetJWTClaimsFromRequest(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+	currentSessionID, _ := claims[service.SessionIDClaim].(string)
+
+	sessions, err := h.sessionSvc.ListSessions(c, userID, currentSessionID)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListSessions").
+			Err(err).
+			Msg("service return error when listing sessions")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listSessionsResponse{
+		Data:    sessions,
+		Message: "Sessions retrieved successfully!",
+	})
+}
+
+// RevokeSession logs the authenticated user out of a single device.
+// @Summary      Revoke a session
+// @Description  Log out of a single device, its token is rejected from the next request on
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/sessions/{id} [delete]
+func (h *sessionHandler) RevokeSession(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_RevokeSession")
+	defer s.End()
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = h.sessionSvc.RevokeSession(c, userID, c.Param("id"))
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: "session not found",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "RevokeSession").
+			Err(err).
+			Msg("service return error when revoking session")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Session revoked successfully!",
+	})
+}
@@ -0,0 +1,56 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+)
+
+// ChallengeTokenHeader carries the solution of a bot protection challenge.
+const ChallengeTokenHeader = "X-Challenge-Token"
+
+// challengeResponse rejects a request that needs a solved challenge.
+type challengeResponse struct {
+	Message   string                   `json:"message"`
+	Challenge *botprotection.Challenge `json:"challenge"`
+}
+
+// checkChallenge lets a request through the bot protection, answering 403 with the challenge to solve otherwise.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+//   - action: botprotection.ActionRegister or botprotection.ActionLogin
+//   - username: The username the request is about
+//
+// Returns:
+//   - bool: true if the request can go on
+func (u *userHandler) checkChallenge(c *gin.Context, action, username string) bool {
+	challenge, err := u.botGuard.Check(c, action, challengeClient(c, username))
+	switch {
+	case errors.Is(err, botprotection.ErrChallengeRequired):
+		c.JSON(http.StatusForbidden, &challengeResponse{
+			Message:   "challenge required, send its solution in the " + ChallengeTokenHeader + " header",
+			Challenge: challenge,
+		})
+		return false
+	case errors.Is(err, botprotection.ErrChallengeFailed):
+		c.JSON(http.StatusForbidden, &challengeResponse{
+			Message:   "challenge failed, solve the new one",
+			Challenge: challenge,
+		})
+		return false
+	}
+
+	return true
+}
+
+// challengeClient describes the origin of a request to the bot protection.
+func challengeClient(c *gin.Context, username string) botprotection.Client {
+	return botprotection.Client{
+		IP:       c.ClientIP(),
+		Username: username,
+		Token:    c.GetHeader(ChallengeTokenHeader),
+	}
+}
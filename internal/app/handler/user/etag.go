@@ -0,0 +1,79 @@
+package user
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+)
+
+// formatETag renders the version of a user as the entity tag of its profile.
+//
+// Parameters:
+//   - version: The version of the user.
+//
+// Returns:
+//   - string: The quoted entity tag, e.g. "3".
+func formatETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// parseIfMatch extracts the version an update is based on from the value of an If-Match header.
+// "*" matches whatever the current version is and yields 0. Weak tags and lists of tags are not accepted,
+// as an update must be based on exactly one version.
+//
+// Parameters:
+//   - header: The value of the If-Match header.
+//
+// Returns:
+//   - int: The version named by the header, or 0 for "*".
+//   - bool: false if the header names no single valid version.
+func parseIfMatch(header string) (int, bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return 0, true
+	}
+
+	value, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// requireIfMatch reads the version a profile update is based on from the If-Match header.
+// It responds 428 if the header is missing and 412 if it names no single valid version.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+//
+// Returns:
+//   - int: The version named by the header, or 0 for "*".
+//   - bool: false if a response was already written and the handler must stop.
+func requireIfMatch(c *gin.Context) (int, bool) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, common.Message{
+			Message: "If-Match header is required",
+		})
+		return 0, false
+	}
+
+	version, ok := parseIfMatch(ifMatch)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, common.Message{
+			Message: "If-Match header must be the ETag of the profile",
+		})
+		return 0, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,82 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ParseIfMatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputHeader string
+
+		expectedVersion int
+		expectedOK      bool
+	}{
+		{
+			name: "Strong tag",
+
+			inputHeader: `"3"`,
+
+			expectedVersion: 3,
+			expectedOK:      true,
+		},
+		{
+			name: "Tag produced by formatETag",
+
+			inputHeader: formatETag(42),
+
+			expectedVersion: 42,
+			expectedOK:      true,
+		},
+		{
+			name: "Any version",
+
+			inputHeader: " * ",
+
+			expectedOK: true,
+		},
+		{
+			name: "Missing header",
+		},
+		{
+			name: "Unquoted version",
+
+			inputHeader: "3",
+		},
+		{
+			name: "Weak tag",
+
+			inputHeader: `W/"3"`,
+		},
+		{
+			name: "List of tags",
+
+			inputHeader: `"3", "4"`,
+		},
+		{
+			name: "Not a version",
+
+			inputHeader: `"abc"`,
+		},
+		{
+			name: "Zero version",
+
+			inputHeader: `"0"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			version, ok := parseIfMatch(tc.inputHeader)
+			assert.Equal(t, tc.expectedVersion, version)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package user
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
 )
 
 // Handler defines the interface for user-related HTTP handlers.
@@ -38,11 +39,40 @@ type Handler interface {
 	// Parameters:
 	//   - c: The Gin context containing the HTTP request and response
 	UpdateProfile(c *gin.Context)
+
+	// PatchProfile is a Gin framework handler that partially updates the profile of the authenticated user.
+	// It processes JSON Merge Patch requests and returns a success message or an error.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	PatchProfile(c *gin.Context)
+
+	// ChangeUsername is a Gin framework handler that changes the username of the authenticated user.
+	// It processes HTTP requests and returns a success message or an error.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ChangeUsername(c *gin.Context)
+
+	// ChangePassword is a Gin framework handler that changes the password of the authenticated user.
+	// It processes HTTP requests and returns a success message or an error.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ChangePassword(c *gin.Context)
+
+	// GetUserByUsername is a Gin framework handler that looks up the public profile of a user by username.
+	// It redirects the lookup of a previous username to the current one.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	GetUserByUsername(c *gin.Context)
 }
 
 // userHandler is the concrete implementation of the Handler interface.
 type userHandler struct {
-	userSvc user.Service
+	userSvc  user.Service
+	botGuard botprotection.Guard
 }
 
 // NewUser creates a new instance of the User handler.
@@ -51,9 +81,13 @@ type userHandler struct {
 //
 // Parameters:
 //   - userSvc: The user service used for user-related operations
+//   - botGuard: The bot protection challenging suspicious registrations and logins
 //
 // Returns:
 //   - Handler: A new user handler instance
-func NewUserHandler(userSvc user.Service) Handler {
-	return &userHandler{userSvc: userSvc}
+func NewUserHandler(userSvc user.Service, botGuard botprotection.Guard) Handler {
+	return &userHandler{
+		userSvc:  userSvc,
+		botGuard: botGuard,
+	}
 }
@@ -9,25 +9,44 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	sessionHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/session"
 	service "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
 )
 
 type loginRequest struct {
 	Username string `json:"username" binding:"required" example:"testuser001"`
 	Password string `json:"password" binding:"required,gte=8" example:"my_SECURE_password123@"`
+	// OrganizationID scopes the token to an organization the user belongs to.
+	OrganizationID string `json:"org_id,omitempty" example:"0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01"`
+}
+
+// loginResponse carries the token of a login.
+// When the password expired, the token can only change it.
+type loginResponse struct {
+	Data                   string `json:"data"`
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty"`
+	Message                string `json:"message"`
 }
 
 // Login generates a Gin framework handler that authenticates a user and returns a JWT token.
 // @Summary      User login
-// @Description  Authenticate a user and return a JWT token
+// @Description  Authenticate a user and return a JWT token. When the password expired, password_change_required is
+// @Description  set and the token, valid for 15 minutes, can only change the password through PUT /v1/self/password.
+// @Description  After repeated failed logins of the username or from the address, a solved bot protection
+// @Description  challenge must be sent in the X-Challenge-Token header; without it the login answers 403 with the challenge.
+// @Description  With org_id, the token carries the organization in the org_id claim and the roles of the user in it in the
+// @Description  roles claim; a user who is not a member of the organization gets 403.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        credentials  body      loginRequest  true  "User credentials"
-// @Success      200          {object}  object{data=string,message=string}
-// @Failure      400          {object}  object{message=string}
-// @Failure      401          {object}  object{message=string}
-// @Failure      500          {object}  object{message=string}
+// @Param        credentials        body      loginRequest  true   "User credentials"
+// @Param        X-Challenge-Token  header    string        false  "Solution of the bot protection challenge"
+// @Success      200                {object}  loginResponse
+// @Failure      400                {object}  object{message=string}
+// @Failure      401                {object}  object{message=string}
+// @Failure      403                {object}  challengeResponse
+// @Failure      500                {object}  object{message=string}
 // @Router       /v1/users/login [post]
 func (u *userHandler) Login(c *gin.Context) {
 	// Implementation for user login handler goes here
@@ -41,9 +60,19 @@ func (u *userHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := u.userSvc.Login(c, input.Username, input.Password)
+	if !u.checkChallenge(c, botprotection.ActionLogin, input.Username) {
+		return
+	}
+
+	result, err := u.userSvc.Login(sessionHandler.WithRequestClient(c), input.Username, input.Password, input.OrganizationID)
 	switch {
+	case errors.Is(err, service.ErrNotOrganizationMember):
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: err.Error(),
+		})
+		return
 	case errors.Is(err, service.ErrInvalidCredentials):
+		u.botGuard.RecordFailure(c, botprotection.ActionLogin, challengeClient(c, input.Username))
 		nrTx.Application().RecordCustomEvent("LoginHit", map[string]interface{}{
 			"endpoint":  "GET /v1/users/login",
 			"login_hit": false,
@@ -53,6 +82,7 @@ func (u *userHandler) Login(c *gin.Context) {
 		})
 		return
 	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		u.botGuard.RecordFailure(c, botprotection.ActionLogin, challengeClient(c, input.Username))
 		nrTx.Application().RecordCustomEvent("LoginHit", map[string]interface{}{
 			"endpoint":  "GET /v1/users/login",
 			"login_hit": false,
@@ -77,8 +107,17 @@ func (u *userHandler) Login(c *gin.Context) {
 		"login_hit": true,
 	})
 
-	c.JSON(http.StatusOK, &common.SuccessResponse[string]{
-		Data:    token,
+	if result.PasswordChangeRequired {
+		c.JSON(http.StatusOK, &loginResponse{
+			Data:                   result.Token,
+			PasswordChangeRequired: true,
+			Message:                "Password expired, change it with this token to continue",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, &loginResponse{
+		Data:    result.Token,
 		Message: "Logged in successfully!",
 	})
 }
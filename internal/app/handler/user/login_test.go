@@ -11,7 +11,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/user"
 	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	mockBotProtection "github.com/vukieuhaihoa/user-service/internal/botprotection/mocks"
 )
 
 func TestUser_Login(t *testing.T) {
@@ -23,7 +26,8 @@ func TestUser_Login(t *testing.T) {
 		inputRequest *loginRequest
 		setupRequest func(ctx *gin.Context, inputRequest *loginRequest)
 
-		setupMockSvc func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service
+		setupMockSvc   func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service
+		setupMockGuard func(ctx *gin.Context) *mockBotProtection.Guard
 
 		expectedCode     int
 		expectedResponse string
@@ -41,13 +45,75 @@ func TestUser_Login(t *testing.T) {
 			},
 			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password).
-					Return("mocked-jwt-token", nil)
+				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.OrganizationID).
+					Return(&service.LoginResult{Token: "mocked-jwt-token"}, nil)
 				return mockUserSvc
 			},
 			expectedCode:     http.StatusOK,
 			expectedResponse: `{"data":"mocked-jwt-token","message":"Logged in successfully!"}`,
 		},
+		{
+			name: "expired password",
+			inputRequest: &loginRequest{
+				Username: "testuser",
+				Password: "my_SECURE_password123@",
+			},
+			setupRequest: func(ctx *gin.Context, inputRequest *loginRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.OrganizationID).
+					Return(&service.LoginResult{Token: "restricted-jwt-token", PasswordChangeRequired: true}, nil)
+				return mockUserSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":"restricted-jwt-token","password_change_required":true,"message":"Password expired, change it with this token to continue"}`,
+		},
+		{
+			name: "successful login scoped to an organization",
+			inputRequest: &loginRequest{
+				Username:       "testuser",
+				Password:       "my_SECURE_password123@",
+				OrganizationID: "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01",
+			},
+			setupRequest: func(ctx *gin.Context, inputRequest *loginRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("Login", mock.Anything, "testuser", "my_SECURE_password123@", "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01").
+					Return(&service.LoginResult{Token: "org-jwt-token"}, nil)
+				return mockUserSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":"org-jwt-token","message":"Logged in successfully!"}`,
+		},
+		{
+			name: "not a member of the organization",
+			inputRequest: &loginRequest{
+				Username:       "testuser",
+				Password:       "my_SECURE_password123@",
+				OrganizationID: "0a9e1f00-0002-4c5d-9e8f-1a2b3c4d5e02",
+			},
+			setupRequest: func(ctx *gin.Context, inputRequest *loginRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("Login", mock.Anything, "testuser", "my_SECURE_password123@", "0a9e1f00-0002-4c5d-9e8f-1a2b3c4d5e02").
+					Return(nil, service.ErrNotOrganizationMember)
+				return mockUserSvc
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"you are not a member of this organization"}`,
+		},
 		{
 			name: "invalid request body",
 			inputRequest: &loginRequest{
@@ -95,8 +161,8 @@ func TestUser_Login(t *testing.T) {
 			},
 			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password).
-					Return("", dbutils.ErrRecordNotFoundType)
+				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.OrganizationID).
+					Return(nil, dbutils.ErrRecordNotFoundType)
 				return mockUserSvc
 			},
 			expectedCode:     http.StatusBadRequest,
@@ -115,13 +181,64 @@ func TestUser_Login(t *testing.T) {
 			},
 			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password).
-					Return("", assert.AnError)
+				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.OrganizationID).
+					Return(nil, assert.AnError)
 				return mockUserSvc
 			},
 			expectedCode:     http.StatusInternalServerError,
 			expectedResponse: `{"message":"Internal server error"}`,
 		},
+		{
+			name: "challenge required",
+			inputRequest: &loginRequest{
+				Username: "testuser",
+				Password: "my_SECURE_password123@",
+			},
+			setupRequest: func(ctx *gin.Context, inputRequest *loginRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+			setupMockGuard: func(ctx *gin.Context) *mockBotProtection.Guard {
+				guardMock := mockBotProtection.NewGuard(t)
+				guardMock.On("Check", ctx, botprotection.ActionLogin, botprotection.Client{IP: "192.0.2.1", Username: "testuser"}).
+					Return(&botprotection.Challenge{Provider: botprotection.ProviderHCaptcha}, botprotection.ErrChallengeRequired)
+				return guardMock
+			},
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"challenge required, send its solution in the X-Challenge-Token header","challenge":{"provider":"hcaptcha"}}`,
+		},
+		{
+			name: "failed login recorded",
+			inputRequest: &loginRequest{
+				Username: "testuser",
+				Password: "wrong_password",
+			},
+			setupRequest: func(ctx *gin.Context, inputRequest *loginRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set(ChallengeTokenHeader, "solution")
+			},
+			setupMockSvc: func(ctx *gin.Context, inputRequest *loginRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("Login", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.OrganizationID).
+					Return(nil, service.ErrInvalidCredentials)
+				return mockUserSvc
+			},
+			setupMockGuard: func(ctx *gin.Context) *mockBotProtection.Guard {
+				client := botprotection.Client{IP: "192.0.2.1", Username: "testuser", Token: "solution"}
+				guardMock := mockBotProtection.NewGuard(t)
+				guardMock.On("Check", ctx, botprotection.ActionLogin, client).Return(nil, nil)
+				guardMock.On("RecordFailure", ctx, botprotection.ActionLogin, client).Return().Once()
+				return guardMock
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid username or password"}`,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -133,7 +250,12 @@ func TestUser_Login(t *testing.T) {
 			tc.setupRequest(ctx, tc.inputRequest)
 			mockUserSvc := tc.setupMockSvc(ctx, tc.inputRequest)
 
-			userHandler := NewUserHandler(mockUserSvc)
+			var botGuard botprotection.Guard = botprotection.NewDisabledGuard()
+			if tc.setupMockGuard != nil {
+				botGuard = tc.setupMockGuard(ctx)
+			}
+
+			userHandler := NewUserHandler(mockUserSvc, botGuard)
 			userHandler.Login(ctx)
 
 			assert.Equal(t, tc.expectedCode, rec.Code)
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+)
+
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"my_SECURE_password123@"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,password_strength" example:"my_NEW_password456@"`
+}
+
+// ChangePassword generates a Gin framework handler that changes the password of the authenticated user.
+// @Summary      Change password
+// @Description  Change the password of the authenticated user. The new password must differ from the recent ones.
+// @Description  This is the only route accepting the token returned by a login with an expired password.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        password  body      changePasswordRequest  true  "Current and new password"
+// @Success      200       {object}  object{message=string}
+// @Failure      400       {object}  object{message=string}
+// @Failure      401       {object}  object{message=string}
+// @Failure      403       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/password [put]
+func (u *userHandler) ChangePassword(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ChangePassword")
+	defer s.End()
+
+	input := &changePasswordRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	err = u.userSvc.ChangePassword(c, userID, input.CurrentPassword, input.NewPassword)
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	case errors.Is(err, user.ErrInvalidCredentials):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "current password is incorrect",
+		})
+		return
+	case errors.Is(err, user.ErrPasswordReused):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "password was used recently, choose another one",
+		})
+		return
+	case errors.Is(err, breach.ErrBreached):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "password has appeared in a data breach, choose another one",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "ChangePassword").
+			Err(err).
+			Msg("service return error when change password")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Password changed successfully!",
+	})
+}
@@ -0,0 +1,151 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+)
+
+func TestHandler_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody string
+
+		setupMockSvc func(ctx *gin.Context) *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "successful change password",
+
+			inputBody: `{"current_password":"my_SECURE_password123@","new_password":"my_NEW_password456@"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangePassword", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", "my_SECURE_password123@", "my_NEW_password456@").Return(nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Password changed successfully!"}`,
+		},
+		{
+			name: "weak new password",
+
+			inputBody: `{"current_password":"my_SECURE_password123@","new_password":"shortshort"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["NewPassword is invalid (password_strength)"]}`,
+		},
+		{
+			name: "wrong current password",
+
+			inputBody: `{"current_password":"wrong","new_password":"my_NEW_password456@"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangePassword", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", "wrong", "my_NEW_password456@").Return(user.ErrInvalidCredentials)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"current password is incorrect"}`,
+		},
+		{
+			name: "password used recently",
+
+			inputBody: `{"current_password":"my_SECURE_password123@","new_password":"my_NEW_password456@"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangePassword", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", "my_SECURE_password123@", "my_NEW_password456@").Return(user.ErrPasswordReused)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"password was used recently, choose another one"}`,
+		},
+		{
+			name: "breached password",
+
+			inputBody: `{"current_password":"my_SECURE_password123@","new_password":"my_NEW_password456@"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangePassword", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", "my_SECURE_password123@", "my_NEW_password456@").Return(breach.ErrBreached)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"password has appeared in a data breach, choose another one"}`,
+		},
+		{
+			name: "user not found",
+
+			inputBody: `{"current_password":"my_SECURE_password123@","new_password":"my_NEW_password456@"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangePassword", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", "my_SECURE_password123@", "my_NEW_password456@").Return(dbutils.ErrRecordNotFoundType)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "service layer error",
+
+			inputBody: `{"current_password":"my_SECURE_password123@","new_password":"my_NEW_password456@"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangePassword", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", "my_SECURE_password123@", "my_NEW_password456@").Return(assert.AnError)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/password", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("claims", jwt.MapClaims{
+				"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+			})
+			mockUserSvc := tc.setupMockSvc(ctx)
+
+			userHandler := NewUserHandler(mockUserSvc, botprotection.NewDisabledGuard())
+			userHandler.ChangePassword(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
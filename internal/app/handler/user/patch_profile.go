@@ -0,0 +1,176 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+)
+
+// mergePatchContentType is the media type of JSON Merge Patch documents (RFC 7396).
+const mergePatchContentType = "application/merge-patch+json"
+
+// patchableProfileFields lists the profile fields a merge patch may supply.
+var patchableProfileFields = []string{"display_name", "email"}
+
+// patchProfileRequest is a JSON Merge Patch of the profile. Only the supplied fields are validated and updated.
+type patchProfileRequest struct {
+	DisplayName *string `json:"display_name,omitempty" binding:"omitempty,min=1" example:"Patched User 001"`
+	Email       *string `json:"email,omitempty" binding:"omitempty,email" example:"patchedtestuser001@example.com"`
+}
+
+// PatchProfile generates a Gin framework handler that partially updates the profile of the authenticated user.
+// @Summary      Partially update user profile
+// @Description  Update the supplied fields of the profile of the authenticated user, as a JSON Merge Patch.
+// @Description  Fields left out are unchanged; fields cannot be removed with null. The If-Match header must carry
+// @Description  the ETag of the profile the update is based on.
+// @Tags         Users
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        If-Match  header    string               true  "ETag of the profile the update is based on"
+// @Param        profile   body      patchProfileRequest  true  "Profile fields to update"
+// @Success      200       {object}  object{message=string}
+// @Header       200       {string}  ETag  "Version of the updated profile"
+// @Failure      400       {object}  object{message=string,details=[]string}
+// @Failure      401       {object}  object{message=string}
+// @Failure      412       {object}  object{message=string}
+// @Failure      415       {object}  object{message=string}
+// @Failure      428       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/info [patch]
+func (u *userHandler) PatchProfile(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_PatchProfile")
+	defer s.End()
+
+	if contentType := c.ContentType(); contentType != mergePatchContentType && contentType != binding.MIMEJSON {
+		c.JSON(http.StatusUnsupportedMediaType, common.Message{
+			Message: "Content-Type must be " + mergePatchContentType,
+		})
+		return
+	}
+
+	patch, details, err := parseProfilePatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+	if len(details) > 0 {
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "Invalid input fields",
+			Details: details,
+		})
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	update, err := u.userSvc.PatchUserByID(c, userID, version, &user.ProfilePatch{
+		DisplayName: patch.DisplayName,
+		Email:       patch.Email,
+	})
+	switch {
+	case errors.Is(err, user.ErrEmptyPatch):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "at least one field must be supplied",
+		})
+		return
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "email already exists",
+		})
+		return
+	case emailpolicy.IsRejected(err):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, user.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, common.Message{
+			Message: "profile was modified since it was read, fetch it again and retry",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "PatchProfile").
+			Err(err).
+			Msg("service return error when patch user profile")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.Header("ETag", formatETag(update.Version))
+	c.JSON(http.StatusOK, profileUpdatedResponse(update))
+}
+
+// parseProfilePatch decodes and validates the merge patch in the request body.
+// The fields supplied in the document form the mask of the update: unknown fields and null values,
+// which would remove a required field, are reported as details rather than ignored.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request
+//
+// Returns:
+//   - *patchProfileRequest: The decoded patch.
+//   - []string: The fields that cannot be patched, sorted by name.
+//   - error: An error if the body is not a JSON object or a supplied field fails validation.
+func parseProfilePatch(c *gin.Context) (*patchProfileRequest, []string, error) {
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, nil, err
+	}
+
+	var details []string
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		switch {
+		case !slices.Contains(patchableProfileFields, name):
+			details = append(details, name+" cannot be updated")
+		case string(fields[name]) == "null":
+			details = append(details, name+" cannot be removed")
+		}
+	}
+	if len(details) > 0 {
+		return nil, details, nil
+	}
+
+	patch := &patchProfileRequest{}
+	if err := json.Unmarshal(body, patch); err != nil {
+		return nil, nil, err
+	}
+
+	if err := binding.Validator.ValidateStruct(patch); err != nil {
+		return nil, nil, err
+	}
+
+	return patch, nil, nil
+}
@@ -0,0 +1,306 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+)
+
+func TestHandler_PatchProfile(t *testing.T) {
+	t.Parallel()
+
+	displayName := "Patched User"
+	email := "patcheduser@example.com"
+
+	testCases := []struct {
+		name string
+
+		inputBody        string
+		inputContentType string
+		inputIfMatch     string
+		authenticated    bool
+
+		setupMockSvc func(ctx *gin.Context) *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+		expectedETag     string
+	}{
+		{
+			name: "successful patch of one field",
+
+			inputBody:        `{"display_name":"Patched User"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{
+					DisplayName: &displayName,
+				}).Return(&user.ProfileUpdate{Version: 4}, nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Edit current user successfully!"}`,
+			expectedETag:     `"4"`,
+		},
+		{
+			name: "successful patch of every field as plain JSON",
+
+			inputBody:        `{"display_name":"Patched User","email":"patcheduser@example.com"}`,
+			inputContentType: "application/json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{
+					DisplayName: &displayName,
+					Email:       &email,
+				}).Return(&user.ProfileUpdate{Version: 4, EmailChangePending: true}, nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Edit current user successfully! Confirm the new email address from the link sent to it"}`,
+			expectedETag:     `"4"`,
+		},
+		{
+			name: "unsupported content type",
+
+			inputBody:        `display_name=Patched`,
+			inputContentType: "application/x-www-form-urlencoded",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusUnsupportedMediaType,
+			expectedResponse: `{"message":"Content-Type must be application/merge-patch+json"}`,
+		},
+		{
+			name: "body is not an object",
+
+			inputBody:        `["display_name"]`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input"}`,
+		},
+		{
+			name: "unknown and removed fields",
+
+			inputBody:        `{"username":"hacker","email":null,"display_name":"Patched User"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["email cannot be removed","username cannot be updated"]}`,
+		},
+		{
+			name: "invalid supplied field",
+
+			inputBody:        `{"email":"not-an-email"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Email is invalid (email)"]}`,
+		},
+		{
+			name: "empty display name",
+
+			inputBody:        `{"display_name":""}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["DisplayName is invalid (min)"]}`,
+		},
+		{
+			name: "empty patch",
+
+			inputBody:        `{}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{}).
+					Return(nil, user.ErrEmptyPatch)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"at least one field must be supplied"}`,
+		},
+		{
+			name: "unauthenticated request",
+
+			inputBody:        `{"display_name":"Patched User"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusUnauthorized,
+			expectedResponse: `{"message":"Unauthorized"}`,
+		},
+		{
+			name: "missing If-Match header",
+
+			inputBody:        `{"display_name":"Patched User"}`,
+			inputContentType: "application/merge-patch+json",
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusPreconditionRequired,
+			expectedResponse: `{"message":"If-Match header is required"}`,
+		},
+		{
+			name: "profile modified since it was read",
+
+			inputBody:        `{"display_name":"Patched User"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{
+					DisplayName: &displayName,
+				}).Return(nil, user.ErrVersionConflict)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusPreconditionFailed,
+			expectedResponse: `{"message":"profile was modified since it was read, fetch it again and retry"}`,
+		},
+		{
+			name: "duplicate email",
+
+			inputBody:        `{"email":"patcheduser@example.com"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{
+					Email: &email,
+				}).Return(nil, dbutils.ErrDuplicationType)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"email already exists"}`,
+		},
+		{
+			name: "email rejected by the policy",
+
+			inputBody:        `{"email":"patcheduser@example.com"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{
+					Email: &email,
+				}).Return(nil, emailpolicy.ErrNoMailServer)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"email domain does not receive email"}`,
+		},
+		{
+			name: "service layer error",
+
+			inputBody:        `{"display_name":"Patched User"}`,
+			inputContentType: "application/merge-patch+json",
+			inputIfMatch:     `"3"`,
+			authenticated:    true,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("PatchUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, &user.ProfilePatch{
+					DisplayName: &displayName,
+				}).Return(nil, assert.AnError)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			ctx.Request = httptest.NewRequest(http.MethodPatch, "/v1/self/info", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", tc.inputContentType)
+			if tc.inputIfMatch != "" {
+				ctx.Request.Header.Set("If-Match", tc.inputIfMatch)
+			}
+			if tc.authenticated {
+				ctx.Set("claims", jwt.MapClaims{
+					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+				})
+			}
+			mockUserSvc := tc.setupMockSvc(ctx)
+
+			userHandler := NewUserHandler(mockUserSvc, botprotection.NewDisabledGuard())
+			userHandler.PatchProfile(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
+		})
+	}
+}
@@ -10,13 +10,20 @@ import (
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
 )
 
 type createUserRequest struct {
-	Username    string `json:"username" binding:"required" example:"testuser001"`
+	Username    string `json:"username" binding:"required,username_policy" example:"testuser001"`
 	Password    string `json:"password" binding:"required,min=8,password_strength" example:"my_SECURE_password123@"`
 	DisplayName string `json:"display_name" binding:"required" example:"Test User"`
 	Email       string `json:"email" binding:"required,email" example:"testuser001@example.com"`
+	// InvitationToken is the token of the invitation link; it is required when registration is invite-only
+	InvitationToken string `json:"invitation_token" example:"k3Jd9sLq0aZx7VbN2mYc5RtW8pUe4HfG"`
 }
 
 type createUserResponse struct {
@@ -26,14 +33,21 @@ type createUserResponse struct {
 
 // CreateUser generates a Gin framework handler that creates a new user.
 // @Summary      Create a new user
-// @Description  Create a new user with the provided information
+// @Description  Create a new user with the provided information. After several registrations from the address, a
+// @Description  solved bot protection challenge must be sent in the X-Challenge-Token header; without it the
+// @Description  registration answers 403 with the challenge.
+// @Description  Depending on the registration mode, registrations without the token of an invitation or with an
+// @Description  email outside the allowed domains are refused with 403. The token of an invitation is consumed by
+// @Description  the registration and grants the role of the invitation.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        user  body      createUserRequest  true  "User to create"
-// @Success      201   {object}  createUserResponse
-// @Failure      400   {object}  object{message=string}
-// @Failure      500   {object}  object{message=string}
+// @Param        user               body      createUserRequest  true   "User to create"
+// @Param        X-Challenge-Token  header    string             false  "Solution of the bot protection challenge"
+// @Success      201                {object}  createUserResponse
+// @Failure      400                {object}  object{message=string}
+// @Failure      403                {object}  challengeResponse  "Bot protection challenge, or {message} when the registration mode refuses the registration"
+// @Failure      500                {object}  object{message=string}
 // @Router       /v1/users/register [post]
 func (u *userHandler) CreateUser(c *gin.Context) {
 	nrTx := newrelic.FromContext(c)
@@ -46,13 +60,37 @@ func (u *userHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	createdUser, err := u.userSvc.CreateUser(c, input.Username, input.Password, input.DisplayName, input.Email)
+	if !u.checkChallenge(c, botprotection.ActionRegister, input.Username) {
+		return
+	}
+
+	createdUser, err := u.userSvc.CreateUser(c, input.Username, input.Password, input.DisplayName, input.Email, input.InvitationToken)
 	switch {
 	case errors.Is(err, dbutils.ErrDuplicationType):
 		c.JSON(http.StatusBadRequest, common.Message{
 			Message: "username or email already exists",
 		})
 		return
+	case emailpolicy.IsRejected(err):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case registration.IsRejected(err):
+		c.JSON(http.StatusForbidden, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, invitation.ErrInvalidInvitation), errors.Is(err, invitation.ErrInvitationEmailMismatch):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, breach.ErrBreached):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "password has appeared in a data breach, choose another one",
+		})
+		return
 	case errors.Is(err, nil):
 	default:
 		log.Error().
@@ -15,7 +15,14 @@ import (
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/validators"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
 	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	mockBotProtection "github.com/vukieuhaihoa/user-service/internal/botprotection/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 )
 
@@ -23,6 +30,7 @@ func init() {
 	// Register custom validators before running tests
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("password_strength", validators.PasswordStrength)
+		v.RegisterValidation("username_policy", usernamepolicy.Validate)
 	}
 }
 
@@ -36,7 +44,8 @@ func TestHandler_CreateUser(t *testing.T) {
 
 		setupRequest func(ctx *gin.Context, inputRequest *createUserRequest)
 
-		setupMockSvc func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service
+		setupMockSvc   func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service
+		setupMockGuard func(ctx *gin.Context) *mockBotProtection.Guard
 
 		expectedCode     int
 		expectedResponse string
@@ -59,11 +68,12 @@ func TestHandler_CreateUser(t *testing.T) {
 
 			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("CreateUser", ctx, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email).
+				mockUserSvc.On("CreateUser", ctx, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, inputRequest.InvitationToken).
 					Return(&model.User{
 						Username:    inputRequest.Username,
 						DisplayName: inputRequest.DisplayName,
 						Email:       inputRequest.Email,
+						Version:     1,
 						Base: model.Base{
 							ID:        "de305d54-75b4-431b-adb2-eb6b9e546099",
 							CreatedAt: fixture.TestTime,
@@ -74,7 +84,7 @@ func TestHandler_CreateUser(t *testing.T) {
 			},
 
 			expectedCode:     http.StatusCreated,
-			expectedResponse: `{"data":{"id":"de305d54-75b4-431b-adb2-eb6b9e546099","created_at":"2023-01-01T00:00:00Z","updated_at":"2023-01-01T00:00:00Z","username":"testuser","email":"testuser@example.com","display_name":"Test User"},"message":"Register an user successfully!"}`,
+			expectedResponse: `{"data":{"id":"de305d54-75b4-431b-adb2-eb6b9e546099","created_at":"2023-01-01T00:00:00Z","updated_at":"2023-01-01T00:00:00Z","username":"testuser","email":"testuser@example.com","display_name":"Test User","version":1},"message":"Register an user successfully!"}`,
 		},
 		{
 			name: "invalid request body",
@@ -122,6 +132,29 @@ func TestHandler_CreateUser(t *testing.T) {
 			expectedCode:     http.StatusBadRequest,
 			expectedResponse: `{"message":"Invalid input fields","details":["Password is invalid (password_strength)"]}`,
 		},
+		{
+			name: "invalid request body - username not allowed by the policy",
+
+			inputRequest: &createUserRequest{
+				Username:    "test user",
+				Password:    "my_SECURE_password123@",
+				DisplayName: "Test User",
+				Email:       "testuser@gmail.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Username is invalid (username_policy)"]}`,
+		},
 		{
 			name: "duplicate username or email",
 
@@ -140,7 +173,7 @@ func TestHandler_CreateUser(t *testing.T) {
 
 			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email).
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, inputRequest.InvitationToken).
 					Return(nil, dbutils.ErrDuplicationType)
 				return mockUserSvc
 			},
@@ -148,6 +181,138 @@ func TestHandler_CreateUser(t *testing.T) {
 			expectedCode:     http.StatusBadRequest,
 			expectedResponse: `{"message":"username or email already exists"}`,
 		},
+		{
+			name: "breached password",
+
+			inputRequest: &createUserRequest{
+				Username:    "testuser",
+				Password:    "Password123!",
+				DisplayName: "Test User",
+				Email:       "testuser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, inputRequest.InvitationToken).
+					Return(nil, breach.ErrBreached)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"password has appeared in a data breach, choose another one"}`,
+		},
+		{
+			name: "email rejected by the policy",
+
+			inputRequest: &createUserRequest{
+				Username:    "testuser",
+				Password:    "my_SECURE_password123@",
+				DisplayName: "Test User",
+				Email:       "testuser@mailinator.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, inputRequest.InvitationToken).
+					Return(nil, emailpolicy.ErrDisposable)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"disposable email addresses are not accepted"}`,
+		},
+		{
+			name: "registration refused by the registration mode",
+
+			inputRequest: &createUserRequest{
+				Username:    "testuser",
+				Password:    "my_SECURE_password123@",
+				DisplayName: "Test User",
+				Email:       "testuser@gmail.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, inputRequest.InvitationToken).
+					Return(nil, registration.ErrInvitationRequired)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"registration requires an invitation"}`,
+		},
+		{
+			name: "invalid invitation",
+
+			inputRequest: &createUserRequest{
+				Username:        "testuser",
+				Password:        "my_SECURE_password123@",
+				DisplayName:     "Test User",
+				Email:           "testuser@gmail.com",
+				InvitationToken: "used-invitation-token",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, "used-invitation-token").
+					Return(nil, invitation.ErrInvalidInvitation)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired invitation"}`,
+		},
+		{
+			name: "invitation sent to another email address",
+
+			inputRequest: &createUserRequest{
+				Username:        "testuser",
+				Password:        "my_SECURE_password123@",
+				DisplayName:     "Test User",
+				Email:           "testuser@gmail.com",
+				InvitationToken: "invitation-token",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, "invitation-token").
+					Return(nil, invitation.ErrInvitationEmailMismatch)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invitation was sent to another email address"}`,
+		},
 		{
 			name: "service layer error",
 
@@ -166,7 +331,7 @@ func TestHandler_CreateUser(t *testing.T) {
 
 			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email).
+				mockUserSvc.On("CreateUser", mock.Anything, inputRequest.Username, inputRequest.Password, inputRequest.DisplayName, inputRequest.Email, inputRequest.InvitationToken).
 					Return(nil, assert.AnError)
 				return mockUserSvc
 			},
@@ -174,6 +339,65 @@ func TestHandler_CreateUser(t *testing.T) {
 			expectedCode:     http.StatusInternalServerError,
 			expectedResponse: `{"message":"Internal server error"}`,
 		},
+		{
+			name: "challenge required",
+
+			inputRequest: &createUserRequest{
+				Username:    "testuser",
+				Password:    "my_SECURE_password123@",
+				DisplayName: "Test User",
+				Email:       "testuser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+			setupMockGuard: func(ctx *gin.Context) *mockBotProtection.Guard {
+				guardMock := mockBotProtection.NewGuard(t)
+				guardMock.On("Check", ctx, botprotection.ActionRegister, botprotection.Client{IP: "192.0.2.1", Username: "testuser"}).
+					Return(&botprotection.Challenge{Provider: botprotection.ProviderTurnstile}, botprotection.ErrChallengeRequired)
+				return guardMock
+			},
+
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"challenge required, send its solution in the X-Challenge-Token header","challenge":{"provider":"turnstile"}}`,
+		},
+		{
+			name: "challenge failed",
+
+			inputRequest: &createUserRequest{
+				Username:    "testuser",
+				Password:    "my_SECURE_password123@",
+				DisplayName: "Test User",
+				Email:       "testuser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *createUserRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set(ChallengeTokenHeader, "wrong-solution")
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *createUserRequest) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+			setupMockGuard: func(ctx *gin.Context) *mockBotProtection.Guard {
+				guardMock := mockBotProtection.NewGuard(t)
+				guardMock.On("Check", ctx, botprotection.ActionRegister, botprotection.Client{IP: "192.0.2.1", Username: "testuser", Token: "wrong-solution"}).
+					Return(&botprotection.Challenge{Provider: botprotection.ProviderProofOfWork, Challenge: "next-challenge", Difficulty: 20}, botprotection.ErrChallengeFailed)
+				return guardMock
+			},
+
+			expectedCode:     http.StatusForbidden,
+			expectedResponse: `{"message":"challenge failed, solve the new one","challenge":{"provider":"pow","challenge":"next-challenge","difficulty":20}}`,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -185,7 +409,12 @@ func TestHandler_CreateUser(t *testing.T) {
 			tc.setupRequest(ctx, tc.inputRequest)
 			mockUserSvc := tc.setupMockSvc(ctx, tc.inputRequest)
 
-			userHandler := NewUserHandler(mockUserSvc)
+			var botGuard botprotection.Guard = botprotection.NewDisabledGuard()
+			if tc.setupMockGuard != nil {
+				botGuard = tc.setupMockGuard(ctx)
+			}
+
+			userHandler := NewUserHandler(mockUserSvc, botGuard)
 			userHandler.CreateUser(ctx)
 
 			assert.Equal(t, tc.expectedCode, rec.Code)
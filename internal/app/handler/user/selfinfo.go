@@ -10,6 +10,8 @@ import (
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
 )
 
 // GetProfile generates a Gin framework handler that retrieves the profile of the authenticated user.
@@ -18,6 +20,7 @@ import (
 // @Tags         Users
 // @Produce      json
 // @Success      200  {object}  createUserResponse
+// @Header       200  {string}  ETag  "Version of the profile, to send as If-Match when updating it"
 // @Failure      401  {object}  object{message=string}
 // @Failure      500  {object}  object{message=string}
 // @Security     Bearer
@@ -48,6 +51,7 @@ func (u *userHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", formatETag(user.Version))
 	c.JSON(http.StatusOK, &createUserResponse{
 		Data:    user,
 		Message: "User profile retrieved successfully!",
@@ -61,15 +65,20 @@ type updateProfileRequest struct {
 
 // UpdateProfile generates a Gin framework handler that updates the profile of the authenticated user.
 // @Summary      Update user profile
-// @Description  Update the profile of the authenticated user
+// @Description  Update the profile of the authenticated user. The If-Match header must carry the ETag of the profile
+// @Description  the update is based on; the update is rejected if the profile was modified since.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        profile  body      updateProfileRequest  true  "Updated user profile"
-// @Success      200      {object}  object{message=string}
-// @Failure      400      {object}  object{message=string}
-// @Failure      401      {object}  object{message=string}
-// @Failure      500      {object}  object{message=string}
+// @Param        If-Match  header    string                true  "ETag of the profile the update is based on"
+// @Param        profile   body      updateProfileRequest  true  "Updated user profile"
+// @Success      200       {object}  object{message=string}
+// @Header       200       {string}  ETag  "Version of the updated profile"
+// @Failure      400       {object}  object{message=string}
+// @Failure      401       {object}  object{message=string}
+// @Failure      412       {object}  object{message=string}
+// @Failure      428       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
 // @Security     Bearer
 // @Router       /v1/self/info [put]
 func (u *userHandler) UpdateProfile(c *gin.Context) {
@@ -86,16 +95,31 @@ func (u *userHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	err = u.userSvc.UpdateUserByID(c, userID, input.DisplayName, input.Email)
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	update, err := u.userSvc.UpdateUserByID(c, userID, version, input.DisplayName, input.Email)
 	switch {
 	case errors.Is(err, dbutils.ErrRecordNotFoundType):
 		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
 		return
+	case errors.Is(err, user.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, common.Message{
+			Message: "profile was modified since it was read, fetch it again and retry",
+		})
+		return
 	case errors.Is(err, dbutils.ErrDuplicationType):
 		c.JSON(http.StatusBadRequest, common.Message{
 			Message: "email already exists",
 		})
 		return
+	case emailpolicy.IsRejected(err):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
 	case errors.Is(err, nil):
 	default:
 		log.Error().
@@ -106,7 +130,19 @@ func (u *userHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, common.Message{
+	c.Header("ETag", formatETag(update.Version))
+	c.JSON(http.StatusOK, profileUpdatedResponse(update))
+}
+
+// profileUpdatedResponse builds the response of a successful profile update, pointing out a pending email change.
+func profileUpdatedResponse(update *user.ProfileUpdate) common.Message {
+	if update.EmailChangePending {
+		return common.Message{
+			Message: "Edit current user successfully! Confirm the new email address from the link sent to it",
+		}
+	}
+
+	return common.Message{
 		Message: "Edit current user successfully!",
-	})
+	}
 }
@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
 	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 )
 
@@ -29,6 +32,7 @@ func TestHandler_GetProfile(t *testing.T) {
 
 		expectedCode     int
 		expectedResponse string
+		expectedETag     string
 	}{
 		{
 			name: "successful get profile",
@@ -54,12 +58,14 @@ func TestHandler_GetProfile(t *testing.T) {
 						Username:    "testuser",
 						Email:       "testuser@example.com",
 						DisplayName: "Test User",
+						Version:     3,
 					}, nil)
 				return mockUserSvc
 			},
 
 			expectedCode:     http.StatusOK,
-			expectedResponse: `{"data":{"id":"de305d54-75b4-431b-adb2-eb6b9e546099","created_at":"2023-01-01T00:00:00Z","updated_at":"2023-01-01T00:00:00Z","username":"testuser","email":"testuser@example.com","display_name":"Test User"},"message":"User profile retrieved successfully!"}`,
+			expectedResponse: `{"data":{"id":"de305d54-75b4-431b-adb2-eb6b9e546099","created_at":"2023-01-01T00:00:00Z","updated_at":"2023-01-01T00:00:00Z","username":"testuser","email":"testuser@example.com","display_name":"Test User","version":3},"message":"User profile retrieved successfully!"}`,
+			expectedETag:     `"3"`,
 		},
 		{
 			name: "unauthenticated request",
@@ -152,11 +158,12 @@ func TestHandler_GetProfile(t *testing.T) {
 			tc.setupRequest(ctx)
 			mockUserSvc := tc.setupMockSvc(ctx)
 
-			userHandler := NewUserHandler(mockUserSvc)
+			userHandler := NewUserHandler(mockUserSvc, botprotection.NewDisabledGuard())
 			userHandler.GetProfile(ctx)
 
 			assert.Equal(t, tc.expectedCode, rec.Code)
 			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
 		})
 	}
 }
@@ -175,6 +182,7 @@ func TestHandler_UpdateProfile(t *testing.T) {
 
 		expectedCode     int
 		expectedResponse string
+		expectedETag     string
 	}{
 		{
 			name: "successful update profile",
@@ -188,6 +196,7 @@ func TestHandler_UpdateProfile(t *testing.T) {
 				reqBody, _ := json.Marshal(inputRequest)
 				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
 				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `"3"`)
 				// Simulate authenticated user by setting userID in context
 				ctx.Set("claims", jwt.MapClaims{
 					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
@@ -196,13 +205,149 @@ func TestHandler_UpdateProfile(t *testing.T) {
 
 			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", inputRequest.DisplayName, inputRequest.Email).
-					Return(nil)
+				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, inputRequest.DisplayName, inputRequest.Email).
+					Return(&user.ProfileUpdate{Version: 4}, nil)
 				return mockUserSvc
 			},
 
 			expectedCode:     http.StatusOK,
 			expectedResponse: `{"message":"Edit current user successfully!"}`,
+			expectedETag:     `"4"`,
+		},
+		{
+			name: "successful update profile with a new email",
+
+			inputRequest: &updateProfileRequest{
+				DisplayName: "Updated User",
+				Email:       "updateduser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *updateProfileRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `"3"`)
+				// Simulate authenticated user by setting userID in context
+				ctx.Set("claims", jwt.MapClaims{
+					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+				})
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, inputRequest.DisplayName, inputRequest.Email).
+					Return(&user.ProfileUpdate{Version: 4, EmailChangePending: true}, nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Edit current user successfully! Confirm the new email address from the link sent to it"}`,
+			expectedETag:     `"4"`,
+		},
+		{
+			name: "missing If-Match header",
+
+			inputRequest: &updateProfileRequest{
+				DisplayName: "Updated User",
+				Email:       "updateduser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *updateProfileRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Set("claims", jwt.MapClaims{
+					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+				})
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusPreconditionRequired,
+			expectedResponse: `{"message":"If-Match header is required"}`,
+		},
+		{
+			name: "invalid If-Match header",
+
+			inputRequest: &updateProfileRequest{
+				DisplayName: "Updated User",
+				Email:       "updateduser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *updateProfileRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `W/"3"`)
+				ctx.Set("claims", jwt.MapClaims{
+					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+				})
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+
+			expectedCode:     http.StatusPreconditionFailed,
+			expectedResponse: `{"message":"If-Match header must be the ETag of the profile"}`,
+		},
+		{
+			name: "profile modified since it was read",
+
+			inputRequest: &updateProfileRequest{
+				DisplayName: "Updated User",
+				Email:       "updateduser@example.com",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *updateProfileRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `"3"`)
+				ctx.Set("claims", jwt.MapClaims{
+					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+				})
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, inputRequest.DisplayName, inputRequest.Email).
+					Return(nil, user.ErrVersionConflict)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusPreconditionFailed,
+			expectedResponse: `{"message":"profile was modified since it was read, fetch it again and retry"}`,
+		},
+		{
+			name: "email rejected by the policy",
+
+			inputRequest: &updateProfileRequest{
+				DisplayName: "Updated User",
+				Email:       "updateduser@blocked.example",
+			},
+
+			setupRequest: func(ctx *gin.Context, inputRequest *updateProfileRequest) {
+				reqBody, _ := json.Marshal(inputRequest)
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
+				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `"3"`)
+				ctx.Set("claims", jwt.MapClaims{
+					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+				})
+			},
+
+			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, inputRequest.DisplayName, inputRequest.Email).
+					Return(nil, emailpolicy.ErrDenied)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"email domain is not accepted"}`,
 		},
 		{
 			name: "unauthenticated request",
@@ -263,6 +408,7 @@ func TestHandler_UpdateProfile(t *testing.T) {
 				reqBody, _ := json.Marshal(inputRequest)
 				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
 				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `"3"`)
 				// Simulate authenticated user by setting userID in context
 				ctx.Set("claims", jwt.MapClaims{
 					"sub": "nonexistent-user-id",
@@ -271,8 +417,8 @@ func TestHandler_UpdateProfile(t *testing.T) {
 
 			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("UpdateUserByID", ctx, "nonexistent-user-id", inputRequest.DisplayName, inputRequest.Email).
-					Return(dbutils.ErrRecordNotFoundType)
+				mockUserSvc.On("UpdateUserByID", ctx, "nonexistent-user-id", 3, inputRequest.DisplayName, inputRequest.Email).
+					Return(nil, dbutils.ErrRecordNotFoundType)
 				return mockUserSvc
 			},
 
@@ -291,6 +437,7 @@ func TestHandler_UpdateProfile(t *testing.T) {
 				reqBody, _ := json.Marshal(inputRequest)
 				ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(string(reqBody)))
 				ctx.Request.Header.Set("Content-Type", "application/json")
+				ctx.Request.Header.Set("If-Match", `"3"`)
 				// Simulate authenticated user by setting userID in context
 				ctx.Set("claims", jwt.MapClaims{
 					"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
@@ -299,8 +446,8 @@ func TestHandler_UpdateProfile(t *testing.T) {
 
 			setupMockSvc: func(ctx *gin.Context, inputRequest *updateProfileRequest) *svcMocks.Service {
 				mockUserSvc := svcMocks.NewService(t)
-				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", inputRequest.DisplayName, inputRequest.Email).
-					Return(assert.AnError)
+				mockUserSvc.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, inputRequest.DisplayName, inputRequest.Email).
+					Return(nil, assert.AnError)
 				return mockUserSvc
 			},
 
@@ -319,11 +466,12 @@ func TestHandler_UpdateProfile(t *testing.T) {
 			tc.setupRequest(ctx, tc.inputRequest)
 			mockUserSvc := tc.setupMockSvc(ctx, tc.inputRequest)
 
-			userHandler := NewUserHandler(mockUserSvc)
+			userHandler := NewUserHandler(mockUserSvc, botprotection.NewDisabledGuard())
 			userHandler.UpdateProfile(ctx)
 
 			assert.Equal(t, tc.expectedCode, rec.Code)
 			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
 		})
 	}
 }
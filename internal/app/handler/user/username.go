@@ -0,0 +1,166 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+)
+
+type changeUsernameRequest struct {
+	Username string `json:"username" binding:"required,username_policy" example:"testuser002"`
+}
+
+// publicProfile is the part of a profile anyone can look up by username.
+type publicProfile struct {
+	ID          string `json:"id" example:"4d9326d6-980c-4c62-9709-dbc70a82cbfe"`
+	Username    string `json:"username" example:"testuser001"`
+	DisplayName string `json:"display_name" example:"Test User"`
+}
+
+type publicProfileResponse struct {
+	Data    *publicProfile `json:"data"`
+	Message string         `json:"message"`
+}
+
+// ChangeUsername generates a Gin framework handler that changes the username of the authenticated user.
+// @Summary      Change username
+// @Description  Change the username of the authenticated user, at most once every 30 days. The old username stays
+// @Description  reserved for the user for 90 days and looking it up redirects to the new one. The If-Match header
+// @Description  must carry the ETag of the profile the change is based on.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        If-Match  header    string                 true  "ETag of the profile the change is based on"
+// @Param        username  body      changeUsernameRequest  true  "New username"
+// @Success      200       {object}  object{message=string}
+// @Header       200       {string}  ETag  "Version of the updated profile"
+// @Failure      400       {object}  object{message=string}
+// @Failure      401       {object}  object{message=string}
+// @Failure      412       {object}  object{message=string}
+// @Failure      428       {object}  object{message=string}
+// @Failure      429       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Security     Bearer
+// @Router       /v1/self/username [put]
+func (u *userHandler) ChangeUsername(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ChangeUsername")
+	defer s.End()
+
+	input := &changeUsernameRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	userID, err := utils.GetUserIDFromJWTClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	}
+
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	newVersion, err := u.userSvc.ChangeUsername(c, userID, version, input.Username)
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusUnauthorized, common.UnauthorizedResponse)
+		return
+	case errors.Is(err, user.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, common.Message{
+			Message: "profile was modified since it was read, fetch it again and retry",
+		})
+		return
+	case errors.Is(err, user.ErrUsernameUnchanged):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, user.ErrUsernameCooldown):
+		c.JSON(http.StatusTooManyRequests, common.Message{
+			Message: "the username can only be changed once every 30 days",
+		})
+		return
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: "username already exists",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "ChangeUsername").
+			Err(err).
+			Msg("service return error when change username")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.Header("ETag", formatETag(newVersion))
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Username changed successfully!",
+	})
+}
+
+// GetUserByUsername generates a Gin framework handler that looks up the public profile of a user by username.
+// @Summary      Look up a user by username
+// @Description  Retrieve the public profile of the user holding a username. A username the user changed away from
+// @Description  redirects to the lookup of its current username, unless someone else holds it now.
+// @Tags         Users
+// @Produce      json
+// @Param        username  path      string  true  "Current or previous username"
+// @Success      200       {object}  publicProfileResponse
+// @Success      307       "Redirect to the lookup of the current username"
+// @Header       307       {string}  Location  "Lookup of the current username"
+// @Failure      404       {object}  object{message=string}
+// @Failure      500       {object}  object{message=string}
+// @Router       /v1/users/by-username/{username} [get]
+func (u *userHandler) GetUserByUsername(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_GetUserByUsername")
+	defer s.End()
+
+	username := c.Param("username")
+	resolved, err := u.userSvc.ResolveUsername(c, username)
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: "user not found",
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "GetUserByUsername").
+			Err(err).
+			Msg("service return error when resolve username")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	if resolved.Username != username {
+		c.Redirect(http.StatusTemporaryRedirect, path.Join(path.Dir(c.Request.URL.Path), url.PathEscape(resolved.Username)))
+		return
+	}
+
+	c.JSON(http.StatusOK, &publicProfileResponse{
+		Data: &publicProfile{
+			ID:          resolved.ID,
+			Username:    resolved.Username,
+			DisplayName: resolved.DisplayName,
+		},
+		Message: "User retrieved successfully!",
+	})
+}
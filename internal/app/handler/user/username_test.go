@@ -0,0 +1,293 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+)
+
+func TestHandler_ChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		inputIfMatch string
+
+		setupMockSvc func(ctx *gin.Context) *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+		expectedETag     string
+	}{
+		{
+			name: "successful change username",
+
+			inputBody:    `{"username":"newname"}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangeUsername", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, "newname").Return(4, nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Username changed successfully!"}`,
+			expectedETag:     `"4"`,
+		},
+		{
+			name: "missing username",
+
+			inputBody:    `{}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Username is invalid (required)"]}`,
+		},
+		{
+			name: "username not allowed by the policy",
+
+			inputBody:    `{"username":"ab"}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Username is invalid (username_policy)"]}`,
+		},
+		{
+			name: "missing If-Match header",
+
+			inputBody: `{"username":"newname"}`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				return svcMocks.NewService(t)
+			},
+
+			expectedCode:     http.StatusPreconditionRequired,
+			expectedResponse: `{"message":"If-Match header is required"}`,
+		},
+		{
+			name: "version conflict",
+
+			inputBody:    `{"username":"newname"}`,
+			inputIfMatch: `"2"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangeUsername", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 2, "newname").Return(0, user.ErrVersionConflict)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusPreconditionFailed,
+			expectedResponse: `{"message":"profile was modified since it was read, fetch it again and retry"}`,
+		},
+		{
+			name: "unchanged username",
+
+			inputBody:    `{"username":"testuser"}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangeUsername", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, "testuser").Return(0, user.ErrUsernameUnchanged)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"the new username is the current one"}`,
+		},
+		{
+			name: "changed too recently",
+
+			inputBody:    `{"username":"newname"}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangeUsername", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, "newname").Return(0, user.ErrUsernameCooldown)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusTooManyRequests,
+			expectedResponse: `{"message":"the username can only be changed once every 30 days"}`,
+		},
+		{
+			name: "username taken",
+
+			inputBody:    `{"username":"takenname"}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangeUsername", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, "takenname").Return(0, dbutils.ErrDuplicationType)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"username already exists"}`,
+		},
+		{
+			name: "service layer error",
+
+			inputBody:    `{"username":"newname"}`,
+			inputIfMatch: `"3"`,
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ChangeUsername", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", 3, "newname").Return(0, assert.AnError)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/self/username", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			if tc.inputIfMatch != "" {
+				ctx.Request.Header.Set("If-Match", tc.inputIfMatch)
+			}
+			ctx.Set("claims", jwt.MapClaims{
+				"sub": "de305d54-75b4-431b-adb2-eb6b9e546099",
+			})
+			mockUserSvc := tc.setupMockSvc(ctx)
+
+			userHandler := NewUserHandler(mockUserSvc, botprotection.NewDisabledGuard())
+			userHandler.ChangeUsername(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestHandler_GetUserByUsername(t *testing.T) {
+	t.Parallel()
+
+	resolvedUser := &model.User{
+		Base:        model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+		Username:    "testuser",
+		Email:       "testuser@example.com",
+		DisplayName: "Test User",
+	}
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+
+		setupMockSvc func(ctx *gin.Context) *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+		expectedLocation string
+	}{
+		{
+			name: "current username",
+
+			inputUsername: "testuser",
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ResolveUsername", ctx, "testuser").Return(resolvedUser, nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":{"id":"de305d54-75b4-431b-adb2-eb6b9e546099","username":"testuser","display_name":"Test User"},"message":"User retrieved successfully!"}`,
+		},
+		{
+			name: "previous username redirects",
+
+			inputUsername: "oldname",
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ResolveUsername", ctx, "oldname").Return(resolvedUser, nil)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusTemporaryRedirect,
+			expectedLocation: "/v1/users/by-username/testuser",
+		},
+		{
+			name: "unknown username",
+
+			inputUsername: "nobody",
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ResolveUsername", ctx, "nobody").Return(nil, dbutils.ErrRecordNotFoundType)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"user not found"}`,
+		},
+		{
+			name: "service layer error",
+
+			inputUsername: "testuser",
+
+			setupMockSvc: func(ctx *gin.Context) *svcMocks.Service {
+				mockUserSvc := svcMocks.NewService(t)
+				mockUserSvc.On("ResolveUsername", ctx, "testuser").Return(nil, assert.AnError)
+				return mockUserSvc
+			},
+
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/by-username/"+tc.inputUsername, nil)
+			ctx.Params = gin.Params{{Key: "username", Value: tc.inputUsername}}
+			mockUserSvc := tc.setupMockSvc(ctx)
+
+			userHandler := NewUserHandler(mockUserSvc, botprotection.NewDisabledGuard())
+			userHandler.GetUserByUsername(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedLocation != "" {
+				assert.Equal(t, tc.expectedLocation, rec.Header().Get("Location"))
+				return
+			}
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package usernamepolicy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+)
+
+type addEntryRequest struct {
+	Kind  string `json:"kind" binding:"required" example:"reserved"`
+	Value string `json:"value" binding:"required,max=255" example:"billing"`
+}
+
+type listEntriesResponse struct {
+	Data    []*model.UsernamePolicyEntry `json:"data"`
+	Message string                       `json:"message"`
+}
+
+// ListEntries lists the entries of the username lists.
+// @Summary      List username policy entries
+// @Description  List the reserved usernames, blocked words and blocked patterns managed at runtime
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  listEntriesResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/username-policy/entries [get]
+func (h *usernamePolicyHandler) ListEntries(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListUsernamePolicyEntries")
+	defer s.End()
+
+	entries, err := h.usernamePolicySvc.ListEntries(c)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListUsernamePolicyEntries").
+			Err(err).
+			Msg("service return error when listing username policy entries")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listEntriesResponse{
+		Data:    entries,
+		Message: "Username policy entries retrieved successfully!",
+	})
+}
+
+// AddEntry adds an entry to a username list.
+// @Summary      Add a username policy entry
+// @Description  Reserve a username, block a word or block a regular expression; it applies to registrations and username changes right away
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        entry  body      addEntryRequest  true  "Entry to add, kind being reserved, blocked_word or blocked_pattern"
+// @Success      201    {object}  object{data=model.UsernamePolicyEntry,message=string}
+// @Failure      400    {object}  object{message=string}
+// @Failure      401    {object}  object{message=string}
+// @Failure      409    {object}  object{message=string}
+// @Failure      500    {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/username-policy/entries [post]
+func (h *usernamePolicyHandler) AddEntry(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_AddUsernamePolicyEntry")
+	defer s.End()
+
+	input := &addEntryRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	entry, err := h.usernamePolicySvc.AddEntry(c, input.Kind, input.Value)
+	switch {
+	case errors.Is(err, service.ErrUnsupportedKind), errors.Is(err, service.ErrEmptyValue), errors.Is(err, service.ErrInvalidPattern):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, service.ErrEntryExists):
+		c.JSON(http.StatusConflict, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "AddUsernamePolicyEntry").
+			Err(err).
+			Msg("service return error when adding username policy entry")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*model.UsernamePolicyEntry]{
+		Data:    entry,
+		Message: "Username policy entry added successfully!",
+	})
+}
+
+// DeleteEntry removes an entry from a username list.
+// @Summary      Delete a username policy entry
+// @Description  Remove a reserved username, blocked word or blocked pattern
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "Entry ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/username-policy/entries/{id} [delete]
+func (h *usernamePolicyHandler) DeleteEntry(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_DeleteUsernamePolicyEntry")
+	defer s.End()
+
+	err := h.usernamePolicySvc.DeleteEntry(c, c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrEntryNotFound):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "DeleteUsernamePolicyEntry").
+			Err(err).
+			Msg("service return error when deleting username policy entry")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Username policy entry deleted successfully!",
+	})
+}
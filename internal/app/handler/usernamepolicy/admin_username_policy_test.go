@@ -0,0 +1,231 @@
+package usernamepolicy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy/mocks"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var testEntry = &model.UsernamePolicyEntry{
+	Base:  model.Base{ID: "entry-001", CreatedAt: testTime, UpdatedAt: testTime},
+	Kind:  model.UsernamePolicyReserved,
+	Value: "billing",
+}
+
+func TestUsernamePolicy_ListEntries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list entries successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListEntries", mock.Anything).Return([]*model.UsernamePolicyEntry{testEntry}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"entry-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","kind":"reserved","value":"billing"}],"message":"Username policy entries retrieved successfully!"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListEntries", mock.Anything).Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/username-policy/entries", nil)
+
+			usernamePolicyHandler := NewUsernamePolicyHandler(tc.setupMockSvc())
+			usernamePolicyHandler.ListEntries(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestUsernamePolicy_AddEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "add entry successfully",
+			inputBody: `{"kind":"reserved","value":"Billing"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AddEntry", mock.Anything, "reserved", "Billing").Return(testEntry, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":{"id":"entry-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","kind":"reserved","value":"billing"},"message":"Username policy entry added successfully!"}`,
+		},
+		{
+			name:      "missing value",
+			inputBody: `{"kind":"reserved"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["Value is invalid (required)"]}`,
+		},
+		{
+			name:      "unsupported kind",
+			inputBody: `{"kind":"allowed","value":"billing"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AddEntry", mock.Anything, "allowed", "billing").Return(nil, service.ErrUnsupportedKind)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"unsupported username policy entry kind"}`,
+		},
+		{
+			name:      "invalid pattern",
+			inputBody: `{"kind":"blocked_pattern","value":"(guest"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AddEntry", mock.Anything, "blocked_pattern", "(guest").Return(nil, service.ErrInvalidPattern)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"username policy pattern is not a valid regular expression"}`,
+		},
+		{
+			name:      "entry exists",
+			inputBody: `{"kind":"reserved","value":"billing"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AddEntry", mock.Anything, "reserved", "billing").Return(nil, service.ErrEntryExists)
+				return mockSvc
+			},
+			expectedCode:     http.StatusConflict,
+			expectedResponse: `{"message":"username policy entry already exists"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"kind":"reserved","value":"billing"}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("AddEntry", mock.Anything, "reserved", "billing").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/username-policy/entries", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			usernamePolicyHandler := NewUsernamePolicyHandler(tc.setupMockSvc())
+			usernamePolicyHandler.AddEntry(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestUsernamePolicy_DeleteEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "delete entry successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteEntry", mock.Anything, "entry-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Username policy entry deleted successfully!"}`,
+		},
+		{
+			name: "entry not found",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteEntry", mock.Anything, "entry-001").Return(service.ErrEntryNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"username policy entry not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteEntry", mock.Anything, "entry-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/admin/username-policy/entries/entry-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "entry-001"}}
+
+			usernamePolicyHandler := NewUsernamePolicyHandler(tc.setupMockSvc())
+			usernamePolicyHandler.DeleteEntry(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
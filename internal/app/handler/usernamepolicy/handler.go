@@ -0,0 +1,45 @@
+// Package usernamepolicy provides HTTP handlers for the admin API managing the reserved usernames,
+// blocked words and blocked patterns of the username policy, using the Gin web framework.
+package usernamepolicy
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+)
+
+// Handler defines the interface for username policy admin HTTP handlers.
+type Handler interface {
+	// ListEntries is a Gin framework handler that lists the entries of the username lists.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListEntries(c *gin.Context)
+
+	// AddEntry is a Gin framework handler that adds an entry to a username list.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	AddEntry(c *gin.Context)
+
+	// DeleteEntry is a Gin framework handler that removes an entry from a username list.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	DeleteEntry(c *gin.Context)
+}
+
+// usernamePolicyHandler is the concrete implementation of the Handler interface.
+type usernamePolicyHandler struct {
+	usernamePolicySvc usernamepolicy.Service
+}
+
+// NewUsernamePolicyHandler creates a new instance of the username policy admin handler.
+//
+// Parameters:
+//   - usernamePolicySvc: The service used for username list operations
+//
+// Returns:
+//   - Handler: A new username policy admin handler instance
+func NewUsernamePolicyHandler(usernamePolicySvc usernamepolicy.Service) Handler {
+	return &usernamePolicyHandler{usernamePolicySvc: usernamePolicySvc}
+}
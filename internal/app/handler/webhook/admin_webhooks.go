@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/webhook"
+)
+
+type createSubscriptionRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=2048" example:"https://partner.example.com/hooks"`
+	EventTypes []string `json:"event_types" binding:"required,min=1" example:"user.created"`
+}
+
+type listSubscriptionsResponse struct {
+	Data    []*model.WebhookSubscription `json:"data"`
+	Message string                       `json:"message"`
+}
+
+type listDeliveriesResponse struct {
+	Data    []*model.WebhookDelivery `json:"data"`
+	Message string                   `json:"message"`
+}
+
+// CreateSubscription subscribes an endpoint to user lifecycle events.
+// The signing secret is only part of this response; it cannot be retrieved again.
+// @Summary      Create a webhook subscription
+// @Description  Subscribe an endpoint to user lifecycle events, deliveries are signed with the returned secret
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        subscription  body      createSubscriptionRequest  true  "Subscription to create"
+// @Success      201           {object}  object{data=webhook.CreatedSubscription,message=string}
+// @Failure      400           {object}  object{message=string}
+// @Failure      401           {object}  object{message=string}
+// @Failure      500           {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/webhooks [post]
+func (h *webhookHandler) CreateSubscription(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_CreateSubscription")
+	defer s.End()
+
+	input := &createSubscriptionRequest{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		c.JSON(http.StatusBadRequest, common.InputFieldError(err))
+		return
+	}
+
+	subscription, err := h.webhookSvc.CreateSubscription(c, input.URL, input.EventTypes)
+	switch {
+	case errors.Is(err, service.ErrInvalidURL), errors.Is(err, service.ErrUnsupportedEventType):
+		c.JSON(http.StatusBadRequest, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "CreateSubscription").
+			Err(err).
+			Msg("service return error when creating webhook subscription")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &common.SuccessResponse[*service.CreatedSubscription]{
+		Data:    subscription,
+		Message: "Webhook created successfully, copy the secret now as it will not be shown again!",
+	})
+}
+
+// ListSubscriptions lists the webhook subscriptions.
+// @Summary      List webhook subscriptions
+// @Description  List the webhook subscriptions, without their secrets
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  listSubscriptionsResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/webhooks [get]
+func (h *webhookHandler) ListSubscriptions(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListSubscriptions")
+	defer s.End()
+
+	subscriptions, err := h.webhookSvc.ListSubscriptions(c)
+	if err != nil {
+		log.Error().
+			Str("operation", "ListSubscriptions").
+			Err(err).
+			Msg("service return error when listing webhook subscriptions")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listSubscriptionsResponse{
+		Data:    subscriptions,
+		Message: "Webhooks retrieved successfully!",
+	})
+}
+
+// DeleteSubscription deletes a webhook subscription.
+// @Summary      Delete a webhook subscription
+// @Description  Delete a webhook subscription, its pending deliveries are dropped
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  object{message=string}
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/webhooks/{id} [delete]
+func (h *webhookHandler) DeleteSubscription(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_DeleteSubscription")
+	defer s.End()
+
+	err := h.webhookSvc.DeleteSubscription(c, c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "DeleteSubscription").
+			Err(err).
+			Msg("service return error when deleting webhook subscription")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, common.Message{
+		Message: "Webhook deleted successfully!",
+	})
+}
+
+// ListDeliveries lists the delivery log of a webhook subscription.
+// @Summary      List webhook deliveries
+// @Description  List the latest 50 deliveries of a webhook subscription with the outcome of their last attempt
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  listDeliveriesResponse
+// @Failure      401  {object}  object{message=string}
+// @Failure      404  {object}  object{message=string}
+// @Failure      500  {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/webhooks/{id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ListDeliveries")
+	defer s.End()
+
+	deliveries, err := h.webhookSvc.ListDeliveries(c, c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "ListDeliveries").
+			Err(err).
+			Msg("service return error when listing webhook deliveries")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusOK, &listDeliveriesResponse{
+		Data:    deliveries,
+		Message: "Deliveries retrieved successfully!",
+	})
+}
+
+// ReplayDelivery queues a webhook delivery again.
+// @Summary      Replay a webhook delivery
+// @Description  Queue a delivery again, whatever its status, to be attempted right away with a fresh set of retries
+// @Tags         Admin
+// @Produce      json
+// @Param        id           path      string  true  "Subscription ID"
+// @Param        delivery_id  path      string  true  "Delivery ID"
+// @Success      202          {object}  object{data=model.WebhookDelivery,message=string}
+// @Failure      401          {object}  object{message=string}
+// @Failure      404          {object}  object{message=string}
+// @Failure      500          {object}  object{message=string}
+// @Security     AdminKey
+// @Router       /v1/admin/webhooks/{id}/deliveries/{delivery_id}/replay [post]
+func (h *webhookHandler) ReplayDelivery(c *gin.Context) {
+	nrTx := newrelic.FromContext(c)
+	s := nrTx.StartSegment("Handler_ReplayDelivery")
+	defer s.End()
+
+	delivery, err := h.webhookSvc.ReplayDelivery(c, c.Param("id"), c.Param("delivery_id"))
+	switch {
+	case errors.Is(err, service.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, common.Message{
+			Message: err.Error(),
+		})
+		return
+	case errors.Is(err, nil):
+	default:
+		log.Error().
+			Str("operation", "ReplayDelivery").
+			Err(err).
+			Msg("service return error when replaying webhook delivery")
+		c.JSON(http.StatusInternalServerError, common.InternalErrorResponse)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, &common.SuccessResponse[*model.WebhookDelivery]{
+		Data:    delivery,
+		Message: "Delivery queued for replay!",
+	})
+}
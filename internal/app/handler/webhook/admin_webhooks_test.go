@@ -0,0 +1,374 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	service "github.com/vukieuhaihoa/user-service/internal/app/service/webhook"
+	svcMocks "github.com/vukieuhaihoa/user-service/internal/app/service/webhook/mocks"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestWebhook_CreateSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name:      "create subscription successfully",
+			inputBody: `{"url":"https://partner.example.com/hooks","event_types":["user.created"]}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateSubscription", mock.Anything, "https://partner.example.com/hooks", []string{"user.created"}).
+					Return(&service.CreatedSubscription{
+						WebhookSubscription: &model.WebhookSubscription{
+							Base:       model.Base{ID: "subscription-001", CreatedAt: testTime, UpdatedAt: testTime},
+							URL:        "https://partner.example.com/hooks",
+							Secret:     "whsec_abcdef",
+							EventTypes: []string{"user.created"},
+						},
+						Secret: "whsec_abcdef",
+					}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusCreated,
+			expectedResponse: `{"data":{"id":"subscription-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","url":"https://partner.example.com/hooks","event_types":["user.created"],"secret":"whsec_abcdef"},"message":"Webhook created successfully, copy the secret now as it will not be shown again!"}`,
+		},
+		{
+			name:      "invalid url",
+			inputBody: `{"url":"partner","event_types":["user.created"]}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["URL is invalid (url)"]}`,
+		},
+		{
+			name:      "missing event types",
+			inputBody: `{"url":"https://partner.example.com/hooks","event_types":[]}`,
+			setupMockSvc: func() *svcMocks.Service {
+				return svcMocks.NewService(t) // No expectations since service should not be called
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"Invalid input fields","details":["EventTypes is invalid (min)"]}`,
+		},
+		{
+			name:      "unsupported event type",
+			inputBody: `{"url":"https://partner.example.com/hooks","event_types":["bookmark.created"]}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateSubscription", mock.Anything, "https://partner.example.com/hooks", []string{"bookmark.created"}).
+					Return(nil, service.ErrUnsupportedEventType)
+				return mockSvc
+			},
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"unsupported webhook event type"}`,
+		},
+		{
+			name:      "service layer error",
+			inputBody: `{"url":"https://partner.example.com/hooks","event_types":["user.created"]}`,
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("CreateSubscription", mock.Anything, "https://partner.example.com/hooks", []string{"user.created"}).
+					Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/webhooks", strings.NewReader(tc.inputBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			webhookHandler := NewWebhookHandler(tc.setupMockSvc())
+			webhookHandler.CreateSubscription(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestWebhook_ListSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list subscriptions successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListSubscriptions", mock.Anything).Return([]*model.WebhookSubscription{
+					{
+						Base:       model.Base{ID: "subscription-001", CreatedAt: testTime, UpdatedAt: testTime},
+						URL:        "https://partner.example.com/hooks",
+						Secret:     "whsec_abcdef",
+						EventTypes: []string{"user.created", "user.updated"},
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"subscription-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","url":"https://partner.example.com/hooks","event_types":["user.created","user.updated"]}],"message":"Webhooks retrieved successfully!"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListSubscriptions", mock.Anything).Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/webhooks", nil)
+
+			webhookHandler := NewWebhookHandler(tc.setupMockSvc())
+			webhookHandler.ListSubscriptions(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestWebhook_DeleteSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "delete subscription successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteSubscription", mock.Anything, "subscription-001").Return(nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Webhook deleted successfully!"}`,
+		},
+		{
+			name: "subscription not found",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteSubscription", mock.Anything, "subscription-001").Return(service.ErrSubscriptionNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"webhook subscription not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("DeleteSubscription", mock.Anything, "subscription-001").Return(assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/admin/webhooks/subscription-001", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "subscription-001"}}
+
+			webhookHandler := NewWebhookHandler(tc.setupMockSvc())
+			webhookHandler.DeleteSubscription(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestWebhook_ListDeliveries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "list deliveries successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListDeliveries", mock.Anything, "subscription-001").Return([]*model.WebhookDelivery{
+					{
+						Base:           model.Base{ID: "delivery-001", CreatedAt: testTime, UpdatedAt: testTime},
+						SubscriptionID: "subscription-001",
+						EventID:        "event-001",
+						EventType:      "user.created",
+						Payload:        `{"id":"user-001"}`,
+						OccurredAt:     testTime,
+						Status:         model.WebhookDeliveryPending,
+						Attempts:       1,
+						LastStatusCode: 503,
+						LastError:      "unexpected status code 503",
+						NextAttemptAt:  testTime.Add(30 * time.Second),
+					},
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"data":[{"id":"delivery-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","subscription_id":"subscription-001","event_id":"event-001","event_type":"user.created","occurred_at":"2024-01-01T00:00:00Z","status":"pending","attempts":1,"last_status_code":503,"last_error":"unexpected status code 503","next_attempt_at":"2024-01-01T00:00:30Z","delivered_at":null}],"message":"Deliveries retrieved successfully!"}`,
+		},
+		{
+			name: "subscription not found",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListDeliveries", mock.Anything, "subscription-001").Return(nil, service.ErrSubscriptionNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"webhook subscription not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ListDeliveries", mock.Anything, "subscription-001").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/webhooks/subscription-001/deliveries", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "subscription-001"}}
+
+			webhookHandler := NewWebhookHandler(tc.setupMockSvc())
+			webhookHandler.ListDeliveries(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestWebhook_ReplayDelivery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSvc func() *svcMocks.Service
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "replay delivery successfully",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ReplayDelivery", mock.Anything, "subscription-001", "delivery-001").Return(&model.WebhookDelivery{
+					Base:           model.Base{ID: "delivery-001", CreatedAt: testTime, UpdatedAt: testTime},
+					SubscriptionID: "subscription-001",
+					EventID:        "event-001",
+					EventType:      "user.created",
+					OccurredAt:     testTime,
+					Status:         model.WebhookDeliveryPending,
+					NextAttemptAt:  testTime,
+				}, nil)
+				return mockSvc
+			},
+			expectedCode:     http.StatusAccepted,
+			expectedResponse: `{"data":{"id":"delivery-001","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","subscription_id":"subscription-001","event_id":"event-001","event_type":"user.created","occurred_at":"2024-01-01T00:00:00Z","status":"pending","attempts":0,"last_status_code":0,"last_error":"","next_attempt_at":"2024-01-01T00:00:00Z","delivered_at":null},"message":"Delivery queued for replay!"}`,
+		},
+		{
+			name: "delivery not found",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ReplayDelivery", mock.Anything, "subscription-001", "delivery-001").Return(nil, service.ErrDeliveryNotFound)
+				return mockSvc
+			},
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: `{"message":"webhook delivery not found"}`,
+		},
+		{
+			name: "service layer error",
+			setupMockSvc: func() *svcMocks.Service {
+				mockSvc := svcMocks.NewService(t)
+				mockSvc.On("ReplayDelivery", mock.Anything, "subscription-001", "delivery-001").Return(nil, assert.AnError)
+				return mockSvc
+			},
+			expectedCode:     http.StatusInternalServerError,
+			expectedResponse: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/webhooks/subscription-001/deliveries/delivery-001/replay", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: "subscription-001"}, {Key: "delivery_id", Value: "delivery-001"}}
+
+			webhookHandler := NewWebhookHandler(tc.setupMockSvc())
+			webhookHandler.ReplayDelivery(ctx)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package webhook provides HTTP handlers for the admin API managing webhook subscriptions
+// and their deliveries, using the Gin web framework.
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/webhook"
+)
+
+// Handler defines the interface for webhook admin HTTP handlers.
+type Handler interface {
+	// CreateSubscription is a Gin framework handler that subscribes an endpoint to user lifecycle events.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	CreateSubscription(c *gin.Context)
+
+	// ListSubscriptions is a Gin framework handler that lists the webhook subscriptions.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListSubscriptions(c *gin.Context)
+
+	// DeleteSubscription is a Gin framework handler that deletes a webhook subscription.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	DeleteSubscription(c *gin.Context)
+
+	// ListDeliveries is a Gin framework handler that lists the delivery log of a webhook subscription.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ListDeliveries(c *gin.Context)
+
+	// ReplayDelivery is a Gin framework handler that queues a webhook delivery again.
+	//
+	// Parameters:
+	//   - c: The Gin context containing the HTTP request and response
+	ReplayDelivery(c *gin.Context)
+}
+
+// webhookHandler is the concrete implementation of the Handler interface.
+type webhookHandler struct {
+	webhookSvc webhook.Service
+}
+
+// NewWebhookHandler creates a new instance of the webhook admin handler.
+//
+// Parameters:
+//   - webhookSvc: The service used for webhook subscription operations
+//
+// Returns:
+//   - Handler: A new webhook admin handler instance
+func NewWebhookHandler(webhookSvc webhook.Service) Handler {
+	return &webhookHandler{webhookSvc: webhookSvc}
+}
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// Statuses of an email change.
+const (
+	EmailChangePending    = "pending"
+	EmailChangeConfirmed  = "confirmed"
+	EmailChangeCancelled  = "cancelled"
+	EmailChangeSuperseded = "superseded"
+)
+
+// EmailChange represents a request to change the email address of a user.
+// The new address is only applied once confirmed from a link sent to it, and the
+// old address can cancel the change, or revert it, until the cancel window ends.
+// Only hashes of the confirm and cancel tokens are stored.
+// It maps to the "email_changes" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the change (UUID).
+//   - TenantID: The tenant of the user.
+//   - UserID: The ID of the user whose email changes.
+//   - OldEmail: The email address of the user when the change was requested.
+//   - NewEmail: The requested email address.
+//   - ConfirmTokenHash: The SHA-256 hash of the token sent to the new address.
+//   - CancelTokenHash: The SHA-256 hash of the token sent to the old address.
+//   - Status: pending, confirmed, cancelled or superseded by a later request.
+//   - ExpiresAt: When the confirm link stops working.
+//   - CancelExpiresAt: When the cancel link stops working.
+//   - ConfirmedAt: When the new address was confirmed.
+//   - CancelledAt: When the change was cancelled from the old address.
+//   - CreatedAt: The timestamp when the change was requested.
+//   - UpdatedAt: The timestamp when the change was last updated.
+type EmailChange struct {
+	Base
+	TenantID         string     `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID           string     `gorm:"not null;column:user_id;index" json:"-"`
+	OldEmail         string     `gorm:"not null;column:old_email" json:"old_email"`
+	NewEmail         string     `gorm:"not null;column:new_email" json:"new_email"`
+	ConfirmTokenHash string     `gorm:"not null;column:confirm_token_hash;uniqueIndex:email_changes_confirm_token_hash_unique" json:"-"`
+	CancelTokenHash  string     `gorm:"not null;column:cancel_token_hash;uniqueIndex:email_changes_cancel_token_hash_unique" json:"-"`
+	Status           string     `gorm:"not null;column:status" json:"status"`
+	ExpiresAt        time.Time  `gorm:"not null;column:expires_at" json:"expires_at"`
+	CancelExpiresAt  time.Time  `gorm:"not null;column:cancel_expires_at" json:"cancel_expires_at"`
+	ConfirmedAt      *time.Time `gorm:"column:confirmed_at" json:"confirmed_at"`
+	CancelledAt      *time.Time `gorm:"column:cancelled_at" json:"cancelled_at"`
+	User             *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for the EmailChange model.
+//
+// Returns:
+//   - string: The name of the database table for the EmailChange model
+func (EmailChange) TableName() string {
+	return "email_changes"
+}
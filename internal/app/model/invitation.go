@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// Statuses of an invitation.
+const (
+	InvitationPending    = "pending"
+	InvitationAccepted   = "accepted"
+	InvitationRevoked    = "revoked"
+	InvitationSuperseded = "superseded"
+)
+
+// Invitation represents an invitation to register, sent to an email address.
+// The link of an invitation registers one user with the invited address, who is granted the role of the
+// invitation, until it expires. Only a hash of the token of the link is stored.
+// It maps to the "invitations" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the invitation (UUID).
+//   - TenantID: The tenant the invitation registers the user in.
+//   - Email: The invited email address.
+//   - Role: The role granted to the user registering with the invitation.
+//   - TokenHash: The SHA-256 hash of the token of the invitation link.
+//   - Status: pending, accepted, revoked or superseded by a later invitation of the same address.
+//   - ExpiresAt: When the invitation link stops working.
+//   - AcceptedAt: When a user registered with the invitation.
+//   - UserID: The ID of the user who registered with the invitation.
+//   - CreatedAt: The timestamp when the invitation was created.
+//   - UpdatedAt: The timestamp when the invitation was last updated.
+type Invitation struct {
+	Base
+	TenantID   string     `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	Email      string     `gorm:"not null;column:email;index" json:"email"`
+	Role       string     `gorm:"not null;column:role" json:"role"`
+	TokenHash  string     `gorm:"not null;column:token_hash;uniqueIndex:invitations_token_hash_unique" json:"-"`
+	Status     string     `gorm:"not null;column:status" json:"status"`
+	ExpiresAt  time.Time  `gorm:"not null;column:expires_at" json:"expires_at"`
+	AcceptedAt *time.Time `gorm:"column:accepted_at" json:"accepted_at"`
+	UserID     *string    `gorm:"column:user_id" json:"user_id"`
+	User       *User      `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// TableName specifies the table name for the Invitation model.
+//
+// Returns:
+//   - string: The name of the database table for the Invitation model
+func (Invitation) TableName() string {
+	return "invitations"
+}
@@ -0,0 +1,34 @@
+package model
+
+// LoginEvent represents a single login attempt of a user, successful or not.
+// It maps to the "login_history" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the event (UUID).
+//   - TenantID: The tenant of the user.
+//   - UserID: The ID of the user the attempt was made for.
+//   - IPAddress: The IP address the attempt came from.
+//   - UserAgent: The raw user-agent of the login request.
+//   - DeviceFingerprint: A hash identifying the device, used to detect logins from new devices.
+//   - Success: Whether the attempt succeeded.
+//   - MFAUsed: Whether a second factor was verified during the attempt.
+//   - CreatedAt: The timestamp of the attempt.
+//   - UpdatedAt: The timestamp when the event was last updated.
+type LoginEvent struct {
+	Base
+	TenantID          string `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID            string `gorm:"not null;column:user_id;index" json:"-"`
+	IPAddress         string `gorm:"not null;column:ip_address" json:"ip_address"`
+	UserAgent         string `gorm:"not null;column:user_agent" json:"user_agent"`
+	DeviceFingerprint string `gorm:"not null;column:device_fingerprint" json:"-"`
+	Success           bool   `gorm:"not null;column:success" json:"success"`
+	MFAUsed           bool   `gorm:"not null;column:mfa_used" json:"mfa_used"`
+}
+
+// TableName specifies the table name for the LoginEvent model.
+//
+// Returns:
+//   - string: The name of the database table for the LoginEvent model
+func (LoginEvent) TableName() string {
+	return "login_history"
+}
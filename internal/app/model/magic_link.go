@@ -0,0 +1,13 @@
+package model
+
+// MagicLink holds the data remembered between sending a login link and its use.
+//
+// Fields:
+//   - UserID: The ID of the user the link logs in.
+//   - Email: The email address the link was sent to.
+//   - NonceHash: The SHA-256 of the nonce cookie set on the requesting device.
+type MagicLink struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	NonceHash string `json:"nonce_hash"`
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// Roles of a member in an organization. An organization has exactly one owner; admins manage its members and
+// invitations.
+const (
+	OrganizationOwner  = "owner"
+	OrganizationAdmin  = "admin"
+	OrganizationMember = "member"
+)
+
+// Organization represents a team users belong to through their memberships.
+// It maps to the "organizations" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the organization (UUID).
+//   - TenantID: The tenant the organization belongs to; only its users can be members.
+//   - Name: The display name of the organization.
+//   - CreatedAt: The timestamp when the organization was created.
+//   - UpdatedAt: The timestamp when the organization was last updated.
+type Organization struct {
+	Base
+	TenantID string `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	Name     string `gorm:"not null;column:name" json:"name"`
+}
+
+// TableName specifies the table name for the Organization model.
+//
+// Returns:
+//   - string: The name of the database table for the Organization model
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// Membership represents the role of a user in an organization.
+// It maps to the "memberships" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the membership (UUID).
+//   - TenantID: The tenant of the organization.
+//   - OrganizationID: The ID of the organization.
+//   - UserID: The ID of the member.
+//   - Role: OrganizationOwner, OrganizationAdmin or OrganizationMember.
+//   - Organization: The organization, when loaded.
+//   - User: The member, when loaded.
+//   - CreatedAt: The timestamp when the user joined the organization.
+//   - UpdatedAt: The timestamp when the membership was last updated.
+type Membership struct {
+	Base
+	TenantID       string        `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	OrganizationID string        `gorm:"not null;column:organization_id;uniqueIndex:memberships_organization_user_unique" json:"organization_id"`
+	UserID         string        `gorm:"not null;column:user_id;uniqueIndex:memberships_organization_user_unique;index" json:"user_id"`
+	Role           string        `gorm:"not null;column:role" json:"role"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE" json:"organization,omitempty"`
+	User           *User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for the Membership model.
+//
+// Returns:
+//   - string: The name of the database table for the Membership model
+func (Membership) TableName() string {
+	return "memberships"
+}
+
+// Roles returns the roles the member holds in the organization. Roles are hierarchical: an owner also holds the
+// admin and member roles, and an admin the member role.
+//
+// Returns:
+//   - []string: The role of the membership followed by the roles it implies
+func (m *Membership) Roles() []string {
+	switch m.Role {
+	case OrganizationOwner:
+		return []string{OrganizationOwner, OrganizationAdmin, OrganizationMember}
+	case OrganizationAdmin:
+		return []string{OrganizationAdmin, OrganizationMember}
+	case OrganizationMember:
+		return []string{OrganizationMember}
+	default:
+		return []string{}
+	}
+}
+
+// HasRole reports whether the member holds the role in the organization, directly or through a higher role.
+//
+// Parameters:
+//   - role: The role to check
+//
+// Returns:
+//   - bool: true if the role is among Roles
+func (m *Membership) HasRole(role string) bool {
+	return slices.Contains(m.Roles(), role)
+}
+
+// OrganizationInvitation represents an invitation to join an organization, sent to an email address.
+// The link of an invitation adds the user logged in with the invited address to the organization, with the role
+// of the invitation, until it expires. Only a hash of the token of the link is stored.
+// It maps to the "organization_invitations" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the invitation (UUID).
+//   - TenantID: The tenant of the organization.
+//   - OrganizationID: The ID of the organization.
+//   - Email: The invited email address.
+//   - Role: The role granted to the user joining with the invitation, OrganizationAdmin or OrganizationMember.
+//   - TokenHash: The SHA-256 hash of the token of the invitation link.
+//   - Status: pending, accepted, revoked or superseded by a later invitation of the same address.
+//   - ExpiresAt: When the invitation link stops working.
+//   - AcceptedAt: When a user joined with the invitation.
+//   - UserID: The ID of the user who joined with the invitation.
+//   - CreatedAt: The timestamp when the invitation was created.
+//   - UpdatedAt: The timestamp when the invitation was last updated.
+type OrganizationInvitation struct {
+	Base
+	TenantID       string        `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	OrganizationID string        `gorm:"not null;column:organization_id;index" json:"organization_id"`
+	Email          string        `gorm:"not null;column:email" json:"email"`
+	Role           string        `gorm:"not null;column:role" json:"role"`
+	TokenHash      string        `gorm:"not null;column:token_hash;uniqueIndex:organization_invitations_token_hash_unique" json:"-"`
+	Status         string        `gorm:"not null;column:status" json:"status"`
+	ExpiresAt      time.Time     `gorm:"not null;column:expires_at" json:"expires_at"`
+	AcceptedAt     *time.Time    `gorm:"column:accepted_at" json:"accepted_at"`
+	UserID         *string       `gorm:"column:user_id" json:"user_id"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE" json:"-"`
+	User           *User         `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// TableName specifies the table name for the OrganizationInvitation model.
+//
+// Returns:
+//   - string: The name of the database table for the OrganizationInvitation model
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
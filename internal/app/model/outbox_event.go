@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// OutboxEvent represents a domain event waiting to be published, or already published, to the event stream.
+// It is written in the same transaction as the change it describes, so no change is ever left without its event.
+// It maps to the "outbox_events" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the event (UUID), for consumers to drop duplicates.
+//   - TenantID: The tenant of the change; the event is only delivered to the webhooks of that tenant.
+//   - AggregateID: The ID of the entity the event is about, such as the user ID.
+//   - EventType: The type of the event (e.g., "user.created").
+//   - Payload: The JSON encoded state of the entity after the change.
+//   - Attempts: How many times publishing the event failed.
+//   - LastError: The error of the last failed attempt.
+//   - NextAttemptAt: When the event may be published, pushed back after each failed attempt.
+//   - StreamID: The ID of the stream entry the event was delivered as, its position in the stream.
+//   - PublishedAt: When the event was published, nil while it is pending.
+//   - DeadLetteredAt: When the event was moved to the dead-letter stream after too many failed attempts.
+//   - CreatedAt: The timestamp when the change happened.
+//   - UpdatedAt: The timestamp when the event was last updated.
+type OutboxEvent struct {
+	Base
+	TenantID       string     `gorm:"not null;default:default;column:tenant_id" json:"tenant_id"`
+	AggregateID    string     `gorm:"not null;column:aggregate_id" json:"aggregate_id"`
+	EventType      string     `gorm:"not null;column:event_type" json:"event_type"`
+	Payload        string     `gorm:"not null;column:payload" json:"payload"`
+	Attempts       int        `gorm:"not null;column:attempts" json:"attempts"`
+	LastError      string     `gorm:"not null;column:last_error" json:"last_error"`
+	NextAttemptAt  time.Time  `gorm:"not null;column:next_attempt_at" json:"next_attempt_at"`
+	StreamID       string     `gorm:"not null;column:stream_id" json:"stream_id"`
+	PublishedAt    *time.Time `gorm:"column:published_at" json:"published_at"`
+	DeadLetteredAt *time.Time `gorm:"column:dead_lettered_at" json:"dead_lettered_at"`
+}
+
+// TableName specifies the table name for the OutboxEvent model.
+//
+// Returns:
+//   - string: The name of the database table for the OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,27 @@
+package model
+
+// PasswordHistory records a password a user had before changing it, so that recent passwords are not reused.
+// It maps to the "password_history" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the entry (UUID).
+//   - TenantID: The tenant of the user.
+//   - UserID: The ID of the user who had the password.
+//   - PasswordHash: The hash of the previous password.
+//   - CreatedAt: The timestamp when the password was replaced.
+//   - UpdatedAt: The timestamp when the entry was last updated.
+type PasswordHistory struct {
+	Base
+	TenantID     string `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID       string `gorm:"not null;column:user_id;index" json:"-"`
+	PasswordHash string `gorm:"not null;column:password_hash" json:"-"`
+	User         *User  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for the PasswordHistory model.
+//
+// Returns:
+//   - string: The name of the database table for the PasswordHistory model
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// PersonalAccessToken represents a long-lived token a user minted for API automation.
+// Only a hash of the token is stored; the plaintext is shown once when it is created.
+// It maps to the "personal_access_tokens" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the token (UUID).
+//   - TenantID: The tenant of the user, which the token is bound to.
+//   - UserID: The ID of the user owning this token.
+//   - Name: A label chosen by the user to recognize the token.
+//   - TokenPrefix: The first characters of the token, shown to help identify it.
+//   - TokenHash: The SHA-256 hash of the token.
+//   - Scopes: The scopes granted to the token (e.g., "profile:read").
+//   - ExpiresAt: When the token stops being accepted, nil if it never expires.
+//   - LastUsedAt: The timestamp of the last request authenticated with this token.
+//   - CreatedAt: The timestamp when the token was created.
+//   - UpdatedAt: The timestamp when the token was last updated.
+type PersonalAccessToken struct {
+	Base
+	TenantID    string     `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID      string     `gorm:"not null;column:user_id;index" json:"-"`
+	Name        string     `gorm:"not null;column:name" json:"name"`
+	TokenPrefix string     `gorm:"not null;column:token_prefix" json:"token_prefix"`
+	TokenHash   string     `gorm:"not null;column:token_hash;uniqueIndex:personal_access_tokens_token_hash_unique" json:"-"`
+	Scopes      []string   `gorm:"not null;column:scopes;serializer:json" json:"scopes"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at" json:"expires_at"`
+	LastUsedAt  *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+}
+
+// TableName specifies the table name for the PersonalAccessToken model.
+//
+// Returns:
+//   - string: The name of the database table for the PersonalAccessToken model
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
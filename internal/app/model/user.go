@@ -1,22 +1,52 @@
 package model
 
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+	"gorm.io/gorm"
+)
+
+// Roles of a user. Users are members unless the invitation they registered with granted another role.
+const (
+	RoleMember = "member"
+	RoleAdmin  = "admin"
+)
+
 // User represents a user in the system.
 // It maps to the "users" table in the database.
 //
 // Fields:
 //   - ID: The unique identifier for the user (UUID).
-//   - Username: The username of the user (unique, not null).
-//   - Email: The email address of the user (unique, not null).
+//   - TenantID: The tenant the user belongs to; queries only see the users of the tenant of their context.
+//   - Username: The username of the user (unique in the tenant, not null).
+//   - Email: The email address of the user (unique in the tenant, not null).
 //   - Password: The hashed password of the user (not null).
 //   - DisplayName: The display name of the user.
+//   - Version: The revision of the user, increased on every update.
+//   - Role: The role of the user, RoleMember or RoleAdmin.
+//   - UsernameNormalized: The canonical form of the username, unique among the users of the tenant.
+//   - EmailNormalized: The canonical form of the email address, unique among the users of the tenant.
+//   - PasswordChangedAt: When the password was last set, nil for users without a password.
+//   - EmailVerifiedAt: When the user proved they own the email address, nil until then. Like the password, it is
+//     left out of the user cache, so only the lookups reading the database, such as by email address, hold it.
 //   - CreatedAt: The timestamp when the user was created.
 //   - UpdatedAt: The timestamp when the user was last updated.
 type User struct {
 	Base
-	Username    string `gorm:"unique;not null;column:username" json:"username"`
-	Email       string `gorm:"unique;not null;column:email" json:"email"`
+	TenantID    string `gorm:"not null;default:default;column:tenant_id;uniqueIndex:users_tenant_username_unique;uniqueIndex:users_tenant_email_unique;uniqueIndex:users_tenant_username_normalized_unique;uniqueIndex:users_tenant_email_normalized_unique" json:"-"`
+	Username    string `gorm:"not null;column:username;uniqueIndex:users_tenant_username_unique" json:"username"`
+	Email       string `gorm:"not null;column:email;uniqueIndex:users_tenant_email_unique" json:"email"`
 	Password    string `gorm:"not null;column:password" json:"-"`
 	DisplayName string `gorm:"column:display_name" json:"display_name"`
+	Version     int    `gorm:"not null;default:1;column:version" json:"version"`
+	Role        string `gorm:"not null;default:member;column:role" json:"role,omitempty"`
+
+	UsernameNormalized *string `gorm:"column:username_normalized;uniqueIndex:users_tenant_username_normalized_unique" json:"-"`
+	EmailNormalized    *string `gorm:"column:email_normalized;uniqueIndex:users_tenant_email_normalized_unique" json:"-"`
+
+	PasswordChangedAt *time.Time `gorm:"column:password_changed_at" json:"-"`
+	EmailVerifiedAt   *time.Time `gorm:"column:email_verified_at" json:"-"`
 }
 
 // TableName specifies the table name for the User model.
@@ -26,3 +56,43 @@ type User struct {
 func (User) TableName() string {
 	return "users"
 }
+
+// HasPassword reports whether the user can log in with a password.
+// Users provisioned through an external identity provider have none until they set one.
+//
+// Returns:
+//   - bool: true if a password hash is stored for the user
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+// Normalize sets the canonical forms of the username and the email address that are set on the user.
+// The canonical forms of the empty ones are left unchanged, so that partial updates only write what they change.
+func (u *User) Normalize() {
+	if u.Username != "" {
+		usernameNormalized := normalize.Username(u.Username)
+		u.UsernameNormalized = &usernameNormalized
+	}
+	if u.Email != "" {
+		emailNormalized := normalize.Email(u.Email)
+		u.EmailNormalized = &emailNormalized
+	}
+}
+
+// BeforeCreate is a GORM hook that is triggered before a new User record is created in the database.
+// It generates the ID of the user and the canonical forms of its username and email address, and records when
+// the password was set.
+//
+// Parameters:
+//   - tx: The GORM database transaction
+//
+// Returns:
+//   - error: An error if ID generation fails, otherwise nil
+func (u *User) BeforeCreate(tx *gorm.DB) error {
+	u.Normalize()
+	if u.HasPassword() && u.PasswordChangedAt == nil {
+		now := time.Now()
+		u.PasswordChangedAt = &now
+	}
+	return u.Base.BeforeCreate(tx)
+}
@@ -0,0 +1,45 @@
+package model
+
+// UserIdentity represents an external identity linked to a local user.
+// It maps to the "user_identities" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the identity link (UUID).
+//   - TenantID: The tenant of the user; a provider subject is linked at most once per tenant.
+//   - UserID: The ID of the local user owning this identity.
+//   - Provider: The name of the upstream identity provider (e.g., "google").
+//   - Subject: The stable "sub" claim issued by the provider.
+//   - Email: The email address reported by the provider at link time.
+//   - CreatedAt: The timestamp when the identity was linked.
+//   - UpdatedAt: The timestamp when the identity was last updated.
+type UserIdentity struct {
+	Base
+	TenantID string `gorm:"not null;default:default;column:tenant_id;uniqueIndex:user_identities_tenant_provider_subject_unique,priority:1" json:"-"`
+	UserID   string `gorm:"not null;column:user_id;index" json:"-"`
+	Provider string `gorm:"not null;column:provider;uniqueIndex:user_identities_tenant_provider_subject_unique,priority:2" json:"provider"`
+	Subject  string `gorm:"not null;column:subject;uniqueIndex:user_identities_tenant_provider_subject_unique,priority:3" json:"subject"`
+	Email    string `gorm:"column:email" json:"email"`
+}
+
+// TableName specifies the table name for the UserIdentity model.
+//
+// Returns:
+//   - string: The name of the database table for the UserIdentity model
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// AuthState holds the data remembered between the start of an
+// authorization-code flow and the provider callback.
+//
+// Fields:
+//   - Provider: The name of the provider the flow was started against.
+//   - Nonce: The nonce expected in the returned ID token.
+//   - CodeVerifier: The PKCE code verifier matching the sent challenge.
+//   - UserID: The ID of the logged in user linking a new identity, empty for a login flow.
+type AuthState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	UserID       string `json:"user_id,omitempty"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+// UserPasskey represents a WebAuthn credential registered by a user.
+// It maps to the "user_passkeys" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the passkey (UUID).
+//   - TenantID: The tenant of the user.
+//   - UserID: The ID of the user owning this passkey.
+//   - CredentialID: The credential ID chosen by the authenticator.
+//   - PublicKey: The COSE-encoded credential public key.
+//   - AttestationType: The attestation format used at registration (e.g., "none").
+//   - Transports: The transports the authenticator reported (e.g., "internal", "usb").
+//   - AAGUID: The model identifier of the authenticator.
+//   - SignCount: The last signature counter seen, used to detect cloned authenticators.
+//   - BackupEligible: Whether the credential can be synced to other devices.
+//   - BackupState: Whether the credential was backed up at registration.
+//   - LastUsedAt: The timestamp of the last login with this passkey.
+//   - CreatedAt: The timestamp when the passkey was registered.
+//   - UpdatedAt: The timestamp when the passkey was last updated.
+type UserPasskey struct {
+	Base
+	TenantID        string     `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID          string     `gorm:"not null;column:user_id;index" json:"-"`
+	CredentialID    []byte     `gorm:"not null;column:credential_id;uniqueIndex:user_passkeys_credential_id_unique" json:"-"`
+	PublicKey       []byte     `gorm:"not null;column:public_key" json:"-"`
+	AttestationType string     `gorm:"not null;column:attestation_type" json:"-"`
+	Transports      []string   `gorm:"not null;column:transports;serializer:json" json:"transports"`
+	AAGUID          []byte     `gorm:"column:aaguid" json:"-"`
+	SignCount       uint32     `gorm:"not null;column:sign_count" json:"sign_count"`
+	BackupEligible  bool       `gorm:"not null;column:backup_eligible" json:"-"`
+	BackupState     bool       `gorm:"not null;column:backup_state" json:"-"`
+	LastUsedAt      *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+}
+
+// TableName specifies the table name for the UserPasskey model.
+//
+// Returns:
+//   - string: The name of the database table for the UserPasskey model
+func (UserPasskey) TableName() string {
+	return "user_passkeys"
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// UserSession represents a device a user is logged in from.
+// A session is recorded for every issued login token, which carries its ID.
+// It maps to the "user_sessions" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the session (UUID).
+//   - TenantID: The tenant of the user, which the tokens of the session are bound to.
+//   - UserID: The ID of the user owning this session.
+//   - DeviceName: A readable name derived from the user-agent (e.g., "Chrome on macOS").
+//   - IPAddress: The IP address the user logged in from.
+//   - UserAgent: The raw user-agent of the login request.
+//   - LastSeenAt: The timestamp of the last request made with this session.
+//   - ExpiresAt: When the token tied to this session expires.
+//   - Current: Whether this is the session of the request, not stored.
+//   - CreatedAt: The timestamp when the user logged in.
+//   - UpdatedAt: The timestamp when the session was last updated.
+type UserSession struct {
+	Base
+	TenantID   string    `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID     string    `gorm:"not null;column:user_id;index" json:"-"`
+	DeviceName string    `gorm:"not null;column:device_name" json:"device_name"`
+	IPAddress  string    `gorm:"not null;column:ip_address" json:"ip_address"`
+	UserAgent  string    `gorm:"not null;column:user_agent" json:"user_agent"`
+	LastSeenAt time.Time `gorm:"not null;column:last_seen_at" json:"last_seen_at"`
+	ExpiresAt  time.Time `gorm:"not null;column:expires_at" json:"expires_at"`
+	Current    bool      `gorm:"-" json:"current"`
+}
+
+// TableName specifies the table name for the UserSession model.
+//
+// Returns:
+//   - string: The name of the database table for the UserSession model
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+	"gorm.io/gorm"
+)
+
+// UsernameChange records a change of the username of a user.
+// The old username stays held for the user until HeldUntil, so nobody else can claim it in the meantime, and
+// lookups of the old username resolve to the user until someone else claims it.
+// It maps to the "username_history" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the change (UUID).
+//   - TenantID: The tenant of the user, within which the old username is held.
+//   - UserID: The ID of the user whose username changed.
+//   - OldUsername: The username before the change.
+//   - OldUsernameNormalized: The canonical form of OldUsername, which holds and lookups compare.
+//   - NewUsername: The username after the change.
+//   - HeldUntil: When the old username can be claimed by another user.
+//   - CreatedAt: The timestamp when the username was changed.
+//   - UpdatedAt: The timestamp when the change was last updated.
+type UsernameChange struct {
+	Base
+	TenantID    string    `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	UserID      string    `gorm:"not null;column:user_id;index" json:"-"`
+	OldUsername string    `gorm:"not null;column:old_username;index" json:"old_username"`
+	NewUsername string    `gorm:"not null;column:new_username" json:"new_username"`
+	HeldUntil   time.Time `gorm:"not null;column:held_until" json:"held_until"`
+	User        *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	OldUsernameNormalized *string `gorm:"column:old_username_normalized;index" json:"-"`
+}
+
+// TableName specifies the table name for the UsernameChange model.
+//
+// Returns:
+//   - string: The name of the database table for the UsernameChange model
+func (UsernameChange) TableName() string {
+	return "username_history"
+}
+
+// BeforeCreate is a GORM hook that is triggered before a new UsernameChange record is created in the database.
+// It generates the ID of the change and the canonical form of the old username.
+//
+// Parameters:
+//   - tx: The GORM database transaction
+//
+// Returns:
+//   - error: An error if ID generation fails, otherwise nil
+func (u *UsernameChange) BeforeCreate(tx *gorm.DB) error {
+	oldUsernameNormalized := normalize.Username(u.OldUsername)
+	u.OldUsernameNormalized = &oldUsernameNormalized
+	return u.Base.BeforeCreate(tx)
+}
@@ -0,0 +1,34 @@
+package model
+
+// Kinds of username policy entries.
+const (
+	// UsernamePolicyReserved reserves a username, and the usernames that look like it, for nobody to register.
+	UsernamePolicyReserved = "reserved"
+	// UsernamePolicyBlockedWord blocks the usernames containing a word.
+	UsernamePolicyBlockedWord = "blocked_word"
+	// UsernamePolicyBlockedPattern blocks the usernames matching a regular expression.
+	UsernamePolicyBlockedPattern = "blocked_pattern"
+)
+
+// UsernamePolicyEntry represents an entry of the username lists managed at runtime through the admin API.
+// It maps to the "username_policy_entries" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the entry (UUID).
+//   - Kind: "reserved", "blocked_word" or "blocked_pattern".
+//   - Value: The reserved username, the blocked word or the blocked regular expression.
+//   - CreatedAt: The timestamp when the entry was created.
+//   - UpdatedAt: The timestamp when the entry was last updated.
+type UsernamePolicyEntry struct {
+	Base
+	Kind  string `gorm:"not null;column:kind;uniqueIndex:username_policy_entries_kind_value_unique" json:"kind"`
+	Value string `gorm:"not null;column:value;uniqueIndex:username_policy_entries_kind_value_unique" json:"value"`
+}
+
+// TableName specifies the table name for the UsernamePolicyEntry model.
+//
+// Returns:
+//   - string: The name of the database table for the UsernamePolicyEntry model
+func (UsernamePolicyEntry) TableName() string {
+	return "username_policy_entries"
+}
@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+// Statuses of a webhook delivery.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookSubscription represents a partner endpoint receiving user lifecycle events over HTTP.
+// It maps to the "webhook_subscriptions" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the subscription (UUID).
+//   - TenantID: The tenant the subscription belongs to; it only receives the events of that tenant.
+//   - URL: The endpoint the events are posted to.
+//   - Secret: The key signing the deliveries, shown once when the subscription is created.
+//   - EventTypes: The event types delivered to the endpoint (e.g., "user.created").
+//   - CreatedAt: The timestamp when the subscription was created.
+//   - UpdatedAt: The timestamp when the subscription was last updated.
+type WebhookSubscription struct {
+	Base
+	TenantID   string   `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	URL        string   `gorm:"not null;column:url" json:"url"`
+	Secret     string   `gorm:"not null;column:secret" json:"-"`
+	EventTypes []string `gorm:"not null;column:event_types;serializer:json" json:"event_types"`
+}
+
+// TableName specifies the table name for the WebhookSubscription model.
+//
+// Returns:
+//   - string: The name of the database table for the WebhookSubscription model
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery represents an event to deliver, or delivered, to a webhook subscription.
+// Deliveries double as the delivery log of a subscription.
+// It maps to the "webhook_deliveries" table in the database.
+//
+// Fields:
+//   - ID: The unique identifier for the delivery (UUID).
+//   - TenantID: The tenant of the subscription.
+//   - SubscriptionID: The ID of the subscription the event is delivered to.
+//   - EventID: The ID of the event, the same for every subscription and retry.
+//   - EventType: The type of the event (e.g., "user.created").
+//   - Payload: The JSON encoded state of the user the event is about.
+//   - OccurredAt: When the change described by the event happened.
+//   - Status: "pending" until the endpoint accepts the event, then "succeeded", or "failed" once retries are exhausted.
+//   - Attempts: How many times the delivery was attempted.
+//   - LastStatusCode: The HTTP status code of the last attempt, 0 if no response was received.
+//   - LastError: The error of the last failed attempt.
+//   - NextAttemptAt: When the delivery may be attempted, pushed back after each failed attempt.
+//   - DeliveredAt: When the endpoint accepted the event, nil until then.
+//   - Subscription: The subscription, loaded for the delivery worker only.
+//   - CreatedAt: The timestamp when the delivery was queued.
+//   - UpdatedAt: The timestamp when the delivery was last updated.
+type WebhookDelivery struct {
+	Base
+	TenantID       string               `gorm:"not null;default:default;column:tenant_id" json:"-"`
+	SubscriptionID string               `gorm:"not null;column:subscription_id;uniqueIndex:webhook_deliveries_subscription_event_unique" json:"subscription_id"`
+	EventID        string               `gorm:"not null;column:event_id;uniqueIndex:webhook_deliveries_subscription_event_unique" json:"event_id"`
+	EventType      string               `gorm:"not null;column:event_type" json:"event_type"`
+	Payload        string               `gorm:"not null;column:payload" json:"-"`
+	OccurredAt     time.Time            `gorm:"not null;column:occurred_at" json:"occurred_at"`
+	Status         string               `gorm:"not null;column:status" json:"status"`
+	Attempts       int                  `gorm:"not null;column:attempts" json:"attempts"`
+	LastStatusCode int                  `gorm:"not null;column:last_status_code" json:"last_status_code"`
+	LastError      string               `gorm:"not null;column:last_error" json:"last_error"`
+	NextAttemptAt  time.Time            `gorm:"not null;column:next_attempt_at" json:"next_attempt_at"`
+	DeliveredAt    *time.Time           `gorm:"column:delivered_at" json:"delivered_at"`
+	Subscription   *WebhookSubscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for the WebhookDelivery model.
+//
+// Returns:
+//   - string: The name of the database table for the WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,30 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateToken stores a newly minted personal access token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - token: The token model containing the hash and owning user.
+//
+// Returns:
+//   - *model.PersonalAccessToken: The created token model.
+//   - error: An error if the creation fails, otherwise nil.
+func (a *accessTokenRepository) CreateToken(ctx context.Context, token *model.PersonalAccessToken) (*model.PersonalAccessToken, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateToken")
+	defer s.End()
+
+	err := a.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,81 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestAccessToken_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB    func(t *testing.T) *gorm.DB
+		inputToken *model.PersonalAccessToken
+
+		expectedError error
+	}{
+		{
+			name: "Create token successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputToken: &model.PersonalAccessToken{
+				UserID:      "de305d54-75b4-431b-adb2-eb6b9e546000",
+				Name:        "backup-job",
+				TokenPrefix: "bmpat_abc123",
+				TokenHash:   "hash-003",
+				Scopes:      []string{"bookmarks:read"},
+			},
+		},
+		{
+			name: "Create token failed - hash already stored",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputToken: &model.PersonalAccessToken{
+				UserID:      "de305d54-75b4-431b-adb2-eb6b9e546000",
+				Name:        "backup-job",
+				TokenPrefix: fixture.ActiveAccessToken[:12],
+				TokenHash:   hashOf(fixture.ActiveAccessToken),
+				Scopes:      []string{"bookmarks:read"},
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testAccessTokenRepo := NewAccessTokenRepository(db)
+
+			res, err := testAccessTokenRepo.CreateToken(ctx, tc.inputToken)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, res.ID)
+
+			saved := &model.PersonalAccessToken{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputToken.TokenHash, saved.TokenHash)
+			assert.Equal(t, tc.inputToken.Scopes, saved.Scopes)
+		})
+	}
+}
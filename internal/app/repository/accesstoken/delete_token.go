@@ -0,0 +1,37 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DeleteToken revokes a personal access token of a user.
+// The user ID is part of the condition so a user can never revoke someone else's token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the token.
+//   - tokenID: The ID of the token to revoke.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user owns no such token, otherwise any deletion error.
+func (a *accessTokenRepository) DeleteToken(ctx context.Context, userID, tokenID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteToken")
+	defer s.End()
+
+	result := a.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", tokenID, userID).
+		Delete(&model.PersonalAccessToken{})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestAccessToken_DeleteToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB      func(t *testing.T) *gorm.DB
+		inputUserID  string
+		inputTokenID string
+
+		expectedError error
+	}{
+		{
+			name: "Delete token successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputUserID:  "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputTokenID: "7a3c9e1f-5b2d-4f6a-8c0e-2d4f6a8c0e13",
+		},
+		{
+			name: "Delete token failed - token owned by another user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputUserID:  "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputTokenID: "7a3c9e1f-5b2d-4f6a-8c0e-2d4f6a8c0e13",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testAccessTokenRepo := NewAccessTokenRepository(db)
+
+			err := testAccessTokenRepo.DeleteToken(ctx, tc.inputUserID, tc.inputTokenID)
+			assert.Equal(t, tc.expectedError, err)
+
+			var count int64
+			db.Model(&model.PersonalAccessToken{}).Where("id = ?", tc.inputTokenID).Count(&count)
+			if tc.expectedError == nil {
+				assert.Equal(t, int64(0), count)
+			} else {
+				assert.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetTokenByHash retrieves the personal access token matching a hash.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - tokenHash: The SHA-256 hash of the presented token.
+//
+// Returns:
+//   - *model.PersonalAccessToken: The token model if found.
+//   - error: dbutils.ErrRecordNotFoundType if no token matches, otherwise any retrieval error.
+func (a *accessTokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetTokenByHash")
+	defer s.End()
+
+	token := &model.PersonalAccessToken{}
+	err := a.db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(token).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,73 @@
+package accesstoken
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestAccessToken_GetTokenByHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB   func(t *testing.T) *gorm.DB
+		inputHash string
+
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name: "Get token by hash successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputHash: hashOf(fixture.ActiveAccessToken),
+
+			expectedID: "7a3c9e1f-5b2d-4f6a-8c0e-2d4f6a8c0e13",
+		},
+		{
+			name: "Get token by hash failed - unknown hash",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputHash: hashOf("bmpat_unknown"),
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testAccessTokenRepo := NewAccessTokenRepository(db)
+
+			res, err := testAccessTokenRepo.GetTokenByHash(ctx, tc.inputHash)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedID, res.ID)
+		})
+	}
+}
+
+// hashOf returns the hex-encoded SHA-256 hash stored for a token.
+func hashOf(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
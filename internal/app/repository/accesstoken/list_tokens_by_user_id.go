@@ -0,0 +1,34 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListTokensByUserID retrieves all personal access tokens of a user, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the tokens.
+//
+// Returns:
+//   - []*model.PersonalAccessToken: The tokens of the user, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (a *accessTokenRepository) ListTokensByUserID(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListTokensByUserID")
+	defer s.End()
+
+	tokens := []*model.PersonalAccessToken{}
+	err := a.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,65 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestAccessToken_ListTokensByUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+
+		expectedNames []string
+		expectedError error
+	}{
+		{
+			name: "List tokens of a user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedNames: []string{"ci-script", "old-script"},
+		},
+		{
+			name: "List tokens of a user without any token",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputUserID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedNames: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testAccessTokenRepo := NewAccessTokenRepository(db)
+
+			res, err := testAccessTokenRepo.ListTokensByUserID(ctx, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+
+			names := []string{}
+			for _, token := range res {
+				names = append(names, token.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
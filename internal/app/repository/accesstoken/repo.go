@@ -0,0 +1,83 @@
+// Package accesstoken provides repository operations for personal access tokens using GORM.
+package accesstoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for personal access token repository operations.
+//
+//go:generate mockery --name=Repository --filename=access_token_repo.go --output=./mocks
+type Repository interface {
+	// CreateToken stores a newly minted personal access token.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - token: The token model containing the hash and owning user.
+	//
+	// Returns:
+	//   - *model.PersonalAccessToken: The created token model.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateToken(ctx context.Context, token *model.PersonalAccessToken) (*model.PersonalAccessToken, error)
+
+	// ListTokensByUserID retrieves all personal access tokens of a user, oldest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the tokens.
+	//
+	// Returns:
+	//   - []*model.PersonalAccessToken: The tokens of the user, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListTokensByUserID(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error)
+
+	// GetTokenByHash retrieves the personal access token matching a hash.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - tokenHash: The SHA-256 hash of the presented token.
+	//
+	// Returns:
+	//   - *model.PersonalAccessToken: The token model if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no token matches, otherwise any retrieval error.
+	GetTokenByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+
+	// DeleteToken revokes a personal access token of a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the token.
+	//   - tokenID: The ID of the token to revoke.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user owns no such token, otherwise any deletion error.
+	DeleteToken(ctx context.Context, userID, tokenID string) error
+
+	// UpdateTokenLastUsed records when a personal access token was last used.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - tokenID: The ID of the token used.
+	//   - usedAt: When the token was used.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the token does not exist, otherwise any update error.
+	UpdateTokenLastUsed(ctx context.Context, tokenID string, usedAt time.Time) error
+}
+
+// accessTokenRepository is the concrete implementation of the Repository interface.
+type accessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAccessTokenRepository creates a new instance of the personal access token repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - Repository: A new personal access token repository instance.
+func NewAccessTokenRepository(db *gorm.DB) Repository {
+	return &accessTokenRepository{
+		db: db,
+	}
+}
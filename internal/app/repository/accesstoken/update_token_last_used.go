@@ -0,0 +1,38 @@
+package accesstoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateTokenLastUsed records when a personal access token was last used.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - tokenID: The ID of the token used.
+//   - usedAt: When the token was used.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the token does not exist, otherwise any update error.
+func (a *accessTokenRepository) UpdateTokenLastUsed(ctx context.Context, tokenID string, usedAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateTokenLastUsed")
+	defer s.End()
+
+	result := a.db.WithContext(ctx).
+		Model(&model.PersonalAccessToken{}).
+		Where("id = ?", tokenID).
+		Update("last_used_at", usedAt)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
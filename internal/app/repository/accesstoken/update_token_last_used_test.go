@@ -0,0 +1,67 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestAccessToken_UpdateTokenLastUsed(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB      func(t *testing.T) *gorm.DB
+		inputTokenID string
+
+		expectedError error
+	}{
+		{
+			name: "Update token last used successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputTokenID: "7a3c9e1f-5b2d-4f6a-8c0e-2d4f6a8c0e13",
+		},
+		{
+			name: "Update token last used failed - token not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			},
+
+			inputTokenID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testAccessTokenRepo := NewAccessTokenRepository(db)
+
+			err := testAccessTokenRepo.UpdateTokenLastUsed(ctx, tc.inputTokenID, fixture.TestTime)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.PersonalAccessToken{}
+			err = db.Where("id = ?", tc.inputTokenID).First(saved).Error
+			assert.Nil(t, err)
+			assert.NotNil(t, saved.LastUsedAt)
+			assert.True(t, fixture.TestTime.Equal(*saved.LastUsedAt))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package emailchange
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// ConfirmEmailChange applies a pending email change: the user gets the new address, recorded as verified, and the
+// change is claimed as confirmed in the same transaction. Of concurrent confirm and cancel attempts only one claims
+// the change, and if the address cannot be written the change stays pending.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - change: The pending email change.
+//   - confirmedAt: When the change is confirmed.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the change is no longer pending or the user does not exist,
+//     dbutils.ErrDuplicationType if another user took the address meanwhile, otherwise any update error.
+func (e *emailChangeRepository) ConfirmEmailChange(ctx context.Context, change *model.EmailChange, confirmedAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ConfirmEmailChange")
+	defer s.End()
+
+	user := &model.User{Email: change.NewEmail, EmailVerifiedAt: &confirmedAt}
+	return e.userRepo.UpdateUserFieldsByIDWith(ctx, change.UserID, user, []string{"email", "email_verified_at"}, func(tx *gorm.DB) error {
+		return updateStatusInTx(tx, change.ID, model.EmailChangePending, &model.EmailChange{
+			Status:      model.EmailChangeConfirmed,
+			ConfirmedAt: &confirmedAt,
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package emailchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_ConfirmEmailChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputChange *model.EmailChange
+
+		expectedError  error
+		expectedEmail  string
+		expectedStatus string
+	}{
+		{
+			name: "Confirm pending email change successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				Base:     model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"},
+				UserID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				NewEmail: "testuser001new@example.com",
+			},
+
+			expectedEmail:  "testuser001new@example.com",
+			expectedStatus: model.EmailChangeConfirmed,
+		},
+		{
+			name: "Confirm email change failed - no longer pending, the address is left as it was",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				Base:     model.Base{ID: "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03"},
+				UserID:   "987e6543-e21b-12d3-a456-eb6b9e546002",
+				NewEmail: "charlie.new@example.com",
+			},
+
+			expectedError:  dbutils.ErrRecordNotFoundType,
+			expectedEmail:  "charlie@example.com",
+			expectedStatus: model.EmailChangeConfirmed,
+		},
+		{
+			name: "Confirm email change failed - email taken, the change stays pending",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				Base:     model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"},
+				UserID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				NewEmail: "bob@example.com",
+			},
+
+			expectedError:  dbutils.ErrDuplicationType,
+			expectedEmail:  "testuser001@example.com",
+			expectedStatus: model.EmailChangePending,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, userRepository.NewUserRepository(db))
+
+			err := testEmailChangeRepo.ConfirmEmailChange(ctx, tc.inputChange, fixture.TestTime)
+			assert.Equal(t, tc.expectedError, err)
+
+			user := &model.User{}
+			err = db.Where("id = ?", tc.inputChange.UserID).First(user).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedEmail, user.Email)
+
+			change := &model.EmailChange{}
+			err = db.Where("id = ?", tc.inputChange.ID).First(change).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatus, change.Status)
+
+			if tc.expectedError == nil {
+				assert.True(t, fixture.TestTime.Equal(*user.EmailVerifiedAt))
+				assert.True(t, fixture.TestTime.Equal(*change.ConfirmedAt))
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package emailchange
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// CreateEmailChange stores a new pending email change, superseding the other pending changes of the user,
+// so only the link of the latest request can be confirmed.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - change: The email change to store.
+//
+// Returns:
+//   - *model.EmailChange: The created email change.
+//   - error: An error if the creation fails, otherwise nil.
+func (e *emailChangeRepository) CreateEmailChange(ctx context.Context, change *model.EmailChange) (*model.EmailChange, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateEmailChange")
+	defer s.End()
+
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.EmailChange{}).
+			Where("user_id = ? AND status = ?", change.UserID, model.EmailChangePending).
+			Update("status", model.EmailChangeSuperseded).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(change).Error
+	})
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return change, nil
+}
@@ -0,0 +1,116 @@
+package emailchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_CreateEmailChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputChange *model.EmailChange
+
+		expectedError           error
+		expectedSupersededCount int64
+	}{
+		{
+			name: "Create email change superseding the pending one",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				UserID:           "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				OldEmail:         "testuser001@example.com",
+				NewEmail:         "testuser001other@example.com",
+				ConfirmTokenHash: "confirm-hash-004",
+				CancelTokenHash:  "cancel-hash-004",
+				Status:           model.EmailChangePending,
+				ExpiresAt:        fixture.TestTime.Add(24 * time.Hour),
+				CancelExpiresAt:  fixture.TestTime.Add(72 * time.Hour),
+			},
+
+			expectedSupersededCount: 1,
+		},
+		{
+			name: "Create first email change of a user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				UserID:           "de305d54-75b4-431b-adb2-eb6b9e546000",
+				OldEmail:         "alice@example.com",
+				NewEmail:         "alice.new@example.com",
+				ConfirmTokenHash: "confirm-hash-005",
+				CancelTokenHash:  "cancel-hash-005",
+				Status:           model.EmailChangePending,
+				ExpiresAt:        fixture.TestTime.Add(24 * time.Hour),
+				CancelExpiresAt:  fixture.TestTime.Add(72 * time.Hour),
+			},
+		},
+		{
+			name: "Create email change failed - token hash already stored",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				UserID:           "de305d54-75b4-431b-adb2-eb6b9e546000",
+				OldEmail:         "alice@example.com",
+				NewEmail:         "alice.new@example.com",
+				ConfirmTokenHash: hashOf(fixture.PendingEmailChangeConfirmToken),
+				CancelTokenHash:  "cancel-hash-006",
+				Status:           model.EmailChangePending,
+				ExpiresAt:        fixture.TestTime.Add(24 * time.Hour),
+				CancelExpiresAt:  fixture.TestTime.Add(72 * time.Hour),
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, nil)
+
+			res, err := testEmailChangeRepo.CreateEmailChange(ctx, tc.inputChange)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, res.ID)
+
+			var superseded int64
+			err = db.Model(&model.EmailChange{}).
+				Where("user_id = ? AND status = ?", tc.inputChange.UserID, model.EmailChangeSuperseded).
+				Count(&superseded).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedSupersededCount, superseded)
+
+			saved := &model.EmailChange{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.EmailChangePending, saved.Status)
+			assert.Equal(t, tc.inputChange.NewEmail, saved.NewEmail)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package emailchange
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DeleteEmailChange deletes a pending email change, such as one whose links could not be sent.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the email change.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if no pending change has the ID, otherwise any deletion error.
+func (e *emailChangeRepository) DeleteEmailChange(ctx context.Context, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteEmailChange")
+	defer s.End()
+
+	result := e.db.WithContext(ctx).
+		Where("id = ? AND status = ?", id, model.EmailChangePending).
+		Delete(&model.EmailChange{})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package emailchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_DeleteEmailChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB func(t *testing.T) *gorm.DB
+		inputID string
+
+		expectedError error
+	}{
+		{
+			name: "Delete pending email change successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01",
+		},
+		{
+			name: "Delete email change failed - not pending",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputID: "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, nil)
+
+			err := testEmailChangeRepo.DeleteEmailChange(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+
+			var count int64
+			err = db.Model(&model.EmailChange{}).Where("id = ?", tc.inputID).Count(&count).Error
+			assert.Nil(t, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, int64(0), count)
+			} else {
+				assert.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package emailchange
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetEmailChangeByCancelTokenHash retrieves the email change whose cancel link carries the hashed token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - tokenHash: The SHA-256 hash of the presented token.
+//
+// Returns:
+//   - *model.EmailChange: The email change if found.
+//   - error: dbutils.ErrRecordNotFoundType if no change matches, otherwise any retrieval error.
+func (e *emailChangeRepository) GetEmailChangeByCancelTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetEmailChangeByCancelTokenHash")
+	defer s.End()
+
+	return e.getEmailChangeByField(ctx, "cancel_token_hash", tokenHash)
+}
@@ -0,0 +1,65 @@
+package emailchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_GetEmailChangeByCancelTokenHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB   func(t *testing.T) *gorm.DB
+		inputHash string
+
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name: "Get email change by cancel token hash successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputHash: hashOf(fixture.ConfirmedEmailChangeCancelToken),
+
+			expectedID: "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03",
+		},
+		{
+			name: "Get email change by cancel token hash failed - confirm token hash",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputHash: hashOf(fixture.ConfirmedEmailChangeConfirmToken),
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, nil)
+
+			res, err := testEmailChangeRepo.GetEmailChangeByCancelTokenHash(ctx, tc.inputHash)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedID, res.ID)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package emailchange
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetEmailChangeByConfirmTokenHash retrieves the email change whose confirm link carries the hashed token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - tokenHash: The SHA-256 hash of the presented token.
+//
+// Returns:
+//   - *model.EmailChange: The email change if found.
+//   - error: dbutils.ErrRecordNotFoundType if no change matches, otherwise any retrieval error.
+func (e *emailChangeRepository) GetEmailChangeByConfirmTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetEmailChangeByConfirmTokenHash")
+	defer s.End()
+
+	return e.getEmailChangeByField(ctx, "confirm_token_hash", tokenHash)
+}
@@ -0,0 +1,73 @@
+package emailchange
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_GetEmailChangeByConfirmTokenHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB   func(t *testing.T) *gorm.DB
+		inputHash string
+
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name: "Get email change by confirm token hash successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputHash: hashOf(fixture.PendingEmailChangeConfirmToken),
+
+			expectedID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01",
+		},
+		{
+			name: "Get email change by confirm token hash failed - cancel token hash",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputHash: hashOf(fixture.PendingEmailChangeCancelToken),
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, nil)
+
+			res, err := testEmailChangeRepo.GetEmailChangeByConfirmTokenHash(ctx, tc.inputHash)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedID, res.ID)
+		})
+	}
+}
+
+// hashOf returns the hex-encoded SHA-256 hash stored for a token.
+func hashOf(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,20 @@
+package emailchange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// getEmailChangeByField retrieves the email change whose field matches value.
+func (e *emailChangeRepository) getEmailChangeByField(ctx context.Context, field, value string) (*model.EmailChange, error) {
+	change := &model.EmailChange{}
+	err := e.db.WithContext(ctx).Where(fmt.Sprintf("%s = ?", field), value).First(change).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return change, nil
+}
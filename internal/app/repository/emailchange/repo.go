@@ -0,0 +1,113 @@
+// Package emailchange provides repository operations for pending email address changes using GORM.
+package emailchange
+
+import (
+	"context"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for email change repository operations.
+//
+//go:generate mockery --name=Repository --filename=email_change_repo.go --output=./mocks
+type Repository interface {
+	// CreateEmailChange stores a new pending email change.
+	// The other pending changes of the user are superseded by it in the same transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - change: The email change to store.
+	//
+	// Returns:
+	//   - *model.EmailChange: The created email change.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateEmailChange(ctx context.Context, change *model.EmailChange) (*model.EmailChange, error)
+
+	// GetEmailChangeByConfirmTokenHash retrieves the email change whose confirm link carries the hashed token.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - tokenHash: The SHA-256 hash of the presented token.
+	//
+	// Returns:
+	//   - *model.EmailChange: The email change if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no change matches, otherwise any retrieval error.
+	GetEmailChangeByConfirmTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error)
+
+	// GetEmailChangeByCancelTokenHash retrieves the email change whose cancel link carries the hashed token.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - tokenHash: The SHA-256 hash of the presented token.
+	//
+	// Returns:
+	//   - *model.EmailChange: The email change if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no change matches, otherwise any retrieval error.
+	GetEmailChangeByCancelTokenHash(ctx context.Context, tokenHash string) (*model.EmailChange, error)
+
+	// UpdateEmailChangeStatus moves an email change to a new status, provided it is still in fromStatus.
+	// The status, confirmed_at and cancelled_at columns are written from the given change, nil times included.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the email change.
+	//   - fromStatus: The status the change must still be in.
+	//   - change: The new status and times of the change.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if no change with the ID is in fromStatus, otherwise any update error.
+	UpdateEmailChangeStatus(ctx context.Context, id, fromStatus string, change *model.EmailChange) error
+
+	// ConfirmEmailChange gives the user the new address of a pending email change and claims the change as
+	// confirmed, in a single transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - change: The pending email change.
+	//   - confirmedAt: When the change is confirmed.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the change is no longer pending or the user does not exist,
+	//     dbutils.ErrDuplicationType if another user took the address meanwhile, otherwise any update error.
+	ConfirmEmailChange(ctx context.Context, change *model.EmailChange, confirmedAt time.Time) error
+
+	// RevertEmailChange gives the user the old address of a confirmed email change back and claims the change as
+	// cancelled, in a single transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - change: The confirmed email change.
+	//   - cancelledAt: When the change is cancelled.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the change is no longer confirmed or the user does not exist,
+	//     dbutils.ErrDuplicationType if another user took the old address meanwhile, otherwise any update error.
+	RevertEmailChange(ctx context.Context, change *model.EmailChange, cancelledAt time.Time) error
+
+	// DeleteEmailChange deletes a pending email change.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the email change.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if no pending change has the ID, otherwise any deletion error.
+	DeleteEmailChange(ctx context.Context, id string) error
+}
+
+// emailChangeRepository is the concrete implementation of the Repository interface.
+type emailChangeRepository struct {
+	db       *gorm.DB
+	userRepo userRepository.Repository
+}
+
+// NewEmailChangeRepository creates a new instance of the email change repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//   - userRepo: The user repository writing the addresses of the confirmed and reverted changes.
+//
+// Returns:
+//   - Repository: A new email change repository instance.
+func NewEmailChangeRepository(db *gorm.DB, userRepo userRepository.Repository) Repository {
+	return &emailChangeRepository{
+		db:       db,
+		userRepo: userRepo,
+	}
+}
@@ -0,0 +1,36 @@
+package emailchange
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// RevertEmailChange cancels a confirmed email change: the user gets the old address back, recorded as verified, and
+// the change is claimed as cancelled in the same transaction. If the address cannot be written the change stays
+// confirmed.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - change: The confirmed email change.
+//   - cancelledAt: When the change is cancelled.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the change is no longer confirmed or the user does not exist,
+//     dbutils.ErrDuplicationType if another user took the old address meanwhile, otherwise any update error.
+func (e *emailChangeRepository) RevertEmailChange(ctx context.Context, change *model.EmailChange, cancelledAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_RevertEmailChange")
+	defer s.End()
+
+	user := &model.User{Email: change.OldEmail, EmailVerifiedAt: &cancelledAt}
+	return e.userRepo.UpdateUserFieldsByIDWith(ctx, change.UserID, user, []string{"email", "email_verified_at"}, func(tx *gorm.DB) error {
+		return updateStatusInTx(tx, change.ID, model.EmailChangeConfirmed, &model.EmailChange{
+			Status:      model.EmailChangeCancelled,
+			ConfirmedAt: change.ConfirmedAt,
+			CancelledAt: &cancelledAt,
+		})
+	})
+}
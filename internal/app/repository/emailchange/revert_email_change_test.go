@@ -0,0 +1,95 @@
+package emailchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_RevertEmailChange(t *testing.T) {
+	t.Parallel()
+
+	confirmedAt := fixture.TestTime.Add(time.Hour)
+	cancelledAt := fixture.TestTime.Add(2 * time.Hour)
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputChange *model.EmailChange
+
+		expectedError  error
+		expectedEmail  string
+		expectedStatus string
+	}{
+		{
+			name: "Revert confirmed email change successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				Base:        model.Base{ID: "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03"},
+				UserID:      "987e6543-e21b-12d3-a456-eb6b9e546002",
+				OldEmail:    "charlie.old@example.com",
+				ConfirmedAt: &confirmedAt,
+			},
+
+			expectedEmail:  "charlie.old@example.com",
+			expectedStatus: model.EmailChangeCancelled,
+		},
+		{
+			name: "Revert email change failed - not confirmed, the address is left as it was",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputChange: &model.EmailChange{
+				Base:     model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"},
+				UserID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				OldEmail: "testuser001old@example.com",
+			},
+
+			expectedError:  dbutils.ErrRecordNotFoundType,
+			expectedEmail:  "testuser001@example.com",
+			expectedStatus: model.EmailChangePending,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, userRepository.NewUserRepository(db))
+
+			err := testEmailChangeRepo.RevertEmailChange(ctx, tc.inputChange, cancelledAt)
+			assert.Equal(t, tc.expectedError, err)
+
+			user := &model.User{}
+			err = db.Where("id = ?", tc.inputChange.UserID).First(user).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedEmail, user.Email)
+
+			change := &model.EmailChange{}
+			err = db.Where("id = ?", tc.inputChange.ID).First(change).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatus, change.Status)
+
+			if tc.expectedError == nil {
+				assert.True(t, cancelledAt.Equal(*user.EmailVerifiedAt))
+				assert.True(t, cancelledAt.Equal(*change.CancelledAt))
+				assert.True(t, confirmedAt.Equal(*change.ConfirmedAt))
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package emailchange
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// UpdateEmailChangeStatus moves an email change to a new status, provided it is still in fromStatus.
+// The condition makes the transition a claim: of concurrent confirm and cancel attempts, only one succeeds.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the email change.
+//   - fromStatus: The status the change must still be in.
+//   - change: The new status and times of the change.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if no change with the ID is in fromStatus, otherwise any update error.
+func (e *emailChangeRepository) UpdateEmailChangeStatus(ctx context.Context, id, fromStatus string, change *model.EmailChange) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateEmailChangeStatus")
+	defer s.End()
+
+	return dbutils.CatchDBError(updateStatusInTx(e.db.WithContext(ctx), id, fromStatus, change))
+}
+
+// updateStatusInTx is UpdateEmailChangeStatus within the transaction tx, for writes that change the user as well.
+func updateStatusInTx(tx *gorm.DB, id, fromStatus string, change *model.EmailChange) error {
+	result := tx.Model(&model.EmailChange{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Select("status", "confirmed_at", "cancelled_at", "updated_at").
+		Updates(change)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
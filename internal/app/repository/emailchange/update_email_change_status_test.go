@@ -0,0 +1,91 @@
+package emailchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestEmailChange_UpdateEmailChangeStatus(t *testing.T) {
+	t.Parallel()
+
+	confirmedAt := fixture.TestTime
+
+	testCases := []struct {
+		name string
+
+		setupDB         func(t *testing.T) *gorm.DB
+		inputID         string
+		inputFromStatus string
+		inputChange     *model.EmailChange
+
+		expectedError error
+	}{
+		{
+			name: "Confirm pending email change successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputID:         "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01",
+			inputFromStatus: model.EmailChangePending,
+			inputChange:     &model.EmailChange{Status: model.EmailChangeConfirmed, ConfirmedAt: &confirmedAt},
+		},
+		{
+			name: "Release confirmed email change back to pending",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputID:         "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03",
+			inputFromStatus: model.EmailChangeConfirmed,
+			inputChange:     &model.EmailChange{Status: model.EmailChangePending},
+		},
+		{
+			name: "Update email change status failed - no longer in the expected status",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			},
+
+			inputID:         "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03",
+			inputFromStatus: model.EmailChangePending,
+			inputChange:     &model.EmailChange{Status: model.EmailChangeConfirmed, ConfirmedAt: &confirmedAt},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testEmailChangeRepo := NewEmailChangeRepository(db, nil)
+
+			err := testEmailChangeRepo.UpdateEmailChangeStatus(ctx, tc.inputID, tc.inputFromStatus, tc.inputChange)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.EmailChange{}
+			err = db.Where("id = ?", tc.inputID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputChange.Status, saved.Status)
+			if tc.inputChange.ConfirmedAt == nil {
+				assert.Nil(t, saved.ConfirmedAt)
+			} else {
+				assert.True(t, tc.inputChange.ConfirmedAt.Equal(*saved.ConfirmedAt))
+			}
+			assert.Nil(t, saved.CancelledAt)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// SaveAuthState stores the state of an authorization-code flow until the provider calls back.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - state: The opaque state value sent to the provider.
+//   - authState: The data to remember for the callback.
+//   - exp: How long the state stays valid.
+//
+// Returns:
+//   - error: An error if the state cannot be stored, otherwise nil.
+func (i *identityRepository) SaveAuthState(ctx context.Context, state string, authState *model.AuthState, exp time.Duration) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_SaveAuthState")
+	defer s.End()
+
+	data, err := json.Marshal(authState)
+	if err != nil {
+		return err
+	}
+
+	return i.redisClient.Set(ctx, fmt.Sprintf(AuthStateKeyFormat, tenant.FromContext(ctx), state), data, exp).Err()
+}
+
+// ConsumeAuthState retrieves and deletes the state of an authorization-code flow, so it can only be used once.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - state: The opaque state value returned by the provider.
+//
+// Returns:
+//   - *model.AuthState: The stored state if found.
+//   - error: dbutils.ErrRecordNotFoundType if the state is unknown or expired, otherwise nil.
+func (i *identityRepository) ConsumeAuthState(ctx context.Context, state string) (*model.AuthState, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ConsumeAuthState")
+	defer s.End()
+
+	data, err := i.redisClient.GetDel(ctx, fmt.Sprintf(AuthStateKeyFormat, tenant.FromContext(ctx), state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, dbutils.ErrRecordNotFoundType
+		}
+		return nil, err
+	}
+
+	authState := &model.AuthState{}
+	if err := json.Unmarshal(data, authState); err != nil {
+		return nil, err
+	}
+
+	return authState, nil
+}
@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+func TestIdentity_AuthState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+		inputState string
+
+		expectedError  error
+		expectedOutput *model.AuthState
+	}{
+		{
+			name: "Consume saved state successfully",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				err := NewIdentityRepository(nil, redisClient, nil).SaveAuthState(ctx, "state-001", &model.AuthState{
+					Provider:     "mockidp",
+					Nonce:        "nonce-001",
+					CodeVerifier: "verifier-001",
+				}, time.Minute)
+				assert.Nil(t, err)
+				return redisClient
+			},
+			inputState: "state-001",
+
+			expectedOutput: &model.AuthState{
+				Provider:     "mockidp",
+				Nonce:        "nonce-001",
+				CodeVerifier: "verifier-001",
+			},
+		},
+		{
+			name: "Consume unknown state",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			inputState: "unknown-state",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := tc.setupRedis(ctx)
+			testIdentityRepo := NewIdentityRepository(nil, redisClient, nil)
+
+			res, err := testIdentityRepo.ConsumeAuthState(ctx, tc.inputState)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedOutput, res)
+
+			// a state can only be consumed once
+			_, err = testIdentityRepo.ConsumeAuthState(ctx, tc.inputState)
+			assert.Equal(t, dbutils.ErrRecordNotFoundType, err)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateIdentity links a new external identity to an existing user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - identity: The identity model containing the provider, subject and owning user.
+//
+// Returns:
+//   - *model.UserIdentity: The created identity model.
+//   - error: An error if the creation fails, otherwise nil.
+func (i *identityRepository) CreateIdentity(ctx context.Context, identity *model.UserIdentity) (*model.UserIdentity, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateIdentity")
+	defer s.End()
+
+	err := i.db.WithContext(ctx).Create(identity).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return identity, nil
+}
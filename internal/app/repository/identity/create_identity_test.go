@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestIdentity_CreateIdentity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB       func(t *testing.T) *gorm.DB
+		inputIdentity *model.UserIdentity
+
+		expectedError error
+		verifyFunc    func(db *gorm.DB, identity *model.UserIdentity)
+	}{
+		{
+			name: "Create identity successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputIdentity: &model.UserIdentity{
+				UserID:   "de305d54-75b4-431b-adb2-eb6b9e546000",
+				Provider: "mockidp",
+				Subject:  "alice-subject",
+				Email:    "alice@example.com",
+			},
+
+			verifyFunc: func(db *gorm.DB, identity *model.UserIdentity) {
+				checkIdentity := &model.UserIdentity{}
+				err := db.Where("id = ?", identity.ID).First(checkIdentity).Error
+				assert.Nil(t, err)
+				assert.Equal(t, "de305d54-75b4-431b-adb2-eb6b9e546000", checkIdentity.UserID)
+				assert.Equal(t, "alice-subject", checkIdentity.Subject)
+			},
+		},
+		{
+			name: "Create identity failed - subject already linked",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputIdentity: &model.UserIdentity{
+				UserID:   "de305d54-75b4-431b-adb2-eb6b9e546000",
+				Provider: "mockidp",
+				Subject:  "mockidp-subject-001",
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testIdentityRepo := NewIdentityRepository(db, nil, nil)
+
+			res, err := testIdentityRepo.CreateIdentity(ctx, tc.inputIdentity)
+			if err != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+			tc.verifyFunc(db, res)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// CreateUserWithIdentity creates a new user and links an external identity to it in a single transaction.
+// If either insert fails, nothing is written. The user is created through the user repository, which adds the
+// user.created event to the outbox in the same transaction and drops what it cached about the username and ID.
+// A username still held after another user changed it counts as taken.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user model to be created.
+//   - identity: The identity model to be linked to the new user.
+//
+// Returns:
+//   - *model.User: The created user model.
+//   - error: dbutils.ErrDuplicationType if the username or email is taken, otherwise an error if either insert fails.
+func (i *identityRepository) CreateUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateUserWithIdentity")
+	defer s.End()
+
+	return i.userRepo.CreateUserWith(ctx, user, func(tx *gorm.DB) error {
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
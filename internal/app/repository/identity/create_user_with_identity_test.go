@@ -0,0 +1,99 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestIdentity_CreateUserWithIdentity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB       func(t *testing.T) *gorm.DB
+		inputUser     *model.User
+		inputIdentity *model.UserIdentity
+
+		expectedError error
+		verifyFunc    func(db *gorm.DB, user *model.User)
+	}{
+		{
+			name: "Create user with identity successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUser: &model.User{
+				Username:    "newfederated",
+				DisplayName: "New Federated",
+				Email:       "newfederated@example.com",
+			},
+			inputIdentity: &model.UserIdentity{
+				Provider: "mockidp",
+				Subject:  "new-subject",
+				Email:    "newfederated@example.com",
+			},
+
+			verifyFunc: func(db *gorm.DB, user *model.User) {
+				checkIdentity := &model.UserIdentity{}
+				err := db.Where("provider = ? AND subject = ?", "mockidp", "new-subject").First(checkIdentity).Error
+				assert.Nil(t, err)
+				assert.Equal(t, user.ID, checkIdentity.UserID)
+
+				checkEvent := &model.OutboxEvent{}
+				err = db.Where("aggregate_id = ?", user.ID).First(checkEvent).Error
+				assert.Nil(t, err)
+				assert.Equal(t, "user.created", checkEvent.EventType)
+			},
+		},
+		{
+			name: "Create user with identity failed - identity already linked, user is rolled back",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUser: &model.User{
+				Username:    "rolledback",
+				DisplayName: "Rolled Back",
+				Email:       "rolledback@example.com",
+			},
+			inputIdentity: &model.UserIdentity{
+				Provider: "mockidp",
+				Subject:  "mockidp-subject-001",
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+			verifyFunc: func(db *gorm.DB, user *model.User) {
+				var count int64
+				db.Model(&model.User{}).Where("username = ?", "rolledback").Count(&count)
+				assert.Equal(t, int64(0), count)
+
+				db.Model(&model.OutboxEvent{}).Count(&count)
+				assert.Equal(t, int64(0), count)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testIdentityRepo := NewIdentityRepository(db, nil, userRepository.NewUserRepository(db))
+
+			res, err := testIdentityRepo.CreateUserWithIdentity(ctx, tc.inputUser, tc.inputIdentity)
+			assert.Equal(t, tc.expectedError, err)
+			tc.verifyFunc(db, res)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeleteIdentity unlinks an identity from the user owning it.
+// The user ID is part of the condition so a user can never unlink someone else's identity.
+// A user must always keep a way to log in: the identity is only deleted if the user has a password, another
+// identity or a passkey. The user row is locked while the credentials are counted, so two concurrent unlinks
+// cannot each count the identity of the other as left.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the identity.
+//   - identityID: The ID of the identity to unlink.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user owns no such identity, ErrLastCredential if it is the last
+//     way for the user to log in, otherwise any deletion error.
+func (i *identityRepository) DeleteIdentity(ctx context.Context, userID, identityID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteIdentity")
+	defer s.End()
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user := &model.User{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "password").
+			Where("id = ?", userID).
+			First(user).Error
+		if err != nil {
+			return err
+		}
+
+		identity := &model.UserIdentity{}
+		if err := tx.Where("id = ? AND user_id = ?", identityID, userID).First(identity).Error; err != nil {
+			return err
+		}
+
+		if !user.HasPassword() {
+			var otherIdentities, passkeys int64
+			err := tx.Model(&model.UserIdentity{}).Where("user_id = ? AND id <> ?", userID, identityID).Count(&otherIdentities).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&model.UserPasskey{}).Where("user_id = ?", userID).Count(&passkeys).Error; err != nil {
+				return err
+			}
+			if otherIdentities+passkeys == 0 {
+				return ErrLastCredential
+			}
+		}
+
+		return tx.Delete(identity).Error
+	})
+
+	return dbutils.CatchDBError(err)
+}
@@ -0,0 +1,100 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestIdentity_DeleteIdentity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB         func(t *testing.T) *gorm.DB
+		inputUserID     string
+		inputIdentityID string
+
+		expectedError error
+	}{
+		{
+			name: "Delete identity successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUserID:     "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputIdentityID: "5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00",
+		},
+		{
+			name: "Delete identity failed - identity owned by another user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUserID:     "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputIdentityID: "5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Delete the only identity of a user without a password but with a passkey",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+				err := db.Create(&model.UserPasskey{
+					UserID:          "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+					CredentialID:    []byte("credential-federated-001"),
+					PublicKey:       []byte("public-key-federated-001"),
+					AttestationType: "none",
+					Transports:      []string{"internal"},
+				}).Error
+				assert.Nil(t, err)
+				return db
+			},
+
+			inputUserID:     "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+			inputIdentityID: "6c1f7b3f-3e2d-4d4f-8b62-1d9f8e3e2b11",
+		},
+		{
+			name: "Delete identity failed - last credential of a user without a password",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUserID:     "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+			inputIdentityID: "6c1f7b3f-3e2d-4d4f-8b62-1d9f8e3e2b11",
+
+			expectedError: ErrLastCredential,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testIdentityRepo := NewIdentityRepository(db, nil, nil)
+
+			err := testIdentityRepo.DeleteIdentity(ctx, tc.inputUserID, tc.inputIdentityID)
+			assert.Equal(t, tc.expectedError, err)
+
+			var count int64
+			db.Model(&model.UserIdentity{}).Where("id = ?", tc.inputIdentityID).Count(&count)
+			if tc.expectedError == nil {
+				assert.Equal(t, int64(0), count)
+			} else {
+				assert.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetIdentityByProviderSubject retrieves the identity issued by a provider for a given subject.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - provider: The name of the identity provider.
+//   - subject: The "sub" claim issued by the provider.
+//
+// Returns:
+//   - *model.UserIdentity: The identity model if found.
+//   - error: An error if the retrieval fails or the identity is not found.
+func (i *identityRepository) GetIdentityByProviderSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetIdentityByProviderSubject")
+	defer s.End()
+
+	identity := &model.UserIdentity{}
+	err := i.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(identity).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return identity, nil
+}
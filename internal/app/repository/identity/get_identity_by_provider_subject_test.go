@@ -0,0 +1,94 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestIdentity_GetIdentityByProviderSubject(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB       func(t *testing.T) *gorm.DB
+		inputTenantID string
+		inputProvider string
+		inputSubject  string
+
+		expectedError  error
+		expectedOutput *model.UserIdentity
+	}{
+		{
+			name: "Get identity successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputProvider: "mockidp",
+			inputSubject:  "mockidp-subject-001",
+
+			expectedOutput: &model.UserIdentity{
+				Base: model.Base{
+					ID:        "5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00",
+					CreatedAt: fixture.TestTime,
+					UpdatedAt: fixture.TestTime,
+				},
+				TenantID: tenant.Default,
+				UserID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				Provider: "mockidp",
+				Subject:  "mockidp-subject-001",
+				Email:    "testuser001@example.com",
+			},
+		},
+		{
+			name: "Get identity failed - subject from another provider",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputProvider: "otheridp",
+			inputSubject:  "mockidp-subject-001",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Get identity failed - subject linked in another tenant",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputTenantID: "brand_a",
+			inputProvider: "mockidp",
+			inputSubject:  "mockidp-subject-001",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := tenant.NewContext(t.Context(), tc.inputTenantID)
+			db := tc.setupDB(t)
+			testIdentityRepo := NewIdentityRepository(db, nil, nil)
+
+			res, err := testIdentityRepo.GetIdentityByProviderSubject(ctx, tc.inputProvider, tc.inputSubject)
+			if err != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListIdentitiesByUserID retrieves all identities linked to a user, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the identities.
+//
+// Returns:
+//   - []*model.UserIdentity: The linked identities, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (i *identityRepository) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*model.UserIdentity, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListIdentitiesByUserID")
+	defer s.End()
+
+	identities := []*model.UserIdentity{}
+	err := i.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&identities).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return identities, nil
+}
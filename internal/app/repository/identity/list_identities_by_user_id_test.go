@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestIdentity_ListIdentitiesByUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+
+		expectedError  error
+		expectedOutput []*model.UserIdentity
+	}{
+		{
+			name: "List identities successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedOutput: []*model.UserIdentity{
+				{
+					Base: model.Base{
+						ID:        "5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00",
+						CreatedAt: fixture.TestTime,
+						UpdatedAt: fixture.TestTime,
+					},
+					TenantID: tenant.Default,
+					UserID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+					Provider: "mockidp",
+					Subject:  "mockidp-subject-001",
+					Email:    "testuser001@example.com",
+				},
+			},
+		},
+		{
+			name: "List identities of a user without any",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			},
+
+			inputUserID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedOutput: []*model.UserIdentity{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testIdentityRepo := NewIdentityRepository(db, nil, nil)
+
+			res, err := testIdentityRepo.ListIdentitiesByUserID(ctx, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
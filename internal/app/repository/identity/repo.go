@@ -0,0 +1,126 @@
+// Package identity provides repository operations for external identities linked to users.
+// It stores the links between upstream identity providers and local users using GORM,
+// and keeps the short-lived authorization state of in-flight logins in Redis.
+package identity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"gorm.io/gorm"
+)
+
+// AuthStateKeyFormat is the Redis key format used to store the state of an authorization-code flow by tenant and
+// state.
+const AuthStateKeyFormat = "oidc_state:%s:%s"
+
+// ErrLastCredential is returned when unlinking an identity would leave its user without a way to log in.
+var ErrLastCredential = errors.New("identity is the last credential of the user")
+
+// Repository represents the interface for identity repository operations.
+//
+//go:generate mockery --name=Repository --filename=identity_repo.go --output=./mocks
+type Repository interface {
+	// CreateIdentity links a new external identity to an existing user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - identity: The identity model containing the provider, subject and owning user.
+	//
+	// Returns:
+	//   - *model.UserIdentity: The created identity model.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateIdentity(ctx context.Context, identity *model.UserIdentity) (*model.UserIdentity, error)
+
+	// GetIdentityByProviderSubject retrieves the identity issued by a provider for a given subject.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - provider: The name of the identity provider.
+	//   - subject: The "sub" claim issued by the provider.
+	//
+	// Returns:
+	//   - *model.UserIdentity: The identity model if found.
+	//   - error: An error if the retrieval fails or the identity is not found.
+	GetIdentityByProviderSubject(ctx context.Context, provider, subject string) (*model.UserIdentity, error)
+
+	// ListIdentitiesByUserID retrieves all identities linked to a user, oldest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the identities.
+	//
+	// Returns:
+	//   - []*model.UserIdentity: The linked identities, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListIdentitiesByUserID(ctx context.Context, userID string) ([]*model.UserIdentity, error)
+
+	// DeleteIdentity unlinks an identity from the user owning it, as long as the user keeps a password, another
+	// identity or a passkey to log in with. Concurrent unlinks of the same user are serialized.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the identity.
+	//   - identityID: The ID of the identity to unlink.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user owns no such identity, ErrLastCredential if it is the last
+	//     way for the user to log in, otherwise any deletion error.
+	DeleteIdentity(ctx context.Context, userID, identityID string) error
+
+	// CreateUserWithIdentity creates a new user and links an external identity to it in a single transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user model to be created.
+	//   - identity: The identity model to be linked to the new user.
+	//
+	// Returns:
+	//   - *model.User: The created user model.
+	//   - error: An error if either insert fails, otherwise nil.
+	CreateUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) (*model.User, error)
+
+	// SaveAuthState stores the state of an authorization-code flow until the provider calls back.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - state: The opaque state value sent to the provider.
+	//   - authState: The data to remember for the callback.
+	//   - exp: How long the state stays valid.
+	//
+	// Returns:
+	//   - error: An error if the state cannot be stored, otherwise nil.
+	SaveAuthState(ctx context.Context, state string, authState *model.AuthState, exp time.Duration) error
+
+	// ConsumeAuthState retrieves and deletes the state of an authorization-code flow, so it can only be used once.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - state: The opaque state value returned by the provider.
+	//
+	// Returns:
+	//   - *model.AuthState: The stored state if found.
+	//   - error: dbutils.ErrRecordNotFoundType if the state is unknown or expired, otherwise nil.
+	ConsumeAuthState(ctx context.Context, state string) (*model.AuthState, error)
+}
+
+// identityRepository is the concrete implementation of the Repository interface.
+type identityRepository struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+	userRepo    userRepository.Repository
+}
+
+// NewIdentityRepository creates a new instance of the identity repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//   - redisClient: The Redis client used to store authorization state.
+//   - userRepo: The user repository creating the users provisioned on a first login.
+//
+// Returns:
+//   - Repository: A new identity repository instance.
+func NewIdentityRepository(db *gorm.DB, redisClient *redis.Client, userRepo userRepository.Repository) Repository {
+	return &identityRepository{
+		db:          db,
+		redisClient: redisClient,
+		userRepo:    userRepo,
+	}
+}
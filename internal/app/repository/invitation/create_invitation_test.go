@@ -0,0 +1,107 @@
+package invitation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestInvitation_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB         func(t *testing.T) *gorm.DB
+		inputInvitation *model.Invitation
+
+		expectedError           error
+		expectedSupersededCount int64
+	}{
+		{
+			name: "Create invitation superseding the pending one",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+			},
+
+			inputInvitation: &model.Invitation{
+				Email:     "invitee@example.com",
+				Role:      model.RoleMember,
+				TokenHash: "token-hash-004",
+				Status:    model.InvitationPending,
+				ExpiresAt: fixture.TestTime.Add(7 * 24 * time.Hour),
+			},
+
+			expectedSupersededCount: 1,
+		},
+		{
+			name: "Create first invitation of an email address",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+			},
+
+			inputInvitation: &model.Invitation{
+				Email:     "newcomer@example.com",
+				Role:      model.RoleMember,
+				TokenHash: "token-hash-005",
+				Status:    model.InvitationPending,
+				ExpiresAt: fixture.TestTime.Add(7 * 24 * time.Hour),
+			},
+		},
+		{
+			name: "Create invitation failed - token hash already stored",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+			},
+
+			inputInvitation: &model.Invitation{
+				Email:     "newcomer@example.com",
+				Role:      model.RoleMember,
+				TokenHash: hashOf(fixture.PendingInvitationToken),
+				Status:    model.InvitationPending,
+				ExpiresAt: fixture.TestTime.Add(7 * 24 * time.Hour),
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testInvitationRepo := NewInvitationRepository(db, nil)
+
+			res, err := testInvitationRepo.CreateInvitation(ctx, tc.inputInvitation)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, res.ID)
+
+			var superseded int64
+			err = db.Model(&model.Invitation{}).
+				Where("email = ? AND status = ?", tc.inputInvitation.Email, model.InvitationSuperseded).
+				Count(&superseded).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedSupersededCount, superseded)
+
+			saved := &model.Invitation{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.InvitationPending, saved.Status)
+			assert.Equal(t, tc.inputInvitation.Email, saved.Email)
+		})
+	}
+}
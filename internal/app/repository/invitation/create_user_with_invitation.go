@@ -0,0 +1,50 @@
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// CreateUserWithInvitation creates a new user and accepts the invitation it registered with in a single transaction.
+// The invitation is claimed with a conditional update after the user is inserted, so of concurrent registrations
+// with the same invitation only one succeeds and the others write nothing. The user is created through the user
+// repository, which adds the user.created event to the outbox in the same transaction and drops what it cached about
+// the username and ID. A username still held after another user changed it counts as taken.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user model to be created.
+//   - invitationID: The ID of the invitation to accept.
+//
+// Returns:
+//   - *model.User: The created user model.
+//   - error: dbutils.ErrRecordNotFoundType if the invitation is no longer pending or has expired,
+//     dbutils.ErrDuplicationType if the username or email is taken, otherwise an error if the creation fails.
+func (i *invitationRepository) CreateUserWithInvitation(ctx context.Context, user *model.User, invitationID string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateUserWithInvitation")
+	defer s.End()
+
+	return i.userRepo.CreateUserWith(ctx, user, func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.Invitation{}).
+			Where("id = ? AND status = ? AND expires_at > ?", invitationID, model.InvitationPending, now).
+			Updates(map[string]any{
+				"status":      model.InvitationAccepted,
+				"accepted_at": now,
+				"user_id":     user.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrRecordNotFoundType
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,127 @@
+package invitation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestInvitation_CreateUserWithInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUser         *model.User
+		inputInvitationID string
+
+		expectedError error
+		verifyFunc    func(t *testing.T, db *gorm.DB, user *model.User)
+	}{
+		{
+			name: "Create user with invitation successfully",
+
+			inputUser: &model.User{
+				Username:    "invitee",
+				DisplayName: "Invitee",
+				Email:       "invitee@example.com",
+				Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+				Role:        model.RoleAdmin,
+			},
+			inputInvitationID: "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01",
+
+			verifyFunc: func(t *testing.T, db *gorm.DB, user *model.User) {
+				checkInvitation := &model.Invitation{}
+				err := db.Where("id = ?", "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").First(checkInvitation).Error
+				assert.Nil(t, err)
+				assert.Equal(t, model.InvitationAccepted, checkInvitation.Status)
+				assert.NotNil(t, checkInvitation.AcceptedAt)
+				assert.Equal(t, &user.ID, checkInvitation.UserID)
+
+				checkUser := &model.User{}
+				err = db.Where("id = ?", user.ID).First(checkUser).Error
+				assert.Nil(t, err)
+				assert.Equal(t, model.RoleAdmin, checkUser.Role)
+
+				checkEvent := &model.OutboxEvent{}
+				err = db.Where("aggregate_id = ?", user.ID).First(checkEvent).Error
+				assert.Nil(t, err)
+				assert.Equal(t, "user.created", checkEvent.EventType)
+			},
+		},
+		{
+			name: "Create user with invitation failed - invitation expired, user is rolled back",
+
+			inputUser: &model.User{
+				Username:    "expired",
+				DisplayName: "Expired",
+				Email:       "expired@example.com",
+			},
+			inputInvitationID: "a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a02",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+			verifyFunc: func(t *testing.T, db *gorm.DB, user *model.User) {
+				var count int64
+				db.Model(&model.User{}).Where("username = ?", "expired").Count(&count)
+				assert.Equal(t, int64(0), count)
+
+				db.Model(&model.OutboxEvent{}).Count(&count)
+				assert.Equal(t, int64(0), count)
+			},
+		},
+		{
+			name: "Create user with invitation failed - invitation already accepted",
+
+			inputUser: &model.User{
+				Username:    "latecomer",
+				DisplayName: "Latecomer",
+				Email:       "latecomer@example.com",
+			},
+			inputInvitationID: "a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a03",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+			verifyFunc: func(t *testing.T, db *gorm.DB, user *model.User) {
+				var count int64
+				db.Model(&model.User{}).Where("username = ?", "latecomer").Count(&count)
+				assert.Equal(t, int64(0), count)
+			},
+		},
+		{
+			name: "Create user with invitation failed - email taken, invitation stays pending",
+
+			inputUser: &model.User{
+				Username:    "invitee",
+				DisplayName: "Invitee",
+				Email:       "alice@example.com",
+			},
+			inputInvitationID: "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01",
+
+			expectedError: dbutils.ErrDuplicationType,
+			verifyFunc: func(t *testing.T, db *gorm.DB, user *model.User) {
+				checkInvitation := &model.Invitation{}
+				err := db.Where("id = ?", "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").First(checkInvitation).Error
+				assert.Nil(t, err)
+				assert.Equal(t, model.InvitationPending, checkInvitation.Status)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+			testInvitationRepo := NewInvitationRepository(db, userRepository.NewUserRepository(db))
+
+			res, err := testInvitationRepo.CreateUserWithInvitation(ctx, tc.inputUser, tc.inputInvitationID)
+			assert.Equal(t, tc.expectedError, err)
+			tc.verifyFunc(t, db, res)
+		})
+	}
+}
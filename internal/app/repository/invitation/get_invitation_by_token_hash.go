@@ -0,0 +1,31 @@
+package invitation
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetInvitationByTokenHash retrieves the invitation whose link carries the hashed token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - tokenHash: The SHA-256 hash of the presented token.
+//
+// Returns:
+//   - *model.Invitation: The invitation if found.
+//   - error: dbutils.ErrRecordNotFoundType if no invitation matches, otherwise any retrieval error.
+func (i *invitationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetInvitationByTokenHash")
+	defer s.End()
+
+	invitation := &model.Invitation{}
+	err := i.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(invitation).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,67 @@
+package invitation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestInvitation_GetInvitationByTokenHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputTokenHash string
+
+		expectedID    string
+		expectedRole  string
+		expectedError error
+	}{
+		{
+			name: "Get invitation by token hash successfully",
+
+			inputTokenHash: hashOf(fixture.PendingInvitationToken),
+
+			expectedID:   "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01",
+			expectedRole: model.RoleAdmin,
+		},
+		{
+			name: "Get invitation by token hash failed - unknown token",
+
+			inputTokenHash: hashOf("unknown-token"),
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+			testInvitationRepo := NewInvitationRepository(db, nil)
+
+			res, err := testInvitationRepo.GetInvitationByTokenHash(ctx, tc.inputTokenHash)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedID, res.ID)
+			assert.Equal(t, tc.expectedRole, res.Role)
+		})
+	}
+}
+
+// hashOf hashes a token the same way the invitation service does.
+func hashOf(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,32 @@
+package invitation
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListInvitations retrieves all invitations, newest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - []*model.Invitation: The invitations, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (i *invitationRepository) ListInvitations(ctx context.Context) ([]*model.Invitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListInvitations")
+	defer s.End()
+
+	invitations := []*model.Invitation{}
+	err := i.db.WithContext(ctx).
+		Order("created_at DESC, id ASC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return invitations, nil
+}
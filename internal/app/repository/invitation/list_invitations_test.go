@@ -0,0 +1,25 @@
+package invitation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestInvitation_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+	testRepo := NewInvitationRepository(db, nil)
+
+	res, err := testRepo.ListInvitations(ctx)
+	assert.Nil(t, err)
+
+	emails := []string{}
+	for _, invitation := range res {
+		emails = append(emails, invitation.Email+":"+invitation.Status)
+	}
+	assert.Equal(t, []string{"invitee@example.com:pending", "expired@example.com:pending", "charlie@example.com:accepted"}, emails)
+}
@@ -0,0 +1,88 @@
+// Package invitation provides repository operations for invitations to register using GORM.
+package invitation
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for invitation repository operations.
+//
+//go:generate mockery --name=Repository --filename=invitation_repo.go --output=./mocks
+type Repository interface {
+	// CreateInvitation stores a new pending invitation.
+	// The other pending invitations of the same email address are superseded by it in the same transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - invitation: The invitation to store.
+	//
+	// Returns:
+	//   - *model.Invitation: The created invitation.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateInvitation(ctx context.Context, invitation *model.Invitation) (*model.Invitation, error)
+
+	// ListInvitations retrieves all invitations, newest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - []*model.Invitation: The invitations, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListInvitations(ctx context.Context) ([]*model.Invitation, error)
+
+	// GetInvitationByTokenHash retrieves the invitation whose link carries the hashed token.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - tokenHash: The SHA-256 hash of the presented token.
+	//
+	// Returns:
+	//   - *model.Invitation: The invitation if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no invitation matches, otherwise any retrieval error.
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.Invitation, error)
+
+	// RevokeInvitation revokes an invitation, provided it is still pending.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the invitation.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if no pending invitation has the ID, otherwise any update error.
+	RevokeInvitation(ctx context.Context, id string) error
+
+	// CreateUserWithInvitation creates a new user and accepts the invitation it registered with in a single
+	// transaction. The invitation must still be pending and not expired; otherwise nothing is written.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user model to be created.
+	//   - invitationID: The ID of the invitation to accept.
+	//
+	// Returns:
+	//   - *model.User: The created user model.
+	//   - error: dbutils.ErrRecordNotFoundType if the invitation is no longer pending or has expired,
+	//     dbutils.ErrDuplicationType if the username or email is taken, otherwise an error if the creation fails.
+	CreateUserWithInvitation(ctx context.Context, user *model.User, invitationID string) (*model.User, error)
+}
+
+// invitationRepository is the concrete implementation of the Repository interface.
+type invitationRepository struct {
+	db       *gorm.DB
+	userRepo userRepository.Repository
+}
+
+// NewInvitationRepository creates a new instance of the invitation repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//   - userRepo: The user repository creating the users who register with an invitation.
+//
+// Returns:
+//   - Repository: A new invitation repository instance.
+func NewInvitationRepository(db *gorm.DB, userRepo userRepository.Repository) Repository {
+	return &invitationRepository{
+		db:       db,
+		userRepo: userRepo,
+	}
+}
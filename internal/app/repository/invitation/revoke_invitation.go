@@ -0,0 +1,37 @@
+package invitation
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// RevokeInvitation revokes an invitation, provided it is still pending.
+// The condition makes the revocation and the acceptance exclusive: of concurrent attempts, only one succeeds.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the invitation.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if no pending invitation has the ID, otherwise any update error.
+func (i *invitationRepository) RevokeInvitation(ctx context.Context, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_RevokeInvitation")
+	defer s.End()
+
+	result := i.db.WithContext(ctx).
+		Model(&model.Invitation{}).
+		Where("id = ? AND status = ?", id, model.InvitationPending).
+		Update("status", model.InvitationRevoked)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
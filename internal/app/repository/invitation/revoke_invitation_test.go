@@ -0,0 +1,63 @@
+package invitation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestInvitation_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID string
+
+		expectedError error
+	}{
+		{
+			name: "Revoke pending invitation successfully",
+
+			inputID: "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01",
+		},
+		{
+			name: "Revoke invitation failed - already accepted",
+
+			inputID: "a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a03",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Revoke invitation failed - unknown ID",
+
+			inputID: "a1b2c3d4-0009-4e5f-8a9b-0c1d2e3f4a09",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.InvitationCommonTestDB{})
+			testInvitationRepo := NewInvitationRepository(db, nil)
+
+			err := testInvitationRepo.RevokeInvitation(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.Invitation{}
+			err = db.Where("id = ?", tc.inputID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.InvitationRevoked, saved.Status)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateLoginEvent stores a login attempt.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - event: The login event containing the device and the outcome.
+//
+// Returns:
+//   - *model.LoginEvent: The created login event.
+//   - error: An error if the creation fails, otherwise nil.
+func (r *loginHistoryRepository) CreateLoginEvent(ctx context.Context, event *model.LoginEvent) (*model.LoginEvent, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateLoginEvent")
+	defer s.End()
+
+	err := r.db.WithContext(ctx).Create(event).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return event, nil
+}
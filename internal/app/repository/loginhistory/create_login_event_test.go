@@ -0,0 +1,64 @@
+package loginhistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestLoginHistory_CreateLoginEvent(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB    func(t *testing.T) *gorm.DB
+		inputEvent *model.LoginEvent
+
+		expectedError error
+	}{
+		{
+			name: "Create failed login event successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			},
+
+			inputEvent: &model.LoginEvent{
+				UserID:            "de305d54-75b4-431b-adb2-eb6b9e546000",
+				IPAddress:         "203.0.113.50",
+				UserAgent:         "curl/8.4.0",
+				DeviceFingerprint: "fingerprint",
+				Success:           false,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testLoginHistoryRepo := NewLoginHistoryRepository(db)
+
+			res, err := testLoginHistoryRepo.CreateLoginEvent(ctx, tc.inputEvent)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, res.ID)
+
+			saved := &model.LoginEvent{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputEvent.IPAddress, saved.IPAddress)
+			assert.Equal(t, tc.inputEvent.DeviceFingerprint, saved.DeviceFingerprint)
+			assert.Equal(t, tc.inputEvent.Success, saved.Success)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListKnownDeviceFingerprints retrieves the fingerprints of the devices a user has successfully logged in from.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - []string: The distinct device fingerprints, empty if the user never logged in.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (r *loginHistoryRepository) ListKnownDeviceFingerprints(ctx context.Context, userID string) ([]string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListKnownDeviceFingerprints")
+	defer s.End()
+
+	fingerprints := []string{}
+	err := r.db.WithContext(ctx).
+		Model(&model.LoginEvent{}).
+		Where("user_id = ? AND success = ?", userID, true).
+		Distinct().
+		Order("device_fingerprint").
+		Pluck("device_fingerprint", &fingerprints).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return fingerprints, nil
+}
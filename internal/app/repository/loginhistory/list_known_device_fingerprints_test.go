@@ -0,0 +1,65 @@
+package loginhistory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestLoginHistory_ListKnownDeviceFingerprints(t *testing.T) {
+	t.Parallel()
+
+	sum := sha256.Sum256([]byte(fixture.KnownDeviceIPAddress + "\n" + fixture.KnownDeviceUserAgent))
+	knownFingerprint := hex.EncodeToString(sum[:])
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+
+		expectedFingerprints []string
+		expectedError        error
+	}{
+		{
+			name: "List devices of successful logins only, once each",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedFingerprints: []string{knownFingerprint},
+		},
+		{
+			name: "List devices of a user who never logged in",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			},
+
+			inputUserID: "00000000-0000-0000-0000-000000000000",
+
+			expectedFingerprints: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testLoginHistoryRepo := NewLoginHistoryRepository(db)
+
+			res, err := testLoginHistoryRepo.ListKnownDeviceFingerprints(ctx, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedFingerprints, res)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListLoginEventsByUserID retrieves the latest login attempts of a user, newest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - limit: The maximum number of events to return.
+//
+// Returns:
+//   - []*model.LoginEvent: The login events, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (r *loginHistoryRepository) ListLoginEventsByUserID(ctx context.Context, userID string, limit int) ([]*model.LoginEvent, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListLoginEventsByUserID")
+	defer s.End()
+
+	events := []*model.LoginEvent{}
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return events, nil
+}
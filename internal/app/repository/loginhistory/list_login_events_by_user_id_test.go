@@ -0,0 +1,87 @@
+package loginhistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestLoginHistory_ListLoginEventsByUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+		inputLimit  int
+
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name: "List login events, newest first",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputLimit:  10,
+
+			expectedIDs: []string{
+				"b2c3d4e5-0003-4f5a-9b0c-1d2e3f4a5b63",
+				"b2c3d4e5-0002-4f5a-9b0c-1d2e3f4a5b62",
+				"b2c3d4e5-0001-4f5a-9b0c-1d2e3f4a5b61",
+			},
+		},
+		{
+			name: "List login events up to the limit",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputLimit:  2,
+
+			expectedIDs: []string{
+				"b2c3d4e5-0003-4f5a-9b0c-1d2e3f4a5b63",
+				"b2c3d4e5-0002-4f5a-9b0c-1d2e3f4a5b62",
+			},
+		},
+		{
+			name: "List login events of a user who never logged in",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			},
+
+			inputUserID: "00000000-0000-0000-0000-000000000000",
+			inputLimit:  10,
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testLoginHistoryRepo := NewLoginHistoryRepository(db)
+
+			res, err := testLoginHistoryRepo.ListLoginEventsByUserID(ctx, tc.inputUserID, tc.inputLimit)
+			assert.Equal(t, tc.expectedError, err)
+
+			ids := []string{}
+			for _, event := range res {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package loginhistory provides repository operations for the login attempts of users using GORM.
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for login history repository operations.
+//
+//go:generate mockery --name=Repository --filename=login_history_repo.go --output=./mocks
+type Repository interface {
+	// CreateLoginEvent stores a login attempt.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - event: The login event containing the device and the outcome.
+	//
+	// Returns:
+	//   - *model.LoginEvent: The created login event.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateLoginEvent(ctx context.Context, event *model.LoginEvent) (*model.LoginEvent, error)
+
+	// ListLoginEventsByUserID retrieves the latest login attempts of a user, newest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - limit: The maximum number of events to return.
+	//
+	// Returns:
+	//   - []*model.LoginEvent: The login events, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListLoginEventsByUserID(ctx context.Context, userID string, limit int) ([]*model.LoginEvent, error)
+
+	// ListKnownDeviceFingerprints retrieves the fingerprints of the devices a user has successfully logged in from.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//
+	// Returns:
+	//   - []string: The distinct device fingerprints, empty if the user never logged in.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListKnownDeviceFingerprints(ctx context.Context, userID string) ([]string, error)
+}
+
+// loginHistoryRepository is the concrete implementation of the Repository interface.
+type loginHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginHistoryRepository creates a new instance of the login history repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - Repository: A new login history repository instance.
+func NewLoginHistoryRepository(db *gorm.DB) Repository {
+	return &loginHistoryRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,37 @@
+package magiclink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// IncreaseRequestCount counts a login link request for an email within a fixed window.
+// The counter expires with the window, so the expiry is only set by the first request.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - email: The normalized email the link was requested for.
+//   - window: The length of the counting window, started by the first request.
+//
+// Returns:
+//   - int64: The number of requests in the current window, including this one.
+//   - error: An error if the counter cannot be updated, otherwise nil.
+func (m *magicLinkRepository) IncreaseRequestCount(ctx context.Context, email string, window time.Duration) (int64, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_IncreaseRequestCount")
+	defer s.End()
+
+	key := fmt.Sprintf(RequestCountKeyFormat, tenant.FromContext(ctx), email)
+
+	pipe := m.redisClient.TxPipeline()
+	count := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
@@ -0,0 +1,78 @@
+package magiclink
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+func TestMagicLink_IncreaseRequestCount(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name: "First request starts the window",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+
+			expectedCount: 1,
+		},
+		{
+			name: "Later request keeps the window",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Set(ctx, fmt.Sprintf(RequestCountKeyFormat, tenant.Default, "testuser001@example.com"), 2, 5*time.Minute)
+				return redisClient
+			},
+
+			expectedCount: 3,
+		},
+		{
+			name: "Closed Redis client",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := tc.setupRedis(ctx)
+			testMagicLinkRepo := NewMagicLinkRepository(redisClient)
+
+			count, err := testMagicLinkRepo.IncreaseRequestCount(ctx, "testuser001@example.com", 15*time.Minute)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedCount, count)
+			if err != nil {
+				return
+			}
+
+			ttl := redisClient.TTL(ctx, fmt.Sprintf(RequestCountKeyFormat, tenant.Default, "testuser001@example.com")).Val()
+			assert.Greater(t, ttl, time.Duration(0))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package magiclink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// SaveMagicLink stores a pending login link until it is used or expires.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The random identifier embedded in the link.
+//   - link: The data to remember for the verification.
+//   - exp: How long the link stays valid.
+//
+// Returns:
+//   - error: An error if the link cannot be stored, otherwise nil.
+func (m *magicLinkRepository) SaveMagicLink(ctx context.Context, id string, link *model.MagicLink, exp time.Duration) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_SaveMagicLink")
+	defer s.End()
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		return err
+	}
+
+	return m.redisClient.Set(ctx, fmt.Sprintf(MagicLinkKeyFormat, tenant.FromContext(ctx), id), data, exp).Err()
+}
+
+// GetMagicLink retrieves a pending login link without using it up.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The random identifier embedded in the link.
+//
+// Returns:
+//   - *model.MagicLink: The stored link if found.
+//   - error: dbutils.ErrRecordNotFoundType if the link is unknown, used or expired, otherwise nil.
+func (m *magicLinkRepository) GetMagicLink(ctx context.Context, id string) (*model.MagicLink, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetMagicLink")
+	defer s.End()
+
+	return decodeMagicLink(m.redisClient.Get(ctx, fmt.Sprintf(MagicLinkKeyFormat, tenant.FromContext(ctx), id)).Bytes())
+}
+
+// ConsumeMagicLink retrieves and deletes a pending login link, so it can only be used once.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The random identifier embedded in the link.
+//
+// Returns:
+//   - *model.MagicLink: The stored link if found.
+//   - error: dbutils.ErrRecordNotFoundType if the link is unknown, used or expired, otherwise nil.
+func (m *magicLinkRepository) ConsumeMagicLink(ctx context.Context, id string) (*model.MagicLink, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ConsumeMagicLink")
+	defer s.End()
+
+	return decodeMagicLink(m.redisClient.GetDel(ctx, fmt.Sprintf(MagicLinkKeyFormat, tenant.FromContext(ctx), id)).Bytes())
+}
+
+// decodeMagicLink decodes a link read from Redis, reporting a missing key as dbutils.ErrRecordNotFoundType.
+func decodeMagicLink(data []byte, err error) (*model.MagicLink, error) {
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, dbutils.ErrRecordNotFoundType
+		}
+		return nil, err
+	}
+
+	link := &model.MagicLink{}
+	if err := json.Unmarshal(data, link); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
@@ -0,0 +1,166 @@
+package magiclink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+func TestMagicLink_MagicLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+		inputID    string
+
+		expectedError  error
+		expectedOutput *model.MagicLink
+	}{
+		{
+			name: "Consume saved link successfully",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				err := NewMagicLinkRepository(redisClient).SaveMagicLink(ctx, "link-001", &model.MagicLink{
+					UserID:    "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+					NonceHash: "nonce-hash-001",
+				}, time.Minute)
+				assert.Nil(t, err)
+				return redisClient
+			},
+			inputID: "link-001",
+
+			expectedOutput: &model.MagicLink{
+				UserID:    "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				NonceHash: "nonce-hash-001",
+			},
+		},
+		{
+			name: "Consume unknown link",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			inputID: "unknown-link",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Consume link with closed Redis client",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+			inputID: "link-001",
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testMagicLinkRepo := NewMagicLinkRepository(tc.setupRedis(ctx))
+
+			res, err := testMagicLinkRepo.ConsumeMagicLink(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedOutput, res)
+
+			// a link can only be used once
+			_, err = testMagicLinkRepo.ConsumeMagicLink(ctx, tc.inputID)
+			assert.Equal(t, dbutils.ErrRecordNotFoundType, err)
+		})
+	}
+}
+
+func TestMagicLink_GetMagicLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+		inputID    string
+
+		expectedError  error
+		expectedOutput *model.MagicLink
+	}{
+		{
+			name: "Get saved link successfully",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				err := NewMagicLinkRepository(redisClient).SaveMagicLink(ctx, "link-001", &model.MagicLink{
+					UserID:    "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+					NonceHash: "nonce-hash-001",
+				}, time.Minute)
+				assert.Nil(t, err)
+				return redisClient
+			},
+			inputID: "link-001",
+
+			expectedOutput: &model.MagicLink{
+				UserID:    "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				NonceHash: "nonce-hash-001",
+			},
+		},
+		{
+			name: "Get unknown link",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			inputID: "unknown-link",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Get link with closed Redis client",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+			inputID: "link-001",
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testMagicLinkRepo := NewMagicLinkRepository(tc.setupRedis(ctx))
+
+			res, err := testMagicLinkRepo.GetMagicLink(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedOutput, res)
+
+			// getting a link leaves it in place to be consumed
+			res, err = testMagicLinkRepo.ConsumeMagicLink(ctx, tc.inputID)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package magiclink provides repository operations for passwordless login links.
+// Pending links and the per-email request counters are short-lived and kept in Redis.
+package magiclink
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+const (
+	// MagicLinkKeyFormat is the Redis key format used to store a pending login link by tenant and ID.
+	MagicLinkKeyFormat = "magic_link:%s:%s"
+
+	// RequestCountKeyFormat is the Redis key format used to count login link requests by tenant and email.
+	RequestCountKeyFormat = "magic_link_requests:%s:%s"
+)
+
+// Repository represents the interface for magic link repository operations.
+//
+//go:generate mockery --name=Repository --filename=magic_link_repo.go --output=./mocks
+type Repository interface {
+	// SaveMagicLink stores a pending login link until it is used or expires.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The random identifier embedded in the link.
+	//   - link: The data to remember for the verification.
+	//   - exp: How long the link stays valid.
+	//
+	// Returns:
+	//   - error: An error if the link cannot be stored, otherwise nil.
+	SaveMagicLink(ctx context.Context, id string, link *model.MagicLink, exp time.Duration) error
+
+	// GetMagicLink retrieves a pending login link without using it up.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The random identifier embedded in the link.
+	//
+	// Returns:
+	//   - *model.MagicLink: The stored link if found.
+	//   - error: dbutils.ErrRecordNotFoundType if the link is unknown, used or expired, otherwise nil.
+	GetMagicLink(ctx context.Context, id string) (*model.MagicLink, error)
+
+	// ConsumeMagicLink retrieves and deletes a pending login link, so it can only be used once.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The random identifier embedded in the link.
+	//
+	// Returns:
+	//   - *model.MagicLink: The stored link if found.
+	//   - error: dbutils.ErrRecordNotFoundType if the link is unknown, used or expired, otherwise nil.
+	ConsumeMagicLink(ctx context.Context, id string) (*model.MagicLink, error)
+
+	// IncreaseRequestCount counts a login link request for an email within a fixed window.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - email: The normalized email the link was requested for.
+	//   - window: The length of the counting window, started by the first request.
+	//
+	// Returns:
+	//   - int64: The number of requests in the current window, including this one.
+	//   - error: An error if the counter cannot be updated, otherwise nil.
+	IncreaseRequestCount(ctx context.Context, email string, window time.Duration) (int64, error)
+}
+
+// magicLinkRepository is the concrete implementation of the Repository interface.
+type magicLinkRepository struct {
+	redisClient *redis.Client
+}
+
+// NewMagicLinkRepository creates a new instance of the magic link repository.
+//
+// Parameters:
+//   - redisClient: The Redis client used to store links and counters.
+//
+// Returns:
+//   - Repository: A new magic link repository instance.
+func NewMagicLinkRepository(redisClient *redis.Client) Repository {
+	return &magicLinkRepository{redisClient: redisClient}
+}
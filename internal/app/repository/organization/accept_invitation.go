@@ -0,0 +1,59 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// AcceptInvitation adds a user to the organization of an invitation and accepts the invitation in a single
+// transaction. The invitation is claimed with a conditional update before the membership is inserted, so of
+// concurrent acceptances of the same invitation only one succeeds and the others write nothing.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - invitation: The invitation to accept.
+//   - userID: The ID of the user joining the organization.
+//
+// Returns:
+//   - *model.Membership: The created membership.
+//   - error: dbutils.ErrRecordNotFoundType if the invitation is no longer pending or has expired,
+//     dbutils.ErrDuplicationType if the user already is a member, otherwise an error if the creation fails.
+func (o *organizationRepository) AcceptInvitation(ctx context.Context, invitation *model.OrganizationInvitation, userID string) (*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_AcceptOrganizationInvitation")
+	defer s.End()
+
+	membership := &model.Membership{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+	}
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.OrganizationInvitation{}).
+			Where("id = ? AND status = ? AND expires_at > ?", invitation.ID, model.InvitationPending, now).
+			Updates(map[string]any{
+				"status":      model.InvitationAccepted,
+				"accepted_at": now,
+				"user_id":     userID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrRecordNotFoundType
+		}
+
+		return tx.Create(membership).Error
+	})
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return membership, nil
+}
@@ -0,0 +1,100 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestOrganization_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputInvitationID string
+		inputUserID       string
+
+		expectedError error
+		verifyFunc    func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name: "Accept invitation successfully",
+
+			inputInvitationID: "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01",
+			inputUserID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				invitation := &model.OrganizationInvitation{}
+				err := db.Where("id = ?", "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01").First(invitation).Error
+				assert.Nil(t, err)
+				assert.Equal(t, model.InvitationAccepted, invitation.Status)
+				assert.NotNil(t, invitation.AcceptedAt)
+				if assert.NotNil(t, invitation.UserID) {
+					assert.Equal(t, "4d9326d6-980c-4c62-9709-dbc70a82cbfe", *invitation.UserID)
+				}
+
+				membership := &model.Membership{}
+				err = db.Where("organization_id = ? AND user_id = ?", fixture.AcmeOrganizationID, "4d9326d6-980c-4c62-9709-dbc70a82cbfe").
+					First(membership).Error
+				assert.Nil(t, err)
+				assert.Equal(t, model.OrganizationMember, membership.Role)
+			},
+		},
+		{
+			name: "Accept invitation failed - invitation expired",
+
+			inputInvitationID: "0c7f3d00-0002-4c5d-9e8f-1a2b3c4d5e02",
+			inputUserID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.Membership{}).Where("user_id = ?", "4d9326d6-980c-4c62-9709-dbc70a82cbfe").Count(&count)
+				assert.Equal(t, int64(0), count)
+			},
+		},
+		{
+			name: "Accept invitation failed - user already is a member, invitation is rolled back",
+
+			inputInvitationID: "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01",
+			inputUserID:       "987e6543-e21b-12d3-a456-eb6b9e546002",
+
+			expectedError: dbutils.ErrDuplicationType,
+			verifyFunc: func(t *testing.T, db *gorm.DB) {
+				invitation := &model.OrganizationInvitation{}
+				err := db.Where("id = ?", "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01").First(invitation).Error
+				assert.Nil(t, err)
+				assert.Equal(t, model.InvitationPending, invitation.Status)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			invitation := &model.OrganizationInvitation{}
+			err := db.Where("id = ?", tc.inputInvitationID).First(invitation).Error
+			if !assert.Nil(t, err) {
+				return
+			}
+
+			res, err := testRepo.AcceptInvitation(ctx, invitation, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			if err == nil {
+				assert.Equal(t, invitation.Role, res.Role)
+			}
+
+			tc.verifyFunc(t, db)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// CreateInvitation stores a new pending invitation to join an organization, superseding the other pending
+// invitations of the same email address to the organization, so only the link of the latest invitation works.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - invitation: The invitation to store.
+//
+// Returns:
+//   - *model.OrganizationInvitation: The created invitation.
+//   - error: An error if the creation fails, otherwise nil.
+func (o *organizationRepository) CreateInvitation(ctx context.Context, invitation *model.OrganizationInvitation) (*model.OrganizationInvitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateOrganizationInvitation")
+	defer s.End()
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.OrganizationInvitation{}).
+			Where("organization_id = ? AND email = ? AND status = ?", invitation.OrganizationID, invitation.Email, model.InvitationPending).
+			Update("status", model.InvitationSuperseded).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(invitation).Error
+	})
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return invitation, nil
+}
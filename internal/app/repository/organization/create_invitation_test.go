@@ -0,0 +1,85 @@
+package organization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputInvitation *model.OrganizationInvitation
+
+		expectedSupersededID string
+	}{
+		{
+			name: "Create invitation successfully",
+
+			inputInvitation: &model.OrganizationInvitation{
+				OrganizationID: fixture.GlobexOrganizationID,
+				Email:          "charlie@example.com",
+				Role:           model.OrganizationMember,
+				TokenHash:      "new-token-hash",
+				Status:         model.InvitationPending,
+				ExpiresAt:      time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "Create invitation supersedes pending invitation of the same email",
+
+			inputInvitation: &model.OrganizationInvitation{
+				OrganizationID: fixture.AcmeOrganizationID,
+				Email:          "testuser001@example.com",
+				Role:           model.OrganizationAdmin,
+				TokenHash:      "new-token-hash",
+				Status:         model.InvitationPending,
+				ExpiresAt:      time.Now().Add(time.Hour),
+			},
+
+			expectedSupersededID: "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			res, err := testRepo.CreateInvitation(ctx, tc.inputInvitation)
+			assert.Nil(t, err)
+			if !assert.NotNil(t, res) {
+				return
+			}
+			assert.NotEmpty(t, res.ID)
+
+			saved := &model.OrganizationInvitation{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.InvitationPending, saved.Status)
+
+			// The invitation of the same email to another organization stays pending.
+			other := &model.OrganizationInvitation{}
+			err = db.Where("id = ?", "0c7f3d00-0002-4c5d-9e8f-1a2b3c4d5e02").First(other).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.InvitationPending, other.Status)
+
+			if tc.expectedSupersededID == "" {
+				return
+			}
+			superseded := &model.OrganizationInvitation{}
+			err = db.Where("id = ?", tc.expectedSupersededID).First(superseded).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.InvitationSuperseded, superseded.Status)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// CreateOrganization stores a new organization along with the membership of its owner in a single transaction,
+// so that an organization never exists without an owner.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organization: The organization to store.
+//   - ownerID: The ID of the user owning the organization.
+//
+// Returns:
+//   - *model.Organization: The created organization.
+//   - error: An error if the creation fails, otherwise nil.
+func (o *organizationRepository) CreateOrganization(ctx context.Context, organization *model.Organization, ownerID string) (*model.Organization, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateOrganization")
+	defer s.End()
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&model.Membership{
+			OrganizationID: organization.ID,
+			UserID:         ownerID,
+			Role:           model.OrganizationOwner,
+		}).Error
+	})
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return organization, nil
+}
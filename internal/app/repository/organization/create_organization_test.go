@@ -0,0 +1,31 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_CreateOrganization(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+	testRepo := NewOrganizationRepository(db)
+
+	res, err := testRepo.CreateOrganization(ctx, &model.Organization{Name: "Initech"}, "987e6543-e21b-12d3-a456-eb6b9e546002")
+	assert.Nil(t, err)
+	if !assert.NotNil(t, res) {
+		return
+	}
+	assert.NotEmpty(t, res.ID)
+	assert.Equal(t, "Initech", res.Name)
+
+	membership := &model.Membership{}
+	err = db.Where("organization_id = ?", res.ID).First(membership).Error
+	assert.Nil(t, err)
+	assert.Equal(t, "987e6543-e21b-12d3-a456-eb6b9e546002", membership.UserID)
+	assert.Equal(t, model.OrganizationOwner, membership.Role)
+}
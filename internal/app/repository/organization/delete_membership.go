@@ -0,0 +1,37 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DeleteMembership removes a member other than the owner from an organization.
+// The owner is excluded by the deletion itself, so an organization always keeps its owner.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//   - userID: The ID of the member.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user is not a member or is the owner, otherwise any deletion error.
+func (o *organizationRepository) DeleteMembership(ctx context.Context, organizationID, userID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteMembership")
+	defer s.End()
+
+	result := o.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ? AND role <> ?", organizationID, userID, model.OrganizationOwner).
+		Delete(&model.Membership{})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_DeleteMembership(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUserID string
+
+		expectedError error
+	}{
+		{
+			name: "Remove member successfully",
+
+			inputUserID: "987e6543-e21b-12d3-a456-eb6b9e546002",
+		},
+		{
+			name: "Remove member failed - owner is excluded",
+
+			inputUserID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Remove member failed - user is not a member",
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			err := testRepo.DeleteMembership(ctx, fixture.AcmeOrganizationID, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			var count int64
+			db.Model(&model.Membership{}).
+				Where("organization_id = ? AND user_id = ?", fixture.AcmeOrganizationID, tc.inputUserID).
+				Count(&count)
+			assert.Equal(t, int64(0), count)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// DeleteOrganization deletes an organization with its memberships and invitations in a single transaction.
+// They are deleted explicitly rather than left to the foreign keys, so the result does not depend on the database
+// enforcing them.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the organization.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if no organization has the ID, otherwise any deletion error.
+func (o *organizationRepository) DeleteOrganization(ctx context.Context, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteOrganization")
+	defer s.End()
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("organization_id = ?", id).Delete(&model.OrganizationInvitation{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("organization_id = ?", id).Delete(&model.Membership{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&model.Organization{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrRecordNotFoundType
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dbutils.CatchDBError(err)
+	}
+
+	return nil
+}
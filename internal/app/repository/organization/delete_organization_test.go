@@ -0,0 +1,65 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_DeleteOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID string
+
+		expectedError error
+	}{
+		{
+			name: "Delete organization with its memberships and invitations successfully",
+
+			inputID: fixture.AcmeOrganizationID,
+		},
+		{
+			name: "Delete organization failed - unknown ID",
+
+			inputID: "0a9e1f00-0009-4c5d-9e8f-1a2b3c4d5e09",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			err := testRepo.DeleteOrganization(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			var count int64
+			db.Model(&model.Organization{}).Where("id = ?", tc.inputID).Count(&count)
+			assert.Equal(t, int64(0), count)
+
+			db.Model(&model.Membership{}).Where("organization_id = ?", tc.inputID).Count(&count)
+			assert.Equal(t, int64(0), count)
+
+			db.Model(&model.OrganizationInvitation{}).Where("organization_id = ?", tc.inputID).Count(&count)
+			assert.Equal(t, int64(0), count)
+
+			// Other organizations are left untouched.
+			db.Model(&model.Membership{}).Where("organization_id = ?", fixture.GlobexOrganizationID).Count(&count)
+			assert.Equal(t, int64(1), count)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetInvitationByTokenHash retrieves the invitation to join an organization whose link carries the hashed token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - tokenHash: The SHA-256 hash of the presented token.
+//
+// Returns:
+//   - *model.OrganizationInvitation: The invitation if found.
+//   - error: dbutils.ErrRecordNotFoundType if no invitation matches, otherwise any retrieval error.
+func (o *organizationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.OrganizationInvitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetOrganizationInvitationByTokenHash")
+	defer s.End()
+
+	invitation := &model.OrganizationInvitation{}
+	err := o.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(invitation).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,58 @@
+package organization
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_GetInvitationByTokenHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputToken string
+
+		expectedID    string
+		expectedError error
+	}{
+		{
+			name: "Get invitation successfully",
+
+			inputToken: fixture.PendingOrganizationInvitationToken,
+
+			expectedID: "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01",
+		},
+		{
+			name: "Get invitation failed - unknown token",
+
+			inputToken: "unknownOrgInvitationToken0000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			hash := sha256.Sum256([]byte(tc.inputToken))
+			res, err := testRepo.GetInvitationByTokenHash(ctx, hex.EncodeToString(hash[:]))
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedID, res.ID)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetMembership retrieves the membership of a user in an organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - *model.Membership: The membership if the user belongs to the organization.
+//   - error: dbutils.ErrRecordNotFoundType if the user is not a member, otherwise any retrieval error.
+func (o *organizationRepository) GetMembership(ctx context.Context, organizationID, userID string) (*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetMembership")
+	defer s.End()
+
+	membership := &model.Membership{}
+	err := o.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(membership).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return membership, nil
+}
@@ -0,0 +1,61 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_GetMembership(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputOrganizationID string
+		inputUserID         string
+
+		expectedRole  string
+		expectedError error
+	}{
+		{
+			name: "Get membership successfully",
+
+			inputOrganizationID: fixture.AcmeOrganizationID,
+			inputUserID:         "123e4567-e89b-12d3-a456-eb6b9e546001",
+
+			expectedRole: model.OrganizationAdmin,
+		},
+		{
+			name: "Get membership failed - user is not a member",
+
+			inputOrganizationID: fixture.GlobexOrganizationID,
+			inputUserID:         "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			res, err := testRepo.GetMembership(ctx, tc.inputOrganizationID, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.inputOrganizationID, res.OrganizationID)
+			assert.Equal(t, tc.inputUserID, res.UserID)
+			assert.Equal(t, tc.expectedRole, res.Role)
+		})
+	}
+}
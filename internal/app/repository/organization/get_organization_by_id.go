@@ -0,0 +1,31 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetOrganizationByID retrieves an organization by its ID.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the organization.
+//
+// Returns:
+//   - *model.Organization: The organization if found.
+//   - error: dbutils.ErrRecordNotFoundType if no organization has the ID, otherwise any retrieval error.
+func (o *organizationRepository) GetOrganizationByID(ctx context.Context, id string) (*model.Organization, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetOrganizationByID")
+	defer s.End()
+
+	organization := &model.Organization{}
+	err := o.db.WithContext(ctx).Where("id = ?", id).First(organization).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return organization, nil
+}
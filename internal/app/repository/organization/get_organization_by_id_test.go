@@ -0,0 +1,56 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_GetOrganizationByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID string
+
+		expectedName  string
+		expectedError error
+	}{
+		{
+			name: "Get organization successfully",
+
+			inputID: fixture.AcmeOrganizationID,
+
+			expectedName: "Acme",
+		},
+		{
+			name: "Get organization failed - unknown ID",
+
+			inputID: "0a9e1f00-0009-4c5d-9e8f-1a2b3c4d5e09",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			res, err := testRepo.GetOrganizationByID(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.inputID, res.ID)
+			assert.Equal(t, tc.expectedName, res.Name)
+		})
+	}
+}
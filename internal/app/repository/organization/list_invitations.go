@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListInvitations retrieves the invitations to join an organization, newest first, whatever their status.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//
+// Returns:
+//   - []*model.OrganizationInvitation: The invitations, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (o *organizationRepository) ListInvitations(ctx context.Context, organizationID string) ([]*model.OrganizationInvitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListOrganizationInvitations")
+	defer s.End()
+
+	invitations := []*model.OrganizationInvitation{}
+	err := o.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC, id ASC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return invitations, nil
+}
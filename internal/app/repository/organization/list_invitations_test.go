@@ -0,0 +1,25 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+	testRepo := NewOrganizationRepository(db)
+
+	res, err := testRepo.ListInvitations(ctx, fixture.AcmeOrganizationID)
+	assert.Nil(t, err)
+
+	invitations := []string{}
+	for _, invitation := range res {
+		invitations = append(invitations, invitation.Email+":"+invitation.Role+":"+invitation.Status)
+	}
+	assert.Equal(t, []string{"testuser001@example.com:member:pending"}, invitations)
+}
@@ -0,0 +1,35 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListMembers retrieves the memberships of an organization along with their users, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//
+// Returns:
+//   - []*model.Membership: The memberships, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (o *organizationRepository) ListMembers(ctx context.Context, organizationID string) ([]*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListMembers")
+	defer s.End()
+
+	memberships := []*model.Membership{}
+	err := o.db.WithContext(ctx).
+		Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC, id ASC").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return memberships, nil
+}
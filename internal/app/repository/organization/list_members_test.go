@@ -0,0 +1,28 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_ListMembers(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+	testRepo := NewOrganizationRepository(db)
+
+	res, err := testRepo.ListMembers(ctx, fixture.AcmeOrganizationID)
+	assert.Nil(t, err)
+
+	members := []string{}
+	for _, membership := range res {
+		if !assert.NotNil(t, membership.User) {
+			return
+		}
+		members = append(members, membership.User.Username+":"+membership.Role)
+	}
+	assert.Equal(t, []string{"Alice:owner", "Bob:admin", "Charlie:member"}, members)
+}
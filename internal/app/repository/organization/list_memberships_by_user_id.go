@@ -0,0 +1,35 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListMembershipsByUserID retrieves the memberships of a user along with their organizations, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - []*model.Membership: The memberships, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (o *organizationRepository) ListMembershipsByUserID(ctx context.Context, userID string) ([]*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListMembershipsByUserID")
+	defer s.End()
+
+	memberships := []*model.Membership{}
+	err := o.db.WithContext(ctx).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return memberships, nil
+}
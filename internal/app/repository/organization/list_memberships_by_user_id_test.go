@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_ListMembershipsByUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUserID string
+
+		expectedMemberships []string
+	}{
+		{
+			name: "List memberships of a user in several organizations",
+
+			inputUserID: "123e4567-e89b-12d3-a456-eb6b9e546001",
+
+			expectedMemberships: []string{"Acme:admin", "Globex:owner"},
+		},
+		{
+			name: "List memberships of a user in no organization",
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedMemberships: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			res, err := testRepo.ListMembershipsByUserID(ctx, tc.inputUserID)
+			assert.Nil(t, err)
+
+			memberships := []string{}
+			for _, membership := range res {
+				if !assert.NotNil(t, membership.Organization) {
+					return
+				}
+				memberships = append(memberships, membership.Organization.Name+":"+membership.Role)
+			}
+			assert.Equal(t, tc.expectedMemberships, memberships)
+		})
+	}
+}
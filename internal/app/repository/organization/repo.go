@@ -0,0 +1,194 @@
+// Package organization provides repository operations for organizations, their memberships and the invitations
+// to join them using GORM.
+package organization
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for organization repository operations.
+//
+//go:generate mockery --name=Repository --filename=organization_repo.go --output=./mocks
+type Repository interface {
+	// CreateOrganization stores a new organization along with the membership of its owner in a single transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organization: The organization to store.
+	//   - ownerID: The ID of the user owning the organization.
+	//
+	// Returns:
+	//   - *model.Organization: The created organization.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateOrganization(ctx context.Context, organization *model.Organization, ownerID string) (*model.Organization, error)
+
+	// GetOrganizationByID retrieves an organization by its ID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the organization.
+	//
+	// Returns:
+	//   - *model.Organization: The organization if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no organization has the ID, otherwise any retrieval error.
+	GetOrganizationByID(ctx context.Context, id string) (*model.Organization, error)
+
+	// UpdateOrganization renames an organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the organization.
+	//   - name: The new name.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if no organization has the ID, otherwise any update error.
+	UpdateOrganization(ctx context.Context, id, name string) error
+
+	// DeleteOrganization deletes an organization with its memberships and invitations.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the organization.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if no organization has the ID, otherwise any deletion error.
+	DeleteOrganization(ctx context.Context, id string) error
+
+	// ListMembershipsByUserID retrieves the memberships of a user along with their organizations.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//
+	// Returns:
+	//   - []*model.Membership: The memberships, oldest first, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListMembershipsByUserID(ctx context.Context, userID string) ([]*model.Membership, error)
+
+	// GetMembership retrieves the membership of a user in an organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//   - userID: The ID of the user.
+	//
+	// Returns:
+	//   - *model.Membership: The membership if the user belongs to the organization.
+	//   - error: dbutils.ErrRecordNotFoundType if the user is not a member, otherwise any retrieval error.
+	GetMembership(ctx context.Context, organizationID, userID string) (*model.Membership, error)
+
+	// ListMembers retrieves the memberships of an organization along with their users.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//
+	// Returns:
+	//   - []*model.Membership: The memberships, oldest first, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListMembers(ctx context.Context, organizationID string) ([]*model.Membership, error)
+
+	// UpdateMemberRole changes the role of a member other than the owner.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//   - userID: The ID of the member.
+	//   - role: The new role, model.OrganizationAdmin or model.OrganizationMember.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user is not a member or is the owner, otherwise any update error.
+	UpdateMemberRole(ctx context.Context, organizationID, userID, role string) error
+
+	// DeleteMembership removes a member other than the owner from an organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//   - userID: The ID of the member.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user is not a member or is the owner, otherwise any deletion error.
+	DeleteMembership(ctx context.Context, organizationID, userID string) error
+
+	// TransferOwnership makes another member the owner of an organization in a single transaction.
+	// The previous owner stays in the organization as an admin.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//   - ownerID: The ID of the current owner.
+	//   - newOwnerID: The ID of the member becoming the owner.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if ownerID is not the owner or newOwnerID is not another member,
+	//     otherwise any update error.
+	TransferOwnership(ctx context.Context, organizationID, ownerID, newOwnerID string) error
+
+	// CreateInvitation stores a new pending invitation to join an organization.
+	// The other pending invitations of the same email address to the organization are superseded by it in the
+	// same transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - invitation: The invitation to store.
+	//
+	// Returns:
+	//   - *model.OrganizationInvitation: The created invitation.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateInvitation(ctx context.Context, invitation *model.OrganizationInvitation) (*model.OrganizationInvitation, error)
+
+	// ListInvitations retrieves the invitations to join an organization, newest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//
+	// Returns:
+	//   - []*model.OrganizationInvitation: The invitations, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListInvitations(ctx context.Context, organizationID string) ([]*model.OrganizationInvitation, error)
+
+	// GetInvitationByTokenHash retrieves the invitation whose link carries the hashed token.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - tokenHash: The SHA-256 hash of the presented token.
+	//
+	// Returns:
+	//   - *model.OrganizationInvitation: The invitation if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no invitation matches, otherwise any retrieval error.
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*model.OrganizationInvitation, error)
+
+	// RevokeInvitation revokes an invitation to join an organization, provided it is still pending.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - organizationID: The ID of the organization.
+	//   - id: The ID of the invitation.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the organization has no pending invitation with the ID,
+	//     otherwise any update error.
+	RevokeInvitation(ctx context.Context, organizationID, id string) error
+
+	// AcceptInvitation adds a user to the organization of an invitation and accepts the invitation in a single
+	// transaction. The invitation must still be pending and not expired; otherwise nothing is written.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - invitation: The invitation to accept.
+	//   - userID: The ID of the user joining the organization.
+	//
+	// Returns:
+	//   - *model.Membership: The created membership.
+	//   - error: dbutils.ErrRecordNotFoundType if the invitation is no longer pending or has expired,
+	//     dbutils.ErrDuplicationType if the user already is a member, otherwise an error if the creation fails.
+	AcceptInvitation(ctx context.Context, invitation *model.OrganizationInvitation, userID string) (*model.Membership, error)
+}
+
+// organizationRepository is the concrete implementation of the Repository interface.
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new instance of the organization repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - Repository: A new organization repository instance.
+func NewOrganizationRepository(db *gorm.DB) Repository {
+	return &organizationRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,39 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// RevokeInvitation revokes an invitation to join an organization, provided it is still pending.
+// The condition makes the revocation and the acceptance exclusive: of concurrent attempts, only one succeeds.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//   - id: The ID of the invitation.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the organization has no pending invitation with the ID,
+//     otherwise any update error.
+func (o *organizationRepository) RevokeInvitation(ctx context.Context, organizationID, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_RevokeOrganizationInvitation")
+	defer s.End()
+
+	result := o.db.WithContext(ctx).
+		Model(&model.OrganizationInvitation{}).
+		Where("id = ? AND organization_id = ? AND status = ?", id, organizationID, model.InvitationPending).
+		Update("status", model.InvitationRevoked)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputOrganizationID string
+		inputID             string
+
+		expectedError error
+	}{
+		{
+			name: "Revoke pending invitation successfully",
+
+			inputOrganizationID: fixture.AcmeOrganizationID,
+			inputID:             "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01",
+		},
+		{
+			name: "Revoke invitation failed - invitation of another organization",
+
+			inputOrganizationID: fixture.GlobexOrganizationID,
+			inputID:             "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Revoke invitation failed - unknown ID",
+
+			inputOrganizationID: fixture.AcmeOrganizationID,
+			inputID:             "0c7f3d00-0009-4c5d-9e8f-1a2b3c4d5e09",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			err := testRepo.RevokeInvitation(ctx, tc.inputOrganizationID, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.OrganizationInvitation{}
+			err = db.Where("id = ?", tc.inputID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, model.InvitationRevoked, saved.Status)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// TransferOwnership makes another member the owner of an organization in a single transaction.
+// The current owner is demoted to admin before the new owner is promoted, as an organization may only have one
+// owner at a time. Both updates are conditional, so of concurrent transfers only one succeeds.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//   - ownerID: The ID of the current owner.
+//   - newOwnerID: The ID of the member becoming the owner.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if ownerID is not the owner or newOwnerID is not another member,
+//     otherwise any update error.
+func (o *organizationRepository) TransferOwnership(ctx context.Context, organizationID, ownerID, newOwnerID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_TransferOwnership")
+	defer s.End()
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Membership{}).
+			Where("organization_id = ? AND user_id = ? AND role = ?", organizationID, ownerID, model.OrganizationOwner).
+			Update("role", model.OrganizationAdmin)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrRecordNotFoundType
+		}
+
+		result = tx.Model(&model.Membership{}).
+			Where("organization_id = ? AND user_id = ? AND user_id <> ?", organizationID, newOwnerID, ownerID).
+			Update("role", model.OrganizationOwner)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrRecordNotFoundType
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dbutils.CatchDBError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_TransferOwnership(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputOwnerID    string
+		inputNewOwnerID string
+
+		expectedError error
+		expectedRoles map[string]string
+	}{
+		{
+			name: "Transfer ownership successfully",
+
+			inputOwnerID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputNewOwnerID: "987e6543-e21b-12d3-a456-eb6b9e546002",
+
+			expectedRoles: map[string]string{
+				"de305d54-75b4-431b-adb2-eb6b9e546000": model.OrganizationAdmin,
+				"123e4567-e89b-12d3-a456-eb6b9e546001": model.OrganizationAdmin,
+				"987e6543-e21b-12d3-a456-eb6b9e546002": model.OrganizationOwner,
+			},
+		},
+		{
+			name: "Transfer ownership failed - user is not the owner",
+
+			inputOwnerID:    "123e4567-e89b-12d3-a456-eb6b9e546001",
+			inputNewOwnerID: "987e6543-e21b-12d3-a456-eb6b9e546002",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Transfer ownership failed - new owner is not a member, owner is not demoted",
+
+			inputOwnerID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputNewOwnerID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Transfer ownership failed - transfer to the owner",
+
+			inputOwnerID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputNewOwnerID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			err := testRepo.TransferOwnership(ctx, fixture.AcmeOrganizationID, tc.inputOwnerID, tc.inputNewOwnerID)
+			assert.Equal(t, tc.expectedError, err)
+
+			expectedRoles := tc.expectedRoles
+			if expectedRoles == nil {
+				expectedRoles = map[string]string{
+					"de305d54-75b4-431b-adb2-eb6b9e546000": model.OrganizationOwner,
+					"123e4567-e89b-12d3-a456-eb6b9e546001": model.OrganizationAdmin,
+					"987e6543-e21b-12d3-a456-eb6b9e546002": model.OrganizationMember,
+				}
+			}
+
+			memberships := []*model.Membership{}
+			err = db.Where("organization_id = ?", fixture.AcmeOrganizationID).Find(&memberships).Error
+			assert.Nil(t, err)
+
+			roles := map[string]string{}
+			for _, membership := range memberships {
+				roles[membership.UserID] = membership.Role
+			}
+			assert.Equal(t, expectedRoles, roles)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateMemberRole changes the role of a member other than the owner.
+// The owner is excluded by the update itself, so the role of the owner only changes through TransferOwnership.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - organizationID: The ID of the organization.
+//   - userID: The ID of the member.
+//   - role: The new role, model.OrganizationAdmin or model.OrganizationMember.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user is not a member or is the owner, otherwise any update error.
+func (o *organizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID, role string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateMemberRole")
+	defer s.End()
+
+	result := o.db.WithContext(ctx).
+		Model(&model.Membership{}).
+		Where("organization_id = ? AND user_id = ? AND role <> ?", organizationID, userID, model.OrganizationOwner).
+		Update("role", role)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
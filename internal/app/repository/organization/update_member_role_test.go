@@ -0,0 +1,73 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_UpdateMemberRole(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUserID string
+		inputRole   string
+
+		expectedError error
+	}{
+		{
+			name: "Promote member to admin successfully",
+
+			inputUserID: "987e6543-e21b-12d3-a456-eb6b9e546002",
+			inputRole:   model.OrganizationAdmin,
+		},
+		{
+			name: "Demote admin to member successfully",
+
+			inputUserID: "123e4567-e89b-12d3-a456-eb6b9e546001",
+			inputRole:   model.OrganizationMember,
+		},
+		{
+			name: "Update member role failed - owner is excluded",
+
+			inputUserID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputRole:   model.OrganizationMember,
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Update member role failed - user is not a member",
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputRole:   model.OrganizationAdmin,
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			err := testRepo.UpdateMemberRole(ctx, fixture.AcmeOrganizationID, tc.inputUserID, tc.inputRole)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.Membership{}
+			err = db.Where("organization_id = ? AND user_id = ?", fixture.AcmeOrganizationID, tc.inputUserID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputRole, saved.Role)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateOrganization renames an organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the organization.
+//   - name: The new name.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if no organization has the ID, otherwise any update error.
+func (o *organizationRepository) UpdateOrganization(ctx context.Context, id, name string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateOrganization")
+	defer s.End()
+
+	result := o.db.WithContext(ctx).
+		Model(&model.Organization{}).
+		Where("id = ?", id).
+		Update("name", name)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
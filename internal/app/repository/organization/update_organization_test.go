@@ -0,0 +1,59 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOrganization_UpdateOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID   string
+		inputName string
+
+		expectedError error
+	}{
+		{
+			name: "Update organization successfully",
+
+			inputID:   fixture.AcmeOrganizationID,
+			inputName: "Acme Corporation",
+		},
+		{
+			name: "Update organization failed - unknown ID",
+
+			inputID:   "0a9e1f00-0009-4c5d-9e8f-1a2b3c4d5e09",
+			inputName: "Nobody",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{})
+			testRepo := NewOrganizationRepository(db)
+
+			err := testRepo.UpdateOrganization(ctx, tc.inputID, tc.inputName)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.Organization{}
+			err = db.Where("id = ?", tc.inputID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputName, saved.Name)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// AddUserEvent stores a user domain event in the outbox.
+// It must be called with the transaction writing the change, so the event is committed or rolled back with it.
+// The payload carries the tenant of the user, which the user model keeps out of its JSON form.
+//
+// Parameters:
+//   - tx: The GORM transaction of the change.
+//   - eventType: The type of the event, one of the EventUser* constants.
+//   - user: The user after the change, or before it for a deletion.
+//
+// Returns:
+//   - error: An error if the event cannot be stored, otherwise nil.
+func AddUserEvent(tx *gorm.DB, eventType string, user *model.User) error {
+	payload, err := json.Marshal(struct {
+		*model.User
+		TenantID string `json:"tenant_id"`
+	}{
+		User:     user,
+		TenantID: user.TenantID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&model.OutboxEvent{
+		TenantID:      user.TenantID,
+		AggregateID:   user.ID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		NextAttemptAt: time.Now(),
+	}).Error
+}
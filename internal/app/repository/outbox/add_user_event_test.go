@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestAddUserEvent(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	user := &model.User{
+		Base:        model.Base{ID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+		Username:    "testuser001",
+		Email:       "testuser001@example.com",
+		Password:    "hashed-password",
+		DisplayName: "Test User 001",
+		TenantID:    "brand_a",
+	}
+
+	err := AddUserEvent(db, EventUserUpdated, user)
+	assert.NoError(t, err)
+
+	// The event belongs to the tenant of the user
+	saved := &model.OutboxEvent{}
+	assert.NoError(t, db.WithContext(tenant.NewContext(t.Context(), "brand_a")).Where("aggregate_id = ?", user.ID).First(saved).Error)
+	assert.NotEmpty(t, saved.ID)
+	assert.Equal(t, "brand_a", saved.TenantID)
+	assert.Equal(t, EventUserUpdated, saved.EventType)
+	assert.False(t, saved.NextAttemptAt.IsZero())
+	assert.Nil(t, saved.PublishedAt)
+	assert.NotContains(t, saved.Payload, "hashed-password")
+
+	payload := map[string]any{}
+	assert.NoError(t, json.Unmarshal([]byte(saved.Payload), &payload))
+	assert.Equal(t, "testuser001", payload["username"])
+	assert.Equal(t, "testuser001@example.com", payload["email"])
+	assert.Equal(t, "brand_a", payload["tenant_id"])
+}
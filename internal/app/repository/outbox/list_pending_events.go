@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListPendingEvents retrieves the events neither published nor dead-lettered, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - limit: The maximum number of events to return.
+//
+// Returns:
+//   - []*model.OutboxEvent: The pending events, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (o *outboxRepository) ListPendingEvents(ctx context.Context, limit int) ([]*model.OutboxEvent, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListPendingEvents")
+	defer s.End()
+
+	events := []*model.OutboxEvent{}
+	err := o.db.WithContext(ctx).
+		Where("published_at IS NULL AND dead_lettered_at IS NULL").
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return events, nil
+}
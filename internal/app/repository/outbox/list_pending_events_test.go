@@ -0,0 +1,73 @@
+package outbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestOutbox_ListPendingEvents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB    func(t *testing.T) *gorm.DB
+		inputLimit int
+
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name: "List pending events, oldest first",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.OutboxCommonTestDB{})
+			},
+			inputLimit: 10,
+
+			expectedIDs: []string{"c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71", "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72"},
+		},
+		{
+			name: "List pending events up to the limit",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.OutboxCommonTestDB{})
+			},
+			inputLimit: 1,
+
+			expectedIDs: []string{"c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"},
+		},
+		{
+			name: "List pending events of an empty outbox",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			inputLimit: 10,
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testOutboxRepo := NewOutboxRepository(db, nil)
+
+			res, err := testOutboxRepo.ListPendingEvents(ctx, tc.inputLimit)
+			assert.Equal(t, tc.expectedError, err)
+
+			ids := []string{}
+			for _, event := range res {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
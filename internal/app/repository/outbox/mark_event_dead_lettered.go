@@ -0,0 +1,32 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// MarkEventDeadLettered records that an event was given up on and moved to the dead-letter stream.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - eventID: The ID of the event.
+//   - streamID: The ID of the dead-letter stream entry.
+//   - attempts: The number of failed attempts.
+//   - lastError: The error of the last attempt.
+//   - deadLetteredAt: When the event was dead-lettered.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (o *outboxRepository) MarkEventDeadLettered(ctx context.Context, eventID, streamID string, attempts int, lastError string, deadLetteredAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_MarkEventDeadLettered")
+	defer s.End()
+
+	return o.updateEvent(ctx, eventID, map[string]any{
+		"stream_id":        streamID,
+		"attempts":         attempts,
+		"last_error":       lastError,
+		"dead_lettered_at": deadLetteredAt,
+	})
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestOutbox_MarkEventDeadLettered(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB      func(t *testing.T) *gorm.DB
+		inputEventID string
+
+		expectedError error
+	}{
+		{
+			name: "Mark event dead-lettered successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.OutboxCommonTestDB{})
+			},
+			inputEventID: "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72",
+		},
+		{
+			name: "Mark event dead-lettered failed - event not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.OutboxCommonTestDB{})
+			},
+			inputEventID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testOutboxRepo := NewOutboxRepository(db, nil)
+
+			err := testOutboxRepo.MarkEventDeadLettered(ctx, tc.inputEventID, "1672534800000-0", 10, "timeout", fixture.TestTime)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.OutboxEvent{}
+			assert.NoError(t, db.Where("id = ?", tc.inputEventID).First(saved).Error)
+			assert.Equal(t, "1672534800000-0", saved.StreamID)
+			assert.Equal(t, 10, saved.Attempts)
+			assert.NotNil(t, saved.DeadLetteredAt)
+			assert.Nil(t, saved.PublishedAt)
+
+			pending, err := testOutboxRepo.ListPendingEvents(ctx, 10)
+			assert.NoError(t, err)
+			assert.Len(t, pending, 1)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// MarkEventFailed records a failed attempt to publish an event and when to try again.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - eventID: The ID of the event.
+//   - attempts: The number of failed attempts so far.
+//   - lastError: The error of the attempt.
+//   - nextAttemptAt: When the event may be published again.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (o *outboxRepository) MarkEventFailed(ctx context.Context, eventID string, attempts int, lastError string, nextAttemptAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_MarkEventFailed")
+	defer s.End()
+
+	return o.updateEvent(ctx, eventID, map[string]any{
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	})
+}
@@ -0,0 +1,69 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestOutbox_MarkEventFailed(t *testing.T) {
+	t.Parallel()
+
+	nextAttemptAt := fixture.TestTime.Add(4 * time.Hour)
+
+	testCases := []struct {
+		name string
+
+		setupDB      func(t *testing.T) *gorm.DB
+		inputEventID string
+
+		expectedError error
+	}{
+		{
+			name: "Mark event failed successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.OutboxCommonTestDB{})
+			},
+			inputEventID: "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72",
+		},
+		{
+			name: "Mark event failed failed - event not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.OutboxCommonTestDB{})
+			},
+			inputEventID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testOutboxRepo := NewOutboxRepository(db, nil)
+
+			err := testOutboxRepo.MarkEventFailed(ctx, tc.inputEventID, 3, "timeout", nextAttemptAt)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.OutboxEvent{}
+			assert.NoError(t, db.Where("id = ?", tc.inputEventID).First(saved).Error)
+			assert.Equal(t, 3, saved.Attempts)
+			assert.Equal(t, "timeout", saved.LastError)
+			assert.True(t, nextAttemptAt.Equal(saved.NextAttemptAt))
+			assert.Nil(t, saved.PublishedAt)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// MarkEventPublished records that an event was delivered and where.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - eventID: The ID of the event.
+//   - streamID: The ID of the stream entry the event was delivered as.
+//   - publishedAt: When the event was delivered.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (o *outboxRepository) MarkEventPublished(ctx context.Context, eventID, streamID string, publishedAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_MarkEventPublished")
+	defer s.End()
+
+	return o.updateEvent(ctx, eventID, map[string]any{
+		"stream_id":    streamID,
+		"published_at": publishedAt,
+	})
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// PublishEvent appends an event to a Redis stream.
+// The entry carries the event ID, so consumers can drop the duplicates at-least-once delivery may produce, and the
+// tenant of the event, so it is only delivered to the webhooks of that tenant.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - stream: The name of the stream.
+//   - event: The event to publish.
+//
+// Returns:
+//   - string: The ID of the stream entry.
+//   - error: An error if the event cannot be appended, otherwise nil.
+func (o *outboxRepository) PublishEvent(ctx context.Context, stream string, event *model.OutboxEvent) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_PublishEvent")
+	defer s.End()
+
+	return o.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		Values: map[string]any{
+			"event_id":     event.ID,
+			"event_type":   event.EventType,
+			"tenant_id":    event.TenantID,
+			"aggregate_id": event.AggregateID,
+			"payload":      event.Payload,
+			"occurred_at":  event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		},
+	}).Result()
+}
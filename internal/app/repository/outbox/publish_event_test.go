@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOutbox_PublishEvent(t *testing.T) {
+	t.Parallel()
+
+	event := &model.OutboxEvent{
+		Base:        model.Base{ID: "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71", CreatedAt: fixture.TestTime},
+		TenantID:    "brand_a",
+		AggregateID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+		EventType:   EventUserCreated,
+		Payload:     `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe"}`,
+	}
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+
+		expectedError error
+	}{
+		{
+			name: "Append the event to the stream",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "Closed Redis client",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := tc.setupRedis(ctx)
+			testOutboxRepo := NewOutboxRepository(nil, redisClient)
+
+			streamID, err := testOutboxRepo.PublishEvent(ctx, "user-events", event)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			entries, err := redisClient.XRange(ctx, "user-events", "-", "+").Result()
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1)
+			assert.Equal(t, streamID, entries[0].ID)
+			assert.Equal(t, map[string]any{
+				"event_id":     "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71",
+				"event_type":   "user.created",
+				"tenant_id":    "brand_a",
+				"aggregate_id": "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				"payload":      `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe"}`,
+				"occurred_at":  "2023-01-01T00:00:00Z",
+			}, entries[0].Values)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package outbox provides repository operations for the transactional outbox of domain events.
+// Events are stored with GORM in the same transaction as the change they describe, and published
+// to Redis Streams by the relay.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Types of the user domain events.
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+)
+
+// Repository represents the interface for outbox repository operations.
+//
+//go:generate mockery --name=Repository --filename=outbox_repo.go --output=./mocks
+type Repository interface {
+	// ListPendingEvents retrieves the events neither published nor dead-lettered, oldest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - limit: The maximum number of events to return.
+	//
+	// Returns:
+	//   - []*model.OutboxEvent: The pending events, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListPendingEvents(ctx context.Context, limit int) ([]*model.OutboxEvent, error)
+
+	// PublishEvent appends an event to a Redis stream.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - stream: The name of the stream.
+	//   - event: The event to publish.
+	//
+	// Returns:
+	//   - string: The ID of the stream entry.
+	//   - error: An error if the event cannot be appended, otherwise nil.
+	PublishEvent(ctx context.Context, stream string, event *model.OutboxEvent) (string, error)
+
+	// MarkEventPublished records that an event was delivered and where.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - eventID: The ID of the event.
+	//   - streamID: The ID of the stream entry the event was delivered as.
+	//   - publishedAt: When the event was delivered.
+	//
+	// Returns:
+	//   - error: An error if the update fails, otherwise nil.
+	MarkEventPublished(ctx context.Context, eventID, streamID string, publishedAt time.Time) error
+
+	// MarkEventFailed records a failed attempt to publish an event and when to try again.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - eventID: The ID of the event.
+	//   - attempts: The number of failed attempts so far.
+	//   - lastError: The error of the attempt.
+	//   - nextAttemptAt: When the event may be published again.
+	//
+	// Returns:
+	//   - error: An error if the update fails, otherwise nil.
+	MarkEventFailed(ctx context.Context, eventID string, attempts int, lastError string, nextAttemptAt time.Time) error
+
+	// MarkEventDeadLettered records that an event was given up on and moved to the dead-letter stream.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - eventID: The ID of the event.
+	//   - streamID: The ID of the dead-letter stream entry.
+	//   - attempts: The number of failed attempts.
+	//   - lastError: The error of the last attempt.
+	//   - deadLetteredAt: When the event was dead-lettered.
+	//
+	// Returns:
+	//   - error: An error if the update fails, otherwise nil.
+	MarkEventDeadLettered(ctx context.Context, eventID, streamID string, attempts int, lastError string, deadLetteredAt time.Time) error
+}
+
+// outboxRepository is the concrete implementation of the Repository interface.
+type outboxRepository struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewOutboxRepository creates a new instance of the outbox repository.
+//
+// Parameters:
+//   - db: The GORM database connection storing the events.
+//   - redisClient: The Redis client the events are published to.
+//
+// Returns:
+//   - Repository: A new outbox repository instance.
+func NewOutboxRepository(db *gorm.DB, redisClient *redis.Client) Repository {
+	return &outboxRepository{
+		db:          db,
+		redisClient: redisClient,
+	}
+}
+
+// updateEvent updates the given columns of an event.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - eventID: The ID of the event.
+//   - columns: The new values keyed by column name.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the event does not exist, otherwise any update error.
+func (o *outboxRepository) updateEvent(ctx context.Context, eventID string, columns map[string]any) error {
+	result := o.db.WithContext(ctx).Model(&model.OutboxEvent{}).Where("id = ?", eventID).Updates(columns)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package passkey
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreatePasskey stores a newly registered passkey.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - passkey: The passkey model containing the credential and owning user.
+//
+// Returns:
+//   - *model.UserPasskey: The created passkey model.
+//   - error: dbutils.ErrDuplicationType if the credential is already registered, otherwise any creation error.
+func (p *passkeyRepository) CreatePasskey(ctx context.Context, passkey *model.UserPasskey) (*model.UserPasskey, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreatePasskey")
+	defer s.End()
+
+	err := p.db.WithContext(ctx).Create(passkey).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return passkey, nil
+}
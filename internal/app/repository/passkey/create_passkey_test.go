@@ -0,0 +1,81 @@
+package passkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestPasskey_CreatePasskey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB      func(t *testing.T) *gorm.DB
+		inputPasskey *model.UserPasskey
+
+		expectedError error
+	}{
+		{
+			name: "Create passkey successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			},
+
+			inputPasskey: &model.UserPasskey{
+				UserID:          "de305d54-75b4-431b-adb2-eb6b9e546000",
+				CredentialID:    []byte("credential-002"),
+				PublicKey:       []byte("public-key-002"),
+				AttestationType: "none",
+				Transports:      []string{"usb", "nfc"},
+			},
+		},
+		{
+			name: "Create passkey failed - credential already registered",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			},
+
+			inputPasskey: &model.UserPasskey{
+				UserID:          "de305d54-75b4-431b-adb2-eb6b9e546000",
+				CredentialID:    []byte("credential-001"),
+				PublicKey:       []byte("public-key-002"),
+				AttestationType: "none",
+				Transports:      []string{},
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testPasskeyRepo := NewPasskeyRepository(db, nil)
+
+			res, err := testPasskeyRepo.CreatePasskey(ctx, tc.inputPasskey)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, res.ID)
+
+			saved := &model.UserPasskey{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputPasskey.CredentialID, saved.CredentialID)
+			assert.Equal(t, tc.inputPasskey.Transports, saved.Transports)
+		})
+	}
+}
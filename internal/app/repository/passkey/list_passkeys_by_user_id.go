@@ -0,0 +1,34 @@
+package passkey
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListPasskeysByUserID retrieves all passkeys registered by a user, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the passkeys.
+//
+// Returns:
+//   - []*model.UserPasskey: The registered passkeys, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (p *passkeyRepository) ListPasskeysByUserID(ctx context.Context, userID string) ([]*model.UserPasskey, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListPasskeysByUserID")
+	defer s.End()
+
+	passkeys := []*model.UserPasskey{}
+	err := p.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&passkeys).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return passkeys, nil
+}
@@ -0,0 +1,77 @@
+package passkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestPasskey_ListPasskeysByUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+
+		expectedError  error
+		expectedOutput []*model.UserPasskey
+	}{
+		{
+			name: "List passkeys successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedOutput: []*model.UserPasskey{
+				{
+					Base: model.Base{
+						ID:        "2f8d6c1a-7b3e-4a5d-9c0f-3e1b2a4d5c6e",
+						CreatedAt: fixture.TestTime,
+						UpdatedAt: fixture.TestTime,
+					},
+					TenantID:        tenant.Default,
+					UserID:          "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+					CredentialID:    []byte("credential-001"),
+					PublicKey:       []byte("public-key-001"),
+					AttestationType: "none",
+					Transports:      []string{"internal"},
+					SignCount:       1,
+				},
+			},
+		},
+		{
+			name: "List passkeys of a user without any",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			},
+
+			inputUserID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedOutput: []*model.UserPasskey{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testPasskeyRepo := NewPasskeyRepository(db, nil)
+
+			res, err := testPasskeyRepo.ListPasskeysByUserID(ctx, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
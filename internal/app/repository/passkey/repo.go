@@ -0,0 +1,96 @@
+// Package passkey provides repository operations for WebAuthn passkeys.
+// It stores the credentials registered by users using GORM, and keeps the
+// short-lived session data of in-flight WebAuthn ceremonies in Redis.
+package passkey
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// SessionKeyFormat is the Redis key format used to store the session data of a WebAuthn ceremony by tenant and
+// session ID.
+const SessionKeyFormat = "passkey_session:%s:%s"
+
+// Repository represents the interface for passkey repository operations.
+//
+//go:generate mockery --name=Repository --filename=passkey_repo.go --output=./mocks
+type Repository interface {
+	// CreatePasskey stores a newly registered passkey.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - passkey: The passkey model containing the credential and owning user.
+	//
+	// Returns:
+	//   - *model.UserPasskey: The created passkey model.
+	//   - error: dbutils.ErrDuplicationType if the credential is already registered, otherwise any creation error.
+	CreatePasskey(ctx context.Context, passkey *model.UserPasskey) (*model.UserPasskey, error)
+
+	// ListPasskeysByUserID retrieves all passkeys registered by a user, oldest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the passkeys.
+	//
+	// Returns:
+	//   - []*model.UserPasskey: The registered passkeys, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListPasskeysByUserID(ctx context.Context, userID string) ([]*model.UserPasskey, error)
+
+	// UpdatePasskeyUsage records a successful login with a passkey.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - passkeyID: The ID of the passkey used.
+	//   - signCount: The signature counter returned by the authenticator.
+	//   - usedAt: When the login happened.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the passkey does not exist, otherwise any update error.
+	UpdatePasskeyUsage(ctx context.Context, passkeyID string, signCount uint32, usedAt time.Time) error
+
+	// SaveSession stores the session data of a WebAuthn ceremony until the client answers the challenge.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - sessionID: The random identifier handed to the client.
+	//   - session: The session data holding the challenge.
+	//   - exp: How long the session stays valid.
+	//
+	// Returns:
+	//   - error: An error if the session cannot be stored, otherwise nil.
+	SaveSession(ctx context.Context, sessionID string, session *webauthn.SessionData, exp time.Duration) error
+
+	// ConsumeSession retrieves and deletes the session data of a WebAuthn ceremony, so a challenge can only be answered once.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - sessionID: The identifier returned by the client.
+	//
+	// Returns:
+	//   - *webauthn.SessionData: The stored session data if found.
+	//   - error: dbutils.ErrRecordNotFoundType if the session is unknown or expired, otherwise nil.
+	ConsumeSession(ctx context.Context, sessionID string) (*webauthn.SessionData, error)
+}
+
+// passkeyRepository is the concrete implementation of the Repository interface.
+type passkeyRepository struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewPasskeyRepository creates a new instance of the passkey repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//   - redisClient: The Redis client used to store ceremony session data.
+//
+// Returns:
+//   - Repository: A new passkey repository instance.
+func NewPasskeyRepository(db *gorm.DB, redisClient *redis.Client) Repository {
+	return &passkeyRepository{
+		db:          db,
+		redisClient: redisClient,
+	}
+}
@@ -0,0 +1,66 @@
+package passkey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// SaveSession stores the session data of a WebAuthn ceremony until the client answers the challenge.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - sessionID: The random identifier handed to the client.
+//   - session: The session data holding the challenge.
+//   - exp: How long the session stays valid.
+//
+// Returns:
+//   - error: An error if the session cannot be stored, otherwise nil.
+func (p *passkeyRepository) SaveSession(ctx context.Context, sessionID string, session *webauthn.SessionData, exp time.Duration) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_SavePasskeySession")
+	defer s.End()
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return p.redisClient.Set(ctx, fmt.Sprintf(SessionKeyFormat, tenant.FromContext(ctx), sessionID), data, exp).Err()
+}
+
+// ConsumeSession retrieves and deletes the session data of a WebAuthn ceremony, so a challenge can only be answered once.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - sessionID: The identifier returned by the client.
+//
+// Returns:
+//   - *webauthn.SessionData: The stored session data if found.
+//   - error: dbutils.ErrRecordNotFoundType if the session is unknown or expired, otherwise nil.
+func (p *passkeyRepository) ConsumeSession(ctx context.Context, sessionID string) (*webauthn.SessionData, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ConsumePasskeySession")
+	defer s.End()
+
+	data, err := p.redisClient.GetDel(ctx, fmt.Sprintf(SessionKeyFormat, tenant.FromContext(ctx), sessionID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, dbutils.ErrRecordNotFoundType
+		}
+		return nil, err
+	}
+
+	session := &webauthn.SessionData{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,87 @@
+package passkey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+)
+
+func TestPasskey_Session(t *testing.T) {
+	t.Parallel()
+
+	expires := time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+
+		setupRedis     func(ctx context.Context) *redis.Client
+		inputSessionID string
+
+		expectedError  error
+		expectedOutput *webauthn.SessionData
+	}{
+		{
+			name: "Consume saved session successfully",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				err := NewPasskeyRepository(nil, redisClient).SaveSession(ctx, "session-001", &webauthn.SessionData{
+					Challenge:        "challenge-001",
+					RelyingPartyID:   "localhost",
+					UserID:           []byte("user-001"),
+					Expires:          expires,
+					UserVerification: protocol.VerificationPreferred,
+				}, time.Minute)
+				assert.Nil(t, err)
+				return redisClient
+			},
+			inputSessionID: "session-001",
+
+			expectedOutput: &webauthn.SessionData{
+				Challenge:        "challenge-001",
+				RelyingPartyID:   "localhost",
+				UserID:           []byte("user-001"),
+				Expires:          expires,
+				UserVerification: protocol.VerificationPreferred,
+			},
+		},
+		{
+			name: "Consume unknown session",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			inputSessionID: "unknown-session",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := tc.setupRedis(ctx)
+			testPasskeyRepo := NewPasskeyRepository(nil, redisClient)
+
+			res, err := testPasskeyRepo.ConsumeSession(ctx, tc.inputSessionID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedOutput, res)
+
+			// a session can only be consumed once
+			_, err = testPasskeyRepo.ConsumeSession(ctx, tc.inputSessionID)
+			assert.Equal(t, dbutils.ErrRecordNotFoundType, err)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package passkey
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdatePasskeyUsage records a successful login with a passkey.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - passkeyID: The ID of the passkey used.
+//   - signCount: The signature counter returned by the authenticator.
+//   - usedAt: When the login happened.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the passkey does not exist, otherwise any update error.
+func (p *passkeyRepository) UpdatePasskeyUsage(ctx context.Context, passkeyID string, signCount uint32, usedAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdatePasskeyUsage")
+	defer s.End()
+
+	result := p.db.WithContext(ctx).
+		Model(&model.UserPasskey{}).
+		Where("id = ?", passkeyID).
+		Updates(map[string]any{
+			"sign_count":   signCount,
+			"last_used_at": usedAt,
+		})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
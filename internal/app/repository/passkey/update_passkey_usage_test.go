@@ -0,0 +1,71 @@
+package passkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestPasskey_UpdatePasskeyUsage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB        func(t *testing.T) *gorm.DB
+		inputPasskeyID string
+		inputSignCount uint32
+
+		expectedError error
+	}{
+		{
+			name: "Update passkey usage successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			},
+
+			inputPasskeyID: "2f8d6c1a-7b3e-4a5d-9c0f-3e1b2a4d5c6e",
+			inputSignCount: 5,
+		},
+		{
+			name: "Update passkey usage failed - passkey not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			},
+
+			inputPasskeyID: "00000000-0000-0000-0000-000000000000",
+			inputSignCount: 5,
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testPasskeyRepo := NewPasskeyRepository(db, nil)
+
+			err := testPasskeyRepo.UpdatePasskeyUsage(ctx, tc.inputPasskeyID, tc.inputSignCount, fixture.TestTime)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.UserPasskey{}
+			err = db.Where("id = ?", tc.inputPasskeyID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputSignCount, saved.SignCount)
+			assert.NotNil(t, saved.LastUsedAt)
+			assert.True(t, fixture.TestTime.Equal(*saved.LastUsedAt))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package session
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateSession stores a new login session.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - session: The session model containing the device and owning user.
+//
+// Returns:
+//   - *model.UserSession: The created session model.
+//   - error: An error if the creation fails, otherwise nil.
+func (r *sessionRepository) CreateSession(ctx context.Context, session *model.UserSession) (*model.UserSession, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateSession")
+	defer s.End()
+
+	err := r.db.WithContext(ctx).Create(session).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,64 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestSession_CreateSession(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB      func(t *testing.T) *gorm.DB
+		inputSession *model.UserSession
+
+		expectedError error
+	}{
+		{
+			name: "Create session successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputSession: &model.UserSession{
+				UserID:     "de305d54-75b4-431b-adb2-eb6b9e546000",
+				DeviceName: "Chrome on Linux",
+				IPAddress:  "203.0.113.50",
+				UserAgent:  "Mozilla/5.0 (X11; Linux x86_64) Chrome/120.0.0.0",
+				LastSeenAt: fixture.TestTime,
+				ExpiresAt:  fixture.SessionFarFuture,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testSessionRepo := NewSessionRepository(db)
+
+			res, err := testSessionRepo.CreateSession(ctx, tc.inputSession)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, res.ID)
+
+			saved := &model.UserSession{}
+			err = db.Where("id = ?", res.ID).First(saved).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.inputSession.DeviceName, saved.DeviceName)
+			assert.Equal(t, tc.inputSession.IPAddress, saved.IPAddress)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package session
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DeleteSession revokes a session of a user.
+// The user ID is part of the condition so a user can never revoke someone else's session.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the session.
+//   - sessionID: The ID of the session to revoke.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user owns no such session, otherwise any deletion error.
+func (r *sessionRepository) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteSession")
+	defer s.End()
+
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", sessionID, userID).
+		Delete(&model.UserSession{})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestSession_DeleteSession(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB        func(t *testing.T) *gorm.DB
+		inputUserID    string
+		inputSessionID string
+
+		expectedError error
+	}{
+		{
+			name: "Delete session successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputUserID:    "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputSessionID: "a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a52",
+		},
+		{
+			name: "Delete session failed - session owned by another user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputUserID:    "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputSessionID: "a1b2c3d4-0004-4e5f-8a9b-0c1d2e3f4a54",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testSessionRepo := NewSessionRepository(db)
+
+			err := testSessionRepo.DeleteSession(ctx, tc.inputUserID, tc.inputSessionID)
+			assert.Equal(t, tc.expectedError, err)
+
+			var count int64
+			db.Model(&model.UserSession{}).Where("id = ?", tc.inputSessionID).Count(&count)
+			if tc.expectedError == nil {
+				assert.Equal(t, int64(0), count)
+			} else {
+				assert.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package session
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetSessionByID retrieves a session by its ID.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - sessionID: The ID of the session.
+//
+// Returns:
+//   - *model.UserSession: The session model if found.
+//   - error: dbutils.ErrRecordNotFoundType if the session does not exist, otherwise any retrieval error.
+func (r *sessionRepository) GetSessionByID(ctx context.Context, sessionID string) (*model.UserSession, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetSessionByID")
+	defer s.End()
+
+	session := &model.UserSession{}
+	err := r.db.WithContext(ctx).
+		Where("id = ?", sessionID).
+		First(session).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return session, nil
+}
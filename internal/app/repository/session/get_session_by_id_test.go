@@ -0,0 +1,65 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestSession_GetSessionByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB        func(t *testing.T) *gorm.DB
+		inputSessionID string
+
+		expectedUserID string
+		expectedError  error
+	}{
+		{
+			name: "Get session successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputSessionID: "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51",
+
+			expectedUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+		},
+		{
+			name: "Get session failed - session not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputSessionID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testSessionRepo := NewSessionRepository(db)
+
+			res, err := testSessionRepo.GetSessionByID(ctx, tc.inputSessionID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedUserID, res.UserID)
+		})
+	}
+}
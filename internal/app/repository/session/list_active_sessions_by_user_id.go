@@ -0,0 +1,36 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListActiveSessionsByUserID retrieves the sessions of a user that have not expired, most recently seen first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the sessions.
+//   - now: The current time, sessions expiring before it are left out.
+//
+// Returns:
+//   - []*model.UserSession: The active sessions, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (r *sessionRepository) ListActiveSessionsByUserID(ctx context.Context, userID string, now time.Time) ([]*model.UserSession, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListActiveSessionsByUserID")
+	defer s.End()
+
+	sessions := []*model.UserSession{}
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC, id ASC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return sessions, nil
+}
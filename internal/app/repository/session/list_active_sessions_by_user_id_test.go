@@ -0,0 +1,85 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestSession_ListActiveSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB     func(t *testing.T) *gorm.DB
+		inputUserID string
+		inputNow    time.Time
+
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name: "List active sessions, most recently seen first",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputNow:    fixture.TestTime.Add(48 * time.Hour),
+
+			expectedIDs: []string{"a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51", "a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a52"},
+		},
+		{
+			name: "List sessions before the last one expired",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputNow:    fixture.TestTime,
+
+			expectedIDs: []string{
+				"a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51",
+				"a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a52",
+				"a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a53",
+			},
+		},
+		{
+			name: "List sessions of a user without any session",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputUserID: "00000000-0000-0000-0000-000000000000",
+			inputNow:    fixture.TestTime,
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testSessionRepo := NewSessionRepository(db)
+
+			res, err := testSessionRepo.ListActiveSessionsByUserID(ctx, tc.inputUserID, tc.inputNow)
+			assert.Equal(t, tc.expectedError, err)
+
+			ids := []string{}
+			for _, session := range res {
+				ids = append(ids, session.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package session provides repository operations for user login sessions using GORM.
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for login session repository operations.
+//
+//go:generate mockery --name=Repository --filename=session_repo.go --output=./mocks
+type Repository interface {
+	// CreateSession stores a new login session.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - session: The session model containing the device and owning user.
+	//
+	// Returns:
+	//   - *model.UserSession: The created session model.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateSession(ctx context.Context, session *model.UserSession) (*model.UserSession, error)
+
+	// ListActiveSessionsByUserID retrieves the sessions of a user that have not expired, most recently seen first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the sessions.
+	//   - now: The current time, sessions expiring before it are left out.
+	//
+	// Returns:
+	//   - []*model.UserSession: The active sessions, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListActiveSessionsByUserID(ctx context.Context, userID string, now time.Time) ([]*model.UserSession, error)
+
+	// GetSessionByID retrieves a session by its ID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - sessionID: The ID of the session.
+	//
+	// Returns:
+	//   - *model.UserSession: The session model if found.
+	//   - error: dbutils.ErrRecordNotFoundType if the session does not exist, otherwise any retrieval error.
+	GetSessionByID(ctx context.Context, sessionID string) (*model.UserSession, error)
+
+	// DeleteSession revokes a session of a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user owning the session.
+	//   - sessionID: The ID of the session to revoke.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user owns no such session, otherwise any deletion error.
+	DeleteSession(ctx context.Context, userID, sessionID string) error
+
+	// UpdateSessionLastSeen records when a session was last used.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - sessionID: The ID of the session used.
+	//   - seenAt: When the session was used.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the session does not exist, otherwise any update error.
+	UpdateSessionLastSeen(ctx context.Context, sessionID string, seenAt time.Time) error
+}
+
+// sessionRepository is the concrete implementation of the Repository interface.
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new instance of the login session repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - Repository: A new login session repository instance.
+func NewSessionRepository(db *gorm.DB) Repository {
+	return &sessionRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,38 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateSessionLastSeen records when a session was last used.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - sessionID: The ID of the session used.
+//   - seenAt: When the session was used.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the session does not exist, otherwise any update error.
+func (r *sessionRepository) UpdateSessionLastSeen(ctx context.Context, sessionID string, seenAt time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateSessionLastSeen")
+	defer s.End()
+
+	result := r.db.WithContext(ctx).
+		Model(&model.UserSession{}).
+		Where("id = ?", sessionID).
+		Update("last_seen_at", seenAt)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestSession_UpdateSessionLastSeen(t *testing.T) {
+	t.Parallel()
+
+	seenAt := fixture.TestTime.Add(3 * time.Hour)
+
+	testCases := []struct {
+		name string
+
+		setupDB        func(t *testing.T) *gorm.DB
+		inputSessionID string
+
+		expectedError error
+	}{
+		{
+			name: "Update session last seen successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputSessionID: "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51",
+		},
+		{
+			name: "Update session last seen failed - session not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			},
+
+			inputSessionID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testSessionRepo := NewSessionRepository(db)
+
+			err := testSessionRepo.UpdateSessionLastSeen(ctx, tc.inputSessionID, seenAt)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			saved := &model.UserSession{}
+			err = db.Where("id = ?", tc.inputSessionID).First(saved).Error
+			assert.Nil(t, err)
+			assert.True(t, seenAt.Equal(saved.LastSeenAt))
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+const (
+	// UserByIDCacheKeyFormat is the Redis key format used to cache a user by tenant and ID.
+	UserByIDCacheKeyFormat = "user:%s:id:%s"
+
+	// UserByUsernameCacheKeyFormat is the Redis key format used to cache a user by tenant and the canonical form of
+	// a username.
+	UserByUsernameCacheKeyFormat = "user:%s:username:%s"
+)
+
+// cacheEntry is the cached form of a user lookup.
+// The password hash and the time it was set are never cached, as the model does not serialize them; checks of a
+// password read them with GetUserCredentialsByUsername or GetUserCredentialsByID, which are not cached.
+// A nil User records that no user matched the lookup.
+type cacheEntry struct {
+	User *model.User `json:"user"`
+}
+
+// cachedUserRepository decorates a Repository with a Redis read-through cache of the lookups by ID and username.
+// The users it returns never hold their password hash, whether they were served from the cache or not.
+// Entries are cached per tenant, as lookups only see the users of the tenant of their context.
+// Lookups of the same key are collapsed into a single database query, and misses are cached for a shorter time.
+// The cache is best effort: Redis errors are logged and the lookups fall back to the database.
+type cachedUserRepository struct {
+	Repository
+
+	redisClient *redis.Client
+	ttl         time.Duration
+	negativeTTL time.Duration
+	group       singleflight.Group
+}
+
+// NewCachedUserRepository creates a user repository caching the lookups of the given repository in Redis.
+//
+// Parameters:
+//   - repo: The repository serving the cache misses and the writes.
+//   - redisClient: The Redis client holding the cache.
+//   - ttl: How long a found user stays cached.
+//   - negativeTTL: How long a lookup that found no user stays cached.
+//
+// Returns:
+//   - Repository: A new cached user repository instance.
+func NewCachedUserRepository(repo Repository, redisClient *redis.Client, ttl, negativeTTL time.Duration) Repository {
+	return &cachedUserRepository{
+		Repository:  repo,
+		redisClient: redisClient,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+// CreateUser creates a new user and drops the cached misses of its ID and username.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user model containing the details of the user to be created.
+//
+// Returns:
+//   - *model.User: The created user model.
+//   - error: An error if the creation fails, otherwise nil.
+func (c *cachedUserRepository) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	newUser, err := c.Repository.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	c.invalidate(ctx, idKey(ctx, newUser.ID), usernameKey(ctx, newUser.Username))
+	return newUser, nil
+}
+
+// CreateUserWith creates a new user along with the other writes of also, and drops the cached misses of its ID and
+// username once the transaction committed.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user model containing the details of the user to be created.
+//   - also: The writes to make in the transaction once the user is inserted.
+//
+// Returns:
+//   - *model.User: The created user model.
+//   - error: An error if the creation fails, otherwise nil.
+func (c *cachedUserRepository) CreateUserWith(ctx context.Context, user *model.User, also func(tx *gorm.DB) error) (*model.User, error) {
+	newUser, err := c.Repository.CreateUserWith(ctx, user, also)
+	if err != nil {
+		return nil, err
+	}
+
+	c.invalidate(ctx, idKey(ctx, newUser.ID), usernameKey(ctx, newUser.Username))
+	return newUser, nil
+}
+
+// GetUserByUsername retrieves a user by their username, from the cache when possible.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - username: The username of the user to be retrieved.
+//
+// Returns:
+//   - *model.User: The user model if found.
+//   - error: An error if the retrieval fails or the user is not found.
+func (c *cachedUserRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserByUsernameCached")
+	defer s.End()
+
+	return c.getUser(ctx, usernameKey(ctx, username), func(ctx context.Context) (*model.User, error) {
+		return c.Repository.GetUserByUsername(ctx, username)
+	})
+}
+
+// GetUserByID retrieves a user by their ID, from the cache when possible.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be retrieved.
+//
+// Returns:
+//   - *model.User: The user model if found.
+//   - error: An error if the retrieval fails or the user is not found.
+func (c *cachedUserRepository) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserByIDCached")
+	defer s.End()
+
+	return c.getUser(ctx, idKey(ctx, id), func(ctx context.Context) (*model.User, error) {
+		return c.Repository.GetUserByID(ctx, id)
+	})
+}
+
+// UpdateUserByID updates an existing user and drops its cached entries, under both the old and the new username.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be updated.
+//   - updatedUser: The user model containing the updated details.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (c *cachedUserRepository) UpdateUserByID(ctx context.Context, id string, updatedUser *model.User) error {
+	return c.updateUser(ctx, id, updatedUser.Username, func() error {
+		return c.Repository.UpdateUserByID(ctx, id, updatedUser)
+	})
+}
+
+// UpdateUserFieldsByID updates the given columns of an existing user and drops its cached entries.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be updated.
+//   - updatedUser: The user model containing the new values.
+//   - fields: The columns to update.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (c *cachedUserRepository) UpdateUserFieldsByID(ctx context.Context, id string, updatedUser *model.User, fields []string) error {
+	return c.updateUser(ctx, id, updatedUser.Username, func() error {
+		return c.Repository.UpdateUserFieldsByID(ctx, id, updatedUser, fields)
+	})
+}
+
+// UpdateUserFieldsByIDWith updates the given columns of an existing user along with the other writes of also, and
+// drops its cached entries.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be updated.
+//   - updatedUser: The user model containing the new values.
+//   - fields: The columns to update.
+//   - also: The writes to make in the transaction once the user is updated.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (c *cachedUserRepository) UpdateUserFieldsByIDWith(ctx context.Context, id string, updatedUser *model.User, fields []string, also func(tx *gorm.DB) error) error {
+	return c.updateUser(ctx, id, updatedUser.Username, func() error {
+		return c.Repository.UpdateUserFieldsByIDWith(ctx, id, updatedUser, fields, also)
+	})
+}
+
+// ChangeUsernameByID changes the username of an existing user and drops its cached entries, under both the old
+// and the new username.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user whose username changes.
+//   - updatedUser: The user model containing the new username.
+//   - heldUntil: When the old username can be claimed by another user.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (c *cachedUserRepository) ChangeUsernameByID(ctx context.Context, id string, updatedUser *model.User, heldUntil time.Time) error {
+	return c.updateUser(ctx, id, updatedUser.Username, func() error {
+		return c.Repository.ChangeUsernameByID(ctx, id, updatedUser, heldUntil)
+	})
+}
+
+// ChangePasswordByID replaces the password of an existing user and drops its cached entries.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user whose password changes.
+//   - hashedPassword: The hash of the new password.
+//   - keep: The number of previous passwords kept in the history of the user.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (c *cachedUserRepository) ChangePasswordByID(ctx context.Context, id, hashedPassword string, keep int) error {
+	return c.updateUser(ctx, id, "", func() error {
+		return c.Repository.ChangePasswordByID(ctx, id, hashedPassword, keep)
+	})
+}
+
+// UpgradePasswordHashByID replaces the hash of the password of a user and drops its cached entries.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user.
+//   - currentHash: The hash the password was verified against.
+//   - newHash: The new hash of the same password.
+//
+// Returns:
+//   - error: An error if the update fails, otherwise nil.
+func (c *cachedUserRepository) UpgradePasswordHashByID(ctx context.Context, id, currentHash, newHash string) error {
+	return c.updateUser(ctx, id, "", func() error {
+		return c.Repository.UpgradePasswordHashByID(ctx, id, currentHash, newHash)
+	})
+}
+
+// updateUser runs an update of the user and drops the entries cached under its ID, its username before the update
+// and newUsername, which may have been cached as a miss.
+func (c *cachedUserRepository) updateUser(ctx context.Context, id, newUsername string, update func() error) error {
+	user, err := c.Repository.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := update(); err != nil {
+		return err
+	}
+
+	keys := []string{idKey(ctx, id), usernameKey(ctx, user.Username)}
+	if newUsername != "" && normalize.Username(newUsername) != normalize.Username(user.Username) {
+		keys = append(keys, usernameKey(ctx, newUsername))
+	}
+	c.invalidate(ctx, keys...)
+
+	return nil
+}
+
+// idKey returns the cache key of a user ID in the tenant of the context.
+func idKey(ctx context.Context, id string) string {
+	return fmt.Sprintf(UserByIDCacheKeyFormat, tenant.FromContext(ctx), id)
+}
+
+// usernameKey returns the cache key of a username in the tenant of the context, built from its canonical form so
+// that every spelling of the username shares one entry.
+func usernameKey(ctx context.Context, username string) string {
+	return fmt.Sprintf(UserByUsernameCacheKeyFormat, tenant.FromContext(ctx), normalize.Username(username))
+}
+
+// getUser reads a user from the cache key, loading and caching it on a miss.
+// Concurrent misses of the same key share a single load.
+// Each caller receives its own copy of the user, without the password hash and the time it was set.
+func (c *cachedUserRepository) getUser(ctx context.Context, key string, load func(ctx context.Context) (*model.User, error)) (*model.User, error) {
+	entry, err := c.readEntry(ctx, key)
+	if err != nil {
+		log.Warn().Str("operation", "Repo_GetUserCached").Str("key", key).Err(err).Msg("failed to read the user cache")
+	}
+	if entry != nil {
+		if entry.User == nil {
+			return nil, dbutils.ErrRecordNotFoundType
+		}
+		return entry.User, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (any, error) {
+		user, err := load(ctx)
+		switch {
+		case err == nil:
+			c.writeEntry(ctx, key, &cacheEntry{User: user}, c.ttl)
+		case errors.Is(err, dbutils.ErrRecordNotFoundType):
+			c.writeEntry(ctx, key, &cacheEntry{}, c.negativeTTL)
+		}
+		return user, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user := *result.(*model.User)
+	user.Password = ""
+	user.PasswordChangedAt = nil
+	return &user, nil
+}
+
+// readEntry returns the cache entry stored under key, or nil when there is none.
+func (c *cachedUserRepository) readEntry(ctx context.Context, key string) (*cacheEntry, error) {
+	data, err := c.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// writeEntry stores a cache entry under key for exp.
+// A failure is only logged, the next lookup loads the user again.
+func (c *cachedUserRepository) writeEntry(ctx context.Context, key string, entry *cacheEntry, exp time.Duration) {
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = c.redisClient.Set(ctx, key, data, exp).Err()
+	}
+	if err != nil {
+		log.Warn().Str("operation", "Repo_GetUserCached").Str("key", key).Err(err).Msg("failed to write the user cache")
+	}
+}
+
+// invalidate drops the given cache keys after a write.
+// A failure is logged, the stale entries then live until their TTL.
+func (c *cachedUserRepository) invalidate(ctx context.Context, keys ...string) {
+	if err := c.redisClient.Del(ctx, keys...).Err(); err != nil {
+		log.Error().Strs("keys", keys).Err(err).Msg("failed to invalidate the user cache")
+	}
+}
@@ -0,0 +1,449 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+var cachedTestUser = &model.User{
+	Base: model.Base{
+		ID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+	},
+	Username:    "Alice",
+	DisplayName: "Alice",
+	Email:       "alice@example.com",
+	Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+
+	PasswordChangedAt: &cachedTestPasswordChangedAt,
+}
+
+var cachedTestPasswordChangedAt = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// withoutPassword returns a copy of a user as the cached lookups return it, without the password hash and the time
+// it was set.
+func withoutPassword(user *model.User) *model.User {
+	copied := *user
+	copied.Password = ""
+	copied.PasswordChangedAt = nil
+	return &copied
+}
+
+func TestUser_CachedGetUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(t *testing.T) *redis.Client
+		setupMock  func(repo *mocks.Repository)
+		getUser    func(ctx context.Context, repo Repository) (*model.User, error)
+		calls      int
+
+		expectedError  error
+		expectedOutput *model.User
+	}{
+		{
+			name: "Get user by ID loads it once",
+
+			setupRedis: redisPkg.InitMockRedis,
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Once()
+			},
+			getUser: func(ctx context.Context, repo Repository) (*model.User, error) {
+				return repo.GetUserByID(ctx, cachedTestUser.ID)
+			},
+			calls: 3,
+
+			expectedOutput: withoutPassword(cachedTestUser),
+		},
+		{
+			name: "Get user by username loads it once",
+
+			setupRedis: redisPkg.InitMockRedis,
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+			},
+			getUser: func(ctx context.Context, repo Repository) (*model.User, error) {
+				return repo.GetUserByUsername(ctx, "Alice")
+			},
+			calls: 3,
+
+			expectedOutput: withoutPassword(cachedTestUser),
+		},
+		{
+			name: "Unknown username is cached as a miss",
+
+			setupRedis: redisPkg.InitMockRedis,
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByUsername", mock.Anything, "Nobody").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+			},
+			getUser: func(ctx context.Context, repo Repository) (*model.User, error) {
+				return repo.GetUserByUsername(ctx, "Nobody")
+			},
+			calls: 3,
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Database error is not cached",
+
+			setupRedis: redisPkg.InitMockRedis,
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(nil, assert.AnError).Times(2)
+			},
+			getUser: func(ctx context.Context, repo Repository) (*model.User, error) {
+				return repo.GetUserByID(ctx, cachedTestUser.ID)
+			},
+			calls: 2,
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Redis unavailable falls back to the database",
+
+			setupRedis: func(t *testing.T) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Times(2)
+			},
+			getUser: func(ctx context.Context, repo Repository) (*model.User, error) {
+				return repo.GetUserByID(ctx, cachedTestUser.ID)
+			},
+			calls: 2,
+
+			expectedOutput: withoutPassword(cachedTestUser),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repoMock := mocks.NewRepository(t)
+			tc.setupMock(repoMock)
+			testUserRepo := NewCachedUserRepository(repoMock, tc.setupRedis(t), time.Minute, time.Minute)
+
+			for range tc.calls {
+				res, err := tc.getUser(ctx, testUserRepo)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Equal(t, tc.expectedOutput, res)
+			}
+		})
+	}
+}
+
+func TestUser_CachedGetUserNeverCachesPasswords(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	redisClient := redisPkg.InitMockRedis(t)
+	repoMock := mocks.NewRepository(t)
+	repoMock.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+	repoMock.On("GetUserCredentialsByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Twice()
+	repoMock.On("GetUserCredentialsByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Twice()
+	testUserRepo := NewCachedUserRepository(repoMock, redisClient, time.Minute, time.Minute)
+
+	res, err := testUserRepo.GetUserByUsername(ctx, "Alice")
+	assert.Nil(t, err)
+	assert.Equal(t, withoutPassword(cachedTestUser), res)
+
+	cached, err := redisClient.Get(ctx, usernameKey(ctx, "Alice")).Result()
+	assert.Nil(t, err)
+	assert.NotContains(t, cached, cachedTestUser.Password)
+
+	// Credential lookups always read the password hash from the database
+	for range 2 {
+		res, err = testUserRepo.GetUserCredentialsByUsername(ctx, "Alice")
+		assert.Nil(t, err)
+		assert.Equal(t, cachedTestUser, res)
+
+		res, err = testUserRepo.GetUserCredentialsByID(ctx, cachedTestUser.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, cachedTestUser, res)
+	}
+}
+
+func TestUser_CachedGetUserCollapsesConcurrentMisses(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	repoMock := mocks.NewRepository(t)
+	repoMock.On("GetUserByID", mock.Anything, cachedTestUser.ID).
+		WaitUntil(time.After(200*time.Millisecond)).
+		Return(cachedTestUser, nil).
+		Once()
+	testUserRepo := NewCachedUserRepository(repoMock, redisPkg.InitMockRedis(t), time.Minute, time.Minute)
+
+	var wg sync.WaitGroup
+	results := make([]*model.User, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := testUserRepo.GetUserByID(ctx, cachedTestUser.ID)
+			assert.Nil(t, err)
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range results {
+		assert.Equal(t, withoutPassword(cachedTestUser), res)
+	}
+	// Every caller gets its own copy
+	results[0].DisplayName = "Changed"
+	assert.Equal(t, "Alice", results[1].DisplayName)
+}
+
+func TestUser_CachedGetUserByUsernameSharesSpellings(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	repoMock := mocks.NewRepository(t)
+	repoMock.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+	testUserRepo := NewCachedUserRepository(repoMock, redisPkg.InitMockRedis(t), time.Minute, time.Minute)
+
+	for _, username := range []string{"Alice", "ALICE", " alice "} {
+		res, err := testUserRepo.GetUserByUsername(ctx, username)
+		assert.Nil(t, err)
+		assert.Equal(t, withoutPassword(cachedTestUser), res)
+	}
+}
+
+func TestUser_CachedGetUserByIDIsPerTenant(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	otherTenantCtx := tenant.NewContext(ctx, "brand_a")
+	repoMock := mocks.NewRepository(t)
+	repoMock.On("GetUserByID", ctx, cachedTestUser.ID).Return(cachedTestUser, nil).Once()
+	repoMock.On("GetUserByID", otherTenantCtx, cachedTestUser.ID).Return(nil, dbutils.ErrRecordNotFoundType).Once()
+	testUserRepo := NewCachedUserRepository(repoMock, redisPkg.InitMockRedis(t), time.Minute, time.Minute)
+
+	res, err := testUserRepo.GetUserByID(ctx, cachedTestUser.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, withoutPassword(cachedTestUser), res)
+
+	// The user cached for its tenant is not served to another one
+	res, err = testUserRepo.GetUserByID(otherTenantCtx, cachedTestUser.ID)
+	assert.Equal(t, dbutils.ErrRecordNotFoundType, err)
+	assert.Nil(t, res)
+}
+
+func TestUser_CachedWriteInvalidates(t *testing.T) {
+	t.Parallel()
+
+	updatedUser := &model.User{
+		Base:        cachedTestUser.Base,
+		Username:    "Alicia",
+		DisplayName: "Alicia",
+		Email:       cachedTestUser.Email,
+		Password:    cachedTestUser.Password,
+	}
+	heldUntil := time.Now().Add(time.Hour)
+	passwordChangedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	passwordChangedUser := &model.User{
+		Base:        cachedTestUser.Base,
+		Username:    cachedTestUser.Username,
+		DisplayName: cachedTestUser.DisplayName,
+		Email:       cachedTestUser.Email,
+		Password:    "$2a$10$newhash",
+
+		PasswordChangedAt: &passwordChangedAt,
+	}
+	upgradedHashUser := &model.User{
+		Base:        cachedTestUser.Base,
+		Username:    cachedTestUser.Username,
+		DisplayName: cachedTestUser.DisplayName,
+		Email:       cachedTestUser.Email,
+		Password:    "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5",
+
+		PasswordChangedAt: cachedTestUser.PasswordChangedAt,
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMock func(repo *mocks.Repository)
+		write     func(ctx context.Context, repo Repository) error
+
+		expectedError    error
+		expectedByID     *model.User
+		expectedByIDErr  error
+		expectedOldName  error
+		expectedByNewErr error
+	}{
+		{
+			name: "Update drops the entries of the old and new username",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Twice()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("UpdateUserByID", mock.Anything, cachedTestUser.ID, &model.User{Username: "Alicia", DisplayName: "Alicia"}).Return(nil).Once()
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(updatedUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(updatedUser, nil).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				return repo.UpdateUserByID(ctx, cachedTestUser.ID, &model.User{Username: "Alicia", DisplayName: "Alicia"})
+			},
+
+			expectedByID:    withoutPassword(updatedUser),
+			expectedOldName: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Username change drops the entries of the old and new username",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Twice()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("ChangeUsernameByID", mock.Anything, cachedTestUser.ID, &model.User{Username: "Alicia"}, heldUntil).Return(nil).Once()
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(updatedUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(updatedUser, nil).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				return repo.ChangeUsernameByID(ctx, cachedTestUser.ID, &model.User{Username: "Alicia"}, heldUntil)
+			},
+
+			expectedByID:    withoutPassword(updatedUser),
+			expectedOldName: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Password change drops the entries of the user",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Twice()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("ChangePasswordByID", mock.Anything, cachedTestUser.ID, "$2a$10$newhash", 4).Return(nil).Once()
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(passwordChangedUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(passwordChangedUser, nil).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				return repo.ChangePasswordByID(ctx, cachedTestUser.ID, "$2a$10$newhash", 4)
+			},
+
+			expectedByID:     withoutPassword(passwordChangedUser),
+			expectedByNewErr: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Password hash upgrade drops the entries of the user",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Twice()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("UpgradePasswordHashByID", mock.Anything, cachedTestUser.ID, cachedTestUser.Password, upgradedHashUser.Password).Return(nil).Once()
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(upgradedHashUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(upgradedHashUser, nil).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				return repo.UpgradePasswordHashByID(ctx, cachedTestUser.ID, cachedTestUser.Password, upgradedHashUser.Password)
+			},
+
+			expectedByID:     withoutPassword(upgradedHashUser),
+			expectedByNewErr: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Create drops the cached miss of the username",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("CreateUser", mock.Anything, &model.User{Username: "Alicia"}).Return(&model.User{Base: model.Base{ID: "new-user-id"}, Username: "Alicia"}, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(updatedUser, nil).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				_, err := repo.CreateUser(ctx, &model.User{Username: "Alicia"})
+				return err
+			},
+
+			expectedByID: withoutPassword(cachedTestUser),
+		},
+		{
+			name: "Create with other writes drops the cached miss of the username",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("CreateUserWith", mock.Anything, &model.User{Username: "Alicia"}, mock.Anything).Return(&model.User{Base: model.Base{ID: "new-user-id"}, Username: "Alicia"}, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(updatedUser, nil).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				_, err := repo.CreateUserWith(ctx, &model.User{Username: "Alicia"}, nil)
+				return err
+			},
+
+			expectedByID: withoutPassword(cachedTestUser),
+		},
+		{
+			name: "Failed update keeps the entries",
+
+			setupMock: func(repo *mocks.Repository) {
+				repo.On("GetUserByID", mock.Anything, cachedTestUser.ID).Return(cachedTestUser, nil).Twice()
+				repo.On("GetUserByUsername", mock.Anything, "Alice").Return(cachedTestUser, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "Alicia").Return(nil, dbutils.ErrRecordNotFoundType).Once()
+				repo.On("UpdateUserByID", mock.Anything, cachedTestUser.ID, &model.User{Username: "Alicia"}).Return(dbutils.ErrDuplicationType).Once()
+			},
+			write: func(ctx context.Context, repo Repository) error {
+				return repo.UpdateUserByID(ctx, cachedTestUser.ID, &model.User{Username: "Alicia"})
+			},
+
+			expectedError:    dbutils.ErrDuplicationType,
+			expectedByID:     withoutPassword(cachedTestUser),
+			expectedByNewErr: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repoMock := mocks.NewRepository(t)
+			tc.setupMock(repoMock)
+			testUserRepo := NewCachedUserRepository(repoMock, redisPkg.InitMockRedis(t), time.Minute, time.Minute)
+
+			// Warm the cache, including a miss on the new username
+			_, err := testUserRepo.GetUserByID(ctx, cachedTestUser.ID)
+			assert.Nil(t, err)
+			_, err = testUserRepo.GetUserByUsername(ctx, "Alice")
+			assert.Nil(t, err)
+			_, err = testUserRepo.GetUserByUsername(ctx, "Alicia")
+			assert.Equal(t, dbutils.ErrRecordNotFoundType, err)
+
+			err = tc.write(ctx, testUserRepo)
+			assert.Equal(t, tc.expectedError, err)
+
+			res, err := testUserRepo.GetUserByID(ctx, cachedTestUser.ID)
+			assert.Equal(t, tc.expectedByIDErr, err)
+			assert.Equal(t, tc.expectedByID, res)
+
+			_, err = testUserRepo.GetUserByUsername(ctx, "Alice")
+			assert.Equal(t, tc.expectedOldName, err)
+
+			_, err = testUserRepo.GetUserByUsername(ctx, "Alicia")
+			assert.Equal(t, tc.expectedByNewErr, err)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// ChangePasswordByID replaces the password of an existing user and records the previous one in the password
+// history. Only the keep most recent previous passwords of the user are kept. The version of the user is
+// increased and the user.updated event added as for UpdateUserByID.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user whose password changes.
+//   - hashedPassword: The hash of the new password.
+//   - keep: The number of previous passwords kept in the history of the user.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user does not exist, otherwise any update error.
+func (u *userRepository) ChangePasswordByID(ctx context.Context, id, hashedPassword string, keep int) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ChangePasswordByID")
+	defer s.End()
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current := &model.User{}
+		if err := tx.Where("id = ?", id).First(current).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		updatedUser := &model.User{Password: hashedPassword, PasswordChangedAt: &now}
+		if err := updateUserInTx(tx, id, updatedUser, []string{"password", "password_changed_at"}); err != nil {
+			return err
+		}
+
+		if current.HasPassword() {
+			if err := tx.Create(&model.PasswordHistory{UserID: id, PasswordHash: current.Password}).Error; err != nil {
+				return err
+			}
+		}
+
+		var kept []string
+		if keep > 0 {
+			err := tx.Model(&model.PasswordHistory{}).
+				Where("user_id = ?", id).
+				Order("created_at DESC").
+				Limit(keep).
+				Pluck("id", &kept).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		query := tx.Where("user_id = ?", id)
+		if len(kept) > 0 {
+			query = query.Where("id NOT IN ?", kept)
+		}
+		return query.Delete(&model.PasswordHistory{}).Error
+	})
+
+	return catchUpdateError(err)
+}
@@ -0,0 +1,125 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestUser_ChangePasswordByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB        func(t *testing.T) *gorm.DB
+		inputID        string
+		inputPassword  string
+		inputKeep      int
+		expectedError  error
+		expectedHashes []string
+	}{
+		{
+			name: "Change password and record the previous one",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputPassword: "$2a$10$newhash",
+			inputKeep:     4,
+
+			expectedHashes: []string{"$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu"},
+		},
+		{
+			name: "Only the most recent previous passwords are kept",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				for i, hash := range []string{"$2a$10$oldest", "$2a$10$older"} {
+					createdAt := fixture.TestTime.Add(time.Duration(i) * time.Hour)
+					assert.Nil(t, db.Create(&model.PasswordHistory{
+						Base:         model.Base{CreatedAt: createdAt, UpdatedAt: createdAt},
+						UserID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+						PasswordHash: hash,
+					}).Error)
+				}
+				return db
+			},
+
+			inputID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputPassword: "$2a$10$newhash",
+			inputKeep:     2,
+
+			expectedHashes: []string{"$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu", "$2a$10$older"},
+		},
+		{
+			name: "No history is kept when keep is zero",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputPassword: "$2a$10$newhash",
+			inputKeep:     0,
+
+			expectedHashes: []string{},
+		},
+		{
+			name: "Change password failed - user not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID:       "non-existent-id",
+			inputPassword: "$2a$10$newhash",
+			inputKeep:     4,
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testUserRepo := NewUserRepository(db)
+
+			err := testUserRepo.ChangePasswordByID(ctx, tc.inputID, tc.inputPassword, tc.inputKeep)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.Equal(t, tc.inputPassword, user.Password)
+			assert.True(t, user.PasswordChangedAt.After(fixture.TestTime))
+			assert.Equal(t, 2, user.Version)
+
+			history := []*model.PasswordHistory{}
+			assert.Nil(t, db.Where("user_id = ?", tc.inputID).Order("created_at DESC").Find(&history).Error)
+			hashes := []string{}
+			for _, entry := range history {
+				hashes = append(hashes, entry.PasswordHash)
+			}
+			assert.Equal(t, tc.expectedHashes, hashes)
+
+			// The event never carries the password
+			event := &model.OutboxEvent{}
+			assert.Nil(t, db.Where("aggregate_id = ?", tc.inputID).First(event).Error)
+			assert.Equal(t, "user.updated", event.EventType)
+			assert.NotContains(t, event.Payload, tc.inputPassword)
+		})
+	}
+}
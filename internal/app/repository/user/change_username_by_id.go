@@ -0,0 +1,53 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// ChangeUsernameByID changes the username of an existing user and records the change in the username history.
+// The old username is held for the user until heldUntil. The version check and the user.updated event are the
+// same as for UpdateUserByID.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user whose username changes.
+//   - updatedUser: The user model containing the new username and, optionally, the expected version.
+//   - heldUntil: When the old username can be claimed by another user.
+//
+// Returns:
+//   - error: dbutils.ErrDuplicationType if the username is taken or held for another user, ErrVersionConflict if
+//     the user was updated since the expected version, dbutils.ErrRecordNotFoundType if the user does not exist,
+//     otherwise any update error.
+func (u *userRepository) ChangeUsernameByID(ctx context.Context, id string, updatedUser *model.User, heldUntil time.Time) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ChangeUsernameByID")
+	defer s.End()
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := EnsureUsernameNotHeld(tx, updatedUser.Username, id); err != nil {
+			return err
+		}
+
+		current := &model.User{}
+		if err := tx.Where("id = ?", id).First(current).Error; err != nil {
+			return err
+		}
+
+		if err := updateUserInTx(tx, id, updatedUser, []string{"username"}); err != nil {
+			return err
+		}
+
+		return tx.Create(&model.UsernameChange{
+			UserID:      id,
+			OldUsername: current.Username,
+			NewUsername: updatedUser.Username,
+			HeldUntil:   heldUntil,
+		}).Error
+	})
+
+	return catchUpdateError(err)
+}
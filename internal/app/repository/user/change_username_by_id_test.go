@@ -0,0 +1,176 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestUser_ChangeUsernameByID(t *testing.T) {
+	t.Parallel()
+
+	heldUntil := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+
+		setupDB       func(t *testing.T) *gorm.DB
+		inputID       string
+		inputUserData *model.User
+
+		expectedError    error
+		expectedUsername string
+		expectedVersion  int
+		expectedOld      string
+	}{
+		{
+			name: "Change username successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				Username: "Alicia",
+				Version:  1,
+			},
+
+			expectedUsername: "Alicia",
+			expectedVersion:  2,
+			expectedOld:      "Alice",
+		},
+		{
+			name: "Change username back to a username held for the same user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputID: "123e4567-e89b-12d3-a456-eb6b9e546001",
+			inputUserData: &model.User{
+				Username: "Bobby",
+			},
+
+			expectedUsername: "Bobby",
+			expectedVersion:  2,
+			expectedOld:      "Bob",
+		},
+		{
+			name: "Change username to a username whose hold is over",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				Username: "Chuck",
+			},
+
+			expectedUsername: "Chuck",
+			expectedVersion:  2,
+			expectedOld:      "Alice",
+		},
+		{
+			name: "Change username failed - username held for another user",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				Username: "Bobby",
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Change username failed - username taken",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				Username: "Bob",
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Change username failed - expected version is outdated",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", "de305d54-75b4-431b-adb2-eb6b9e546000").Update("version", 2).Error)
+				return db
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				Username: "Alicia",
+				Version:  1,
+			},
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Change username failed - user not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputID: "non-existent-id",
+			inputUserData: &model.User{
+				Username: "Nobody",
+			},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testUserRepo := NewUserRepository(db)
+
+			err := testUserRepo.ChangeUsernameByID(ctx, tc.inputID, tc.inputUserData, heldUntil)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				// Nothing is recorded for a rolled back change
+				var count int64
+				db.Model(&model.UsernameChange{}).Where("user_id = ?", tc.inputID).Where("created_at > ?", fixture.TestTime).Count(&count)
+				assert.Equal(t, int64(0), count)
+				return
+			}
+
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.Equal(t, tc.expectedUsername, user.Username)
+			assert.Equal(t, tc.expectedVersion, user.Version)
+			assert.Equal(t, tc.expectedVersion, tc.inputUserData.Version)
+
+			change := &model.UsernameChange{}
+			assert.Nil(t, db.Where("user_id = ?", tc.inputID).Order("created_at DESC").First(change).Error)
+			assert.Equal(t, tc.expectedOld, change.OldUsername)
+			assert.Equal(t, tc.expectedUsername, change.NewUsername)
+			assert.True(t, change.HeldUntil.Equal(heldUntil))
+
+			checkEvent := &model.OutboxEvent{}
+			assert.Nil(t, db.Where("aggregate_id = ?", tc.inputID).First(checkEvent).Error)
+			assert.Equal(t, "user.updated", checkEvent.EventType)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CountLegacyPasswordHashes counts the users with a password whose hash was not made with the preferred algorithm.
+// Users without a password are not counted.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - preferredPrefix: The prefix of the hashes of the preferred algorithm, e.g. "$argon2id$".
+//
+// Returns:
+//   - int64: The number of users whose hash lacks the prefix.
+//   - error: An error if the query fails, otherwise nil.
+func (u *userRepository) CountLegacyPasswordHashes(ctx context.Context, preferredPrefix string) (int64, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CountLegacyPasswordHashes")
+	defer s.End()
+
+	var count int64
+	err := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("password <> '' AND SUBSTR(password, 1, ?) <> ?", len(preferredPrefix), preferredPrefix).
+		Count(&count).Error
+	if err != nil {
+		return 0, dbutils.CatchDBError(err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_CountLegacyPasswordHashes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		upgradedIDs          []string
+		otherTenantIDs       []string
+		inputAllTenants      bool
+		inputPreferredPrefix string
+
+		expectedOutput int64
+	}{
+		{
+			name: "Count every bcrypt hash",
+
+			inputPreferredPrefix: "$argon2id$",
+
+			expectedOutput: 3,
+		},
+		{
+			name: "Skip the upgraded hashes",
+
+			upgradedIDs:          []string{"de305d54-75b4-431b-adb2-eb6b9e546000", "123e4567-e89b-12d3-a456-eb6b9e546001"},
+			inputPreferredPrefix: "$argon2id$",
+
+			expectedOutput: 1,
+		},
+		{
+			name: "Skip the hashes of other tenants",
+
+			otherTenantIDs:       []string{"de305d54-75b4-431b-adb2-eb6b9e546000"},
+			inputPreferredPrefix: "$argon2id$",
+
+			expectedOutput: 2,
+		},
+		{
+			name: "Count the hashes of every tenant",
+
+			otherTenantIDs:       []string{"de305d54-75b4-431b-adb2-eb6b9e546000"},
+			inputAllTenants:      true,
+			inputPreferredPrefix: "$argon2id$",
+
+			expectedOutput: 3,
+		},
+		{
+			name: "Count nothing when bcrypt is preferred",
+
+			inputPreferredPrefix: "$2",
+
+			expectedOutput: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			// A user without a password is never counted
+			assert.Nil(t, db.Model(&model.User{}).Where("id = ?", "987e6543-e21b-12d3-a456-eb6b9e546002").UpdateColumn("password", "").Error)
+			for _, id := range tc.upgradedIDs {
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", id).UpdateColumn("password", "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5").Error)
+			}
+			for _, id := range tc.otherTenantIDs {
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", id).UpdateColumn("tenant_id", "brand_a").Error)
+			}
+			if tc.inputAllTenants {
+				ctx = tenant.WithAllTenants(ctx)
+			}
+
+			res, err := testUserRepo.CountLegacyPasswordHashes(ctx, tc.inputPreferredPrefix)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
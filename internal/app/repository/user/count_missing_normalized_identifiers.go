@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CountMissingNormalizedIdentifiers counts the users lacking the canonical form of their username or email address,
+// and the username changes lacking the canonical form of their old username.
+// The unique indexes only hold for canonical forms that are set, so uniqueness is only enforced once it is 0.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - int64: The number of users and username changes without their canonical forms.
+//   - error: An error if a query fails, otherwise nil.
+func (u *userRepository) CountMissingNormalizedIdentifiers(ctx context.Context) (int64, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CountMissingNormalizedIdentifiers")
+	defer s.End()
+
+	var users, changes int64
+	err := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("username_normalized IS NULL OR email_normalized IS NULL").
+		Count(&users).Error
+	if err != nil {
+		return 0, dbutils.CatchDBError(err)
+	}
+
+	err = u.db.WithContext(ctx).Model(&model.UsernameChange{}).
+		Where("old_username_normalized IS NULL").
+		Count(&changes).Error
+	if err != nil {
+		return 0, dbutils.CatchDBError(err)
+	}
+
+	return users + changes, nil
+}
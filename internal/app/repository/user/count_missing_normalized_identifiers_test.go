@@ -0,0 +1,74 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_CountMissingNormalizedIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		clearUsernameIDs  []string
+		clearEmailIDs     []string
+		clearOldUsernames []string
+		otherTenantIDs    []string
+
+		expectedOutput int64
+	}{
+		{
+			name: "Nothing is missing",
+		},
+		{
+			name: "Count the users and username changes without canonical forms",
+
+			clearUsernameIDs:  []string{"de305d54-75b4-431b-adb2-eb6b9e546000"},
+			clearEmailIDs:     []string{"de305d54-75b4-431b-adb2-eb6b9e546000", "123e4567-e89b-12d3-a456-eb6b9e546001"},
+			clearOldUsernames: []string{"Bobby"},
+
+			expectedOutput: 3,
+		},
+		{
+			name: "Count the users of every tenant",
+
+			clearEmailIDs:  []string{"de305d54-75b4-431b-adb2-eb6b9e546000"},
+			otherTenantIDs: []string{"de305d54-75b4-431b-adb2-eb6b9e546000"},
+
+			expectedOutput: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := tenant.WithAllTenants(t.Context())
+			db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			for _, id := range tc.clearUsernameIDs {
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", id).UpdateColumn("username_normalized", nil).Error)
+			}
+			for _, id := range tc.clearEmailIDs {
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", id).UpdateColumn("email_normalized", nil).Error)
+			}
+			if len(tc.clearOldUsernames) > 0 {
+				assert.Nil(t, db.Model(&model.UsernameChange{}).Where("old_username IN ?", tc.clearOldUsernames).
+					UpdateColumn("old_username_normalized", nil).Error)
+			}
+			for _, id := range tc.otherTenantIDs {
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", id).UpdateColumn("tenant_id", "brand_a").Error)
+			}
+
+			count, err := testUserRepo.CountMissingNormalizedIdentifiers(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedOutput, count)
+		})
+	}
+}
@@ -6,10 +6,14 @@ import (
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/outbox"
+	"gorm.io/gorm"
 )
 
 // CreateUser creates a new user in the database.
 // It takes a context and a user model as input and returns the created user or an error.
+// The user.created event is added to the outbox in the same transaction.
+// A username still held after another user changed it counts as taken.
 //
 // Parameters:
 //   - ctx: The context for managing request-scoped values and cancellation.
@@ -17,12 +21,52 @@ import (
 //
 // Returns:
 //   - *model.User: The created user model.
-//   - error: An error if the creation fails, otherwise nil.
+//   - error: dbutils.ErrDuplicationType if the username or email is taken, otherwise an error if the creation fails.
 func (u *userRepository) CreateUser(ctx context.Context, newUser *model.User) (*model.User, error) {
 	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateUser")
 	defer s.End()
 
-	err := u.db.WithContext(ctx).Create(newUser).Error
+	return u.createUser(ctx, newUser, nil)
+}
+
+// CreateUserWith creates a new user as CreateUser does, and runs also in the same transaction once the user is
+// inserted, for the writes other repositories make along with the user. If also fails, nothing is written.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - newUser: The user model containing the details of the user to be created.
+//   - also: The writes to make in the transaction, given the transaction; newUser holds its ID by then.
+//
+// Returns:
+//   - *model.User: The created user model.
+//   - error: dbutils.ErrDuplicationType if the username or email is taken, otherwise the error of also or an error
+//     if the creation fails.
+func (u *userRepository) CreateUserWith(ctx context.Context, newUser *model.User, also func(tx *gorm.DB) error) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateUserWith")
+	defer s.End()
+
+	return u.createUser(ctx, newUser, also)
+}
+
+// createUser inserts a user and adds the user.created event, running also, when set, in between.
+func (u *userRepository) createUser(ctx context.Context, newUser *model.User, also func(tx *gorm.DB) error) (*model.User, error) {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := EnsureUsernameNotHeld(tx, newUser.Username, ""); err != nil {
+			return err
+		}
+
+		if err := tx.Create(newUser).Error; err != nil {
+			return err
+		}
+
+		if also != nil {
+			if err := also(tx); err != nil {
+				return err
+			}
+		}
+
+		return outbox.AddUserEvent(tx, outbox.EventUserCreated, newUser)
+	})
 	if err != nil {
 		return nil, dbutils.CatchDBError(err)
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 	"gorm.io/gorm"
 )
@@ -17,8 +18,9 @@ func TestUser_CreateUser(t *testing.T) {
 	testCases := []struct {
 		name string
 
-		setupDB   func(t *testing.T) *gorm.DB
-		inputUser *model.User
+		setupDB       func(t *testing.T) *gorm.DB
+		inputTenantID string
+		inputUser     *model.User
 
 		expectedError  error
 		expectedOutput *model.User
@@ -67,6 +69,57 @@ func TestUser_CreateUser(t *testing.T) {
 				assert.Equal(t, user.Username, checkUser.Username)
 				assert.Equal(t, user.Email, checkUser.Email)
 				assert.Equal(t, user.DisplayName, checkUser.DisplayName)
+				assert.Equal(t, "new user", *checkUser.UsernameNormalized)
+				assert.Equal(t, "newuser@example.com", *checkUser.EmailNormalized)
+
+				// Verify the event is added to the outbox
+				checkEvent := &model.OutboxEvent{}
+				err = db.Where("aggregate_id = ?", user.ID).First(checkEvent).Error
+				assert.Nil(t, err)
+				assert.Equal(t, "user.created", checkEvent.EventType)
+				assert.Contains(t, checkEvent.Payload, `"email":"newuser@example.com"`)
+			},
+		},
+		{
+			name: "Create user with the username and email of a user of another tenant",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputTenantID: "brand_a",
+			inputUser: &model.User{
+				Base: model.Base{
+					ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+				DisplayName: "Brand A User",
+				Username:    "Bobby", // previous username of Bob, only held in the default tenant
+				Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+				Email:       "alice@example.com",
+			},
+
+			verifyFunc: func(db *gorm.DB, user *model.User) {
+				assert.Equal(t, "brand_a", user.TenantID)
+
+				// The user is only visible in its tenant
+				checkUser := &model.User{}
+				err := db.WithContext(tenant.NewContext(t.Context(), "brand_a")).Where("email = ?", "alice@example.com").First(checkUser).Error
+				assert.Nil(t, err)
+				assert.Equal(t, user.ID, checkUser.ID)
+
+				checkUser = &model.User{}
+				err = db.Where("email = ?", "alice@example.com").First(checkUser).Error
+				assert.Nil(t, err)
+				assert.Equal(t, "de305d54-75b4-431b-adb2-eb6b9e546000", checkUser.ID)
+
+				// The event belongs to the tenant of the user and tells it
+				checkEvent := &model.OutboxEvent{}
+				err = db.Where("aggregate_id = ?", user.ID).First(checkEvent).Error
+				assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+				err = db.WithContext(tenant.NewContext(t.Context(), "brand_a")).Where("aggregate_id = ?", user.ID).First(checkEvent).Error
+				assert.Nil(t, err)
+				assert.Equal(t, "brand_a", checkEvent.TenantID)
+				assert.Contains(t, checkEvent.Payload, `"tenant_id":"brand_a"`)
 			},
 		},
 		{
@@ -87,6 +140,42 @@ func TestUser_CreateUser(t *testing.T) {
 
 			expectedError: dbutils.ErrDuplicationType,
 		},
+		{
+			name: "Create user failed - duplicate username in another case",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{
+					ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+				DisplayName: "Another User",
+				Username:    "ＡＬＩＣＥ", // fullwidth spelling of Alice
+				Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+				Email:       "alice1@example.com",
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Create user failed - username held after a change",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{
+					ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+				DisplayName: "Another User",
+				Username:    "Bobby", // previous username of Bob, still held
+				Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+				Email:       "bobby@example.com",
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
 		{
 			name: "Create user failed - duplicate email",
 
@@ -103,6 +192,24 @@ func TestUser_CreateUser(t *testing.T) {
 				Email:       "alice@example.com",
 			},
 
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Create user failed - duplicate email in another case",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{
+					ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+				DisplayName: "Another User",
+				Username:    "AnotherUser",
+				Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+				Email:       " Alice@Example.com ",
+			},
+
 			expectedError: dbutils.ErrDuplicationType,
 		},
 	}
@@ -112,12 +219,20 @@ func TestUser_CreateUser(t *testing.T) {
 			t.Parallel()
 
 			ctx := t.Context()
+			if tc.inputTenantID != "" {
+				ctx = tenant.NewContext(ctx, tc.inputTenantID)
+			}
 			db := tc.setupDB(t)
 			testUserRepo := NewUserRepository(db)
 
 			res, err := testUserRepo.CreateUser(ctx, tc.inputUser)
 			if err != nil {
 				assert.Equal(t, tc.expectedError, err)
+
+				// No event is added for a rolled back user
+				var count int64
+				db.Model(&model.OutboxEvent{}).Count(&count)
+				assert.Equal(t, int64(0), count)
 				return
 			}
 			tc.verifyFunc(db, res)
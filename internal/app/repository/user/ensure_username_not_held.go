@@ -0,0 +1,37 @@
+package user
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+	"gorm.io/gorm"
+)
+
+// EnsureUsernameNotHeld checks that a username is not held for another user after a username change.
+// Usernames are compared by their canonical form.
+// It must be called with the transaction claiming the username, whether by creating a user or changing a username.
+//
+// Parameters:
+//   - tx: The GORM transaction claiming the username.
+//   - username: The username being claimed.
+//   - userID: The ID of the user claiming it, or "" for a new user; a user may claim back its own held usernames.
+//
+// Returns:
+//   - error: dbutils.ErrDuplicationType if the username is held for another user, otherwise any query error.
+func EnsureUsernameNotHeld(tx *gorm.DB, username, userID string) error {
+	var count int64
+	err := whereNormalized(tx.Model(&model.UsernameChange{}), "old_username", username, normalize.Username(username)).
+		Where("held_until > ? AND user_id <> ?", time.Now(), userID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return dbutils.ErrDuplicationType
+	}
+
+	return nil
+}
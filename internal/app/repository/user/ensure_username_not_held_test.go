@@ -0,0 +1,63 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestEnsureUsernameNotHeld(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+		inputUserID   string
+
+		expectedError error
+	}{
+		{
+			name: "Username never used",
+
+			inputUsername: "Nobody",
+
+			expectedError: nil,
+		},
+		{
+			name: "Username held for another user",
+
+			inputUsername: "Bobby",
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Username held for the claiming user",
+
+			inputUsername: "Bobby",
+			inputUserID:   "123e4567-e89b-12d3-a456-eb6b9e546001",
+
+			expectedError: nil,
+		},
+		{
+			name: "Username whose hold is over",
+
+			inputUsername: "Chuck",
+
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+
+			err := EnsureUsernameNotHeld(db, tc.inputUsername, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
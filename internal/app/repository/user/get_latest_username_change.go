@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetLatestUsernameChange retrieves the most recent username change of a user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user whose username changes are searched.
+//
+// Returns:
+//   - *model.UsernameChange: The latest username change of the user.
+//   - error: dbutils.ErrRecordNotFoundType if the user never changed its username, otherwise any query error.
+func (u *userRepository) GetLatestUsernameChange(ctx context.Context, userID string) (*model.UsernameChange, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetLatestUsernameChange")
+	defer s.End()
+
+	change := &model.UsernameChange{}
+	err := u.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").First(change).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return change, nil
+}
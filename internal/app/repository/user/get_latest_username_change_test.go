@@ -0,0 +1,58 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_GetLatestUsernameChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUserID string
+
+		expectedError       error
+		expectedID          string
+		expectedOldUsername string
+	}{
+		{
+			name: "Get the latest of several changes",
+
+			inputUserID: "987e6543-e21b-12d3-a456-eb6b9e546002",
+
+			expectedID:          "a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a03",
+			expectedOldUsername: "Chuck",
+		},
+		{
+			name: "Get latest username change failed - never changed",
+
+			inputUserID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			res, err := testUserRepo.GetLatestUsernameChange(ctx, tc.inputUserID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedID, res.ID)
+			assert.Equal(t, tc.expectedOldUsername, res.OldUsername)
+			assert.Equal(t, "Charlie", res.NewUsername)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// GetUserByEmail retrieves a user from the database by their email address.
+// It takes a context and an email as input and returns the user or an error.
+// Addresses are compared by their canonical form, so "John.Doe@Gmail.com" finds "johndoe@gmail.com".
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - email: The email address of the user to be retrieved.
+//
+// Returns:
+//   - *model.User: The user model if found.
+//   - error: An error if the retrieval fails or the user is not found.
+func (u *userRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserByEmail")
+	defer s.End()
+
+	return u.getUserByNormalizedField(ctx, "email", email, normalize.Email(email))
+}
@@ -0,0 +1,110 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestUser_GetUserByEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB    func(t *testing.T) *gorm.DB
+		inputEmail string
+
+		expectedError  error
+		expectedOutput *model.User
+	}{
+		{
+			name: "Get user by email successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputEmail: "bob@example.com",
+
+			expectedOutput: &model.User{
+				Base: model.Base{
+					ID:        "123e4567-e89b-12d3-a456-eb6b9e546001",
+					CreatedAt: fixture.TestTime,
+					UpdatedAt: fixture.TestTime,
+				},
+				TenantID:    tenant.Default,
+				Username:    "Bob",
+				DisplayName: "Bob",
+				Email:       "bob@example.com",
+				Version:     1,
+				Role:        model.RoleMember,
+
+				UsernameNormalized: stringPtr("bob"),
+				EmailNormalized:    stringPtr("bob@example.com"),
+				PasswordChangedAt:  &fixture.TestTime,
+			},
+		},
+		{
+			name: "Get user by email in another case",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputEmail: "Bob@Example.com",
+
+			expectedOutput: &model.User{
+				Base: model.Base{
+					ID:        "123e4567-e89b-12d3-a456-eb6b9e546001",
+					CreatedAt: fixture.TestTime,
+					UpdatedAt: fixture.TestTime,
+				},
+				TenantID:    tenant.Default,
+				Username:    "Bob",
+				DisplayName: "Bob",
+				Email:       "bob@example.com",
+				Version:     1,
+				Role:        model.RoleMember,
+
+				UsernameNormalized: stringPtr("bob"),
+				EmailNormalized:    stringPtr("bob@example.com"),
+				PasswordChangedAt:  &fixture.TestTime,
+			},
+		},
+		{
+			name: "Get user by email failed - user not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputEmail: "nonexistent@example.com",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testUserRepo := NewUserRepository(db)
+
+			res, err := testUserRepo.GetUserByEmail(ctx, tc.inputEmail)
+			if err != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+			res.Password = "" // omit password field for comparison
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
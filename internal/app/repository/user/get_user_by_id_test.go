@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 	"gorm.io/gorm"
 )
@@ -37,9 +38,17 @@ func TestUser_GetUserByID(t *testing.T) {
 					CreatedAt: fixture.TestTime,
 					UpdatedAt: fixture.TestTime,
 				},
+				TenantID:    tenant.Default,
 				Username:    "Alice",
 				DisplayName: "Alice",
 				Email:       "alice@example.com",
+				Version:     1,
+				Role:        model.RoleMember,
+
+				UsernameNormalized: stringPtr("alice"),
+				EmailNormalized:    stringPtr("alice@example.com"),
+				PasswordChangedAt:  &fixture.TestTime,
+				EmailVerifiedAt:    &fixture.TestTime,
 			},
 		},
 		{
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// getUserByNormalizedField retrieves a user by the canonical form of an identifier field.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - field: The identifier field to search by, "username" or "email".
+//   - value: The value of the field as entered.
+//   - normalized: The canonical form of the value.
+//
+// Returns:
+//   - *model.User: The user model if found.
+//   - error: An error if the retrieval fails or the user is not found.
+func (u *userRepository) getUserByNormalizedField(ctx context.Context, field, value, normalized string) (*model.User, error) {
+	user := &model.User{}
+	err := whereNormalized(u.db.WithContext(ctx), field, value, normalized).First(user).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+	return user, nil
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+// stringPtr returns a pointer to s, for the optional fields of the expected users.
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestUser_GetUserByNormalizedField(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputField      string
+		inputValue      string
+		inputNormalized string
+
+		expectedError error
+		expectedID    string
+	}{
+		{
+			name: "Match the canonical form",
+
+			inputField:      "username",
+			inputValue:      "ALICE",
+			inputNormalized: "alice",
+
+			expectedID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+		},
+		{
+			name: "Match the exact value of a user without canonical form",
+
+			inputField:      "email",
+			inputValue:      "Bob@Example.com",
+			inputNormalized: "bob@example.com",
+
+			expectedID: "123e4567-e89b-12d3-a456-eb6b9e546001",
+		},
+		{
+			name: "No match on another case of a user without canonical form",
+
+			inputField:      "email",
+			inputValue:      "bob@example.com",
+			inputNormalized: "bob@example.com",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			// Bob predates the canonical forms
+			assert.Nil(t, db.Model(&model.User{}).Where("id = ?", "123e4567-e89b-12d3-a456-eb6b9e546001").
+				Updates(map[string]any{"email": "Bob@Example.com", "username_normalized": nil, "email_normalized": nil}).Error)
+			testUserRepo := &userRepository{db: db}
+
+			res, err := testUserRepo.getUserByNormalizedField(ctx, tc.inputField, tc.inputValue, tc.inputNormalized)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedID, res.ID)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// GetUserByPreviousUsername retrieves the user that most recently changed away from a username.
+// Usernames are compared by their canonical form.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - username: The previous username of the user to be retrieved.
+//
+// Returns:
+//   - *model.User: The user model if found.
+//   - error: dbutils.ErrRecordNotFoundType if no user ever had the username, otherwise any query error.
+func (u *userRepository) GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserByPreviousUsername")
+	defer s.End()
+
+	user := &model.User{}
+	query := u.db.WithContext(ctx).Joins("JOIN username_history ON username_history.user_id = users.id")
+	err := whereNormalized(query, "username_history.old_username", username, normalize.Username(username)).
+		Order("username_history.created_at DESC").
+		First(user).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,65 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_GetUserByPreviousUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+
+		expectedError    error
+		expectedID       string
+		expectedUsername string
+	}{
+		{
+			name: "Get user by a held previous username",
+
+			inputUsername: "Bobby",
+
+			expectedID:       "123e4567-e89b-12d3-a456-eb6b9e546001",
+			expectedUsername: "Bob",
+		},
+		{
+			name: "Get user by a previous username whose hold is over",
+
+			inputUsername: "Charles",
+
+			expectedID:       "987e6543-e21b-12d3-a456-eb6b9e546002",
+			expectedUsername: "Charlie",
+		},
+		{
+			name: "Get user by previous username failed - never used",
+
+			inputUsername: "Alice",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			res, err := testUserRepo.GetUserByPreviousUsername(ctx, tc.inputUsername)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedID, res.ID)
+			assert.Equal(t, tc.expectedUsername, res.Username)
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
 )
 
 // GetUserByUsername retrieves a user from the database by their username.
 // It takes a context and a username as input and returns the user or an error.
+// Usernames are compared by their canonical form, so "ALICE" finds "Alice".
 //
 // Parameters:
 //   - ctx: The context for managing request-scoped values and cancellation.
@@ -21,5 +23,5 @@ func (u *userRepository) GetUserByUsername(ctx context.Context, username string)
 	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserByUsername")
 	defer s.End()
 
-	return u.GetUserByField(ctx, "username", username)
+	return u.getUserByNormalizedField(ctx, "username", username, normalize.Username(username))
 }
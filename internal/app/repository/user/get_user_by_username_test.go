@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 	"gorm.io/gorm"
 )
@@ -17,6 +18,7 @@ func TestUser_GetUserByUsername(t *testing.T) {
 		name string
 
 		setupDB       func(t *testing.T) *gorm.DB
+		inputTenantID string
 		inputUsername string
 
 		expectedError  error
@@ -37,9 +39,43 @@ func TestUser_GetUserByUsername(t *testing.T) {
 					CreatedAt: fixture.TestTime,
 					UpdatedAt: fixture.TestTime,
 				},
+				TenantID:    tenant.Default,
 				Username:    "Bob",
 				DisplayName: "Bob",
 				Email:       "bob@example.com",
+				Version:     1,
+				Role:        model.RoleMember,
+
+				UsernameNormalized: stringPtr("bob"),
+				EmailNormalized:    stringPtr("bob@example.com"),
+				PasswordChangedAt:  &fixture.TestTime,
+			},
+		},
+		{
+			name: "Get user by username in another case",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputUsername: " BOB ",
+
+			expectedOutput: &model.User{
+				Base: model.Base{
+					ID:        "123e4567-e89b-12d3-a456-eb6b9e546001",
+					CreatedAt: fixture.TestTime,
+					UpdatedAt: fixture.TestTime,
+				},
+				TenantID:    tenant.Default,
+				Username:    "Bob",
+				DisplayName: "Bob",
+				Email:       "bob@example.com",
+				Version:     1,
+				Role:        model.RoleMember,
+
+				UsernameNormalized: stringPtr("bob"),
+				EmailNormalized:    stringPtr("bob@example.com"),
+				PasswordChangedAt:  &fixture.TestTime,
 			},
 		},
 		{
@@ -51,6 +87,18 @@ func TestUser_GetUserByUsername(t *testing.T) {
 
 			inputUsername: "NonExistentUser",
 
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Get user by username failed - user of another tenant",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputTenantID: "brand_a",
+			inputUsername: "Bob",
+
 			expectedError: dbutils.ErrRecordNotFoundType,
 		},
 	}
@@ -60,6 +108,9 @@ func TestUser_GetUserByUsername(t *testing.T) {
 			t.Parallel()
 
 			ctx := t.Context()
+			if tc.inputTenantID != "" {
+				ctx = tenant.NewContext(ctx, tc.inputTenantID)
+			}
 			db := tc.setupDB(t)
 			testUserRepo := NewUserRepository(db)
 
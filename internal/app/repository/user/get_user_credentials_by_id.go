@@ -0,0 +1,25 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetUserCredentialsByID retrieves a user from the database by their ID, with the hash of their password.
+// It is never served from the cache, which does not hold password hashes.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be retrieved.
+//
+// Returns:
+//   - *model.User: The user model, with the password hash, if found.
+//   - error: An error if the retrieval fails or the user is not found.
+func (u *userRepository) GetUserCredentialsByID(ctx context.Context, id string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserCredentialsByID")
+	defer s.End()
+
+	return u.GetUserByField(ctx, "id", id)
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// GetUserCredentialsByUsername retrieves a user from the database by their username, with the hash of their
+// password. It is never served from the cache, which does not hold password hashes.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - username: The username of the user to be retrieved.
+//
+// Returns:
+//   - *model.User: The user model, with the password hash, if found.
+//   - error: An error if the retrieval fails or the user is not found.
+func (u *userRepository) GetUserCredentialsByUsername(ctx context.Context, username string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetUserCredentialsByUsername")
+	defer s.End()
+
+	return u.getUserByNormalizedField(ctx, "username", username, normalize.Username(username))
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_GetUserCredentialsByUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+
+		expectedError    error
+		expectedID       string
+		expectedPassword string
+	}{
+		{
+			name: "Get the credentials of a user by username successfully",
+
+			inputUsername: "ALICE",
+
+			expectedID:       "de305d54-75b4-431b-adb2-eb6b9e546000",
+			expectedPassword: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+		},
+		{
+			name: "Get the credentials of a user by username failed - user not found",
+
+			inputUsername: "nobody",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			res, err := testUserRepo.GetUserCredentialsByUsername(ctx, tc.inputUsername)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.expectedID, res.ID)
+			assert.Equal(t, tc.expectedPassword, res.Password)
+			assert.Equal(t, &fixture.TestTime, res.PasswordChangedAt)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListPasswordHistory retrieves the most recent previous passwords of a user, newest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user whose previous passwords are listed.
+//   - limit: The maximum number of previous passwords to return.
+//
+// Returns:
+//   - []*model.PasswordHistory: The previous passwords of the user; empty if it never changed its password.
+//   - error: An error if the query fails, otherwise nil.
+func (u *userRepository) ListPasswordHistory(ctx context.Context, userID string, limit int) ([]*model.PasswordHistory, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListPasswordHistory")
+	defer s.End()
+
+	history := []*model.PasswordHistory{}
+	err := u.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&history).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestUser_ListPasswordHistory(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB    func(t *testing.T) *gorm.DB
+		inputID    string
+		inputLimit int
+
+		expectedHashes []string
+	}{
+		{
+			name: "List the most recent previous passwords first",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				for i, hash := range []string{"$2a$10$first", "$2a$10$second", "$2a$10$third"} {
+					createdAt := fixture.TestTime.Add(time.Duration(i) * time.Hour)
+					assert.Nil(t, db.Create(&model.PasswordHistory{
+						Base:         model.Base{CreatedAt: createdAt, UpdatedAt: createdAt},
+						UserID:       "de305d54-75b4-431b-adb2-eb6b9e546000",
+						PasswordHash: hash,
+					}).Error)
+				}
+				return db
+			},
+			inputID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputLimit: 2,
+
+			expectedHashes: []string{"$2a$10$third", "$2a$10$second"},
+		},
+		{
+			name: "User that never changed its password",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			inputID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputLimit: 2,
+
+			expectedHashes: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testUserRepo := NewUserRepository(tc.setupDB(t))
+
+			history, err := testUserRepo.ListPasswordHistory(ctx, tc.inputID, tc.inputLimit)
+			assert.Nil(t, err)
+			hashes := []string{}
+			for _, entry := range history {
+				assert.Equal(t, tc.inputID, entry.UserID)
+				hashes = append(hashes, entry.PasswordHash)
+			}
+			assert.Equal(t, tc.expectedHashes, hashes)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListUsers retrieves a page of users ordered by ID, for jobs walking through every user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - afterID: The ID of the last user of the previous page, empty for the first page.
+//   - limit: The maximum number of users to return.
+//
+// Returns:
+//   - []*model.User: The users whose ID sorts after afterID, empty past the last page.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (u *userRepository) ListUsers(ctx context.Context, afterID string, limit int) ([]*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListUsers")
+	defer s.End()
+
+	users := []*model.User{}
+	err := u.db.WithContext(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputAfterID string
+		inputLimit   int
+
+		expectedIDs []string
+	}{
+		{
+			name: "List the first page",
+
+			inputLimit: 2,
+
+			expectedIDs: []string{"123e4567-e89b-12d3-a456-eb6b9e546001", "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+		},
+		{
+			name: "List the next page",
+
+			inputAfterID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputLimit:   2,
+
+			expectedIDs: []string{"987e6543-e21b-12d3-a456-eb6b9e546002", "de305d54-75b4-431b-adb2-eb6b9e546000"},
+		},
+		{
+			name: "List past the last page",
+
+			inputAfterID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputLimit:   2,
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			res, err := testUserRepo.ListUsers(ctx, tc.inputAfterID, tc.inputLimit)
+			assert.Nil(t, err)
+
+			ids := []string{}
+			for _, user := range res {
+				ids = append(ids, user.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
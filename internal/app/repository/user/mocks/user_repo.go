@@ -4,9 +4,11 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/vukieuhaihoa/user-service/internal/app/model"
+	gorm "gorm.io/gorm"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -14,6 +16,98 @@ type Repository struct {
 	mock.Mock
 }
 
+// ChangePasswordByID provides a mock function with given fields: ctx, id, hashedPassword, keep
+func (_m *Repository) ChangePasswordByID(ctx context.Context, id string, hashedPassword string, keep int) error {
+	ret := _m.Called(ctx, id, hashedPassword, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePasswordByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(ctx, id, hashedPassword, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeUsernameByID provides a mock function with given fields: ctx, id, updatedUser, heldUntil
+func (_m *Repository) ChangeUsernameByID(ctx context.Context, id string, updatedUser *model.User, heldUntil time.Time) error {
+	ret := _m.Called(ctx, id, updatedUser, heldUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUsernameByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.User, time.Time) error); ok {
+		r0 = rf(ctx, id, updatedUser, heldUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountLegacyPasswordHashes provides a mock function with given fields: ctx, preferredPrefix
+func (_m *Repository) CountLegacyPasswordHashes(ctx context.Context, preferredPrefix string) (int64, error) {
+	ret := _m.Called(ctx, preferredPrefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountLegacyPasswordHashes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, preferredPrefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, preferredPrefix)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, preferredPrefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountMissingNormalizedIdentifiers provides a mock function with given fields: ctx
+func (_m *Repository) CountMissingNormalizedIdentifiers(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountMissingNormalizedIdentifiers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: ctx, _a1
 func (_m *Repository) CreateUser(ctx context.Context, _a1 *model.User) (*model.User, error) {
 	ret := _m.Called(ctx, _a1)
@@ -44,6 +138,96 @@ func (_m *Repository) CreateUser(ctx context.Context, _a1 *model.User) (*model.U
 	return r0, r1
 }
 
+// CreateUserWith provides a mock function with given fields: ctx, _a1, also
+func (_m *Repository) CreateUserWith(ctx context.Context, _a1 *model.User, also func(*gorm.DB) error) (*model.User, error) {
+	ret := _m.Called(ctx, _a1, also)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserWith")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, func(*gorm.DB) error) (*model.User, error)); ok {
+		return rf(ctx, _a1, also)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, func(*gorm.DB) error) *model.User); ok {
+		r0 = rf(ctx, _a1, also)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, func(*gorm.DB) error) error); ok {
+		r1 = rf(ctx, _a1, also)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestUsernameChange provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetLatestUsernameChange(ctx context.Context, userID string) (*model.UsernameChange, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestUsernameChange")
+	}
+
+	var r0 *model.UsernameChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UsernameChange, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UsernameChange); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UsernameChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *Repository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByID provides a mock function with given fields: ctx, id
 func (_m *Repository) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	ret := _m.Called(ctx, id)
@@ -74,6 +258,36 @@ func (_m *Repository) GetUserByID(ctx context.Context, id string) (*model.User,
 	return r0, r1
 }
 
+// GetUserByPreviousUsername provides a mock function with given fields: ctx, username
+func (_m *Repository) GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByPreviousUsername")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByUsername provides a mock function with given fields: ctx, username
 func (_m *Repository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	ret := _m.Called(ctx, username)
@@ -104,6 +318,172 @@ func (_m *Repository) GetUserByUsername(ctx context.Context, username string) (*
 	return r0, r1
 }
 
+// GetUserCredentialsByID provides a mock function with given fields: ctx, id
+func (_m *Repository) GetUserCredentialsByID(ctx context.Context, id string) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserCredentialsByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserCredentialsByUsername provides a mock function with given fields: ctx, username
+func (_m *Repository) GetUserCredentialsByUsername(ctx context.Context, username string) (*model.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserCredentialsByUsername")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPasswordHistory provides a mock function with given fields: ctx, userID, limit
+func (_m *Repository) ListPasswordHistory(ctx context.Context, userID string, limit int) ([]*model.PasswordHistory, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPasswordHistory")
+	}
+
+	var r0 []*model.PasswordHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*model.PasswordHistory, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*model.PasswordHistory); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PasswordHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, afterID, limit
+func (_m *Repository) ListUsers(ctx context.Context, afterID string, limit int) ([]*model.User, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*model.User, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*model.User); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NormalizeUsernameHistory provides a mock function with given fields: ctx, batchSize
+func (_m *Repository) NormalizeUsernameHistory(ctx context.Context, batchSize int) (int, error) {
+	ret := _m.Called(ctx, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NormalizeUsernameHistory")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, batchSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, batchSize)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetNormalizedIdentifiers provides a mock function with given fields: ctx, id, usernameNormalized, emailNormalized
+func (_m *Repository) SetNormalizedIdentifiers(ctx context.Context, id string, usernameNormalized *string, emailNormalized *string) error {
+	ret := _m.Called(ctx, id, usernameNormalized, emailNormalized)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNormalizedIdentifiers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string, *string) error); ok {
+		r0 = rf(ctx, id, usernameNormalized, emailNormalized)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserByID provides a mock function with given fields: ctx, id, updatedUser
 func (_m *Repository) UpdateUserByID(ctx context.Context, id string, updatedUser *model.User) error {
 	ret := _m.Called(ctx, id, updatedUser)
@@ -122,6 +502,78 @@ func (_m *Repository) UpdateUserByID(ctx context.Context, id string, updatedUser
 	return r0
 }
 
+// UpdateUserFieldsByID provides a mock function with given fields: ctx, id, updatedUser, fields
+func (_m *Repository) UpdateUserFieldsByID(ctx context.Context, id string, updatedUser *model.User, fields []string) error {
+	ret := _m.Called(ctx, id, updatedUser, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserFieldsByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.User, []string) error); ok {
+		r0 = rf(ctx, id, updatedUser, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserFieldsByIDWith provides a mock function with given fields: ctx, id, updatedUser, fields, also
+func (_m *Repository) UpdateUserFieldsByIDWith(ctx context.Context, id string, updatedUser *model.User, fields []string, also func(*gorm.DB) error) error {
+	ret := _m.Called(ctx, id, updatedUser, fields, also)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserFieldsByIDWith")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.User, []string, func(*gorm.DB) error) error); ok {
+		r0 = rf(ctx, id, updatedUser, fields, also)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpgradePasswordHashByID provides a mock function with given fields: ctx, id, currentHash, newHash
+func (_m *Repository) UpgradePasswordHashByID(ctx context.Context, id string, currentHash string, newHash string) error {
+	ret := _m.Called(ctx, id, currentHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradePasswordHashByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, currentHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmailByID provides a mock function with given fields: ctx, id, email
+func (_m *Repository) VerifyEmailByID(ctx context.Context, id string, email string) error {
+	ret := _m.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmailByID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// NormalizeUsernameHistory sets the canonical form of the old username of the username changes recorded without one.
+// The changes are processed batchSize at a time.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - batchSize: How many changes are loaded at once.
+//
+// Returns:
+//   - int: The number of changes updated.
+//   - error: An error if a retrieval or an update fails, otherwise nil.
+func (u *userRepository) NormalizeUsernameHistory(ctx context.Context, batchSize int) (int, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_NormalizeUsernameHistory")
+	defer s.End()
+
+	db := u.db.WithContext(ctx)
+	updated := 0
+	for {
+		changes := []*model.UsernameChange{}
+		if err := db.Where("old_username_normalized IS NULL").Order("id").Limit(batchSize).Find(&changes).Error; err != nil {
+			return updated, dbutils.CatchDBError(err)
+		}
+		if len(changes) == 0 {
+			return updated, nil
+		}
+
+		for _, change := range changes {
+			err := db.Model(&model.UsernameChange{}).Where("id = ?", change.ID).
+				UpdateColumn("old_username_normalized", normalize.Username(change.OldUsername)).Error
+			if err != nil {
+				return updated, dbutils.CatchDBError(err)
+			}
+			updated++
+		}
+	}
+}
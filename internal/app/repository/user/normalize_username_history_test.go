@@ -0,0 +1,70 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_NormalizeUsernameHistory(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		clearOldUsernames []string
+		inputBatchSize    int
+
+		expectedUpdated int
+	}{
+		{
+			name: "Normalize the changes over several batches",
+
+			clearOldUsernames: []string{"Bobby", "Charles", "Chuck"},
+			inputBatchSize:    2,
+
+			expectedUpdated: 3,
+		},
+		{
+			name: "Normalize only the changes without canonical form",
+
+			clearOldUsernames: []string{"Chuck"},
+			inputBatchSize:    10,
+
+			expectedUpdated: 1,
+		},
+		{
+			name: "Nothing to normalize",
+
+			inputBatchSize: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+			if len(tc.clearOldUsernames) > 0 {
+				assert.Nil(t, db.Model(&model.UsernameChange{}).Where("old_username IN ?", tc.clearOldUsernames).
+					Update("old_username_normalized", nil).Error)
+			}
+			testUserRepo := NewUserRepository(db)
+
+			updated, err := testUserRepo.NormalizeUsernameHistory(ctx, tc.inputBatchSize)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedUpdated, updated)
+
+			changes := []*model.UsernameChange{}
+			assert.Nil(t, db.Find(&changes).Error)
+			for _, change := range changes {
+				assert.NotNil(t, change.OldUsernameNormalized)
+				assert.Equal(t, strings.ToLower(change.OldUsername), *change.OldUsernameNormalized)
+			}
+		})
+	}
+}
@@ -6,11 +6,16 @@ package user
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
 	"gorm.io/gorm"
 )
 
+// ErrVersionConflict is returned when a user is updated from a version that is no longer current.
+var ErrVersionConflict = errors.New("user version conflict")
+
 // Repository represents the interface for user repository operations.
 //
 //go:generate mockery --name=Repository --filename=user_repo.go --output=./mocks
@@ -26,6 +31,18 @@ type Repository interface {
 	//   - error: An error if the creation fails, otherwise nil.
 	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
 
+	// CreateUserWith creates a new user in the database, and makes other writes in the same transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user model containing the details of the user to be created.
+	//   - also: The writes to make in the transaction once the user is inserted; if it fails, nothing is written.
+	//
+	// Returns:
+	//   - *model.User: The created user model.
+	//   - error: dbutils.ErrDuplicationType if the username or email is taken, otherwise the error of also or an
+	//     error if the creation fails.
+	CreateUserWith(ctx context.Context, user *model.User, also func(tx *gorm.DB) error) (*model.User, error)
+
 	// GetUserByUsername retrieves a user from the database by their username.
 	// Returns the user or an error if the operation fails.
 	// Parameters:
@@ -37,6 +54,17 @@ type Repository interface {
 	//   - error: An error if the retrieval fails or the user is not found.
 	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
 
+	// GetUserByEmail retrieves a user from the database by their email address.
+	// Returns the user or an error if the operation fails.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - email: The email address of the user to be retrieved.
+	//
+	// Returns:
+	//   - *model.User: The user model if found.
+	//   - error: An error if the retrieval fails or the user is not found.
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+
 	// GetUserByID retrieves a user from the database by their ID.
 	// Returns the user or an error if the operation fails.
 	// Parameters:
@@ -48,16 +76,201 @@ type Repository interface {
 	//   - error: An error if the retrieval fails or the user is not found.
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
 
+	// GetUserCredentialsByUsername retrieves a user from the database by their username, with the hash of their
+	// password. The lookups that may be served from a cache leave the hash out, so checks of a password must use it.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - username: The username of the user to be retrieved.
+	//
+	// Returns:
+	//   - *model.User: The user model, with the password hash, if found.
+	//   - error: An error if the retrieval fails or the user is not found.
+	GetUserCredentialsByUsername(ctx context.Context, username string) (*model.User, error)
+
+	// GetUserCredentialsByID retrieves a user from the database by their ID, with the hash of their password.
+	// The lookups that may be served from a cache leave the hash out, so checks of a password must use it.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user to be retrieved.
+	//
+	// Returns:
+	//   - *model.User: The user model, with the password hash, if found.
+	//   - error: An error if the retrieval fails or the user is not found.
+	GetUserCredentialsByID(ctx context.Context, id string) (*model.User, error)
+
 	// UpdateUserByID updates an existing user in the database by their ID.
 	// Returns an error if the operation fails.
+	// A non-zero updatedUser.Version makes the update conditional on the user still being at that version.
+	// On success, updatedUser.Version holds the new version of the user.
 	// Parameters:
 	//   - ctx: The context for managing request-scoped values and cancellation.
 	//   - id: The ID of the user to be updated.
-	//   - updatedUser: The user model containing the updated details.
+	//   - updatedUser: The user model containing the updated details and, optionally, the expected version.
 	//
 	// Returns:
-	//   - error: An error if the update fails, otherwise nil.
+	//   - error: ErrVersionConflict if the user is no longer at the expected version, otherwise an error if the update fails.
 	UpdateUserByID(ctx context.Context, id string, updatedUser *model.User) error
+
+	// UpdateUserFieldsByID updates the given columns of an existing user by their ID, including to zero values.
+	// The version of the user is checked and increased as by UpdateUserByID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user to be updated.
+	//   - updatedUser: The user model containing the new values and, optionally, the expected version.
+	//   - fields: The columns to update, e.g. "display_name" or "email".
+	//
+	// Returns:
+	//   - error: ErrVersionConflict if the user is no longer at the expected version, otherwise an error if the update fails.
+	UpdateUserFieldsByID(ctx context.Context, id string, updatedUser *model.User, fields []string) error
+
+	// UpdateUserFieldsByIDWith updates the given columns of an existing user, and makes other writes in the same
+	// transaction.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user to be updated.
+	//   - updatedUser: The user model containing the new values and, optionally, the expected version.
+	//   - fields: The columns to update.
+	//   - also: The writes to make in the transaction once the user is updated; if it fails, nothing is written.
+	//
+	// Returns:
+	//   - error: ErrVersionConflict if the user was updated since the expected version,
+	//     dbutils.ErrRecordNotFoundType if the user does not exist, otherwise the error of also or any update error.
+	UpdateUserFieldsByIDWith(ctx context.Context, id string, updatedUser *model.User, fields []string, also func(tx *gorm.DB) error) error
+
+	// ChangeUsernameByID changes the username of an existing user by their ID and records the change in the username history.
+	// The old username stays held for the user until heldUntil. The version is checked and increased as by UpdateUserByID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user whose username changes.
+	//   - updatedUser: The user model containing the new username and, optionally, the expected version.
+	//   - heldUntil: When the old username can be claimed by another user.
+	//
+	// Returns:
+	//   - error: dbutils.ErrDuplicationType if the username is taken or held for another user, ErrVersionConflict if
+	//     the user is no longer at the expected version, otherwise an error if the update fails.
+	ChangeUsernameByID(ctx context.Context, id string, updatedUser *model.User, heldUntil time.Time) error
+
+	// ChangePasswordByID replaces the password of an existing user by their ID and records the previous one in the
+	// password history, of which only the keep most recent entries are kept. The version is increased as by
+	// UpdateUserByID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user whose password changes.
+	//   - hashedPassword: The hash of the new password.
+	//   - keep: The number of previous passwords kept in the history of the user.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user does not exist, otherwise an error if the update fails.
+	ChangePasswordByID(ctx context.Context, id, hashedPassword string, keep int) error
+
+	// ListPasswordHistory retrieves the most recent previous passwords of a user, newest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user whose previous passwords are listed.
+	//   - limit: The maximum number of previous passwords to return.
+	//
+	// Returns:
+	//   - []*model.PasswordHistory: The previous passwords of the user.
+	//   - error: An error if the query fails, otherwise nil.
+	ListPasswordHistory(ctx context.Context, userID string, limit int) ([]*model.PasswordHistory, error)
+
+	// GetLatestUsernameChange retrieves the most recent username change of a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user whose username changes are searched.
+	//
+	// Returns:
+	//   - *model.UsernameChange: The latest username change of the user.
+	//   - error: dbutils.ErrRecordNotFoundType if the user never changed its username, otherwise any query error.
+	GetLatestUsernameChange(ctx context.Context, userID string) (*model.UsernameChange, error)
+
+	// GetUserByPreviousUsername retrieves the user that most recently changed away from a username.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - username: The previous username of the user to be retrieved.
+	//
+	// Returns:
+	//   - *model.User: The user model if found.
+	//   - error: dbutils.ErrRecordNotFoundType if no user ever had the username, otherwise any query error.
+	GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error)
+
+	// ListUsers retrieves a page of users ordered by ID, for jobs walking through every user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - afterID: The ID of the last user of the previous page, empty for the first page.
+	//   - limit: The maximum number of users to return.
+	//
+	// Returns:
+	//   - []*model.User: The users whose ID sorts after afterID, empty past the last page.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListUsers(ctx context.Context, afterID string, limit int) ([]*model.User, error)
+
+	// SetNormalizedIdentifiers writes the canonical forms of the username and email address of a user, without
+	// changing its version or adding an event.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user.
+	//   - usernameNormalized: The canonical form of the username, nil to clear it.
+	//   - emailNormalized: The canonical form of the email address, nil to clear it.
+	//
+	// Returns:
+	//   - error: dbutils.ErrDuplicationType if another user holds one of the canonical forms, otherwise an error if
+	//     the update fails.
+	SetNormalizedIdentifiers(ctx context.Context, id string, usernameNormalized, emailNormalized *string) error
+
+	// UpgradePasswordHashByID replaces the hash of the password of a user by a new hash of the same password, as long as
+	// the stored hash is still currentHash, without changing its version or adding an event.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user.
+	//   - currentHash: The hash the password was verified against.
+	//   - newHash: The new hash of the same password.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user does not exist or its hash changed since, otherwise an
+	//     error if the update fails.
+	UpgradePasswordHashByID(ctx context.Context, id, currentHash, newHash string) error
+
+	// VerifyEmailByID records that a user proved they own their email address, without changing its version or
+	// adding an event.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user.
+	//   - email: The email address the user proved they own; nothing is recorded if the user has changed it since.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user does not exist or has another email address, otherwise
+	//     an error if the update fails.
+	VerifyEmailByID(ctx context.Context, id, email string) error
+
+	// CountLegacyPasswordHashes counts the users with a password whose hash does not start with preferredPrefix.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - preferredPrefix: The prefix of the hashes of the preferred algorithm.
+	//
+	// Returns:
+	//   - int64: The number of users still on a legacy hash.
+	//   - error: An error if the query fails, otherwise nil.
+	CountLegacyPasswordHashes(ctx context.Context, preferredPrefix string) (int64, error)
+
+	// CountMissingNormalizedIdentifiers counts the users and username changes still lacking a canonical form.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - int64: The number of users and username changes without their canonical forms.
+	//   - error: An error if a query fails, otherwise nil.
+	CountMissingNormalizedIdentifiers(ctx context.Context) (int64, error)
+
+	// NormalizeUsernameHistory sets the canonical form of the old username of the username changes recorded without one.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - batchSize: How many changes are loaded at once.
+	//
+	// Returns:
+	//   - int: The number of changes updated.
+	//   - error: An error if a retrieval or an update fails, otherwise nil.
+	NormalizeUsernameHistory(ctx context.Context, batchSize int) (int, error)
 }
 
 // user is the concrete implementation of the Repository interface.
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// SetNormalizedIdentifiers writes the canonical forms of the username and email address of a user.
+// It is a maintenance write: the version, the update time and the outbox are left untouched.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user.
+//   - usernameNormalized: The canonical form of the username, nil to clear it.
+//   - emailNormalized: The canonical form of the email address, nil to clear it.
+//
+// Returns:
+//   - error: dbutils.ErrDuplicationType if another user holds one of the canonical forms, dbutils.ErrRecordNotFoundType
+//     if the user does not exist, otherwise any update error.
+func (u *userRepository) SetNormalizedIdentifiers(ctx context.Context, id string, usernameNormalized, emailNormalized *string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_SetNormalizedIdentifiers")
+	defer s.End()
+
+	result := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"username_normalized": usernameNormalized,
+		"email_normalized":    emailNormalized,
+	})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_SetNormalizedIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID                 string
+		inputUsernameNormalized *string
+		inputEmailNormalized    *string
+
+		expectedError error
+	}{
+		{
+			name: "Set the canonical forms",
+
+			inputID:                 "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUsernameNormalized: stringPtr("alice"),
+			inputEmailNormalized:    stringPtr("alice@example.com"),
+		},
+		{
+			name: "Clear the canonical forms",
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+		},
+		{
+			name: "Set failed - canonical form held by another user",
+
+			inputID:                 "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUsernameNormalized: stringPtr("bob"),
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Set failed - user not found",
+
+			inputID: "non-existent-id",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			err := testUserRepo.SetNormalizedIdentifiers(ctx, tc.inputID, tc.inputUsernameNormalized, tc.inputEmailNormalized)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			// Neither the version nor the update time change
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.Equal(t, tc.inputUsernameNormalized, user.UsernameNormalized)
+			assert.Equal(t, tc.inputEmailNormalized, user.EmailNormalized)
+			assert.Equal(t, 1, user.Version)
+			assert.Equal(t, fixture.TestTime, user.UpdatedAt)
+		})
+	}
+}
@@ -2,35 +2,116 @@ package user
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/outbox"
+	"gorm.io/gorm"
 )
 
 // UpdateUserByID updates an existing user in the database by their ID.
 // It takes a context, an ID, and a user model with updated details as input.
 // Returns an error if the operation fails.
+// A non-zero updatedUser.Version is the version the caller last read: the update only applies if the user is
+// still at it. Every update increases the version, and the new one is written back to updatedUser.Version.
+// The user.updated event, carrying the user after the update, is added to the outbox in the same transaction.
 //
 // Parameters:
 //   - ctx: The context for managing request-scoped values and cancellation.
 //   - id: The ID of the user to be updated.
-//   - updatedUser: The user model containing the updated details.
+//   - updatedUser: The user model containing the updated details and, optionally, the expected version.
 //
 // Returns:
-//   - error: An error if the update fails, otherwise nil.
+//   - error: ErrVersionConflict if the user was updated since the expected version, dbutils.ErrRecordNotFoundType
+//     if the user does not exist, otherwise any update error.
 func (u *userRepository) UpdateUserByID(ctx context.Context, id string, updatedUser *model.User) error {
 	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateUserByID")
 	defer s.End()
 
-	result := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Updates(updatedUser)
+	return u.updateUser(ctx, id, updatedUser, nil, nil)
+}
+
+// updateUser applies an update to a user, checking and increasing its version, and adds the user.updated event.
+// The canonical forms of the username and email address are updated with them.
+// Only the given columns are written when fields is non-nil, zero values included; otherwise the non-zero fields of
+// updatedUser are. also, when set, runs in the same transaction once the user is updated.
+func (u *userRepository) updateUser(ctx context.Context, id string, updatedUser *model.User, fields []string, also func(tx *gorm.DB) error) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateUserInTx(tx, id, updatedUser, fields); err != nil {
+			return err
+		}
+
+		if also != nil {
+			return also(tx)
+		}
+		return nil
+	})
+
+	return catchUpdateError(err)
+}
+
+// updateUserInTx is updateUser within the transaction tx, for writes that change more than the user.
+func updateUserInTx(tx *gorm.DB, id string, updatedUser *model.User, fields []string) error {
+	query := tx.Model(&model.User{}).Where("id = ?", id)
+	if updatedUser.Version != 0 {
+		query = query.Where("version = ?", updatedUser.Version)
+	}
+
+	result := query.UpdateColumn("version", gorm.Expr("version + 1"))
 	if result.Error != nil {
-		return dbutils.CatchDBError(result.Error)
+		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
+		if updatedUser.Version != 0 {
+			var count int64
+			if err := tx.Model(&model.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return ErrVersionConflict
+			}
+		}
 		return dbutils.ErrRecordNotFoundType
 	}
 
-	return nil
+	updatedUser.Normalize()
+	query = tx.Model(&model.User{}).Where("id = ?", id)
+	if fields != nil {
+		query = query.Select(append(normalizedFields(fields), "updated_at"))
+	}
+	if err := query.Omit("version").Updates(updatedUser).Error; err != nil {
+		return err
+	}
+
+	user := &model.User{}
+	if err := tx.Where("id = ?", id).First(user).Error; err != nil {
+		return err
+	}
+	updatedUser.Version = user.Version
+
+	return outbox.AddUserEvent(tx, outbox.EventUserUpdated, user)
+}
+
+// normalizedFields returns the columns to update along with the canonical forms of the identifiers among them.
+func normalizedFields(fields []string) []string {
+	selected := slices.Clone(fields)
+	for _, field := range fields {
+		if field == "username" || field == "email" {
+			selected = append(selected, field+"_normalized")
+		}
+	}
+	return selected
+}
+
+// catchUpdateError maps the error of an update transaction, keeping ErrVersionConflict as it is.
+func catchUpdateError(err error) error {
+	if errors.Is(err, ErrVersionConflict) {
+		return err
+	}
+
+	return dbutils.CatchDBError(err)
 }
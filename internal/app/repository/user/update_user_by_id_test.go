@@ -21,7 +21,8 @@ func TestUser_UpdateUserByID(t *testing.T) {
 		inputID       string
 		inputUserData *model.User
 
-		expectedError error
+		expectedError   error
+		expectedVersion int
 	}{
 		{
 			name: "Update user by ID successfully",
@@ -36,6 +37,60 @@ func TestUser_UpdateUserByID(t *testing.T) {
 				DisplayName: "Alice Updated",
 				Email:       "alice.updated@example.com",
 			},
+
+			expectedVersion: 2,
+		},
+		{
+			name: "Update user by ID successfully - expected version is current",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			inputUserData: &model.User{
+				DisplayName: "Alice Updated",
+				Email:       "alice.updated@example.com",
+				Version:     1,
+			},
+
+			expectedVersion: 2,
+		},
+		{
+			name: "Update user by ID failed - expected version is outdated",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", "de305d54-75b4-431b-adb2-eb6b9e546000").Update("version", 3).Error)
+				return db
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			inputUserData: &model.User{
+				DisplayName: "Alice Updated",
+				Email:       "alice.updated@example.com",
+				Version:     2,
+			},
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Update user by ID failed - user with expected version not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID: "non-existent-id",
+
+			inputUserData: &model.User{
+				DisplayName: "Non Existent User",
+				Version:     1,
+			},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
 		},
 		{
 			name: "Update user by ID failed - user not found",
@@ -67,6 +122,22 @@ func TestUser_UpdateUserByID(t *testing.T) {
 				Email:       "bob@example.com", // duplicate email
 			},
 
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Update user by ID failed - duplicate email in another case",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+
+			inputUserData: &model.User{
+				DisplayName: "Alice",
+				Email:       "BOB@example.com",
+			},
+
 			expectedError: dbutils.ErrDuplicationType,
 		},
 	}
@@ -80,10 +151,29 @@ func TestUser_UpdateUserByID(t *testing.T) {
 			testUserRepo := NewUserRepository(db)
 
 			err := testUserRepo.UpdateUserByID(ctx, tc.inputID, tc.inputUserData)
+			events := []*model.OutboxEvent{}
+			assert.Nil(t, db.Where("aggregate_id = ?", tc.inputID).Find(&events).Error)
 			if err != nil {
 				assert.Equal(t, tc.expectedError, err)
+
+				// No event is added for a rolled back update
+				assert.Empty(t, events)
 				return
 			}
+
+			// The new version is written back and stored
+			assert.Equal(t, tc.expectedVersion, tc.inputUserData.Version)
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.Equal(t, tc.expectedVersion, user.Version)
+			assert.Equal(t, tc.inputUserData.DisplayName, user.DisplayName)
+			assert.Equal(t, "alice.updated@example.com", *user.EmailNormalized)
+
+			// The event carries the user after the update
+			assert.Len(t, events, 1)
+			assert.Equal(t, "user.updated", events[0].EventType)
+			assert.Contains(t, events[0].Payload, `"email":"`+tc.inputUserData.Email+`"`)
+			assert.Contains(t, events[0].Payload, `"username":"Alice"`)
 		})
 	}
 }
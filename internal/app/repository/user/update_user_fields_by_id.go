@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// UpdateUserFieldsByID updates the given columns of an existing user by their ID.
+// Unlike UpdateUserByID, the listed columns are written even when updatedUser holds their zero value,
+// and the other columns are left unchanged.
+// The version check and the user.updated event are the same as for UpdateUserByID.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be updated.
+//   - updatedUser: The user model containing the new values and, optionally, the expected version.
+//   - fields: The columns to update, e.g. "display_name" or "email".
+//
+// Returns:
+//   - error: ErrVersionConflict if the user was updated since the expected version, dbutils.ErrRecordNotFoundType
+//     if the user does not exist, otherwise any update error.
+func (u *userRepository) UpdateUserFieldsByID(ctx context.Context, id string, updatedUser *model.User, fields []string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateUserFieldsByID")
+	defer s.End()
+
+	return u.updateUser(ctx, id, updatedUser, fields, nil)
+}
+
+// UpdateUserFieldsByIDWith updates the given columns of an existing user as UpdateUserFieldsByID does, and runs also
+// in the same transaction once the user is updated, for the writes other repositories make along with the user.
+// If also fails, nothing is written.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be updated.
+//   - updatedUser: The user model containing the new values and, optionally, the expected version.
+//   - fields: The columns to update, e.g. "display_name" or "email".
+//   - also: The writes to make in the transaction, given the transaction.
+//
+// Returns:
+//   - error: ErrVersionConflict if the user was updated since the expected version, dbutils.ErrRecordNotFoundType
+//     if the user does not exist, otherwise the error of also or any update error.
+func (u *userRepository) UpdateUserFieldsByIDWith(ctx context.Context, id string, updatedUser *model.User, fields []string, also func(tx *gorm.DB) error) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateUserFieldsByIDWith")
+	defer s.End()
+
+	return u.updateUser(ctx, id, updatedUser, fields, also)
+}
@@ -0,0 +1,146 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestUser_UpdateUserFieldsByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB       func(t *testing.T) *gorm.DB
+		inputID       string
+		inputUserData *model.User
+		inputFields   []string
+
+		expectedError error
+		expectedUser  *model.User
+	}{
+		{
+			name: "Update only the given field",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				DisplayName: "Ignored",
+				Email:       "alice.updated@example.com",
+				Version:     1,
+			},
+			inputFields: []string{"email"},
+
+			expectedUser: &model.User{
+				Username:    "Alice",
+				DisplayName: "Alice",
+				Email:       "alice.updated@example.com",
+				Version:     2,
+
+				EmailNormalized: stringPtr("alice.updated@example.com"),
+			},
+		},
+		{
+			name: "Update a field to its zero value",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID:       "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{},
+			inputFields:   []string{"display_name"},
+
+			expectedUser: &model.User{
+				Username:    "Alice",
+				DisplayName: "",
+				Email:       "alice@example.com",
+				Version:     2,
+
+				EmailNormalized: stringPtr("alice@example.com"),
+			},
+		},
+		{
+			name: "Update failed - expected version is outdated",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				assert.Nil(t, db.Model(&model.User{}).Where("id = ?", "de305d54-75b4-431b-adb2-eb6b9e546000").Update("version", 2).Error)
+				return db
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				DisplayName: "Alice Updated",
+				Version:     1,
+			},
+			inputFields: []string{"display_name"},
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Update failed - duplicate email",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID: "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputUserData: &model.User{
+				Email: "bob@example.com",
+			},
+			inputFields: []string{"email"},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Update failed - user not found",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+
+			inputID: "non-existent-id",
+			inputUserData: &model.User{
+				DisplayName: "Nobody",
+			},
+			inputFields: []string{"display_name"},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testUserRepo := NewUserRepository(db)
+
+			err := testUserRepo.UpdateUserFieldsByID(ctx, tc.inputID, tc.inputUserData, tc.inputFields)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.Equal(t, tc.expectedUser.Username, user.Username)
+			assert.Equal(t, tc.expectedUser.DisplayName, user.DisplayName)
+			assert.Equal(t, tc.expectedUser.Email, user.Email)
+			assert.Equal(t, tc.expectedUser.EmailNormalized, user.EmailNormalized)
+			assert.Equal(t, tc.expectedUser.Version, user.Version)
+			assert.Equal(t, tc.expectedUser.Version, tc.inputUserData.Version)
+			assert.True(t, user.UpdatedAt.After(fixture.TestTime))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpgradePasswordHashByID replaces the hash of the password of a user by a hash of the same password made with the
+// preferred algorithm. The hash is only replaced while it is still currentHash, so a concurrent password change wins.
+// It is a maintenance write: the version, the update time, the password change time and the outbox are left untouched.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user.
+//   - currentHash: The hash the password was verified against.
+//   - newHash: The new hash of the same password.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user does not exist or its hash changed since, otherwise any update
+//     error.
+func (u *userRepository) UpgradePasswordHashByID(ctx context.Context, id, currentHash, newHash string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpgradePasswordHashByID")
+	defer s.End()
+
+	result := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ? AND password = ?", id, currentHash).
+		UpdateColumn("password", newHash)
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
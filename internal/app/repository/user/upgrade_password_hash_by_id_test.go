@@ -0,0 +1,74 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_UpgradePasswordHashByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID          string
+		inputCurrentHash string
+
+		expectedError    error
+		expectedPassword string
+	}{
+		{
+			name: "Upgrade the hash",
+
+			inputID:          "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputCurrentHash: "$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu",
+
+			expectedPassword: "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5",
+		},
+		{
+			name: "Upgrade failed - hash changed since",
+
+			inputID:          "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			inputCurrentHash: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+
+			expectedError:    dbutils.ErrRecordNotFoundType,
+			expectedPassword: "$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu",
+		},
+		{
+			name: "Upgrade failed - user not found",
+
+			inputID:          "non-existent-id",
+			inputCurrentHash: "$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			err := testUserRepo.UpgradePasswordHashByID(ctx, tc.inputID, tc.inputCurrentHash, "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5")
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedPassword == "" {
+				return
+			}
+
+			// Neither the version, the update time nor the password change time change
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.Equal(t, tc.expectedPassword, user.Password)
+			assert.Equal(t, 1, user.Version)
+			assert.Equal(t, fixture.TestTime, user.UpdatedAt)
+			assert.Equal(t, fixture.TestTime, *user.PasswordChangedAt)
+		})
+	}
+}
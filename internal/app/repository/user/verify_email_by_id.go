@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// VerifyEmailByID records that a user proved they own their email address, as long as it is still email.
+// The version, the update time and the outbox are left untouched, and an address verified before keeps its time.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user.
+//   - email: The email address the user proved they own.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user does not exist or has another email address, otherwise any
+//     update error.
+func (u *userRepository) VerifyEmailByID(ctx context.Context, id, email string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_VerifyEmailByID")
+	defer s.End()
+
+	result := u.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ? AND email = ?", id, email).
+		UpdateColumn("email_verified_at", gorm.Expr("COALESCE(email_verified_at, ?)", time.Now()))
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUser_VerifyEmailByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID    string
+		inputEmail string
+
+		expectedError error
+	}{
+		{
+			name: "Verify the email address",
+
+			inputID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputEmail: "alice@example.com",
+		},
+		{
+			name: "Verify failed - email address changed since",
+
+			inputID:    "de305d54-75b4-431b-adb2-eb6b9e546000",
+			inputEmail: "alice.old@example.com",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Verify failed - user not found",
+
+			inputID:    "non-existent-id",
+			inputEmail: "alice@example.com",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			testUserRepo := NewUserRepository(db)
+
+			err := testUserRepo.VerifyEmailByID(ctx, tc.inputID, tc.inputEmail)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			// Neither the version nor the update time change
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.NotNil(t, user.EmailVerifiedAt)
+			assert.Equal(t, 1, user.Version)
+			assert.Equal(t, fixture.TestTime, user.UpdatedAt)
+
+			// Verifying again keeps the first time
+			verifiedAt := *user.EmailVerifiedAt
+			assert.Nil(t, testUserRepo.VerifyEmailByID(ctx, tc.inputID, tc.inputEmail))
+			assert.Nil(t, db.Where("id = ?", tc.inputID).First(user).Error)
+			assert.True(t, verifiedAt.Equal(*user.EmailVerifiedAt))
+		})
+	}
+}
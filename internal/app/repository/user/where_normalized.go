@@ -0,0 +1,14 @@
+package user
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// whereNormalized matches the canonical form of an identifier column, e.g. "username" through
+// "username_normalized". Rows created before the canonical forms existed have none until they are backfilled,
+// and are matched on the exact value instead.
+func whereNormalized(db *gorm.DB, column, value, normalized string) *gorm.DB {
+	return db.Where(fmt.Sprintf("(%[1]s_normalized = ? OR (%[1]s_normalized IS NULL AND %[1]s = ?))", column), normalized, value)
+}
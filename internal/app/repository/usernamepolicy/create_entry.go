@@ -0,0 +1,30 @@
+package usernamepolicy
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateEntry stores a new username policy entry.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - entry: The entry to store.
+//
+// Returns:
+//   - *model.UsernamePolicyEntry: The created entry.
+//   - error: dbutils.ErrDuplicationType if the same entry exists, otherwise an error if the creation fails.
+func (u *usernamePolicyRepository) CreateEntry(ctx context.Context, entry *model.UsernamePolicyEntry) (*model.UsernamePolicyEntry, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateUsernamePolicyEntry")
+	defer s.End()
+
+	err := u.db.WithContext(ctx).Create(entry).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return entry, nil
+}
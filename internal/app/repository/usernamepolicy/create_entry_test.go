@@ -0,0 +1,66 @@
+package usernamepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUsernamePolicy_CreateEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputEntry *model.UsernamePolicyEntry
+
+		expectedError error
+		expectedCount int64
+	}{
+		{
+			name: "Create entry successfully",
+
+			inputEntry: &model.UsernamePolicyEntry{Kind: model.UsernamePolicyReserved, Value: "billing"},
+
+			expectedCount: 4,
+		},
+		{
+			name: "Same value of another kind",
+
+			inputEntry: &model.UsernamePolicyEntry{Kind: model.UsernamePolicyBlockedWord, Value: "acme"},
+
+			expectedCount: 4,
+		},
+		{
+			name: "Create entry failed - duplicate entry",
+
+			inputEntry: &model.UsernamePolicyEntry{Kind: model.UsernamePolicyReserved, Value: "acme"},
+
+			expectedError: dbutils.ErrDuplicationType,
+			expectedCount: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UsernamePolicyCommonTestDB{})
+			testRepo := NewUsernamePolicyRepository(db)
+
+			res, err := testRepo.CreateEntry(ctx, tc.inputEntry)
+			assert.Equal(t, tc.expectedError, err)
+			if err == nil {
+				assert.NotEmpty(t, res.ID)
+			}
+
+			var count int64
+			db.Model(&model.UsernamePolicyEntry{}).Count(&count)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
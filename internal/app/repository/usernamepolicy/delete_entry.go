@@ -0,0 +1,33 @@
+package usernamepolicy
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DeleteEntry deletes a username policy entry.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the entry.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if there is no such entry, otherwise any deletion error.
+func (u *usernamePolicyRepository) DeleteEntry(ctx context.Context, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteUsernamePolicyEntry")
+	defer s.End()
+
+	result := u.db.WithContext(ctx).Where("id = ?", id).Delete(&model.UsernamePolicyEntry{})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package usernamepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUsernamePolicy_DeleteEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputID string
+
+		expectedError error
+		expectedCount int64
+	}{
+		{
+			name:    "Delete entry successfully",
+			inputID: "e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92",
+
+			expectedCount: 2,
+		},
+		{
+			name:    "Entry not found",
+			inputID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+			expectedCount: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.UsernamePolicyCommonTestDB{})
+			testRepo := NewUsernamePolicyRepository(db)
+
+			err := testRepo.DeleteEntry(ctx, tc.inputID)
+			assert.Equal(t, tc.expectedError, err)
+
+			var count int64
+			db.Model(&model.UsernamePolicyEntry{}).Count(&count)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package usernamepolicy
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListEntries retrieves all username policy entries, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - []*model.UsernamePolicyEntry: The entries, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (u *usernamePolicyRepository) ListEntries(ctx context.Context) ([]*model.UsernamePolicyEntry, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListUsernamePolicyEntries")
+	defer s.End()
+
+	entries := []*model.UsernamePolicyEntry{}
+	err := u.db.WithContext(ctx).
+		Order("created_at ASC, id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return entries, nil
+}
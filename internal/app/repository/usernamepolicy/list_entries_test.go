@@ -0,0 +1,25 @@
+package usernamepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUsernamePolicy_ListEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db := fixture.NewFixture(t, &fixture.UsernamePolicyCommonTestDB{})
+	testRepo := NewUsernamePolicyRepository(db)
+
+	res, err := testRepo.ListEntries(ctx)
+	assert.Nil(t, err)
+
+	values := []string{}
+	for _, entry := range res {
+		values = append(values, entry.Kind+":"+entry.Value)
+	}
+	assert.Equal(t, []string{"reserved:acme", "blocked_word:scam", "blocked_pattern:^guest[0-9]+$"}, values)
+}
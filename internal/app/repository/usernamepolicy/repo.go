@@ -0,0 +1,59 @@
+// Package usernamepolicy provides repository operations for the username lists managed at runtime,
+// stored with GORM.
+package usernamepolicy
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Repository represents the interface for username policy repository operations.
+//
+//go:generate mockery --name=Repository --filename=username_policy_repo.go --output=./mocks
+type Repository interface {
+	// CreateEntry stores a new username policy entry.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - entry: The entry to store.
+	//
+	// Returns:
+	//   - *model.UsernamePolicyEntry: The created entry.
+	//   - error: dbutils.ErrDuplicationType if the same entry exists, otherwise an error if the creation fails.
+	CreateEntry(ctx context.Context, entry *model.UsernamePolicyEntry) (*model.UsernamePolicyEntry, error)
+
+	// ListEntries retrieves all username policy entries, oldest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - []*model.UsernamePolicyEntry: The entries, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListEntries(ctx context.Context) ([]*model.UsernamePolicyEntry, error)
+
+	// DeleteEntry deletes a username policy entry.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the entry.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if there is no such entry, otherwise any deletion error.
+	DeleteEntry(ctx context.Context, id string) error
+}
+
+// usernamePolicyRepository is the concrete implementation of the Repository interface.
+type usernamePolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewUsernamePolicyRepository creates a new instance of the username policy repository.
+//
+// Parameters:
+//   - db: The GORM database connection.
+//
+// Returns:
+//   - Repository: A new username policy repository instance.
+func NewUsernamePolicyRepository(db *gorm.DB) Repository {
+	return &usernamePolicyRepository{db: db}
+}
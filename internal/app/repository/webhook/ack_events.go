@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// AckEvents acknowledges events of a stream, so they are not read again by the consumer group.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - stream: The name of the stream.
+//   - group: The name of the consumer group.
+//   - streamIDs: The IDs of the stream entries.
+//
+// Returns:
+//   - error: An error if the events cannot be acknowledged, otherwise nil.
+func (w *webhookRepository) AckEvents(ctx context.Context, stream, group string, streamIDs []string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_AckEvents")
+	defer s.End()
+
+	if len(streamIDs) == 0 {
+		return nil
+	}
+
+	return w.redisClient.XAck(ctx, stream, group, streamIDs...).Err()
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+)
+
+func TestWebhook_AckEvents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) (*redis.Client, []string)
+
+		expectedPending int64
+		expectedError   error
+	}{
+		{
+			name: "Acknowledge read events",
+
+			setupRedis: func(ctx context.Context) (*redis.Client, []string) {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.XGroupCreateMkStream(ctx, "user-events", "webhooks", "$")
+				streamID := redisClient.XAdd(ctx, &redis.XAddArgs{Stream: "user-events", Values: map[string]any{"event_id": "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"}}).Val()
+				redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+					Group:    "webhooks",
+					Consumer: "webhook-worker",
+					Streams:  []string{"user-events", ">"},
+					Block:    -1,
+				})
+				return redisClient, []string{streamID}
+			},
+		},
+		{
+			name: "Nothing to acknowledge",
+
+			setupRedis: func(ctx context.Context) (*redis.Client, []string) {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.XGroupCreateMkStream(ctx, "user-events", "webhooks", "$")
+				return redisClient, nil
+			},
+		},
+		{
+			name: "Closed Redis client",
+
+			setupRedis: func(ctx context.Context) (*redis.Client, []string) {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient, []string{"1672531200000-0"}
+			},
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient, streamIDs := tc.setupRedis(ctx)
+			testWebhookRepo := NewWebhookRepository(nil, redisClient)
+
+			err := testWebhookRepo.AckEvents(ctx, "user-events", "webhooks", streamIDs)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			pending, err := redisClient.XPending(ctx, "user-events", "webhooks").Result()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPending, pending.Count)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm/clause"
+)
+
+// CreateDeliveries queues deliveries, skipping those of an event already queued for the subscription.
+// An event read again off the stream after a crash is therefore not delivered twice.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - deliveries: The deliveries to queue.
+//
+// Returns:
+//   - error: An error if the creation fails, otherwise nil.
+func (w *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateDeliveries")
+	defer s.End()
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := w.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(deliveries).Error
+
+	return dbutils.CatchDBError(err)
+}
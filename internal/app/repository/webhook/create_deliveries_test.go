@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_CreateDeliveries(t *testing.T) {
+	t.Parallel()
+
+	newDelivery := func(subscriptionID, eventID string) *model.WebhookDelivery {
+		return &model.WebhookDelivery{
+			SubscriptionID: subscriptionID,
+			EventID:        eventID,
+			EventType:      "user.updated",
+			Payload:        `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe"}`,
+			OccurredAt:     fixture.TestTime.Add(6 * time.Hour),
+			Status:         model.WebhookDeliveryPending,
+			NextAttemptAt:  fixture.TestTime.Add(6 * time.Hour),
+		}
+	}
+
+	testCases := []struct {
+		name string
+
+		inputDeliveries []*model.WebhookDelivery
+
+		expectedCount int64
+	}{
+		{
+			name: "Queue deliveries successfully",
+			inputDeliveries: []*model.WebhookDelivery{
+				newDelivery("d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81", "c3d4e5f6-0006-4a5b-8c9d-2e3f4a5b6c76"),
+				newDelivery("d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82", "c3d4e5f6-0006-4a5b-8c9d-2e3f4a5b6c76"),
+			},
+
+			expectedCount: 6,
+		},
+		{
+			name: "Skip an event already queued for the subscription",
+			inputDeliveries: []*model.WebhookDelivery{
+				newDelivery("d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81", "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"),
+				newDelivery("d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82", "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"),
+			},
+
+			expectedCount: 5,
+		},
+		{
+			name:            "Nothing to queue",
+			inputDeliveries: []*model.WebhookDelivery{},
+
+			expectedCount: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+			testWebhookRepo := NewWebhookRepository(db, nil)
+
+			err := testWebhookRepo.CreateDeliveries(ctx, tc.inputDeliveries)
+			assert.NoError(t, err)
+
+			var count int64
+			db.Model(&model.WebhookDelivery{}).Count(&count)
+			assert.Equal(t, tc.expectedCount, count)
+
+			// The delivery queued before is left untouched
+			existing := &model.WebhookDelivery{}
+			assert.NoError(t, db.Where("id = ?", "e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92").First(existing).Error)
+			assert.Equal(t, "user.created", existing.EventType)
+		})
+	}
+}
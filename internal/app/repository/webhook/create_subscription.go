@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateSubscription stores a new webhook subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscription: The subscription to store.
+//
+// Returns:
+//   - *model.WebhookSubscription: The created subscription.
+//   - error: An error if the creation fails, otherwise nil.
+func (w *webhookRepository) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_CreateSubscription")
+	defer s.End()
+
+	err := w.db.WithContext(ctx).Create(subscription).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestWebhook_CreateSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB           func(t *testing.T) *gorm.DB
+		inputSubscription *model.WebhookSubscription
+
+		expectedError error
+	}{
+		{
+			name: "Create subscription successfully",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+			},
+			inputSubscription: &model.WebhookSubscription{
+				URL:        "https://billing.example.com/webhooks",
+				Secret:     "whsec_billingsecret00000000000000000000000",
+				EventTypes: []string{"user.created"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := tc.setupDB(t)
+			testWebhookRepo := NewWebhookRepository(db, nil)
+
+			subscription, err := testWebhookRepo.CreateSubscription(ctx, tc.inputSubscription)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotEmpty(t, subscription.ID)
+
+			stored := &model.WebhookSubscription{}
+			assert.NoError(t, db.Where("id = ?", subscription.ID).First(stored).Error)
+			assert.Equal(t, tc.inputSubscription.URL, stored.URL)
+			assert.Equal(t, tc.inputSubscription.Secret, stored.Secret)
+			assert.Equal(t, tc.inputSubscription.EventTypes, stored.EventTypes)
+		})
+	}
+}
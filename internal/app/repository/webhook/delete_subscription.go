@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// DeleteSubscription deletes a webhook subscription along with its deliveries,
+// so pending deliveries are not attempted anymore.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if there is no such subscription, otherwise any deletion error.
+func (w *webhookRepository) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_DeleteSubscription")
+	defer s.End()
+
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("subscription_id = ?", subscriptionID).Delete(&model.WebhookDelivery{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", subscriptionID).Delete(&model.WebhookSubscription{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return dbutils.ErrRecordNotFoundType
+		}
+
+		return nil
+	})
+
+	return dbutils.CatchDBError(err)
+}
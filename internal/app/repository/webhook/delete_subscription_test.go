@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_DeleteSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputSubscriptionID string
+
+		expectedError error
+	}{
+		{
+			name:                "Delete subscription along with its deliveries",
+			inputSubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+		},
+		{
+			name:                "Subscription not found",
+			inputSubscriptionID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+			testWebhookRepo := NewWebhookRepository(db, nil)
+
+			err := testWebhookRepo.DeleteSubscription(ctx, tc.inputSubscriptionID)
+			assert.Equal(t, tc.expectedError, err)
+
+			var subscriptions, deliveries int64
+			db.Model(&model.WebhookSubscription{}).Count(&subscriptions)
+			db.Model(&model.WebhookDelivery{}).Count(&deliveries)
+			if tc.expectedError == nil {
+				assert.Equal(t, int64(1), subscriptions)
+				assert.Equal(t, int64(0), deliveries)
+			} else {
+				assert.Equal(t, int64(2), subscriptions)
+				assert.Equal(t, int64(4), deliveries)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetDeliveryByID retrieves a delivery of a subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//   - deliveryID: The ID of the delivery.
+//
+// Returns:
+//   - *model.WebhookDelivery: The delivery.
+//   - error: dbutils.ErrRecordNotFoundType if the subscription has no such delivery, otherwise any retrieval error.
+func (w *webhookRepository) GetDeliveryByID(ctx context.Context, subscriptionID, deliveryID string) (*model.WebhookDelivery, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetDeliveryByID")
+	defer s.End()
+
+	delivery := &model.WebhookDelivery{}
+	err := w.db.WithContext(ctx).
+		Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).
+		First(delivery).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_GetDeliveryByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputSubscriptionID string
+		inputDeliveryID     string
+
+		expectedError error
+	}{
+		{
+			name:                "Get delivery successfully",
+			inputSubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			inputDeliveryID:     "e5f6a7b8-0003-4c5d-8e9f-4a5b6c7d8e93",
+		},
+		{
+			name:                "Delivery of another subscription",
+			inputSubscriptionID: "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82",
+			inputDeliveryID:     "e5f6a7b8-0003-4c5d-8e9f-4a5b6c7d8e93",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testWebhookRepo := NewWebhookRepository(fixture.NewFixture(t, &fixture.WebhookCommonTestDB{}), nil)
+
+			delivery, err := testWebhookRepo.GetDeliveryByID(ctx, tc.inputSubscriptionID, tc.inputDeliveryID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, delivery)
+				return
+			}
+
+			assert.Equal(t, tc.inputDeliveryID, delivery.ID)
+			assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+			assert.Equal(t, 8, delivery.Attempts)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetSubscriptionByID retrieves a webhook subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//
+// Returns:
+//   - *model.WebhookSubscription: The subscription.
+//   - error: dbutils.ErrRecordNotFoundType if there is no such subscription, otherwise any retrieval error.
+func (w *webhookRepository) GetSubscriptionByID(ctx context.Context, subscriptionID string) (*model.WebhookSubscription, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_GetSubscriptionByID")
+	defer s.End()
+
+	subscription := &model.WebhookSubscription{}
+	err := w.db.WithContext(ctx).Where("id = ?", subscriptionID).First(subscription).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_GetSubscriptionByID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputSubscriptionID string
+
+		expectedURL   string
+		expectedError error
+	}{
+		{
+			name:                "Get subscription successfully",
+			inputSubscriptionID: "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82",
+
+			expectedURL: "https://crm.example.com/webhooks",
+		},
+		{
+			name:                "Subscription not found",
+			inputSubscriptionID: "00000000-0000-0000-0000-000000000000",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testWebhookRepo := NewWebhookRepository(fixture.NewFixture(t, &fixture.WebhookCommonTestDB{}), nil)
+
+			subscription, err := testWebhookRepo.GetSubscriptionByID(ctx, tc.inputSubscriptionID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, subscription)
+				return
+			}
+
+			assert.Equal(t, tc.inputSubscriptionID, subscription.ID)
+			assert.Equal(t, tc.expectedURL, subscription.URL)
+			assert.Equal(t, []string{"user.deleted"}, subscription.EventTypes)
+		})
+	}
+}
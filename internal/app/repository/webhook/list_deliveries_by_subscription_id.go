@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListDeliveriesBySubscriptionID retrieves the most recent deliveries of a subscription, newest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//   - limit: The maximum number of deliveries to return.
+//
+// Returns:
+//   - []*model.WebhookDelivery: The deliveries, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (w *webhookRepository) ListDeliveriesBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*model.WebhookDelivery, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListDeliveriesBySubscriptionID")
+	defer s.End()
+
+	deliveries := []*model.WebhookDelivery{}
+	err := w.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return deliveries, nil
+}
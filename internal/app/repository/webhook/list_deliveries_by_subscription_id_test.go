@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_ListDeliveriesBySubscriptionID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputSubscriptionID string
+		inputLimit          int
+
+		expectedIDs []string
+	}{
+		{
+			name:                "List deliveries newest first",
+			inputSubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			inputLimit:          50,
+
+			expectedIDs: []string{
+				"e5f6a7b8-0004-4c5d-8e9f-4a5b6c7d8e94",
+				"e5f6a7b8-0003-4c5d-8e9f-4a5b6c7d8e93",
+				"e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92",
+				"e5f6a7b8-0001-4c5d-8e9f-4a5b6c7d8e91",
+			},
+		},
+		{
+			name:                "List deliveries up to the limit",
+			inputSubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			inputLimit:          1,
+
+			expectedIDs: []string{"e5f6a7b8-0004-4c5d-8e9f-4a5b6c7d8e94"},
+		},
+		{
+			name:                "Subscription without deliveries",
+			inputSubscriptionID: "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82",
+			inputLimit:          50,
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testWebhookRepo := NewWebhookRepository(fixture.NewFixture(t, &fixture.WebhookCommonTestDB{}), nil)
+
+			deliveries, err := testWebhookRepo.ListDeliveriesBySubscriptionID(ctx, tc.inputSubscriptionID, tc.inputLimit)
+			assert.NoError(t, err)
+
+			ids := []string{}
+			for _, delivery := range deliveries {
+				ids = append(ids, delivery.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListDueDeliveries retrieves the pending deliveries due for an attempt, with their subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - now: The current time.
+//   - limit: The maximum number of deliveries to return.
+//
+// Returns:
+//   - []*model.WebhookDelivery: The due deliveries, longest waiting first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (w *webhookRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListDueDeliveries")
+	defer s.End()
+
+	deliveries := []*model.WebhookDelivery{}
+	err := w.db.WithContext(ctx).
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_ListDueDeliveries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputNow   time.Time
+		inputLimit int
+
+		expectedIDs []string
+	}{
+		{
+			name:       "Only pending deliveries that are due",
+			inputNow:   fixture.TestTime.Add(2 * time.Hour),
+			inputLimit: 100,
+
+			expectedIDs: []string{"e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92"},
+		},
+		{
+			name:       "Longest waiting first",
+			inputNow:   fixture.TestTime.Add(5 * time.Hour),
+			inputLimit: 100,
+
+			expectedIDs: []string{
+				"e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92",
+				"e5f6a7b8-0004-4c5d-8e9f-4a5b6c7d8e94",
+			},
+		},
+		{
+			name:       "Up to the limit",
+			inputNow:   fixture.TestTime.Add(5 * time.Hour),
+			inputLimit: 1,
+
+			expectedIDs: []string{"e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92"},
+		},
+		{
+			name:       "Nothing due",
+			inputNow:   fixture.TestTime,
+			inputLimit: 100,
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testWebhookRepo := NewWebhookRepository(fixture.NewFixture(t, &fixture.WebhookCommonTestDB{}), nil)
+
+			deliveries, err := testWebhookRepo.ListDueDeliveries(ctx, tc.inputNow, tc.inputLimit)
+			assert.NoError(t, err)
+
+			ids := []string{}
+			for _, delivery := range deliveries {
+				ids = append(ids, delivery.ID)
+				assert.NotNil(t, delivery.Subscription)
+				assert.Equal(t, "https://partner.example.com/hooks", delivery.Subscription.URL)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListSubscriptions retrieves all webhook subscriptions, oldest first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - []*model.WebhookSubscription: The subscriptions, empty if there are none.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (w *webhookRepository) ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ListSubscriptions")
+	defer s.End()
+
+	subscriptions := []*model.WebhookSubscription{}
+	err := w.db.WithContext(ctx).
+		Order("created_at ASC, id ASC").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, dbutils.CatchDBError(err)
+	}
+
+	return subscriptions, nil
+}
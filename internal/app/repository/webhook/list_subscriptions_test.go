@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+func TestWebhook_ListSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupDB func(t *testing.T) *gorm.DB
+
+		expectedIDs []string
+	}{
+		{
+			name: "List subscriptions oldest first",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+			},
+
+			expectedIDs: []string{
+				"d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+				"d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82",
+			},
+		},
+		{
+			name: "No subscriptions",
+
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+				db.Where("1 = 1").Delete(&model.WebhookDelivery{})
+				db.Where("1 = 1").Delete(&model.WebhookSubscription{})
+				return db
+			},
+
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testWebhookRepo := NewWebhookRepository(tc.setupDB(t), nil)
+
+			subscriptions, err := testWebhookRepo.ListSubscriptions(ctx)
+			assert.NoError(t, err)
+
+			ids := []string{}
+			for _, subscription := range subscriptions {
+				ids = append(ids, subscription.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// ReadEvents reads the next events of a stream as a member of a consumer group.
+// Events read before but never acknowledged, because the consumer stopped, are returned first.
+// The group is created on first use, starting with the first event of the stream, so the events published before
+// the worker first ran are delivered too.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - stream: The name of the stream.
+//   - group: The name of the consumer group.
+//   - consumer: The name of the consumer within the group.
+//   - count: The maximum number of events to return.
+//
+// Returns:
+//   - []*Event: The events, empty if there are none.
+//   - error: An error if the stream cannot be read, otherwise nil.
+func (w *webhookRepository) ReadEvents(ctx context.Context, stream, group, consumer string, count int) ([]*Event, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_ReadEvents")
+	defer s.End()
+
+	// "0" reads the unacknowledged events of the consumer, ">" the ones never delivered to the group
+	for _, start := range []string{"0", ">"} {
+		streams, err := w.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: consumer,
+			Streams:  []string{stream, start},
+			Count:    int64(count),
+			Block:    -1,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil && strings.HasPrefix(err.Error(), "NOGROUP") {
+			// A new group has nothing unacknowledged, its events are read with ">"
+			if err := w.redisClient.XGroupCreateMkStream(ctx, stream, group, "0").Err(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(streams) > 0 && len(streams[0].Messages) > 0 {
+			return parseEvents(streams[0].Messages), nil
+		}
+	}
+
+	return []*Event{}, nil
+}
+
+// parseEvents converts stream entries written by the outbox relay into events.
+//
+// Parameters:
+//   - messages: The stream entries.
+//
+// Returns:
+//   - []*Event: The events, in stream order.
+func parseEvents(messages []redis.XMessage) []*Event {
+	events := make([]*Event, 0, len(messages))
+	for _, message := range messages {
+		field := func(name string) string {
+			value, _ := message.Values[name].(string)
+			return value
+		}
+
+		occurredAt, _ := time.Parse(time.RFC3339Nano, field("occurred_at"))
+		// Entries published before events carried a tenant are of the default one
+		tenantID := field("tenant_id")
+		if tenantID == "" {
+			tenantID = tenant.Default
+		}
+
+		events = append(events, &Event{
+			StreamID:    message.ID,
+			ID:          field("event_id"),
+			Type:        field("event_type"),
+			TenantID:    tenantID,
+			AggregateID: field("aggregate_id"),
+			Payload:     field("payload"),
+			OccurredAt:  occurredAt,
+		})
+	}
+
+	return events
+}
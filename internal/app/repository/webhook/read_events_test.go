@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_ReadEvents(t *testing.T) {
+	t.Parallel()
+
+	addEvent := func(ctx context.Context, redisClient *redis.Client, eventID string) string {
+		return redisClient.XAdd(ctx, &redis.XAddArgs{
+			Stream: "user-events",
+			Values: map[string]any{
+				"event_id":     eventID,
+				"event_type":   "user.created",
+				"tenant_id":    "brand_a",
+				"aggregate_id": "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+				"payload":      `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe"}`,
+				"occurred_at":  "2023-01-01T00:00:00Z",
+			},
+		}).Val()
+	}
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+
+		expectedEventIDs []string
+		expectedError    error
+	}{
+		{
+			name: "Create the group on first use, from the first event of the stream",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				addEvent(ctx, redisClient, "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71")
+				return redisClient
+			},
+
+			expectedEventIDs: []string{"c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"},
+		},
+		{
+			name: "Read new events",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.XGroupCreateMkStream(ctx, "user-events", "webhooks", "$")
+				addEvent(ctx, redisClient, "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71")
+				addEvent(ctx, redisClient, "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72")
+				return redisClient
+			},
+
+			expectedEventIDs: []string{
+				"c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71",
+				"c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72",
+			},
+		},
+		{
+			name: "Read unacknowledged events again before new ones",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.XGroupCreateMkStream(ctx, "user-events", "webhooks", "$")
+				addEvent(ctx, redisClient, "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71")
+				redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+					Group:    "webhooks",
+					Consumer: "webhook-worker",
+					Streams:  []string{"user-events", ">"},
+					Block:    -1,
+				})
+				addEvent(ctx, redisClient, "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72")
+				return redisClient
+			},
+
+			expectedEventIDs: []string{"c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"},
+		},
+		{
+			name: "No new events",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.XGroupCreateMkStream(ctx, "user-events", "webhooks", "$")
+				return redisClient
+			},
+
+			expectedEventIDs: []string{},
+		},
+		{
+			name: "Closed Redis client",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testWebhookRepo := NewWebhookRepository(nil, tc.setupRedis(ctx))
+
+			events, err := testWebhookRepo.ReadEvents(ctx, "user-events", "webhooks", "webhook-worker", 10)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, events)
+				return
+			}
+
+			eventIDs := []string{}
+			for _, event := range events {
+				eventIDs = append(eventIDs, event.ID)
+				assert.NotEmpty(t, event.StreamID)
+				assert.Equal(t, "user.created", event.Type)
+				assert.Equal(t, "brand_a", event.TenantID)
+				assert.Equal(t, "4d9326d6-980c-4c62-9709-dbc70a82cbfe", event.AggregateID)
+				assert.Equal(t, `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe"}`, event.Payload)
+				assert.True(t, fixture.TestTime.Equal(event.OccurredAt))
+			}
+			assert.Equal(t, tc.expectedEventIDs, eventIDs)
+		})
+	}
+}
+
+func TestWebhook_ReadEvents_AfterGroupCreated(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	redisClient := redisPkg.InitMockRedis(t)
+	testWebhookRepo := NewWebhookRepository(nil, redisClient)
+
+	events, err := testWebhookRepo.ReadEvents(ctx, "user-events", "webhooks", "webhook-worker", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: "user-events",
+		Values: map[string]any{"event_id": "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71", "occurred_at": time.Now().UTC().Format(time.RFC3339Nano)},
+	})
+
+	events, err = testWebhookRepo.ReadEvents(ctx, "user-events", "webhooks", "webhook-worker", 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71", events[0].ID)
+
+	// An entry published before events carried a tenant is of the default one
+	assert.Equal(t, tenant.Default, events[0].TenantID)
+}
@@ -0,0 +1,163 @@
+// Package webhook provides repository operations for webhook subscriptions and their deliveries,
+// stored with GORM, and for reading the user domain events they are fanned out from off Redis Streams.
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// Event is a user domain event read from the event stream.
+type Event struct {
+	// StreamID is the ID of the stream entry, used to acknowledge it.
+	StreamID    string
+	ID          string
+	Type        string
+	TenantID    string
+	AggregateID string
+	Payload     string
+	OccurredAt  time.Time
+}
+
+// Repository represents the interface for webhook repository operations.
+//
+//go:generate mockery --name=Repository --filename=webhook_repo.go --output=./mocks
+type Repository interface {
+	// CreateSubscription stores a new webhook subscription.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscription: The subscription to store.
+	//
+	// Returns:
+	//   - *model.WebhookSubscription: The created subscription.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error)
+
+	// ListSubscriptions retrieves all webhook subscriptions, oldest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - []*model.WebhookSubscription: The subscriptions, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+
+	// GetSubscriptionByID retrieves a webhook subscription.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//
+	// Returns:
+	//   - *model.WebhookSubscription: The subscription.
+	//   - error: dbutils.ErrRecordNotFoundType if there is no such subscription, otherwise any retrieval error.
+	GetSubscriptionByID(ctx context.Context, subscriptionID string) (*model.WebhookSubscription, error)
+
+	// DeleteSubscription deletes a webhook subscription along with its deliveries.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if there is no such subscription, otherwise any deletion error.
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	// CreateDeliveries queues deliveries, skipping those of an event already queued for the subscription.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - deliveries: The deliveries to queue.
+	//
+	// Returns:
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateDeliveries(ctx context.Context, deliveries []*model.WebhookDelivery) error
+
+	// ListDeliveriesBySubscriptionID retrieves the most recent deliveries of a subscription, newest first.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//   - limit: The maximum number of deliveries to return.
+	//
+	// Returns:
+	//   - []*model.WebhookDelivery: The deliveries, empty if there are none.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListDeliveriesBySubscriptionID(ctx context.Context, subscriptionID string, limit int) ([]*model.WebhookDelivery, error)
+
+	// GetDeliveryByID retrieves a delivery of a subscription.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//   - deliveryID: The ID of the delivery.
+	//
+	// Returns:
+	//   - *model.WebhookDelivery: The delivery.
+	//   - error: dbutils.ErrRecordNotFoundType if the subscription has no such delivery, otherwise any retrieval error.
+	GetDeliveryByID(ctx context.Context, subscriptionID, deliveryID string) (*model.WebhookDelivery, error)
+
+	// ListDueDeliveries retrieves the pending deliveries due for an attempt, with their subscription.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - now: The current time.
+	//   - limit: The maximum number of deliveries to return.
+	//
+	// Returns:
+	//   - []*model.WebhookDelivery: The due deliveries, longest waiting first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error)
+
+	// UpdateDelivery saves the outcome of a delivery attempt, or of a replay.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - delivery: The delivery with its new status, attempts, error and schedule.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the delivery does not exist, otherwise any update error.
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+
+	// ReadEvents reads the next events of a stream as a member of a consumer group.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - stream: The name of the stream.
+	//   - group: The name of the consumer group.
+	//   - consumer: The name of the consumer within the group.
+	//   - count: The maximum number of events to return.
+	//
+	// Returns:
+	//   - []*Event: The events, empty if there are none.
+	//   - error: An error if the stream cannot be read, otherwise nil.
+	ReadEvents(ctx context.Context, stream, group, consumer string, count int) ([]*Event, error)
+
+	// AckEvents acknowledges events of a stream, so they are not read again by the consumer group.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - stream: The name of the stream.
+	//   - group: The name of the consumer group.
+	//   - streamIDs: The IDs of the stream entries.
+	//
+	// Returns:
+	//   - error: An error if the events cannot be acknowledged, otherwise nil.
+	AckEvents(ctx context.Context, stream, group string, streamIDs []string) error
+}
+
+// webhookRepository is the concrete implementation of the Repository interface.
+type webhookRepository struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewWebhookRepository creates a new instance of the webhook repository.
+//
+// Parameters:
+//   - db: The GORM database connection storing the subscriptions and deliveries.
+//   - redisClient: The Redis client the events are read from.
+//
+// Returns:
+//   - Repository: A new webhook repository instance.
+func NewWebhookRepository(db *gorm.DB, redisClient *redis.Client) Repository {
+	return &webhookRepository{
+		db:          db,
+		redisClient: redisClient,
+	}
+}
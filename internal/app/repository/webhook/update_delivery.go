@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateDelivery saves the outcome of a delivery attempt, or of a replay.
+// Only the status, attempt and schedule columns are written; the event itself never changes.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - delivery: The delivery with its new status, attempts, error and schedule.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the delivery does not exist, otherwise any update error.
+func (w *webhookRepository) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	s := newrelic.FromContext(ctx).StartSegment("Repo_UpdateDelivery")
+	defer s.End()
+
+	result := w.db.WithContext(ctx).
+		Model(&model.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]any{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"delivered_at":     delivery.DeliveredAt,
+		})
+	if result.Error != nil {
+		return dbutils.CatchDBError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return dbutils.ErrRecordNotFoundType
+	}
+
+	return nil
+}
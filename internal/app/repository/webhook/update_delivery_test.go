@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestWebhook_UpdateDelivery(t *testing.T) {
+	t.Parallel()
+
+	deliveredAt := fixture.TestTime.Add(90 * time.Minute)
+
+	testCases := []struct {
+		name string
+
+		inputDelivery *model.WebhookDelivery
+
+		expectedError error
+	}{
+		{
+			name: "Record a successful attempt",
+			inputDelivery: &model.WebhookDelivery{
+				Base:           model.Base{ID: "e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92"},
+				Status:         model.WebhookDeliverySucceeded,
+				Attempts:       1,
+				LastStatusCode: 204,
+				NextAttemptAt:  fixture.TestTime.Add(time.Hour),
+				DeliveredAt:    &deliveredAt,
+			},
+		},
+		{
+			name: "Record a failed attempt",
+			inputDelivery: &model.WebhookDelivery{
+				Base:           model.Base{ID: "e5f6a7b8-0004-4c5d-8e9f-4a5b6c7d8e94"},
+				Status:         model.WebhookDeliveryPending,
+				Attempts:       3,
+				LastStatusCode: 503,
+				LastError:      "unexpected status code 503",
+				NextAttemptAt:  fixture.TestTime.Add(5 * time.Hour),
+			},
+		},
+		{
+			name: "Delivery not found",
+			inputDelivery: &model.WebhookDelivery{
+				Base:   model.Base{ID: "00000000-0000-0000-0000-000000000000"},
+				Status: model.WebhookDeliveryPending,
+			},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			db := fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+			testWebhookRepo := NewWebhookRepository(db, nil)
+
+			err := testWebhookRepo.UpdateDelivery(ctx, tc.inputDelivery)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			stored := &model.WebhookDelivery{}
+			assert.NoError(t, db.Where("id = ?", tc.inputDelivery.ID).First(stored).Error)
+			assert.Equal(t, tc.inputDelivery.Status, stored.Status)
+			assert.Equal(t, tc.inputDelivery.Attempts, stored.Attempts)
+			assert.Equal(t, tc.inputDelivery.LastStatusCode, stored.LastStatusCode)
+			assert.Equal(t, tc.inputDelivery.LastError, stored.LastError)
+			assert.True(t, tc.inputDelivery.NextAttemptAt.Equal(stored.NextAttemptAt))
+			assert.Equal(t, tc.inputDelivery.DeliveredAt == nil, stored.DeliveredAt == nil)
+			assert.NotEmpty(t, stored.Payload)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package accesstoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockAccessTokenRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/accesstoken/mocks"
+)
+
+func TestService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	token := TokenPrefix + testSecret
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+	justNow := time.Now()
+
+	testCases := []struct {
+		name string
+
+		setupMockAccessTokenRepo func(ctx context.Context) *mockAccessTokenRepo.Repository
+
+		expectedOutput jwt.MapClaims
+		expectedError  error
+	}{
+		{
+			name: "Authenticate a token used for the first time",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", ctx, hashToken(token)).Return(&model.PersonalAccessToken{
+					Base:     model.Base{ID: testTokenID},
+					TenantID: "default",
+					UserID:   testUserID,
+					Scopes:   []string{ScopeProfileRead, ScopeProfileWrite},
+				}, nil)
+				repoMock.On("UpdateTokenLastUsed", ctx, testTokenID, mock.AnythingOfType("time.Time")).Return(nil)
+				return repoMock
+			},
+
+			expectedOutput: jwt.MapClaims{
+				"sub":       testUserID,
+				"scope":     "profile:read profile:write",
+				"token_id":  testTokenID,
+				"tenant_id": "default",
+			},
+		},
+		{
+			name: "Authenticate a recently used token with an expiry",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", ctx, hashToken(token)).Return(&model.PersonalAccessToken{
+					Base:       model.Base{ID: testTokenID},
+					TenantID:   "brand_a",
+					UserID:     testUserID,
+					Scopes:     []string{ScopeBookmarksRead},
+					ExpiresAt:  &future,
+					LastUsedAt: &justNow,
+				}, nil)
+				return repoMock
+			},
+
+			expectedOutput: jwt.MapClaims{
+				"sub":       testUserID,
+				"scope":     "bookmarks:read",
+				"token_id":  testTokenID,
+				"tenant_id": "brand_a",
+				"exp":       float64(future.Unix()),
+			},
+		},
+		{
+			name: "Authenticate failed - unknown token",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", ctx, hashToken(token)).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "Authenticate failed - expired token",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", ctx, hashToken(token)).Return(&model.PersonalAccessToken{
+					Base:      model.Base{ID: testTokenID},
+					UserID:    testUserID,
+					ExpiresAt: &past,
+				}, nil)
+				return repoMock
+			},
+
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "Authenticate failed - repository error",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", ctx, hashToken(token)).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Authenticate failed - last used update error",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", ctx, hashToken(token)).Return(&model.PersonalAccessToken{
+					Base:   model.Base{ID: testTokenID},
+					UserID: testUserID,
+				}, nil)
+				repoMock.On("UpdateTokenLastUsed", ctx, testTokenID, mock.AnythingOfType("time.Time")).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			accessTokenService := NewAccessTokenService(tc.setupMockAccessTokenRepo(ctx), nil)
+
+			res, err := accessTokenService.Authenticate(ctx, token)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package accesstoken
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateToken mints a named, scoped personal access token for a user.
+// Scopes are deduplicated and stored sorted.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user owning the token.
+//   - name: A label to recognize the token.
+//   - scopes: The scopes granted to the token.
+//   - expiresAt: When the token stops being accepted, nil if it never expires.
+//
+// Returns:
+//   - *CreatedToken: The stored token along with its plaintext value.
+//   - error: ErrUnsupportedScope or ErrInvalidExpiry for invalid input, otherwise any storage error.
+func (a *accessTokenService) CreateToken(ctx context.Context, userID, name string, scopes []string, expiresAt *time.Time) (*CreatedToken, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CreateToken")
+	defer s.End()
+
+	for _, scope := range scopes {
+		if !supportedScopes[scope] {
+			return nil, ErrUnsupportedScope
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrInvalidExpiry
+	}
+
+	secret, err := a.codeGen.GenerateCode(secretLength)
+	if err != nil {
+		return nil, err
+	}
+	token := TokenPrefix + secret
+
+	grantedScopes := slices.Clone(scopes)
+	slices.Sort(grantedScopes)
+
+	created, err := a.accessTokenRepo.CreateToken(ctx, &model.PersonalAccessToken{
+		UserID:      userID,
+		Name:        strings.TrimSpace(name),
+		TokenPrefix: token[:displayPrefixLength],
+		TokenHash:   hashToken(token),
+		Scopes:      slices.Compact(grantedScopes),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreatedToken{
+		PersonalAccessToken: created,
+		Token:               token,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package accesstoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockAccessTokenRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/accesstoken/mocks"
+)
+
+const (
+	testUserID  = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	testTokenID = "7a3c9e1f-5b2d-4f6a-8c0e-2d4f6a8c0e13"
+	testSecret  = "abcdef0123456789abcdef0123456789abcdef01"
+)
+
+func TestService_CreateToken(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name string
+
+		inputName      string
+		inputScopes    []string
+		inputExpiresAt *time.Time
+
+		setupMockAccessTokenRepo func(ctx context.Context) *mockAccessTokenRepo.Repository
+		setupMockCodeGen         func() *mockUtils.CodeGenerator
+
+		expectedOutput *CreatedToken
+		expectedError  error
+	}{
+		{
+			name:           "Create token successfully",
+			inputName:      " ci-script ",
+			inputScopes:    []string{ScopeProfileWrite, ScopeProfileRead, ScopeProfileWrite},
+			inputExpiresAt: &future,
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("CreateToken", ctx, &model.PersonalAccessToken{
+					UserID:      testUserID,
+					Name:        "ci-script",
+					TokenPrefix: "bmpat_abcdef",
+					TokenHash:   hashToken(TokenPrefix + testSecret),
+					Scopes:      []string{ScopeProfileRead, ScopeProfileWrite},
+					ExpiresAt:   &future,
+				}).Return(func(_ context.Context, token *model.PersonalAccessToken) (*model.PersonalAccessToken, error) {
+					token.ID = testTokenID
+					return token, nil
+				})
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", secretLength).Return(testSecret, nil)
+				return codeGenMock
+			},
+
+			expectedOutput: &CreatedToken{
+				PersonalAccessToken: &model.PersonalAccessToken{
+					Base:        model.Base{ID: testTokenID},
+					UserID:      testUserID,
+					Name:        "ci-script",
+					TokenPrefix: "bmpat_abcdef",
+					TokenHash:   hashToken(TokenPrefix + testSecret),
+					Scopes:      []string{ScopeProfileRead, ScopeProfileWrite},
+					ExpiresAt:   &future,
+				},
+				Token: TokenPrefix + testSecret,
+			},
+		},
+		{
+			name:        "Create token failed - unsupported scope",
+			inputName:   "ci-script",
+			inputScopes: []string{ScopeProfileRead, "admin"},
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				return mockAccessTokenRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: ErrUnsupportedScope,
+		},
+		{
+			name:           "Create token failed - expiry in the past",
+			inputName:      "ci-script",
+			inputScopes:    []string{ScopeProfileRead},
+			inputExpiresAt: &past,
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				return mockAccessTokenRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: ErrInvalidExpiry,
+		},
+		{
+			name:        "Create token failed - code generation error",
+			inputName:   "ci-script",
+			inputScopes: []string{ScopeProfileRead},
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				return mockAccessTokenRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", secretLength).Return("", assert.AnError)
+				return codeGenMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name:        "Create token failed - repository error",
+			inputName:   "ci-script",
+			inputScopes: []string{ScopeProfileRead},
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("CreateToken", ctx, mock.Anything).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", secretLength).Return(testSecret, nil)
+				return codeGenMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			accessTokenService := NewAccessTokenService(tc.setupMockAccessTokenRepo(ctx), tc.setupMockCodeGen())
+
+			res, err := accessTokenService.CreateToken(ctx, testUserID, tc.inputName, tc.inputScopes, tc.inputExpiresAt)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
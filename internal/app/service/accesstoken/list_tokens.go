@@ -0,0 +1,24 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListTokens retrieves the personal access tokens of a user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - []*model.PersonalAccessToken: The tokens of the user, oldest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (a *accessTokenService) ListTokens(ctx context.Context, userID string) ([]*model.PersonalAccessToken, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListTokens")
+	defer s.End()
+
+	return a.accessTokenRepo.ListTokensByUserID(ctx, userID)
+}
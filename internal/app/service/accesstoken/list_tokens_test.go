@@ -0,0 +1,60 @@
+package accesstoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockAccessTokenRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/accesstoken/mocks"
+)
+
+func TestService_ListTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockAccessTokenRepo func(ctx context.Context) *mockAccessTokenRepo.Repository
+
+		expectedOutput []*model.PersonalAccessToken
+		expectedError  error
+	}{
+		{
+			name: "List tokens successfully",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("ListTokensByUserID", ctx, testUserID).
+					Return([]*model.PersonalAccessToken{{Name: "ci-script"}}, nil)
+				return repoMock
+			},
+
+			expectedOutput: []*model.PersonalAccessToken{{Name: "ci-script"}},
+		},
+		{
+			name: "List tokens failed - repository error",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("ListTokensByUserID", ctx, testUserID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			accessTokenService := NewAccessTokenService(tc.setupMockAccessTokenRepo(ctx), nil)
+
+			res, err := accessTokenService.ListTokens(ctx, testUserID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// RevokeToken deletes a personal access token of a user.
+// The token stops being accepted on the next request.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - tokenID: The ID of the token to revoke.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user owns no such token, otherwise any deletion error.
+func (a *accessTokenService) RevokeToken(ctx context.Context, userID, tokenID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RevokeToken")
+	defer s.End()
+
+	return a.accessTokenRepo.DeleteToken(ctx, userID, tokenID)
+}
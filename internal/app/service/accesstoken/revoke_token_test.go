@@ -0,0 +1,55 @@
+package accesstoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockAccessTokenRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/accesstoken/mocks"
+)
+
+func TestService_RevokeToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockAccessTokenRepo func(ctx context.Context) *mockAccessTokenRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Revoke token successfully",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("DeleteToken", ctx, testUserID, testTokenID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Revoke token failed - token not found",
+
+			setupMockAccessTokenRepo: func(ctx context.Context) *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("DeleteToken", ctx, testUserID, testTokenID).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			accessTokenService := NewAccessTokenService(tc.setupMockAccessTokenRepo(ctx), nil)
+
+			err := accessTokenService.RevokeToken(ctx, testUserID, testTokenID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package accesstoken
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IsAccessToken reports whether the claims were issued for a personal access token rather than a login.
+//
+// Parameters:
+//   - claims: The claims set by the authentication middleware.
+//
+// Returns:
+//   - bool: true if a personal access token authenticated the request.
+func IsAccessToken(claims jwt.MapClaims) bool {
+	_, ok := claims[TokenIDClaim]
+	return ok
+}
+
+// HasScope reports whether the claims allow an operation requiring the scope.
+// Login tokens are not scoped and allow every operation.
+//
+// Parameters:
+//   - claims: The claims set by the authentication middleware.
+//   - scope: The scope required by the operation.
+//
+// Returns:
+//   - bool: true if the operation is allowed.
+func HasScope(claims jwt.MapClaims, scope string) bool {
+	if !IsAccessToken(claims) {
+		return true
+	}
+
+	granted, _ := claims[ScopeClaim].(string)
+	return slices.Contains(strings.Fields(granted), scope)
+}
@@ -0,0 +1,58 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasScope(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputClaims jwt.MapClaims
+		inputScope  string
+
+		expectedOutput bool
+	}{
+		{
+			name:        "Login token allows every scope",
+			inputClaims: jwt.MapClaims{"sub": testUserID},
+			inputScope:  ScopeProfileWrite,
+
+			expectedOutput: true,
+		},
+		{
+			name:        "Access token granted the scope",
+			inputClaims: jwt.MapClaims{"sub": testUserID, "token_id": testTokenID, "scope": "profile:read profile:write"},
+			inputScope:  ScopeProfileWrite,
+
+			expectedOutput: true,
+		},
+		{
+			name:        "Access token missing the scope",
+			inputClaims: jwt.MapClaims{"sub": testUserID, "token_id": testTokenID, "scope": "profile:read"},
+			inputScope:  ScopeProfileWrite,
+
+			expectedOutput: false,
+		},
+		{
+			name:        "Access token without any scope",
+			inputClaims: jwt.MapClaims{"sub": testUserID, "token_id": testTokenID},
+			inputScope:  ScopeProfileRead,
+
+			expectedOutput: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedOutput, HasScope(tc.inputClaims, tc.inputScope))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package accesstoken
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// tokenValidator lets the JWT authentication middleware accept personal access tokens.
+type tokenValidator struct {
+	accessTokenSvc Service
+	jwtValidator   jwtutils.JWTValidator
+}
+
+// NewTokenValidator wraps a JWT validator so that tokens carrying TokenPrefix are
+// authenticated as personal access tokens, and every other token as a JWT.
+//
+// Parameters:
+//   - accessTokenSvc: The service authenticating personal access tokens.
+//   - jwtValidator: The validator used for every other token.
+//
+// Returns:
+//   - jwtutils.JWTValidator: A validator accepting both kinds of tokens.
+func NewTokenValidator(accessTokenSvc Service, jwtValidator jwtutils.JWTValidator) jwtutils.JWTValidator {
+	return &tokenValidator{
+		accessTokenSvc: accessTokenSvc,
+		jwtValidator:   jwtValidator,
+	}
+}
+
+// ValidateToken validates a bearer token of either kind and returns its claims.
+// The middleware does not hand over the request context, so personal access tokens are looked up across every
+// tenant; their claims carry the tenant of the token.
+//
+// Parameters:
+//   - tokenString: The bearer token taken from the request.
+//
+// Returns:
+//   - jwt.MapClaims: The claims of the token.
+//   - error: An error if the token is not valid.
+func (v *tokenValidator) ValidateToken(tokenString string) (jwt.MapClaims, error) {
+	if strings.HasPrefix(tokenString, TokenPrefix) {
+		return v.accessTokenSvc.Authenticate(tenant.WithAllTenants(context.Background()), tokenString)
+	}
+
+	return v.jwtValidator.ValidateToken(tokenString)
+}
@@ -0,0 +1,94 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	jwtMocks "github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockAccessTokenRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/accesstoken/mocks"
+)
+
+func TestTokenValidator_ValidateToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputToken string
+
+		setupMockAccessTokenRepo func() *mockAccessTokenRepo.Repository
+		setupMockJWTValidator    func() *jwtMocks.JWTValidator
+
+		expectedOutput jwt.MapClaims
+		expectedError  error
+	}{
+		{
+			name:       "Personal access token of any tenant is authenticated by the service",
+			inputToken: TokenPrefix + testSecret,
+
+			setupMockAccessTokenRepo: func() *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", mock.Anything, hashToken(TokenPrefix+testSecret)).Return(&model.PersonalAccessToken{
+					Base:     model.Base{ID: testTokenID},
+					TenantID: "brand_a",
+					UserID:   testUserID,
+					Scopes:   []string{ScopeProfileRead},
+				}, nil)
+				repoMock.On("UpdateTokenLastUsed", mock.Anything, testTokenID, mock.AnythingOfType("time.Time")).Return(nil)
+				return repoMock
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				return jwtMocks.NewJWTValidator(t)
+			},
+
+			expectedOutput: jwt.MapClaims{"sub": testUserID, "scope": "profile:read", "token_id": testTokenID, "tenant_id": "brand_a"},
+		},
+		{
+			name:       "Any other token is validated as a JWT",
+			inputToken: "valid_jwt_token",
+
+			setupMockAccessTokenRepo: func() *mockAccessTokenRepo.Repository {
+				return mockAccessTokenRepo.NewRepository(t)
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				validatorMock := jwtMocks.NewJWTValidator(t)
+				validatorMock.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": testUserID}, nil)
+				return validatorMock
+			},
+
+			expectedOutput: jwt.MapClaims{"sub": testUserID},
+		},
+		{
+			name:       "Invalid personal access token",
+			inputToken: TokenPrefix + "unknown",
+
+			setupMockAccessTokenRepo: func() *mockAccessTokenRepo.Repository {
+				repoMock := mockAccessTokenRepo.NewRepository(t)
+				repoMock.On("GetTokenByHash", mock.Anything, hashToken(TokenPrefix+"unknown")).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				return jwtMocks.NewJWTValidator(t)
+			},
+
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			accessTokenService := NewAccessTokenService(tc.setupMockAccessTokenRepo(), nil)
+			validator := NewTokenValidator(accessTokenService, tc.setupMockJWTValidator())
+
+			res, err := validator.ValidateToken(tc.inputToken)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
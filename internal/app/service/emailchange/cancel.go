@@ -0,0 +1,79 @@
+package emailchange
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// Cancel cancels an email change from the token of the link sent to the old address.
+// A pending change is simply cancelled. A confirmed change is reverted to the old address, unless the user changed
+// their address again since, in which case the link no longer applies.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - token: The token carried by the cancel link.
+//
+// Returns:
+//   - error: ErrInvalidEmailChangeToken if the link is unknown, used or expired, or the email address changed
+//     again since, otherwise any update error.
+func (svc *emailChangeService) Cancel(ctx context.Context, token string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CancelEmailChange")
+	defer s.End()
+
+	change, err := svc.emailChangeRepo.GetEmailChangeByCancelTokenHash(ctx, hashToken(token))
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if now.After(change.CancelExpiresAt) {
+		return ErrInvalidEmailChangeToken
+	}
+
+	switch change.Status {
+	case model.EmailChangePending:
+		err = svc.emailChangeRepo.UpdateEmailChangeStatus(ctx, change.ID, model.EmailChangePending, &model.EmailChange{
+			Status:      model.EmailChangeCancelled,
+			CancelledAt: &now,
+		})
+		if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+			return ErrInvalidEmailChangeToken
+		}
+		return err
+	case model.EmailChangeConfirmed:
+		return svc.revert(ctx, change, now)
+	default:
+		return ErrInvalidEmailChangeToken
+	}
+}
+
+// revert cancels a confirmed email change and puts the old address back, verified by the use of the cancel link,
+// in a single transaction.
+func (svc *emailChangeService) revert(ctx context.Context, change *model.EmailChange, now time.Time) error {
+	user, err := svc.userRepo.GetUserByID(ctx, change.UserID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Email != change.NewEmail {
+		return ErrInvalidEmailChangeToken
+	}
+
+	err = svc.emailChangeRepo.RevertEmailChange(ctx, change, now)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvalidEmailChangeToken
+	}
+
+	return err
+}
@@ -0,0 +1,196 @@
+package emailchange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockEmailChangeRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/emailchange/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func TestService_Cancel(t *testing.T) {
+	t.Parallel()
+
+	confirmedAt := time.Now().Add(-time.Hour)
+	pendingChange := &model.EmailChange{
+		Base:            model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"},
+		UserID:          testUser.ID,
+		OldEmail:        "testuser001@example.com",
+		NewEmail:        "testuser001new@example.com",
+		Status:          model.EmailChangePending,
+		CancelExpiresAt: time.Now().Add(time.Hour),
+	}
+	confirmedChange := &model.EmailChange{
+		Base:            model.Base{ID: "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03"},
+		UserID:          testUser.ID,
+		OldEmail:        "testuser001old@example.com",
+		NewEmail:        "testuser001@example.com",
+		Status:          model.EmailChangeConfirmed,
+		CancelExpiresAt: time.Now().Add(time.Hour),
+		ConfirmedAt:     &confirmedAt,
+	}
+	isCancelled := mock.MatchedBy(func(change *model.EmailChange) bool {
+		return change.Status == model.EmailChangeCancelled && change.CancelledAt != nil
+	})
+
+	testCases := []struct {
+		name string
+
+		setupMockEmailChangeRepo func(ctx context.Context) *mockEmailChangeRepo.Repository
+		setupMockUserRepo        func(ctx context.Context) *mockUserRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Cancel pending change successfully",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(pendingChange, nil)
+				repoMock.On("UpdateEmailChangeStatus", ctx, pendingChange.ID, model.EmailChangePending, isCancelled).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+		},
+		{
+			name: "Revert confirmed change successfully",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(confirmedChange, nil)
+				repoMock.On("RevertEmailChange", ctx, confirmedChange, mock.AnythingOfType("time.Time")).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Cancel change failed - unknown token",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Cancel change failed - window closed",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(&model.EmailChange{
+					Status:          model.EmailChangeConfirmed,
+					CancelExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Cancel change failed - already cancelled",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(&model.EmailChange{
+					Status:          model.EmailChangeCancelled,
+					CancelExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Revert confirmed change failed - email changed again since",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(confirmedChange, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(&model.User{Email: "testuser001latest@example.com"}, nil)
+				return repoMock
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Revert confirmed change failed - cancelled meanwhile",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(confirmedChange, nil)
+				repoMock.On("RevertEmailChange", ctx, confirmedChange, mock.AnythingOfType("time.Time")).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Revert confirmed change failed - old email taken meanwhile",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByCancelTokenHash", ctx, hashToken("cancel-001")).Return(confirmedChange, nil)
+				repoMock.On("RevertEmailChange", ctx, confirmedChange, mock.AnythingOfType("time.Time")).Return(dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewEmailChangeService(
+				tc.setupMockEmailChangeRepo(ctx),
+				tc.setupMockUserRepo(ctx),
+				nil,
+				nil,
+				nil,
+				testConfirmURL,
+				testCancelURL,
+			)
+
+			err := testSvc.Cancel(ctx, "cancel-001")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package emailchange
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// Confirm applies a pending email change from the token of its confirmation link.
+// The change is claimed in the transaction writing the address, so a concurrent cancel either wins or reverts it,
+// and the change stays pending if the address cannot be written.
+// The new address is recorded as verified, since the link was sent to it.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - token: The token carried by the confirmation link.
+//
+// Returns:
+//   - error: ErrInvalidEmailChangeToken if the link is unknown, used, superseded or expired,
+//     dbutils.ErrDuplicationType if another user took the address meanwhile, otherwise any update error.
+func (svc *emailChangeService) Confirm(ctx context.Context, token string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ConfirmEmailChange")
+	defer s.End()
+
+	change, err := svc.emailChangeRepo.GetEmailChangeByConfirmTokenHash(ctx, hashToken(token))
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if change.Status != model.EmailChangePending || now.After(change.ExpiresAt) {
+		return ErrInvalidEmailChangeToken
+	}
+
+	err = svc.emailChangeRepo.ConfirmEmailChange(ctx, change, now)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvalidEmailChangeToken
+	}
+
+	return err
+}
@@ -0,0 +1,148 @@
+package emailchange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockEmailChangeRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/emailchange/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func TestService_Confirm(t *testing.T) {
+	t.Parallel()
+
+	pendingChange := &model.EmailChange{
+		Base:      model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"},
+		UserID:    testUser.ID,
+		OldEmail:  "testuser001@example.com",
+		NewEmail:  "testuser001new@example.com",
+		Status:    model.EmailChangePending,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockEmailChangeRepo func(ctx context.Context) *mockEmailChangeRepo.Repository
+		setupMockUserRepo        func(ctx context.Context) *mockUserRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Confirm change successfully",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByConfirmTokenHash", ctx, hashToken("confirm-001")).Return(pendingChange, nil)
+				repoMock.On("ConfirmEmailChange", ctx, pendingChange, mock.AnythingOfType("time.Time")).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+		},
+		{
+			name: "Confirm change failed - unknown token",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByConfirmTokenHash", ctx, hashToken("confirm-001")).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Confirm change failed - superseded",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByConfirmTokenHash", ctx, hashToken("confirm-001")).Return(&model.EmailChange{
+					Status:    model.EmailChangeSuperseded,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Confirm change failed - expired",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByConfirmTokenHash", ctx, hashToken("confirm-001")).Return(&model.EmailChange{
+					Status:    model.EmailChangePending,
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Confirm change failed - cancelled meanwhile",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByConfirmTokenHash", ctx, hashToken("confirm-001")).Return(pendingChange, nil)
+				repoMock.On("ConfirmEmailChange", ctx, pendingChange, mock.AnythingOfType("time.Time")).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidEmailChangeToken,
+		},
+		{
+			name: "Confirm change failed - email taken meanwhile",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("GetEmailChangeByConfirmTokenHash", ctx, hashToken("confirm-001")).Return(pendingChange, nil)
+				repoMock.On("ConfirmEmailChange", ctx, pendingChange, mock.AnythingOfType("time.Time")).Return(dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewEmailChangeService(
+				tc.setupMockEmailChangeRepo(ctx),
+				tc.setupMockUserRepo(ctx),
+				nil,
+				nil,
+				nil,
+				testConfirmURL,
+				testCancelURL,
+			)
+
+			err := testSvc.Confirm(ctx, "confirm-001")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package emailchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+// RequestChange records a pending change of the email address of a user and sends both links.
+// If a link cannot be sent, the pending change is deleted so that no change goes on without both addresses told.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user changing their email address.
+//   - newEmail: The requested email address.
+//
+// Returns:
+//   - error: An error if the change cannot be stored or the emails cannot be sent, otherwise nil.
+func (svc *emailChangeService) RequestChange(ctx context.Context, user *model.User, newEmail string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RequestEmailChange")
+	defer s.End()
+
+	confirmToken, err := svc.codeGen.GenerateCode(tokenLength)
+	if err != nil {
+		return err
+	}
+
+	cancelToken, err := svc.codeGen.GenerateCode(tokenLength)
+	if err != nil {
+		return err
+	}
+
+	confirmLink, err := buildLink(svc.confirmURL, confirmToken)
+	if err != nil {
+		return err
+	}
+
+	cancelLink, err := buildLink(svc.cancelURL, cancelToken)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	change, err := svc.emailChangeRepo.CreateEmailChange(ctx, &model.EmailChange{
+		UserID:           user.ID,
+		OldEmail:         user.Email,
+		NewEmail:         newEmail,
+		ConfirmTokenHash: hashToken(confirmToken),
+		CancelTokenHash:  hashToken(cancelToken),
+		Status:           model.EmailChangePending,
+		ExpiresAt:        now.Add(ConfirmationExpiration),
+		CancelExpiresAt:  now.Add(CancelWindow),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = svc.send(ctx, user, change, newEmail, confirmLink, cancelLink)
+	if err != nil {
+		if deleteErr := svc.emailChangeRepo.DeleteEmailChange(ctx, change.ID); deleteErr != nil {
+			log.Error().Str("operation", "Service_RequestEmailChange").Str("email_change_id", change.ID).Err(deleteErr).Msg("failed to delete the unsent email change")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// send sends the confirmation link to the new address and the cancel link to the current one.
+func (svc *emailChangeService) send(ctx context.Context, user *model.User, change *model.EmailChange, newEmail, confirmLink, cancelLink string) error {
+	err := svc.mailer.Send(ctx, &mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nConfirm this address as the new email address of your account with the link below. It expires in %d hours.\n\n%s\n\nIf you did not ask for it, you can ignore this email.\n",
+			user.DisplayName, int(ConfirmationExpiration.Hours()), confirmLink,
+		),
+	})
+	if err != nil {
+		return err
+	}
+
+	return svc.notifier.NotifyEmailChange(ctx, user, change, cancelLink)
+}
@@ -0,0 +1,159 @@
+package emailchange
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockEmailChangeRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/emailchange/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	mockMailer "github.com/vukieuhaihoa/user-service/internal/mailer/mocks"
+	mockNotifier "github.com/vukieuhaihoa/user-service/internal/notifier/mocks"
+)
+
+const (
+	testConfirmURL = "https://app.example.com/email-change/confirm"
+	testCancelURL  = "https://app.example.com/email-change/cancel"
+)
+
+var testUser = &model.User{
+	Base:        model.Base{ID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+	Username:    "testuser001",
+	DisplayName: "Test User 1",
+	Email:       "testuser001@example.com",
+}
+
+func TestService_RequestChange(t *testing.T) {
+	t.Parallel()
+
+	isPendingChange := mock.MatchedBy(func(change *model.EmailChange) bool {
+		return change.UserID == testUser.ID &&
+			change.OldEmail == "testuser001@example.com" &&
+			change.NewEmail == "testuser001new@example.com" &&
+			change.ConfirmTokenHash == hashToken("confirm-001") &&
+			change.CancelTokenHash == hashToken("cancel-001") &&
+			change.Status == model.EmailChangePending &&
+			change.CancelExpiresAt.Sub(change.ExpiresAt) == CancelWindow-ConfirmationExpiration
+	})
+
+	testCases := []struct {
+		name string
+
+		setupMockEmailChangeRepo func(ctx context.Context) *mockEmailChangeRepo.Repository
+		setupMockMailer          func(ctx context.Context) *mockMailer.Mailer
+		setupMockNotifier        func(ctx context.Context) *mockNotifier.Notifier
+
+		expectedError error
+	}{
+		{
+			name: "Request change successfully",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("CreateEmailChange", ctx, isPendingChange).Return(&model.EmailChange{NewEmail: "testuser001new@example.com"}, nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.To == "testuser001new@example.com" &&
+						msg.Subject == "Confirm your new email address" &&
+						strings.Contains(msg.Body, testConfirmURL+"?token=confirm-001")
+				})).Return(nil)
+				return mailerMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				notifierMock := mockNotifier.NewNotifier(t)
+				notifierMock.On("NotifyEmailChange", ctx, testUser, &model.EmailChange{NewEmail: "testuser001new@example.com"}, testCancelURL+"?token=cancel-001").Return(nil)
+				return notifierMock
+			},
+		},
+		{
+			name: "Request change failed - repository error",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("CreateEmailChange", ctx, isPendingChange).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Request change failed - mailer error deletes the change",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("CreateEmailChange", ctx, isPendingChange).Return(&model.EmailChange{Base: model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"}}, nil)
+				repoMock.On("DeleteEmailChange", ctx, "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01").Return(nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(assert.AnError)
+				return mailerMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Request change failed - notifier error deletes the change",
+
+			setupMockEmailChangeRepo: func(ctx context.Context) *mockEmailChangeRepo.Repository {
+				repoMock := mockEmailChangeRepo.NewRepository(t)
+				repoMock.On("CreateEmailChange", ctx, isPendingChange).Return(&model.EmailChange{Base: model.Base{ID: "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01"}}, nil)
+				repoMock.On("DeleteEmailChange", ctx, "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01").Return(assert.AnError)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(nil)
+				return mailerMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				notifierMock := mockNotifier.NewNotifier(t)
+				notifierMock.On("NotifyEmailChange", ctx, testUser, mock.Anything, testCancelURL+"?token=cancel-001").Return(assert.AnError)
+				return notifierMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			codeGenMock := mockUtils.NewCodeGenerator(t)
+			codeGenMock.On("GenerateCode", tokenLength).Return("confirm-001", nil).Once()
+			codeGenMock.On("GenerateCode", tokenLength).Return("cancel-001", nil).Once()
+
+			testSvc := NewEmailChangeService(
+				tc.setupMockEmailChangeRepo(ctx),
+				nil,
+				codeGenMock,
+				tc.setupMockMailer(ctx),
+				tc.setupMockNotifier(ctx),
+				testConfirmURL,
+				testCancelURL,
+			)
+
+			err := testSvc.RequestChange(ctx, testUser, "testuser001new@example.com")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package emailchange provides the confirmation flow of email address changes.
+// A new address only replaces the current one once confirmed from a link sent to it,
+// and the current address is told about the change with a link cancelling it, which
+// also reverts the change for a while after it was confirmed.
+package emailchange
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	emailChangeRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/emailchange"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+)
+
+const (
+	// ConfirmationExpiration is how long the link sent to the new address works.
+	ConfirmationExpiration = 24 * time.Hour
+
+	// CancelWindow is how long after the request the link sent to the old address works.
+	CancelWindow = 72 * time.Hour
+
+	tokenLength = 32
+)
+
+var ErrInvalidEmailChangeToken = errors.New("invalid or expired email change link")
+
+// Service represents the interface for email change operations.
+//
+//go:generate mockery --name=Service --filename=email_change_service.go --output=./mocks
+type Service interface {
+	// RequestChange records a pending change of the email address of a user, sends the confirmation link to the
+	// new address and the notice with the cancel link to the current one.
+	// A previous pending change of the user is superseded.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user changing their email address.
+	//   - newEmail: The requested email address.
+	//
+	// Returns:
+	//   - error: An error if the change cannot be stored or the emails cannot be sent, otherwise nil.
+	RequestChange(ctx context.Context, user *model.User, newEmail string) error
+
+	// Confirm applies a pending email change from the token of its confirmation link.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - token: The token carried by the confirmation link.
+	//
+	// Returns:
+	//   - error: ErrInvalidEmailChangeToken if the link is unknown, used, superseded or expired,
+	//     dbutils.ErrDuplicationType if another user took the address meanwhile, otherwise any update error.
+	Confirm(ctx context.Context, token string) error
+
+	// Cancel cancels an email change from the token of the link sent to the old address.
+	// A change already confirmed is reverted to the old address.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - token: The token carried by the cancel link.
+	//
+	// Returns:
+	//   - error: ErrInvalidEmailChangeToken if the link is unknown, used or expired, or the email address changed
+	//     again since, otherwise any update error.
+	Cancel(ctx context.Context, token string) error
+}
+
+// emailChangeService is the concrete implementation of the Service interface.
+type emailChangeService struct {
+	emailChangeRepo emailChangeRepository.Repository
+	userRepo        userRepository.Repository
+	codeGen         utils.CodeGenerator
+	mailer          mailer.Mailer
+	notifier        notifier.Notifier
+	confirmURL      string
+	cancelURL       string
+}
+
+// NewEmailChangeService creates a new instance of the email change service.
+//
+// Parameters:
+//   - emailChangeRepo: The repository storing the email changes.
+//   - userRepo: The user repository applying the changes.
+//   - codeGen: The random code generator used for the link tokens.
+//   - mailer: The mailer delivering the confirmation links.
+//   - notifier: The notifier telling the old address about the change.
+//   - confirmURL: The frontend page confirmation links point to; the token is added as the "token" query parameter.
+//   - cancelURL: The frontend page cancel links point to; the token is added as the "token" query parameter.
+//
+// Returns:
+//   - Service: A new email change service instance.
+func NewEmailChangeService(
+	emailChangeRepo emailChangeRepository.Repository,
+	userRepo userRepository.Repository,
+	codeGen utils.CodeGenerator,
+	mailer mailer.Mailer,
+	notifier notifier.Notifier,
+	confirmURL string,
+	cancelURL string,
+) Service {
+	return &emailChangeService{
+		emailChangeRepo: emailChangeRepo,
+		userRepo:        userRepo,
+		codeGen:         codeGen,
+		mailer:          mailer,
+		notifier:        notifier,
+		confirmURL:      confirmURL,
+		cancelURL:       cancelURL,
+	}
+}
+
+// hashToken returns the hex SHA-256 of a link token, so the token itself is never stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// buildLink adds the token to a configured link URL.
+func buildLink(linkURL, token string) (string, error) {
+	u, err := url.Parse(linkURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
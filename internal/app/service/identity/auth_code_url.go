@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// AuthCodeURL starts an authorization-code flow against the named provider.
+// It generates the state, nonce and PKCE verifier, stores them until the callback
+// and returns the provider URL the user agent must be redirected to.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - provider: The name of the configured provider.
+//
+// Returns:
+//   - string: The provider URL the user agent must be redirected to.
+//   - error: ErrUnknownProvider if the provider is not configured, otherwise any storage or provider error.
+func (i *identityService) AuthCodeURL(ctx context.Context, provider string) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_AuthCodeURL")
+	defer s.End()
+
+	return i.startFlow(ctx, provider, "")
+}
+
+// startFlow generates the state, nonce and PKCE verifier of a new authorization-code flow,
+// stores them with the optional linking user and returns the provider authorization URL.
+func (i *identityService) startFlow(ctx context.Context, provider, userID string) (string, error) {
+	p, ok := i.providers[provider]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	state, err := i.codeGen.GenerateCode(stateLength)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := i.codeGen.GenerateCode(nonceLength)
+	if err != nil {
+		return "", err
+	}
+
+	codeVerifier, err := i.codeGen.GenerateCode(codeVerifierLength)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, codeChallengeS256(codeVerifier))
+	if err != nil {
+		return "", err
+	}
+
+	err = i.identityRepo.SaveAuthState(ctx, state, &model.AuthState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		UserID:       userID,
+	}, AuthStateExpiration)
+	if err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+// codeChallengeS256 derives the PKCE S256 code challenge from a code verifier.
+func codeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
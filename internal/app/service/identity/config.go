@@ -0,0 +1,36 @@
+package identity
+
+import (
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// ProviderConfig holds the settings of one upstream OpenID Connect provider.
+// Each provider is configured with environment variables prefixed by OIDC_<NAME>,
+// e.g. OIDC_GOOGLE_ISSUER for a provider named "google".
+type ProviderConfig struct {
+	Issuer       string   `envconfig:"ISSUER" required:"true"`
+	ClientID     string   `envconfig:"CLIENT_ID" required:"true"`
+	ClientSecret string   `envconfig:"CLIENT_SECRET"`
+	RedirectURL  string   `envconfig:"REDIRECT_URL" required:"true"`
+	Scopes       []string `envconfig:"SCOPES" default:"openid,email,profile"`
+}
+
+// NewProviderConfig loads the configuration of the named provider from the environment.
+//
+// Parameters:
+//   - name: The provider name used in the environment variable prefix and in the login URLs
+//
+// Returns:
+//   - *ProviderConfig: The loaded provider configuration
+//   - error: An error if a required variable is missing, otherwise nil
+func NewProviderConfig(name string) (*ProviderConfig, error) {
+	cfg := &ProviderConfig{}
+	err := envconfig.Process("OIDC_"+strings.ToUpper(name), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,24 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListIdentities retrieves the identities linked to a user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - []*model.UserIdentity: The linked identities, oldest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (i *identityService) ListIdentities(ctx context.Context, userID string) ([]*model.UserIdentity, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListIdentities")
+	defer s.End()
+
+	return i.identityRepo.ListIdentitiesByUserID(ctx, userID)
+}
@@ -0,0 +1,61 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockIdentityRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/identity/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+func TestService_ListIdentities(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockIdentityRepo func(ctx context.Context) *mockIdentityRepo.Repository
+
+		expectedOutput []*model.UserIdentity
+		expectedError  error
+	}{
+		{
+			name: "List identities successfully",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ListIdentitiesByUserID", ctx, testUser.ID).
+					Return([]*model.UserIdentity{{Provider: "mockidp", Subject: "subject-001"}}, nil)
+				return repoMock
+			},
+
+			expectedOutput: []*model.UserIdentity{{Provider: "mockidp", Subject: "subject-001"}},
+		},
+		{
+			name: "List identities failed - repository error",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ListIdentitiesByUserID", ctx, testUser.ID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			identityService := NewIdentityService(tc.setupMockIdentityRepo(ctx), nil, nil, nil, nil, registration.Policy{}, nil, nil)
+
+			res, err := identityService.ListIdentities(ctx, testUser.ID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// Login completes an authorization-code flow and returns a token for the linked local user.
+// A flow started by StartLink links the external subject to the user who started it.
+// Otherwise the external subject is resolved in this order:
+//  1. an identity already linked to the subject,
+//  2. a local user owning the same email, if both the provider and the local user verified that email,
+//  3. a newly provisioned user without a password, if the registration and email policies let the email register.
+//
+// The provider must share an email address for the last two steps.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - provider: The name of the configured provider.
+//   - code: The authorization code returned by the provider.
+//   - state: The state value returned by the provider.
+//
+// Returns:
+//   - string: The JWT token if authentication is successful.
+//   - error: An error of the registration policy if a new user cannot be provisioned, an error of the email policy
+//     if the email address is rejected, otherwise an error if the flow cannot be completed.
+func (i *identityService) Login(ctx context.Context, provider, code, state string) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_FederatedLogin")
+	defer s.End()
+
+	p, ok := i.providers[provider]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	authState, err := i.identityRepo.ConsumeAuthState(ctx, state)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return "", ErrInvalidAuthState
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if authState.Provider != provider {
+		return "", ErrInvalidAuthState
+	}
+
+	claims, err := p.Exchange(ctx, code, authState.CodeVerifier)
+	if err != nil {
+		return "", err
+	}
+
+	if claims.Nonce != authState.Nonce {
+		return "", ErrInvalidAuthState
+	}
+
+	var user *model.User
+	if authState.UserID != "" {
+		user, err = i.linkUser(ctx, authState.UserID, provider, claims)
+	} else {
+		user, err = i.resolveUser(ctx, provider, claims)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return i.userSvc.IssueToken(ctx, user)
+}
+
+// linkUser links the provider subject to the user who started the flow.
+// Linking a subject the user already owns is a no-op, one owned by another user is refused.
+func (i *identityService) linkUser(ctx context.Context, userID, provider string, claims *Claims) (*model.User, error) {
+	identity, err := i.identityRepo.GetIdentityByProviderSubject(ctx, provider, claims.Subject)
+	switch {
+	case err == nil && identity.UserID != userID:
+		return nil, ErrIdentityAlreadyLinked
+	case err == nil:
+		return i.userRepo.GetUserByID(ctx, userID)
+	case !errors.Is(err, dbutils.ErrRecordNotFoundType):
+		return nil, err
+	}
+
+	user, err := i.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = i.identityRepo.CreateIdentity(ctx, &model.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if errors.Is(err, dbutils.ErrDuplicationType) {
+		// Another request linked the same subject in the meantime.
+		return nil, ErrIdentityAlreadyLinked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// resolveUser finds or provisions the local user for the given provider claims.
+func (i *identityService) resolveUser(ctx context.Context, provider string, claims *Claims) (*model.User, error) {
+	identity, err := i.identityRepo.GetIdentityByProviderSubject(ctx, provider, claims.Subject)
+	switch {
+	case err == nil:
+		return i.userRepo.GetUserByID(ctx, identity.UserID)
+	case !errors.Is(err, dbutils.ErrRecordNotFoundType):
+		return nil, err
+	}
+
+	newIdentity := &model.UserIdentity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	// The email is needed both to find an existing account and to provision a new one.
+	if claims.Email == "" {
+		return nil, ErrProviderEmailMissing
+	}
+
+	existingUser, err := i.userRepo.GetUserByEmail(ctx, claims.Email)
+	switch {
+	case err == nil && claims.EmailVerified && existingUser.EmailVerifiedAt != nil:
+		newIdentity.UserID = existingUser.ID
+		if _, err := i.identityRepo.CreateIdentity(ctx, newIdentity); err != nil {
+			return nil, err
+		}
+		return existingUser, nil
+	case err == nil:
+		// An unverified email must never grant access to the account that owns it, and an account whose owner
+		// never proved the address may have been registered by someone else ahead of its owner.
+		return nil, ErrIdentityEmailConflict
+	case !errors.Is(err, dbutils.ErrRecordNotFoundType):
+		return nil, err
+	}
+
+	err = i.registration.Check(claims.Email, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.emailPolicy.Check(ctx, claims.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := i.availableUsername(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := claims.Name
+	if displayName == "" {
+		displayName = username
+	}
+
+	// Federated users have no password until they set one, so password login stays impossible.
+	newUser := &model.User{
+		Username:    username,
+		DisplayName: displayName,
+		Email:       claims.Email,
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		newUser.EmailVerifiedAt = &now
+	}
+
+	return i.identityRepo.CreateUserWithIdentity(ctx, newUser, newIdentity)
+}
+
+// availableUsername derives a username from the provider claims. A taken username gets a random suffix, and a
+// username the username policy rejects, even once suffixed, is replaced by a generated one.
+func (i *identityService) availableUsername(ctx context.Context, claims *Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	if i.usernamePolicy.Check(base) == nil {
+		_, err := i.userRepo.GetUserByUsername(ctx, base)
+		switch {
+		case errors.Is(err, dbutils.ErrRecordNotFoundType):
+			return base, nil
+		case err != nil:
+			return "", err
+		}
+	}
+
+	suffix, err := i.codeGen.GenerateCode(usernameSuffixLen)
+	if err != nil {
+		return "", err
+	}
+
+	if username := base + "_" + suffix; i.usernamePolicy.Check(username) == nil {
+		return username, nil
+	}
+
+	return generatedUsernamePrefix + suffix, nil
+}
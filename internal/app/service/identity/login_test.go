@@ -0,0 +1,690 @@
+package identity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockIdentityRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/identity/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockUserSvc "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	usernamePolicyService "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	mockEmailPolicy "github.com/vukieuhaihoa/user-service/internal/emailpolicy/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+var testAuthState = &model.AuthState{
+	Provider:     "mockidp",
+	Nonce:        "nonce-001",
+	CodeVerifier: "verifier-001",
+}
+
+var testLinkAuthState = &model.AuthState{
+	Provider:     "mockidp",
+	Nonce:        "nonce-001",
+	CodeVerifier: "verifier-001",
+	UserID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+}
+
+// testUsernamePolicy reserves "admin" on top of the default length and characters.
+var testUsernamePolicy, _ = usernamePolicyService.NewPolicy(&usernamePolicyService.Config{
+	MinLength:      3,
+	MaxLength:      32,
+	AllowedPattern: `^[\p{L}\p{N}_.-]+$`,
+	Reserved:       []string{"admin"},
+})
+
+var testUser = &model.User{
+	Base:     model.Base{ID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+	Username: "testuser001",
+	Email:    "testuser001@example.com",
+}
+
+var testVerifiedUser = &model.User{
+	Base:            model.Base{ID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+	Username:        "testuser001",
+	Email:           "testuser001@example.com",
+	EmailVerifiedAt: &time.Time{},
+}
+
+func TestService_Login(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockIdentityRepo func(ctx context.Context) *mockIdentityRepo.Repository
+		setupMockUserRepo     func(ctx context.Context) *mockUserRepo.Repository
+		setupMockUserSvc      func(ctx context.Context) *mockUserSvc.Service
+		setupMockProvider     func(ctx context.Context) *mockProvider
+		setupMockCodeGen      func() *mockUtils.CodeGenerator
+		setupMockEmailPolicy  func(ctx context.Context) *mockEmailPolicy.Checker
+		registrationPolicy    registration.Policy
+
+		inputProvider string
+
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name: "Login with an already linked identity",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-001").
+					Return(&model.UserIdentity{UserID: testUser.ID}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-001", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Login links the identity to the user owning the verified email",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-002").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("CreateIdentity", ctx, &model.UserIdentity{
+					UserID:   testUser.ID,
+					Provider: "mockidp",
+					Subject:  "subject-002",
+					Email:    testUser.Email,
+				}).Return(&model.UserIdentity{}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, testUser.Email).Return(testVerifiedUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testVerifiedUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-002", Email: testUser.Email, EmailVerified: true, Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Login refuses to link a verified email to a user who never verified it",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-002").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, testUser.Email).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-002", Email: testUser.Email, EmailVerified: true, Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrIdentityEmailConflict,
+		},
+		{
+			name: "Login refuses to link an unverified email owned by another user",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-002").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, testUser.Email).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-002", Email: testUser.Email, Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrIdentityEmailConflict,
+		},
+		{
+			name: "Login provisions a new user with a suffixed username",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-003").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("CreateUserWithIdentity", ctx, &model.User{
+					Username:    "testuser001_a1b2c3",
+					DisplayName: "testuser001_a1b2c3",
+					Email:       "testuser001@other.example.com",
+				}, &model.UserIdentity{
+					Provider: "mockidp",
+					Subject:  "subject-003",
+					Email:    "testuser001@other.example.com",
+				}).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "testuser001@other.example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("GetUserByUsername", ctx, "testuser001").Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-003", Email: "testuser001@other.example.com", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", usernameSuffixLen).Return("a1b2c3", nil)
+				return codeGenMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "testuser001@other.example.com").Return(nil)
+				return policyMock
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Login provisions a new user with a suffixed username when the provider one is reserved",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-007").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("CreateUserWithIdentity", ctx, &model.User{
+					Username:    "admin_a1b2c3",
+					DisplayName: "admin_a1b2c3",
+					Email:       "admin@other.example.com",
+				}, &model.UserIdentity{
+					Provider: "mockidp",
+					Subject:  "subject-007",
+					Email:    "admin@other.example.com",
+				}).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "admin@other.example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-007", Email: "admin@other.example.com", PreferredUsername: "admin", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", usernameSuffixLen).Return("a1b2c3", nil)
+				return codeGenMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "admin@other.example.com").Return(nil)
+				return policyMock
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Login provisions a new user with a generated username when the provider one is not allowed",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-008").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("CreateUserWithIdentity", ctx, &model.User{
+					Username:    "user_a1b2c3",
+					DisplayName: "user_a1b2c3",
+					Email:       "john.doe@other.example.com",
+				}, &model.UserIdentity{
+					Provider: "mockidp",
+					Subject:  "subject-008",
+					Email:    "john.doe@other.example.com",
+				}).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "john.doe@other.example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-008", Email: "john.doe@other.example.com", PreferredUsername: "John Doe", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", usernameSuffixLen).Return("a1b2c3", nil)
+				return codeGenMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "john.doe@other.example.com").Return(nil)
+				return policyMock
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Login provisions a new user whose email the provider verified",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-009").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("CreateUserWithIdentity", ctx, mock.MatchedBy(func(user *model.User) bool {
+					return user.Username == "jane" && user.Email == "jane@other.example.com" && user.EmailVerifiedAt != nil
+				}), &model.UserIdentity{
+					Provider: "mockidp",
+					Subject:  "subject-009",
+					Email:    "jane@other.example.com",
+				}).Return(testVerifiedUser, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "jane@other.example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("GetUserByUsername", ctx, "jane").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testVerifiedUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-009", Email: "jane@other.example.com", EmailVerified: true, Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "jane@other.example.com").Return(nil)
+				return policyMock
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Login failed - the email policy rejects the email",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-009").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "newcomer@mailinator.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-009", Email: "newcomer@mailinator.com", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "newcomer@mailinator.com").Return(emailpolicy.ErrDisposable)
+				return policyMock
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: emailpolicy.ErrDisposable,
+		},
+		{
+			name: "Login failed - the registration policy refuses to provision a new user",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-003").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "testuser001@other.example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-003", Email: "testuser001@other.example.com", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+			registrationPolicy: registration.Policy{Mode: registration.ModeAllowedDomains, AllowedDomains: []string{"example.org"}},
+
+			inputProvider: "mockidp",
+
+			expectedError: registration.ErrEmailDomainNotAllowed,
+		},
+		{
+			name: "Login failed - provider did not share an email",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-004").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-004", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrProviderEmailMissing,
+		},
+		{
+			name: "Link flow links a new identity to the user who started it",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testLinkAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-005").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("CreateIdentity", ctx, &model.UserIdentity{
+					UserID:   testUser.ID,
+					Provider: "mockidp",
+					Subject:  "subject-005",
+					Email:    "someone@else.example.com",
+				}).Return(&model.UserIdentity{}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-005", Email: "someone@else.example.com", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Link flow failed - identity linked to another user",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testLinkAuthState, nil)
+				repoMock.On("GetIdentityByProviderSubject", ctx, "mockidp", "subject-006").
+					Return(&model.UserIdentity{UserID: "de305d54-75b4-431b-adb2-eb6b9e546000"}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-006", Nonce: "nonce-001"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrIdentityAlreadyLinked,
+		},
+		{
+			name: "Login failed - nonce mismatch",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, "code-001", "verifier-001").
+					Return(&Claims{Subject: "subject-001", Nonce: "replayed-nonce"}, nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrInvalidAuthState,
+		},
+		{
+			name: "Login failed - unknown state",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				return newMockProvider(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrInvalidAuthState,
+		},
+		{
+			name: "Login failed - provider exchange error",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("ConsumeAuthState", ctx, "state-001").Return(testAuthState, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("Exchange", ctx, mock.Anything, mock.Anything).Return(nil, ErrInvalidIDToken)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+
+			expectedError: ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			providers := map[string]Provider{"mockidp": tc.setupMockProvider(ctx)}
+			emailPolicyMock := mockEmailPolicy.NewChecker(t)
+			if tc.setupMockEmailPolicy != nil {
+				emailPolicyMock = tc.setupMockEmailPolicy(ctx)
+			}
+
+			identityService := NewIdentityService(
+				tc.setupMockIdentityRepo(ctx),
+				tc.setupMockUserRepo(ctx),
+				tc.setupMockUserSvc(ctx),
+				tc.setupMockCodeGen(),
+				providers,
+				tc.registrationPolicy,
+				testUsernamePolicy,
+				emailPolicyMock,
+			)
+
+			res, err := identityService.Login(ctx, tc.inputProvider, "code-001", "state-001")
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
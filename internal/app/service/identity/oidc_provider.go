@@ -0,0 +1,295 @@
+package identity
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+var (
+	ErrProviderRequestFailed = errors.New("identity provider request failed")
+	ErrInvalidIDToken        = errors.New("invalid id token")
+)
+
+// discoveryDocument is the subset of the provider metadata used by the authorization-code flow.
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// jsonWebKey is an RSA public key published in the provider JWKS.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// oidcProvider implements Provider against a standard OpenID Connect provider.
+// The discovery document and the signing keys are fetched lazily and cached.
+type oidcProvider struct {
+	cfg        *ProviderConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]*rsa.PublicKey
+}
+
+// NewOIDCProvider creates a Provider for the given OpenID Connect configuration.
+//
+// Parameters:
+//   - cfg: The provider configuration
+//   - httpClient: The HTTP client used to talk to the provider
+//
+// Returns:
+//   - Provider: A new provider instance
+func NewOIDCProvider(cfg *ProviderConfig, httpClient *http.Client) Provider {
+	return &oidcProvider{
+		cfg:        cfg,
+		httpClient: httpClient,
+		keys:       map[string]*rsa.PublicKey{},
+	}
+}
+
+// AuthCodeURL builds the provider URL the user agent is sent to in order to authenticate.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - state: The opaque value echoed back by the provider on the callback.
+//   - nonce: The value the provider must embed in the ID token.
+//   - codeChallenge: The S256 PKCE challenge derived from the code verifier.
+//
+// Returns:
+//   - string: The authorization endpoint URL.
+//   - error: An error if the provider metadata cannot be fetched, otherwise nil.
+func (o *oidcProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	doc, err := o.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.cfg.ClientID},
+		"redirect_uri":          {o.cfg.RedirectURL},
+		"scope":                 {strings.Join(o.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return doc.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the claims of the verified ID token.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - code: The authorization code returned on the callback.
+//   - codeVerifier: The PKCE code verifier matching the challenge sent earlier.
+//
+// Returns:
+//   - *Claims: The claims of the ID token once its signature, issuer, audience and expiry are verified.
+//   - error: An error if the exchange or the verification fails, otherwise nil.
+func (o *oidcProvider) Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Provider_Exchange")
+	defer s.End()
+
+	doc, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {o.cfg.RedirectURL},
+		"client_id":     {o.cfg.ClientID},
+		"client_secret": {o.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	tokenResp := &struct {
+		IDToken string `json:"id_token"`
+	}{}
+	if err := o.doJSON(req, tokenResp); err != nil {
+		return nil, err
+	}
+
+	if tokenResp.IDToken == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	return o.verifyIDToken(ctx, tokenResp.IDToken)
+}
+
+// verifyIDToken checks the signature, issuer, audience and expiry of an ID token and returns its claims.
+func (o *oidcProvider) verifyIDToken(ctx context.Context, rawIDToken string) (*Claims, error) {
+	token, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return o.getKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(o.cfg.Issuer),
+		jwt.WithAudience(o.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidIDToken
+	}
+
+	// Re-decode the claims into a typed struct instead of reading the generic map field by field.
+	raw, err := json.Marshal(token.Claims)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{}
+	if err := json.Unmarshal(raw, claims); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	return claims, nil
+}
+
+// getDiscovery returns the cached discovery document, fetching it on first use.
+func (o *oidcProvider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(o.cfg.Issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &discoveryDocument{}
+	if err := o.doJSON(req, doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Issuer != o.cfg.Issuer {
+		return nil, fmt.Errorf("%w: issuer mismatch %q", ErrProviderRequestFailed, doc.Issuer)
+	}
+
+	o.discovery = doc
+	return doc, nil
+}
+
+// getKey returns the signing key with the given ID, refreshing the JWKS when the key is unknown
+// so that provider key rotation is picked up without a restart.
+func (o *oidcProvider) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	doc, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if key, ok := o.keys[kid]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, doc.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jwks := &struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := o.doJSON(req, jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		key, err := parseRSAKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keys[k.Kid] = key
+	}
+	o.keys = keys
+
+	key, ok := o.keys[kid]
+	if !ok {
+		return nil, ErrInvalidIDToken
+	}
+
+	return key, nil
+}
+
+// doJSON sends the request and decodes a successful JSON response into out.
+func (o *oidcProvider) doJSON(req *http.Request, out any) error {
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrProviderRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned %d", ErrProviderRequestFailed, req.URL.Path, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// parseRSAKey converts a JWK into an RSA public key.
+func parseRSAKey(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,155 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	const codeVerifier = "mock-code-verifier-0123456789-0123456789-0123456789"
+
+	testCases := []struct {
+		name string
+
+		setupConfig       func(idp *fixture.MockOIDCProvider) *ProviderConfig
+		inputCodeVerifier string
+
+		expectedError  error
+		expectedOutput *Claims
+	}{
+		{
+			name: "Exchange code successfully",
+
+			setupConfig: func(idp *fixture.MockOIDCProvider) *ProviderConfig {
+				return &ProviderConfig{
+					Issuer:       idp.Issuer(),
+					ClientID:     fixture.MockOIDCClientID,
+					ClientSecret: fixture.MockOIDCClientSecret,
+					RedirectURL:  "http://localhost:8080/v1/users/login/oidc/mockidp/callback",
+					Scopes:       []string{"openid", "email"},
+				}
+			},
+			inputCodeVerifier: codeVerifier,
+
+			expectedOutput: &Claims{
+				Subject:       "subject-001",
+				Email:         "federated@example.com",
+				EmailVerified: true,
+				Name:          "Federated User",
+				Nonce:         "nonce-001",
+			},
+		},
+		{
+			name: "Exchange failed - code verifier does not match challenge",
+
+			setupConfig: func(idp *fixture.MockOIDCProvider) *ProviderConfig {
+				return &ProviderConfig{
+					Issuer:       idp.Issuer(),
+					ClientID:     fixture.MockOIDCClientID,
+					ClientSecret: fixture.MockOIDCClientSecret,
+					RedirectURL:  "http://localhost:8080/v1/users/login/oidc/mockidp/callback",
+				}
+			},
+			inputCodeVerifier: "another-code-verifier",
+
+			expectedError: ErrProviderRequestFailed,
+		},
+		{
+			name: "Exchange failed - wrong client secret",
+
+			setupConfig: func(idp *fixture.MockOIDCProvider) *ProviderConfig {
+				return &ProviderConfig{
+					Issuer:       idp.Issuer(),
+					ClientID:     fixture.MockOIDCClientID,
+					ClientSecret: "wrong-secret",
+					RedirectURL:  "http://localhost:8080/v1/users/login/oidc/mockidp/callback",
+				}
+			},
+			inputCodeVerifier: codeVerifier,
+
+			expectedError: ErrProviderRequestFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			idp := fixture.NewMockOIDCProvider(t)
+			idp.SetUser(fixture.MockOIDCUser{
+				Subject:       "subject-001",
+				Email:         "federated@example.com",
+				EmailVerified: true,
+				Name:          "Federated User",
+			})
+
+			provider := NewOIDCProvider(tc.setupConfig(idp), idp.Server.Client())
+
+			authURL, err := provider.AuthCodeURL(ctx, "state-001", "nonce-001", codeChallengeS256(codeVerifier))
+			assert.Nil(t, err)
+
+			callbackURL := idp.Authorize(t, authURL)
+			assert.Equal(t, "state-001", callbackURL.Query().Get("state"))
+
+			res, err := provider.Exchange(ctx, callbackURL.Query().Get("code"), tc.inputCodeVerifier)
+			assert.True(t, errors.Is(err, tc.expectedError), "unexpected error: %v", err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	idp := fixture.NewMockOIDCProvider(t)
+
+	testCases := []struct {
+		name string
+
+		inputConfig *ProviderConfig
+
+		expectedError error
+	}{
+		{
+			name: "Build authorization URL successfully",
+
+			inputConfig: &ProviderConfig{
+				Issuer:   idp.Issuer(),
+				ClientID: fixture.MockOIDCClientID,
+			},
+		},
+		{
+			name: "Discovery failed - issuer mismatch",
+
+			inputConfig: &ProviderConfig{
+				Issuer:   idp.Issuer() + "/",
+				ClientID: fixture.MockOIDCClientID,
+			},
+
+			expectedError: ErrProviderRequestFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := NewOIDCProvider(tc.inputConfig, idp.Server.Client())
+
+			res, err := provider.AuthCodeURL(t.Context(), "state-001", "nonce-001", "challenge")
+			if tc.expectedError != nil {
+				assert.True(t, errors.Is(err, tc.expectedError), "unexpected error: %v", err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Contains(t, res, idp.Issuer()+"/authorize?")
+			assert.Contains(t, res, "code_challenge_method=S256")
+		})
+	}
+}
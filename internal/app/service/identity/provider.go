@@ -0,0 +1,41 @@
+package identity
+
+import "context"
+
+// Claims holds the user information asserted by a provider in a verified ID token.
+type Claims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Nonce             string `json:"nonce"`
+}
+
+// Provider defines the contract for an upstream OpenID Connect identity provider.
+type Provider interface {
+	// AuthCodeURL builds the provider URL the user agent is sent to in order to authenticate.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - state: The opaque value echoed back by the provider on the callback.
+	//   - nonce: The value the provider must embed in the ID token.
+	//   - codeChallenge: The S256 PKCE challenge derived from the code verifier.
+	//
+	// Returns:
+	//   - string: The authorization endpoint URL.
+	//   - error: An error if the provider metadata cannot be fetched, otherwise nil.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange redeems an authorization code and returns the claims of the verified ID token.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - code: The authorization code returned on the callback.
+	//   - codeVerifier: The PKCE code verifier matching the challenge sent earlier.
+	//
+	// Returns:
+	//   - *Claims: The claims of the ID token once its signature, issuer, audience and expiry are verified.
+	//   - error: An error if the exchange or the verification fails, otherwise nil.
+	Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error)
+}
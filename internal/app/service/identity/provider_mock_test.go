@@ -0,0 +1,32 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// mockProvider is a testify mock of Provider. It lives in the package because the
+// Provider contract returns *Claims, so a generated mock in ./mocks would import this package.
+type mockProvider struct {
+	mock.Mock
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	m := &mockProvider{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *mockProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	ret := m.Called(ctx, state, nonce, codeChallenge)
+	return ret.String(0), ret.Error(1)
+}
+
+func (m *mockProvider) Exchange(ctx context.Context, code, codeVerifier string) (*Claims, error) {
+	ret := m.Called(ctx, code, codeVerifier)
+	claims, _ := ret.Get(0).(*Claims)
+	return claims, ret.Error(1)
+}
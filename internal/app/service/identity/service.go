@@ -0,0 +1,157 @@
+// Package identity provides federated login through external OpenID Connect providers.
+// It runs the authorization-code flow against configured upstream providers, links the
+// external subject to a local user and issues the same token as a password login.
+package identity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	identityRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/identity"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	usernamePolicyService "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+const (
+	AuthStateExpiration = 10 * time.Minute
+
+	// ReauthenticationWindow is how recent a login must be to link a new identity.
+	ReauthenticationWindow = 5 * time.Minute
+
+	stateLength        = 32
+	nonceLength        = 32
+	codeVerifierLength = 64
+	usernameSuffixLen  = 6
+
+	// generatedUsernamePrefix starts the usernames of provisioned users whose provider name the policy rejects.
+	generatedUsernamePrefix = "user_"
+)
+
+var (
+	ErrUnknownProvider       = errors.New("unknown identity provider")
+	ErrInvalidAuthState      = errors.New("invalid or expired login state")
+	ErrIdentityEmailConflict = errors.New("an account with this email already exists, log in and link the identity through POST /v1/self/identities/:provider")
+	ErrProviderEmailMissing  = errors.New("identity provider did not share an email address")
+
+	ErrReauthenticationRequired = errors.New("recent login required, please log in again")
+	ErrIdentityAlreadyLinked    = errors.New("this identity is already linked to another account")
+	ErrLastCredential           = errors.New("cannot remove the last remaining login method")
+)
+
+// Service represents the interface for federated login operations.
+//
+//go:generate mockery --name=Service --filename=identity_service.go --output=./mocks
+type Service interface {
+	// AuthCodeURL starts an authorization-code flow against the named provider.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - provider: The name of the configured provider.
+	//
+	// Returns:
+	//   - string: The provider URL the user agent must be redirected to.
+	//   - error: ErrUnknownProvider if the provider is not configured, otherwise any storage or provider error.
+	AuthCodeURL(ctx context.Context, provider string) (string, error)
+
+	// Login completes an authorization-code flow and returns a token for the linked local user.
+	// Unknown subjects are linked to the user owning the same verified email, or a new user is provisioned if the
+	// registration policy lets the email register.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - provider: The name of the configured provider.
+	//   - code: The authorization code returned by the provider.
+	//   - state: The state value returned by the provider.
+	//
+	// Returns:
+	//   - string: The JWT token if authentication is successful.
+	//   - error: An error of the registration policy if a new user cannot be provisioned, an error of the email
+	//     policy if the email address is rejected, otherwise an error if the flow cannot be completed.
+	Login(ctx context.Context, provider, code, state string) (string, error)
+
+	// ListIdentities retrieves the identities linked to a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//
+	// Returns:
+	//   - []*model.UserIdentity: The linked identities, oldest first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListIdentities(ctx context.Context, userID string) ([]*model.UserIdentity, error)
+
+	// StartLink starts an authorization-code flow that links a new identity to a logged in user.
+	// The callback is the same as for Login, which links instead of resolving the user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the logged in user.
+	//   - provider: The name of the configured provider.
+	//   - authTime: When the user last authenticated, taken from the token issue time.
+	//
+	// Returns:
+	//   - string: The provider URL the user agent must be redirected to.
+	//   - error: ErrReauthenticationRequired if the login is older than ReauthenticationWindow,
+	//     ErrUnknownProvider if the provider is not configured, otherwise any storage or provider error.
+	StartLink(ctx context.Context, userID, provider string, authTime time.Time) (string, error)
+
+	// Unlink removes a linked identity from a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - identityID: The ID of the identity to unlink.
+	//
+	// Returns:
+	//   - error: ErrLastCredential if the identity is the only way left to log in,
+	//     dbutils.ErrRecordNotFoundType if the user owns no such identity, otherwise nil.
+	Unlink(ctx context.Context, userID, identityID string) error
+}
+
+type identityService struct {
+	identityRepo   identityRepository.Repository
+	userRepo       userRepository.Repository
+	userSvc        userService.Service
+	codeGen        utils.CodeGenerator
+	providers      map[string]Provider
+	registration   registration.Policy
+	usernamePolicy *usernamePolicyService.Policy
+	emailPolicy    emailpolicy.Checker
+}
+
+// NewIdentityService creates a new instance of the identity service.
+//
+// Parameters:
+//   - identityRepo: The repository storing linked identities and login state.
+//   - userRepo: The user repository used to look up and provision local users.
+//   - userSvc: The user service issuing the access token.
+//   - codeGen: The random code generator used for state, nonce and PKCE values.
+//   - providers: The configured providers indexed by name.
+//   - registrationPolicy: The rules deciding whether new users may be provisioned; federated users have no invitation.
+//   - usernamePolicy: The username policy the usernames of provisioned users must pass.
+//   - emailPolicy: The email policy the email addresses of provisioned users must pass.
+//
+// Returns:
+//   - Service: A new identity service instance.
+func NewIdentityService(
+	identityRepo identityRepository.Repository,
+	userRepo userRepository.Repository,
+	userSvc userService.Service,
+	codeGen utils.CodeGenerator,
+	providers map[string]Provider,
+	registrationPolicy registration.Policy,
+	usernamePolicy *usernamePolicyService.Policy,
+	emailPolicy emailpolicy.Checker,
+) Service {
+	return &identityService{
+		identityRepo:   identityRepo,
+		userRepo:       userRepo,
+		userSvc:        userSvc,
+		codeGen:        codeGen,
+		providers:      providers,
+		registration:   registrationPolicy,
+		usernamePolicy: usernamePolicy,
+		emailPolicy:    emailPolicy,
+	}
+}
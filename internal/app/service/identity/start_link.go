@@ -0,0 +1,32 @@
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// StartLink starts an authorization-code flow that links a new identity to a logged in user.
+// Linking is a sensitive change, so the user must have logged in within ReauthenticationWindow.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the logged in user.
+//   - provider: The name of the configured provider.
+//   - authTime: When the user last authenticated, taken from the token issue time.
+//
+// Returns:
+//   - string: The provider URL the user agent must be redirected to.
+//   - error: ErrReauthenticationRequired if the login is older than ReauthenticationWindow,
+//     ErrUnknownProvider if the provider is not configured, otherwise any storage or provider error.
+func (i *identityService) StartLink(ctx context.Context, userID, provider string, authTime time.Time) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_StartLink")
+	defer s.End()
+
+	if time.Since(authTime) > ReauthenticationWindow {
+		return "", ErrReauthenticationRequired
+	}
+
+	return i.startFlow(ctx, provider, userID)
+}
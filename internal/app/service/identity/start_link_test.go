@@ -0,0 +1,115 @@
+package identity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockIdentityRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/identity/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+func TestService_StartLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockIdentityRepo func(ctx context.Context) *mockIdentityRepo.Repository
+		setupMockProvider     func(ctx context.Context) *mockProvider
+		setupMockCodeGen      func() *mockUtils.CodeGenerator
+
+		inputProvider string
+		inputAuthTime time.Time
+
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name: "Start linking successfully",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("SaveAuthState", ctx, "generated-state", &model.AuthState{
+					Provider:     "mockidp",
+					Nonce:        "generated-nonce",
+					CodeVerifier: "generated-verifier",
+					UserID:       testUser.ID,
+				}, AuthStateExpiration).Return(nil)
+				return repoMock
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				providerMock := newMockProvider(t)
+				providerMock.On("AuthCodeURL", ctx, "generated-state", "generated-nonce", codeChallengeS256("generated-verifier")).
+					Return("https://idp.example.com/authorize?state=generated-state", nil)
+				return providerMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", stateLength).Return("generated-state", nil).Once()
+				codeGenMock.On("GenerateCode", nonceLength).Return("generated-nonce", nil).Once()
+				codeGenMock.On("GenerateCode", codeVerifierLength).Return("generated-verifier", nil).Once()
+				return codeGenMock
+			},
+
+			inputProvider: "mockidp",
+			inputAuthTime: time.Now().Add(-time.Minute),
+
+			expectedOutput: "https://idp.example.com/authorize?state=generated-state",
+		},
+		{
+			name: "Start linking failed - login is too old",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				return mockIdentityRepo.NewRepository(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				return newMockProvider(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "mockidp",
+			inputAuthTime: time.Now().Add(-ReauthenticationWindow - time.Minute),
+
+			expectedError: ErrReauthenticationRequired,
+		},
+		{
+			name: "Start linking failed - unknown provider",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				return mockIdentityRepo.NewRepository(t)
+			},
+			setupMockProvider: func(ctx context.Context) *mockProvider {
+				return newMockProvider(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			inputProvider: "unknown",
+			inputAuthTime: time.Now(),
+
+			expectedError: ErrUnknownProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			providers := map[string]Provider{"mockidp": tc.setupMockProvider(ctx)}
+
+			identityService := NewIdentityService(tc.setupMockIdentityRepo(ctx), nil, nil, tc.setupMockCodeGen(), providers, registration.Policy{}, nil, nil)
+
+			res, err := identityService.StartLink(ctx, testUser.ID, tc.inputProvider, tc.inputAuthTime)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package identity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	identityRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/identity"
+)
+
+// Unlink removes a linked identity from a user.
+// A user must always keep a way to log in: the identity can only be removed
+// if the user has a password, another linked identity or a passkey.
+// The check and the removal are one transaction, so concurrent unlinks cannot remove every identity.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - identityID: The ID of the identity to unlink.
+//
+// Returns:
+//   - error: ErrLastCredential if the identity is the only way left to log in,
+//     dbutils.ErrRecordNotFoundType if the user owns no such identity, otherwise nil.
+func (i *identityService) Unlink(ctx context.Context, userID, identityID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_Unlink")
+	defer s.End()
+
+	err := i.identityRepo.DeleteIdentity(ctx, userID, identityID)
+	if errors.Is(err, identityRepository.ErrLastCredential) {
+		return ErrLastCredential
+	}
+
+	return err
+}
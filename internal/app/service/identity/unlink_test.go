@@ -0,0 +1,87 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	identityRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/identity"
+	mockIdentityRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/identity/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+)
+
+var testFederatedUser = &model.User{
+	Base:     model.Base{ID: "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f"},
+	Username: "federated001",
+	Email:    "federated001@example.com",
+}
+
+func TestService_Unlink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockIdentityRepo func(ctx context.Context) *mockIdentityRepo.Repository
+
+		inputUserID     string
+		inputIdentityID string
+
+		expectedError error
+	}{
+		{
+			name: "Unlink an identity",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("DeleteIdentity", ctx, testUser.ID, "identity-001").Return(nil)
+				return repoMock
+			},
+
+			inputUserID:     testUser.ID,
+			inputIdentityID: "identity-001",
+		},
+		{
+			name: "Unlink failed - last credential",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("DeleteIdentity", ctx, testFederatedUser.ID, "identity-001").Return(identityRepository.ErrLastCredential)
+				return repoMock
+			},
+
+			inputUserID:     testFederatedUser.ID,
+			inputIdentityID: "identity-001",
+
+			expectedError: ErrLastCredential,
+		},
+		{
+			name: "Unlink failed - identity not owned by the user",
+
+			setupMockIdentityRepo: func(ctx context.Context) *mockIdentityRepo.Repository {
+				repoMock := mockIdentityRepo.NewRepository(t)
+				repoMock.On("DeleteIdentity", ctx, testFederatedUser.ID, "identity-999").Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			inputUserID:     testFederatedUser.ID,
+			inputIdentityID: "identity-999",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			identityService := NewIdentityService(tc.setupMockIdentityRepo(ctx), nil, nil, nil, nil, registration.Policy{}, nil, nil)
+
+			err := identityService.Unlink(ctx, tc.inputUserID, tc.inputIdentityID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// Accept creates a user with the invitation carrying the token, granting it the role of the invitation.
+// The email address of the user is recorded as verified, since the invitation link was sent to it.
+// The email address of the user must be the invited one, compared in their canonical forms.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - token: The token carried by the invitation link.
+//   - user: The user to create, with the invited email address.
+//
+// Returns:
+//   - *model.User: The created user.
+//   - error: ErrInvalidInvitation if the invitation is unknown, used, revoked, superseded or expired,
+//     ErrInvitationEmailMismatch if the user has another email address, dbutils.ErrDuplicationType if the
+//     username or email is taken, otherwise an error if the creation fails.
+func (svc *invitationService) Accept(ctx context.Context, token string, user *model.User) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_AcceptInvitation")
+	defer s.End()
+
+	invitation, err := svc.invitationRepo.GetInvitationByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if invitation.Status != model.InvitationPending || !now.Before(invitation.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+
+	if normalize.Email(invitation.Email) != normalize.Email(user.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	user.Role = invitation.Role
+	// The invitation link was sent to the address
+	user.EmailVerifiedAt = &now
+	createdUser, err := svc.invitationRepo.CreateUserWithInvitation(ctx, user, invitation.ID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		// The invitation was accepted, revoked or superseded meanwhile.
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return createdUser, nil
+}
@@ -0,0 +1,181 @@
+package invitation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockInvitationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/invitation/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func TestService_Accept(t *testing.T) {
+	t.Parallel()
+
+	pendingInvitation := func() *model.Invitation {
+		return &model.Invitation{
+			Base:      model.Base{ID: "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01"},
+			Email:     "Invitee@Example.com",
+			Role:      model.RoleAdmin,
+			Status:    model.InvitationPending,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+	newUser := func() *model.User {
+		return &model.User{Username: "invitee", DisplayName: "Invitee", Email: "invitee@example.com"}
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockInvitationRepo func(ctx context.Context) *mockInvitationRepo.Repository
+		setupMockUserRepo       func(ctx context.Context) *mockUserRepo.Repository
+		inputUser               *model.User
+
+		expectedOutput *model.User
+		expectedError  error
+	}{
+		{
+			name: "Accept invitation successfully",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation(), nil)
+				repoMock.On("CreateUserWithInvitation", ctx, invitedUser(), "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").
+					Return(&model.User{Base: model.Base{ID: "new-user-id"}, Username: "invitee", Role: model.RoleAdmin}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: newUser(),
+
+			expectedOutput: &model.User{Base: model.Base{ID: "new-user-id"}, Username: "invitee", Role: model.RoleAdmin},
+		},
+		{
+			name: "Accept invitation failed - unknown token",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: newUser(),
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - already accepted",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				invitation := pendingInvitation()
+				invitation.Status = model.InvitationAccepted
+
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(invitation, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: newUser(),
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - expired",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				invitation := pendingInvitation()
+				invitation.ExpiresAt = time.Now().Add(-time.Minute)
+
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(invitation, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: newUser(),
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - another email address",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation(), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: &model.User{Username: "invitee", DisplayName: "Invitee", Email: "someone.else@example.com"},
+
+			expectedError: ErrInvitationEmailMismatch,
+		},
+		{
+			name: "Accept invitation failed - accepted meanwhile",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation(), nil)
+				repoMock.On("CreateUserWithInvitation", ctx, invitedUser(), "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: newUser(),
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - username taken",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation(), nil)
+				repoMock.On("CreateUserWithInvitation", ctx, invitedUser(), "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").
+					Return(nil, dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			inputUser: newUser(),
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewInvitationService(tc.setupMockInvitationRepo(ctx), tc.setupMockUserRepo(ctx), nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.Accept(ctx, "invite-001", tc.inputUser)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
+
+// invitedUser matches the user created with the pending invitation, its email address verified.
+func invitedUser() any {
+	return mock.MatchedBy(func(user *model.User) bool {
+		return user.Username == "invitee" && user.DisplayName == "Invitee" && user.Email == "invitee@example.com" &&
+			user.Role == model.RoleAdmin && user.EmailVerifiedAt != nil
+	})
+}
@@ -0,0 +1,80 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+// CreateInvitation invites an email address and sends it the invitation link.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - email: The invited email address.
+//   - role: The role granted to the invited user, model.RoleMember when empty.
+//
+// Returns:
+//   - *model.Invitation: The created invitation.
+//   - error: ErrUnsupportedRole if the role is unknown, dbutils.ErrDuplicationType if a user already has the
+//     address, otherwise an error if the invitation cannot be stored or the email cannot be sent.
+func (svc *invitationService) CreateInvitation(ctx context.Context, email, role string) (*model.Invitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CreateInvitation")
+	defer s.End()
+
+	switch role {
+	case "":
+		role = model.RoleMember
+	case model.RoleMember, model.RoleAdmin:
+	default:
+		return nil, ErrUnsupportedRole
+	}
+
+	_, err := svc.userRepo.GetUserByEmail(ctx, email)
+	if err == nil {
+		return nil, dbutils.ErrDuplicationType
+	}
+	if !errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, err
+	}
+
+	token, err := svc.codeGen.GenerateCode(tokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := buildLink(svc.invitationURL, token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := svc.invitationRepo.CreateInvitation(ctx, &model.Invitation{
+		Email:     email,
+		Role:      role,
+		TokenHash: hashToken(token),
+		Status:    model.InvitationPending,
+		ExpiresAt: time.Now().Add(svc.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "You are invited to create an account",
+		Body: fmt.Sprintf(
+			"Hi,\n\nYou are invited to create an account with this email address. Register with the link below; it expires in %d hours.\n\n%s\n\nIf you did not expect it, you can ignore this email.\n",
+			int(svc.ttl.Hours()), link,
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,199 @@
+package invitation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockInvitationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/invitation/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	mockMailer "github.com/vukieuhaihoa/user-service/internal/mailer/mocks"
+)
+
+const (
+	testInvitationURL = "https://app.example.com/register"
+	testTTL           = 7 * 24 * time.Hour
+)
+
+func TestService_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	isPendingInvitation := func(role string) any {
+		return mock.MatchedBy(func(invitation *model.Invitation) bool {
+			return invitation.Email == "invitee@example.com" &&
+				invitation.Role == role &&
+				invitation.TokenHash == hashToken("invite-001") &&
+				invitation.Status == model.InvitationPending &&
+				time.Until(invitation.ExpiresAt) > testTTL-time.Minute
+		})
+	}
+
+	testCases := []struct {
+		name string
+
+		inputRole string
+
+		setupMockUserRepo       func(ctx context.Context) *mockUserRepo.Repository
+		setupMockInvitationRepo func(ctx context.Context) *mockInvitationRepo.Repository
+		setupMockMailer         func(ctx context.Context) *mockMailer.Mailer
+		generatesToken          bool
+
+		expectedOutput *model.Invitation
+		expectedError  error
+	}{
+		{
+			name: "Create invitation with a role successfully",
+
+			inputRole: model.RoleAdmin,
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("CreateInvitation", ctx, isPendingInvitation(model.RoleAdmin)).
+					Return(&model.Invitation{Email: "invitee@example.com", Role: model.RoleAdmin}, nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.To == "invitee@example.com" &&
+						msg.Subject == "You are invited to create an account" &&
+						strings.Contains(msg.Body, testInvitationURL+"?token=invite-001") &&
+						strings.Contains(msg.Body, "expires in 168 hours")
+				})).Return(nil)
+				return mailerMock
+			},
+			generatesToken: true,
+
+			expectedOutput: &model.Invitation{Email: "invitee@example.com", Role: model.RoleAdmin},
+		},
+		{
+			name: "Create invitation without a role grants the member role",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("CreateInvitation", ctx, isPendingInvitation(model.RoleMember)).
+					Return(&model.Invitation{Email: "invitee@example.com", Role: model.RoleMember}, nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(nil)
+				return mailerMock
+			},
+			generatesToken: true,
+
+			expectedOutput: &model.Invitation{Email: "invitee@example.com", Role: model.RoleMember},
+		},
+		{
+			name: "Create invitation failed - unsupported role",
+
+			inputRole: "owner",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				return mockInvitationRepo.NewRepository(t)
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: ErrUnsupportedRole,
+		},
+		{
+			name: "Create invitation failed - email already registered",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(&model.User{Email: "invitee@example.com"}, nil)
+				return repoMock
+			},
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				return mockInvitationRepo.NewRepository(t)
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Create invitation failed - repository error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("CreateInvitation", ctx, mock.Anything).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+			generatesToken: true,
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Create invitation failed - mailer error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("CreateInvitation", ctx, mock.Anything).Return(&model.Invitation{}, nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(assert.AnError)
+				return mailerMock
+			},
+			generatesToken: true,
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			codeGenMock := mockUtils.NewCodeGenerator(t)
+			if tc.generatesToken {
+				codeGenMock.On("GenerateCode", tokenLength).Return("invite-001", nil).Once()
+			}
+
+			testSvc := NewInvitationService(tc.setupMockInvitationRepo(ctx), tc.setupMockUserRepo(ctx), codeGenMock, tc.setupMockMailer(ctx), testInvitationURL, testTTL)
+
+			res, err := testSvc.CreateInvitation(ctx, "invitee@example.com", tc.inputRole)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
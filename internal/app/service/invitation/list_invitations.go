@@ -0,0 +1,23 @@
+package invitation
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListInvitations retrieves all invitations.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - []*model.Invitation: The invitations, newest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (svc *invitationService) ListInvitations(ctx context.Context) ([]*model.Invitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListInvitations")
+	defer s.End()
+
+	return svc.invitationRepo.ListInvitations(ctx)
+}
@@ -0,0 +1,59 @@
+package invitation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockInvitationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/invitation/mocks"
+)
+
+func TestService_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockInvitationRepo func(ctx context.Context) *mockInvitationRepo.Repository
+
+		expectedOutput []*model.Invitation
+		expectedError  error
+	}{
+		{
+			name: "List invitations successfully",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("ListInvitations", ctx).Return([]*model.Invitation{{Email: "invitee@example.com"}}, nil)
+				return repoMock
+			},
+
+			expectedOutput: []*model.Invitation{{Email: "invitee@example.com"}},
+		},
+		{
+			name: "List invitations failed - repository error",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("ListInvitations", ctx).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewInvitationService(tc.setupMockInvitationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.ListInvitations(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+)
+
+// RevokeInvitation revokes a pending invitation, so its link stops working.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the invitation.
+//
+// Returns:
+//   - error: ErrInvitationNotFound if no pending invitation has the ID, otherwise any storage error.
+func (svc *invitationService) RevokeInvitation(ctx context.Context, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RevokeInvitation")
+	defer s.End()
+
+	err := svc.invitationRepo.RevokeInvitation(ctx, id)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvitationNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+package invitation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockInvitationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/invitation/mocks"
+)
+
+func TestService_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockInvitationRepo func(ctx context.Context) *mockInvitationRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Revoke invitation successfully",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("RevokeInvitation", ctx, "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Revoke invitation failed - no pending invitation",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("RevokeInvitation", ctx, "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrInvitationNotFound,
+		},
+		{
+			name: "Revoke invitation failed - repository error",
+
+			setupMockInvitationRepo: func(ctx context.Context) *mockInvitationRepo.Repository {
+				repoMock := mockInvitationRepo.NewRepository(t)
+				repoMock.On("RevokeInvitation", ctx, "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01").Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewInvitationService(tc.setupMockInvitationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			err := testSvc.RevokeInvitation(ctx, "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
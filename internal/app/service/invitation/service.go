@@ -0,0 +1,139 @@
+// Package invitation provides the invitations to register.
+// An admin invites an email address, optionally with a role, and the address receives a link whose token registers
+// one user with that address, who is granted the role. A new invitation of an address supersedes the pending one,
+// and pending invitations can be revoked.
+package invitation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	invitationRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/invitation"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+const tokenLength = 32
+
+var (
+	ErrInvalidInvitation       = errors.New("invalid or expired invitation")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to another email address")
+	ErrUnsupportedRole         = errors.New("unsupported role")
+	ErrInvitationNotFound      = errors.New("pending invitation not found")
+)
+
+// Service represents the interface for invitation operations.
+//
+//go:generate mockery --name=Service --filename=invitation_service.go --output=./mocks
+type Service interface {
+	// CreateInvitation invites an email address and sends it the invitation link.
+	// A previous pending invitation of the address is superseded.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - email: The invited email address.
+	//   - role: The role granted to the invited user, model.RoleMember when empty.
+	//
+	// Returns:
+	//   - *model.Invitation: The created invitation.
+	//   - error: ErrUnsupportedRole if the role is unknown, dbutils.ErrDuplicationType if a user already has the
+	//     address, otherwise an error if the invitation cannot be stored or the email cannot be sent.
+	CreateInvitation(ctx context.Context, email, role string) (*model.Invitation, error)
+
+	// ListInvitations retrieves all invitations.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - []*model.Invitation: The invitations, newest first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListInvitations(ctx context.Context) ([]*model.Invitation, error)
+
+	// RevokeInvitation revokes a pending invitation, so its link stops working.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the invitation.
+	//
+	// Returns:
+	//   - error: ErrInvitationNotFound if no pending invitation has the ID, otherwise any storage error.
+	RevokeInvitation(ctx context.Context, id string) error
+
+	// Accept creates a user with the invitation carrying the token, granting it the role of the invitation.
+	// The invitation is accepted in the same transaction, so it registers one user only.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - token: The token carried by the invitation link.
+	//   - user: The user to create, with the invited email address.
+	//
+	// Returns:
+	//   - *model.User: The created user.
+	//   - error: ErrInvalidInvitation if the invitation is unknown, used, revoked, superseded or expired,
+	//     ErrInvitationEmailMismatch if the user has another email address, dbutils.ErrDuplicationType if the
+	//     username or email is taken, otherwise an error if the creation fails.
+	Accept(ctx context.Context, token string, user *model.User) (*model.User, error)
+}
+
+// invitationService is the concrete implementation of the Service interface.
+type invitationService struct {
+	invitationRepo invitationRepository.Repository
+	userRepo       userRepository.Repository
+	codeGen        utils.CodeGenerator
+	mailer         mailer.Mailer
+	invitationURL  string
+	ttl            time.Duration
+}
+
+// NewInvitationService creates a new instance of the invitation service.
+//
+// Parameters:
+//   - invitationRepo: The repository storing the invitations.
+//   - userRepo: The user repository checking the invited addresses are free.
+//   - codeGen: The random code generator used for the link tokens.
+//   - mailer: The mailer delivering the invitation links.
+//   - invitationURL: The frontend page invitation links point to; the token is added as the "token" query parameter.
+//   - ttl: How long an invitation link works.
+//
+// Returns:
+//   - Service: A new invitation service instance.
+func NewInvitationService(
+	invitationRepo invitationRepository.Repository,
+	userRepo userRepository.Repository,
+	codeGen utils.CodeGenerator,
+	mailer mailer.Mailer,
+	invitationURL string,
+	ttl time.Duration,
+) Service {
+	return &invitationService{
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		codeGen:        codeGen,
+		mailer:         mailer,
+		invitationURL:  invitationURL,
+		ttl:            ttl,
+	}
+}
+
+// hashToken returns the hex SHA-256 of a link token, so the token itself is never stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// buildLink adds the token to the configured invitation URL.
+func buildLink(linkURL, token string) (string, error) {
+	u, err := url.Parse(linkURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
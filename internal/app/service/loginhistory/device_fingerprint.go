@@ -0,0 +1,20 @@
+package loginhistory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// deviceFingerprint identifies the device a login comes from by its IP address and user-agent,
+// so a known browser on a new network counts as a new device.
+//
+// Parameters:
+//   - ipAddress: The IP address of the client.
+//   - userAgent: The user-agent of the client.
+//
+// Returns:
+//   - string: The hex encoded SHA-256 fingerprint.
+func deviceFingerprint(ipAddress, userAgent string) string {
+	sum := sha256.Sum256([]byte(ipAddress + "\n" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,18 @@
+package loginhistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceFingerprint(t *testing.T) {
+	t.Parallel()
+
+	fingerprint := deviceFingerprint("203.0.113.10", testUserAgent)
+
+	assert.Len(t, fingerprint, 64)
+	assert.Equal(t, fingerprint, deviceFingerprint("203.0.113.10", testUserAgent))
+	assert.NotEqual(t, fingerprint, deviceFingerprint("198.51.100.20", testUserAgent))
+	assert.NotEqual(t, fingerprint, deviceFingerprint("203.0.113.10", "curl/8.4.0"))
+}
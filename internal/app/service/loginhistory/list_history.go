@@ -0,0 +1,24 @@
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListHistory retrieves the latest login attempts of a user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - []*model.LoginEvent: Up to HistoryLimit login attempts, newest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (svc *loginHistoryService) ListHistory(ctx context.Context, userID string) ([]*model.LoginEvent, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListHistory")
+	defer s.End()
+
+	return svc.loginHistoryRepo.ListLoginEventsByUserID(ctx, userID, HistoryLimit)
+}
@@ -0,0 +1,62 @@
+package loginhistory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockLoginHistoryRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/loginhistory/mocks"
+	mockNotifier "github.com/vukieuhaihoa/user-service/internal/notifier/mocks"
+)
+
+func TestService_ListHistory(t *testing.T) {
+	t.Parallel()
+
+	events := []*model.LoginEvent{{Base: model.Base{ID: testEventID}, Success: true}}
+
+	testCases := []struct {
+		name string
+
+		setupMockLoginHistoryRepo func(ctx context.Context) *mockLoginHistoryRepo.Repository
+
+		expectedOutput []*model.LoginEvent
+		expectedError  error
+	}{
+		{
+			name: "List the latest login attempts",
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListLoginEventsByUserID", ctx, testUserID, HistoryLimit).Return(events, nil)
+				return repoMock
+			},
+
+			expectedOutput: events,
+		},
+		{
+			name: "List history failed - repository error",
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListLoginEventsByUserID", ctx, testUserID, HistoryLimit).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			loginHistoryService := NewLoginHistoryService(tc.setupMockLoginHistoryRepo(ctx), mockNotifier.NewNotifier(t))
+
+			res, err := loginHistoryService.ListHistory(ctx, testUserID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package loginhistory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+)
+
+// RecordLogin stores a login attempt from the client attached to the context with session.WithClient.
+// A successful login from a new device of a user who logged in before triggers an alert.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user the attempt was made for.
+//   - success: Whether the attempt succeeded.
+//   - mfaUsed: Whether a second factor was verified during the attempt.
+//
+// Returns:
+//   - error: An error if the attempt cannot be stored, otherwise nil. Alerts are best effort.
+func (svc *loginHistoryService) RecordLogin(ctx context.Context, user *model.User, success, mfaUsed bool) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RecordLogin")
+	defer s.End()
+
+	client := session.ClientFromContext(ctx)
+	fingerprint := deviceFingerprint(client.IPAddress, client.UserAgent)
+
+	// The first login of an account has nothing to compare with, so it is not reported
+	newDevice := false
+	if success {
+		known, err := svc.loginHistoryRepo.ListKnownDeviceFingerprints(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		newDevice = len(known) > 0 && !slices.Contains(known, fingerprint)
+	}
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	event, err := svc.loginHistoryRepo.CreateLoginEvent(ctx, &model.LoginEvent{
+		UserID:            user.ID,
+		IPAddress:         client.IPAddress,
+		UserAgent:         userAgent,
+		DeviceFingerprint: fingerprint,
+		Success:           success,
+		MFAUsed:           mfaUsed,
+	})
+	if err != nil {
+		return err
+	}
+
+	if newDevice {
+		// The login itself must not fail because the alert could not be delivered
+		err = svc.notifier.NotifyNewDevice(ctx, user, event)
+		if err != nil {
+			log.Warn().Str("operation", "Service_RecordLogin").Str("user_id", user.ID).Err(err).Msg("failed to send the new device alert")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package loginhistory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockLoginHistoryRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/loginhistory/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+	mockNotifier "github.com/vukieuhaihoa/user-service/internal/notifier/mocks"
+)
+
+const (
+	testUserID    = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	testEventID   = "b2c3d4e5-0001-4f5a-9b0c-1d2e3f4a5b61"
+	testUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
+func TestService_RecordLogin(t *testing.T) {
+	t.Parallel()
+
+	testUser := &model.User{Base: model.Base{ID: testUserID}, Email: "testuser001@example.com"}
+	client := session.Client{IPAddress: "203.0.113.10", UserAgent: testUserAgent}
+	fingerprint := deviceFingerprint(client.IPAddress, client.UserAgent)
+	longUserAgent := "curl/8.4.0 " + strings.Repeat("x", maxUserAgentLength)
+	storedEvent := &model.LoginEvent{Base: model.Base{ID: testEventID}}
+
+	testCases := []struct {
+		name string
+
+		inputClient  session.Client
+		inputSuccess bool
+		inputMFAUsed bool
+
+		setupMockLoginHistoryRepo func(ctx context.Context) *mockLoginHistoryRepo.Repository
+		setupMockNotifier         func(ctx context.Context) *mockNotifier.Notifier
+
+		expectedError error
+	}{
+		{
+			name:         "Record login from a known device without alert",
+			inputClient:  client,
+			inputSuccess: true,
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListKnownDeviceFingerprints", ctx, testUserID).Return([]string{"other", fingerprint}, nil)
+				repoMock.On("CreateLoginEvent", ctx, mock.MatchedBy(func(event *model.LoginEvent) bool {
+					return event.UserID == testUserID &&
+						event.IPAddress == "203.0.113.10" &&
+						event.UserAgent == testUserAgent &&
+						event.DeviceFingerprint == fingerprint &&
+						event.Success && !event.MFAUsed
+				})).Return(storedEvent, nil)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+		},
+		{
+			name:         "Record login from a new device and alert the user",
+			inputClient:  client,
+			inputSuccess: true,
+			inputMFAUsed: true,
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListKnownDeviceFingerprints", ctx, testUserID).Return([]string{"other"}, nil)
+				repoMock.On("CreateLoginEvent", ctx, mock.MatchedBy(func(event *model.LoginEvent) bool {
+					return event.Success && event.MFAUsed
+				})).Return(storedEvent, nil)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				notifierMock := mockNotifier.NewNotifier(t)
+				notifierMock.On("NotifyNewDevice", ctx, testUser, storedEvent).Return(nil)
+				return notifierMock
+			},
+		},
+		{
+			name:         "Record first login of the user without alert",
+			inputClient:  client,
+			inputSuccess: true,
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListKnownDeviceFingerprints", ctx, testUserID).Return([]string{}, nil)
+				repoMock.On("CreateLoginEvent", ctx, mock.Anything).Return(storedEvent, nil)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+		},
+		{
+			name:        "Record failed login without looking up devices",
+			inputClient: session.Client{IPAddress: "198.51.100.20", UserAgent: longUserAgent},
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("CreateLoginEvent", ctx, mock.MatchedBy(func(event *model.LoginEvent) bool {
+					return !event.Success &&
+						event.UserAgent == longUserAgent[:maxUserAgentLength] &&
+						event.DeviceFingerprint == deviceFingerprint("198.51.100.20", longUserAgent)
+				})).Return(storedEvent, nil)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+		},
+		{
+			name:         "Record login even though the alert cannot be delivered",
+			inputClient:  client,
+			inputSuccess: true,
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListKnownDeviceFingerprints", ctx, testUserID).Return([]string{"other"}, nil)
+				repoMock.On("CreateLoginEvent", ctx, mock.Anything).Return(storedEvent, nil)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				notifierMock := mockNotifier.NewNotifier(t)
+				notifierMock.On("NotifyNewDevice", ctx, testUser, storedEvent).Return(assert.AnError)
+				return notifierMock
+			},
+		},
+		{
+			name:         "Record login failed - known devices lookup error",
+			inputClient:  client,
+			inputSuccess: true,
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListKnownDeviceFingerprints", ctx, testUserID).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name:         "Record login failed - repository error",
+			inputClient:  client,
+			inputSuccess: true,
+
+			setupMockLoginHistoryRepo: func(ctx context.Context) *mockLoginHistoryRepo.Repository {
+				repoMock := mockLoginHistoryRepo.NewRepository(t)
+				repoMock.On("ListKnownDeviceFingerprints", ctx, testUserID).Return([]string{"other"}, nil)
+				repoMock.On("CreateLoginEvent", ctx, mock.Anything).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockNotifier: func(ctx context.Context) *mockNotifier.Notifier {
+				return mockNotifier.NewNotifier(t)
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := session.WithClient(t.Context(), tc.inputClient)
+			loginHistoryService := NewLoginHistoryService(tc.setupMockLoginHistoryRepo(ctx), tc.setupMockNotifier(ctx))
+
+			err := loginHistoryService.RecordLogin(ctx, testUser, tc.inputSuccess, tc.inputMFAUsed)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
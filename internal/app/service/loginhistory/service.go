@@ -0,0 +1,66 @@
+// Package loginhistory keeps a per-user history of login attempts and alerts users
+// when their account is logged in to from a device never seen before. A device is
+// recognized by a fingerprint of its IP address and user-agent.
+package loginhistory
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	loginHistoryRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/loginhistory"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+)
+
+const (
+	// HistoryLimit is how many of the latest login attempts are returned to the user.
+	HistoryLimit = 50
+
+	maxUserAgentLength = 512
+)
+
+// Service represents the interface for login history operations.
+//
+//go:generate mockery --name=Service --filename=login_history_service.go --output=./mocks
+type Service interface {
+	// RecordLogin stores a login attempt from the client attached to the context with session.WithClient.
+	// A successful login from a new device of a user who logged in before triggers an alert.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user the attempt was made for.
+	//   - success: Whether the attempt succeeded.
+	//   - mfaUsed: Whether a second factor was verified during the attempt.
+	//
+	// Returns:
+	//   - error: An error if the attempt cannot be stored, otherwise nil. Alerts are best effort.
+	RecordLogin(ctx context.Context, user *model.User, success, mfaUsed bool) error
+
+	// ListHistory retrieves the latest login attempts of a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//
+	// Returns:
+	//   - []*model.LoginEvent: Up to HistoryLimit login attempts, newest first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListHistory(ctx context.Context, userID string) ([]*model.LoginEvent, error)
+}
+
+type loginHistoryService struct {
+	loginHistoryRepo loginHistoryRepository.Repository
+	notifier         notifier.Notifier
+}
+
+// NewLoginHistoryService creates a new instance of the login history service.
+//
+// Parameters:
+//   - loginHistoryRepo: The repository storing the login attempts.
+//   - notifier: The notifier alerting users about logins from new devices.
+//
+// Returns:
+//   - Service: A new login history service instance.
+func NewLoginHistoryService(loginHistoryRepo loginHistoryRepository.Repository, notifier notifier.Notifier) Service {
+	return &loginHistoryService{
+		loginHistoryRepo: loginHistoryRepo,
+		notifier:         notifier,
+	}
+}
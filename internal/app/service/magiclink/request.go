@@ -0,0 +1,101 @@
+package magiclink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// Request sends a login link to the user owning the email.
+// Every request counts towards the per-email limit, whether or not the email belongs to a user. The limit is kept
+// per canonical form of the email, so spellings reaching the same mailbox share it.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - email: The email address of the user.
+//
+// Returns:
+//   - string: The nonce the requesting device must present with the link.
+//   - error: ErrTooManyRequests if the email is throttled, otherwise any storage or delivery error.
+func (m *magicLinkService) Request(ctx context.Context, email string) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RequestMagicLink")
+	defer s.End()
+
+	email = strings.TrimSpace(email)
+
+	count, err := m.magicLinkRepo.IncreaseRequestCount(ctx, normalize.Email(email), RequestWindow)
+	if err != nil {
+		return "", err
+	}
+	if count > RequestLimit {
+		return "", ErrTooManyRequests
+	}
+
+	nonce, err := m.codeGen.GenerateCode(nonceLength)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := m.userRepo.GetUserByEmail(ctx, email)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nonce, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	linkID, err := m.codeGen.GenerateCode(linkIDLength)
+	if err != nil {
+		return "", err
+	}
+
+	link, err := m.buildLink(linkID + "." + m.sign(linkID))
+	if err != nil {
+		return "", err
+	}
+
+	err = m.magicLinkRepo.SaveMagicLink(ctx, linkID, &model.MagicLink{
+		UserID:    user.ID,
+		Email:     user.Email,
+		NonceHash: hashNonce(nonce),
+	}, MagicLinkExpiration)
+	if err != nil {
+		return "", err
+	}
+
+	err = m.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Your login link",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to log in. It works once, only in the browser you requested it from, and expires in %d minutes.\n\n%s\n\nIf you did not ask for it, you can ignore this email.\n",
+			user.DisplayName, int(MagicLinkExpiration.Minutes()), link,
+		),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return nonce, nil
+}
+
+// buildLink adds the token to the configured link URL.
+func (m *magicLinkService) buildLink(token string) (string, error) {
+	u, err := url.Parse(m.linkURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,203 @@
+package magiclink
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockMagicLinkRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/magiclink/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	mockMailer "github.com/vukieuhaihoa/user-service/internal/mailer/mocks"
+)
+
+const (
+	testSecret  = "test-secret"
+	testLinkURL = "https://app.example.com/login/magic-link"
+)
+
+var testUser = &model.User{
+	Base:        model.Base{ID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+	Username:    "testuser001",
+	DisplayName: "Test User 1",
+	Email:       "testuser001@example.com",
+}
+
+func TestService_Request(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputEmail string
+
+		setupMockMagicLinkRepo func(ctx context.Context) *mockMagicLinkRepo.Repository
+		setupMockUserRepo      func(ctx context.Context) *mockUserRepo.Repository
+		setupMockCodeGen       func() *mockUtils.CodeGenerator
+		setupMockMailer        func(ctx context.Context) *mockMailer.Mailer
+
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:       "Send a link to an existing user",
+			inputEmail: " TestUser001@example.com ",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("IncreaseRequestCount", ctx, "testuser001@example.com", RequestWindow).Return(int64(1), nil)
+				repoMock.On("SaveMagicLink", ctx, "link-001", &model.MagicLink{
+					UserID:    testUser.ID,
+					Email:     testUser.Email,
+					NonceHash: hashNonce("nonce-001"),
+				}, MagicLinkExpiration).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "TestUser001@example.com").Return(testUser, nil)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", nonceLength).Return("nonce-001", nil).Once()
+				codeGenMock.On("GenerateCode", linkIDLength).Return("link-001", nil).Once()
+				return codeGenMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.To == testUser.Email &&
+						strings.Contains(msg.Body, testLinkURL+"?token=link-001.")
+				})).Return(nil)
+				return mailerMock
+			},
+
+			expectedOutput: "nonce-001",
+		},
+		{
+			name:       "Unknown email gets a nonce without any email",
+			inputEmail: "nobody@example.com",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("IncreaseRequestCount", ctx, "nobody@example.com", RequestWindow).Return(int64(1), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "nobody@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", nonceLength).Return("nonce-001", nil)
+				return codeGenMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedOutput: "nonce-001",
+		},
+		{
+			name:       "Email is throttled",
+			inputEmail: "testuser001@example.com",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("IncreaseRequestCount", ctx, "testuser001@example.com", RequestWindow).
+					Return(int64(RequestLimit+1), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: ErrTooManyRequests,
+		},
+		{
+			name:       "Spellings of the same mailbox share the limit",
+			inputEmail: "Test.User+links@GoogleMail.com",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("IncreaseRequestCount", ctx, "testuser@gmail.com", RequestWindow).
+					Return(int64(RequestLimit+1), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: ErrTooManyRequests,
+		},
+		{
+			name:       "Mail delivery fails",
+			inputEmail: "testuser001@example.com",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("IncreaseRequestCount", ctx, "testuser001@example.com", RequestWindow).Return(int64(1), nil)
+				repoMock.On("SaveMagicLink", ctx, "link-001", mock.Anything, MagicLinkExpiration).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "testuser001@example.com").Return(testUser, nil)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", nonceLength).Return("nonce-001", nil).Once()
+				codeGenMock.On("GenerateCode", linkIDLength).Return("link-001", nil).Once()
+				return codeGenMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(assert.AnError)
+				return mailerMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			magicLinkService := NewMagicLinkService(
+				tc.setupMockMagicLinkRepo(ctx),
+				tc.setupMockUserRepo(ctx),
+				nil,
+				tc.setupMockCodeGen(),
+				tc.setupMockMailer(ctx),
+				testSecret,
+				testLinkURL,
+			)
+
+			res, err := magicLinkService.Request(ctx, tc.inputEmail)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package magiclink provides passwordless login through single-use links sent by email.
+// A link is signed with a server secret, expires quickly and only works on the device
+// that requested it, which holds the matching nonce cookie.
+package magiclink
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	magicLinkRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/magiclink"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+const (
+	MagicLinkExpiration = 15 * time.Minute
+
+	// RequestLimit is how many links can be requested for one email within RequestWindow.
+	RequestLimit  = 3
+	RequestWindow = 15 * time.Minute
+
+	linkIDLength = 32
+	nonceLength  = 32
+)
+
+var (
+	ErrTooManyRequests  = errors.New("too many login links requested, please try again later")
+	ErrInvalidMagicLink = errors.New("invalid or expired login link")
+)
+
+// Service represents the interface for magic link login operations.
+//
+//go:generate mockery --name=Service --filename=magic_link_service.go --output=./mocks
+type Service interface {
+	// Request sends a login link to the user owning the email.
+	// Unknown emails get the same result without an email being sent, so accounts cannot be enumerated.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - email: The email address of the user.
+	//
+	// Returns:
+	//   - string: The nonce the requesting device must present with the link.
+	//   - error: ErrTooManyRequests if the email is throttled, otherwise any storage or delivery error.
+	Request(ctx context.Context, email string) (string, error)
+
+	// Verify exchanges a login link for the same token a password login returns.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - token: The token carried by the link.
+	//   - nonce: The nonce held by the device presenting the link.
+	//
+	// Returns:
+	//   - string: The JWT token if the link is valid.
+	//   - error: ErrInvalidMagicLink if the link is forged, used, expired or presented by another device.
+	Verify(ctx context.Context, token, nonce string) (string, error)
+}
+
+// magicLinkService is the concrete implementation of the Service interface.
+type magicLinkService struct {
+	magicLinkRepo magicLinkRepository.Repository
+	userRepo      userRepository.Repository
+	userSvc       userService.Service
+	codeGen       utils.CodeGenerator
+	mailer        mailer.Mailer
+	secret        []byte
+	linkURL       string
+}
+
+// NewMagicLinkService creates a new instance of the magic link service.
+//
+// Parameters:
+//   - magicLinkRepo: The repository storing pending links and request counters.
+//   - userRepo: The user repository used to look up users.
+//   - userSvc: The user service issuing the access token.
+//   - codeGen: The random code generator used for link identifiers and nonces.
+//   - mailer: The mailer delivering the links.
+//   - secret: The key used to sign the links.
+//   - linkURL: The frontend page the link points to; the token is added as the "token" query parameter.
+//
+// Returns:
+//   - Service: A new magic link service instance.
+func NewMagicLinkService(
+	magicLinkRepo magicLinkRepository.Repository,
+	userRepo userRepository.Repository,
+	userSvc userService.Service,
+	codeGen utils.CodeGenerator,
+	mailer mailer.Mailer,
+	secret string,
+	linkURL string,
+) Service {
+	return &magicLinkService{
+		magicLinkRepo: magicLinkRepo,
+		userRepo:      userRepo,
+		userSvc:       userSvc,
+		codeGen:       codeGen,
+		mailer:        mailer,
+		secret:        []byte(secret),
+		linkURL:       linkURL,
+	}
+}
+
+// sign returns the signature of a link identifier.
+func (m *magicLinkService) sign(linkID string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(linkID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// hashNonce returns the hex SHA-256 of a nonce, so the nonce itself is never stored.
+func hashNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,251 @@
+package magiclink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockMagicLinkRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/magiclink/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockUserSvc "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+)
+
+func TestService_Verify(t *testing.T) {
+	t.Parallel()
+
+	signer := &magicLinkService{secret: []byte(testSecret)}
+	validToken := "link-001." + signer.sign("link-001")
+
+	testCases := []struct {
+		name string
+
+		inputToken string
+		inputNonce string
+
+		setupMockMagicLinkRepo func(ctx context.Context) *mockMagicLinkRepo.Repository
+		setupMockUserRepo      func(ctx context.Context) *mockUserRepo.Repository
+		setupMockUserSvc       func(ctx context.Context) *mockUserSvc.Service
+
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:       "Verify link successfully",
+			inputToken: validToken,
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, Email: testUser.Email, NonceHash: hashNonce("nonce-001")}, nil)
+				repoMock.On("ConsumeMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, Email: testUser.Email, NonceHash: hashNonce("nonce-001")}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				repoMock.On("VerifyEmailByID", ctx, testUser.ID, testUser.Email).Return(nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name:       "Verify link successfully - recording the verified email fails",
+			inputToken: validToken,
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, Email: testUser.Email, NonceHash: hashNonce("nonce-001")}, nil)
+				repoMock.On("ConsumeMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, Email: testUser.Email, NonceHash: hashNonce("nonce-001")}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				repoMock.On("VerifyEmailByID", ctx, testUser.ID, testUser.Email).Return(assert.AnError)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name:       "Verify link successfully - email changed since the link was sent",
+			inputToken: validToken,
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, Email: "old@example.com", NonceHash: hashNonce("nonce-001")}, nil)
+				repoMock.On("ConsumeMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, Email: "old@example.com", NonceHash: hashNonce("nonce-001")}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name:       "Forged signature",
+			inputToken: "link-001.forged",
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				return mockMagicLinkRepo.NewRepository(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidMagicLink,
+		},
+		{
+			name:       "Malformed token",
+			inputToken: "link-001",
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				return mockMagicLinkRepo.NewRepository(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidMagicLink,
+		},
+		{
+			name:       "Link already used or expired",
+			inputToken: validToken,
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidMagicLink,
+		},
+		{
+			name:       "Link used by another verification meanwhile",
+			inputToken: validToken,
+			inputNonce: "nonce-001",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, NonceHash: hashNonce("nonce-001")}, nil)
+				repoMock.On("ConsumeMagicLink", ctx, "link-001").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidMagicLink,
+		},
+		{
+			name:       "Link presented by another device",
+			inputToken: validToken,
+			inputNonce: "other-device-nonce",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				// The link is not consumed, so the device that requested it can still use it
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, NonceHash: hashNonce("nonce-001")}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidMagicLink,
+		},
+		{
+			name:       "Link presented without a nonce",
+			inputToken: validToken,
+			inputNonce: "",
+
+			setupMockMagicLinkRepo: func(ctx context.Context) *mockMagicLinkRepo.Repository {
+				repoMock := mockMagicLinkRepo.NewRepository(t)
+				repoMock.On("GetMagicLink", ctx, "link-001").
+					Return(&model.MagicLink{UserID: testUser.ID, NonceHash: hashNonce("nonce-001")}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidMagicLink,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			magicLinkService := NewMagicLinkService(
+				tc.setupMockMagicLinkRepo(ctx),
+				tc.setupMockUserRepo(ctx),
+				tc.setupMockUserSvc(ctx),
+				nil,
+				nil,
+				testSecret,
+				testLinkURL,
+			)
+
+			res, err := magicLinkService.Verify(ctx, tc.inputToken, tc.inputNonce)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
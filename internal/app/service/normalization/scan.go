@@ -0,0 +1,164 @@
+package normalization
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// scannedUser is the part of a user the scan keeps until every user was seen.
+type scannedUser struct {
+	id                 string
+	usernameNormalized *string
+	emailNormalized    *string
+}
+
+// Scan walks through every user and reports the collisions of canonical forms, backfilling them when asked.
+// Identifiers only collide within a tenant, and only the users of the tenant of the context are walked through
+// unless it lifts the tenant scope.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - backfill: Whether to write the canonical forms.
+//
+// Returns:
+//   - *Report: The collisions and the number of rows backfilled.
+//   - error: An error if a retrieval or a write fails, otherwise nil.
+func (svc *normalizationService) Scan(ctx context.Context, backfill bool) (*Report, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_Scan")
+	defer s.End()
+
+	usernames := newGroups(FieldUsername)
+	emails := newGroups(FieldEmail)
+	users := []*scannedUser{}
+
+	afterID := ""
+	for {
+		page, err := svc.userRepo.ListUsers(ctx, afterID, BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, user := range page {
+			usernames.add(user.TenantID, user.ID, user.Username, normalize.Username(user.Username))
+			emails.add(user.TenantID, user.ID, user.Email, normalize.Email(user.Email))
+			users = append(users, &scannedUser{
+				id:                 user.ID,
+				usernameNormalized: user.UsernameNormalized,
+				emailNormalized:    user.EmailNormalized,
+			})
+		}
+		afterID = page[len(page)-1].ID
+	}
+
+	report := &Report{
+		Users:      len(users),
+		Collisions: append(usernames.collisions(), emails.collisions()...),
+	}
+	if !backfill {
+		return report, nil
+	}
+
+	for _, user := range users {
+		usernameNormalized := usernames.canonical(user.id, user.usernameNormalized)
+		emailNormalized := emails.canonical(user.id, user.emailNormalized)
+		if equal(usernameNormalized, user.usernameNormalized) && equal(emailNormalized, user.emailNormalized) {
+			continue
+		}
+
+		if err := svc.userRepo.SetNormalizedIdentifiers(ctx, user.id, usernameNormalized, emailNormalized); err != nil {
+			return nil, err
+		}
+		report.UsersBackfilled++
+	}
+
+	changes, err := svc.userRepo.NormalizeUsernameHistory(ctx, BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	report.ChangesBackfilled = changes
+
+	return report, nil
+}
+
+// groups gathers the users by the canonical form of one of their identifiers, within their tenant.
+type groups struct {
+	field    string
+	byForm   map[formKey]*Collision
+	formByID map[string]formKey
+}
+
+// formKey is a canonical form in a tenant.
+type formKey struct {
+	tenantID   string
+	normalized string
+}
+
+func newGroups(field string) *groups {
+	return &groups{
+		field:    field,
+		byForm:   map[formKey]*Collision{},
+		formByID: map[string]formKey{},
+	}
+}
+
+// add records the identifier value of a user of a tenant and its canonical form. Empty identifiers are not recorded.
+func (g *groups) add(tenantID, id, value, normalized string) {
+	if normalized == "" {
+		return
+	}
+
+	key := formKey{tenantID: tenantID, normalized: normalized}
+	group, ok := g.byForm[key]
+	if !ok {
+		group = &Collision{Field: g.field, TenantID: tenantID, Normalized: normalized}
+		g.byForm[key] = group
+	}
+	group.UserIDs = append(group.UserIDs, id)
+	group.Values = append(group.Values, value)
+	g.formByID[id] = key
+}
+
+// collisions returns the canonical forms shared by several users, in order.
+func (g *groups) collisions() []*Collision {
+	collisions := []*Collision{}
+	for _, group := range g.byForm {
+		if len(group.UserIDs) > 1 {
+			collisions = append(collisions, group)
+		}
+	}
+	slices.SortFunc(collisions, func(a, b *Collision) int {
+		if c := strings.Compare(a.TenantID, b.TenantID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Normalized, b.Normalized)
+	})
+
+	return collisions
+}
+
+// canonical returns the canonical form the user should store: its own unless it collides, in which case the stored
+// one is kept.
+func (g *groups) canonical(id string, stored *string) *string {
+	key, ok := g.formByID[id]
+	if !ok || len(g.byForm[key].UserIDs) > 1 {
+		return stored
+	}
+
+	return &key.normalized
+}
+
+// equal reports whether two optional canonical forms are the same.
+func equal(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
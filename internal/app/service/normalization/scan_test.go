@@ -0,0 +1,142 @@
+package normalization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestService_Scan(t *testing.T) {
+	t.Parallel()
+
+	alice := &model.User{Base: model.Base{ID: "id-1"}, Username: "Alice", Email: "alice@example.com"}
+	aliceUpper := &model.User{Base: model.Base{ID: "id-2"}, Username: "ALICE", Email: "a.lice+news@gmail.com"}
+	aliceGmail := &model.User{Base: model.Base{ID: "id-3"}, Username: "alice.g", Email: "Alice@googlemail.com"}
+	bob := &model.User{
+		Base:               model.Base{ID: "id-4"},
+		Username:           "Bob",
+		Email:              "bob@example.com",
+		UsernameNormalized: stringPtr("bob"),
+		EmailNormalized:    stringPtr("bob@example.com"),
+	}
+	users := []*model.User{alice, aliceUpper, aliceGmail, bob}
+
+	expectedCollisions := []*Collision{
+		{Field: FieldUsername, Normalized: "alice", UserIDs: []string{"id-1", "id-2"}, Values: []string{"Alice", "ALICE"}},
+		{Field: FieldEmail, Normalized: "alice@gmail.com", UserIDs: []string{"id-2", "id-3"}, Values: []string{"a.lice+news@gmail.com", "Alice@googlemail.com"}},
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockUserRepo func(ctx context.Context) *mockUserRepo.Repository
+		inputBackfill     bool
+
+		expectedError  error
+		expectedOutput *Report
+	}{
+		{
+			name: "Report the collisions",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("ListUsers", ctx, "", BatchSize).Return(users[:2], nil).Once()
+				repoMock.On("ListUsers", ctx, "id-2", BatchSize).Return(users[2:], nil).Once()
+				repoMock.On("ListUsers", ctx, "id-4", BatchSize).Return([]*model.User{}, nil).Once()
+				return repoMock
+			},
+
+			expectedOutput: &Report{Users: 4, Collisions: expectedCollisions},
+		},
+		{
+			name: "Identifiers of different tenants do not collide",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				brandAlice := &model.User{Base: model.Base{ID: "id-5"}, TenantID: "brand_a", Username: "alice", Email: "Alice@example.com"}
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("ListUsers", ctx, "", BatchSize).Return([]*model.User{alice, brandAlice}, nil).Once()
+				repoMock.On("ListUsers", ctx, "id-5", BatchSize).Return([]*model.User{}, nil).Once()
+				return repoMock
+			},
+
+			expectedOutput: &Report{Users: 2, Collisions: []*Collision{}},
+		},
+		{
+			name: "Backfill the users outside of a collision and the username history",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("ListUsers", ctx, "", BatchSize).Return(users, nil).Once()
+				repoMock.On("ListUsers", ctx, "id-4", BatchSize).Return([]*model.User{}, nil).Once()
+				repoMock.On("SetNormalizedIdentifiers", ctx, "id-1", (*string)(nil), stringPtr("alice@example.com")).Return(nil).Once()
+				repoMock.On("SetNormalizedIdentifiers", ctx, "id-3", stringPtr("alice.g"), (*string)(nil)).Return(nil).Once()
+				repoMock.On("NormalizeUsernameHistory", ctx, BatchSize).Return(2, nil).Once()
+				return repoMock
+			},
+			inputBackfill: true,
+
+			expectedOutput: &Report{Users: 4, Collisions: expectedCollisions, UsersBackfilled: 2, ChangesBackfilled: 2},
+		},
+		{
+			name: "Fail to scan - list error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("ListUsers", ctx, "", BatchSize).Return(nil, assert.AnError).Once()
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Fail to backfill - canonical form taken",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("ListUsers", ctx, "", BatchSize).Return(users[:1], nil).Once()
+				repoMock.On("ListUsers", ctx, "id-1", BatchSize).Return([]*model.User{}, nil).Once()
+				repoMock.On("SetNormalizedIdentifiers", ctx, "id-1", stringPtr("alice"), stringPtr("alice@example.com")).
+					Return(dbutils.ErrDuplicationType).Once()
+				return repoMock
+			},
+			inputBackfill: true,
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Fail to backfill - username history error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("ListUsers", ctx, "", BatchSize).Return([]*model.User{bob}, nil).Once()
+				repoMock.On("ListUsers", ctx, "id-4", BatchSize).Return([]*model.User{}, nil).Once()
+				repoMock.On("NormalizeUsernameHistory", ctx, BatchSize).Return(0, assert.AnError).Once()
+				return repoMock
+			},
+			inputBackfill: true,
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			normalizationService := NewNormalizationService(tc.setupMockUserRepo(ctx))
+
+			res, err := normalizationService.Scan(ctx, tc.inputBackfill)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package normalization reports the users whose usernames or email addresses share a canonical form, and
+// backfills the canonical forms of the users created before they were stored.
+// Users sharing a canonical form cannot all hold it under the unique indexes, so their canonical forms are left
+// unset: lookups then only match their identifiers exactly, until the collisions are resolved by hand.
+package normalization
+
+import (
+	"context"
+
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+)
+
+// BatchSize is how many users or username changes are loaded at once.
+const BatchSize = 500
+
+const (
+	// FieldUsername is the Collision.Field of usernames.
+	FieldUsername = "username"
+
+	// FieldEmail is the Collision.Field of email addresses.
+	FieldEmail = "email"
+)
+
+// Collision is a canonical form shared by the identifiers of several users of a tenant.
+type Collision struct {
+	// Field is FieldUsername or FieldEmail.
+	Field string
+	// TenantID is the tenant of the users.
+	TenantID string
+	// Normalized is the shared canonical form.
+	Normalized string
+	// UserIDs are the IDs of the users sharing it.
+	UserIDs []string
+	// Values are the identifiers of the users as stored, in the order of UserIDs.
+	Values []string
+}
+
+// Report is the outcome of a scan of the users.
+type Report struct {
+	// Users is the number of users scanned.
+	Users int
+	// Collisions are the shared canonical forms, ordered by field, tenant and canonical form.
+	Collisions []*Collision
+	// UsersBackfilled is the number of users whose canonical forms were written.
+	UsersBackfilled int
+	// ChangesBackfilled is the number of username changes whose canonical form was written.
+	ChangesBackfilled int
+}
+
+// Service represents the interface for the normalization maintenance operations.
+//
+//go:generate mockery --name=Service --filename=normalization_service.go --output=./mocks
+type Service interface {
+	// Scan walks through every user and reports the collisions of canonical forms.
+	// With backfill, the missing or outdated canonical forms of the users outside of a collision are written, and
+	// so are those of the username history.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - backfill: Whether to write the canonical forms.
+	//
+	// Returns:
+	//   - *Report: The collisions and the number of rows backfilled.
+	//   - error: An error if a retrieval or a write fails, otherwise nil.
+	Scan(ctx context.Context, backfill bool) (*Report, error)
+}
+
+type normalizationService struct {
+	userRepo userRepository.Repository
+}
+
+// NewNormalizationService creates a new instance of the normalization service.
+//
+// Parameters:
+//   - userRepo: The repository storing the users and their username history.
+//
+// Returns:
+//   - Service: A new normalization service instance.
+func NewNormalizationService(userRepo userRepository.Repository) Service {
+	return &normalizationService{
+		userRepo: userRepo,
+	}
+}
@@ -0,0 +1,64 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// AcceptInvitation adds the user to the organization of the invitation carrying the token, with the role of
+// the invitation. The email address of the user must be the invited one, compared in their canonical forms.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user, who must own the invited email address.
+//   - token: The token carried by the invitation link.
+//
+// Returns:
+//   - *model.Membership: The created membership.
+//   - error: ErrInvalidInvitation if the invitation is unknown, used, revoked, superseded or expired,
+//     ErrInvitationEmailMismatch if the user has another email address, ErrAlreadyMember if the user already
+//     is a member, otherwise an error if the creation fails.
+func (svc *organizationService) AcceptInvitation(ctx context.Context, userID, token string) (*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_AcceptOrganizationInvitation")
+	defer s.End()
+
+	invitation, err := svc.organizationRepo.GetInvitationByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, ErrInvalidInvitation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.Status != model.InvitationPending || !time.Now().Before(invitation.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+
+	user, err := svc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if normalize.Email(invitation.Email) != normalize.Email(user.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	membership, err := svc.organizationRepo.AcceptInvitation(ctx, invitation, userID)
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+		// The invitation was accepted, revoked or superseded meanwhile.
+		return nil, ErrInvalidInvitation
+	case errors.Is(err, dbutils.ErrDuplicationType):
+		return nil, ErrAlreadyMember
+	case err != nil:
+		return nil, err
+	}
+
+	return membership, nil
+}
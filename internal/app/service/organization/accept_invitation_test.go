@@ -0,0 +1,167 @@
+package organization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func TestService_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	invitationWith := func(status string, expiresAt time.Time) *model.OrganizationInvitation {
+		return &model.OrganizationInvitation{
+			Base:           model.Base{ID: "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01"},
+			OrganizationID: testOrganizationID,
+			Email:          "Invitee@Example.com",
+			Role:           model.OrganizationAdmin,
+			Status:         status,
+			ExpiresAt:      expiresAt,
+		}
+	}
+	pendingInvitation := invitationWith(model.InvitationPending, time.Now().Add(time.Hour))
+	userWithEmail := func(email string) *model.User {
+		return &model.User{Base: model.Base{ID: testUserID}, Email: email}
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+		setupMockUserRepo         func(ctx context.Context) *mockUserRepo.Repository
+
+		expectedOutput *model.Membership
+		expectedError  error
+	}{
+		{
+			name: "Accept invitation successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation, nil)
+				repoMock.On("AcceptInvitation", ctx, pendingInvitation, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUserID).Return(userWithEmail("invitee@example.com"), nil)
+				return repoMock
+			},
+
+			expectedOutput: testMembership(model.OrganizationAdmin),
+		},
+		{
+			name: "Accept invitation failed - unknown token",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - invitation revoked",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).
+					Return(invitationWith(model.InvitationRevoked, time.Now().Add(time.Hour)), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - invitation expired",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).
+					Return(invitationWith(model.InvitationPending, time.Now().Add(-time.Minute)), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - user has another email address",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUserID).Return(userWithEmail("someone@example.com"), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInvitationEmailMismatch,
+		},
+		{
+			name: "Accept invitation failed - invitation accepted meanwhile",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation, nil)
+				repoMock.On("AcceptInvitation", ctx, pendingInvitation, testUserID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUserID).Return(userWithEmail("invitee@example.com"), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInvalidInvitation,
+		},
+		{
+			name: "Accept invitation failed - user already is a member",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetInvitationByTokenHash", ctx, hashToken("invite-001")).Return(pendingInvitation, nil)
+				repoMock.On("AcceptInvitation", ctx, pendingInvitation, testUserID).Return(nil, dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUserID).Return(userWithEmail("invitee@example.com"), nil)
+				return repoMock
+			},
+
+			expectedError: ErrAlreadyMember,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), tc.setupMockUserRepo(ctx), nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.AcceptInvitation(ctx, testUserID, "invite-001")
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+// CreateInvitation invites an email address to join an organization and sends it the invitation link.
+// The address does not need to be registered yet: its owner accepts the invitation once logged in.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//   - email: The invited email address.
+//   - role: The role granted to the invited user, model.OrganizationMember when empty.
+//
+// Returns:
+//   - *model.OrganizationInvitation: The created invitation.
+//   - error: ErrUnsupportedRole if the role is unknown or is the owner role, ErrOrganizationNotFound if the
+//     user is not a member, ErrInsufficientRole if the user is not an admin, ErrAlreadyMember if the user with
+//     the address is a member, otherwise an error if the invitation cannot be stored or the email cannot be sent.
+func (svc *organizationService) CreateInvitation(ctx context.Context, userID, organizationID, email, role string) (*model.OrganizationInvitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CreateOrganizationInvitation")
+	defer s.End()
+
+	role, err := checkGrantableRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = svc.requireRole(ctx, userID, organizationID, model.OrganizationAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, err := svc.userRepo.GetUserByEmail(ctx, email)
+	switch {
+	case err == nil:
+		_, err = svc.organizationRepo.GetMembership(ctx, organizationID, invitee.ID)
+		if err == nil {
+			return nil, ErrAlreadyMember
+		}
+		if !errors.Is(err, dbutils.ErrRecordNotFoundType) {
+			return nil, err
+		}
+	case !errors.Is(err, dbutils.ErrRecordNotFoundType):
+		return nil, err
+	}
+
+	organization, err := svc.organizationRepo.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := svc.codeGen.GenerateCode(tokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := buildLink(svc.invitationURL, token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := svc.organizationRepo.CreateInvitation(ctx, &model.OrganizationInvitation{
+		OrganizationID: organizationID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hashToken(token),
+		Status:         model.InvitationPending,
+		ExpiresAt:      time.Now().Add(svc.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: fmt.Sprintf("You are invited to join %s", organization.Name),
+		Body: fmt.Sprintf(
+			"Hi,\n\nYou are invited to join the organization %s. Log in with this email address and open the link below; it expires in %d hours.\n\n%s\n\nIf you did not expect it, you can ignore this email.\n",
+			organization.Name, int(svc.ttl.Hours()), link,
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
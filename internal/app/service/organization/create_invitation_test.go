@@ -0,0 +1,215 @@
+package organization
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	mockMailer "github.com/vukieuhaihoa/user-service/internal/mailer/mocks"
+)
+
+const (
+	testInvitationURL  = "https://app.example.com/organizations/join"
+	testTTL            = 7 * 24 * time.Hour
+	testOrganizationID = "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01"
+	testUserID         = "de305d54-75b4-431b-adb2-eb6b9e546000"
+	testMemberID       = "987e6543-e21b-12d3-a456-eb6b9e546002"
+)
+
+// testMembership returns the membership of the test user in the test organization with the role.
+func testMembership(role string) *model.Membership {
+	return &model.Membership{OrganizationID: testOrganizationID, UserID: testUserID, Role: role}
+}
+
+func TestService_CreateInvitation(t *testing.T) {
+	t.Parallel()
+
+	isPendingInvitation := func(role string) any {
+		return mock.MatchedBy(func(invitation *model.OrganizationInvitation) bool {
+			return invitation.OrganizationID == testOrganizationID &&
+				invitation.Email == "invitee@example.com" &&
+				invitation.Role == role &&
+				invitation.TokenHash == hashToken("invite-001") &&
+				invitation.Status == model.InvitationPending &&
+				time.Until(invitation.ExpiresAt) > testTTL-time.Minute
+		})
+	}
+
+	testCases := []struct {
+		name string
+
+		inputRole string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+		setupMockUserRepo         func(ctx context.Context) *mockUserRepo.Repository
+		setupMockMailer           func(ctx context.Context) *mockMailer.Mailer
+		generatesToken            bool
+
+		expectedOutput *model.OrganizationInvitation
+		expectedError  error
+	}{
+		{
+			name: "Create invitation of an unregistered address successfully",
+
+			inputRole: model.OrganizationAdmin,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetOrganizationByID", ctx, testOrganizationID).Return(&model.Organization{Name: "Acme"}, nil)
+				repoMock.On("CreateInvitation", ctx, isPendingInvitation(model.OrganizationAdmin)).
+					Return(&model.OrganizationInvitation{Email: "invitee@example.com", Role: model.OrganizationAdmin}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.To == "invitee@example.com" &&
+						msg.Subject == "You are invited to join Acme" &&
+						strings.Contains(msg.Body, testInvitationURL+"?token=invite-001") &&
+						strings.Contains(msg.Body, "expires in 168 hours")
+				})).Return(nil)
+				return mailerMock
+			},
+			generatesToken: true,
+
+			expectedOutput: &model.OrganizationInvitation{Email: "invitee@example.com", Role: model.OrganizationAdmin},
+		},
+		{
+			name: "Create invitation of a registered non-member without a role grants the member role",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, "invitee-id").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("GetOrganizationByID", ctx, testOrganizationID).Return(&model.Organization{Name: "Acme"}, nil)
+				repoMock.On("CreateInvitation", ctx, isPendingInvitation(model.OrganizationMember)).
+					Return(&model.OrganizationInvitation{Email: "invitee@example.com", Role: model.OrganizationMember}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(&model.User{Base: model.Base{ID: "invitee-id"}}, nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(nil)
+				return mailerMock
+			},
+			generatesToken: true,
+
+			expectedOutput: &model.OrganizationInvitation{Email: "invitee@example.com", Role: model.OrganizationMember},
+		},
+		{
+			name: "Create invitation failed - owner role",
+
+			inputRole: model.OrganizationOwner,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				return mockOrganizationRepo.NewRepository(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: ErrUnsupportedRole,
+		},
+		{
+			name: "Create invitation failed - user is a member only",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+		{
+			name: "Create invitation failed - address belongs to a member",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(&model.Membership{UserID: testMemberID}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(&model.User{Base: model.Base{ID: testMemberID}}, nil)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				return mockMailer.NewMailer(t)
+			},
+
+			expectedError: ErrAlreadyMember,
+		},
+		{
+			name: "Create invitation failed - mailer error",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetOrganizationByID", ctx, testOrganizationID).Return(&model.Organization{Name: "Acme"}, nil)
+				repoMock.On("CreateInvitation", ctx, mock.Anything).Return(&model.OrganizationInvitation{}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockMailer: func(ctx context.Context) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", ctx, mock.Anything).Return(assert.AnError)
+				return mailerMock
+			},
+			generatesToken: true,
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			codeGenMock := mockUtils.NewCodeGenerator(t)
+			if tc.generatesToken {
+				codeGenMock.On("GenerateCode", tokenLength).Return("invite-001", nil).Once()
+			}
+
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), tc.setupMockUserRepo(ctx), codeGenMock, tc.setupMockMailer(ctx), testInvitationURL, testTTL)
+
+			res, err := testSvc.CreateInvitation(ctx, testUserID, testOrganizationID, "invitee@example.com", tc.inputRole)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
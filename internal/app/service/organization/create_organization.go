@@ -0,0 +1,25 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateOrganization creates an organization owned by the user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user creating the organization.
+//   - name: The name of the organization.
+//
+// Returns:
+//   - *model.Organization: The created organization.
+//   - error: An error if the creation fails, otherwise nil.
+func (svc *organizationService) CreateOrganization(ctx context.Context, userID, name string) (*model.Organization, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CreateOrganization")
+	defer s.End()
+
+	return svc.organizationRepo.CreateOrganization(ctx, &model.Organization{Name: name}, userID)
+}
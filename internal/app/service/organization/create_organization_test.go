@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_CreateOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedOutput *model.Organization
+		expectedError  error
+	}{
+		{
+			name: "Create organization successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("CreateOrganization", ctx, &model.Organization{Name: "Acme"}, testUserID).
+					Return(&model.Organization{Base: model.Base{ID: testOrganizationID}, Name: "Acme"}, nil)
+				return repoMock
+			},
+
+			expectedOutput: &model.Organization{Base: model.Base{ID: testOrganizationID}, Name: "Acme"},
+		},
+		{
+			name: "Create organization failed - repository error",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("CreateOrganization", ctx, &model.Organization{Name: "Acme"}, testUserID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.CreateOrganization(ctx, testUserID, "Acme")
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DeleteOrganization deletes an organization with its memberships and invitations.
+// The user must be the owner of the organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//
+// Returns:
+//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not the
+//     owner, otherwise any deletion error.
+func (svc *organizationService) DeleteOrganization(ctx context.Context, userID, organizationID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_DeleteOrganization")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationOwner)
+	if err != nil {
+		return err
+	}
+
+	return svc.organizationRepo.DeleteOrganization(ctx, organizationID)
+}
@@ -0,0 +1,68 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_DeleteOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Delete organization successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("DeleteOrganization", ctx, testOrganizationID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Delete organization failed - user is an admin only",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+		{
+			name: "Delete organization failed - repository error",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("DeleteOrganization", ctx, testOrganizationID).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			err := testSvc.DeleteOrganization(ctx, testUserID, testOrganizationID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
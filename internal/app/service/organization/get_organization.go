@@ -0,0 +1,30 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// GetOrganization retrieves an organization the user belongs to.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//
+// Returns:
+//   - *model.Organization: The organization.
+//   - error: ErrOrganizationNotFound if the user is not a member, otherwise any retrieval error.
+func (svc *organizationService) GetOrganization(ctx context.Context, userID, organizationID string) (*model.Organization, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_GetOrganization")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.organizationRepo.GetOrganizationByID(ctx, organizationID)
+}
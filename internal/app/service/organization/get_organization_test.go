@@ -0,0 +1,73 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_GetOrganization(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedOutput *model.Organization
+		expectedError  error
+	}{
+		{
+			name: "Get organization successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				repoMock.On("GetOrganizationByID", ctx, testOrganizationID).
+					Return(&model.Organization{Base: model.Base{ID: testOrganizationID}, Name: "Acme"}, nil)
+				return repoMock
+			},
+
+			expectedOutput: &model.Organization{Base: model.Base{ID: testOrganizationID}, Name: "Acme"},
+		},
+		{
+			name: "Get organization failed - user is not a member",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrOrganizationNotFound,
+		},
+		{
+			name: "Get organization failed - repository error",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.GetOrganization(ctx, testUserID, testOrganizationID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListInvitations retrieves the invitations to join an organization. The user must be an admin of the
+// organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//
+// Returns:
+//   - []*model.OrganizationInvitation: The invitations, newest first.
+//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not an
+//     admin, otherwise any retrieval error.
+func (svc *organizationService) ListInvitations(ctx context.Context, userID, organizationID string) ([]*model.OrganizationInvitation, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListOrganizationInvitations")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.organizationRepo.ListInvitations(ctx, organizationID)
+}
@@ -0,0 +1,61 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_ListInvitations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedOutput []*model.OrganizationInvitation
+		expectedError  error
+	}{
+		{
+			name: "List invitations successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("ListInvitations", ctx, testOrganizationID).
+					Return([]*model.OrganizationInvitation{{Email: "invitee@example.com"}}, nil)
+				return repoMock
+			},
+
+			expectedOutput: []*model.OrganizationInvitation{{Email: "invitee@example.com"}},
+		},
+		{
+			name: "List invitations failed - user is a member only",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.ListInvitations(ctx, testUserID, testOrganizationID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
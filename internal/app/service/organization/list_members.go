@@ -0,0 +1,30 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListMembers retrieves the memberships of an organization the user belongs to, along with their users.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//
+// Returns:
+//   - []*model.Membership: The memberships, oldest first.
+//   - error: ErrOrganizationNotFound if the user is not a member, otherwise any retrieval error.
+func (svc *organizationService) ListMembers(ctx context.Context, userID, organizationID string) ([]*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListMembers")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.organizationRepo.ListMembers(ctx, organizationID)
+}
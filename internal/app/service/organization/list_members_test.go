@@ -0,0 +1,61 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_ListMembers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedOutput []*model.Membership
+		expectedError  error
+	}{
+		{
+			name: "List members successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				repoMock.On("ListMembers", ctx, testOrganizationID).Return([]*model.Membership{testMembership(model.OrganizationMember)}, nil)
+				return repoMock
+			},
+
+			expectedOutput: []*model.Membership{testMembership(model.OrganizationMember)},
+		},
+		{
+			name: "List members failed - user is not a member",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrOrganizationNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			res, err := testSvc.ListMembers(ctx, testUserID, testOrganizationID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
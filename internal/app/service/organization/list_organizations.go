@@ -0,0 +1,24 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListOrganizations retrieves the memberships of the user along with their organizations.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//
+// Returns:
+//   - []*model.Membership: The memberships, oldest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (svc *organizationService) ListOrganizations(ctx context.Context, userID string) ([]*model.Membership, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListOrganizations")
+	defer s.End()
+
+	return svc.organizationRepo.ListMembershipsByUserID(ctx, userID)
+}
@@ -0,0 +1,58 @@
+package organization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// RemoveMember removes a member from an organization. Any member may leave, but only admins remove others.
+// The owner cannot leave before transferring the ownership, so an organization always has an owner.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//   - memberID: The ID of the member, userID to leave the organization.
+//
+// Returns:
+//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user removes
+//     another member without being an admin, ErrMemberNotFound if memberID is not a member,
+//     ErrOwnerMembership if memberID is the owner, otherwise any deletion error.
+func (svc *organizationService) RemoveMember(ctx context.Context, userID, organizationID, memberID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RemoveMember")
+	defer s.End()
+
+	requiredRole := model.OrganizationAdmin
+	if memberID == userID {
+		requiredRole = model.OrganizationMember
+	}
+
+	_, err := svc.requireRole(ctx, userID, organizationID, requiredRole)
+	if err != nil {
+		return err
+	}
+
+	member, err := svc.organizationRepo.GetMembership(ctx, organizationID, memberID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrMemberNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if member.Role == model.OrganizationOwner {
+		return ErrOwnerMembership
+	}
+
+	err = svc.organizationRepo.DeleteMembership(ctx, organizationID, memberID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		// The member left or became the owner meanwhile.
+		return ErrMemberNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,118 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_RemoveMember(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputMemberID string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Remove member successfully",
+
+			inputMemberID: testMemberID,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(&model.Membership{UserID: testMemberID, Role: model.OrganizationAdmin}, nil)
+				repoMock.On("DeleteMembership", ctx, testOrganizationID, testMemberID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Leave organization successfully",
+
+			inputMemberID: testUserID,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				repoMock.On("DeleteMembership", ctx, testOrganizationID, testUserID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Remove member failed - user is a member only",
+
+			inputMemberID: testMemberID,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+		{
+			name: "Remove member failed - member not found",
+
+			inputMemberID: testMemberID,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrMemberNotFound,
+		},
+		{
+			name: "Leave organization failed - user is the owner",
+
+			inputMemberID: testUserID,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				return repoMock
+			},
+
+			expectedError: ErrOwnerMembership,
+		},
+		{
+			name: "Remove member failed - repository error",
+
+			inputMemberID: testMemberID,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(&model.Membership{UserID: testMemberID, Role: model.OrganizationMember}, nil)
+				repoMock.On("DeleteMembership", ctx, testOrganizationID, testMemberID).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			err := testSvc.RemoveMember(ctx, testUserID, testOrganizationID, tc.inputMemberID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
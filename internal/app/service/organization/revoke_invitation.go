@@ -0,0 +1,40 @@
+package organization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// RevokeInvitation revokes a pending invitation, so its link stops working. The user must be an admin of the
+// organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//   - id: The ID of the invitation.
+//
+// Returns:
+//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not an
+//     admin, ErrInvitationNotFound if the organization has no pending invitation with the ID, otherwise any
+//     storage error.
+func (svc *organizationService) RevokeInvitation(ctx context.Context, userID, organizationID, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RevokeOrganizationInvitation")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationAdmin)
+	if err != nil {
+		return err
+	}
+
+	err = svc.organizationRepo.RevokeInvitation(ctx, organizationID, id)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrInvitationNotFound
+	}
+
+	return err
+}
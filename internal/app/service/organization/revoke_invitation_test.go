@@ -0,0 +1,71 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_RevokeInvitation(t *testing.T) {
+	t.Parallel()
+
+	const invitationID = "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01"
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Revoke invitation successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("RevokeInvitation", ctx, testOrganizationID, invitationID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Revoke invitation failed - user is a member only",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+		{
+			name: "Revoke invitation failed - no pending invitation",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("RevokeInvitation", ctx, testOrganizationID, invitationID).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrInvitationNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			err := testSvc.RevokeInvitation(ctx, testUserID, testOrganizationID, invitationID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,297 @@
+// Package organization provides the organizations users belong to.
+// A user creating an organization becomes its owner. Admins rename the organization, manage the roles of its
+// members and invite email addresses to join it; the owner alone deletes it or transfers the ownership to another
+// member. Members only see the organizations they belong to.
+package organization
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	organizationRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/organization"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+const tokenLength = 32
+
+var (
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrInsufficientRole        = errors.New("your role in the organization does not allow this action")
+	ErrUnsupportedRole         = errors.New("unsupported role")
+	ErrMemberNotFound          = errors.New("member not found")
+	ErrOwnerMembership         = errors.New("the owner cannot leave or change role, transfer the ownership first")
+	ErrAlreadyMember           = errors.New("user already is a member of the organization")
+	ErrInvalidInvitation       = errors.New("invalid or expired invitation")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to another email address")
+	ErrInvitationNotFound      = errors.New("pending invitation not found")
+)
+
+// Service represents the interface for organization operations.
+// Every operation acts on behalf of a user, and organizations the user does not belong to are reported as
+// ErrOrganizationNotFound, so their existence is not disclosed.
+//
+//go:generate mockery --name=Service --filename=organization_service.go --output=./mocks
+type Service interface {
+	// CreateOrganization creates an organization owned by the user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user creating the organization.
+	//   - name: The name of the organization.
+	//
+	// Returns:
+	//   - *model.Organization: The created organization.
+	//   - error: An error if the creation fails, otherwise nil.
+	CreateOrganization(ctx context.Context, userID, name string) (*model.Organization, error)
+
+	// ListOrganizations retrieves the memberships of the user along with their organizations.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//
+	// Returns:
+	//   - []*model.Membership: The memberships, oldest first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListOrganizations(ctx context.Context, userID string) ([]*model.Membership, error)
+
+	// GetOrganization retrieves an organization the user belongs to.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//
+	// Returns:
+	//   - *model.Organization: The organization.
+	//   - error: ErrOrganizationNotFound if the user is not a member, otherwise any retrieval error.
+	GetOrganization(ctx context.Context, userID, organizationID string) (*model.Organization, error)
+
+	// UpdateOrganization renames an organization. The user must be an admin of the organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//   - name: The new name.
+	//
+	// Returns:
+	//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not an
+	//     admin, otherwise any update error.
+	UpdateOrganization(ctx context.Context, userID, organizationID, name string) error
+
+	// DeleteOrganization deletes an organization with its memberships and invitations.
+	// The user must be the owner of the organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//
+	// Returns:
+	//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not the
+	//     owner, otherwise any deletion error.
+	DeleteOrganization(ctx context.Context, userID, organizationID string) error
+
+	// ListMembers retrieves the memberships of an organization the user belongs to, along with their users.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//
+	// Returns:
+	//   - []*model.Membership: The memberships, oldest first.
+	//   - error: ErrOrganizationNotFound if the user is not a member, otherwise any retrieval error.
+	ListMembers(ctx context.Context, userID, organizationID string) ([]*model.Membership, error)
+
+	// UpdateMemberRole changes the role of a member. The user must be an admin of the organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//   - memberID: The ID of the member.
+	//   - role: The new role, model.OrganizationAdmin or model.OrganizationMember.
+	//
+	// Returns:
+	//   - error: ErrUnsupportedRole if the role is unknown or is the owner role, ErrOrganizationNotFound if the
+	//     user is not a member, ErrInsufficientRole if the user is not an admin, ErrMemberNotFound if memberID is
+	//     not a member, ErrOwnerMembership if memberID is the owner, otherwise any update error.
+	UpdateMemberRole(ctx context.Context, userID, organizationID, memberID, role string) error
+
+	// RemoveMember removes a member from an organization. Any member may leave, but only admins remove others.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//   - memberID: The ID of the member, userID to leave the organization.
+	//
+	// Returns:
+	//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user removes
+	//     another member without being an admin, ErrMemberNotFound if memberID is not a member,
+	//     ErrOwnerMembership if memberID is the owner, otherwise any deletion error.
+	RemoveMember(ctx context.Context, userID, organizationID, memberID string) error
+
+	// TransferOwnership makes another member the owner of an organization; the user becomes an admin.
+	// The user must be the owner of the organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//   - newOwnerID: The ID of the member becoming the owner.
+	//
+	// Returns:
+	//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not the
+	//     owner, ErrMemberNotFound if newOwnerID is not another member, otherwise any update error.
+	TransferOwnership(ctx context.Context, userID, organizationID, newOwnerID string) error
+
+	// CreateInvitation invites an email address to join an organization and sends it the invitation link.
+	// A previous pending invitation of the address to the organization is superseded.
+	// The user must be an admin of the organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//   - email: The invited email address.
+	//   - role: The role granted to the invited user, model.OrganizationMember when empty.
+	//
+	// Returns:
+	//   - *model.OrganizationInvitation: The created invitation.
+	//   - error: ErrUnsupportedRole if the role is unknown or is the owner role, ErrOrganizationNotFound if the
+	//     user is not a member, ErrInsufficientRole if the user is not an admin, ErrAlreadyMember if the user with
+	//     the address is a member, otherwise an error if the invitation cannot be stored or the email cannot be sent.
+	CreateInvitation(ctx context.Context, userID, organizationID, email, role string) (*model.OrganizationInvitation, error)
+
+	// ListInvitations retrieves the invitations to join an organization. The user must be an admin of the
+	// organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//
+	// Returns:
+	//   - []*model.OrganizationInvitation: The invitations, newest first.
+	//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not an
+	//     admin, otherwise any retrieval error.
+	ListInvitations(ctx context.Context, userID, organizationID string) ([]*model.OrganizationInvitation, error)
+
+	// RevokeInvitation revokes a pending invitation, so its link stops working. The user must be an admin of the
+	// organization.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - organizationID: The ID of the organization.
+	//   - id: The ID of the invitation.
+	//
+	// Returns:
+	//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not an
+	//     admin, ErrInvitationNotFound if the organization has no pending invitation with the ID, otherwise any
+	//     storage error.
+	RevokeInvitation(ctx context.Context, userID, organizationID, id string) error
+
+	// AcceptInvitation adds the user to the organization of the invitation carrying the token, with the role of
+	// the invitation. The invitation is accepted in the same transaction, so it adds one user only.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user, who must own the invited email address.
+	//   - token: The token carried by the invitation link.
+	//
+	// Returns:
+	//   - *model.Membership: The created membership.
+	//   - error: ErrInvalidInvitation if the invitation is unknown, used, revoked, superseded or expired,
+	//     ErrInvitationEmailMismatch if the user has another email address, ErrAlreadyMember if the user already
+	//     is a member, otherwise an error if the creation fails.
+	AcceptInvitation(ctx context.Context, userID, token string) (*model.Membership, error)
+}
+
+// organizationService is the concrete implementation of the Service interface.
+type organizationService struct {
+	organizationRepo organizationRepository.Repository
+	userRepo         userRepository.Repository
+	codeGen          utils.CodeGenerator
+	mailer           mailer.Mailer
+	invitationURL    string
+	ttl              time.Duration
+}
+
+// NewOrganizationService creates a new instance of the organization service.
+//
+// Parameters:
+//   - organizationRepo: The repository storing the organizations, memberships and invitations.
+//   - userRepo: The user repository resolving the invited and accepting users.
+//   - codeGen: The random code generator used for the link tokens.
+//   - mailer: The mailer delivering the invitation links.
+//   - invitationURL: The frontend page invitation links point to; the token is added as the "token" query parameter.
+//   - ttl: How long an invitation link works.
+//
+// Returns:
+//   - Service: A new organization service instance.
+func NewOrganizationService(
+	organizationRepo organizationRepository.Repository,
+	userRepo userRepository.Repository,
+	codeGen utils.CodeGenerator,
+	mailer mailer.Mailer,
+	invitationURL string,
+	ttl time.Duration,
+) Service {
+	return &organizationService{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+		codeGen:          codeGen,
+		mailer:           mailer,
+		invitationURL:    invitationURL,
+		ttl:              ttl,
+	}
+}
+
+// requireRole returns the membership of the user in the organization if it holds the role.
+func (svc *organizationService) requireRole(ctx context.Context, userID, organizationID, role string) (*model.Membership, error) {
+	membership, err := svc.organizationRepo.GetMembership(ctx, organizationID, userID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !membership.HasRole(role) {
+		return nil, ErrInsufficientRole
+	}
+
+	return membership, nil
+}
+
+// checkGrantableRole validates a role granted to a member, defaulting to model.OrganizationMember.
+// The owner role is only granted through TransferOwnership.
+func checkGrantableRole(role string) (string, error) {
+	switch role {
+	case "":
+		return model.OrganizationMember, nil
+	case model.OrganizationAdmin, model.OrganizationMember:
+		return role, nil
+	default:
+		return "", ErrUnsupportedRole
+	}
+}
+
+// hashToken returns the hex SHA-256 of a link token, so the token itself is never stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// buildLink adds the token to the configured invitation URL.
+func buildLink(linkURL, token string) (string, error) {
+	u, err := url.Parse(linkURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
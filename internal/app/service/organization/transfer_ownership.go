@@ -0,0 +1,40 @@
+package organization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// TransferOwnership makes another member the owner of an organization; the user becomes an admin.
+// The user must be the owner of the organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//   - newOwnerID: The ID of the member becoming the owner.
+//
+// Returns:
+//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not the
+//     owner, ErrMemberNotFound if newOwnerID is not another member, otherwise any update error.
+func (svc *organizationService) TransferOwnership(ctx context.Context, userID, organizationID, newOwnerID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_TransferOwnership")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationOwner)
+	if err != nil {
+		return err
+	}
+
+	err = svc.organizationRepo.TransferOwnership(ctx, organizationID, userID, newOwnerID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		// The owner was checked above, so the new owner is not another member.
+		return ErrMemberNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,81 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_TransferOwnership(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Transfer ownership successfully",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("TransferOwnership", ctx, testOrganizationID, testUserID, testMemberID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Transfer ownership failed - user is an admin only",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+		{
+			name: "Transfer ownership failed - new owner is not a member",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("TransferOwnership", ctx, testOrganizationID, testUserID, testMemberID).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrMemberNotFound,
+		},
+		{
+			name: "Transfer ownership failed - repository error",
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationOwner), nil)
+				repoMock.On("TransferOwnership", ctx, testOrganizationID, testUserID, testMemberID).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			err := testSvc.TransferOwnership(ctx, testUserID, testOrganizationID, testMemberID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
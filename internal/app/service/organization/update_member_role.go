@@ -0,0 +1,58 @@
+package organization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateMemberRole changes the role of a member. The user must be an admin of the organization.
+// The role of the owner only changes through TransferOwnership.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//   - memberID: The ID of the member.
+//   - role: The new role, model.OrganizationAdmin or model.OrganizationMember.
+//
+// Returns:
+//   - error: ErrUnsupportedRole if the role is unknown or is the owner role, ErrOrganizationNotFound if the
+//     user is not a member, ErrInsufficientRole if the user is not an admin, ErrMemberNotFound if memberID is
+//     not a member, ErrOwnerMembership if memberID is the owner, otherwise any update error.
+func (svc *organizationService) UpdateMemberRole(ctx context.Context, userID, organizationID, memberID, role string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_UpdateMemberRole")
+	defer s.End()
+
+	if role != model.OrganizationAdmin && role != model.OrganizationMember {
+		return ErrUnsupportedRole
+	}
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationAdmin)
+	if err != nil {
+		return err
+	}
+
+	member, err := svc.organizationRepo.GetMembership(ctx, organizationID, memberID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrMemberNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if member.Role == model.OrganizationOwner {
+		return ErrOwnerMembership
+	}
+
+	err = svc.organizationRepo.UpdateMemberRole(ctx, organizationID, memberID, role)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		// The member left or became the owner meanwhile.
+		return ErrMemberNotFound
+	}
+
+	return err
+}
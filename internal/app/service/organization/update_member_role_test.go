@@ -0,0 +1,118 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
+)
+
+func TestService_UpdateMemberRole(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputRole string
+
+		setupMockOrganizationRepo func(ctx context.Context) *mockOrganizationRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Update member role successfully",
+
+			inputRole: model.OrganizationAdmin,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(&model.Membership{UserID: testMemberID, Role: model.OrganizationMember}, nil)
+				repoMock.On("UpdateMemberRole", ctx, testOrganizationID, testMemberID, model.OrganizationAdmin).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Update member role failed - owner role",
+
+			inputRole: model.OrganizationOwner,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				return mockOrganizationRepo.NewRepository(t)
+			},
+
+			expectedError: ErrUnsupportedRole,
+		},
+		{
+			name: "Update member role failed - user is a member only",
+
+			inputRole: model.OrganizationAdmin,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationMember), nil)
+				return repoMock
+			},
+
+			expectedError: ErrInsufficientRole,
+		},
+		{
+			name: "Update member role failed - member not found",
+
+			inputRole: model.OrganizationAdmin,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrMemberNotFound,
+		},
+		{
+			name: "Update member role failed - member is the owner",
+
+			inputRole: model.OrganizationMember,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(&model.Membership{UserID: testMemberID, Role: model.OrganizationOwner}, nil)
+				return repoMock
+			},
+
+			expectedError: ErrOwnerMembership,
+		},
+		{
+			name: "Update member role failed - member left meanwhile",
+
+			inputRole: model.OrganizationAdmin,
+
+			setupMockOrganizationRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testUserID).Return(testMembership(model.OrganizationAdmin), nil)
+				repoMock.On("GetMembership", ctx, testOrganizationID, testMemberID).Return(&model.Membership{UserID: testMemberID, Role: model.OrganizationMember}, nil)
+				repoMock.On("UpdateMemberRole", ctx, testOrganizationID, testMemberID, model.OrganizationAdmin).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrMemberNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testSvc := NewOrganizationService(tc.setupMockOrganizationRepo(ctx), nil, nil, nil, testInvitationURL, testTTL)
+
+			err := testSvc.UpdateMemberRole(ctx, testUserID, testOrganizationID, testMemberID, tc.inputRole)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
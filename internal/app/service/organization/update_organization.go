@@ -0,0 +1,31 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// UpdateOrganization renames an organization. The user must be an admin of the organization.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - organizationID: The ID of the organization.
+//   - name: The new name.
+//
+// Returns:
+//   - error: ErrOrganizationNotFound if the user is not a member, ErrInsufficientRole if the user is not an
+//     admin, otherwise any update error.
+func (svc *organizationService) UpdateOrganization(ctx context.Context, userID, organizationID, name string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_UpdateOrganization")
+	defer s.End()
+
+	_, err := svc.requireRole(ctx, userID, organizationID, model.OrganizationAdmin)
+	if err != nil {
+		return err
+	}
+
+	return svc.organizationRepo.UpdateOrganization(ctx, organizationID, name)
+}
@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// RelayPending publishes the pending events, oldest first, until one is not due or fails.
+// A failed event is retried with an exponential backoff, and dead-lettered after MaxAttempts.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - int: The number of events published or dead-lettered.
+//   - error: The error of the event that failed, or any storage error, otherwise nil.
+func (svc *outboxService) RelayPending(ctx context.Context) (int, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RelayPending")
+	defer s.End()
+
+	events, err := svc.outboxRepo.ListPendingEvents(ctx, svc.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	relayed := 0
+	for _, event := range events {
+		now := time.Now()
+
+		// Events are relayed in order, so one waiting for a retry holds back the ones after it
+		if event.NextAttemptAt.After(now) {
+			break
+		}
+
+		streamID, err := svc.outboxRepo.PublishEvent(ctx, svc.cfg.Stream, event)
+		if err != nil {
+			deadLettered, failErr := svc.handleFailure(ctx, event, err, now)
+			if failErr != nil {
+				return relayed, failErr
+			}
+			if !deadLettered {
+				return relayed, err
+			}
+
+			relayed++
+			continue
+		}
+
+		err = svc.outboxRepo.MarkEventPublished(ctx, event.ID, streamID, now)
+		if err != nil {
+			return relayed, err
+		}
+		relayed++
+	}
+
+	return relayed, nil
+}
+
+// handleFailure records a failed attempt to publish an event, moving it to the dead-letter stream
+// once it has failed MaxAttempts times.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - event: The event that failed.
+//   - publishErr: The error of the attempt.
+//   - now: When the attempt was made.
+//
+// Returns:
+//   - bool: Whether the event was dead-lettered.
+//   - error: An error if the failure cannot be recorded, otherwise nil.
+func (svc *outboxService) handleFailure(ctx context.Context, event *model.OutboxEvent, publishErr error, now time.Time) (bool, error) {
+	attempts := event.Attempts + 1
+
+	if attempts >= svc.cfg.MaxAttempts {
+		streamID, err := svc.outboxRepo.PublishEvent(ctx, svc.cfg.DeadLetterStream, event)
+		if err == nil {
+			return true, svc.outboxRepo.MarkEventDeadLettered(ctx, event.ID, streamID, attempts, publishErr.Error(), now)
+		}
+	}
+
+	return false, svc.outboxRepo.MarkEventFailed(ctx, event.ID, attempts, publishErr.Error(), now.Add(svc.retryBackoff(attempts)))
+}
+
+// retryBackoff returns how long to wait before retrying an event, doubling with every failed attempt.
+//
+// Parameters:
+//   - attempts: The number of failed attempts so far.
+//
+// Returns:
+//   - time.Duration: The delay, at most MaxRetryBackoff.
+func (svc *outboxService) retryBackoff(attempts int) time.Duration {
+	backoff := svc.cfg.RetryBackoff
+	for i := 1; i < attempts && backoff < svc.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, svc.cfg.MaxRetryBackoff)
+}
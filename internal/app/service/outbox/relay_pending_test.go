@@ -0,0 +1,226 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOutboxRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/outbox/mocks"
+)
+
+const (
+	testEventID1  = "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"
+	testEventID2  = "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72"
+	testStreamID1 = "1700000000000-0"
+	testStreamID2 = "1700000000000-1"
+)
+
+func TestService_RelayPending(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Stream:           "user-events",
+		DeadLetterStream: "user-events-dead-letter",
+		BatchSize:        100,
+		MaxAttempts:      3,
+		RetryBackoff:     time.Second,
+		MaxRetryBackoff:  time.Minute,
+	}
+	publishErr := errors.New("redis: connection refused")
+	dbErr := errors.New("database error")
+
+	dueEvent := func(id string, attempts int) *model.OutboxEvent {
+		return &model.OutboxEvent{
+			Base:          model.Base{ID: id},
+			EventType:     "user.created",
+			Attempts:      attempts,
+			NextAttemptAt: time.Now().Add(-time.Minute),
+		}
+	}
+	futureEvent := &model.OutboxEvent{Base: model.Base{ID: testEventID2}, NextAttemptAt: time.Now().Add(time.Hour)}
+
+	// nextAttemptWithin matches a retry scheduled the given backoff after the attempt
+	nextAttemptWithin := func(backoff time.Duration) any {
+		return mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			delay := time.Until(nextAttemptAt)
+			return delay > backoff-5*time.Second && delay <= backoff
+		})
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockOutboxRepo func(ctx context.Context) *mockOutboxRepo.Repository
+
+		expectedRelayed int
+		expectedError   error
+	}{
+		{
+			name: "Publish all pending events in order",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1, event2 := dueEvent(testEventID1, 0), dueEvent(testEventID2, 0)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1, event2}, nil)
+				call1 := repoMock.On("PublishEvent", ctx, "user-events", event1).Return(testStreamID1, nil).Once()
+				repoMock.On("MarkEventPublished", ctx, testEventID1, testStreamID1, mock.AnythingOfType("time.Time")).Return(nil).Once()
+				repoMock.On("PublishEvent", ctx, "user-events", event2).Return(testStreamID2, nil).Once().NotBefore(call1)
+				repoMock.On("MarkEventPublished", ctx, testEventID2, testStreamID2, mock.AnythingOfType("time.Time")).Return(nil).Once()
+				return repoMock
+			},
+
+			expectedRelayed: 2,
+		},
+		{
+			name: "Nothing pending",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{}, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Stop at the first event waiting for a retry",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1 := dueEvent(testEventID1, 0)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1, futureEvent, dueEvent("c3d4e5f6-0005-4a5b-8c9d-2e3f4a5b6c75", 0)}, nil)
+				repoMock.On("PublishEvent", ctx, "user-events", event1).Return(testStreamID1, nil).Once()
+				repoMock.On("MarkEventPublished", ctx, testEventID1, testStreamID1, mock.AnythingOfType("time.Time")).Return(nil).Once()
+				return repoMock
+			},
+
+			expectedRelayed: 1,
+		},
+		{
+			name: "Schedule a retry when publishing fails",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1 := dueEvent(testEventID1, 1)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1, dueEvent(testEventID2, 0)}, nil)
+				repoMock.On("PublishEvent", ctx, "user-events", event1).Return("", publishErr).Once()
+				repoMock.On("MarkEventFailed", ctx, testEventID1, 2, publishErr.Error(), nextAttemptWithin(2*time.Second)).Return(nil).Once()
+				return repoMock
+			},
+
+			expectedError: publishErr,
+		},
+		{
+			name: "Dead-letter an event after the last attempt and continue",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1, event2 := dueEvent(testEventID1, 2), dueEvent(testEventID2, 0)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1, event2}, nil)
+				repoMock.On("PublishEvent", ctx, "user-events", event1).Return("", publishErr).Once()
+				repoMock.On("PublishEvent", ctx, "user-events-dead-letter", event1).Return(testStreamID1, nil).Once()
+				repoMock.On("MarkEventDeadLettered", ctx, testEventID1, testStreamID1, 3, publishErr.Error(), mock.AnythingOfType("time.Time")).Return(nil).Once()
+				repoMock.On("PublishEvent", ctx, "user-events", event2).Return(testStreamID2, nil).Once()
+				repoMock.On("MarkEventPublished", ctx, testEventID2, testStreamID2, mock.AnythingOfType("time.Time")).Return(nil).Once()
+				return repoMock
+			},
+
+			expectedRelayed: 2,
+		},
+		{
+			name: "Keep retrying when the dead-letter stream is unavailable",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1 := dueEvent(testEventID1, 9)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1}, nil)
+				repoMock.On("PublishEvent", ctx, "user-events", event1).Return("", publishErr).Once()
+				repoMock.On("PublishEvent", ctx, "user-events-dead-letter", event1).Return("", publishErr).Once()
+				repoMock.On("MarkEventFailed", ctx, testEventID1, 10, publishErr.Error(), nextAttemptWithin(time.Minute)).Return(nil).Once()
+				return repoMock
+			},
+
+			expectedError: publishErr,
+		},
+		{
+			name: "Error listing pending events",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				repoMock.On("ListPendingEvents", ctx, 100).Return(nil, dbErr)
+				return repoMock
+			},
+
+			expectedError: dbErr,
+		},
+		{
+			name: "Error marking an event as published",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1 := dueEvent(testEventID1, 0)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1}, nil)
+				repoMock.On("PublishEvent", ctx, "user-events", event1).Return(testStreamID1, nil).Once()
+				repoMock.On("MarkEventPublished", ctx, testEventID1, testStreamID1, mock.AnythingOfType("time.Time")).Return(dbErr).Once()
+				return repoMock
+			},
+
+			expectedError: dbErr,
+		},
+		{
+			name: "Error recording a failed attempt",
+
+			setupMockOutboxRepo: func(ctx context.Context) *mockOutboxRepo.Repository {
+				repoMock := mockOutboxRepo.NewRepository(t)
+				event1 := dueEvent(testEventID1, 0)
+				repoMock.On("ListPendingEvents", ctx, 100).Return([]*model.OutboxEvent{event1}, nil)
+				repoMock.On("PublishEvent", ctx, "user-events", event1).Return("", publishErr).Once()
+				repoMock.On("MarkEventFailed", ctx, testEventID1, 1, publishErr.Error(), mock.AnythingOfType("time.Time")).Return(dbErr).Once()
+				return repoMock
+			},
+
+			expectedError: dbErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := NewOutboxService(tc.setupMockOutboxRepo(ctx), cfg)
+
+			relayed, err := svc.RelayPending(ctx)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedRelayed, relayed)
+		})
+	}
+}
+
+func TestService_retryBackoff(t *testing.T) {
+	t.Parallel()
+
+	svc := &outboxService{cfg: &Config{RetryBackoff: time.Second, MaxRetryBackoff: 5 * time.Minute}}
+
+	testCases := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{name: "First retry", attempts: 1, expected: time.Second},
+		{name: "Doubles with every attempt", attempts: 4, expected: 8 * time.Second},
+		{name: "Capped at the maximum", attempts: 10, expected: 5 * time.Minute},
+		{name: "Capped without overflowing", attempts: 1000, expected: 5 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, svc.retryBackoff(tc.attempts))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Run relays the pending events until the context is cancelled.
+// It relays again right away while events keep coming, and otherwise waits for the poll interval.
+//
+// Parameters:
+//   - ctx: The context stopping the relay when cancelled.
+//   - svc: The outbox relay service.
+//   - pollInterval: How long to wait when there is nothing to relay.
+func Run(ctx context.Context, svc Service, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		relayed, err := svc.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().
+				Str("operation", "Outbox_Run").
+				Err(err).
+				Msg("failed to relay the outbox events")
+		}
+
+		if err == nil && relayed > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
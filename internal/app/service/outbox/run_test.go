@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockOutboxService "github.com/vukieuhaihoa/user-service/internal/app/service/outbox/mocks"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockService func(cancel context.CancelFunc) *mockOutboxService.Service
+	}{
+		{
+			name: "Relay again right away while events keep coming",
+
+			setupMockService: func(cancel context.CancelFunc) *mockOutboxService.Service {
+				svcMock := mockOutboxService.NewService(t)
+				svcMock.On("RelayPending", mock.Anything).Return(2, nil).Once()
+				svcMock.On("RelayPending", mock.Anything).Return(0, nil).Once().Run(func(mock.Arguments) { cancel() })
+				return svcMock
+			},
+		},
+		{
+			name: "Wait for the next poll after an error",
+
+			setupMockService: func(cancel context.CancelFunc) *mockOutboxService.Service {
+				svcMock := mockOutboxService.NewService(t)
+				svcMock.On("RelayPending", mock.Anything).Return(1, errors.New("redis: connection refused")).Once()
+				svcMock.On("RelayPending", mock.Anything).Return(0, nil).Once().Run(func(mock.Arguments) { cancel() })
+				return svcMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			done := make(chan struct{})
+			go func() {
+				Run(ctx, tc.setupMockService(cancel), time.Millisecond)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "relay did not stop after the context was cancelled")
+			}
+		})
+	}
+}
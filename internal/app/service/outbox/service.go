@@ -0,0 +1,74 @@
+// Package outbox relays the domain events stored in the transactional outbox to Redis Streams.
+// Delivery is at-least-once: an event is marked as published only after it was appended to the
+// stream, so a crash in between publishes it again and consumers must drop duplicates by event ID.
+// Events are relayed in the order they happened, and one failing repeatedly is moved to a
+// dead-letter stream so it does not hold back the others forever.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	outboxRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/outbox"
+)
+
+// Config holds the relay settings, read from OUTBOX_* environment variables.
+type Config struct {
+	Stream           string        `envconfig:"STREAM" default:"user-events"`
+	DeadLetterStream string        `envconfig:"DEAD_LETTER_STREAM" default:"user-events-dead-letter"`
+	BatchSize        int           `envconfig:"BATCH_SIZE" default:"100"`
+	MaxAttempts      int           `envconfig:"MAX_ATTEMPTS" default:"10"`
+	RetryBackoff     time.Duration `envconfig:"RETRY_BACKOFF" default:"1s"`
+	MaxRetryBackoff  time.Duration `envconfig:"MAX_RETRY_BACKOFF" default:"5m"`
+	PollInterval     time.Duration `envconfig:"POLL_INTERVAL" default:"1s"`
+}
+
+// NewConfig loads the relay configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("OUTBOX", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Service represents the interface for outbox relay operations.
+//
+//go:generate mockery --name=Service --filename=outbox_service.go --output=./mocks
+type Service interface {
+	// RelayPending publishes the pending events, oldest first, until one is not due or fails.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - int: The number of events published or dead-lettered.
+	//   - error: The error of the event that failed, or any storage error, otherwise nil.
+	RelayPending(ctx context.Context) (int, error)
+}
+
+type outboxService struct {
+	outboxRepo outboxRepository.Repository
+	cfg        *Config
+}
+
+// NewOutboxService creates a new instance of the outbox relay service.
+//
+// Parameters:
+//   - outboxRepo: The repository storing and publishing the events.
+//   - cfg: The relay configuration.
+//
+// Returns:
+//   - Service: A new outbox relay service instance.
+func NewOutboxService(outboxRepo outboxRepository.Repository, cfg *Config) Service {
+	return &outboxService{
+		outboxRepo: outboxRepo,
+		cfg:        cfg,
+	}
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Setenv("OUTBOX_STREAM", "events")
+	t.Setenv("OUTBOX_MAX_ATTEMPTS", "5")
+
+	cfg, err := NewConfig()
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		Stream:           "events",
+		DeadLetterStream: "user-events-dead-letter",
+		BatchSize:        100,
+		MaxAttempts:      5,
+		RetryBackoff:     time.Second,
+		MaxRetryBackoff:  5 * time.Minute,
+		PollInterval:     time.Second,
+	}, cfg)
+}
+
+func TestNewConfig_InvalidValue(t *testing.T) {
+	t.Setenv("OUTBOX_BATCH_SIZE", "many")
+
+	cfg, err := NewConfig()
+
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+}
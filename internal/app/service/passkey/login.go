@@ -0,0 +1,106 @@
+package passkey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+)
+
+// BeginLogin starts a passkey login. No account is named: the options allow any
+// discoverable credential, and the authenticator returns the user handle of the one picked.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - *LoginOptions: The options to pass to the authenticator.
+//   - error: An error if the session cannot be stored, otherwise nil.
+func (p *passkeyService) BeginLogin(ctx context.Context) (*LoginOptions, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_BeginPasskeyLogin")
+	defer s.End()
+
+	options, session, err := p.webAuthn.BeginDiscoverableLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := p.saveSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginOptions{
+		SessionID: sessionID,
+		Options:   options,
+	}, nil
+}
+
+// FinishLogin verifies the assertion signed by the authenticator and returns a token for its user.
+// The session is consumed first, so a challenge can only be answered once. A signature counter
+// that did not increase means the authenticator may have been cloned, and the login is refused.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - sessionID: The session identifier returned by BeginLogin.
+//   - credential: The JSON encoded PublicKeyCredential returned by the authenticator.
+//
+// Returns:
+//   - string: The JWT token if authentication is successful.
+//   - error: ErrInvalidSession if the session is unknown or expired,
+//     ErrInvalidCredential if the assertion does not verify, otherwise nil.
+func (p *passkeyService) FinishLogin(ctx context.Context, sessionID string, credential []byte) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_FinishPasskeyLogin")
+	defer s.End()
+
+	session, err := p.passkeyRepo.ConsumeSession(ctx, sessionID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return "", ErrInvalidSession
+	}
+	if err != nil {
+		return "", err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		return "", ErrInvalidCredential
+	}
+
+	// lookupErr keeps storage failures apart from unknown accounts, which the library reports alike
+	var lookupErr error
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		user, err := p.loadWebAuthnUser(ctx, string(userHandle))
+		if err != nil {
+			if !errors.Is(err, dbutils.ErrRecordNotFoundType) {
+				lookupErr = err
+			}
+			return nil, err
+		}
+		return user, nil
+	}
+
+	found, validated, err := p.webAuthn.ValidatePasskeyLogin(handler, *session, parsed)
+	if lookupErr != nil {
+		return "", lookupErr
+	}
+	if err != nil || validated.Authenticator.CloneWarning {
+		return "", ErrInvalidCredential
+	}
+
+	user := found.(*webAuthnUser)
+	passkey := user.passkeyByCredentialID(validated.ID)
+	if passkey == nil {
+		return "", ErrInvalidCredential
+	}
+
+	err = p.passkeyRepo.UpdatePasskeyUsage(ctx, passkey.ID, validated.Authenticator.SignCount, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return p.userSvc.IssueToken(ctx, user.user)
+}
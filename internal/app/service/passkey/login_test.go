@@ -0,0 +1,302 @@
+package passkey
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockPasskeyRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/passkey/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockUserSvc "github.com/vukieuhaihoa/user-service/internal/app/service/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+// registerTestPasskey registers the credential of a software authenticator for testUser
+// and returns the passkey as it would be stored.
+func registerTestPasskey(t *testing.T, webAuthn *webauthn.WebAuthn, authenticator *fixture.SoftwareAuthenticator) *model.UserPasskey {
+	options, session, err := webAuthn.BeginRegistration(&webAuthnUser{user: testUser})
+	assert.Nil(t, err)
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(authenticator.Register(t, options))
+	assert.Nil(t, err)
+
+	credential, err := webAuthn.CreateCredential(&webAuthnUser{user: testUser}, *session, parsed)
+	assert.Nil(t, err)
+
+	return &model.UserPasskey{
+		Base:            model.Base{ID: "passkey-001"},
+		UserID:          testUser.ID,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		SignCount:       credential.Authenticator.SignCount,
+	}
+}
+
+func TestService_BeginLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockPasskeyRepo func(ctx context.Context) *mockPasskeyRepo.Repository
+		setupMockCodeGen     func() *mockUtils.CodeGenerator
+
+		expectedSessionID string
+		expectedError     error
+	}{
+		{
+			name: "Begin login successfully",
+
+			setupMockPasskeyRepo: func(ctx context.Context) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("SaveSession", ctx, "session-001", mock.MatchedBy(func(session *webauthn.SessionData) bool {
+					return len(session.UserID) == 0 && session.Challenge != ""
+				}), SessionExpiration).Return(nil)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", sessionIDLength).Return("session-001", nil)
+				return codeGenMock
+			},
+
+			expectedSessionID: "session-001",
+		},
+		{
+			name: "Generating session ID fails",
+
+			setupMockPasskeyRepo: func(ctx context.Context) *mockPasskeyRepo.Repository {
+				return mockPasskeyRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", sessionIDLength).Return("", assert.AnError)
+				return codeGenMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			passkeyService := NewPasskeyService(
+				tc.setupMockPasskeyRepo(ctx),
+				nil,
+				nil,
+				tc.setupMockCodeGen(),
+				newTestWebAuthn(t),
+			)
+
+			res, err := passkeyService.BeginLogin(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.Equal(t, tc.expectedSessionID, res.SessionID)
+			assert.Equal(t, fixture.MockWebAuthnRPID, res.Options.Response.RelyingPartyID)
+			assert.Empty(t, res.Options.Response.AllowedCredentials)
+		})
+	}
+}
+
+func TestService_FinishLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		// inputCredential replaces the assertion signed by the authenticator when set
+		inputCredential []byte
+
+		setupMockPasskeyRepo func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository
+		setupMockUserRepo    func(ctx context.Context) *mockUserRepo.Repository
+		setupMockUserSvc     func(ctx context.Context) *mockUserSvc.Service
+
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name: "Finish login successfully",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{passkey}, nil)
+				repoMock.On("UpdatePasskeyUsage", ctx, "passkey-001", uint32(1), mock.Anything).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				svcMock := mockUserSvc.NewService(t)
+				svcMock.On("IssueToken", ctx, testUser).Return("mocked_jwt_token", nil)
+				return svcMock
+			},
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Unknown or expired session",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidSession,
+		},
+		{
+			name:            "Malformed assertion",
+			inputCredential: []byte(`{"id":"not-an-assertion"}`),
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidCredential,
+		},
+		{
+			name: "User of the passkey no longer exists",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidCredential,
+		},
+		{
+			name: "Loading the user fails",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Passkey removed from the account",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidCredential,
+		},
+		{
+			name: "Signature counter did not increase",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, passkey *model.UserPasskey) *mockPasskeyRepo.Repository {
+				cloned := *passkey
+				cloned.SignCount = 5
+
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{&cloned}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockUserSvc: func(ctx context.Context) *mockUserSvc.Service {
+				return mockUserSvc.NewService(t)
+			},
+
+			expectedError: ErrInvalidCredential,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webAuthn := newTestWebAuthn(t)
+			authenticator := fixture.NewSoftwareAuthenticator(t)
+			passkey := registerTestPasskey(t, webAuthn, authenticator)
+
+			options, session, err := webAuthn.BeginDiscoverableLogin()
+			assert.Nil(t, err)
+
+			credential := authenticator.Assert(t, options)
+			if tc.inputCredential != nil {
+				credential = tc.inputCredential
+			}
+
+			passkeyService := NewPasskeyService(
+				tc.setupMockPasskeyRepo(ctx, session, passkey),
+				tc.setupMockUserRepo(ctx),
+				tc.setupMockUserSvc(ctx),
+				nil,
+				webAuthn,
+			)
+
+			res, err := passkeyService.FinishLogin(ctx, "session-001", credential)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package passkey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// BeginRegistration starts registering a new passkey for a logged in user.
+// Adding a login method is a sensitive change, so the user must have logged in within
+// ReauthenticationWindow. Passkeys the user already has are excluded, so an authenticator
+// cannot be registered twice.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the logged in user.
+//   - authTime: When the user last authenticated, taken from the token issue time.
+//
+// Returns:
+//   - *RegistrationOptions: The options to pass to the authenticator.
+//   - error: ErrReauthenticationRequired if the login is older than ReauthenticationWindow, otherwise any storage error.
+func (p *passkeyService) BeginRegistration(ctx context.Context, userID string, authTime time.Time) (*RegistrationOptions, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_BeginPasskeyRegistration")
+	defer s.End()
+
+	if time.Since(authTime) > ReauthenticationWindow {
+		return nil, ErrReauthenticationRequired
+	}
+
+	user, err := p.loadWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, session, err := p.webAuthn.BeginRegistration(user,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExclusions(webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := p.saveSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistrationOptions{
+		SessionID: sessionID,
+		Options:   options,
+	}, nil
+}
+
+// FinishRegistration verifies the credential created by the authenticator and stores it.
+// The session is consumed first, so a challenge can only be answered once.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the logged in user.
+//   - sessionID: The session identifier returned by BeginRegistration.
+//   - credential: The JSON encoded PublicKeyCredential returned by the authenticator.
+//
+// Returns:
+//   - *model.UserPasskey: The registered passkey.
+//   - error: ErrInvalidSession if the session is unknown, expired or started by another user,
+//     ErrInvalidCredential if the credential does not verify, ErrPasskeyAlreadyRegistered if it is already stored, otherwise nil.
+func (p *passkeyService) FinishRegistration(ctx context.Context, userID, sessionID string, credential []byte) (*model.UserPasskey, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_FinishPasskeyRegistration")
+	defer s.End()
+
+	session, err := p.passkeyRepo.ConsumeSession(ctx, sessionID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, ErrInvalidSession
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if string(session.UserID) != userID {
+		return nil, ErrInvalidSession
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(credential)
+	if err != nil {
+		return nil, ErrInvalidCredential
+	}
+
+	user, err := p.loadWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := p.webAuthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		return nil, ErrInvalidCredential
+	}
+
+	transports := make([]string, 0, len(created.Transport))
+	for _, transport := range created.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	passkey, err := p.passkeyRepo.CreatePasskey(ctx, &model.UserPasskey{
+		UserID:          userID,
+		CredentialID:    created.ID,
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		Transports:      transports,
+		AAGUID:          created.Authenticator.AAGUID,
+		SignCount:       created.Authenticator.SignCount,
+		BackupEligible:  created.Flags.BackupEligible,
+		BackupState:     created.Flags.BackupState,
+	})
+	if errors.Is(err, dbutils.ErrDuplicationType) {
+		return nil, ErrPasskeyAlreadyRegistered
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return passkey, nil
+}
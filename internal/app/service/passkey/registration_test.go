@@ -0,0 +1,339 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockPasskeyRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/passkey/mocks"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+var testUser = &model.User{
+	Base:        model.Base{ID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe"},
+	Username:    "testuser001",
+	DisplayName: "Test User 1",
+	Email:       "testuser001@example.com",
+}
+
+var testPasskey = &model.UserPasskey{
+	Base:            model.Base{ID: "2f8d6c1a-7b3e-4a5d-9c0f-3e1b2a4d5c6e"},
+	UserID:          testUser.ID,
+	CredentialID:    []byte("credential-001"),
+	PublicKey:       []byte("public-key-001"),
+	AttestationType: "none",
+	Transports:      []string{"internal"},
+}
+
+// newTestWebAuthn returns a relying party accepting the software authenticator used in tests.
+func newTestWebAuthn(t *testing.T) *webauthn.WebAuthn {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          fixture.MockWebAuthnRPID,
+		RPDisplayName: "User Service",
+		RPOrigins:     []string{fixture.MockWebAuthnOrigin},
+	})
+	assert.Nil(t, err)
+
+	return webAuthn
+}
+
+func TestService_BeginRegistration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputAuthTime time.Time
+
+		setupMockPasskeyRepo func(ctx context.Context) *mockPasskeyRepo.Repository
+		setupMockUserRepo    func(ctx context.Context) *mockUserRepo.Repository
+		setupMockCodeGen     func() *mockUtils.CodeGenerator
+
+		expectedSessionID string
+		expectedExcluded  int
+		expectedError     error
+	}{
+		{
+			name:          "Begin registration successfully",
+			inputAuthTime: time.Now().Add(-time.Minute),
+
+			setupMockPasskeyRepo: func(ctx context.Context) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{testPasskey}, nil)
+				repoMock.On("SaveSession", ctx, "session-001", mock.MatchedBy(func(session *webauthn.SessionData) bool {
+					return string(session.UserID) == testUser.ID && session.Challenge != ""
+				}), SessionExpiration).Return(nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", sessionIDLength).Return("session-001", nil)
+				return codeGenMock
+			},
+
+			expectedSessionID: "session-001",
+			expectedExcluded:  1,
+		},
+		{
+			name:          "Login is too old",
+			inputAuthTime: time.Now().Add(-ReauthenticationWindow - time.Minute),
+
+			setupMockPasskeyRepo: func(ctx context.Context) *mockPasskeyRepo.Repository {
+				return mockPasskeyRepo.NewRepository(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: ErrReauthenticationRequired,
+		},
+		{
+			name:          "Listing passkeys fails",
+			inputAuthTime: time.Now().Add(-time.Minute),
+
+			setupMockPasskeyRepo: func(ctx context.Context) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name:          "Saving session fails",
+			inputAuthTime: time.Now().Add(-time.Minute),
+
+			setupMockPasskeyRepo: func(ctx context.Context) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{}, nil)
+				repoMock.On("SaveSession", ctx, "session-001", mock.Anything, SessionExpiration).Return(assert.AnError)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", sessionIDLength).Return("session-001", nil)
+				return codeGenMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			passkeyService := NewPasskeyService(
+				tc.setupMockPasskeyRepo(ctx),
+				tc.setupMockUserRepo(ctx),
+				nil,
+				tc.setupMockCodeGen(),
+				newTestWebAuthn(t),
+			)
+
+			res, err := passkeyService.BeginRegistration(ctx, testUser.ID, tc.inputAuthTime)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.Equal(t, tc.expectedSessionID, res.SessionID)
+			assert.Equal(t, protocol.URLEncodedBase64(testUser.ID), res.Options.Response.User.ID)
+			assert.Equal(t, protocol.ResidentKeyRequirementRequired, res.Options.Response.AuthenticatorSelection.ResidentKey)
+			assert.Len(t, res.Options.Response.CredentialExcludeList, tc.expectedExcluded)
+		})
+	}
+}
+
+func TestService_FinishRegistration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		// inputCredential replaces the credential created by the authenticator when set
+		inputCredential []byte
+
+		setupMockPasskeyRepo func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository
+		setupMockUserRepo    func(ctx context.Context) *mockUserRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Finish registration successfully",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{}, nil)
+				repoMock.On("CreatePasskey", ctx, mock.MatchedBy(func(passkey *model.UserPasskey) bool {
+					return passkey.UserID == testUser.ID &&
+						bytes.Equal(passkey.CredentialID, credentialID) &&
+						len(passkey.PublicKey) > 0 &&
+						passkey.AttestationType == "none"
+				})).Return(func(ctx context.Context, passkey *model.UserPasskey) (*model.UserPasskey, error) {
+					passkey.ID = "passkey-001"
+					return passkey, nil
+				})
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Unknown or expired session",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidSession,
+		},
+		{
+			name: "Session started by another user",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository {
+				other := *session
+				other.UserID = []byte("de305d54-75b4-431b-adb2-eb6b9e546000")
+
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(&other, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidSession,
+		},
+		{
+			name:            "Malformed credential",
+			inputCredential: []byte(`{"id":"not-a-credential"}`),
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			expectedError: ErrInvalidCredential,
+		},
+		{
+			name: "Credential answers another challenge",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository {
+				other := *session
+				other.Challenge = "b3RoZXItY2hhbGxlbmdl"
+
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(&other, nil)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{}, nil)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+
+			expectedError: ErrInvalidCredential,
+		},
+		{
+			name: "Passkey already registered",
+
+			setupMockPasskeyRepo: func(ctx context.Context, session *webauthn.SessionData, credentialID []byte) *mockPasskeyRepo.Repository {
+				repoMock := mockPasskeyRepo.NewRepository(t)
+				repoMock.On("ConsumeSession", ctx, "session-001").Return(session, nil)
+				repoMock.On("ListPasskeysByUserID", ctx, testUser.ID).Return([]*model.UserPasskey{}, nil)
+				repoMock.On("CreatePasskey", ctx, mock.Anything).Return(nil, dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil)
+				return repoMock
+			},
+
+			expectedError: ErrPasskeyAlreadyRegistered,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webAuthn := newTestWebAuthn(t)
+			authenticator := fixture.NewSoftwareAuthenticator(t)
+
+			options, session, err := webAuthn.BeginRegistration(&webAuthnUser{user: testUser})
+			assert.Nil(t, err)
+
+			credential := authenticator.Register(t, options)
+			if tc.inputCredential != nil {
+				credential = tc.inputCredential
+			}
+
+			passkeyService := NewPasskeyService(
+				tc.setupMockPasskeyRepo(ctx, session, authenticator.CredentialID()),
+				tc.setupMockUserRepo(ctx),
+				nil,
+				nil,
+				webAuthn,
+			)
+
+			res, err := passkeyService.FinishRegistration(ctx, testUser.ID, "session-001", credential)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.Equal(t, "passkey-001", res.ID)
+			assert.Equal(t, []string{"internal"}, res.Transports)
+		})
+	}
+}
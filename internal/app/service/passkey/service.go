@@ -0,0 +1,155 @@
+// Package passkey provides passwordless login with WebAuthn passkeys.
+// Logged in users register passkeys on their authenticators, which then log them in
+// through a discoverable credential ceremony and get the same token as a password login.
+package passkey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	passkeyRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/passkey"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+)
+
+const (
+	// SessionExpiration is how long a client has to answer a registration or login challenge.
+	SessionExpiration = 5 * time.Minute
+
+	// ReauthenticationWindow is how recent a login must be to register a new passkey.
+	ReauthenticationWindow = 5 * time.Minute
+
+	sessionIDLength = 32
+)
+
+var (
+	ErrReauthenticationRequired = errors.New("recent login required, please log in again")
+	ErrInvalidSession           = errors.New("invalid or expired passkey session")
+	ErrInvalidCredential        = errors.New("passkey verification failed")
+	ErrPasskeyAlreadyRegistered = errors.New("this passkey is already registered")
+)
+
+// RegistrationOptions are the options handed to navigator.credentials.create().
+//
+// Fields:
+//   - SessionID: The identifier to send back with the created credential.
+//   - Options: The WebAuthn credential creation options.
+type RegistrationOptions struct {
+	SessionID string                       `json:"session_id"`
+	Options   *protocol.CredentialCreation `json:"options" swaggertype:"object"`
+}
+
+// LoginOptions are the options handed to navigator.credentials.get().
+//
+// Fields:
+//   - SessionID: The identifier to send back with the assertion.
+//   - Options: The WebAuthn credential request options.
+type LoginOptions struct {
+	SessionID string                        `json:"session_id"`
+	Options   *protocol.CredentialAssertion `json:"options" swaggertype:"object"`
+}
+
+// Service represents the interface for passkey operations.
+//
+//go:generate mockery --name=Service --filename=passkey_service.go --output=./mocks
+type Service interface {
+	// BeginRegistration starts registering a new passkey for a logged in user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the logged in user.
+	//   - authTime: When the user last authenticated, taken from the token issue time.
+	//
+	// Returns:
+	//   - *RegistrationOptions: The options to pass to the authenticator.
+	//   - error: ErrReauthenticationRequired if the login is older than ReauthenticationWindow, otherwise any storage error.
+	BeginRegistration(ctx context.Context, userID string, authTime time.Time) (*RegistrationOptions, error)
+
+	// FinishRegistration verifies the credential created by the authenticator and stores it.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the logged in user.
+	//   - sessionID: The session identifier returned by BeginRegistration.
+	//   - credential: The JSON encoded PublicKeyCredential returned by the authenticator.
+	//
+	// Returns:
+	//   - *model.UserPasskey: The registered passkey.
+	//   - error: ErrInvalidSession if the session is unknown or expired, ErrInvalidCredential if the
+	//     credential does not verify, ErrPasskeyAlreadyRegistered if it is already stored, otherwise nil.
+	FinishRegistration(ctx context.Context, userID, sessionID string, credential []byte) (*model.UserPasskey, error)
+
+	// BeginLogin starts a passkey login; the authenticator picks the account.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - *LoginOptions: The options to pass to the authenticator.
+	//   - error: An error if the session cannot be stored, otherwise nil.
+	BeginLogin(ctx context.Context) (*LoginOptions, error)
+
+	// FinishLogin verifies the assertion signed by the authenticator and returns a token for its user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - sessionID: The session identifier returned by BeginLogin.
+	//   - credential: The JSON encoded PublicKeyCredential returned by the authenticator.
+	//
+	// Returns:
+	//   - string: The JWT token if authentication is successful.
+	//   - error: ErrInvalidSession if the session is unknown or expired,
+	//     ErrInvalidCredential if the assertion does not verify, otherwise nil.
+	FinishLogin(ctx context.Context, sessionID string, credential []byte) (string, error)
+}
+
+// passkeyService is the concrete implementation of the Service interface.
+type passkeyService struct {
+	passkeyRepo passkeyRepository.Repository
+	userRepo    userRepository.Repository
+	userSvc     userService.Service
+	codeGen     utils.CodeGenerator
+	webAuthn    *webauthn.WebAuthn
+}
+
+// NewPasskeyService creates a new instance of the passkey service.
+//
+// Parameters:
+//   - passkeyRepo: The repository storing passkeys and ceremony sessions.
+//   - userRepo: The user repository used to look up users.
+//   - userSvc: The user service issuing the access token.
+//   - codeGen: The random code generator used for session identifiers.
+//   - webAuthn: The WebAuthn relying party running the ceremonies.
+//
+// Returns:
+//   - Service: A new passkey service instance.
+func NewPasskeyService(
+	passkeyRepo passkeyRepository.Repository,
+	userRepo userRepository.Repository,
+	userSvc userService.Service,
+	codeGen utils.CodeGenerator,
+	webAuthn *webauthn.WebAuthn,
+) Service {
+	return &passkeyService{
+		passkeyRepo: passkeyRepo,
+		userRepo:    userRepo,
+		userSvc:     userSvc,
+		codeGen:     codeGen,
+		webAuthn:    webAuthn,
+	}
+}
+
+// saveSession stores the session data of a ceremony under a new random identifier.
+func (p *passkeyService) saveSession(ctx context.Context, session *webauthn.SessionData) (string, error) {
+	sessionID, err := p.codeGen.GenerateCode(sessionIDLength)
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.passkeyRepo.SaveSession(ctx, sessionID, session, SessionExpiration); err != nil {
+		return "", err
+	}
+
+	return sessionID, nil
+}
@@ -0,0 +1,83 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// webAuthnUser adapts a user and their passkeys to the webauthn.User interface.
+// The user ID is used as the WebAuthn user handle, so a discoverable login
+// resolves the account directly from the handle stored on the authenticator.
+type webAuthnUser struct {
+	user     *model.User
+	passkeys []*model.UserPasskey
+}
+
+func (w *webAuthnUser) WebAuthnID() []byte {
+	return []byte(w.user.ID)
+}
+
+func (w *webAuthnUser) WebAuthnName() string {
+	return w.user.Username
+}
+
+func (w *webAuthnUser) WebAuthnDisplayName() string {
+	return w.user.DisplayName
+}
+
+func (w *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(w.passkeys))
+	for _, passkey := range w.passkeys {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(passkey.Transports))
+		for _, transport := range passkey.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              passkey.CredentialID,
+			PublicKey:       passkey.PublicKey,
+			AttestationType: passkey.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: passkey.BackupEligible,
+				BackupState:    passkey.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    passkey.AAGUID,
+				SignCount: passkey.SignCount,
+			},
+		})
+	}
+
+	return credentials
+}
+
+// passkeyByCredentialID returns the passkey holding a credential, or nil if the user has none.
+func (w *webAuthnUser) passkeyByCredentialID(credentialID []byte) *model.UserPasskey {
+	for _, passkey := range w.passkeys {
+		if bytes.Equal(passkey.CredentialID, credentialID) {
+			return passkey
+		}
+	}
+
+	return nil
+}
+
+// loadWebAuthnUser loads a user with their passkeys.
+func (p *passkeyService) loadWebAuthnUser(ctx context.Context, userID string) (*webAuthnUser, error) {
+	user, err := p.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := p.passkeyRepo.ListPasskeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webAuthnUser{user: user, passkeys: passkeys}, nil
+}
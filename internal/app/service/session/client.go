@@ -0,0 +1,35 @@
+package session
+
+import "context"
+
+// Client describes the device a request comes from.
+type Client struct {
+	IPAddress string
+	UserAgent string
+}
+
+type clientContextKey struct{}
+
+// WithClient attaches the client of a login request to the context, for the session created when the token is issued.
+//
+// Parameters:
+//   - ctx: The request context.
+//   - client: The client making the request.
+//
+// Returns:
+//   - context.Context: A context carrying the client.
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// ClientFromContext returns the client attached with WithClient, or an empty client if there is none.
+//
+// Parameters:
+//   - ctx: The request context.
+//
+// Returns:
+//   - Client: The client making the request.
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientContextKey{}).(Client)
+	return client
+}
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateSession records a login from the client attached to the context with WithClient.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user logging in.
+//   - expiresAt: When the token tied to the session expires.
+//
+// Returns:
+//   - *model.UserSession: The created session.
+//   - error: An error if the session cannot be stored, otherwise nil.
+func (svc *sessionService) CreateSession(ctx context.Context, userID string, expiresAt time.Time) (*model.UserSession, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CreateSession")
+	defer s.End()
+
+	client := ClientFromContext(ctx)
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return svc.sessionRepo.CreateSession(ctx, &model.UserSession{
+		UserID:     userID,
+		DeviceName: deviceName(client.UserAgent),
+		IPAddress:  client.IPAddress,
+		UserAgent:  userAgent,
+		LastSeenAt: time.Now(),
+		ExpiresAt:  expiresAt,
+	})
+}
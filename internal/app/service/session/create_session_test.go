@@ -0,0 +1,109 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockSessionRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/session/mocks"
+)
+
+const (
+	testUserID    = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	testSessionID = "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51"
+	testUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
+func TestService_CreateSession(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	longUserAgent := "curl/8.4.0 " + strings.Repeat("x", maxUserAgentLength)
+
+	testCases := []struct {
+		name string
+
+		inputClient *Client
+
+		setupMockSessionRepo func(ctx context.Context) *mockSessionRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name:        "Create session for the client of the request",
+			inputClient: &Client{IPAddress: "203.0.113.10", UserAgent: testUserAgent},
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("CreateSession", ctx, mock.MatchedBy(func(session *model.UserSession) bool {
+					return session.UserID == testUserID &&
+						session.DeviceName == "Chrome on macOS" &&
+						session.IPAddress == "203.0.113.10" &&
+						session.UserAgent == testUserAgent &&
+						session.ExpiresAt.Equal(expiresAt) &&
+						!session.LastSeenAt.IsZero()
+				})).Return(&model.UserSession{Base: model.Base{ID: testSessionID}}, nil)
+				return repoMock
+			},
+		},
+		{
+			name:        "Create session with a truncated user-agent",
+			inputClient: &Client{IPAddress: "203.0.113.10", UserAgent: longUserAgent},
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("CreateSession", ctx, mock.MatchedBy(func(session *model.UserSession) bool {
+					return session.DeviceName == "curl" && session.UserAgent == longUserAgent[:maxUserAgentLength]
+				})).Return(&model.UserSession{Base: model.Base{ID: testSessionID}}, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Create session without client information",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("CreateSession", ctx, mock.MatchedBy(func(session *model.UserSession) bool {
+					return session.DeviceName == "Unknown device" && session.IPAddress == ""
+				})).Return(&model.UserSession{Base: model.Base{ID: testSessionID}}, nil)
+				return repoMock
+			},
+		},
+		{
+			name:        "Create session failed - repository error",
+			inputClient: &Client{IPAddress: "203.0.113.10", UserAgent: testUserAgent},
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("CreateSession", ctx, mock.Anything).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			if tc.inputClient != nil {
+				ctx = WithClient(ctx, *tc.inputClient)
+			}
+			sessionService := NewSessionService(tc.setupMockSessionRepo(ctx))
+
+			res, err := sessionService.CreateSession(ctx, testUserID, expiresAt)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, testSessionID, res.ID)
+		})
+	}
+}
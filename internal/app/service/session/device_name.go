@@ -0,0 +1,64 @@
+package session
+
+import "strings"
+
+// userAgentRule maps a user-agent token to a readable name, the first matching rule wins.
+type userAgentRule struct {
+	token string
+	name  string
+}
+
+// Order matters: Edge and Opera also announce Chrome, and Chrome also announces Safari.
+var browserRules = []userAgentRule{
+	{token: "Edg/", name: "Edge"},
+	{token: "OPR/", name: "Opera"},
+	{token: "Firefox/", name: "Firefox"},
+	{token: "Chrome/", name: "Chrome"},
+	{token: "Safari/", name: "Safari"},
+	{token: "curl/", name: "curl"},
+	{token: "PostmanRuntime/", name: "Postman"},
+}
+
+var platformRules = []userAgentRule{
+	{token: "iPhone", name: "iOS"},
+	{token: "iPad", name: "iPadOS"},
+	{token: "Android", name: "Android"},
+	{token: "Windows", name: "Windows"},
+	{token: "Mac OS X", name: "macOS"},
+	{token: "CrOS", name: "ChromeOS"},
+	{token: "Linux", name: "Linux"},
+}
+
+// deviceName derives a readable device name such as "Chrome on macOS" from a user-agent.
+//
+// Parameters:
+//   - userAgent: The user-agent of the login request.
+//
+// Returns:
+//   - string: The device name, "Unknown device" if nothing is recognized.
+func deviceName(userAgent string) string {
+	browser := matchUserAgent(userAgent, browserRules)
+	platform := matchUserAgent(userAgent, platformRules)
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return "Unknown browser on " + platform
+	default:
+		return "Unknown device"
+	}
+}
+
+// matchUserAgent returns the name of the first rule whose token is part of the user-agent.
+func matchUserAgent(userAgent string, rules []userAgentRule) string {
+	for _, rule := range rules {
+		if strings.Contains(userAgent, rule.token) {
+			return rule.name
+		}
+	}
+
+	return ""
+}
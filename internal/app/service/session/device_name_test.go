@@ -0,0 +1,63 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUserAgent string
+
+		expectedOutput string
+	}{
+		{
+			name:           "Chrome on macOS",
+			inputUserAgent: testUserAgent,
+			expectedOutput: "Chrome on macOS",
+		},
+		{
+			name:           "Edge is not mistaken for Chrome",
+			inputUserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+			expectedOutput: "Edge on Windows",
+		},
+		{
+			name:           "Safari on iOS",
+			inputUserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1",
+			expectedOutput: "Safari on iOS",
+		},
+		{
+			name:           "Firefox on Android",
+			inputUserAgent: "Mozilla/5.0 (Android 14; Mobile; rv:121.0) Gecko/121.0 Firefox/121.0",
+			expectedOutput: "Firefox on Android",
+		},
+		{
+			name:           "Command-line client",
+			inputUserAgent: "curl/8.4.0",
+			expectedOutput: "curl",
+		},
+		{
+			name:           "Unknown browser on a known platform",
+			inputUserAgent: "SomeApp/1.0 (Linux)",
+			expectedOutput: "Unknown browser on Linux",
+		},
+		{
+			name:           "Nothing recognized",
+			inputUserAgent: "",
+			expectedOutput: "Unknown device",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedOutput, deviceName(tc.inputUserAgent))
+		})
+	}
+}
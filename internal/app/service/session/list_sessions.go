@@ -0,0 +1,35 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListSessions retrieves the active sessions of a user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - currentSessionID: The session of the request, flagged as current in the result.
+//
+// Returns:
+//   - []*model.UserSession: The active sessions, most recently seen first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (svc *sessionService) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*model.UserSession, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListSessions")
+	defer s.End()
+
+	sessions, err := svc.sessionRepo.ListActiveSessionsByUserID(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockSessionRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/session/mocks"
+)
+
+func TestService_ListSessions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSessionRepo func(ctx context.Context) *mockSessionRepo.Repository
+
+		expectedOutput []*model.UserSession
+		expectedError  error
+	}{
+		{
+			name: "List sessions and flag the current one",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("ListActiveSessionsByUserID", ctx, testUserID, mock.AnythingOfType("time.Time")).
+					Return([]*model.UserSession{
+						{Base: model.Base{ID: "session-002"}},
+						{Base: model.Base{ID: testSessionID}},
+					}, nil)
+				return repoMock
+			},
+
+			expectedOutput: []*model.UserSession{
+				{Base: model.Base{ID: "session-002"}},
+				{Base: model.Base{ID: testSessionID}, Current: true},
+			},
+		},
+		{
+			name: "List sessions failed - repository error",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("ListActiveSessionsByUserID", ctx, testUserID, mock.AnythingOfType("time.Time")).
+					Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			sessionService := NewSessionService(tc.setupMockSessionRepo(ctx))
+
+			res, err := sessionService.ListSessions(ctx, testUserID, testSessionID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
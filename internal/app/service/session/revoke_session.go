@@ -0,0 +1,24 @@
+package session
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// RevokeSession logs a user out of a single device.
+// Tokens tied to the session are rejected from the next request on.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - userID: The ID of the user.
+//   - sessionID: The ID of the session to revoke.
+//
+// Returns:
+//   - error: dbutils.ErrRecordNotFoundType if the user owns no such session, otherwise any deletion error.
+func (svc *sessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RevokeSession")
+	defer s.End()
+
+	return svc.sessionRepo.DeleteSession(ctx, userID, sessionID)
+}
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockSessionRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/session/mocks"
+)
+
+func TestService_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSessionRepo func(ctx context.Context) *mockSessionRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Revoke session successfully",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("DeleteSession", ctx, testUserID, testSessionID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Revoke session failed - session not found",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("DeleteSession", ctx, testUserID, testSessionID).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			sessionService := NewSessionService(tc.setupMockSessionRepo(ctx))
+
+			err := sessionService.RevokeSession(ctx, testUserID, testSessionID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
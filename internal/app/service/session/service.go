@@ -0,0 +1,90 @@
+// Package session records a session for every login token and lets users see
+// and revoke the devices they are logged in from. Tokens carry the session ID,
+// so revoking a session logs out that single device.
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	sessionRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/session"
+)
+
+const (
+	// SessionIDClaim is the claim holding the ID of the session a login token belongs to.
+	SessionIDClaim = "sid"
+
+	// LastSeenResolution is how stale the last-seen time may get before it is written again.
+	LastSeenResolution = time.Minute
+
+	maxUserAgentLength = 512
+)
+
+var (
+	ErrSessionRevoked = errors.New("session has been revoked or has expired")
+)
+
+// Service represents the interface for login session operations.
+//
+//go:generate mockery --name=Service --filename=session_service.go --output=./mocks
+type Service interface {
+	// CreateSession records a login from the client attached to the context with WithClient.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user logging in.
+	//   - expiresAt: When the token tied to the session expires.
+	//
+	// Returns:
+	//   - *model.UserSession: The created session.
+	//   - error: An error if the session cannot be stored, otherwise nil.
+	CreateSession(ctx context.Context, userID string, expiresAt time.Time) (*model.UserSession, error)
+
+	// ListSessions retrieves the active sessions of a user.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - currentSessionID: The session of the request, flagged as current in the result.
+	//
+	// Returns:
+	//   - []*model.UserSession: The active sessions, most recently seen first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]*model.UserSession, error)
+
+	// RevokeSession logs a user out of a single device.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - userID: The ID of the user.
+	//   - sessionID: The ID of the session to revoke.
+	//
+	// Returns:
+	//   - error: dbutils.ErrRecordNotFoundType if the user owns no such session, otherwise any deletion error.
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+
+	// ValidateSession checks that a session is still active and records that it was seen.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - sessionID: The ID of the session taken from the token.
+	//
+	// Returns:
+	//   - error: ErrSessionRevoked if the session was revoked or has expired, otherwise any storage error.
+	ValidateSession(ctx context.Context, sessionID string) error
+}
+
+type sessionService struct {
+	sessionRepo sessionRepository.Repository
+}
+
+// NewSessionService creates a new instance of the login session service.
+//
+// Parameters:
+//   - sessionRepo: The repository storing the sessions.
+//
+// Returns:
+//   - Service: A new login session service instance.
+func NewSessionService(sessionRepo sessionRepository.Repository) Service {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+	}
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+)
+
+// ValidateSession checks that a session is still active and records that it was seen.
+// The last-seen time is written at most once per LastSeenResolution.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - sessionID: The ID of the session taken from the token.
+//
+// Returns:
+//   - error: ErrSessionRevoked if the session was revoked or has expired, otherwise any storage error.
+func (svc *sessionService) ValidateSession(ctx context.Context, sessionID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ValidateSession")
+	defer s.End()
+
+	session, err := svc.sessionRepo.GetSessionByID(ctx, sessionID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrSessionRevoked
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !now.Before(session.ExpiresAt) {
+		return ErrSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) >= LastSeenResolution {
+		return svc.sessionRepo.UpdateSessionLastSeen(ctx, session.ID, now)
+	}
+
+	return nil
+}
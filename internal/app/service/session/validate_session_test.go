@@ -0,0 +1,104 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockSessionRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/session/mocks"
+)
+
+func TestService_ValidateSession(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(24 * time.Hour)
+
+	testCases := []struct {
+		name string
+
+		setupMockSessionRepo func(ctx context.Context) *mockSessionRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Validate a session seen a while ago",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", ctx, testSessionID).Return(&model.UserSession{
+					Base:       model.Base{ID: testSessionID},
+					LastSeenAt: time.Now().Add(-time.Hour),
+					ExpiresAt:  future,
+				}, nil)
+				repoMock.On("UpdateSessionLastSeen", ctx, testSessionID, mock.AnythingOfType("time.Time")).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Validate a session seen just now",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", ctx, testSessionID).Return(&model.UserSession{
+					Base:       model.Base{ID: testSessionID},
+					LastSeenAt: time.Now(),
+					ExpiresAt:  future,
+				}, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Validate failed - session revoked",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", ctx, testSessionID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrSessionRevoked,
+		},
+		{
+			name: "Validate failed - session expired",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", ctx, testSessionID).Return(&model.UserSession{
+					Base:       model.Base{ID: testSessionID},
+					LastSeenAt: time.Now().Add(-25 * time.Hour),
+					ExpiresAt:  time.Now().Add(-time.Hour),
+				}, nil)
+				return repoMock
+			},
+
+			expectedError: ErrSessionRevoked,
+		},
+		{
+			name: "Validate failed - repository error",
+
+			setupMockSessionRepo: func(ctx context.Context) *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", ctx, testSessionID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			sessionService := NewSessionService(tc.setupMockSessionRepo(ctx))
+
+			err := sessionService.ValidateSession(ctx, testSessionID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
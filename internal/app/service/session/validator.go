@@ -0,0 +1,60 @@
+package session
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// sessionValidator rejects login tokens whose session was revoked.
+type sessionValidator struct {
+	sessionSvc   Service
+	jwtValidator jwtutils.JWTValidator
+}
+
+// NewSessionValidator wraps a JWT validator so that tokens tied to a revoked or expired session are rejected.
+//
+// Parameters:
+//   - sessionSvc: The service checking the sessions.
+//   - jwtValidator: The validator checking the token signature and expiry.
+//
+// Returns:
+//   - jwtutils.JWTValidator: A validator also checking the session of the token.
+func NewSessionValidator(sessionSvc Service, jwtValidator jwtutils.JWTValidator) jwtutils.JWTValidator {
+	return &sessionValidator{
+		sessionSvc:   sessionSvc,
+		jwtValidator: jwtValidator,
+	}
+}
+
+// ValidateToken validates a JWT and the session it belongs to.
+// Tokens issued before sessions were recorded carry no session ID and are only checked by the wrapped validator.
+// The middleware does not hand over the request context, so the lookup runs on a background context carrying
+// the tenant of the token.
+//
+// Parameters:
+//   - tokenString: The bearer token taken from the request.
+//
+// Returns:
+//   - jwt.MapClaims: The claims of the token.
+//   - error: An error if the token or its session is not valid.
+func (v *sessionValidator) ValidateToken(tokenString string) (jwt.MapClaims, error) {
+	claims, err := v.jwtValidator.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, ok := claims[SessionIDClaim].(string)
+	if !ok {
+		return claims, nil
+	}
+
+	ctx := tenant.NewContext(context.Background(), tenant.FromClaims(claims))
+	if err := v.sessionSvc.ValidateSession(ctx, sessionID); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
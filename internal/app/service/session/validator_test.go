@@ -0,0 +1,108 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	jwtMocks "github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockSessionRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/session/mocks"
+)
+
+func TestSessionValidator_ValidateToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSessionRepo  func() *mockSessionRepo.Repository
+		setupMockJWTValidator func() *jwtMocks.JWTValidator
+
+		expectedOutput jwt.MapClaims
+		expectedError  error
+	}{
+		{
+			name: "Token of an active session",
+
+			setupMockSessionRepo: func() *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", mock.Anything, testSessionID).Return(&model.UserSession{
+					Base:       model.Base{ID: testSessionID},
+					LastSeenAt: time.Now(),
+					ExpiresAt:  time.Now().Add(time.Hour),
+				}, nil)
+				return repoMock
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				validatorMock := jwtMocks.NewJWTValidator(t)
+				validatorMock.On("ValidateToken", "valid_jwt_token").
+					Return(jwt.MapClaims{"sub": testUserID, "sid": testSessionID}, nil)
+				return validatorMock
+			},
+
+			expectedOutput: jwt.MapClaims{"sub": testUserID, "sid": testSessionID},
+		},
+		{
+			name: "Token issued before sessions were recorded",
+
+			setupMockSessionRepo: func() *mockSessionRepo.Repository {
+				return mockSessionRepo.NewRepository(t)
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				validatorMock := jwtMocks.NewJWTValidator(t)
+				validatorMock.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": testUserID}, nil)
+				return validatorMock
+			},
+
+			expectedOutput: jwt.MapClaims{"sub": testUserID},
+		},
+		{
+			name: "Token of a revoked session",
+
+			setupMockSessionRepo: func() *mockSessionRepo.Repository {
+				repoMock := mockSessionRepo.NewRepository(t)
+				repoMock.On("GetSessionByID", mock.Anything, testSessionID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				validatorMock := jwtMocks.NewJWTValidator(t)
+				validatorMock.On("ValidateToken", "valid_jwt_token").
+					Return(jwt.MapClaims{"sub": testUserID, "sid": testSessionID}, nil)
+				return validatorMock
+			},
+
+			expectedError: ErrSessionRevoked,
+		},
+		{
+			name: "Invalid JWT",
+
+			setupMockSessionRepo: func() *mockSessionRepo.Repository {
+				return mockSessionRepo.NewRepository(t)
+			},
+			setupMockJWTValidator: func() *jwtMocks.JWTValidator {
+				validatorMock := jwtMocks.NewJWTValidator(t)
+				validatorMock.On("ValidateToken", "valid_jwt_token").Return(nil, assert.AnError)
+				return validatorMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sessionService := NewSessionService(tc.setupMockSessionRepo())
+			validator := NewSessionValidator(sessionService, tc.setupMockJWTValidator())
+
+			res, err := validator.ValidateToken("valid_jwt_token")
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
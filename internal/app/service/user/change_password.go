@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// ChangePassword replaces the password of a user after checking the current one.
+// The new password is screened against breached passwords, and must differ from the last
+// PasswordPolicy.HistorySize passwords of the user, the current one included.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user whose password changes.
+//   - currentPassword: The current password of the user.
+//   - newPassword: The new password.
+//
+// Returns:
+//   - error: ErrInvalidCredentials if the current password is wrong or the user has none, breach.ErrBreached if
+//     the new password was breached and breached passwords are rejected, ErrPasswordReused if it is one of the
+//     recent passwords, otherwise an error if the change fails.
+func (u *userService) ChangePassword(ctx context.Context, id, currentPassword, newPassword string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ChangePassword")
+	defer s.End()
+
+	user, err := u.userRepo.GetUserCredentialsByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !user.HasPassword() || !u.passwordHashing.CompareHashAndPassword(user.Password, currentPassword) {
+		return ErrInvalidCredentials
+	}
+
+	err = u.breachChecker.Check(ctx, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// The current password counts as the first of the history
+	keep := max(u.passwordPolicy.HistorySize-1, 0)
+	if u.passwordPolicy.HistorySize > 0 && u.passwordHashing.CompareHashAndPassword(user.Password, newPassword) {
+		return ErrPasswordReused
+	}
+	if keep > 0 {
+		history, err := u.userRepo.ListPasswordHistory(ctx, id, keep)
+		if err != nil {
+			return err
+		}
+		for _, previous := range history {
+			if u.passwordHashing.CompareHashAndPassword(previous.PasswordHash, newPassword) {
+				return ErrPasswordReused
+			}
+		}
+	}
+
+	hashedPassword, err := u.passwordHashing.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepo.ChangePasswordByID(ctx, id, hashedPassword, keep)
+}
@@ -0,0 +1,249 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	mockBreach "github.com/vukieuhaihoa/user-service/internal/breach/mocks"
+	mockPasswordHashing "github.com/vukieuhaihoa/user-service/internal/passwordhash/mocks"
+)
+
+var passwordTestUser = &model.User{
+	Base:     model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+	Username: "testuser",
+	Password: "current-hash",
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockUserRepo        func(ctx context.Context) *mockUserRepo.Repository
+		setupMockPasswordHashing func(t *testing.T) *mockPasswordHashing.PasswordHashing
+		setupMockBreachChecker   func(ctx context.Context) *mockBreach.Checker
+
+		passwordPolicy PasswordPolicy
+
+		expectedError error
+	}{
+		{
+			name: "Change password successfully",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				repoMock.On("ListPasswordHistory", ctx, passwordTestUser.ID, 2).Return([]*model.PasswordHistory{
+					{PasswordHash: "old-hash-1"},
+					{PasswordHash: "old-hash-2"},
+				}, nil)
+				repoMock.On("ChangePasswordByID", ctx, passwordTestUser.ID, "new-hash", 2).Return(nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(true)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "new-password").Return(false)
+				hashingMock.On("CompareHashAndPassword", "old-hash-1", "new-password").Return(false)
+				hashingMock.On("CompareHashAndPassword", "old-hash-2", "new-password").Return(false)
+				hashingMock.On("Hash", "new-password").Return("new-hash", nil)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "new-password").Return(nil).Once()
+				return checkerMock
+			},
+
+			passwordPolicy: PasswordPolicy{HistorySize: 3},
+		},
+		{
+			name: "Change password without a history",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				repoMock.On("ChangePasswordByID", ctx, passwordTestUser.ID, "new-hash", 0).Return(nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(true)
+				hashingMock.On("Hash", "new-password").Return("new-hash", nil)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "new-password").Return(nil).Once()
+				return checkerMock
+			},
+		},
+		{
+			name: "Fail to change password - wrong current password",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(false)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				return mockBreach.NewChecker(t)
+			},
+
+			passwordPolicy: PasswordPolicy{HistorySize: 3},
+
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name: "Fail to change password - user has no password",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(&model.User{
+					Base:     passwordTestUser.Base,
+					Username: passwordTestUser.Username,
+				}, nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				return mockPasswordHashing.NewPasswordHashing(t)
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				return mockBreach.NewChecker(t)
+			},
+
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name: "Fail to change password - breached password",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(true)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "new-password").Return(breach.ErrBreached).Once()
+				return checkerMock
+			},
+
+			passwordPolicy: PasswordPolicy{HistorySize: 3},
+
+			expectedError: breach.ErrBreached,
+		},
+		{
+			name: "Fail to change password - same as the current one",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(true)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "new-password").Return(true)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "new-password").Return(nil).Once()
+				return checkerMock
+			},
+
+			passwordPolicy: PasswordPolicy{HistorySize: 1},
+
+			expectedError: ErrPasswordReused,
+		},
+		{
+			name: "Fail to change password - used recently",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				repoMock.On("ListPasswordHistory", ctx, passwordTestUser.ID, 2).Return([]*model.PasswordHistory{
+					{PasswordHash: "old-hash-1"},
+					{PasswordHash: "old-hash-2"},
+				}, nil)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(true)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "new-password").Return(false)
+				hashingMock.On("CompareHashAndPassword", "old-hash-1", "new-password").Return(false)
+				hashingMock.On("CompareHashAndPassword", "old-hash-2", "new-password").Return(true)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "new-password").Return(nil).Once()
+				return checkerMock
+			},
+
+			passwordPolicy: PasswordPolicy{HistorySize: 3},
+
+			expectedError: ErrPasswordReused,
+		},
+		{
+			name: "Fail to change password - repository error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByID", ctx, passwordTestUser.ID).Return(passwordTestUser, nil)
+				repoMock.On("ChangePasswordByID", ctx, passwordTestUser.ID, "new-hash", 0).Return(assert.AnError)
+				return repoMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "current-password").Return(true)
+				hashingMock.On("CompareHashAndPassword", "current-hash", "new-password").Return(false)
+				hashingMock.On("Hash", "new-password").Return("new-hash", nil)
+				return hashingMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "new-password").Return(nil).Once()
+				return checkerMock
+			},
+
+			passwordPolicy: PasswordPolicy{HistorySize: 1},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			userService := NewUserService(Deps{
+				UserRepo:        tc.setupMockUserRepo(ctx),
+				PasswordHashing: tc.setupMockPasswordHashing(t),
+				BreachChecker:   tc.setupMockBreachChecker(ctx),
+				PasswordPolicy:  tc.passwordPolicy,
+			})
+
+			err := userService.ChangePassword(ctx, passwordTestUser.ID, "current-password", "new-password")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
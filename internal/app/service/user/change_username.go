@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+)
+
+// ChangeUsername changes the username of a user, at most once per UsernameChangeCooldown.
+// The old username stays held for the user for UsernameHoldPeriod.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user whose username changes.
+//   - version: The version of the user the change is based on, or 0 to change whatever the current version is.
+//   - username: The new username.
+//
+// Returns:
+//   - int: The version of the user after the change.
+//   - error: ErrUsernameUnchanged, ErrUsernameCooldown, ErrVersionConflict, dbutils.ErrDuplicationType, or any error
+//     of the change.
+func (u *userService) ChangeUsername(ctx context.Context, id string, version int, username string) (int, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ChangeUsername")
+	defer s.End()
+
+	current, err := u.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if version != 0 && current.Version != version {
+		return 0, ErrVersionConflict
+	}
+
+	if username == current.Username {
+		return 0, ErrUsernameUnchanged
+	}
+
+	latest, err := u.userRepo.GetLatestUsernameChange(ctx, id)
+	switch {
+	case errors.Is(err, dbutils.ErrRecordNotFoundType):
+	case err != nil:
+		return 0, err
+	case time.Since(latest.CreatedAt) < UsernameChangeCooldown:
+		return 0, ErrUsernameCooldown
+	}
+
+	updatedUser := &model.User{
+		Username: username,
+		Version:  version,
+	}
+
+	err = u.userRepo.ChangeUsernameByID(ctx, id, updatedUser, time.Now().Add(UsernameHoldPeriod))
+	if errors.Is(err, user.ErrVersionConflict) {
+		return 0, ErrVersionConflict
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return updatedUser.Version, nil
+}
@@ -0,0 +1,187 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func TestService_ChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	heldUntil := mock.MatchedBy(func(heldUntil time.Time) bool {
+		return time.Until(heldUntil) > UsernameHoldPeriod-time.Minute
+	})
+
+	testCases := []struct {
+		name string
+
+		setupMockUserRepo func(ctx context.Context) *mockUserRepo.Repository
+		inputVersion      int
+		inputUsername     string
+
+		expectedError   error
+		expectedVersion int
+	}{
+		{
+			name: "Change username successfully",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetLatestUsernameChange", ctx, profileTestUser.ID).Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("ChangeUsernameByID", ctx, profileTestUser.ID, &model.User{Username: "newname", Version: 2}, heldUntil).
+					Run(func(args mock.Arguments) {
+						args.Get(2).(*model.User).Version = 3
+					}).Return(nil)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: "newname",
+
+			expectedVersion: 3,
+		},
+		{
+			name: "Change username after the cooldown",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetLatestUsernameChange", ctx, profileTestUser.ID).Return(&model.UsernameChange{
+					Base: model.Base{CreatedAt: time.Now().Add(-UsernameChangeCooldown - time.Hour)},
+				}, nil)
+				repoMock.On("ChangeUsernameByID", ctx, profileTestUser.ID, &model.User{Username: "newname"}, heldUntil).
+					Run(func(args mock.Arguments) {
+						args.Get(2).(*model.User).Version = 3
+					}).Return(nil)
+				return repoMock
+			},
+			inputUsername: "newname",
+
+			expectedVersion: 3,
+		},
+		{
+			name: "Fail to change username - within the cooldown",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetLatestUsernameChange", ctx, profileTestUser.ID).Return(&model.UsernameChange{
+					Base: model.Base{CreatedAt: time.Now().Add(-time.Hour)},
+				}, nil)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: "newname",
+
+			expectedError: ErrUsernameCooldown,
+		},
+		{
+			name: "Fail to change username - unchanged",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: profileTestUser.Username,
+
+			expectedError: ErrUsernameUnchanged,
+		},
+		{
+			name: "Fail to change username - version conflict",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				return repoMock
+			},
+			inputVersion:  1,
+			inputUsername: "newname",
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Fail to change username - version conflict while writing",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetLatestUsernameChange", ctx, profileTestUser.ID).Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("ChangeUsernameByID", ctx, profileTestUser.ID, &model.User{Username: "newname", Version: 2}, heldUntil).Return(user.ErrVersionConflict)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: "newname",
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Fail to change username - username taken",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetLatestUsernameChange", ctx, profileTestUser.ID).Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("ChangeUsernameByID", ctx, profileTestUser.ID, &model.User{Username: "takenname", Version: 2}, heldUntil).Return(dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: "takenname",
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Fail to change username - history error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetLatestUsernameChange", ctx, profileTestUser.ID).Return(nil, assert.AnError)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: "newname",
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Fail to change username - user not found",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			inputVersion:  2,
+			inputUsername: "newname",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			userRepoMock := tc.setupMockUserRepo(ctx)
+
+			userService := NewUserService(Deps{
+				UserRepo: userRepoMock,
+			})
+
+			version, err := userService.ChangeUsername(ctx, profileTestUser.ID, tc.inputVersion, tc.inputUsername)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
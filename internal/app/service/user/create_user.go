@@ -8,7 +8,10 @@ import (
 )
 
 // CreateUser creates a new user with the provided information.
-// It hashes the password before storing the user in the database.
+// It checks the registration against the registration policy and the email address against the email policy,
+// screens the password against breached passwords and hashes it before storing the user in the database.
+// With an invitation token, the user is stored by the invitation service, which consumes the invitation in the
+// same transaction and grants its role.
 //
 // Parameters:
 //   - ctx: The context for managing request-scoped values and cancellation.
@@ -16,14 +19,33 @@ import (
 //   - password: The password of the new user.
 //   - displayName: The display name of the new user.
 //   - email: The email address of the new user.
+//   - invitationToken: The token of the invitation of the user, or empty to register without invitation.
 //
 // Returns:
 //   - *model.User: The created user model.
-//   - error: An error if the creation fails, otherwise nil.
-func (u *userService) CreateUser(ctx context.Context, username, password, displayName, email string) (*model.User, error) {
+//   - error: An error of the registration policy if the registration is refused, an error of the email policy if
+//     the email address is rejected, breach.ErrBreached if the password was breached and breached passwords are
+//     rejected, an error of the invitation service if the invitation cannot be used, otherwise an error if the
+//     creation fails.
+func (u *userService) CreateUser(ctx context.Context, username, password, displayName, email, invitationToken string) (*model.User, error) {
 	s := newrelic.FromContext(ctx).StartSegment("Service_CreateUser")
 	defer s.End()
 
+	err := u.registration.Check(email, invitationToken != "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.emailPolicy.Check(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.breachChecker.Check(ctx, password)
+	if err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := u.passwordHashing.Hash(password)
 	if err != nil {
 		return nil, err
@@ -36,6 +58,10 @@ func (u *userService) CreateUser(ctx context.Context, username, password, displa
 		Email:       email,
 	}
 
+	if invitationToken != "" {
+		return u.invitationSvc.Accept(ctx, invitationToken, newUser)
+	}
+
 	createdUser, err := u.userRepo.CreateUser(ctx, newUser)
 	if err != nil {
 		return nil, err
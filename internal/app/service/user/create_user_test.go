@@ -5,10 +5,18 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
-	mockPasswordHashing "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
 	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+	mockInvitationSvc "github.com/vukieuhaihoa/user-service/internal/app/service/invitation/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	mockBreach "github.com/vukieuhaihoa/user-service/internal/breach/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	mockEmailPolicy "github.com/vukieuhaihoa/user-service/internal/emailpolicy/mocks"
+	mockPasswordHashing "github.com/vukieuhaihoa/user-service/internal/passwordhash/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
 )
 
 func TestService_CreateUser(t *testing.T) {
@@ -19,11 +27,16 @@ func TestService_CreateUser(t *testing.T) {
 
 		setupMockPasswordHashing func(t *testing.T) *mockPasswordHashing.PasswordHashing
 		setupMockUserRepo        func(ctx context.Context) *mockUserRepo.Repository
+		setupMockBreachChecker   func(ctx context.Context) *mockBreach.Checker
+		setupMockEmailPolicy     func(ctx context.Context) *mockEmailPolicy.Checker
+		setupMockInvitationSvc   func(ctx context.Context) *mockInvitationSvc.Service
+		registrationPolicy       registration.Policy
 
-		inputUsername    string
-		inputPassword    string
-		inputDisplayName string
-		inputEmail       string
+		inputUsername        string
+		inputPassword        string
+		inputDisplayName     string
+		inputEmail           string
+		inputInvitationToken string
 
 		expectedOutput *model.User
 		expectedError  error
@@ -31,6 +44,17 @@ func TestService_CreateUser(t *testing.T) {
 		{
 			name: "Create user successfully",
 
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "testuser@example.com").Return(nil).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "password123").Return(nil).Once()
+				return checkerMock
+			},
+
 			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
 				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
 				hashingMock.On("Hash", "password123").Return("$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", nil)
@@ -75,6 +99,17 @@ func TestService_CreateUser(t *testing.T) {
 		{
 			name: "Fail to hash password",
 
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "testuser2@example.com").Return(nil).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "badpassword").Return(nil).Once()
+				return checkerMock
+			},
+
 			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
 				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
 				hashingMock.On("Hash", "badpassword").Return("", utils.ErrCannotGenerateHash)
@@ -95,6 +130,17 @@ func TestService_CreateUser(t *testing.T) {
 		{
 			name: "Fail to create user in repository",
 
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "testuser3@example.com").Return(nil).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "password123").Return(nil).Once()
+				return checkerMock
+			},
+
 			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
 				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
 				hashingMock.On("Hash", "password123").Return("$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", nil)
@@ -119,6 +165,217 @@ func TestService_CreateUser(t *testing.T) {
 
 			expectedError: assert.AnError,
 		},
+
+		{
+			name: "Fail because the password was breached",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "testuser4@example.com").Return(nil).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "Password123!").Return(breach.ErrBreached).Once()
+				return checkerMock
+			},
+
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				return mockPasswordHashing.NewPasswordHashing(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			inputUsername:    "testuser4",
+			inputPassword:    "Password123!",
+			inputDisplayName: "Test User 4",
+			inputEmail:       "testuser4@example.com",
+
+			expectedError: breach.ErrBreached,
+		},
+		{
+			name: "Fail because the email domain is disposable",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "testuser5@mailinator.com").Return(emailpolicy.ErrDisposable).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				return mockBreach.NewChecker(t)
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				return mockPasswordHashing.NewPasswordHashing(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+
+			inputUsername:    "testuser5",
+			inputPassword:    "password123",
+			inputDisplayName: "Test User 5",
+			inputEmail:       "testuser5@mailinator.com",
+
+			expectedError: emailpolicy.ErrDisposable,
+		},
+		{
+			name: "Create user with an invitation successfully",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "invitee@example.com").Return(nil).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "password123").Return(nil).Once()
+				return checkerMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("Hash", "password123").Return("$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", nil)
+				return hashingMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockInvitationSvc: func(ctx context.Context) *mockInvitationSvc.Service {
+				svcMock := mockInvitationSvc.NewService(t)
+				svcMock.On("Accept", ctx, "invite-001", &model.User{
+					Username:    "invitee",
+					Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+					DisplayName: "Invitee",
+					Email:       "invitee@example.com",
+				}).Return(&model.User{
+					Base:     model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+					Username: "invitee",
+					Role:     model.RoleAdmin,
+				}, nil)
+				return svcMock
+			},
+			registrationPolicy: registration.Policy{Mode: registration.ModeInviteOnly},
+
+			inputUsername:        "invitee",
+			inputPassword:        "password123",
+			inputDisplayName:     "Invitee",
+			inputEmail:           "invitee@example.com",
+			inputInvitationToken: "invite-001",
+
+			expectedOutput: &model.User{
+				Base:     model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+				Username: "invitee",
+				Role:     model.RoleAdmin,
+			},
+		},
+		{
+			name: "Fail because the invitation cannot be used",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "invitee@example.com").Return(nil).Once()
+				return policyMock
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				checkerMock := mockBreach.NewChecker(t)
+				checkerMock.On("Check", ctx, "password123").Return(nil).Once()
+				return checkerMock
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("Hash", "password123").Return("hashed", nil)
+				return hashingMock
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockInvitationSvc: func(ctx context.Context) *mockInvitationSvc.Service {
+				svcMock := mockInvitationSvc.NewService(t)
+				svcMock.On("Accept", ctx, "invite-001", mock.Anything).Return(nil, invitation.ErrInvalidInvitation)
+				return svcMock
+			},
+
+			inputUsername:        "invitee",
+			inputPassword:        "password123",
+			inputDisplayName:     "Invitee",
+			inputEmail:           "invitee@example.com",
+			inputInvitationToken: "invite-001",
+
+			expectedError: invitation.ErrInvalidInvitation,
+		},
+		{
+			name: "Fail because registration is closed",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				return mockEmailPolicy.NewChecker(t)
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				return mockBreach.NewChecker(t)
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				return mockPasswordHashing.NewPasswordHashing(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			registrationPolicy: registration.Policy{Mode: registration.ModeClosed},
+
+			inputUsername:        "invitee",
+			inputPassword:        "password123",
+			inputDisplayName:     "Invitee",
+			inputEmail:           "invitee@example.com",
+			inputInvitationToken: "invite-001",
+
+			expectedError: registration.ErrClosed,
+		},
+		{
+			name: "Fail because an invitation is required",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				return mockEmailPolicy.NewChecker(t)
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				return mockBreach.NewChecker(t)
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				return mockPasswordHashing.NewPasswordHashing(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			registrationPolicy: registration.Policy{Mode: registration.ModeInviteOnly},
+
+			inputUsername:    "testuser6",
+			inputPassword:    "password123",
+			inputDisplayName: "Test User 6",
+			inputEmail:       "testuser6@example.com",
+
+			expectedError: registration.ErrInvitationRequired,
+		},
+		{
+			name: "Fail because the email domain is not allowed",
+
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				return mockEmailPolicy.NewChecker(t)
+			},
+			setupMockBreachChecker: func(ctx context.Context) *mockBreach.Checker {
+				return mockBreach.NewChecker(t)
+			},
+			setupMockPasswordHashing: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				return mockPasswordHashing.NewPasswordHashing(t)
+			},
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			registrationPolicy: registration.Policy{Mode: registration.ModeAllowedDomains, AllowedDomains: []string{"corp.example.com"}},
+
+			inputUsername:    "testuser7",
+			inputPassword:    "password123",
+			inputDisplayName: "Test User 7",
+			inputEmail:       "testuser7@example.com",
+
+			expectedError: registration.ErrEmailDomainNotAllowed,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -128,10 +385,23 @@ func TestService_CreateUser(t *testing.T) {
 			ctx := t.Context()
 			passwordHashingMock := tc.setupMockPasswordHashing(t)
 			userRepoMock := tc.setupMockUserRepo(ctx)
+			breachCheckerMock := tc.setupMockBreachChecker(ctx)
+			emailPolicyMock := tc.setupMockEmailPolicy(ctx)
+			invitationSvcMock := mockInvitationSvc.NewService(t)
+			if tc.setupMockInvitationSvc != nil {
+				invitationSvcMock = tc.setupMockInvitationSvc(ctx)
+			}
 
-			userService := NewUserService(userRepoMock, passwordHashingMock, nil)
+			userService := NewUserService(Deps{
+				UserRepo:           userRepoMock,
+				PasswordHashing:    passwordHashingMock,
+				BreachChecker:      breachCheckerMock,
+				EmailPolicy:        emailPolicyMock,
+				InvitationSvc:      invitationSvcMock,
+				RegistrationPolicy: tc.registrationPolicy,
+			})
 
-			res, err := userService.CreateUser(ctx, tc.inputUsername, tc.inputPassword, tc.inputDisplayName, tc.inputEmail)
+			res, err := userService.CreateUser(ctx, tc.inputUsername, tc.inputPassword, tc.inputDisplayName, tc.inputEmail, tc.inputInvitationToken)
 			assert.Equal(t, tc.expectedError, err)
 			assert.Equal(t, tc.expectedOutput, res)
 		})
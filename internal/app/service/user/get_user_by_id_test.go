@@ -77,7 +77,9 @@ func TestService_GetUserByID(t *testing.T) {
 			ctx := t.Context()
 			userRepoMock := tc.setupMockUserRepo(ctx)
 
-			userService := NewUserService(userRepoMock, nil, nil)
+			userService := NewUserService(Deps{
+				UserRepo: userRepoMock,
+			})
 
 			res, err := userService.GetUserByID(ctx, tc.inputUserID)
 			assert.Equal(t, tc.expectedError, err)
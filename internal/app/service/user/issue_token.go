@@ -0,0 +1,62 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// IssueToken generates the JWT access token handed out after a successful login.
+// Every login method goes through this function so that all tokens share the same claims.
+// A session is recorded for the client attached to the context, and the token carries its ID, the tenant of the
+// context and the role of the user.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The authenticated user the token is issued for.
+//
+// Returns:
+//   - string: The signed JWT token.
+//   - error: An error if token generation fails, otherwise nil.
+func (u *userService) IssueToken(ctx context.Context, user *model.User) (string, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_IssueToken")
+	defer s.End()
+
+	return u.issueToken(ctx, user, TokenExpirationDuration, nil)
+}
+
+// issueToken records a session valid for ttl and generates a JWT token carrying its ID and the extra claims.
+// A user without a role loaded, such as one just created with the default of the column, is a member.
+func (u *userService) issueToken(ctx context.Context, user *model.User, ttl time.Duration, extraClaims jwt.MapClaims) (string, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	userSession, err := u.sessionSvc.CreateSession(ctx, user.ID, expiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	role := user.Role
+	if role == "" {
+		role = model.RoleMember
+	}
+
+	jwtContent := jwt.MapClaims{
+		"sub":                  user.ID,
+		session.SessionIDClaim: userSession.ID,
+		tenant.Claim:           tenant.FromContext(ctx),
+		RoleClaim:              role,
+		"iat":                  now.Unix(),
+		"exp":                  expiresAt.Unix(),
+	}
+	for claim, value := range extraClaims {
+		jwtContent[claim] = value
+	}
+
+	return u.jwtGenerator.GenerateToken(jwtContent)
+}
@@ -0,0 +1,136 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockJWT "github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockSessionSvc "github.com/vukieuhaihoa/user-service/internal/app/service/session/mocks"
+)
+
+func TestService_IssueToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockJWTGen     func(t *testing.T) *mockJWT.JWTGenerator
+		setupMockSessionSvc func(ctx context.Context) *mockSessionSvc.Service
+
+		inputUser *model.User
+
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name: "Issue token successfully",
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					_, hasIat := claims["iat"].(int64)
+					_, hasExp := claims["exp"].(int64)
+					return claims["sub"] == "de305d54-75b4-431b-adb2-eb6b9e546099" && claims["sid"] == "session-001" &&
+						claims[RoleClaim] == model.RoleMember && hasIat && hasExp
+				})).Return("mocked_jwt_token", nil)
+				return jwtMock
+			},
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+			},
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Issue token carrying the role of an admin",
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					return claims["sub"] == "de305d54-75b4-431b-adb2-eb6b9e546099" && claims[RoleClaim] == model.RoleAdmin
+				})).Return("mocked_jwt_token", nil)
+				return jwtMock
+			},
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+				Role: model.RoleAdmin,
+			},
+
+			expectedOutput: "mocked_jwt_token",
+		},
+		{
+			name: "Fail to generate token",
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return("", ErrCannotGenerateToken)
+				return jwtMock
+			},
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+			},
+
+			expectedError: ErrCannotGenerateToken,
+		},
+		{
+			name: "Fail to record the session",
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(nil, assert.AnError)
+				return sessionMock
+			},
+
+			inputUser: &model.User{
+				Base: model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			userService := NewUserService(Deps{
+				JWTGenerator: tc.setupMockJWTGen(t),
+				SessionSvc:   tc.setupMockSessionSvc(ctx),
+			})
+
+			res, err := userService.IssueToken(ctx, tc.inputUser)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -2,47 +2,91 @@ package user
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 )
 
 // Login authenticates a user with the provided username and password.
-// If authentication is successful, it generates and returns a JWT token.
+// If authentication is successful, it generates and returns a JWT token. When the password expired, the token
+// only allows changing the password and expires after PasswordChangeTokenExpiration.
+// Attempts on an existing user are recorded in the login history of the user. A password hash made with a legacy
+// algorithm or weaker parameters is replaced by a hash of the preferred algorithm once the password is verified.
+// A login selecting an organization issues a token carrying the ID of the organization and the roles of the user in
+// it, so that other services can scope their data to the organization. The restricted token of an expired password
+// carries neither.
 //
 // Parameters:
 //   - ctx: The context for managing request-scoped values and cancellation.
 //   - username: The username of the user attempting to log in.
 //   - password: The password of the user attempting to log in.
+//   - organizationID: The ID of the organization the token is scoped to, empty for none.
 //
 // Returns:
-//   - string: The JWT token if authentication is successful.
-//   - error: An error if authentication fails, otherwise nil.
-func (u *userService) Login(ctx context.Context, username, password string) (string, error) {
+//   - *LoginResult: The JWT token and whether the password must be changed.
+//   - error: ErrNotOrganizationMember if the user does not belong to the selected organization, otherwise an error
+//     if authentication fails.
+func (u *userService) Login(ctx context.Context, username, password, organizationID string) (*LoginResult, error) {
 	s := newrelic.FromContext(ctx).StartSegment("Service_Login")
 	defer s.End()
 
-	user, err := u.userRepo.GetUserByUsername(ctx, username)
+	user, err := u.userRepo.GetUserCredentialsByUsername(ctx, username)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	ok := u.passwordHashing.CompareHashAndPassword(user.Password, password)
 	if !ok {
-		return "", ErrInvalidCredentials
+		err = u.loginHistorySvc.RecordLogin(ctx, user, false, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if u.passwordHashing.NeedsRehash(user.Password) {
+		u.upgradePasswordHash(ctx, user, password)
+	}
+
+	var organizationClaims jwt.MapClaims
+	if organizationID != "" {
+		membership, err := u.organizationRepo.GetMembership(ctx, organizationID, user.ID)
+		if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+			return nil, ErrNotOrganizationMember
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		organizationClaims = jwt.MapClaims{
+			OrganizationIDClaim: membership.OrganizationID,
+			RolesClaim:          membership.Roles(),
+		}
 	}
 
-	jwtContent := jwt.MapClaims{
-		"sub": user.ID,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(TokenExpirationDuration).Unix(),
+	result := &LoginResult{}
+	switch {
+	case PasswordExpired(user, u.passwordPolicy.MaxAge, time.Now()):
+		result.PasswordChangeRequired = true
+		result.Token, err = u.issueToken(ctx, user, PasswordChangeTokenExpiration, jwt.MapClaims{PasswordChangeClaim: true})
+	case organizationClaims != nil:
+		result.Token, err = u.issueToken(ctx, user, TokenExpirationDuration, organizationClaims)
+	default:
+		result.Token, err = u.IssueToken(ctx, user)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	token, err := u.jwtGenerator.GenerateToken(jwtContent)
+	// Password logins have no second factor
+	err = u.loginHistorySvc.RecordLogin(ctx, user, true, false)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	return result, nil
 }
@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	mockJWT "github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
-	mockPasswordHashing "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockOrganizationRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/organization/mocks"
 	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockLoginHistorySvc "github.com/vukieuhaihoa/user-service/internal/app/service/loginhistory/mocks"
+	mockSessionSvc "github.com/vukieuhaihoa/user-service/internal/app/service/session/mocks"
+	mockPasswordHashing "github.com/vukieuhaihoa/user-service/internal/passwordhash/mocks"
 )
 
 var ErrCannotGenerateToken = errors.New("cannot generate token")
@@ -25,20 +30,26 @@ func TestService_Login(t *testing.T) {
 		setupMockUserRepo     func(ctx context.Context) *mockUserRepo.Repository
 		setupMockPasswordHash func(t *testing.T) *mockPasswordHashing.PasswordHashing
 		setupMockJWTGen       func(t *testing.T) *mockJWT.JWTGenerator
+		setupMockSessionSvc   func(ctx context.Context) *mockSessionSvc.Service
+		setupMockLoginHistory func(ctx context.Context) *mockLoginHistorySvc.Service
+		setupMockOrgRepo      func(ctx context.Context) *mockOrganizationRepo.Repository
 
-		inputUsername string
-		inputPassword string
+		passwordPolicy PasswordPolicy
+
+		inputUsername       string
+		inputPassword       string
+		inputOrganizationID string
 
 		expectedError error
 
-		expectedOutput string
+		expectedOutput *LoginResult
 	}{
 		{
 			name: "Login successfully",
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("GetUserByUsername", ctx, "testuser").Return(&model.User{
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
 					Base: model.Base{
 						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
 					},
@@ -51,13 +62,14 @@ func TestService_Login(t *testing.T) {
 			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
 				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
 				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
 				return hashingMock
 			},
 
 			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
 				jwtMock := mockJWT.NewJWTGenerator(t)
 				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
-					if claims["sub"] != "de305d54-75b4-431b-adb2-eb6b9e546099" {
+					if claims["sub"] != "de305d54-75b4-431b-adb2-eb6b9e546099" || claims["sid"] != "session-001" {
 						return false
 					}
 
@@ -74,17 +86,395 @@ func TestService_Login(t *testing.T) {
 				return jwtMock
 			},
 
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.MatchedBy(func(user *model.User) bool {
+					return user.ID == "de305d54-75b4-431b-adb2-eb6b9e546099"
+				}), true, false).Return(nil)
+				return loginHistoryMock
+			},
+
 			inputUsername: "testuser",
 			inputPassword: "password123",
 
-			expectedOutput: "mocked_jwt_token",
+			expectedOutput: &LoginResult{Token: "mocked_jwt_token"},
+		},
+		{
+			name: "Login with a legacy hash upgrades it",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username: "testuser",
+					Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+				}, nil)
+				repoMock.On("UpgradePasswordHashByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099",
+					"$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5").Return(nil).Once()
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(true)
+				hashingMock.On("Hash", "password123").Return("$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5", nil)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+				return jwtMock
+			},
+
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, true, false).Return(nil)
+				return loginHistoryMock
+			},
+
+			inputUsername: "testuser",
+			inputPassword: "password123",
+
+			expectedOutput: &LoginResult{Token: "mocked_jwt_token"},
+		},
+		{
+			name: "Login succeeds when the hash upgrade fails",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username: "testuser",
+					Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+				}, nil)
+				repoMock.On("UpgradePasswordHashByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099",
+					"$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5").Return(assert.AnError).Once()
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(true)
+				hashingMock.On("Hash", "password123").Return("$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$a2V5", nil)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+				return jwtMock
+			},
+
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, true, false).Return(nil)
+				return loginHistoryMock
+			},
+
+			inputUsername: "testuser",
+			inputPassword: "password123",
+
+			expectedOutput: &LoginResult{Token: "mocked_jwt_token"},
+		},
+		{
+			name: "Login with an expired password issues a password change token",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				passwordChangedAt := time.Now().Add(-48 * time.Hour)
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username:          "testuser",
+					Password:          "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+					PasswordChangedAt: &passwordChangedAt,
+				}, nil)
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					if claims["sub"] != "de305d54-75b4-431b-adb2-eb6b9e546099" || claims["sid"] != "session-001" {
+						return false
+					}
+
+					return IsPasswordChangeToken(claims)
+				})).Return("restricted_jwt_token", nil)
+				return jwtMock
+			},
+
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.MatchedBy(func(expiresAt time.Time) bool {
+					return time.Until(expiresAt) <= PasswordChangeTokenExpiration
+				})).Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, true, false).Return(nil)
+				return loginHistoryMock
+			},
+
+			passwordPolicy: PasswordPolicy{MaxAge: 24 * time.Hour},
+
+			inputUsername: "testuser",
+			inputPassword: "password123",
+
+			expectedOutput: &LoginResult{Token: "restricted_jwt_token", PasswordChangeRequired: true},
+		},
+		{
+			name: "Login with an organization issues a token scoped to it",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username: "testuser",
+					Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+				}, nil)
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					return claims["sub"] == "de305d54-75b4-431b-adb2-eb6b9e546099" &&
+						claims[OrganizationIDClaim] == "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01" &&
+						assert.ObjectsAreEqual([]string{model.OrganizationAdmin, model.OrganizationMember}, claims[RolesClaim])
+				})).Return("org_jwt_token", nil)
+				return jwtMock
+			},
+
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, true, false).Return(nil)
+				return loginHistoryMock
+			},
+
+			setupMockOrgRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01", "de305d54-75b4-431b-adb2-eb6b9e546099").
+					Return(&model.Membership{
+						OrganizationID: "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01",
+						UserID:         "de305d54-75b4-431b-adb2-eb6b9e546099",
+						Role:           model.OrganizationAdmin,
+					}, nil)
+				return repoMock
+			},
+
+			inputUsername:       "testuser",
+			inputPassword:       "password123",
+			inputOrganizationID: "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01",
+
+			expectedOutput: &LoginResult{Token: "org_jwt_token"},
+		},
+		{
+			name: "Login with an organization and an expired password issues an unscoped password change token",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				passwordChangedAt := time.Now().Add(-48 * time.Hour)
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username:          "testuser",
+					Password:          "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+					PasswordChangedAt: &passwordChangedAt,
+				}, nil)
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					_, scoped := claims[OrganizationIDClaim]
+					return IsPasswordChangeToken(claims) && !scoped
+				})).Return("restricted_jwt_token", nil)
+				return jwtMock
+			},
+
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, true, false).Return(nil)
+				return loginHistoryMock
+			},
+
+			setupMockOrgRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01", "de305d54-75b4-431b-adb2-eb6b9e546099").
+					Return(&model.Membership{OrganizationID: "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01", Role: model.OrganizationOwner}, nil)
+				return repoMock
+			},
+
+			passwordPolicy: PasswordPolicy{MaxAge: 24 * time.Hour},
+
+			inputUsername:       "testuser",
+			inputPassword:       "password123",
+			inputOrganizationID: "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01",
+
+			expectedOutput: &LoginResult{Token: "restricted_jwt_token", PasswordChangeRequired: true},
+		},
+		{
+			name: "Login with an organization the user does not belong to",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username: "testuser",
+					Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+				}, nil)
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+
+			setupMockOrgRepo: func(ctx context.Context) *mockOrganizationRepo.Repository {
+				repoMock := mockOrganizationRepo.NewRepository(t)
+				repoMock.On("GetMembership", ctx, "0a9e1f00-0002-4c5d-9e8f-1a2b3c4d5e02", "de305d54-75b4-431b-adb2-eb6b9e546099").
+					Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			inputUsername:       "testuser",
+			inputPassword:       "password123",
+			inputOrganizationID: "0a9e1f00-0002-4c5d-9e8f-1a2b3c4d5e02",
+
+			expectedError: ErrNotOrganizationMember,
+		},
+		{
+			name: "Fail to record the successful login",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username: "testuser",
+					Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+				}, nil)
+				return repoMock
+			},
+
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+				return jwtMock
+			},
+
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, true, false).Return(assert.AnError)
+				return loginHistoryMock
+			},
+
+			inputUsername: "testuser",
+			inputPassword: "password123",
+
+			expectedError: assert.AnError,
 		},
 		{
 			name: "Fail to get user by username",
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("GetUserByUsername", ctx, "nonexistentuser").Return(nil, ErrInvalidCredentials)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "nonexistentuser").Return(nil, ErrInvalidCredentials)
 				return repoMock
 			},
 
@@ -106,7 +496,7 @@ func TestService_Login(t *testing.T) {
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("GetUserByUsername", ctx, "testuser").Return(&model.User{
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
 					Base: model.Base{
 						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
 					},
@@ -125,17 +515,60 @@ func TestService_Login(t *testing.T) {
 				return mockJWT.NewJWTGenerator(t)
 			},
 
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.MatchedBy(func(user *model.User) bool {
+					return user.ID == "de305d54-75b4-431b-adb2-eb6b9e546099"
+				}), false, false).Return(nil)
+				return loginHistoryMock
+			},
+
 			inputUsername: "testuser",
 			inputPassword: "wrongpassword",
 
 			expectedError: ErrInvalidCredentials,
 		},
+		{
+			name: "Fail to record the failed attempt",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
+					Base: model.Base{
+						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
+					},
+					Username: "testuser",
+					Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", // hash for "password123"
+				}, nil)
+				return repoMock
+			},
+			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
+				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "wrongpassword").Return(false)
+				return hashingMock
+			},
+
+			setupMockJWTGen: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+
+			setupMockLoginHistory: func(ctx context.Context) *mockLoginHistorySvc.Service {
+				loginHistoryMock := mockLoginHistorySvc.NewService(t)
+				loginHistoryMock.On("RecordLogin", ctx, mock.Anything, false, false).Return(assert.AnError)
+				return loginHistoryMock
+			},
+
+			inputUsername: "testuser",
+			inputPassword: "wrongpassword",
+
+			expectedError: assert.AnError,
+		},
 		{
 			name: "Fail to generate JWT token",
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("GetUserByUsername", ctx, "testuser").Return(&model.User{
+				repoMock.On("GetUserCredentialsByUsername", ctx, "testuser").Return(&model.User{
 					Base: model.Base{
 						ID: "de305d54-75b4-431b-adb2-eb6b9e546099",
 					},
@@ -148,6 +581,7 @@ func TestService_Login(t *testing.T) {
 			setupMockPasswordHash: func(t *testing.T) *mockPasswordHashing.PasswordHashing {
 				hashingMock := mockPasswordHashing.NewPasswordHashing(t)
 				hashingMock.On("CompareHashAndPassword", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e", "password123").Return(true)
+				hashingMock.On("NeedsRehash", "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e").Return(false)
 				return hashingMock
 			},
 
@@ -157,6 +591,13 @@ func TestService_Login(t *testing.T) {
 				return jwtMock
 			},
 
+			setupMockSessionSvc: func(ctx context.Context) *mockSessionSvc.Service {
+				sessionMock := mockSessionSvc.NewService(t)
+				sessionMock.On("CreateSession", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", mock.AnythingOfType("time.Time")).
+					Return(&model.UserSession{Base: model.Base{ID: "session-001"}}, nil)
+				return sessionMock
+			},
+
 			inputUsername: "testuser",
 			inputPassword: "password123",
 
@@ -173,10 +614,31 @@ func TestService_Login(t *testing.T) {
 			userRepoMock := tc.setupMockUserRepo(ctx)
 			passwordHashingMock := tc.setupMockPasswordHash(t)
 			jwtGenMock := tc.setupMockJWTGen(t)
-
-			userService := NewUserService(userRepoMock, passwordHashingMock, jwtGenMock)
-
-			res, err := userService.Login(ctx, tc.inputUsername, tc.inputPassword)
+			sessionSvcMock := mockSessionSvc.NewService(t) // No expectations unless a token is issued
+			if tc.setupMockSessionSvc != nil {
+				sessionSvcMock = tc.setupMockSessionSvc(ctx)
+			}
+			loginHistoryMock := mockLoginHistorySvc.NewService(t) // No expectations unless the password is checked
+			if tc.setupMockLoginHistory != nil {
+				loginHistoryMock = tc.setupMockLoginHistory(ctx)
+			}
+
+			orgRepoMock := mockOrganizationRepo.NewRepository(t) // No expectations unless an organization is selected
+			if tc.setupMockOrgRepo != nil {
+				orgRepoMock = tc.setupMockOrgRepo(ctx)
+			}
+
+			userService := NewUserService(Deps{
+				UserRepo:         userRepoMock,
+				PasswordHashing:  passwordHashingMock,
+				JWTGenerator:     jwtGenMock,
+				SessionSvc:       sessionSvcMock,
+				LoginHistorySvc:  loginHistoryMock,
+				OrganizationRepo: orgRepoMock,
+				PasswordPolicy:   tc.passwordPolicy,
+			})
+
+			res, err := userService.Login(ctx, tc.inputUsername, tc.inputPassword, tc.inputOrganizationID)
 			assert.Equal(t, tc.expectedError, err)
 			assert.Equal(t, tc.expectedOutput, res)
 
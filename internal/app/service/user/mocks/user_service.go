@@ -7,6 +7,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/vukieuhaihoa/user-service/internal/app/model"
+	user "github.com/vukieuhaihoa/user-service/internal/app/service/user"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -14,9 +15,55 @@ type Service struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: ctx, username, password, displayName, email
-func (_m *Service) CreateUser(ctx context.Context, username string, password string, displayName string, email string) (*model.User, error) {
-	ret := _m.Called(ctx, username, password, displayName, email)
+// ChangePassword provides a mock function with given fields: ctx, id, currentPassword, newPassword
+func (_m *Service) ChangePassword(ctx context.Context, id string, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, id, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, id, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeUsername provides a mock function with given fields: ctx, id, version, username
+func (_m *Service) ChangeUsername(ctx context.Context, id string, version int, username string) (int, error) {
+	ret := _m.Called(ctx, id, version, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUsername")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) (int, error)); ok {
+		return rf(ctx, id, version, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) int); ok {
+		r0 = rf(ctx, id, version, username)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) error); ok {
+		r1 = rf(ctx, id, version, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: ctx, username, password, displayName, email, invitationToken
+func (_m *Service) CreateUser(ctx context.Context, username string, password string, displayName string, email string, invitationToken string) (*model.User, error) {
+	ret := _m.Called(ctx, username, password, displayName, email, invitationToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
@@ -24,19 +71,19 @@ func (_m *Service) CreateUser(ctx context.Context, username string, password str
 
 	var r0 *model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*model.User, error)); ok {
-		return rf(ctx, username, password, displayName, email)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (*model.User, error)); ok {
+		return rf(ctx, username, password, displayName, email, invitationToken)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *model.User); ok {
-		r0 = rf(ctx, username, password, displayName, email)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) *model.User); ok {
+		r0 = rf(ctx, username, password, displayName, email, invitationToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
-		r1 = rf(ctx, username, password, displayName, email)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, username, password, displayName, email, invitationToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,27 +121,57 @@ func (_m *Service) GetUserByID(ctx context.Context, id string) (*model.User, err
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, username, password
-func (_m *Service) Login(ctx context.Context, username string, password string) (string, error) {
-	ret := _m.Called(ctx, username, password)
+// IssueToken provides a mock function with given fields: ctx, _a1
+func (_m *Service) IssueToken(ctx context.Context, _a1 *model.User) (string, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for IssueToken")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) (string, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) string); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, username, password)
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, username, password, organizationID
+func (_m *Service) Login(ctx context.Context, username string, password string, organizationID string) (*user.LoginResult, error) {
+	ret := _m.Called(ctx, username, password, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *user.LoginResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*user.LoginResult, error)); ok {
+		return rf(ctx, username, password, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *user.LoginResult); ok {
+		r0 = rf(ctx, username, password, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.LoginResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, username, password, organizationID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,22 +179,94 @@ func (_m *Service) Login(ctx context.Context, username string, password string)
 	return r0, r1
 }
 
-// UpdateUserByID provides a mock function with given fields: ctx, id, displayName, email
-func (_m *Service) UpdateUserByID(ctx context.Context, id string, displayName string, email string) error {
-	ret := _m.Called(ctx, id, displayName, email)
+// PatchUserByID provides a mock function with given fields: ctx, id, version, patch
+func (_m *Service) PatchUserByID(ctx context.Context, id string, version int, patch *user.ProfilePatch) (*user.ProfileUpdate, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUserByID")
+	}
+
+	var r0 *user.ProfileUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *user.ProfilePatch) (*user.ProfileUpdate, error)); ok {
+		return rf(ctx, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *user.ProfilePatch) *user.ProfileUpdate); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.ProfileUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *user.ProfilePatch) error); ok {
+		r1 = rf(ctx, id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveUsername provides a mock function with given fields: ctx, username
+func (_m *Service) ResolveUsername(ctx context.Context, username string) (*model.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveUsername")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserByID provides a mock function with given fields: ctx, id, version, displayName, email
+func (_m *Service) UpdateUserByID(ctx context.Context, id string, version int, displayName string, email string) (*user.ProfileUpdate, error) {
+	ret := _m.Called(ctx, id, version, displayName, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUserByID")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, id, displayName, email)
+	var r0 *user.ProfileUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, string) (*user.ProfileUpdate, error)); ok {
+		return rf(ctx, id, version, displayName, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, string) *user.ProfileUpdate); ok {
+		r0 = rf(ctx, id, version, displayName, email)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.ProfileUpdate)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, string) error); ok {
+		r1 = rf(ctx, id, version, displayName, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
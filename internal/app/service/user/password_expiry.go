@@ -0,0 +1,38 @@
+package user
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// PasswordExpired reports whether the password of a user is older than maxAge.
+// Passwords never expire when maxAge is 0, and users without a password have nothing to expire.
+//
+// Parameters:
+//   - user: The user whose password is checked.
+//   - maxAge: How long a password is valid.
+//   - now: The current time.
+//
+// Returns:
+//   - bool: true if the password must be changed.
+func PasswordExpired(user *model.User, maxAge time.Duration, now time.Time) bool {
+	if maxAge <= 0 || user.PasswordChangedAt == nil {
+		return false
+	}
+
+	return now.After(user.PasswordChangedAt.Add(maxAge))
+}
+
+// IsPasswordChangeToken reports whether the claims belong to a token restricted to changing an expired password.
+//
+// Parameters:
+//   - claims: The claims set by the authentication middleware.
+//
+// Returns:
+//   - bool: true if the token can only change the password.
+func IsPasswordChangeToken(claims jwt.MapClaims) bool {
+	restricted, _ := claims[PasswordChangeClaim].(bool)
+	return restricted
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+func TestPasswordExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := now.Add(-30 * 24 * time.Hour)
+
+	testCases := []struct {
+		name string
+
+		user   *model.User
+		maxAge time.Duration
+
+		expectedOutput bool
+	}{
+		{
+			name:   "Password older than the max age",
+			user:   &model.User{Password: "hash", PasswordChangedAt: &changedAt},
+			maxAge: 7 * 24 * time.Hour,
+
+			expectedOutput: true,
+		},
+		{
+			name:   "Password within the max age",
+			user:   &model.User{Password: "hash", PasswordChangedAt: &changedAt},
+			maxAge: 90 * 24 * time.Hour,
+
+			expectedOutput: false,
+		},
+		{
+			name:   "Passwords never expire without a max age",
+			user:   &model.User{Password: "hash", PasswordChangedAt: &changedAt},
+			maxAge: 0,
+
+			expectedOutput: false,
+		},
+		{
+			name:   "User without a password",
+			user:   &model.User{},
+			maxAge: time.Hour,
+
+			expectedOutput: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedOutput, PasswordExpired(tc.user, tc.maxAge, now))
+		})
+	}
+}
+
+func TestIsPasswordChangeToken(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsPasswordChangeToken(jwt.MapClaims{"sub": "user", PasswordChangeClaim: true}))
+	assert.False(t, IsPasswordChangeToken(jwt.MapClaims{"sub": "user"}))
+}
@@ -0,0 +1,48 @@
+package user
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// PatchUserByID updates only the profile fields supplied in the patch, leaving the others unchanged.
+// A new email address only applies once confirmed, see updateProfile.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the user to be updated.
+//   - version: The version of the user the update is based on, or 0 to update whatever the current version is.
+//   - patch: The fields to update.
+//
+// Returns:
+//   - *ProfileUpdate: The new version of the user and whether an email change is pending.
+//   - error: ErrEmptyPatch if the patch supplies no field, ErrVersionConflict if the user changed since the
+//     given version, dbutils.ErrDuplicationType if the email belongs to another user, otherwise an error if the
+//     update fails.
+func (u *userService) PatchUserByID(ctx context.Context, id string, version int, patch *ProfilePatch) (*ProfileUpdate, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_PatchUserByID")
+	defer s.End()
+
+	updatedUser := &model.User{Version: version}
+	fields := []string{}
+	if patch.DisplayName != nil {
+		updatedUser.DisplayName = *patch.DisplayName
+		fields = append(fields, "display_name")
+	}
+
+	if len(fields) == 0 && patch.Email == nil {
+		return nil, ErrEmptyPatch
+	}
+
+	var update func() (int, error)
+	if len(fields) > 0 {
+		update = func() (int, error) {
+			err := u.userRepo.UpdateUserFieldsByID(ctx, id, updatedUser, fields)
+			return updatedUser.Version, err
+		}
+	}
+
+	return u.updateProfile(ctx, id, version, patch.Email, update)
+}
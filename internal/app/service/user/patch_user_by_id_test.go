@@ -0,0 +1,241 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockEmailChangeSvc "github.com/vukieuhaihoa/user-service/internal/app/service/emailchange/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	mockEmailPolicy "github.com/vukieuhaihoa/user-service/internal/emailpolicy/mocks"
+)
+
+func TestService_PatchUserByID(t *testing.T) {
+	t.Parallel()
+
+	displayName := "Patched User"
+	email := "patcheduser@example.com"
+	currentEmail := profileTestUser.Email
+
+	testCases := []struct {
+		name string
+
+		setupMockUserRepo       func(ctx context.Context) *mockUserRepo.Repository
+		setupMockEmailChangeSvc func(ctx context.Context) *mockEmailChangeSvc.Service
+		setupMockEmailPolicy    func(ctx context.Context) *mockEmailPolicy.Checker
+		inputVersion            int
+		inputPatch              *ProfilePatch
+
+		expectedError  error
+		expectedOutput *ProfileUpdate
+	}{
+		{
+			name: "Patch the display name only",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("UpdateUserFieldsByID", ctx, profileTestUser.ID, &model.User{
+					DisplayName: displayName,
+					Version:     2,
+				}, []string{"display_name"}).Run(func(args mock.Arguments) {
+					args.Get(2).(*model.User).Version = 3
+				}).Return(nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{DisplayName: &displayName},
+
+			expectedOutput: &ProfileUpdate{Version: 3},
+		},
+		{
+			name: "Patch every field",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, email).Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("UpdateUserFieldsByID", ctx, profileTestUser.ID, &model.User{
+					DisplayName: displayName,
+					Version:     2,
+				}, []string{"display_name"}).Run(func(args mock.Arguments) {
+					args.Get(2).(*model.User).Version = 3
+				}).Return(nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				svcMock := mockEmailChangeSvc.NewService(t)
+				svcMock.On("RequestChange", ctx, profileTestUser, email).Return(nil)
+				return svcMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, email).Return(nil).Once()
+				return policyMock
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{DisplayName: &displayName, Email: &email},
+
+			expectedOutput: &ProfileUpdate{Version: 3, EmailChangePending: true},
+		},
+		{
+			name: "Patch the email only",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, email).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				svcMock := mockEmailChangeSvc.NewService(t)
+				svcMock.On("RequestChange", ctx, profileTestUser, email).Return(nil)
+				return svcMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, email).Return(nil).Once()
+				return policyMock
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{Email: &email},
+
+			expectedOutput: &ProfileUpdate{Version: 2, EmailChangePending: true},
+		},
+		{
+			name: "Patch the email with the current one",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{Email: &currentEmail},
+
+			expectedOutput: &ProfileUpdate{Version: 2},
+		},
+		{
+			name: "Empty patch",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				return mockUserRepo.NewRepository(t)
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{},
+
+			expectedError: ErrEmptyPatch,
+		},
+		{
+			name: "Version conflict",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			inputVersion: 1,
+			inputPatch:   &ProfilePatch{Email: &email},
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Version conflict while writing",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("UpdateUserFieldsByID", ctx, profileTestUser.ID, &model.User{
+					DisplayName: displayName,
+					Version:     2,
+				}, []string{"display_name"}).Return(user.ErrVersionConflict)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{DisplayName: &displayName},
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Duplicate email",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, email).Return(&model.User{Email: email}, nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{Email: &email},
+
+			expectedError: dbutils.ErrDuplicationType,
+		},
+		{
+			name: "Email rejected by the policy",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, email).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, email).Return(emailpolicy.ErrDenied).Once()
+				return policyMock
+			},
+			inputVersion: 2,
+			inputPatch:   &ProfilePatch{Email: &email},
+
+			expectedError: emailpolicy.ErrDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			emailPolicyMock := mockEmailPolicy.NewChecker(t)
+			if tc.setupMockEmailPolicy != nil {
+				emailPolicyMock = tc.setupMockEmailPolicy(ctx)
+			}
+
+			userService := NewUserService(Deps{
+				UserRepo:       tc.setupMockUserRepo(ctx),
+				EmailChangeSvc: tc.setupMockEmailChangeSvc(ctx),
+				EmailPolicy:    emailPolicyMock,
+			})
+
+			res, err := userService.PatchUserByID(ctx, profileTestUser.ID, tc.inputVersion, tc.inputPatch)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/passwordhash"
+)
+
+// LegacyPasswordHashesMetric is the name of the metric counting the users still on a legacy password hash.
+const LegacyPasswordHashesMetric = "Custom/Users/LegacyPasswordHashes"
+
+// ReportLegacyPasswordHashes counts the users whose password hash was not made with the preferred algorithm, then
+// counts them again every interval until the context is cancelled. Every count is handed to record.
+// Hashes of the preferred algorithm with outdated parameters are not told apart and are not counted.
+//
+// Parameters:
+//   - ctx: The context stopping the reports when cancelled.
+//   - userRepo: The user repository counting the hashes.
+//   - passwordHashing: The password hashing registry, giving the prefix of the preferred hashes.
+//   - record: The function recording a count, e.g. as a New Relic metric.
+//   - interval: How long to wait between two counts.
+func ReportLegacyPasswordHashes(ctx context.Context, userRepo user.Repository, passwordHashing passwordhash.PasswordHashing, record func(count int64), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := userRepo.CountLegacyPasswordHashes(ctx, passwordHashing.PreferredPrefix())
+		switch {
+		case err == nil:
+			record(count)
+		case ctx.Err() == nil:
+			log.Error().
+				Str("operation", "ReportLegacyPasswordHashes").
+				Err(err).
+				Msg("failed to count the legacy password hashes")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockPasswordHashing "github.com/vukieuhaihoa/user-service/internal/passwordhash/mocks"
+)
+
+func TestReportLegacyPasswordHashes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockUserRepo func(cancel context.CancelFunc) *mockUserRepo.Repository
+
+		expectedCounts []int64
+	}{
+		{
+			name: "Report on start and on every tick",
+
+			setupMockUserRepo: func(cancel context.CancelFunc) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("CountLegacyPasswordHashes", mock.Anything, "$argon2id$").Return(int64(12), nil).Once()
+				repoMock.On("CountLegacyPasswordHashes", mock.Anything, "$argon2id$").Return(int64(11), nil).Once().Run(func(mock.Arguments) { cancel() })
+				return repoMock
+			},
+
+			expectedCounts: []int64{12, 11},
+		},
+		{
+			name: "Keep reporting after an error",
+
+			setupMockUserRepo: func(cancel context.CancelFunc) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("CountLegacyPasswordHashes", mock.Anything, "$argon2id$").Return(int64(0), errors.New("database error")).Once()
+				repoMock.On("CountLegacyPasswordHashes", mock.Anything, "$argon2id$").Return(int64(3), nil).Once().Run(func(mock.Arguments) { cancel() })
+				return repoMock
+			},
+
+			expectedCounts: []int64{3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			hashingMock := mockPasswordHashing.NewPasswordHashing(t)
+			hashingMock.On("PreferredPrefix").Return("$argon2id$")
+
+			counts := []int64{}
+			done := make(chan struct{})
+			go func() {
+				ReportLegacyPasswordHashes(ctx, tc.setupMockUserRepo(cancel), hashingMock, func(count int64) {
+					counts = append(counts, count)
+				}, time.Millisecond)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				assert.Equal(t, tc.expectedCounts, counts)
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "reports did not stop after the context was cancelled")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ResolveUsername retrieves the user holding a username, falling back to the user that most recently changed away
+// from it. The current holder of a username always wins over its previous owners.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - username: The current or previous username to resolve.
+//
+// Returns:
+//   - *model.User: The resolved user.
+//   - error: dbutils.ErrRecordNotFoundType if no user ever had the username, otherwise any retrieval error.
+func (u *userService) ResolveUsername(ctx context.Context, username string) (*model.User, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ResolveUsername")
+	defer s.End()
+
+	user, err := u.userRepo.GetUserByUsername(ctx, username)
+	if !errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return user, err
+	}
+
+	return u.userRepo.GetUserByPreviousUsername(ctx, username)
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+)
+
+func TestService_ResolveUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockUserRepo func(ctx context.Context) *mockUserRepo.Repository
+		inputUsername     string
+
+		expectedError  error
+		expectedOutput *model.User
+	}{
+		{
+			name: "Resolve a current username",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByUsername", ctx, "testuser").Return(profileTestUser, nil)
+				return repoMock
+			},
+			inputUsername: "testuser",
+
+			expectedOutput: profileTestUser,
+		},
+		{
+			name: "Resolve a previous username",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByUsername", ctx, "oldname").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("GetUserByPreviousUsername", ctx, "oldname").Return(profileTestUser, nil)
+				return repoMock
+			},
+			inputUsername: "oldname",
+
+			expectedOutput: profileTestUser,
+		},
+		{
+			name: "Fail to resolve username - never used",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByUsername", ctx, "nobody").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("GetUserByPreviousUsername", ctx, "nobody").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			inputUsername: "nobody",
+
+			expectedError: dbutils.ErrRecordNotFoundType,
+		},
+		{
+			name: "Fail to resolve username - database error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByUsername", ctx, "testuser").Return(nil, assert.AnError)
+				return repoMock
+			},
+			inputUsername: "testuser",
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			userRepoMock := tc.setupMockUserRepo(ctx)
+
+			userService := NewUserService(Deps{
+				UserRepo: userRepoMock,
+			})
+
+			res, err := userService.ResolveUsername(ctx, tc.inputUsername)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -9,22 +9,108 @@ import (
 	"time"
 
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils"
-	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/organization"
 	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/emailchange"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/invitation"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/loginhistory"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/session"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/passwordhash"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
 )
 
 const TokenExpirationDuration = 24 * time.Hour
 
+const (
+	// UsernameChangeCooldown is the time a user must wait between two username changes.
+	UsernameChangeCooldown = 30 * 24 * time.Hour
+
+	// UsernameHoldPeriod is how long an old username stays held for the user after a change.
+	UsernameHoldPeriod = 90 * 24 * time.Hour
+)
+
+const (
+	// PasswordChangeClaim marks the restricted tokens issued for an expired password, which can only change it.
+	PasswordChangeClaim = "pwd_change"
+
+	// RoleClaim carries the role of the user in the tokens issued on login, for the services trusting them to
+	// authorize the admins.
+	RoleClaim = "role"
+
+	// PasswordChangeTokenExpiration is how long a restricted token issued for an expired password is valid.
+	PasswordChangeTokenExpiration = 15 * time.Minute
+)
+
+const (
+	// OrganizationIDClaim carries the organization a token was issued for, when the login selected one.
+	OrganizationIDClaim = "org_id"
+
+	// RolesClaim carries the roles of the user in the organization of OrganizationIDClaim, implied roles included.
+	RolesClaim = "roles"
+)
+
 var (
 	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrVersionConflict    = errors.New("the user was modified since it was read")
+	ErrEmptyPatch         = errors.New("no field to update")
+	ErrUsernameUnchanged  = errors.New("the new username is the current one")
+	ErrUsernameCooldown   = errors.New("the username was changed too recently")
+	ErrPasswordReused     = errors.New("the password was used recently")
+
+	ErrNotOrganizationMember = errors.New("you are not a member of this organization")
 )
 
+// PasswordPolicy holds the rules applying to the passwords of users.
+//
+// Fields:
+//   - HistorySize: The number of most recent passwords, the current one included, a new password must differ from;
+//     0 disables the check.
+//   - MaxAge: How long a password is valid before it must be changed; 0 never expires passwords.
+type PasswordPolicy struct {
+	HistorySize int
+	MaxAge      time.Duration
+}
+
+// LoginResult describes the outcome of a successful password login.
+//
+// Fields:
+//   - Token: The JWT access token.
+//   - PasswordChangeRequired: Whether the password expired; the token is then restricted to changing the password
+//     and valid for PasswordChangeTokenExpiration.
+type LoginResult struct {
+	Token                  string
+	PasswordChangeRequired bool
+}
+
+// ProfilePatch holds the profile fields supplied in a partial update.
+// Nil fields are left unchanged.
+type ProfilePatch struct {
+	DisplayName *string
+	Email       *string
+}
+
+// ProfileUpdate describes the outcome of a profile update.
+//
+// Fields:
+//   - Version: The version of the user after the update.
+//   - EmailChangePending: Whether a new email address was requested; it only applies once confirmed from the
+//     link sent to it.
+type ProfileUpdate struct {
+	Version            int
+	EmailChangePending bool
+}
+
 // Service represents the interface for user service operations.
 //
 //go:generate mockery --name=Service --filename=user_service.go --output=./mocks
 type Service interface {
 	// CreateUser creates a new user with the provided information.
+	// The registration is checked against the registration policy, the email address against the email policy and
+	// the password is screened against breached passwords first. An invitation token is consumed along with the
+	// creation of the user, who gets the role of the invitation.
 	// Returns the created user or an error if the operation fails.
 	// Parameters:
 	//   - ctx: The context for managing request-scoped values and cancellation.
@@ -32,23 +118,58 @@ type Service interface {
 	//   - password: The password of the new user.
 	//   - displayName: The display name of the new user.
 	//   - email: The email address of the new user.
+	//   - invitationToken: The token of the invitation of the user, or empty to register without invitation.
 	//
 	// Returns:
 	//   - *model.User: The created user model.
-	//   - error: An error if the creation fails, otherwise nil.
-	CreateUser(ctx context.Context, username, password, displayName, email string) (*model.User, error)
+	//   - error: an error of the registration policy if the registration is refused, an error of the email policy if
+	//     the email is rejected, breach.ErrBreached if the password was breached and breached passwords are rejected,
+	//     invitation.ErrInvalidInvitation or invitation.ErrInvitationEmailMismatch if the invitation cannot be used,
+	//     otherwise an error if the creation fails.
+	CreateUser(ctx context.Context, username, password, displayName, email, invitationToken string) (*model.User, error)
 
 	// Login authenticates a user with the provided username and password.
 	// Returns a JWT token if authentication is successful, or an error if it fails.
+	// When the password expired, the token is restricted to changing the password.
+	// Attempts on an existing user are recorded in the login history of the user.
+	// When an organization is selected, the token carries its ID and the roles of the user in it.
 	// Parameters:
 	//   - ctx: The context for managing request-scoped values and cancellation.
 	//   - username: The username of the user attempting to log in.
 	//   - password: The password of the user attempting to log in.
+	//   - organizationID: The ID of the organization the token is scoped to, empty for none.
 	//
 	// Returns:
-	//   - string: The JWT token if authentication is successful.
-	//   - error: An error if authentication fails, otherwise nil.
-	Login(ctx context.Context, username, password string) (string, error)
+	//   - *LoginResult: The JWT token and whether the password must be changed.
+	//   - error: ErrNotOrganizationMember if the user does not belong to the selected organization, otherwise an
+	//     error if authentication fails.
+	Login(ctx context.Context, username, password, organizationID string) (*LoginResult, error)
+
+	// ChangePassword replaces the password of a user after checking the current one.
+	// The new password is screened against breached passwords and must differ from the most recent ones kept in the
+	// password history.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user whose password changes.
+	//   - currentPassword: The current password of the user.
+	//   - newPassword: The new password.
+	//
+	// Returns:
+	//   - error: ErrInvalidCredentials if the current password is wrong or the user has none, breach.ErrBreached if
+	//     the new password was breached and breached passwords are rejected, ErrPasswordReused if it is one of the
+	//     recent passwords, otherwise an error if the change fails.
+	ChangePassword(ctx context.Context, id, currentPassword, newPassword string) error
+
+	// IssueToken generates the JWT access token for an already authenticated user.
+	// It is shared by every login method so that all issued tokens carry the same claims.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The authenticated user the token is issued for.
+	//
+	// Returns:
+	//   - string: The signed JWT token.
+	//   - error: An error if token generation fails, otherwise nil.
+	IssueToken(ctx context.Context, user *model.User) (string, error)
 
 	// GetUserByID retrieves a user by their ID.
 	// Returns the user or an error if the operation fails.
@@ -62,37 +183,132 @@ type Service interface {
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
 
 	// UpdateUserByID updates a user's display name and email by their ID.
-	// Returns an error if the operation fails.
+	// The update only applies if the user is still at the given version, so concurrent edits do not overwrite each other.
+	// A new email address is not applied right away: a change confirmed from the new address is requested instead.
+	// Returns the outcome of the update or an error if the operation fails.
 	// Parameters:
 	//   - ctx: The context for managing request-scoped values and cancellation.
 	//   - id: The ID of the user to be updated.
+	//   - version: The version of the user the update is based on, or 0 to update whatever the current version is.
 	//   - displayName: The new display name for the user.
 	//   - email: The new email address for the user.
 	//
 	// Returns:
-	//   - error: An error if the update fails, otherwise nil.
-	UpdateUserByID(ctx context.Context, id, displayName, email string) error
+	//   - *ProfileUpdate: The new version of the user and whether an email change is pending.
+	//   - error: ErrVersionConflict if the user changed since the given version, dbutils.ErrDuplicationType if the
+	//     email belongs to another user, an error of the email policy if the email is rejected, otherwise an error
+	//     if the update fails.
+	UpdateUserByID(ctx context.Context, id string, version int, displayName, email string) (*ProfileUpdate, error)
+
+	// PatchUserByID updates only the profile fields supplied in the patch.
+	// The version check and the email change are the same as for UpdateUserByID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user to be updated.
+	//   - version: The version of the user the update is based on, or 0 to update whatever the current version is.
+	//   - patch: The fields to update.
+	//
+	// Returns:
+	//   - *ProfileUpdate: The new version of the user and whether an email change is pending.
+	//   - error: ErrEmptyPatch if the patch supplies no field, ErrVersionConflict if the user changed since the
+	//     given version, dbutils.ErrDuplicationType if the email belongs to another user, an error of the email
+	//     policy if the email is rejected, otherwise an error if the update fails.
+	PatchUserByID(ctx context.Context, id string, version int, patch *ProfilePatch) (*ProfileUpdate, error)
+
+	// ChangeUsername changes the username of a user and records the change in its username history.
+	// Changes are limited to one per UsernameChangeCooldown, and the old username stays held for the user for
+	// UsernameHoldPeriod. The version check is the same as for UpdateUserByID.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the user whose username changes.
+	//   - version: The version of the user the change is based on, or 0 to change whatever the current version is.
+	//   - username: The new username.
+	//
+	// Returns:
+	//   - int: The version of the user after the change.
+	//   - error: ErrUsernameUnchanged if the username is the current one, ErrUsernameCooldown if the last change is
+	//     too recent, ErrVersionConflict if the user changed since the given version, dbutils.ErrDuplicationType if
+	//     the username is taken or held for another user, otherwise an error if the change fails.
+	ChangeUsername(ctx context.Context, id string, version int, username string) (int, error)
+
+	// ResolveUsername retrieves the user currently holding a username or, failing that, the user that most recently
+	// changed away from it, so that links to an old username can be redirected.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - username: The current or previous username to resolve.
+	//
+	// Returns:
+	//   - *model.User: The resolved user; its username differs from the given one when it was resolved from the history.
+	//   - error: dbutils.ErrRecordNotFoundType if no user ever had the username, otherwise any retrieval error.
+	ResolveUsername(ctx context.Context, username string) (*model.User, error)
 }
 
 type userService struct {
-	userRepo        user.Repository
-	passwordHashing utils.PasswordHashing
-	jwtGenerator    jwtutils.JWTGenerator
+	userRepo         user.Repository
+	passwordHashing  passwordhash.PasswordHashing
+	jwtGenerator     jwtutils.JWTGenerator
+	sessionSvc       session.Service
+	loginHistorySvc  loginhistory.Service
+	emailChangeSvc   emailchange.Service
+	breachChecker    breach.Checker
+	emailPolicy      emailpolicy.Checker
+	invitationSvc    invitation.Service
+	organizationRepo organization.Repository
+	registration     registration.Policy
+	passwordPolicy   PasswordPolicy
+}
+
+// Deps holds the dependencies of the user service.
+// Dependencies a caller does not use may be left nil.
+//
+// Fields:
+//   - UserRepo: The user repository used for database operations.
+//   - PasswordHashing: The password hashing registry, hashing with the preferred algorithm and verifying any registered one.
+//   - JWTGenerator: The JWT generator for creating authentication tokens.
+//   - SessionSvc: The session service recording the device of every issued token.
+//   - LoginHistorySvc: The login history service recording password login attempts.
+//   - EmailChangeSvc: The email change service confirming new email addresses.
+//   - BreachChecker: The checker screening new passwords against breached passwords.
+//   - EmailPolicy: The checker of new email addresses, at registration and on an email change.
+//   - InvitationSvc: The invitation service consuming the invitations users register with.
+//   - OrganizationRepo: The organization repository resolving the roles carried by tokens scoped to an organization.
+//   - RegistrationPolicy: The rules deciding who may register.
+//   - PasswordPolicy: The reuse and expiry rules of passwords.
+type Deps struct {
+	UserRepo           user.Repository
+	PasswordHashing    passwordhash.PasswordHashing
+	JWTGenerator       jwtutils.JWTGenerator
+	SessionSvc         session.Service
+	LoginHistorySvc    loginhistory.Service
+	EmailChangeSvc     emailchange.Service
+	BreachChecker      breach.Checker
+	EmailPolicy        emailpolicy.Checker
+	InvitationSvc      invitation.Service
+	OrganizationRepo   organization.Repository
+	RegistrationPolicy registration.Policy
+	PasswordPolicy     PasswordPolicy
 }
 
 // NewUserService creates a new instance of the  user service.
 //
 // Parameters:
-//   - userRepo: The user repository used for database operations.
-//   - passwordHashing: The password hashing utility for securing passwords.
-//   - jwtGenerator: The JWT generator for creating authentication tokens.
+//   - deps: The dependencies of the service.
 //
 // Returns:
 //   - Service: A new user service instance.
-func NewUserService(userRepo user.Repository, passwordHashing utils.PasswordHashing, jwtGenerator jwtutils.JWTGenerator) Service {
+func NewUserService(deps Deps) Service {
 	return &userService{
-		userRepo:        userRepo,
-		passwordHashing: passwordHashing,
-		jwtGenerator:    jwtGenerator,
+		userRepo:         deps.UserRepo,
+		passwordHashing:  deps.PasswordHashing,
+		jwtGenerator:     deps.JWTGenerator,
+		sessionSvc:       deps.SessionSvc,
+		loginHistorySvc:  deps.LoginHistorySvc,
+		emailChangeSvc:   deps.EmailChangeSvc,
+		breachChecker:    deps.BreachChecker,
+		emailPolicy:      deps.EmailPolicy,
+		invitationSvc:    deps.InvitationSvc,
+		organizationRepo: deps.OrganizationRepo,
+		registration:     deps.RegistrationPolicy,
+		passwordPolicy:   deps.PasswordPolicy,
 	}
 }
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+)
+
+// updateProfile applies a profile update, holding back a change of the email address until the new address is
+// confirmed: the email change service records it and sends the links instead.
+// The version, the availability of the new address and the email policy are checked before anything is written.
+// update writes the other fields at the given version and returns the new one; it is nil when there are none.
+func (u *userService) updateProfile(ctx context.Context, id string, version int, email *string, update func() (int, error)) (*ProfileUpdate, error) {
+	current, err := u.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 && current.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	emailChanged := email != nil && *email != current.Email
+	if emailChanged {
+		_, err := u.userRepo.GetUserByEmail(ctx, *email)
+		if err == nil {
+			return nil, dbutils.ErrDuplicationType
+		}
+		if !errors.Is(err, dbutils.ErrRecordNotFoundType) {
+			return nil, err
+		}
+
+		if err := u.emailPolicy.Check(ctx, *email); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ProfileUpdate{Version: current.Version}
+	if update != nil {
+		result.Version, err = update()
+		if errors.Is(err, user.ErrVersionConflict) {
+			return nil, ErrVersionConflict
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if emailChanged {
+		if err := u.emailChangeSvc.RequestChange(ctx, current, *email); err != nil {
+			return nil, err
+		}
+		result.EmailChangePending = true
+	}
+
+	return result, nil
+}
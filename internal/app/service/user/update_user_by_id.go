@@ -8,24 +8,30 @@ import (
 )
 
 // UpdateUserByID updates a user's display name and email by their ID.
+// A new email address only applies once confirmed, see updateProfile.
 //
 // Parameters:
 //   - ctx: The context for managing request-scoped values and cancellation.
 //   - id: The ID of the user to be updated.
+//   - version: The version of the user the update is based on, or 0 to update whatever the current version is.
 //   - displayName: The new display name for the user.
 //   - email: The new email address for the user.
 //
 // Returns:
-//   - *model.User: The updated user model.
-//   - error: An error if the update fails, otherwise nil.
-func (u *userService) UpdateUserByID(ctx context.Context, id, displayName, email string) error {
+//   - *ProfileUpdate: The new version of the user and whether an email change is pending.
+//   - error: ErrVersionConflict if the user changed since the given version, dbutils.ErrDuplicationType if the
+//     email belongs to another user, otherwise an error if the update fails.
+func (u *userService) UpdateUserByID(ctx context.Context, id string, version int, displayName, email string) (*ProfileUpdate, error) {
 	s := newrelic.FromContext(ctx).StartSegment("Service_UpdateUserByID")
 	defer s.End()
 
-	updatedUser := &model.User{
-		DisplayName: displayName,
-		Email:       email,
-	}
+	return u.updateProfile(ctx, id, version, &email, func() (int, error) {
+		updatedUser := &model.User{
+			DisplayName: displayName,
+			Version:     version,
+		}
 
-	return u.userRepo.UpdateUserByID(ctx, id, updatedUser)
+		err := u.userRepo.UpdateUserByID(ctx, id, updatedUser)
+		return updatedUser.Version, err
+	})
 }
@@ -5,53 +5,155 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
 	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/app/repository/user"
 	mockUserRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/user/mocks"
+	mockEmailChangeSvc "github.com/vukieuhaihoa/user-service/internal/app/service/emailchange/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	mockEmailPolicy "github.com/vukieuhaihoa/user-service/internal/emailpolicy/mocks"
 )
 
+var profileTestUser = &model.User{
+	Base:        model.Base{ID: "de305d54-75b4-431b-adb2-eb6b9e546099"},
+	Username:    "testuser",
+	DisplayName: "Test User",
+	Email:       "testuser@example.com",
+	Version:     2,
+}
+
 func TestService_UpdateUserByID(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
 		name string
 
-		setupMockUserRepo func(ctx context.Context) *mockUserRepo.Repository
-		inputUserID       string
-		inputDisplayName  string
-		inputEmail        string
+		setupMockUserRepo       func(ctx context.Context) *mockUserRepo.Repository
+		setupMockEmailChangeSvc func(ctx context.Context) *mockEmailChangeSvc.Service
+		setupMockEmailPolicy    func(ctx context.Context) *mockEmailPolicy.Checker
+		inputUserID             string
+		inputVersion            int
+		inputDisplayName        string
+		inputEmail              string
 
-		expectedError error
+		expectedError  error
+		expectedOutput *ProfileUpdate
 	}{
 		{
 			name: "Update user by ID successfully",
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", &model.User{
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("UpdateUserByID", ctx, profileTestUser.ID, &model.User{
 					DisplayName: "Updated User",
-					Email:       "updateduser@example.com",
+					Version:     2,
+				}).Run(func(args mock.Arguments) {
+					args.Get(2).(*model.User).Version = 3
 				}).Return(nil)
 				return repoMock
 			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
 
-			inputUserID:      "de305d54-75b4-431b-adb2-eb6b9e546099",
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     2,
+			inputDisplayName: "Updated User",
+			inputEmail:       "testuser@example.com",
+
+			expectedOutput: &ProfileUpdate{Version: 3},
+		},
+		{
+			name: "Update user by ID with a new email requests a change",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, "updateduser@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("UpdateUserByID", ctx, profileTestUser.ID, &model.User{
+					DisplayName: "Updated User",
+					Version:     2,
+				}).Run(func(args mock.Arguments) {
+					args.Get(2).(*model.User).Version = 3
+				}).Return(nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				svcMock := mockEmailChangeSvc.NewService(t)
+				svcMock.On("RequestChange", ctx, profileTestUser, "updateduser@example.com").Return(nil)
+				return svcMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "updateduser@example.com").Return(nil).Once()
+				return policyMock
+			},
+
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     2,
 			inputDisplayName: "Updated User",
 			inputEmail:       "updateduser@example.com",
+
+			expectedOutput: &ProfileUpdate{Version: 3, EmailChangePending: true},
 		},
 		{
-			name: "Fail to update user by ID - user not found",
+			name: "Fail to update user by ID - version conflict",
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("UpdateUserByID", ctx, "nonexistentid", &model.User{
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     1,
+			inputDisplayName: "Updated User",
+			inputEmail:       "updateduser@example.com",
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Fail to update user by ID - version conflict while writing",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("UpdateUserByID", ctx, profileTestUser.ID, &model.User{
 					DisplayName: "Updated User",
-					Email:       "updateduser@example.com",
-				}).Return(dbutils.ErrRecordNotFoundType)
+					Version:     2,
+				}).Return(user.ErrVersionConflict)
 				return repoMock
 			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     2,
+			inputDisplayName: "Updated User",
+			inputEmail:       "testuser@example.com",
+
+			expectedError: ErrVersionConflict,
+		},
+		{
+			name: "Fail to update user by ID - user not found",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, "nonexistentid").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
 
 			inputUserID:      "nonexistentid",
+			inputVersion:     1,
 			inputDisplayName: "Updated User",
 			inputEmail:       "updateduser@example.com",
 
@@ -62,19 +164,77 @@ func TestService_UpdateUserByID(t *testing.T) {
 
 			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
 				repoMock := mockUserRepo.NewRepository(t)
-				repoMock.On("UpdateUserByID", ctx, "de305d54-75b4-431b-adb2-eb6b9e546099", &model.User{
-					DisplayName: "Updated User",
-					Email:       "duplicateemail@example.com",
-				}).Return(dbutils.ErrDuplicationType)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, "duplicateemail@example.com").Return(&model.User{Email: "duplicateemail@example.com"}, nil)
 				return repoMock
 			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
 
-			inputUserID:      "de305d54-75b4-431b-adb2-eb6b9e546099",
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     2,
 			inputDisplayName: "Updated User",
 			inputEmail:       "duplicateemail@example.com",
 
 			expectedError: dbutils.ErrDuplicationType,
 		},
+		{
+			name: "Fail to update user by ID - email change request error",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, "updateduser@example.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				repoMock.On("UpdateUserByID", ctx, profileTestUser.ID, &model.User{
+					DisplayName: "Updated User",
+					Version:     2,
+				}).Return(nil)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				svcMock := mockEmailChangeSvc.NewService(t)
+				svcMock.On("RequestChange", ctx, profileTestUser, "updateduser@example.com").Return(assert.AnError)
+				return svcMock
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "updateduser@example.com").Return(nil).Once()
+				return policyMock
+			},
+
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     2,
+			inputDisplayName: "Updated User",
+			inputEmail:       "updateduser@example.com",
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Fail to update user by ID - email rejected by the policy",
+
+			setupMockUserRepo: func(ctx context.Context) *mockUserRepo.Repository {
+				repoMock := mockUserRepo.NewRepository(t)
+				repoMock.On("GetUserByID", ctx, profileTestUser.ID).Return(profileTestUser, nil)
+				repoMock.On("GetUserByEmail", ctx, "updateduser@mailinator.com").Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+			setupMockEmailChangeSvc: func(ctx context.Context) *mockEmailChangeSvc.Service {
+				return mockEmailChangeSvc.NewService(t)
+			},
+			setupMockEmailPolicy: func(ctx context.Context) *mockEmailPolicy.Checker {
+				policyMock := mockEmailPolicy.NewChecker(t)
+				policyMock.On("Check", ctx, "updateduser@mailinator.com").Return(emailpolicy.ErrDisposable).Once()
+				return policyMock
+			},
+
+			inputUserID:      profileTestUser.ID,
+			inputVersion:     2,
+			inputDisplayName: "Updated User",
+			inputEmail:       "updateduser@mailinator.com",
+
+			expectedError: emailpolicy.ErrDisposable,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -83,11 +243,20 @@ func TestService_UpdateUserByID(t *testing.T) {
 
 			ctx := t.Context()
 			userRepoMock := tc.setupMockUserRepo(ctx)
+			emailPolicyMock := mockEmailPolicy.NewChecker(t)
+			if tc.setupMockEmailPolicy != nil {
+				emailPolicyMock = tc.setupMockEmailPolicy(ctx)
+			}
 
-			userService := NewUserService(userRepoMock, nil, nil)
+			userService := NewUserService(Deps{
+				UserRepo:       userRepoMock,
+				EmailChangeSvc: tc.setupMockEmailChangeSvc(ctx),
+				EmailPolicy:    emailPolicyMock,
+			})
 
-			err := userService.UpdateUserByID(ctx, tc.inputUserID, tc.inputDisplayName, tc.inputEmail)
+			res, err := userService.UpdateUserByID(ctx, tc.inputUserID, tc.inputVersion, tc.inputDisplayName, tc.inputEmail)
 			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
 		})
 	}
 }
@@ -0,0 +1,24 @@
+package user
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// upgradePasswordHash replaces the stored hash of a verified password by a hash of the preferred algorithm.
+// A failure is only logged: the login goes on and the hash is upgraded on a later login.
+func (u *userService) upgradePasswordHash(ctx context.Context, user *model.User, password string) {
+	hashedPassword, err := u.passwordHashing.Hash(password)
+	if err == nil {
+		err = u.userRepo.UpgradePasswordHashByID(ctx, user.ID, user.Password, hashedPassword)
+	}
+	if err != nil {
+		log.Warn().
+			Str("operation", "Service_UpgradePasswordHash").
+			Str("user_id", user.ID).
+			Err(err).
+			Msg("failed to upgrade the password hash")
+	}
+}
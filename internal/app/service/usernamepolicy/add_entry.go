@@ -0,0 +1,59 @@
+package usernamepolicy
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// AddEntry adds an entry to a list and applies it to the policy.
+// Reserved usernames and blocked words are stored in their canonical form, patterns as given.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - kind: model.UsernamePolicyReserved, model.UsernamePolicyBlockedWord or model.UsernamePolicyBlockedPattern.
+//   - value: The reserved username, the blocked word or the blocked regular expression.
+//
+// Returns:
+//   - *model.UsernamePolicyEntry: The stored entry.
+//   - error: ErrUnsupportedKind, ErrEmptyValue, ErrInvalidPattern or ErrEntryExists for invalid input, otherwise
+//     any storage error.
+func (svc *usernamePolicyService) AddEntry(ctx context.Context, kind, value string) (*model.UsernamePolicyEntry, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_AddUsernamePolicyEntry")
+	defer s.End()
+
+	switch kind {
+	case model.UsernamePolicyReserved, model.UsernamePolicyBlockedWord:
+		value = normalize.Username(value)
+	case model.UsernamePolicyBlockedPattern:
+		value = strings.TrimSpace(value)
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, ErrInvalidPattern
+		}
+	default:
+		return nil, ErrUnsupportedKind
+	}
+	if value == "" {
+		return nil, ErrEmptyValue
+	}
+
+	entry, err := svc.usernamePolicyRepo.CreateEntry(ctx, &model.UsernamePolicyEntry{Kind: kind, Value: value})
+	if errors.Is(err, dbutils.ErrDuplicationType) {
+		return nil, ErrEntryExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,136 @@
+package usernamepolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUsernamePolicyRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy/mocks"
+)
+
+func TestService_AddEntry(t *testing.T) {
+	t.Parallel()
+
+	storedEntry := &model.UsernamePolicyEntry{Base: model.Base{ID: "entry-001"}, Kind: model.UsernamePolicyReserved, Value: "acme"}
+
+	testCases := []struct {
+		name string
+
+		setupMockRepo func(ctx context.Context) *mockUsernamePolicyRepo.Repository
+		inputKind     string
+		inputValue    string
+
+		expectedError    error
+		expectedOutput   *model.UsernamePolicyEntry
+		expectedReserved bool
+	}{
+		{
+			name: "Add a reserved username in its canonical form",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("CreateEntry", ctx, &model.UsernamePolicyEntry{Kind: model.UsernamePolicyReserved, Value: "acme"}).Return(storedEntry, nil)
+				repoMock.On("ListEntries", ctx).Return([]*model.UsernamePolicyEntry{storedEntry}, nil)
+				return repoMock
+			},
+			inputKind:  model.UsernamePolicyReserved,
+			inputValue: " ACME ",
+
+			expectedOutput:   storedEntry,
+			expectedReserved: true,
+		},
+		{
+			name: "Add a blocked pattern",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				entry := &model.UsernamePolicyEntry{Kind: model.UsernamePolicyBlockedPattern, Value: "^Guest[0-9]+$"}
+				repoMock.On("CreateEntry", ctx, entry).Return(entry, nil)
+				repoMock.On("ListEntries", ctx).Return([]*model.UsernamePolicyEntry{entry}, nil)
+				return repoMock
+			},
+			inputKind:  model.UsernamePolicyBlockedPattern,
+			inputValue: "^Guest[0-9]+$",
+
+			expectedOutput: &model.UsernamePolicyEntry{Kind: model.UsernamePolicyBlockedPattern, Value: "^Guest[0-9]+$"},
+		},
+		{
+			name: "Fail to add - unsupported kind",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				return mockUsernamePolicyRepo.NewRepository(t)
+			},
+			inputKind:  "allowed",
+			inputValue: "acme",
+
+			expectedError: ErrUnsupportedKind,
+		},
+		{
+			name: "Fail to add - empty value",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				return mockUsernamePolicyRepo.NewRepository(t)
+			},
+			inputKind:  model.UsernamePolicyBlockedWord,
+			inputValue: "  ",
+
+			expectedError: ErrEmptyValue,
+		},
+		{
+			name: "Fail to add - invalid pattern",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				return mockUsernamePolicyRepo.NewRepository(t)
+			},
+			inputKind:  model.UsernamePolicyBlockedPattern,
+			inputValue: "(guest",
+
+			expectedError: ErrInvalidPattern,
+		},
+		{
+			name: "Fail to add - entry exists",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("CreateEntry", ctx, &model.UsernamePolicyEntry{Kind: model.UsernamePolicyReserved, Value: "acme"}).Return(nil, dbutils.ErrDuplicationType)
+				return repoMock
+			},
+			inputKind:  model.UsernamePolicyReserved,
+			inputValue: "acme",
+
+			expectedError: ErrEntryExists,
+		},
+		{
+			name: "Fail to add - refresh error",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("CreateEntry", ctx, &model.UsernamePolicyEntry{Kind: model.UsernamePolicyReserved, Value: "acme"}).Return(storedEntry, nil)
+				repoMock.On("ListEntries", ctx).Return(nil, assert.AnError)
+				return repoMock
+			},
+			inputKind:  model.UsernamePolicyReserved,
+			inputValue: "acme",
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			policy, err := NewPolicy(&Config{MinLength: 3, AllowedPattern: `^[a-z]+$`})
+			assert.NoError(t, err)
+			usernamePolicyService := NewUsernamePolicyService(tc.setupMockRepo(ctx), policy)
+
+			res, err := usernamePolicyService.AddEntry(ctx, tc.inputKind, tc.inputValue)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+			assert.Equal(t, tc.expectedReserved, policy.Check("acme") == ErrReserved)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package usernamepolicy
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// confusables maps the characters that look like a Latin letter, once case folded, to that letter.
+// Letters that look alike among themselves, such as "i", "l" and "1", map to the same one.
+var confusables = map[rune]rune{
+	// Digits and Latin letters
+	'0': 'o', '1': 'l', 'i': 'l', '|': 'l',
+	// Cyrillic
+	'а': 'a', 'е': 'e', 'ё': 'e', 'к': 'k', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'l',
+	'ї': 'l', 'ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'һ': 'h', 'ӏ': 'l',
+	// Greek
+	'α': 'a', 'ε': 'e', 'ι': 'l', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w',
+}
+
+// sequenceConfusables maps the letter sequences that look like a single letter to it.
+var sequenceConfusables = strings.NewReplacer("rn", "m", "vv", "w")
+
+// skeleton returns the form usernames are compared by to tell whether they look alike: the canonical form of the
+// username without separators, with look-alike characters mapped to a single one.
+// For example, "Аdmin" with a Cyrillic "А", "ad_min" and "adm1n" all share the skeleton of "admin".
+func skeleton(username string) string {
+	var b strings.Builder
+	for _, r := range normalize.Username(username) {
+		if r == '_' || r == '.' || r == '-' || unicode.IsSpace(r) {
+			continue
+		}
+		if mapped, ok := confusables[r]; ok {
+			r = mapped
+		}
+		b.WriteRune(r)
+	}
+
+	return sequenceConfusables.Replace(b.String())
+}
+
+// scripts groups the scripts of letters; usernames are written in a single group.
+// Chinese characters and the Japanese and Korean scripts are written together, so they share a group.
+var scripts = []struct {
+	group string
+	table *unicode.RangeTable
+}{
+	{"latin", unicode.Latin},
+	{"cyrillic", unicode.Cyrillic},
+	{"greek", unicode.Greek},
+	{"armenian", unicode.Armenian},
+	{"georgian", unicode.Georgian},
+	{"hebrew", unicode.Hebrew},
+	{"arabic", unicode.Arabic},
+	{"devanagari", unicode.Devanagari},
+	{"thai", unicode.Thai},
+	{"cjk", unicode.Han},
+	{"cjk", unicode.Hiragana},
+	{"cjk", unicode.Katakana},
+	{"cjk", unicode.Hangul},
+}
+
+// mixesScripts reports whether the letters of a username belong to more than one script group, as in "pаypal"
+// with a Cyrillic "а". Digits and separators belong to none.
+func mixesScripts(username string) bool {
+	seen := ""
+	for _, r := range username {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		for _, script := range scripts {
+			if !unicode.Is(script.table, r) {
+				continue
+			}
+			if seen != "" && seen != script.group {
+				return true
+			}
+			seen = script.group
+			break
+		}
+	}
+
+	return false
+}
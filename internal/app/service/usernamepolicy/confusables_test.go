@@ -0,0 +1,61 @@
+package usernamepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkeleton(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+
+		expectedOutput string
+	}{
+		{name: "Case and separators", inputUsername: "Ad_Min.", expectedOutput: "admln"},
+		{name: "Cyrillic look-alike", inputUsername: "Аdmin", expectedOutput: "admln"},
+		{name: "Digits", inputUsername: "adm1n", expectedOutput: "admln"},
+		{name: "Fullwidth letters", inputUsername: "ａｄｍｉｎ", expectedOutput: "admln"},
+		{name: "Letter sequences", inputUsername: "rnoderator", expectedOutput: "moderator"},
+		{name: "Greek look-alike", inputUsername: "rοot", expectedOutput: "root"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedOutput, skeleton(tc.inputUsername))
+		})
+	}
+}
+
+func TestMixesScripts(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+
+		expectedOutput bool
+	}{
+		{name: "Latin only", inputUsername: "alice_01", expectedOutput: false},
+		{name: "Cyrillic only", inputUsername: "алиса", expectedOutput: false},
+		{name: "Latin with a Cyrillic letter", inputUsername: "pаypal", expectedOutput: true},
+		{name: "Latin with a Greek letter", inputUsername: "rοot", expectedOutput: true},
+		{name: "Japanese scripts together", inputUsername: "日本ひらカタ", expectedOutput: false},
+		{name: "Digits only", inputUsername: "12345", expectedOutput: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedOutput, mixesScripts(tc.inputUsername))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package usernamepolicy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+)
+
+// DeleteEntry removes an entry from its list and from the policy.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - id: The ID of the entry.
+//
+// Returns:
+//   - error: ErrEntryNotFound if there is no such entry, otherwise any storage error.
+func (svc *usernamePolicyService) DeleteEntry(ctx context.Context, id string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_DeleteUsernamePolicyEntry")
+	defer s.End()
+
+	err := svc.usernamePolicyRepo.DeleteEntry(ctx, id)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrEntryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return svc.Refresh(ctx)
+}
@@ -0,0 +1,75 @@
+package usernamepolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUsernamePolicyRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy/mocks"
+)
+
+func TestService_DeleteEntry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockRepo func(ctx context.Context) *mockUsernamePolicyRepo.Repository
+
+		expectedError    error
+		expectedReserved bool
+	}{
+		{
+			name: "Delete entry successfully",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("DeleteEntry", ctx, "entry-001").Return(nil)
+				repoMock.On("ListEntries", ctx).Return([]*model.UsernamePolicyEntry{}, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Fail to delete - entry not found",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("DeleteEntry", ctx, "entry-001").Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError:    ErrEntryNotFound,
+			expectedReserved: true,
+		},
+		{
+			name: "Fail to delete - storage error",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("DeleteEntry", ctx, "entry-001").Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError:    assert.AnError,
+			expectedReserved: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			policy, err := NewPolicy(&Config{MinLength: 3, AllowedPattern: `^[a-z]+$`})
+			assert.NoError(t, err)
+			policy.SetEntries([]*model.UsernamePolicyEntry{{Kind: model.UsernamePolicyReserved, Value: "acme"}})
+			usernamePolicyService := NewUsernamePolicyService(tc.setupMockRepo(ctx), policy)
+
+			err = usernamePolicyService.DeleteEntry(ctx, "entry-001")
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedReserved, policy.Check("acme") == ErrReserved)
+		})
+	}
+}
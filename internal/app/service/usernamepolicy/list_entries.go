@@ -0,0 +1,23 @@
+package usernamepolicy
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListEntries retrieves the entries of the lists managed at runtime.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - []*model.UsernamePolicyEntry: The entries, oldest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (svc *usernamePolicyService) ListEntries(ctx context.Context) ([]*model.UsernamePolicyEntry, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListUsernamePolicyEntries")
+	defer s.End()
+
+	return svc.usernamePolicyRepo.ListEntries(ctx)
+}
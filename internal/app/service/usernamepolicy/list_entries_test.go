@@ -0,0 +1,61 @@
+package usernamepolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUsernamePolicyRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy/mocks"
+)
+
+func TestService_ListEntries(t *testing.T) {
+	t.Parallel()
+
+	entries := []*model.UsernamePolicyEntry{{Base: model.Base{ID: "entry-001"}, Kind: model.UsernamePolicyReserved, Value: "acme"}}
+
+	testCases := []struct {
+		name string
+
+		setupMockRepo func(ctx context.Context) *mockUsernamePolicyRepo.Repository
+
+		expectedError  error
+		expectedOutput []*model.UsernamePolicyEntry
+	}{
+		{
+			name: "List entries successfully",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("ListEntries", ctx).Return(entries, nil)
+				return repoMock
+			},
+
+			expectedOutput: entries,
+		},
+		{
+			name: "Fail to list entries - storage error",
+
+			setupMockRepo: func(ctx context.Context) *mockUsernamePolicyRepo.Repository {
+				repoMock := mockUsernamePolicyRepo.NewRepository(t)
+				repoMock.On("ListEntries", ctx).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			usernamePolicyService := NewUsernamePolicyService(tc.setupMockRepo(ctx), nil)
+
+			res, err := usernamePolicyService.ListEntries(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package usernamepolicy
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// Policy checks usernames against the static configuration and the lists managed at runtime.
+// It is safe for concurrent use.
+type Policy struct {
+	cfg            *Config
+	allowedPattern *regexp.Regexp
+	reserved       map[string]bool
+
+	mu              sync.RWMutex
+	entryReserved   map[string]bool
+	blockedWords    []string
+	blockedPatterns []*regexp.Regexp
+}
+
+// NewPolicy creates a username policy from the static configuration, with empty runtime lists.
+//
+// Parameters:
+//   - cfg: The static username policy.
+//
+// Returns:
+//   - *Policy: The username policy.
+//   - error: An error if the allowed pattern is not a valid regular expression, otherwise nil.
+func NewPolicy(cfg *Config) (*Policy, error) {
+	allowedPattern, err := regexp.Compile(cfg.AllowedPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := map[string]bool{}
+	for _, username := range cfg.Reserved {
+		if key := skeleton(username); key != "" {
+			reserved[key] = true
+		}
+	}
+
+	return &Policy{
+		cfg:            cfg,
+		allowedPattern: allowedPattern,
+		reserved:       reserved,
+		entryReserved:  map[string]bool{},
+	}, nil
+}
+
+// SetEntries replaces the runtime lists of the policy.
+// Patterns that do not compile are skipped, as they cannot be added through the service.
+//
+// Parameters:
+//   - entries: The entries of every list.
+func (p *Policy) SetEntries(entries []*model.UsernamePolicyEntry) {
+	entryReserved := map[string]bool{}
+	blockedWords := []string{}
+	blockedPatterns := []*regexp.Regexp{}
+	for _, entry := range entries {
+		switch entry.Kind {
+		case model.UsernamePolicyReserved:
+			entryReserved[skeleton(entry.Value)] = true
+		case model.UsernamePolicyBlockedWord:
+			blockedWords = append(blockedWords, skeleton(entry.Value))
+		case model.UsernamePolicyBlockedPattern:
+			pattern, err := regexp.Compile(entry.Value)
+			if err != nil {
+				log.Warn().Str("entry_id", entry.ID).Err(err).Msg("skipping an invalid blocked username pattern")
+				continue
+			}
+			blockedPatterns = append(blockedPatterns, pattern)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entryReserved = entryReserved
+	p.blockedWords = blockedWords
+	p.blockedPatterns = blockedPatterns
+}
+
+// Check tells whether a username can be registered or changed to.
+// The length counts characters, and the allowed pattern is matched against the username as entered. Reserved
+// usernames and blocked words are compared by skeleton, so look-alike characters, case and separators do not get
+// around them, while blocked patterns are matched against the canonical form of the username.
+//
+// Parameters:
+//   - username: The username as entered.
+//
+// Returns:
+//   - error: ErrTooShort, ErrTooLong, ErrInvalidCharacters, ErrMixedScripts, ErrReserved or ErrBlocked if the
+//     username is rejected, otherwise nil.
+func (p *Policy) Check(username string) error {
+	length := utf8.RuneCountInString(username)
+	switch {
+	case length < p.cfg.MinLength:
+		return ErrTooShort
+	case p.cfg.MaxLength > 0 && length > p.cfg.MaxLength:
+		return ErrTooLong
+	case !p.allowedPattern.MatchString(username):
+		return ErrInvalidCharacters
+	case mixesScripts(username):
+		return ErrMixedScripts
+	}
+
+	key := skeleton(username)
+	normalized := normalize.Username(username)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.reserved[key] || p.entryReserved[key] {
+		return ErrReserved
+	}
+	for _, word := range p.blockedWords {
+		if word != "" && strings.Contains(key, word) {
+			return ErrBlocked
+		}
+	}
+	for _, pattern := range p.blockedPatterns {
+		if pattern.MatchString(normalized) {
+			return ErrBlocked
+		}
+	}
+
+	return nil
+}
+
+// fallback is the policy checked until one is activated: the default length and characters, nothing reserved.
+var fallback, _ = NewPolicy(&Config{MinLength: 3, MaxLength: 32, AllowedPattern: `^[\p{L}\p{N}_.-]+$`})
+
+var active atomic.Pointer[Policy]
+
+// Activate makes a policy the one checked by Validate.
+// Until a policy is activated, Validate checks usernames for their length and characters only.
+//
+// Parameters:
+//   - policy: The policy to check.
+func Activate(policy *Policy) {
+	active.Store(policy)
+}
+
+// Active returns the policy checked by Validate.
+//
+// Returns:
+//   - *Policy: The active policy.
+func Active() *Policy {
+	if policy := active.Load(); policy != nil {
+		return policy
+	}
+
+	return fallback
+}
+
+// Validate is the validator function of the "username_policy" tag, checking the field against the active policy.
+//
+// Parameters:
+//   - fl: The field level information provided by the validator
+//
+// Returns:
+//   - bool: true if the username is allowed, false otherwise
+func Validate(fl validator.FieldLevel) bool {
+	return Active().Check(fl.Field().String()) == nil
+}
@@ -0,0 +1,107 @@
+package usernamepolicy
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+func newTestPolicy(t *testing.T) *Policy {
+	policy, err := NewPolicy(&Config{
+		MinLength:      3,
+		MaxLength:      16,
+		AllowedPattern: `^[\p{L}\p{N}_.-]+$`,
+		Reserved:       []string{"admin", "support"},
+	})
+	assert.NoError(t, err)
+
+	policy.SetEntries([]*model.UsernamePolicyEntry{
+		{Kind: model.UsernamePolicyReserved, Value: "acme"},
+		{Kind: model.UsernamePolicyBlockedWord, Value: "scam"},
+		{Kind: model.UsernamePolicyBlockedPattern, Value: `^guest[0-9]+$`},
+		{Base: model.Base{ID: "invalid-pattern"}, Kind: model.UsernamePolicyBlockedPattern, Value: `(`},
+	})
+	return policy
+}
+
+func TestPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputUsername string
+
+		expectedError error
+	}{
+		{name: "Allowed username", inputUsername: "alice_01"},
+		{name: "Allowed username in another script", inputUsername: "алиса"},
+		{name: "Allowed username containing a reserved one", inputUsername: "admin_fan"},
+		{name: "Too short", inputUsername: "al", expectedError: ErrTooShort},
+		{name: "Length counts characters", inputUsername: "ééé"},
+		{name: "Too long", inputUsername: "alice_in_wonderland", expectedError: ErrTooLong},
+		{name: "Characters not allowed", inputUsername: "alice smith", expectedError: ErrInvalidCharacters},
+		{name: "Mixed scripts", inputUsername: "pаypal", expectedError: ErrMixedScripts},
+		{name: "Reserved in the configuration", inputUsername: "Support", expectedError: ErrReserved},
+		{name: "Reserved look-alike", inputUsername: "adm1n", expectedError: ErrReserved},
+		{name: "Reserved with separators", inputUsername: "ad_min", expectedError: ErrReserved},
+		{name: "Reserved at runtime", inputUsername: "ACME", expectedError: ErrReserved},
+		{name: "Digits other than look-alikes are kept", inputUsername: "best_sc4m_ever"},
+		{name: "Blocked word inside", inputUsername: "bestscamever", expectedError: ErrBlocked},
+		{name: "Blocked word with look-alikes", inputUsername: "the_scаm", expectedError: ErrMixedScripts},
+		{name: "Blocked word in the canonical form", inputUsername: "ScAm.Bot", expectedError: ErrBlocked},
+		{name: "Blocked pattern", inputUsername: "Guest42", expectedError: ErrBlocked},
+	}
+
+	policy := newTestPolicy(t)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedError, policy.Check(tc.inputUsername))
+		})
+	}
+}
+
+func TestPolicy_SetEntries(t *testing.T) {
+	t.Parallel()
+
+	policy := newTestPolicy(t)
+	assert.Equal(t, ErrReserved, policy.Check("acme"))
+
+	// The runtime lists are replaced, the configured ones stay
+	policy.SetEntries([]*model.UsernamePolicyEntry{})
+	assert.NoError(t, policy.Check("acme"))
+	assert.NoError(t, policy.Check("guest42"))
+	assert.Equal(t, ErrReserved, policy.Check("admin"))
+}
+
+func TestNewPolicy_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	policy, err := NewPolicy(&Config{AllowedPattern: `[`})
+
+	assert.Error(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestValidate(t *testing.T) {
+	validate := validator.New()
+	assert.NoError(t, validate.RegisterValidation("username_policy", Validate))
+
+	type request struct {
+		Username string `validate:"username_policy"`
+	}
+
+	// The fallback policy only checks the length and characters
+	assert.NoError(t, validate.Struct(&request{Username: "acme"}))
+	assert.Error(t, validate.Struct(&request{Username: "a b"}))
+
+	Activate(newTestPolicy(t))
+	defer active.Store(nil)
+
+	assert.Error(t, validate.Struct(&request{Username: "acme"}))
+	assert.NoError(t, validate.Struct(&request{Username: "alice"}))
+}
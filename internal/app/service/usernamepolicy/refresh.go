@@ -0,0 +1,27 @@
+package usernamepolicy
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// Refresh reloads the lists of the policy from the database.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - error: An error if the retrieval fails, in which case the policy keeps its lists.
+func (svc *usernamePolicyService) Refresh(ctx context.Context) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_RefreshUsernamePolicy")
+	defer s.End()
+
+	entries, err := svc.usernamePolicyRepo.ListEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	svc.policy.SetEntries(entries)
+	return nil
+}
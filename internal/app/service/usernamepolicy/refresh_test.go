@@ -0,0 +1,28 @@
+package usernamepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockUsernamePolicyRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy/mocks"
+)
+
+func TestService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	policy, err := NewPolicy(&Config{MinLength: 3, AllowedPattern: `^[a-z]+$`})
+	assert.NoError(t, err)
+	repoMock := mockUsernamePolicyRepo.NewRepository(t)
+	repoMock.On("ListEntries", ctx).Return([]*model.UsernamePolicyEntry{{Kind: model.UsernamePolicyReserved, Value: "acme"}}, nil).Once()
+	repoMock.On("ListEntries", ctx).Return(nil, assert.AnError).Once()
+	usernamePolicyService := NewUsernamePolicyService(repoMock, policy)
+
+	assert.NoError(t, usernamePolicyService.Refresh(ctx))
+	assert.Equal(t, ErrReserved, policy.Check("acme"))
+
+	// A failed refresh keeps the lists
+	assert.Equal(t, assert.AnError, usernamePolicyService.Refresh(ctx))
+	assert.Equal(t, ErrReserved, policy.Check("acme"))
+}
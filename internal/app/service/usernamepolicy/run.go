@@ -0,0 +1,34 @@
+package usernamepolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Run loads the lists of the policy, then reloads them every refresh interval until the context is cancelled.
+//
+// Parameters:
+//   - ctx: The context stopping the refresh when cancelled.
+//   - svc: The username policy service.
+//   - refreshInterval: How long to wait between two reloads.
+func Run(ctx context.Context, svc Service, refreshInterval time.Duration) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Error().
+				Str("operation", "UsernamePolicy_Run").
+				Err(err).
+				Msg("failed to refresh the username policy")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
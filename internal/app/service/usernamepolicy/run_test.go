@@ -0,0 +1,64 @@
+package usernamepolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockUsernamePolicyService "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy/mocks"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockService func(cancel context.CancelFunc) *mockUsernamePolicyService.Service
+	}{
+		{
+			name: "Refresh on start and on every tick",
+
+			setupMockService: func(cancel context.CancelFunc) *mockUsernamePolicyService.Service {
+				svcMock := mockUsernamePolicyService.NewService(t)
+				svcMock.On("Refresh", mock.Anything).Return(nil).Once()
+				svcMock.On("Refresh", mock.Anything).Return(nil).Once().Run(func(mock.Arguments) { cancel() })
+				return svcMock
+			},
+		},
+		{
+			name: "Keep refreshing after an error",
+
+			setupMockService: func(cancel context.CancelFunc) *mockUsernamePolicyService.Service {
+				svcMock := mockUsernamePolicyService.NewService(t)
+				svcMock.On("Refresh", mock.Anything).Return(errors.New("database error")).Once()
+				svcMock.On("Refresh", mock.Anything).Return(nil).Once().Run(func(mock.Arguments) { cancel() })
+				return svcMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			done := make(chan struct{})
+			go func() {
+				Run(ctx, tc.setupMockService(cancel), time.Millisecond)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "refresh did not stop after the context was cancelled")
+			}
+		})
+	}
+}
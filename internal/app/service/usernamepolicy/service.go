@@ -0,0 +1,125 @@
+// Package usernamepolicy decides which usernames can be registered or changed to.
+// The length and allowed characters are configured through the environment, while the reserved usernames, blocked
+// words and blocked patterns are lists managed at runtime through the admin API, on top of the reserved usernames of
+// the configuration. Usernames that mix scripts, or that only differ from a reserved or blocked one by look-alike
+// characters, are rejected as well.
+//
+// The policy is enforced by the "username_policy" validation tag. As the validator of Gin is shared by the whole
+// process, so is the policy it checks: see Activate.
+package usernamepolicy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	usernamePolicyRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy"
+)
+
+// Config holds the static username policy, read from USERNAME_POLICY_* environment variables.
+type Config struct {
+	MinLength int `envconfig:"MIN_LENGTH" default:"3"`
+	MaxLength int `envconfig:"MAX_LENGTH" default:"32"`
+	// AllowedPattern is the regular expression every username must match
+	AllowedPattern string `envconfig:"ALLOWED_PATTERN" default:"^[\\p{L}\\p{N}_.-]+$"`
+	// Reserved lists the usernames reserved on top of the ones managed through the admin API
+	Reserved []string `envconfig:"RESERVED" default:"admin,administrator,root,system,support,help,security,abuse,postmaster,webmaster,noreply,moderator,staff,official,api,www,mail"`
+	// RefreshInterval is how often the lists managed through the admin API are reloaded from the database, so changes
+	// made on another instance apply
+	RefreshInterval time.Duration `envconfig:"REFRESH_INTERVAL" default:"1m"`
+}
+
+// NewConfig loads the username policy configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("USERNAME_POLICY", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+var (
+	ErrTooShort          = errors.New("username is too short")
+	ErrTooLong           = errors.New("username is too long")
+	ErrInvalidCharacters = errors.New("username contains characters that are not allowed")
+	ErrMixedScripts      = errors.New("username mixes characters of several scripts")
+	ErrReserved          = errors.New("username is reserved")
+	ErrBlocked           = errors.New("username is not allowed")
+
+	ErrUnsupportedKind = errors.New("unsupported username policy entry kind")
+	ErrInvalidPattern  = errors.New("username policy pattern is not a valid regular expression")
+	ErrEmptyValue      = errors.New("username policy entry value is empty")
+	ErrEntryExists     = errors.New("username policy entry already exists")
+	ErrEntryNotFound   = errors.New("username policy entry not found")
+)
+
+// Service represents the interface for managing the username lists of the policy.
+//
+//go:generate mockery --name=Service --filename=username_policy_service.go --output=./mocks
+type Service interface {
+	// ListEntries retrieves the entries of the lists managed at runtime.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - []*model.UsernamePolicyEntry: The entries, oldest first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListEntries(ctx context.Context) ([]*model.UsernamePolicyEntry, error)
+
+	// AddEntry adds an entry to a list and applies it to the policy.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - kind: model.UsernamePolicyReserved, model.UsernamePolicyBlockedWord or model.UsernamePolicyBlockedPattern.
+	//   - value: The reserved username, the blocked word or the blocked regular expression.
+	//
+	// Returns:
+	//   - *model.UsernamePolicyEntry: The stored entry.
+	//   - error: ErrUnsupportedKind, ErrEmptyValue, ErrInvalidPattern or ErrEntryExists for invalid input, otherwise
+	//     any storage error.
+	AddEntry(ctx context.Context, kind, value string) (*model.UsernamePolicyEntry, error)
+
+	// DeleteEntry removes an entry from its list and from the policy.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - id: The ID of the entry.
+	//
+	// Returns:
+	//   - error: ErrEntryNotFound if there is no such entry, otherwise any storage error.
+	DeleteEntry(ctx context.Context, id string) error
+
+	// Refresh reloads the lists of the policy from the database.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - error: An error if the retrieval fails, in which case the policy keeps its lists.
+	Refresh(ctx context.Context) error
+}
+
+type usernamePolicyService struct {
+	usernamePolicyRepo usernamePolicyRepository.Repository
+	policy             *Policy
+}
+
+// NewUsernamePolicyService creates a new instance of the username policy service.
+//
+// Parameters:
+//   - usernamePolicyRepo: The repository storing the lists managed at runtime.
+//   - policy: The policy the lists are applied to.
+//
+// Returns:
+//   - Service: A new username policy service instance.
+func NewUsernamePolicyService(usernamePolicyRepo usernamePolicyRepository.Repository, policy *Policy) Service {
+	return &usernamePolicyService{
+		usernamePolicyRepo: usernamePolicyRepo,
+		policy:             policy,
+	}
+}
@@ -0,0 +1,33 @@
+package usernamepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Setenv("USERNAME_POLICY_MAX_LENGTH", "20")
+	t.Setenv("USERNAME_POLICY_RESERVED", "admin,root")
+
+	cfg, err := NewConfig()
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		MinLength:       3,
+		MaxLength:       20,
+		AllowedPattern:  `^[\p{L}\p{N}_.-]+$`,
+		Reserved:        []string{"admin", "root"},
+		RefreshInterval: time.Minute,
+	}, cfg)
+}
+
+func TestNewConfig_InvalidValue(t *testing.T) {
+	t.Setenv("USERNAME_POLICY_MIN_LENGTH", "short")
+
+	cfg, err := NewConfig()
+
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// CreateSubscription subscribes an endpoint to event types, generating its signing secret.
+// Event types are deduplicated and stored sorted.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - endpoint: The endpoint the events are posted to.
+//   - eventTypes: The event types delivered to the endpoint.
+//
+// Returns:
+//   - *CreatedSubscription: The stored subscription along with its secret.
+//   - error: ErrInvalidURL or ErrUnsupportedEventType for invalid input, otherwise any storage error.
+func (svc *webhookService) CreateSubscription(ctx context.Context, endpoint string, eventTypes []string) (*CreatedSubscription, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_CreateSubscription")
+	defer s.End()
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	for _, eventType := range eventTypes {
+		if !supportedEventTypes[eventType] {
+			return nil, ErrUnsupportedEventType
+		}
+	}
+
+	secret, err := svc.codeGen.GenerateCode(secretLength)
+	if err != nil {
+		return nil, err
+	}
+	secret = SecretPrefix + secret
+
+	subscribedTypes := slices.Clone(eventTypes)
+	slices.Sort(subscribedTypes)
+
+	created, err := svc.webhookRepo.CreateSubscription(ctx, &model.WebhookSubscription{
+		URL:        endpoint,
+		Secret:     secret,
+		EventTypes: slices.Compact(subscribedTypes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreatedSubscription{
+		WebhookSubscription: created,
+		Secret:              secret,
+	}, nil
+}
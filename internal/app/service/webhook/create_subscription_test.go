@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mockUtils "github.com/vukieuhaihoa/bookmark-libs/pkg/utils/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+const (
+	testSubscriptionID = "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81"
+	testDeliveryID     = "e5f6a7b8-0003-4c5d-8e9f-4a5b6c7d8e93"
+	testSecret         = "abcdef0123456789abcdef0123456789"
+	testURL            = "https://partner.example.com/hooks"
+)
+
+func TestService_CreateSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputURL        string
+		inputEventTypes []string
+
+		setupMockWebhookRepo func(ctx context.Context) *mockWebhookRepo.Repository
+		setupMockCodeGen     func() *mockUtils.CodeGenerator
+
+		expectedOutput *CreatedSubscription
+		expectedError  error
+	}{
+		{
+			name:            "Create subscription successfully",
+			inputURL:        testURL,
+			inputEventTypes: []string{"user.updated", "user.created", "user.updated"},
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("CreateSubscription", ctx, &model.WebhookSubscription{
+					URL:        testURL,
+					Secret:     SecretPrefix + testSecret,
+					EventTypes: []string{"user.created", "user.updated"},
+				}).Return(func(_ context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+					subscription.ID = testSubscriptionID
+					return subscription, nil
+				})
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", secretLength).Return(testSecret, nil)
+				return codeGenMock
+			},
+
+			expectedOutput: &CreatedSubscription{
+				WebhookSubscription: &model.WebhookSubscription{
+					Base:       model.Base{ID: testSubscriptionID},
+					URL:        testURL,
+					Secret:     SecretPrefix + testSecret,
+					EventTypes: []string{"user.created", "user.updated"},
+				},
+				Secret: SecretPrefix + testSecret,
+			},
+		},
+		{
+			name:            "Create subscription failed - not an http url",
+			inputURL:        "ftp://partner.example.com/hooks",
+			inputEventTypes: []string{"user.created"},
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				return mockWebhookRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: ErrInvalidURL,
+		},
+		{
+			name:            "Create subscription failed - relative url",
+			inputURL:        "/hooks",
+			inputEventTypes: []string{"user.created"},
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				return mockWebhookRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: ErrInvalidURL,
+		},
+		{
+			name:            "Create subscription failed - unsupported event type",
+			inputURL:        testURL,
+			inputEventTypes: []string{"user.created", "bookmark.created"},
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				return mockWebhookRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				return mockUtils.NewCodeGenerator(t)
+			},
+
+			expectedError: ErrUnsupportedEventType,
+		},
+		{
+			name:            "Create subscription failed - code generator error",
+			inputURL:        testURL,
+			inputEventTypes: []string{"user.created"},
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				return mockWebhookRepo.NewRepository(t)
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", secretLength).Return("", assert.AnError)
+				return codeGenMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name:            "Create subscription failed - repository error",
+			inputURL:        testURL,
+			inputEventTypes: []string{"user.created"},
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("CreateSubscription", ctx, mock.Anything).Return(nil, assert.AnError)
+				return repoMock
+			},
+			setupMockCodeGen: func() *mockUtils.CodeGenerator {
+				codeGenMock := mockUtils.NewCodeGenerator(t)
+				codeGenMock.On("GenerateCode", secretLength).Return(testSecret, nil)
+				return codeGenMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webhookService := NewWebhookService(tc.setupMockWebhookRepo(ctx), tc.setupMockCodeGen())
+
+			res, err := webhookService.CreateSubscription(ctx, tc.inputURL, tc.inputEventTypes)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+)
+
+// DeleteSubscription deletes a webhook subscription, dropping its pending deliveries.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//
+// Returns:
+//   - error: ErrSubscriptionNotFound if there is no such subscription, otherwise any deletion error.
+func (svc *webhookService) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Service_DeleteSubscription")
+	defer s.End()
+
+	err := svc.webhookRepo.DeleteSubscription(ctx, subscriptionID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return ErrSubscriptionNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+func TestService_DeleteSubscription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockWebhookRepo func(ctx context.Context) *mockWebhookRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Delete subscription successfully",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("DeleteSubscription", ctx, testSubscriptionID).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Delete subscription failed - not found",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("DeleteSubscription", ctx, testSubscriptionID).Return(dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrSubscriptionNotFound,
+		},
+		{
+			name: "Delete subscription failed - repository error",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("DeleteSubscription", ctx, testSubscriptionID).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webhookService := NewWebhookService(tc.setupMockWebhookRepo(ctx), nil)
+
+			err := webhookService.DeleteSubscription(ctx, testSubscriptionID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
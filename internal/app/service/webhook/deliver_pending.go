@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// userAgent identifies the deliveries to the receiving endpoints.
+const userAgent = "user-service-webhooks/1.0"
+
+// maxResponseBodySize bounds how much of a response is read before the connection is reused.
+const maxResponseBodySize = 64 << 10
+
+// deliveryBody is the JSON body posted to a webhook endpoint.
+type deliveryBody struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// DeliverPending attempts the deliveries that are due, recording the outcome of each.
+// A delivery succeeds on a 2xx response; otherwise it is retried with an exponential backoff,
+// and marked as failed after MaxAttempts attempts. A failing endpoint does not hold back the others.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - int: The number of deliveries attempted.
+//   - error: An error if the deliveries cannot be read or updated, otherwise nil.
+func (svc *webhookWorker) DeliverPending(ctx context.Context) (int, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_DeliverPending")
+	defer s.End()
+
+	deliveries, err := svc.webhookRepo.ListDueDeliveries(ctx, time.Now(), svc.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, delivery := range deliveries {
+		now := time.Now()
+		statusCode, err := svc.send(ctx, delivery, now)
+
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		switch {
+		case err == nil:
+			delivery.Status = model.WebhookDeliverySucceeded
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+		case delivery.Attempts >= svc.cfg.MaxAttempts:
+			delivery.Status = model.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = now.Add(svc.retryBackoff(delivery.Attempts))
+		}
+
+		err = svc.webhookRepo.UpdateDelivery(ctx, delivery)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send posts a delivery to the endpoint of its subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - delivery: The delivery, with its subscription.
+//   - now: When the attempt is made, sent as the signature timestamp.
+//
+// Returns:
+//   - int: The status code of the response, 0 if none was received.
+//   - error: An error if the request fails or the response is not a 2xx, otherwise nil.
+func (svc *webhookWorker) send(ctx context.Context, delivery *model.WebhookDelivery, now time.Time) (int, error) {
+	body, err := json.Marshal(&deliveryBody{
+		ID:         delivery.EventID,
+		Type:       delivery.EventType,
+		OccurredAt: delivery.OccurredAt.UTC(),
+		Data:       json.RawMessage(delivery.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Subscription.Secret, timestamp, body))
+
+	resp, err := svc.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryBackoff returns how long to wait before retrying a delivery, doubling with every failed attempt.
+//
+// Parameters:
+//   - attempts: The number of failed attempts so far.
+//
+// Returns:
+//   - time.Duration: The delay, at most MaxRetryBackoff.
+func (svc *webhookWorker) retryBackoff(attempts int) time.Duration {
+	backoff := svc.cfg.RetryBackoff
+	for i := 1; i < attempts && backoff < svc.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, svc.cfg.MaxRetryBackoff)
+}
@@ -0,0 +1,256 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+func TestWorker_DeliverPending(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{BatchSize: 100, MaxAttempts: 3, RetryBackoff: 30 * time.Second, MaxRetryBackoff: time.Hour}
+	const secret = "whsec_partnersecret"
+
+	// newReceiver starts an endpoint answering with the status code, recording the requests it received
+	newReceiver := func(t *testing.T, statusCode int) (*httptest.Server, *[]*http.Request, *[][]byte) {
+		requests, bodies := []*http.Request{}, [][]byte{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r)
+			bodies = append(bodies, body)
+			w.WriteHeader(statusCode)
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests, &bodies
+	}
+	newDelivery := func(url string, attempts int) *model.WebhookDelivery {
+		return &model.WebhookDelivery{
+			Base:           model.Base{ID: "e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92"},
+			SubscriptionID: testSubscriptionID,
+			EventID:        "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71",
+			EventType:      "user.created",
+			Payload:        `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","username":"testuser001"}`,
+			OccurredAt:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Status:         model.WebhookDeliveryPending,
+			Attempts:       attempts,
+			Subscription:   &model.WebhookSubscription{Base: model.Base{ID: testSubscriptionID}, URL: url, Secret: secret},
+		}
+	}
+
+	testCases := []struct {
+		name string
+
+		receiverStatusCode int
+		setupMockRepo      func(ctx context.Context, url string) *mockWebhookRepo.Repository
+
+		expectedAttempted int
+		expectedRequests  int
+		expectedError     error
+	}{
+		{
+			name:               "Deliver a signed event",
+			receiverStatusCode: http.StatusNoContent,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{newDelivery(url, 0)}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+					return delivery.Status == model.WebhookDeliverySucceeded &&
+						delivery.Attempts == 1 &&
+						delivery.LastStatusCode == http.StatusNoContent &&
+						delivery.LastError == "" &&
+						delivery.DeliveredAt != nil
+				})).Return(nil)
+				return repoMock
+			},
+
+			expectedAttempted: 1,
+			expectedRequests:  1,
+		},
+		{
+			name:               "Schedule a retry when the endpoint fails",
+			receiverStatusCode: http.StatusServiceUnavailable,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{newDelivery(url, 1)}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+					delay := time.Until(delivery.NextAttemptAt)
+					return delivery.Status == model.WebhookDeliveryPending &&
+						delivery.Attempts == 2 &&
+						delivery.LastStatusCode == http.StatusServiceUnavailable &&
+						delivery.LastError == "unexpected status code 503" &&
+						delivery.DeliveredAt == nil &&
+						delay > 55*time.Second && delay <= time.Minute
+				})).Return(nil)
+				return repoMock
+			},
+
+			expectedAttempted: 1,
+			expectedRequests:  1,
+		},
+		{
+			name:               "Give up after the last attempt",
+			receiverStatusCode: http.StatusInternalServerError,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{newDelivery(url, 2)}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+					return delivery.Status == model.WebhookDeliveryFailed &&
+						delivery.Attempts == 3 &&
+						delivery.LastStatusCode == http.StatusInternalServerError
+				})).Return(nil)
+				return repoMock
+			},
+
+			expectedAttempted: 1,
+			expectedRequests:  1,
+		},
+		{
+			name:               "Record an unreachable endpoint",
+			receiverStatusCode: http.StatusOK,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{newDelivery("http://127.0.0.1:0/hooks", 0)}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+					return delivery.Status == model.WebhookDeliveryPending &&
+						delivery.Attempts == 1 &&
+						delivery.LastStatusCode == 0 &&
+						delivery.LastError != ""
+				})).Return(nil)
+				return repoMock
+			},
+
+			expectedAttempted: 1,
+		},
+		{
+			name:               "Record an invalid payload",
+			receiverStatusCode: http.StatusOK,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				delivery := newDelivery(url, 0)
+				delivery.Payload = "not json"
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{delivery}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+					return delivery.Status == model.WebhookDeliveryPending && delivery.LastError != ""
+				})).Return(nil)
+				return repoMock
+			},
+
+			expectedAttempted: 1,
+		},
+		{
+			name:               "Record an invalid url",
+			receiverStatusCode: http.StatusOK,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{newDelivery("http://[::1", 0)}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+					return delivery.Status == model.WebhookDeliveryPending && delivery.LastError != ""
+				})).Return(nil)
+				return repoMock
+			},
+
+			expectedAttempted: 1,
+		},
+		{
+			name:               "Error listing the due deliveries",
+			receiverStatusCode: http.StatusOK,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name:               "Error recording the outcome",
+			receiverStatusCode: http.StatusOK,
+
+			setupMockRepo: func(ctx context.Context, url string) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListDueDeliveries", ctx, mock.AnythingOfType("time.Time"), 100).Return([]*model.WebhookDelivery{newDelivery(url, 0), newDelivery(url, 0)}, nil)
+				repoMock.On("UpdateDelivery", ctx, mock.Anything).Return(assert.AnError).Once()
+				return repoMock
+			},
+
+			expectedRequests: 1,
+			expectedError:    assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			server, requests, bodies := newReceiver(t, tc.receiverStatusCode)
+			worker := NewWebhookWorker(tc.setupMockRepo(ctx, server.URL+"/hooks"), server.Client(), cfg)
+
+			attempted, err := worker.DeliverPending(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedAttempted, attempted)
+			assert.Len(t, *requests, tc.expectedRequests)
+
+			for i, req := range *requests {
+				body := (*bodies)[i]
+				timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+				assert.NoError(t, err)
+
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/hooks", req.URL.Path)
+				assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+				assert.Equal(t, "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71", req.Header.Get(HeaderEventID))
+				assert.Equal(t, "user.created", req.Header.Get(HeaderEventType))
+				assert.Equal(t, Sign(secret, timestamp, body), req.Header.Get(HeaderSignature))
+				assert.JSONEq(t, `{
+					"id": "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71",
+					"type": "user.created",
+					"occurred_at": "2023-01-01T00:00:00Z",
+					"data": {"id": "4d9326d6-980c-4c62-9709-dbc70a82cbfe", "username": "testuser001"}
+				}`, string(body))
+			}
+		})
+	}
+}
+
+func TestWorker_retryBackoff(t *testing.T) {
+	t.Parallel()
+
+	worker := &webhookWorker{cfg: &Config{RetryBackoff: 30 * time.Second, MaxRetryBackoff: time.Hour}}
+
+	testCases := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{name: "First retry", attempts: 1, expected: 30 * time.Second},
+		{name: "Doubles with every attempt", attempts: 4, expected: 4 * time.Minute},
+		{name: "Capped at the maximum", attempts: 8, expected: time.Hour},
+		{name: "Capped without overflowing", attempts: 1000, expected: time.Hour},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, worker.retryBackoff(tc.attempts))
+		})
+	}
+}
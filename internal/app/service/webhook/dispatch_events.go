@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// DispatchEvents reads the next events off the event stream and queues a delivery
+// of each to every subscription of its tenant and type. Events are acknowledged once their deliveries
+// are stored, so an event is never lost, and queued at most once per subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - int: The number of events read.
+//   - error: An error if the events cannot be read, queued or acknowledged, otherwise nil.
+func (svc *webhookWorker) DispatchEvents(ctx context.Context) (int, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_DispatchEvents")
+	defer s.End()
+
+	events, err := svc.webhookRepo.ReadEvents(ctx, svc.cfg.Stream, svc.cfg.Group, svc.cfg.Consumer, svc.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	subscriptions, err := svc.webhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	deliveries := []*model.WebhookDelivery{}
+	streamIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		streamIDs = append(streamIDs, event.StreamID)
+
+		if event.ID == "" {
+			log.Warn().
+				Str("operation", "DispatchEvents").
+				Str("stream_id", event.StreamID).
+				Msg("skipping stream entry without event ID")
+			continue
+		}
+
+		for _, subscription := range subscriptions {
+			if subscription.TenantID != event.TenantID || !slices.Contains(subscription.EventTypes, event.Type) {
+				continue
+			}
+
+			deliveries = append(deliveries, &model.WebhookDelivery{
+				TenantID:       subscription.TenantID,
+				SubscriptionID: subscription.ID,
+				EventID:        event.ID,
+				EventType:      event.Type,
+				Payload:        event.Payload,
+				OccurredAt:     event.OccurredAt,
+				Status:         model.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+			})
+		}
+	}
+
+	err = svc.webhookRepo.CreateDeliveries(ctx, deliveries)
+	if err != nil {
+		return 0, err
+	}
+
+	err = svc.webhookRepo.AckEvents(ctx, svc.cfg.Stream, svc.cfg.Group, streamIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	webhookRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+func TestWorker_DispatchEvents(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{Stream: "user-events", Group: "webhooks", Consumer: "webhook-worker", BatchSize: 100}
+	occurredAt := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []*webhookRepository.Event{
+		{StreamID: "1672531200000-0", ID: "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71", Type: "user.created", TenantID: "default", Payload: `{"id":"a"}`, OccurredAt: occurredAt},
+		{StreamID: "1672531200000-1", ID: "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72", Type: "user.deleted", TenantID: "default", Payload: `{"id":"b"}`, OccurredAt: occurredAt},
+		{StreamID: "1672531200000-2"},
+		{StreamID: "1672531200000-3", ID: "c3d4e5f6-0003-4a5b-8c9d-2e3f4a5b6c73", Type: "user.created", TenantID: "brand_a", Payload: `{"id":"c"}`, OccurredAt: occurredAt},
+	}
+	subscriptions := []*model.WebhookSubscription{
+		{Base: model.Base{ID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81"}, TenantID: "default", EventTypes: []string{"user.created", "user.updated"}},
+		{Base: model.Base{ID: "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82"}, TenantID: "default", EventTypes: []string{"user.created", "user.deleted"}},
+		{Base: model.Base{ID: "d4e5f6a7-0003-4b5c-9d0e-3f4a5b6c7d83"}, TenantID: "brand_a", EventTypes: []string{"user.created"}},
+	}
+	streamIDs := []string{"1672531200000-0", "1672531200000-1", "1672531200000-2", "1672531200000-3"}
+
+	// queued lists the tenant, subscription and event of each delivery queued
+	queued := func(deliveries []*model.WebhookDelivery) [][3]string {
+		triples := [][3]string{}
+		for _, delivery := range deliveries {
+			triples = append(triples, [3]string{delivery.TenantID, delivery.SubscriptionID, delivery.EventID})
+		}
+		return triples
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockWebhookRepo func(ctx context.Context) *mockWebhookRepo.Repository
+
+		expectedDispatched int
+		expectedError      error
+	}{
+		{
+			name: "Queue a delivery per matching subscription of the tenant and acknowledge the events",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ReadEvents", ctx, "user-events", "webhooks", "webhook-worker", 100).Return(events, nil)
+				repoMock.On("ListSubscriptions", ctx).Return(subscriptions, nil)
+				repoMock.On("CreateDeliveries", ctx, mock.MatchedBy(func(deliveries []*model.WebhookDelivery) bool {
+					for _, delivery := range deliveries {
+						if delivery.Status != model.WebhookDeliveryPending || !delivery.OccurredAt.Equal(occurredAt) || delivery.Payload == "" {
+							return false
+						}
+					}
+					return assert.ObjectsAreEqual([][3]string{
+						{"default", "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81", "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"},
+						{"default", "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82", "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"},
+						{"default", "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82", "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72"},
+						{"brand_a", "d4e5f6a7-0003-4b5c-9d0e-3f4a5b6c7d83", "c3d4e5f6-0003-4a5b-8c9d-2e3f4a5b6c73"},
+					}, queued(deliveries))
+				})).Return(nil)
+				repoMock.On("AckEvents", ctx, "user-events", "webhooks", streamIDs).Return(nil)
+				return repoMock
+			},
+
+			expectedDispatched: 4,
+		},
+		{
+			name: "No new events",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ReadEvents", ctx, "user-events", "webhooks", "webhook-worker", 100).Return([]*webhookRepository.Event{}, nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Error reading the events",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ReadEvents", ctx, "user-events", "webhooks", "webhook-worker", 100).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Error listing the subscriptions",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ReadEvents", ctx, "user-events", "webhooks", "webhook-worker", 100).Return(events, nil)
+				repoMock.On("ListSubscriptions", ctx).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Events are not acknowledged when the deliveries cannot be queued",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ReadEvents", ctx, "user-events", "webhooks", "webhook-worker", 100).Return(events, nil)
+				repoMock.On("ListSubscriptions", ctx).Return(subscriptions, nil)
+				repoMock.On("CreateDeliveries", ctx, mock.Anything).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Error acknowledging the events",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ReadEvents", ctx, "user-events", "webhooks", "webhook-worker", 100).Return(events, nil)
+				repoMock.On("ListSubscriptions", ctx).Return(subscriptions, nil)
+				repoMock.On("CreateDeliveries", ctx, mock.Anything).Return(nil)
+				repoMock.On("AckEvents", ctx, "user-events", "webhooks", streamIDs).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			worker := NewWebhookWorker(tc.setupMockWebhookRepo(ctx), nil, cfg)
+
+			dispatched, err := worker.DispatchEvents(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedDispatched, dispatched)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListDeliveries retrieves the delivery log of a subscription.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//
+// Returns:
+//   - []*model.WebhookDelivery: The latest DeliveryLogLimit deliveries, newest first.
+//   - error: ErrSubscriptionNotFound if there is no such subscription, otherwise any retrieval error.
+func (svc *webhookService) ListDeliveries(ctx context.Context, subscriptionID string) ([]*model.WebhookDelivery, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListDeliveries")
+	defer s.End()
+
+	_, err := svc.webhookRepo.GetSubscriptionByID(ctx, subscriptionID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.webhookRepo.ListDeliveriesBySubscriptionID(ctx, subscriptionID, DeliveryLogLimit)
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+func TestService_ListDeliveries(t *testing.T) {
+	t.Parallel()
+
+	subscription := &model.WebhookSubscription{Base: model.Base{ID: testSubscriptionID}, URL: testURL}
+	deliveries := []*model.WebhookDelivery{{Base: model.Base{ID: testDeliveryID}, SubscriptionID: testSubscriptionID}}
+
+	testCases := []struct {
+		name string
+
+		setupMockWebhookRepo func(ctx context.Context) *mockWebhookRepo.Repository
+
+		expectedOutput []*model.WebhookDelivery
+		expectedError  error
+	}{
+		{
+			name: "List deliveries successfully",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetSubscriptionByID", ctx, testSubscriptionID).Return(subscription, nil)
+				repoMock.On("ListDeliveriesBySubscriptionID", ctx, testSubscriptionID, DeliveryLogLimit).Return(deliveries, nil)
+				return repoMock
+			},
+
+			expectedOutput: deliveries,
+		},
+		{
+			name: "List deliveries failed - subscription not found",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetSubscriptionByID", ctx, testSubscriptionID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrSubscriptionNotFound,
+		},
+		{
+			name: "List deliveries failed - repository error",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetSubscriptionByID", ctx, testSubscriptionID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webhookService := NewWebhookService(tc.setupMockWebhookRepo(ctx), nil)
+
+			res, err := webhookService.ListDeliveries(ctx, testSubscriptionID)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ListSubscriptions retrieves all webhook subscriptions, without their secrets.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - []*model.WebhookSubscription: The subscriptions, oldest first.
+//   - error: An error if the retrieval fails, otherwise nil.
+func (svc *webhookService) ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ListSubscriptions")
+	defer s.End()
+
+	return svc.webhookRepo.ListSubscriptions(ctx)
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+func TestService_ListSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	subscriptions := []*model.WebhookSubscription{{Base: model.Base{ID: testSubscriptionID}, URL: testURL}}
+
+	testCases := []struct {
+		name string
+
+		setupMockWebhookRepo func(ctx context.Context) *mockWebhookRepo.Repository
+
+		expectedOutput []*model.WebhookSubscription
+		expectedError  error
+	}{
+		{
+			name: "List subscriptions successfully",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListSubscriptions", ctx).Return(subscriptions, nil)
+				return repoMock
+			},
+
+			expectedOutput: subscriptions,
+		},
+		{
+			name: "List subscriptions failed - repository error",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("ListSubscriptions", ctx).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webhookService := NewWebhookService(tc.setupMockWebhookRepo(ctx), nil)
+
+			res, err := webhookService.ListSubscriptions(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedOutput, res)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// ReplayDelivery queues a delivery again, whatever its status, to be attempted right away.
+// The attempts start over, so a failed delivery gets the full number of retries again.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - subscriptionID: The ID of the subscription.
+//   - deliveryID: The ID of the delivery.
+//
+// Returns:
+//   - *model.WebhookDelivery: The queued delivery.
+//   - error: ErrDeliveryNotFound if the subscription has no such delivery, otherwise any storage error.
+func (svc *webhookService) ReplayDelivery(ctx context.Context, subscriptionID, deliveryID string) (*model.WebhookDelivery, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Service_ReplayDelivery")
+	defer s.End()
+
+	delivery, err := svc.webhookRepo.GetDeliveryByID(ctx, subscriptionID, deliveryID)
+	if errors.Is(err, dbutils.ErrRecordNotFoundType) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = model.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.LastStatusCode = 0
+	delivery.LastError = ""
+	delivery.NextAttemptAt = time.Now()
+	delivery.DeliveredAt = nil
+
+	err = svc.webhookRepo.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/dbutils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	mockWebhookRepo "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook/mocks"
+)
+
+func TestService_ReplayDelivery(t *testing.T) {
+	t.Parallel()
+
+	failedDelivery := func() *model.WebhookDelivery {
+		return &model.WebhookDelivery{
+			Base:           model.Base{ID: testDeliveryID},
+			SubscriptionID: testSubscriptionID,
+			Status:         model.WebhookDeliveryFailed,
+			Attempts:       8,
+			LastStatusCode: 500,
+			LastError:      "unexpected status code 500",
+			NextAttemptAt:  time.Now().Add(-time.Hour),
+		}
+	}
+	isReset := func(delivery *model.WebhookDelivery) bool {
+		return delivery.ID == testDeliveryID &&
+			delivery.Status == model.WebhookDeliveryPending &&
+			delivery.Attempts == 0 &&
+			delivery.LastStatusCode == 0 &&
+			delivery.LastError == "" &&
+			delivery.DeliveredAt == nil &&
+			time.Since(delivery.NextAttemptAt) < time.Minute
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockWebhookRepo func(ctx context.Context) *mockWebhookRepo.Repository
+
+		expectedError error
+	}{
+		{
+			name: "Replay delivery successfully",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetDeliveryByID", ctx, testSubscriptionID, testDeliveryID).Return(failedDelivery(), nil)
+				repoMock.On("UpdateDelivery", ctx, mock.MatchedBy(isReset)).Return(nil)
+				return repoMock
+			},
+		},
+		{
+			name: "Replay delivery failed - not found",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetDeliveryByID", ctx, testSubscriptionID, testDeliveryID).Return(nil, dbutils.ErrRecordNotFoundType)
+				return repoMock
+			},
+
+			expectedError: ErrDeliveryNotFound,
+		},
+		{
+			name: "Replay delivery failed - repository error",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetDeliveryByID", ctx, testSubscriptionID, testDeliveryID).Return(nil, assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+		{
+			name: "Replay delivery failed - update error",
+
+			setupMockWebhookRepo: func(ctx context.Context) *mockWebhookRepo.Repository {
+				repoMock := mockWebhookRepo.NewRepository(t)
+				repoMock.On("GetDeliveryByID", ctx, testSubscriptionID, testDeliveryID).Return(failedDelivery(), nil)
+				repoMock.On("UpdateDelivery", ctx, mock.Anything).Return(assert.AnError)
+				return repoMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			webhookService := NewWebhookService(tc.setupMockWebhookRepo(ctx), nil)
+
+			res, err := webhookService.ReplayDelivery(ctx, testSubscriptionID, testDeliveryID)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.True(t, isReset(res))
+		})
+	}
+}
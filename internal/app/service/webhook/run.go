@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Run dispatches and delivers the events until the context is cancelled.
+// It goes on right away while there is work, and otherwise waits for the poll interval.
+//
+// Parameters:
+//   - ctx: The context stopping the worker when cancelled.
+//   - worker: The webhook delivery worker.
+//   - pollInterval: How long to wait when there is nothing to do.
+func Run(ctx context.Context, worker Worker, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		dispatched, dispatchErr := worker.DispatchEvents(ctx)
+		if dispatchErr != nil && ctx.Err() == nil {
+			log.Error().
+				Str("operation", "Webhook_Run").
+				Err(dispatchErr).
+				Msg("failed to dispatch the webhook events")
+		}
+
+		delivered, deliverErr := worker.DeliverPending(ctx)
+		if deliverErr != nil && ctx.Err() == nil {
+			log.Error().
+				Str("operation", "Webhook_Run").
+				Err(deliverErr).
+				Msg("failed to deliver the webhook events")
+		}
+
+		if dispatchErr == nil && deliverErr == nil && dispatched+delivered > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubWorker replays scripted results, one per round, and cancels the run after the last one.
+type stubWorker struct {
+	dispatched []int
+	delivered  []int
+	errs       []error
+	round      int
+	cancel     context.CancelFunc
+}
+
+func (w *stubWorker) DispatchEvents(ctx context.Context) (int, error) {
+	return w.dispatched[w.round], w.errs[w.round]
+}
+
+func (w *stubWorker) DeliverPending(ctx context.Context) (int, error) {
+	delivered, err := w.delivered[w.round], w.errs[w.round]
+	w.round++
+	if w.round == len(w.delivered) {
+		w.cancel()
+	}
+	return delivered, err
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		dispatched []int
+		delivered  []int
+		errs       []error
+	}{
+		{
+			name:       "Go on right away while there is work",
+			dispatched: []int{2, 0},
+			delivered:  []int{2, 0},
+			errs:       []error{nil, nil},
+		},
+		{
+			name:       "Wait for the next poll after an error",
+			dispatched: []int{0, 0},
+			delivered:  []int{1, 0},
+			errs:       []error{assert.AnError, nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			worker := &stubWorker{dispatched: tc.dispatched, delivered: tc.delivered, errs: tc.errs, cancel: cancel}
+			done := make(chan struct{})
+			go func() {
+				Run(ctx, worker, time.Millisecond)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				assert.Equal(t, len(tc.delivered), worker.round)
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "worker did not stop after the context was cancelled")
+			}
+		})
+	}
+}
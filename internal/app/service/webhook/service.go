@@ -0,0 +1,120 @@
+// Package webhook delivers user lifecycle events to partner endpoints over HTTP.
+// Subscriptions are managed through the admin API. The worker fans the events read off the
+// event stream out into one delivery per matching subscription, and posts each delivery signed
+// with the secret of its subscription, retrying with an exponential backoff until it is accepted.
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	outboxRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/outbox"
+	webhookRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook"
+)
+
+const (
+	// SecretPrefix marks webhook signing secrets so they can be spotted by secret scanners.
+	SecretPrefix = "whsec_"
+
+	// DeliveryLogLimit is how many of the latest deliveries of a subscription are returned.
+	DeliveryLogLimit = 50
+
+	secretLength = 32
+)
+
+var supportedEventTypes = map[string]bool{
+	outboxRepository.EventUserCreated: true,
+	outboxRepository.EventUserUpdated: true,
+}
+
+var (
+	ErrInvalidURL           = errors.New("webhook url must be an absolute http or https url")
+	ErrUnsupportedEventType = errors.New("unsupported webhook event type")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
+
+// CreatedSubscription is a newly created webhook subscription along with its signing secret.
+type CreatedSubscription struct {
+	*model.WebhookSubscription
+
+	// Secret signs the deliveries; it cannot be retrieved again.
+	Secret string `json:"secret"`
+}
+
+// Service represents the interface for managing webhook subscriptions.
+//
+//go:generate mockery --name=Service --filename=webhook_service.go --output=./mocks
+type Service interface {
+	// CreateSubscription subscribes an endpoint to event types, generating its signing secret.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - url: The endpoint the events are posted to.
+	//   - eventTypes: The event types delivered to the endpoint.
+	//
+	// Returns:
+	//   - *CreatedSubscription: The stored subscription along with its secret.
+	//   - error: ErrInvalidURL or ErrUnsupportedEventType for invalid input, otherwise any storage error.
+	CreateSubscription(ctx context.Context, url string, eventTypes []string) (*CreatedSubscription, error)
+
+	// ListSubscriptions retrieves all webhook subscriptions, without their secrets.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - []*model.WebhookSubscription: The subscriptions, oldest first.
+	//   - error: An error if the retrieval fails, otherwise nil.
+	ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+
+	// DeleteSubscription deletes a webhook subscription, dropping its pending deliveries.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//
+	// Returns:
+	//   - error: ErrSubscriptionNotFound if there is no such subscription, otherwise any deletion error.
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	// ListDeliveries retrieves the delivery log of a subscription.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//
+	// Returns:
+	//   - []*model.WebhookDelivery: The latest DeliveryLogLimit deliveries, newest first.
+	//   - error: ErrSubscriptionNotFound if there is no such subscription, otherwise any retrieval error.
+	ListDeliveries(ctx context.Context, subscriptionID string) ([]*model.WebhookDelivery, error)
+
+	// ReplayDelivery queues a delivery again, whatever its status, to be attempted right away.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - subscriptionID: The ID of the subscription.
+	//   - deliveryID: The ID of the delivery.
+	//
+	// Returns:
+	//   - *model.WebhookDelivery: The queued delivery.
+	//   - error: ErrDeliveryNotFound if the subscription has no such delivery, otherwise any storage error.
+	ReplayDelivery(ctx context.Context, subscriptionID, deliveryID string) (*model.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	webhookRepo webhookRepository.Repository
+	codeGen     utils.CodeGenerator
+}
+
+// NewWebhookService creates a new instance of the webhook subscription service.
+//
+// Parameters:
+//   - webhookRepo: The repository storing the subscriptions and deliveries.
+//   - codeGen: The random code generator used for the signing secrets.
+//
+// Returns:
+//   - Service: A new webhook subscription service instance.
+func NewWebhookService(webhookRepo webhookRepository.Repository, codeGen utils.CodeGenerator) Service {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		codeGen:     codeGen,
+	}
+}
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers of a webhook delivery.
+const (
+	HeaderEventID   = "X-Webhook-ID"
+	HeaderEventType = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign computes the signature of a delivery, sent in the X-Webhook-Signature header.
+// It is the hex-encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret,
+// so receivers can both authenticate the request and reject old ones replayed by a third party.
+//
+// Parameters:
+//   - secret: The signing secret of the subscription.
+//   - timestamp: The Unix time sent in the X-Webhook-Timestamp header.
+//   - body: The request body.
+//
+// Returns:
+//   - string: The signature, prefixed with "sha256=".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputSecret    string
+		inputTimestamp int64
+		inputBody      []byte
+
+		expected string
+	}{
+		{
+			name:           "Sign the timestamp and body",
+			inputSecret:    "whsec_test",
+			inputTimestamp: 1672531200,
+			inputBody:      []byte(`{"id":"c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71"}`),
+
+			expected: "sha256=11d930790340840752d0bc56c294251ac45df676fe1eb7c6b0cfe8180676c3a9",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, Sign(tc.inputSecret, tc.inputTimestamp, tc.inputBody))
+			assert.NotEqual(t, tc.expected, Sign(tc.inputSecret, tc.inputTimestamp+1, tc.inputBody))
+			assert.NotEqual(t, tc.expected, Sign("whsec_other", tc.inputTimestamp, tc.inputBody))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	webhookRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook"
+)
+
+// Config holds the delivery worker settings, read from WEBHOOK_* environment variables.
+type Config struct {
+	Stream          string        `envconfig:"STREAM" default:"user-events"`
+	Group           string        `envconfig:"GROUP" default:"webhooks"`
+	Consumer        string        `envconfig:"CONSUMER" default:"webhook-worker"`
+	BatchSize       int           `envconfig:"BATCH_SIZE" default:"100"`
+	MaxAttempts     int           `envconfig:"MAX_ATTEMPTS" default:"8"`
+	RetryBackoff    time.Duration `envconfig:"RETRY_BACKOFF" default:"30s"`
+	MaxRetryBackoff time.Duration `envconfig:"MAX_RETRY_BACKOFF" default:"1h"`
+	Timeout         time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	PollInterval    time.Duration `envconfig:"POLL_INTERVAL" default:"1s"`
+}
+
+// NewConfig loads the delivery worker configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("WEBHOOK", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Worker represents the interface for delivering events to webhook subscriptions.
+//
+//go:generate mockery --name=Worker --filename=webhook_worker.go --output=./mocks
+type Worker interface {
+	// DispatchEvents reads the next events off the event stream and queues a delivery
+	// of each to every subscription of its type.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - int: The number of events read.
+	//   - error: An error if the events cannot be read, queued or acknowledged, otherwise nil.
+	DispatchEvents(ctx context.Context) (int, error)
+
+	// DeliverPending attempts the deliveries that are due, recording the outcome of each.
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - int: The number of deliveries attempted.
+	//   - error: An error if the deliveries cannot be read or updated, otherwise nil.
+	DeliverPending(ctx context.Context) (int, error)
+}
+
+type webhookWorker struct {
+	webhookRepo webhookRepository.Repository
+	httpClient  *http.Client
+	cfg         *Config
+}
+
+// NewWebhookWorker creates a new instance of the webhook delivery worker.
+//
+// Parameters:
+//   - webhookRepo: The repository storing the subscriptions and deliveries, and reading the events.
+//   - httpClient: The client posting the deliveries, its timeout bounding each attempt.
+//   - cfg: The worker configuration.
+//
+// Returns:
+//   - Worker: A new webhook delivery worker instance.
+func NewWebhookWorker(webhookRepo webhookRepository.Repository, httpClient *http.Client, cfg *Config) Worker {
+	return &webhookWorker{
+		webhookRepo: webhookRepo,
+		httpClient:  httpClient,
+		cfg:         cfg,
+	}
+}
@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Setenv("WEBHOOK_CONSUMER", "webhook-worker-2")
+	t.Setenv("WEBHOOK_TIMEOUT", "3s")
+
+	cfg, err := NewConfig()
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		Stream:          "user-events",
+		Group:           "webhooks",
+		Consumer:        "webhook-worker-2",
+		BatchSize:       100,
+		MaxAttempts:     8,
+		RetryBackoff:    30 * time.Second,
+		MaxRetryBackoff: time.Hour,
+		Timeout:         3 * time.Second,
+		PollInterval:    time.Second,
+	}, cfg)
+}
+
+func TestNewConfig_InvalidValue(t *testing.T) {
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "forever")
+
+	cfg, err := NewConfig()
+
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+}
@@ -0,0 +1,196 @@
+// Package botprotection challenges registrations and logins that look scripted.
+// A guard watches risk signals, the failed logins of a username or an address and the registrations
+// coming from one address, and once a signal crosses its threshold it only lets requests through with
+// a solved challenge. Challenges are verified by a CAPTCHA provider, hCaptcha or Cloudflare Turnstile,
+// or are proofs of work issued and verified by the service itself, which needs no third party.
+package botprotection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/bookmark-libs/ratelimit"
+)
+
+const (
+	// ProviderHCaptcha verifies hCaptcha responses.
+	ProviderHCaptcha = "hcaptcha"
+
+	// ProviderTurnstile verifies Cloudflare Turnstile responses.
+	ProviderTurnstile = "turnstile"
+
+	// ProviderProofOfWork issues and verifies proof-of-work challenges.
+	ProviderProofOfWork = "pow"
+)
+
+const (
+	// ActionRegister is the registration of a new user.
+	ActionRegister = "register"
+
+	// ActionLogin is a password login.
+	ActionLogin = "login"
+)
+
+const (
+	hCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+var (
+	ErrChallengeRequired   = errors.New("challenge required")
+	ErrChallengeFailed     = errors.New("challenge failed")
+	ErrUnsupportedProvider = errors.New("unsupported bot protection provider")
+	ErrMissingSecret       = errors.New("bot protection secret is required")
+)
+
+// Challenge tells a client how to prove it is not a bot.
+type Challenge struct {
+	// Provider is the provider verifying the solution.
+	Provider string `json:"provider" example:"pow"`
+	// Challenge is the proof-of-work challenge to solve; CAPTCHA widgets issue their own.
+	Challenge string `json:"challenge,omitempty" example:"1767225600.9f86d081884c7d65.4e0c4b7e3a"`
+	// Difficulty is the number of leading zero bits the SHA-256 hash of "<challenge>:<counter>" must start with.
+	Difficulty int `json:"difficulty,omitempty" example:"20"`
+}
+
+// Client is the origin of a request to guard.
+type Client struct {
+	// IP is the address of the client.
+	IP string
+	// Username is the username the request is about, if any.
+	Username string
+	// Token is the solution of a challenge sent with the request, if any.
+	Token string
+}
+
+// Verifier issues and verifies challenges.
+//
+//go:generate mockery --name=Verifier --filename=verifier.go --output=./mocks
+type Verifier interface {
+	// Issue returns a new challenge. CAPTCHA verifiers only name the provider, whose widget issues the challenge.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//
+	// Returns:
+	//   - *Challenge: The challenge to solve.
+	//   - error: An error if the challenge cannot be issued, otherwise nil.
+	Issue(ctx context.Context) (*Challenge, error)
+
+	// Verify checks the solution of a challenge.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - token: The solution sent by the client.
+	//   - remoteIP: The address of the client.
+	//
+	// Returns:
+	//   - error: ErrChallengeFailed if the solution is rejected, another error if it cannot be verified, otherwise nil.
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// Guard decides whether requests need a solved challenge.
+//
+//go:generate mockery --name=Guard --filename=guard.go --output=./mocks
+type Guard interface {
+	// Check lets a request through if its risk signals are low or it carries a solved challenge.
+	// Registrations are counted by address as they are checked. A solution that cannot be verified, the
+	// provider being unreachable, is logged and lets the request through.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - action: ActionRegister or ActionLogin.
+	//   - client: The origin of the request.
+	//
+	// Returns:
+	//   - *Challenge: The challenge to solve when the request is rejected, otherwise nil.
+	//   - error: ErrChallengeRequired if a challenge is needed and none was sent, ErrChallengeFailed if the
+	//     solution was rejected, otherwise nil.
+	Check(ctx context.Context, action string, client Client) (*Challenge, error)
+
+	// RecordFailure counts a failed attempt of a client, raising the risk signals of its next attempts.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - action: ActionRegister or ActionLogin.
+	//   - client: The origin of the request.
+	RecordFailure(ctx context.Context, action string, client Client)
+}
+
+// Config holds the bot protection settings, read from BOT_PROTECTION_* environment variables.
+// Bot protection is disabled when no provider is set.
+type Config struct {
+	Provider     string        `envconfig:"PROVIDER" default:""`
+	Secret       string        `envconfig:"SECRET" default:""`
+	VerifyURL    string        `envconfig:"VERIFY_URL" default:""`
+	Timeout      time.Duration `envconfig:"TIMEOUT" default:"5s"`
+	Difficulty   int           `envconfig:"POW_DIFFICULTY" default:"20"`
+	ChallengeTTL time.Duration `envconfig:"POW_TTL" default:"5m"`
+
+	FailedLoginThreshold  int           `envconfig:"FAILED_LOGIN_THRESHOLD" default:"3"`
+	RegistrationThreshold int           `envconfig:"REGISTRATION_THRESHOLD" default:"3"`
+	Window                time.Duration `envconfig:"WINDOW" default:"15m"`
+}
+
+// NewConfig loads the bot protection configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("BOT_PROTECTION", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// New builds the guard selected by the configuration, keeping its counters in Redis.
+//
+// Parameters:
+//   - cfg: The bot protection configuration
+//   - redisClient: The Redis client keeping the risk signals and the solved proof-of-work challenges
+//
+// Returns:
+//   - Guard: The guard, or a disabled guard when no provider is configured
+//   - error: ErrUnsupportedProvider or ErrMissingSecret if the configuration is invalid
+func New(cfg *Config, redisClient *redis.Client) (Guard, error) {
+	if cfg.Provider == "" {
+		return NewDisabledGuard(), nil
+	}
+	if cfg.Secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	var verifier Verifier
+	switch cfg.Provider {
+	case ProviderHCaptcha:
+		verifier = NewCaptchaVerifier(ProviderHCaptcha, orDefault(cfg.VerifyURL, hCaptchaVerifyURL), cfg.Secret, cfg.Timeout)
+	case ProviderTurnstile:
+		verifier = NewCaptchaVerifier(ProviderTurnstile, orDefault(cfg.VerifyURL, turnstileVerifyURL), cfg.Secret, cfg.Timeout)
+	case ProviderProofOfWork:
+		verifier = NewProofOfWork([]byte(cfg.Secret), cfg.Difficulty, cfg.ChallengeTTL, redisClient)
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+
+	return NewGuard(verifier, ratelimit.NewRedisRepo(redisClient), Thresholds{
+		FailedLogins:  cfg.FailedLoginThreshold,
+		Registrations: cfg.RegistrationThreshold,
+		Window:        cfg.Window,
+	}), nil
+}
+
+// orDefault returns a value, or a default when it is empty.
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
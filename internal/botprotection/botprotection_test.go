@@ -0,0 +1,78 @@
+package botprotection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputConfig *Config
+
+		expectedGuard Guard
+		expectedError error
+	}{
+		{
+			name: "No provider disables bot protection",
+
+			inputConfig: &Config{},
+
+			expectedGuard: NewDisabledGuard(),
+		},
+		{
+			name: "hCaptcha",
+
+			inputConfig: &Config{Provider: ProviderHCaptcha, Secret: "site-secret", Timeout: time.Second},
+		},
+		{
+			name: "Turnstile",
+
+			inputConfig: &Config{Provider: ProviderTurnstile, Secret: "site-secret", Timeout: time.Second},
+		},
+		{
+			name: "Proof of work",
+
+			inputConfig: &Config{Provider: ProviderProofOfWork, Secret: "signing-key", Difficulty: 20, ChallengeTTL: time.Minute},
+		},
+		{
+			name: "Missing secret",
+
+			inputConfig: &Config{Provider: ProviderTurnstile},
+
+			expectedError: ErrMissingSecret,
+		},
+		{
+			name: "Unsupported provider",
+
+			inputConfig: &Config{Provider: "recaptcha", Secret: "site-secret"},
+
+			expectedError: ErrUnsupportedProvider,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testGuard, err := New(tc.inputConfig, redisPkg.InitMockRedis(t))
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				assert.Nil(t, testGuard)
+				return
+			}
+
+			if tc.expectedGuard != nil {
+				assert.Equal(t, tc.expectedGuard, testGuard)
+				return
+			}
+			assert.IsType(t, &guard{}, testGuard)
+		})
+	}
+}
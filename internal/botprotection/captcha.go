@@ -0,0 +1,95 @@
+package botprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// captchaVerifier verifies CAPTCHA responses with the siteverify API of a provider.
+type captchaVerifier struct {
+	provider  string
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+// NewCaptchaVerifier creates a verifier checking CAPTCHA responses with a siteverify API, as offered by hCaptcha
+// and Cloudflare Turnstile: the secret, the response and the address of the client are posted as a form, and the
+// API answers a JSON object whose "success" field tells whether the response is valid.
+//
+// Parameters:
+//   - provider: The name of the provider, told to clients
+//   - verifyURL: The URL of the siteverify API
+//   - secret: The secret key of the site
+//   - timeout: The timeout of a verification request
+//
+// Returns:
+//   - Verifier: A new CAPTCHA verifier instance
+func NewCaptchaVerifier(provider, verifyURL, secret string, timeout time.Duration) Verifier {
+	return &captchaVerifier{
+		provider:  provider,
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Issue names the provider, whose widget issues the challenge.
+func (v *captchaVerifier) Issue(ctx context.Context) (*Challenge, error) {
+	return &Challenge{Provider: v.provider}, nil
+}
+
+// Verify posts a CAPTCHA response to the siteverify API.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - token: The CAPTCHA response.
+//   - remoteIP: The address of the client.
+//
+// Returns:
+//   - error: ErrChallengeFailed if the provider rejects the response, an error if the request fails or the API
+//     does not answer 200, otherwise nil
+func (v *captchaVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	s := newrelic.FromContext(ctx).StartSegment("BotProtection_VerifyCaptcha")
+	defer s.End()
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+		"remoteip": {remoteIP},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s siteverify API answered %d", v.provider, resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		return ErrChallengeFailed
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package botprotection
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptchaVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputToken string
+
+		expectedRequestError bool
+		expectedError        error
+	}{
+		{
+			name: "Accepted response",
+
+			inputToken: "valid-response",
+		},
+		{
+			name: "Rejected response",
+
+			inputToken: "invalid-response",
+
+			expectedError: ErrChallengeFailed,
+		},
+		{
+			name: "Provider error",
+
+			inputToken: "server-error",
+
+			expectedRequestError: true,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "site-secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "192.0.2.1", r.PostForm.Get("remoteip"))
+
+		switch r.PostForm.Get("response") {
+		case "valid-response":
+			w.Write([]byte(`{"success":true}`))
+		case "server-error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testVerifier := NewCaptchaVerifier(ProviderTurnstile, server.URL, "site-secret", time.Second)
+
+			err := testVerifier.Verify(t.Context(), tc.inputToken, "192.0.2.1")
+			if tc.expectedRequestError {
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrChallengeFailed)
+				return
+			}
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCaptchaVerifier_Issue(t *testing.T) {
+	t.Parallel()
+
+	challenge, err := NewCaptchaVerifier(ProviderHCaptcha, hCaptchaVerifyURL, "site-secret", time.Second).Issue(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, &Challenge{Provider: ProviderHCaptcha}, challenge)
+}
@@ -0,0 +1,184 @@
+package botprotection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	"github.com/vukieuhaihoa/bookmark-libs/ratelimit"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+// SignalKeyFormat formats the keys of the risk signal counters from the action, what is counted ("ip" or
+// "username") and the counted value.
+const SignalKeyFormat = "bot_protection:%s:%s:%s"
+
+// Thresholds are the risk signal counts from which requests need a solved challenge.
+// A threshold of 0 challenges every request of its action.
+type Thresholds struct {
+	// FailedLogins is the number of failed logins of a username, or from an address, within the window.
+	FailedLogins int
+	// Registrations is the number of registrations from an address within the window.
+	Registrations int
+	// Window is how long the signals are counted, from their first occurrence.
+	Window time.Duration
+}
+
+// guard challenges requests once their risk signals cross the thresholds.
+type guard struct {
+	verifier   Verifier
+	counters   ratelimit.Repository
+	thresholds Thresholds
+}
+
+// NewGuard creates a guard.
+//
+// Parameters:
+//   - verifier: The verifier issuing and verifying the challenges
+//   - counters: The repository keeping the risk signal counters
+//   - thresholds: The counts from which requests are challenged
+//
+// Returns:
+//   - Guard: A new guard instance
+func NewGuard(verifier Verifier, counters ratelimit.Repository, thresholds Thresholds) Guard {
+	return &guard{
+		verifier:   verifier,
+		counters:   counters,
+		thresholds: thresholds,
+	}
+}
+
+// Check lets a request through if its risk signals are low or it carries a solved challenge.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - action: ActionRegister or ActionLogin.
+//   - client: The origin of the request.
+//
+// Returns:
+//   - *Challenge: The challenge to solve when the request is rejected, otherwise nil.
+//   - error: ErrChallengeRequired, ErrChallengeFailed, or nil.
+func (g *guard) Check(ctx context.Context, action string, client Client) (*Challenge, error) {
+	s := newrelic.FromContext(ctx).StartSegment("BotProtection_Check")
+	defer s.End()
+
+	if !g.risky(ctx, action, client) {
+		return nil, nil
+	}
+
+	if client.Token != "" {
+		err := g.verifier.Verify(ctx, client.Token, client.IP)
+		switch {
+		case err == nil:
+			return nil, nil
+		case !errors.Is(err, ErrChallengeFailed):
+			log.Warn().
+				Str("operation", "BotProtection_Check").
+				Err(err).
+				Msg("challenge solution cannot be verified, request let through")
+			return nil, nil
+		}
+	}
+
+	challenge, err := g.verifier.Issue(ctx)
+	if err != nil {
+		log.Warn().
+			Str("operation", "BotProtection_Check").
+			Err(err).
+			Msg("challenge cannot be issued, request let through")
+		return nil, nil
+	}
+
+	if client.Token == "" {
+		return challenge, ErrChallengeRequired
+	}
+
+	return challenge, ErrChallengeFailed
+}
+
+// RecordFailure counts a failed login of a username and of an address.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - action: ActionRegister or ActionLogin; only logins are counted.
+//   - client: The origin of the request.
+func (g *guard) RecordFailure(ctx context.Context, action string, client Client) {
+	if action != ActionLogin {
+		return
+	}
+
+	g.increase(ctx, signalKey(action, "ip", client.IP))
+	if client.Username != "" {
+		g.increase(ctx, signalKey(action, "username", normalize.Username(client.Username)))
+	}
+}
+
+// risky reports whether the risk signals of a request cross the thresholds, counting registrations as it goes.
+func (g *guard) risky(ctx context.Context, action string, client Client) bool {
+	switch action {
+	case ActionLogin:
+		if g.count(ctx, signalKey(action, "ip", client.IP)) >= g.thresholds.FailedLogins {
+			return true
+		}
+		return client.Username != "" &&
+			g.count(ctx, signalKey(action, "username", normalize.Username(client.Username))) >= g.thresholds.FailedLogins
+	case ActionRegister:
+		key := signalKey(action, "ip", client.IP)
+		count := g.count(ctx, key)
+		g.increase(ctx, key)
+		return count >= g.thresholds.Registrations
+	default:
+		return false
+	}
+}
+
+// count returns the value of a counter, 0 if it cannot be read.
+func (g *guard) count(ctx context.Context, key string) int {
+	count, err := g.counters.GetCurrentRateLimit(ctx, key)
+	if err != nil {
+		log.Warn().
+			Str("operation", "BotProtection_Count").
+			Err(err).
+			Msg("risk signal cannot be read")
+		return 0
+	}
+
+	return count
+}
+
+// increase increments a counter, starting its window on the first increment.
+func (g *guard) increase(ctx context.Context, key string) {
+	if err := g.counters.IncreaseRateLimit(ctx, key, g.thresholds.Window); err != nil {
+		log.Warn().
+			Str("operation", "BotProtection_Increase").
+			Err(err).
+			Msg("risk signal cannot be recorded")
+	}
+}
+
+// signalKey returns the key of a risk signal counter.
+func signalKey(action, kind, value string) string {
+	return fmt.Sprintf(SignalKeyFormat, action, kind, value)
+}
+
+// disabledGuard lets every request through.
+type disabledGuard struct{}
+
+// NewDisabledGuard creates a guard that never challenges requests.
+//
+// Returns:
+//   - Guard: A guard letting every request through
+func NewDisabledGuard() Guard {
+	return &disabledGuard{}
+}
+
+// Check lets the request through.
+func (d *disabledGuard) Check(ctx context.Context, action string, client Client) (*Challenge, error) {
+	return nil, nil
+}
+
+// RecordFailure does nothing.
+func (d *disabledGuard) RecordFailure(ctx context.Context, action string, client Client) {}
@@ -0,0 +1,224 @@
+package botprotection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/ratelimit"
+	mockRateLimit "github.com/vukieuhaihoa/bookmark-libs/ratelimit/mocks"
+)
+
+// fakeVerifier accepts the solution "solved", and fails to verify "unreachable" as an unreachable provider would.
+type fakeVerifier struct{}
+
+func (f *fakeVerifier) Issue(ctx context.Context) (*Challenge, error) {
+	return &Challenge{Provider: "fake"}, nil
+}
+
+func (f *fakeVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	switch token {
+	case "solved":
+		return nil
+	case "unreachable":
+		return assert.AnError
+	default:
+		return ErrChallengeFailed
+	}
+}
+
+var guardTestThresholds = Thresholds{FailedLogins: 2, Registrations: 1, Window: time.Minute}
+
+func TestGuard_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context, redisClient *redis.Client)
+
+		inputAction string
+		inputClient Client
+
+		expectedChallenge *Challenge
+		expectedError     error
+	}{
+		{
+			name: "Login without failures",
+
+			inputAction: ActionLogin,
+			inputClient: Client{IP: "192.0.2.1", Username: "alice"},
+		},
+		{
+			name: "Login after failures of the username",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, signalKey(ActionLogin, "username", "alice"), 2, time.Minute)
+			},
+
+			inputAction: ActionLogin,
+			inputClient: Client{IP: "192.0.2.1", Username: " Alice "},
+
+			expectedChallenge: &Challenge{Provider: "fake"},
+			expectedError:     ErrChallengeRequired,
+		},
+		{
+			name: "Login after failures from the address",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, signalKey(ActionLogin, "ip", "192.0.2.1"), 2, time.Minute)
+			},
+
+			inputAction: ActionLogin,
+			inputClient: Client{IP: "192.0.2.1", Username: "bob"},
+
+			expectedChallenge: &Challenge{Provider: "fake"},
+			expectedError:     ErrChallengeRequired,
+		},
+		{
+			name: "Solved challenge",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, signalKey(ActionLogin, "ip", "192.0.2.1"), 2, time.Minute)
+			},
+
+			inputAction: ActionLogin,
+			inputClient: Client{IP: "192.0.2.1", Username: "bob", Token: "solved"},
+		},
+		{
+			name: "Wrong solution",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, signalKey(ActionLogin, "ip", "192.0.2.1"), 2, time.Minute)
+			},
+
+			inputAction: ActionLogin,
+			inputClient: Client{IP: "192.0.2.1", Username: "bob", Token: "guessed"},
+
+			expectedChallenge: &Challenge{Provider: "fake"},
+			expectedError:     ErrChallengeFailed,
+		},
+		{
+			name: "Unverifiable solution lets the request through",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, signalKey(ActionLogin, "ip", "192.0.2.1"), 2, time.Minute)
+			},
+
+			inputAction: ActionLogin,
+			inputClient: Client{IP: "192.0.2.1", Username: "bob", Token: "unreachable"},
+		},
+		{
+			name: "First registration from the address",
+
+			inputAction: ActionRegister,
+			inputClient: Client{IP: "192.0.2.1", Username: "alice"},
+		},
+		{
+			name: "Registration velocity of the address",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, signalKey(ActionRegister, "ip", "192.0.2.1"), 1, time.Minute)
+			},
+
+			inputAction: ActionRegister,
+			inputClient: Client{IP: "192.0.2.1", Username: "alice"},
+
+			expectedChallenge: &Challenge{Provider: "fake"},
+			expectedError:     ErrChallengeRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := redisPkg.InitMockRedis(t)
+			if tc.setupRedis != nil {
+				tc.setupRedis(ctx, redisClient)
+			}
+
+			testGuard := NewGuard(&fakeVerifier{}, ratelimit.NewRedisRepo(redisClient), guardTestThresholds)
+
+			challenge, err := testGuard.Check(ctx, tc.inputAction, tc.inputClient)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedChallenge, challenge)
+		})
+	}
+}
+
+func TestGuard_Check_CountsRegistrations(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	redisClient := redisPkg.InitMockRedis(t)
+	testGuard := NewGuard(&fakeVerifier{}, ratelimit.NewRedisRepo(redisClient), guardTestThresholds)
+
+	client := Client{IP: "192.0.2.1", Username: "alice"}
+	_, err := testGuard.Check(ctx, ActionRegister, client)
+	assert.Nil(t, err)
+
+	_, err = testGuard.Check(ctx, ActionRegister, client)
+	assert.Equal(t, ErrChallengeRequired, err)
+
+	// Another address is not affected
+	_, err = testGuard.Check(ctx, ActionRegister, Client{IP: "198.51.100.7", Username: "bob"})
+	assert.Nil(t, err)
+}
+
+func TestGuard_RecordFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	redisClient := redisPkg.InitMockRedis(t)
+	testGuard := NewGuard(&fakeVerifier{}, ratelimit.NewRedisRepo(redisClient), guardTestThresholds)
+
+	client := Client{IP: "192.0.2.1", Username: "Alice"}
+	testGuard.RecordFailure(ctx, ActionLogin, client)
+	testGuard.RecordFailure(ctx, ActionLogin, client)
+	testGuard.RecordFailure(ctx, ActionRegister, client)
+
+	count, err := redisClient.Get(ctx, signalKey(ActionLogin, "ip", "192.0.2.1")).Int()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = redisClient.Get(ctx, signalKey(ActionLogin, "username", "alice")).Int()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.Equal(t, int64(0), redisClient.Exists(ctx, signalKey(ActionRegister, "ip", "192.0.2.1")).Val())
+
+	// The username is challenged from any address
+	_, err = testGuard.Check(ctx, ActionLogin, Client{IP: "198.51.100.7", Username: "ALICE"})
+	assert.Equal(t, ErrChallengeRequired, err)
+}
+
+func TestGuard_Check_CountersUnavailable(t *testing.T) {
+	t.Parallel()
+
+	repoMock := mockRateLimit.NewRepository(t)
+	repoMock.On("GetCurrentRateLimit", mock.Anything, mock.Anything).Return(-1, assert.AnError)
+	repoMock.On("IncreaseRateLimit", mock.Anything, mock.Anything, time.Minute).Return(assert.AnError)
+
+	testGuard := NewGuard(&fakeVerifier{}, repoMock, Thresholds{FailedLogins: 1, Registrations: 1, Window: time.Minute})
+
+	challenge, err := testGuard.Check(t.Context(), ActionRegister, Client{IP: "192.0.2.1"})
+	assert.Nil(t, err)
+	assert.Nil(t, challenge)
+}
+
+func TestDisabledGuard(t *testing.T) {
+	t.Parallel()
+
+	testGuard := NewDisabledGuard()
+	testGuard.RecordFailure(t.Context(), ActionLogin, Client{IP: "192.0.2.1"})
+
+	challenge, err := testGuard.Check(t.Context(), ActionLogin, Client{IP: "192.0.2.1"})
+	assert.Nil(t, err)
+	assert.Nil(t, challenge)
+}
@@ -0,0 +1,155 @@
+package botprotection
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/redis/go-redis/v9"
+)
+
+// SolvedChallengeKeyFormat formats the keys marking the proof-of-work challenges already solved.
+const SolvedChallengeKeyFormat = "bot_protection:pow:%s"
+
+// proofOfWork issues challenges signed by the service and verifies their solutions.
+// A challenge is "<expiry>.<nonce>.<signature>", the expiry being a Unix time and the signature the hex
+// HMAC-SHA256 of "<expiry>.<nonce>". A solution is "<challenge>:<counter>" such that the SHA-256 hash of the
+// solution starts with as many zero bits as the difficulty. Each challenge is accepted once.
+type proofOfWork struct {
+	secret      []byte
+	difficulty  int
+	ttl         time.Duration
+	redisClient *redis.Client
+	now         func() time.Time
+}
+
+// NewProofOfWork creates a verifier of proof-of-work challenges.
+//
+// Parameters:
+//   - secret: The key signing the challenges; all instances must share it
+//   - difficulty: The number of leading zero bits the hash of a solution must have, each bit doubling the work
+//   - ttl: How long a challenge can be solved
+//   - redisClient: The Redis client remembering the solved challenges
+//
+// Returns:
+//   - Verifier: A new proof-of-work verifier instance
+func NewProofOfWork(secret []byte, difficulty int, ttl time.Duration, redisClient *redis.Client) Verifier {
+	return &proofOfWork{
+		secret:      secret,
+		difficulty:  difficulty,
+		ttl:         ttl,
+		redisClient: redisClient,
+		now:         time.Now,
+	}
+}
+
+// Issue returns a new signed challenge.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//
+// Returns:
+//   - *Challenge: The challenge with its difficulty.
+//   - error: An error if no random nonce can be generated, otherwise nil.
+func (p *proofOfWork) Issue(ctx context.Context) (*Challenge, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	payload := strconv.FormatInt(p.now().Add(p.ttl).Unix(), 10) + "." + hex.EncodeToString(nonce)
+
+	return &Challenge{
+		Provider:   ProviderProofOfWork,
+		Challenge:  payload + "." + p.sign(payload),
+		Difficulty: p.difficulty,
+	}, nil
+}
+
+// Verify checks the solution of a challenge and marks the challenge as solved.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - token: The solution, "<challenge>:<counter>".
+//   - remoteIP: The address of the client, unused.
+//
+// Returns:
+//   - error: ErrChallengeFailed if the challenge is forged, expired or already solved, or the hash of the solution
+//     has too few leading zero bits; an error if the challenge cannot be marked as solved, otherwise nil
+func (p *proofOfWork) Verify(ctx context.Context, token, remoteIP string) error {
+	s := newrelic.FromContext(ctx).StartSegment("BotProtection_VerifyProofOfWork")
+	defer s.End()
+
+	challenge, _, ok := strings.Cut(token, ":")
+	if !ok {
+		return ErrChallengeFailed
+	}
+
+	expiry, ok := p.verifyChallenge(challenge)
+	if !ok || !p.now().Before(expiry) {
+		return ErrChallengeFailed
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	if leadingZeroBits(sum[:]) < p.difficulty {
+		return ErrChallengeFailed
+	}
+
+	solved, err := p.redisClient.SetNX(ctx, fmt.Sprintf(SolvedChallengeKeyFormat, challenge), 1, expiry.Sub(p.now())).Result()
+	if err != nil {
+		return err
+	}
+	if !solved {
+		return ErrChallengeFailed
+	}
+
+	return nil
+}
+
+// verifyChallenge checks the signature of a challenge and returns its expiry.
+func (p *proofOfWork) verifyChallenge(challenge string) (time.Time, bool) {
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(p.sign(payload)), []byte(parts[2])) {
+		return time.Time{}, false
+	}
+
+	expiry, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(expiry, 0), true
+}
+
+// sign returns the hex HMAC-SHA256 of a payload.
+func (p *proofOfWork) sign(payload string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// leadingZeroBits counts the leading zero bits of a hash.
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+
+	return count
+}
@@ -0,0 +1,158 @@
+package botprotection
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+)
+
+// solve finds a solution of a challenge by brute force.
+func solve(challenge string, difficulty int) string {
+	for counter := 0; ; counter++ {
+		token := challenge + ":" + strconv.Itoa(counter)
+		sum := sha256.Sum256([]byte(token))
+		if leadingZeroBits(sum[:]) >= difficulty {
+			return token
+		}
+	}
+}
+
+func TestProofOfWork_Issue(t *testing.T) {
+	t.Parallel()
+
+	testVerifier := NewProofOfWork([]byte("secret"), 8, 5*time.Minute, redisPkg.InitMockRedis(t))
+
+	challenge, err := testVerifier.Issue(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, ProviderProofOfWork, challenge.Provider)
+	assert.Equal(t, 8, challenge.Difficulty)
+	assert.Len(t, strings.Split(challenge.Challenge, "."), 3)
+
+	other, err := testVerifier.Issue(t.Context())
+	assert.Nil(t, err)
+	assert.NotEqual(t, challenge.Challenge, other.Challenge)
+}
+
+func TestProofOfWork_Verify(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupToken func(t *testing.T, testVerifier *proofOfWork) string
+
+		expectedError error
+	}{
+		{
+			name: "Solved challenge",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				challenge, err := testVerifier.Issue(t.Context())
+				assert.Nil(t, err)
+				return solve(challenge.Challenge, challenge.Difficulty)
+			},
+		},
+		{
+			name: "Insufficient work",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				challenge, err := testVerifier.Issue(t.Context())
+				assert.Nil(t, err)
+				for counter := 0; ; counter++ {
+					token := challenge.Challenge + ":" + strconv.Itoa(counter)
+					sum := sha256.Sum256([]byte(token))
+					if leadingZeroBits(sum[:]) < challenge.Difficulty {
+						return token
+					}
+				}
+			},
+
+			expectedError: ErrChallengeFailed,
+		},
+		{
+			name: "Forged challenge",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				challenge, err := testVerifier.Issue(t.Context())
+				assert.Nil(t, err)
+				parts := strings.Split(challenge.Challenge, ".")
+				forged := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + "." + parts[1] + "." + parts[2]
+				return solve(forged, challenge.Difficulty)
+			},
+
+			expectedError: ErrChallengeFailed,
+		},
+		{
+			name: "Challenge of another secret",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				otherVerifier := NewProofOfWork([]byte("other-secret"), 8, 5*time.Minute, testVerifier.redisClient)
+				challenge, err := otherVerifier.Issue(t.Context())
+				assert.Nil(t, err)
+				return solve(challenge.Challenge, challenge.Difficulty)
+			},
+
+			expectedError: ErrChallengeFailed,
+		},
+		{
+			name: "Expired challenge",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				challenge, err := testVerifier.Issue(t.Context())
+				assert.Nil(t, err)
+				testVerifier.now = func() time.Time { return time.Now().Add(10 * time.Minute) }
+				return solve(challenge.Challenge, challenge.Difficulty)
+			},
+
+			expectedError: ErrChallengeFailed,
+		},
+		{
+			name: "Already solved challenge",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				challenge, err := testVerifier.Issue(t.Context())
+				assert.Nil(t, err)
+				token := solve(challenge.Challenge, challenge.Difficulty)
+				assert.Nil(t, testVerifier.Verify(t.Context(), token, "192.0.2.1"))
+				return token
+			},
+
+			expectedError: ErrChallengeFailed,
+		},
+		{
+			name: "Malformed token",
+
+			setupToken: func(t *testing.T, testVerifier *proofOfWork) string {
+				return "not-a-solution"
+			},
+
+			expectedError: ErrChallengeFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testVerifier := NewProofOfWork([]byte("secret"), 8, 5*time.Minute, redisPkg.InitMockRedis(t)).(*proofOfWork)
+			token := tc.setupToken(t, testVerifier)
+
+			err := testVerifier.Verify(t.Context(), token, "192.0.2.1")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, leadingZeroBits([]byte{0x80, 0x00}))
+	assert.Equal(t, 3, leadingZeroBits([]byte{0x10, 0x00}))
+	assert.Equal(t, 12, leadingZeroBits([]byte{0x00, 0x08}))
+	assert.Equal(t, 16, leadingZeroBits([]byte{0x00, 0x00}))
+}
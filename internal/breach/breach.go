@@ -0,0 +1,123 @@
+// Package breach screens passwords against a corpus of passwords exposed in data breaches.
+// Lookups use the k-anonymity model of Have I Been Pwned: only the first five hex characters
+// of the SHA-1 hash of a password leave the checker, and the matching range of hash suffixes
+// is searched locally. Ranges come from a local HIBP-format file loaded at startup or from a
+// remote range API.
+package breach
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const (
+	// ActionReject rejects breached passwords.
+	ActionReject = "reject"
+
+	// ActionWarn accepts breached passwords and only logs them.
+	ActionWarn = "warn"
+)
+
+// PrefixLength is the number of hex characters of the SHA-1 hash sent to a range source.
+const PrefixLength = 5
+
+var (
+	ErrBreached          = errors.New("password has appeared in a data breach")
+	ErrUnsupportedAction = errors.New("unsupported breached password action")
+	ErrSourceConflict    = errors.New("only one of the range file and the range URL can be set")
+	ErrMalformedRange    = errors.New("malformed breached password range")
+)
+
+// Source returns the ranges of breached password hashes.
+//
+//go:generate mockery --name=Source --filename=source.go --output=./mocks
+type Source interface {
+	// Range returns the breached password hashes starting with a prefix.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - prefix: The first PrefixLength upper-case hex characters of a SHA-1 hash.
+	//
+	// Returns:
+	//   - map[string]int: The upper-case hex suffixes of the hashes in the range, with the number of times each
+	//     password was seen in breaches.
+	//   - error: An error if the range cannot be retrieved, otherwise nil.
+	Range(ctx context.Context, prefix string) (map[string]int, error)
+}
+
+// Checker screens passwords against breached passwords.
+//
+//go:generate mockery --name=Checker --filename=checker.go --output=./mocks
+type Checker interface {
+	// Check looks a password up in the breached passwords.
+	// A range that cannot be retrieved is logged and lets the password through, so an unreachable source never
+	// blocks registrations.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - password: The plain-text password to check.
+	//
+	// Returns:
+	//   - error: ErrBreached if the password was breached and breached passwords are rejected, otherwise nil.
+	Check(ctx context.Context, password string) error
+}
+
+// Config holds the breached password screening settings, read from BREACHED_PASSWORD_* environment variables.
+// Screening is disabled when neither a range file nor a range URL is set.
+type Config struct {
+	RangeFile string        `envconfig:"RANGE_FILE" default:""`
+	RangeURL  string        `envconfig:"RANGE_URL" default:""`
+	Action    string        `envconfig:"ACTION" default:"reject"`
+	Timeout   time.Duration `envconfig:"TIMEOUT" default:"2s"`
+}
+
+// NewConfig loads the breached password screening configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("BREACHED_PASSWORD", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// New builds the checker selected by the configuration.
+// The range file is read once, here.
+//
+// Parameters:
+//   - cfg: The breached password screening configuration
+//
+// Returns:
+//   - Checker: The checker, or a disabled checker when no source is configured
+//   - error: ErrUnsupportedAction, ErrSourceConflict, or an error if the range file cannot be loaded
+func New(cfg *Config) (Checker, error) {
+	if cfg.Action != ActionReject && cfg.Action != ActionWarn {
+		return nil, ErrUnsupportedAction
+	}
+
+	var source Source
+	switch {
+	case cfg.RangeFile != "" && cfg.RangeURL != "":
+		return nil, ErrSourceConflict
+	case cfg.RangeFile != "":
+		fileSource, err := LoadFileSource(cfg.RangeFile)
+		if err != nil {
+			return nil, err
+		}
+		source = fileSource
+	case cfg.RangeURL != "":
+		source = NewAPISource(cfg.RangeURL, cfg.Timeout)
+	default:
+		return NewDisabledChecker(), nil
+	}
+
+	return NewChecker(source, cfg.Action), nil
+}
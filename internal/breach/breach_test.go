@@ -0,0 +1,69 @@
+package breach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputConfig *Config
+
+		expectedChecker Checker
+		expectedError   error
+	}{
+		{
+			name: "No source disables screening",
+
+			inputConfig: &Config{Action: ActionReject},
+
+			expectedChecker: NewDisabledChecker(),
+		},
+		{
+			name: "Range file",
+
+			inputConfig: &Config{RangeFile: "testdata/corpus.txt", Action: ActionWarn},
+		},
+		{
+			name: "Range API",
+
+			inputConfig: &Config{RangeURL: "https://api.pwnedpasswords.com/range/", Action: ActionReject},
+		},
+		{
+			name: "Unsupported action",
+
+			inputConfig: &Config{RangeURL: "https://api.pwnedpasswords.com/range/", Action: "block"},
+
+			expectedError: ErrUnsupportedAction,
+		},
+		{
+			name: "Both sources",
+
+			inputConfig: &Config{RangeFile: "testdata/corpus.txt", RangeURL: "https://api.pwnedpasswords.com/range/", Action: ActionReject},
+
+			expectedError: ErrSourceConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testChecker, err := New(tc.inputConfig)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+			if tc.expectedChecker != nil {
+				assert.Equal(t, tc.expectedChecker, testChecker)
+				return
+			}
+			assert.IsType(t, &checker{}, testChecker)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package breach
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+)
+
+// checker looks passwords up in the ranges of a source.
+type checker struct {
+	source Source
+	action string
+}
+
+// NewChecker creates a checker looking passwords up in the ranges of a source.
+//
+// Parameters:
+//   - source: The source of the breached password ranges
+//   - action: ActionReject or ActionWarn
+//
+// Returns:
+//   - Checker: A new checker instance
+func NewChecker(source Source, action string) Checker {
+	return &checker{
+		source: source,
+		action: action,
+	}
+}
+
+// Check looks a password up in the range of its hash prefix.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - password: The plain-text password to check.
+//
+// Returns:
+//   - error: ErrBreached if the password was breached and the action is ActionReject, otherwise nil.
+func (c *checker) Check(ctx context.Context, password string) error {
+	s := newrelic.FromContext(ctx).StartSegment("Breach_Check")
+	defer s.End()
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	hashes, err := c.source.Range(ctx, hash[:PrefixLength])
+	if err != nil {
+		log.Warn().
+			Str("operation", "Breach_Check").
+			Err(err).
+			Msg("breached password range unavailable, password not screened")
+		return nil
+	}
+
+	count := hashes[hash[PrefixLength:]]
+	if count == 0 {
+		return nil
+	}
+
+	if c.action == ActionWarn {
+		log.Warn().
+			Str("operation", "Breach_Check").
+			Int("count", count).
+			Msg("password has appeared in a data breach")
+		return nil
+	}
+
+	return ErrBreached
+}
+
+// disabledChecker lets every password through.
+type disabledChecker struct{}
+
+// NewDisabledChecker creates a checker that does not screen passwords.
+//
+// Returns:
+//   - Checker: A checker letting every password through
+func NewDisabledChecker() Checker {
+	return &disabledChecker{}
+}
+
+// Check lets the password through.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - password: The plain-text password to check.
+//
+// Returns:
+//   - error: Always nil.
+func (c *disabledChecker) Check(ctx context.Context, password string) error {
+	return nil
+}
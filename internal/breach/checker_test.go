@@ -0,0 +1,109 @@
+package breach
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/breach/mocks"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupMockSource func(ctx context.Context) *mocks.Source
+		inputAction     string
+		inputPassword   string
+
+		expectedError error
+	}{
+		{
+			name: "Breached password is rejected",
+
+			setupMockSource: func(ctx context.Context) *mocks.Source {
+				sourceMock := mocks.NewSource(t)
+				sourceMock.On("Range", ctx, "49EFE").Return(map[string]int{
+					"F5F70D47ADC2DB2EB397FBEF5F7BC560E29": 24601,
+				}, nil).Once()
+				return sourceMock
+			},
+			inputAction:   ActionReject,
+			inputPassword: "Password123!",
+
+			expectedError: ErrBreached,
+		},
+		{
+			name: "Breached password is only logged when warning",
+
+			setupMockSource: func(ctx context.Context) *mocks.Source {
+				sourceMock := mocks.NewSource(t)
+				sourceMock.On("Range", ctx, "49EFE").Return(map[string]int{
+					"F5F70D47ADC2DB2EB397FBEF5F7BC560E29": 24601,
+				}, nil).Once()
+				return sourceMock
+			},
+			inputAction:   ActionWarn,
+			inputPassword: "Password123!",
+		},
+		{
+			name: "Password missing from its range is accepted",
+
+			setupMockSource: func(ctx context.Context) *mocks.Source {
+				sourceMock := mocks.NewSource(t)
+				sourceMock.On("Range", ctx, "49EFE").Return(map[string]int{
+					"0000000000000000000000000000000000A": 3,
+				}, nil).Once()
+				return sourceMock
+			},
+			inputAction:   ActionReject,
+			inputPassword: "Password123!",
+		},
+		{
+			name: "Padding entries of a range are not breaches",
+
+			setupMockSource: func(ctx context.Context) *mocks.Source {
+				sourceMock := mocks.NewSource(t)
+				sourceMock.On("Range", ctx, "49EFE").Return(map[string]int{
+					"F5F70D47ADC2DB2EB397FBEF5F7BC560E29": 0,
+				}, nil).Once()
+				return sourceMock
+			},
+			inputAction:   ActionReject,
+			inputPassword: "Password123!",
+		},
+		{
+			name: "Unavailable range lets the password through",
+
+			setupMockSource: func(ctx context.Context) *mocks.Source {
+				sourceMock := mocks.NewSource(t)
+				sourceMock.On("Range", ctx, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+				return sourceMock
+			},
+			inputAction:   ActionReject,
+			inputPassword: "Password123!",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			testChecker := NewChecker(tc.setupMockSource(ctx), tc.inputAction)
+
+			err := testChecker.Check(ctx, tc.inputPassword)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDisabledChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewDisabledChecker().Check(t.Context(), "Password123!"))
+}
@@ -0,0 +1,113 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// fileSource serves ranges from a corpus held in memory.
+type fileSource struct {
+	ranges map[string]map[string]int
+}
+
+// LoadFileSource reads a corpus in the format of the downloadable Have I Been Pwned files: one
+// "<SHA-1 hash>:<count>" line per breached password, the hash in hex.
+//
+// Parameters:
+//   - path: The path of the corpus file
+//
+// Returns:
+//   - Source: A source serving the ranges of the corpus
+//   - error: An error if the file cannot be read, ErrMalformedRange if a line is malformed
+func LoadFileSource(path string) (Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewFileSource(f)
+}
+
+// NewFileSource reads a corpus in the format of LoadFileSource.
+//
+// Parameters:
+//   - r: The reader of the corpus
+//
+// Returns:
+//   - Source: A source serving the ranges of the corpus
+//   - error: An error if the corpus cannot be read, ErrMalformedRange if a line is malformed
+func NewFileSource(r io.Reader) (Source, error) {
+	hashes, err := parseRange(r, sha1HexLength)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := map[string]map[string]int{}
+	for hash, count := range hashes {
+		prefix, suffix := hash[:PrefixLength], hash[PrefixLength:]
+		if ranges[prefix] == nil {
+			ranges[prefix] = map[string]int{}
+		}
+		ranges[prefix][suffix] = count
+	}
+
+	return &fileSource{ranges: ranges}, nil
+}
+
+// Range returns the hashes of the corpus starting with a prefix.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - prefix: The first PrefixLength upper-case hex characters of a SHA-1 hash.
+//
+// Returns:
+//   - map[string]int: The suffixes of the hashes in the range with their count; empty when none matches.
+//   - error: Always nil.
+func (f *fileSource) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	return f.ranges[prefix], nil
+}
+
+const (
+	// sha1HexLength is the length of a SHA-1 hash in hex.
+	sha1HexLength = 40
+
+	hexDigits = "0123456789abcdefABCDEF"
+)
+
+// parseRange reads "<hex>:<count>" lines, the hex part being hashLength characters long.
+// Blank lines are skipped and the hex part is upper-cased.
+func parseRange(r io.Reader, hashLength int) (map[string]int, error) {
+	hashes := map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		hash, countText, found := strings.Cut(line, ":")
+		if !found || len(hash) != hashLength {
+			return nil, ErrMalformedRange
+		}
+		if strings.Trim(hash, hexDigits) != "" {
+			return nil, ErrMalformedRange
+		}
+		count, err := strconv.Atoi(countText)
+		if err != nil || count < 0 {
+			return nil, ErrMalformedRange
+		}
+
+		hashes[strings.ToUpper(hash)] = count
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
@@ -0,0 +1,118 @@
+package breach
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSource_Range(t *testing.T) {
+	t.Parallel()
+
+	testSource, err := LoadFileSource("testdata/corpus.txt")
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name string
+
+		inputPrefix string
+
+		expectedRange map[string]int
+	}{
+		{
+			name: "Range of a breached hash",
+
+			inputPrefix: "49EFE",
+
+			expectedRange: map[string]int{"F5F70D47ADC2DB2EB397FBEF5F7BC560E29": 24601},
+		},
+		{
+			name: "Lower-case hashes of the corpus are upper-cased",
+
+			inputPrefix: "D4F55",
+
+			expectedRange: map[string]int{"DEC8C7BC9675182779E564FAE1327D30F9B": 1337},
+		},
+		{
+			name: "Empty range",
+
+			inputPrefix: "00000",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			hashes, err := testSource.Range(t.Context(), tc.inputPrefix)
+			assert.Nil(t, err)
+			assert.Equal(t, len(tc.expectedRange), len(hashes))
+			for suffix, count := range tc.expectedRange {
+				assert.Equal(t, count, hashes[suffix])
+			}
+		})
+	}
+}
+
+func TestNewFileSource(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputCorpus string
+
+		expectedError error
+	}{
+		{
+			name: "Valid corpus",
+
+			inputCorpus: "49EFEF5F70D47ADC2DB2EB397FBEF5F7BC560E29:24601\r\n\nF206AC8BE89ECE832FB2687AAD73C97D15684A8A:42\n",
+		},
+		{
+			name: "Line without count",
+
+			inputCorpus: "49EFEF5F70D47ADC2DB2EB397FBEF5F7BC560E29\n",
+
+			expectedError: ErrMalformedRange,
+		},
+		{
+			name: "Truncated hash",
+
+			inputCorpus: "49EFEF5F70D47ADC2DB2EB397FBEF5F7BC560E2:24601\n",
+
+			expectedError: ErrMalformedRange,
+		},
+		{
+			name: "Hash that is not hex",
+
+			inputCorpus: "49EFEF5F70D47ADC2DB2EB397FBEF5F7BC560EZZ:24601\n",
+
+			expectedError: ErrMalformedRange,
+		},
+		{
+			name: "Count that is not a number",
+
+			inputCorpus: "49EFEF5F70D47ADC2DB2EB397FBEF5F7BC560E29:many\n",
+
+			expectedError: ErrMalformedRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewFileSource(strings.NewReader(tc.inputCorpus))
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLoadFileSource_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadFileSource("testdata/missing.txt")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,71 @@
+package breach
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// apiSource fetches ranges from a remote range API.
+type apiSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewAPISource creates a source fetching ranges from an API compatible with the Pwned Passwords range API,
+// such as https://api.pwnedpasswords.com/range/. The range of a prefix is fetched from the base URL followed
+// by the prefix, and answers one "<suffix>:<count>" line per hash. Responses are padded so that their size
+// does not give the range away.
+//
+// Parameters:
+//   - baseURL: The URL the prefixes are appended to
+//   - timeout: The timeout of a range request
+//
+// Returns:
+//   - Source: A new remote source instance
+func NewAPISource(baseURL string, timeout time.Duration) Source {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return &apiSource{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Range fetches the hashes starting with a prefix.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - prefix: The first PrefixLength upper-case hex characters of a SHA-1 hash.
+//
+// Returns:
+//   - map[string]int: The suffixes of the hashes in the range with their count; padding entries count 0.
+//   - error: An error if the request fails or the API does not answer 200, ErrMalformedRange if the body is malformed
+func (a *apiSource) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	s := newrelic.FromContext(ctx).StartSegment("Breach_Range")
+	defer s.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("breached password range API answered %d", resp.StatusCode)
+	}
+
+	return parseRange(resp.Body, sha1HexLength-PrefixLength)
+}
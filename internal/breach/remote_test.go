@@ -0,0 +1,70 @@
+package breach
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPISource_Range(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		handler http.HandlerFunc
+
+		expectedRange map[string]int
+		expectedError bool
+	}{
+		{
+			name: "Padded range",
+
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/range/49EFE", r.URL.Path)
+				assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+				w.Write([]byte("F5F70D47ADC2DB2EB397FBEF5F7BC560E29:24601\r\n0018A45C4D1DEF81644B54AB7F969B88D65:0\r\n"))
+			},
+
+			expectedRange: map[string]int{
+				"F5F70D47ADC2DB2EB397FBEF5F7BC560E29": 24601,
+				"0018A45C4D1DEF81644B54AB7F969B88D65": 0,
+			},
+		},
+		{
+			name: "API error",
+
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+
+			expectedError: true,
+		},
+		{
+			name: "Malformed range",
+
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("<html>maintenance</html>"))
+			},
+
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tc.handler)
+			t.Cleanup(server.Close)
+			testSource := NewAPISource(server.URL+"/range", time.Second)
+
+			hashes, err := testSource.Range(t.Context(), "49EFE")
+			assert.Equal(t, tc.expectedError, err != nil)
+			assert.Equal(t, tc.expectedRange, hashes)
+		})
+	}
+}
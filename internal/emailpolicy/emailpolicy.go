@@ -0,0 +1,93 @@
+// Package emailpolicy decides which email addresses can be registered or changed to.
+// Addresses of a denied domain or of a disposable email provider are rejected, and the domain can be
+// required to have mail servers. Domains are matched with their subdomains, and allowed domains skip
+// every other check. The list of disposable domains is built in and can be replaced by a file that is
+// reloaded while the service runs.
+package emailpolicy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+var (
+	ErrDisposable    = errors.New("disposable email addresses are not accepted")
+	ErrDenied        = errors.New("email domain is not accepted")
+	ErrNoMailServer  = errors.New("email domain does not receive email")
+	ErrInvalidFormat = errors.New("email address has no domain")
+)
+
+// Checker checks email addresses against the policy.
+//
+//go:generate mockery --name=Checker --filename=checker.go --output=./mocks
+type Checker interface {
+	// Check tells whether an email address can be registered or changed to.
+	// A mail server lookup that fails for another reason than the domain not existing is logged and lets the
+	// address through, so an unreachable DNS server never blocks registrations.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - email: The email address as entered.
+	//
+	// Returns:
+	//   - error: ErrInvalidFormat, ErrDenied, ErrDisposable or ErrNoMailServer if the address is rejected,
+	//     otherwise nil.
+	Check(ctx context.Context, email string) error
+}
+
+// Resolver looks up the mail servers of a domain; *net.Resolver implements it.
+//
+//go:generate mockery --name=Resolver --filename=resolver.go --output=./mocks
+type Resolver interface {
+	// LookupMX returns the MX records of a domain.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - name: The domain.
+	//
+	// Returns:
+	//   - []*net.MX: The MX records of the domain.
+	//   - error: A *net.DNSError if the lookup fails, otherwise nil.
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// Config holds the email policy, read from EMAIL_POLICY_* environment variables.
+type Config struct {
+	AllowedDomains  []string      `envconfig:"ALLOWED_DOMAINS" default:""`
+	DeniedDomains   []string      `envconfig:"DENIED_DOMAINS" default:""`
+	DisposableFile  string        `envconfig:"DISPOSABLE_FILE" default:""`
+	RefreshInterval time.Duration `envconfig:"REFRESH_INTERVAL" default:"1h"`
+	CheckMX         bool          `envconfig:"CHECK_MX" default:"false"`
+	MXTimeout       time.Duration `envconfig:"MX_TIMEOUT" default:"3s"`
+}
+
+// IsRejected reports whether an error is the rejection of an email address by the policy.
+//
+// Parameters:
+//   - err: The error to inspect.
+//
+// Returns:
+//   - bool: true if the error is ErrInvalidFormat, ErrDenied, ErrDisposable or ErrNoMailServer.
+func IsRejected(err error) bool {
+	return errors.Is(err, ErrInvalidFormat) || errors.Is(err, ErrDenied) ||
+		errors.Is(err, ErrDisposable) || errors.Is(err, ErrNoMailServer)
+}
+
+// NewConfig loads the email policy from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("EMAIL_POLICY", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
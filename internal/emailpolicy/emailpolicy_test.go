@@ -0,0 +1,65 @@
+package emailpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRejected(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputError error
+
+		expectedRejected bool
+	}{
+		{
+			name: "Disposable domain",
+
+			inputError: ErrDisposable,
+
+			expectedRejected: true,
+		},
+		{
+			name: "Wrapped denied domain",
+
+			inputError: fmt.Errorf("register: %w", ErrDenied),
+
+			expectedRejected: true,
+		},
+		{
+			name: "No mail server",
+
+			inputError: ErrNoMailServer,
+
+			expectedRejected: true,
+		},
+		{
+			name: "Invalid format",
+
+			inputError: ErrInvalidFormat,
+
+			expectedRejected: true,
+		},
+		{
+			name: "Other error",
+
+			inputError: assert.AnError,
+		},
+		{
+			name: "No error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedRejected, IsRejected(tc.inputError))
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package emailpolicy
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+)
+
+//go:embed disposable_domains.txt
+var builtinDisposableDomains string
+
+// Policy checks email addresses against the configured domain lists and the disposable domains.
+// It is safe for concurrent use.
+type Policy struct {
+	allowed   map[string]bool
+	denied    map[string]bool
+	resolver  Resolver
+	mxTimeout time.Duration
+
+	mu         sync.RWMutex
+	disposable map[string]bool
+}
+
+// NewPolicy creates an email policy. The disposable domains are read from the configured file, or are the
+// built-in ones when no file is set.
+//
+// Parameters:
+//   - cfg: The email policy configuration.
+//   - resolver: The resolver looking up mail servers when cfg.CheckMX is set.
+//
+// Returns:
+//   - *Policy: The email policy.
+//   - error: An error if the disposable domains file cannot be read, otherwise nil.
+func NewPolicy(cfg *Config, resolver Resolver) (*Policy, error) {
+	policy := &Policy{
+		allowed:   domainSet(cfg.AllowedDomains),
+		denied:    domainSet(cfg.DeniedDomains),
+		mxTimeout: cfg.MXTimeout,
+	}
+	if cfg.CheckMX {
+		policy.resolver = resolver
+	}
+
+	disposable, err := ParseDomains(strings.NewReader(builtinDisposableDomains))
+	if cfg.DisposableFile != "" {
+		disposable, err = LoadDomains(cfg.DisposableFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	policy.SetDisposableDomains(disposable)
+
+	return policy, nil
+}
+
+// SetDisposableDomains replaces the disposable domains of the policy.
+//
+// Parameters:
+//   - domains: The disposable domains.
+func (p *Policy) SetDisposableDomains(domains []string) {
+	disposable := domainSet(domains)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disposable = disposable
+}
+
+// Check tells whether an email address can be registered or changed to.
+// The domain is checked against the allowed domains, then the denied and the disposable ones, then for mail
+// servers; each list matches the domain and its subdomains.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - email: The email address as entered.
+//
+// Returns:
+//   - error: ErrInvalidFormat, ErrDenied, ErrDisposable or ErrNoMailServer if the address is rejected, otherwise nil.
+func (p *Policy) Check(ctx context.Context, email string) error {
+	s := newrelic.FromContext(ctx).StartSegment("EmailPolicy_Check")
+	defer s.End()
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ErrInvalidFormat
+	}
+	domain := normalizeDomain(email[at+1:])
+	if domain == "" {
+		return ErrInvalidFormat
+	}
+
+	if matches(p.allowed, domain) {
+		return nil
+	}
+	if matches(p.denied, domain) {
+		return ErrDenied
+	}
+
+	p.mu.RLock()
+	disposable := matches(p.disposable, domain)
+	p.mu.RUnlock()
+	if disposable {
+		return ErrDisposable
+	}
+
+	if p.resolver == nil {
+		return nil
+	}
+
+	return p.checkMailServers(ctx, domain)
+}
+
+// checkMailServers looks up the MX records of a domain, rejecting domains that do not exist, have none, or
+// publish a null MX record.
+func (p *Policy) checkMailServers(ctx context.Context, domain string) error {
+	lookupCtx, cancel := context.WithTimeout(ctx, p.mxTimeout)
+	defer cancel()
+
+	records, err := p.resolver.LookupMX(lookupCtx, domain)
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		return ErrNoMailServer
+	case err != nil:
+		log.Warn().
+			Str("operation", "EmailPolicy_Check").
+			Err(err).
+			Msg("mail servers lookup failed, email address not checked")
+		return nil
+	}
+
+	for _, record := range records {
+		if record.Host != "." && record.Host != "" {
+			return nil
+		}
+	}
+
+	return ErrNoMailServer
+}
+
+// ParseDomains reads one domain per line, skipping blank lines and "#" comments.
+//
+// Parameters:
+//   - r: The list.
+//
+// Returns:
+//   - []string: The domains.
+//   - error: An error if the list cannot be read, otherwise nil.
+func ParseDomains(r io.Reader) ([]string, error) {
+	domains := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if domain := normalizeDomain(line); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	return domains, scanner.Err()
+}
+
+// LoadDomains reads a file of domains in the format of ParseDomains.
+//
+// Parameters:
+//   - path: The path of the file.
+//
+// Returns:
+//   - []string: The domains.
+//   - error: An error if the file cannot be read, otherwise nil.
+func LoadDomains(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseDomains(f)
+}
+
+// Run reloads the disposable domains of a policy from a file every refresh interval until the context is cancelled.
+// A file that cannot be read is logged and the previous domains are kept.
+//
+// Parameters:
+//   - ctx: The context stopping the reloads when cancelled.
+//   - policy: The email policy.
+//   - path: The path of the disposable domains file.
+//   - refreshInterval: How long to wait between two reloads.
+func Run(ctx context.Context, policy *Policy, path string, refreshInterval time.Duration) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		domains, err := LoadDomains(path)
+		if err != nil {
+			log.Error().
+				Str("operation", "EmailPolicy_Run").
+				Err(err).
+				Msg("failed to reload the disposable email domains")
+			continue
+		}
+		policy.SetDisposableDomains(domains)
+	}
+}
+
+// disabledChecker accepts every email address.
+type disabledChecker struct{}
+
+// NewDisabledChecker creates a checker that does not check email addresses.
+//
+// Returns:
+//   - Checker: A checker accepting every email address
+func NewDisabledChecker() Checker {
+	return &disabledChecker{}
+}
+
+// Check accepts the email address.
+func (d *disabledChecker) Check(ctx context.Context, email string) error {
+	return nil
+}
+
+// normalizeDomain returns a domain in lower case without surrounding spaces or a trailing dot.
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// domainSet builds the set of the normalized domains of a list.
+func domainSet(domains []string) map[string]bool {
+	set := map[string]bool{}
+	for _, domain := range domains {
+		if domain = normalizeDomain(domain); domain != "" {
+			set[domain] = true
+		}
+	}
+
+	return set
+}
+
+// matches reports whether a domain or one of its parent domains is in a set.
+func matches(set map[string]bool, domain string) bool {
+	for {
+		if set[domain] {
+			return true
+		}
+
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return false
+		}
+		domain = parent
+	}
+}
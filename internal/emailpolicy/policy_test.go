@@ -0,0 +1,227 @@
+package emailpolicy
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy/mocks"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputConfig   *Config
+		setupResolver func(t *testing.T) *mocks.Resolver
+		inputEmail    string
+
+		expectedError error
+	}{
+		{
+			name: "Ordinary address",
+
+			inputConfig: &Config{},
+			inputEmail:  "testuser@example.com",
+		},
+		{
+			name: "Address without a domain",
+
+			inputConfig: &Config{},
+			inputEmail:  "testuser@",
+
+			expectedError: ErrInvalidFormat,
+		},
+		{
+			name: "Address without an at sign",
+
+			inputConfig: &Config{},
+			inputEmail:  "testuser",
+
+			expectedError: ErrInvalidFormat,
+		},
+		{
+			name: "Built-in disposable domain in another case",
+
+			inputConfig: &Config{},
+			inputEmail:  "testuser@Mailinator.COM",
+
+			expectedError: ErrDisposable,
+		},
+		{
+			name: "Subdomain of a disposable domain",
+
+			inputConfig: &Config{},
+			inputEmail:  "testuser@mx.yopmail.com",
+
+			expectedError: ErrDisposable,
+		},
+		{
+			name: "Denied domain",
+
+			inputConfig: &Config{DeniedDomains: []string{"competitor.example"}},
+			inputEmail:  "testuser@sales.competitor.example",
+
+			expectedError: ErrDenied,
+		},
+		{
+			name: "Allowed domain skips the other checks",
+
+			inputConfig: &Config{AllowedDomains: []string{"mailinator.com"}, CheckMX: true},
+			inputEmail:  "testuser@mailinator.com",
+		},
+		{
+			name: "Domain with mail servers",
+
+			inputConfig: &Config{CheckMX: true, MXTimeout: time.Second},
+			setupResolver: func(t *testing.T) *mocks.Resolver {
+				resolverMock := mocks.NewResolver(t)
+				resolverMock.On("LookupMX", mock.Anything, "example.com").
+					Return([]*net.MX{{Host: "mail.example.com.", Pref: 10}}, nil).Once()
+				return resolverMock
+			},
+			inputEmail: "testuser@example.com",
+		},
+		{
+			name: "Domain that does not exist",
+
+			inputConfig: &Config{CheckMX: true, MXTimeout: time.Second},
+			setupResolver: func(t *testing.T) *mocks.Resolver {
+				resolverMock := mocks.NewResolver(t)
+				resolverMock.On("LookupMX", mock.Anything, "nowhere.example").
+					Return(nil, &net.DNSError{Err: "no such host", Name: "nowhere.example", IsNotFound: true}).Once()
+				return resolverMock
+			},
+			inputEmail: "testuser@nowhere.example",
+
+			expectedError: ErrNoMailServer,
+		},
+		{
+			name: "Domain publishing a null MX record",
+
+			inputConfig: &Config{CheckMX: true, MXTimeout: time.Second},
+			setupResolver: func(t *testing.T) *mocks.Resolver {
+				resolverMock := mocks.NewResolver(t)
+				resolverMock.On("LookupMX", mock.Anything, "nomail.example").
+					Return([]*net.MX{{Host: ".", Pref: 0}}, nil).Once()
+				return resolverMock
+			},
+			inputEmail: "testuser@nomail.example",
+
+			expectedError: ErrNoMailServer,
+		},
+		{
+			name: "Failed lookup lets the address through",
+
+			inputConfig: &Config{CheckMX: true, MXTimeout: time.Second},
+			setupResolver: func(t *testing.T) *mocks.Resolver {
+				resolverMock := mocks.NewResolver(t)
+				resolverMock.On("LookupMX", mock.Anything, "example.com").
+					Return(nil, &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}).Once()
+				return resolverMock
+			},
+			inputEmail: "testuser@example.com",
+		},
+		{
+			name: "Resolver unused when mail servers are not checked",
+
+			inputConfig: &Config{},
+			setupResolver: func(t *testing.T) *mocks.Resolver {
+				return mocks.NewResolver(t)
+			},
+			inputEmail: "testuser@nowhere.example",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var resolver Resolver
+			if tc.setupResolver != nil {
+				resolver = tc.setupResolver(t)
+			}
+
+			testPolicy, err := NewPolicy(tc.inputConfig, resolver)
+			assert.Nil(t, err)
+
+			err = testPolicy.Check(t.Context(), tc.inputEmail)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputConfig *Config
+		inputEmail  string
+
+		expectedCheckError error
+		expectedError      bool
+	}{
+		{
+			name: "Disposable domains read from a file",
+
+			inputConfig: &Config{DisposableFile: "testdata/disposable.txt"},
+			inputEmail:  "testuser@burner.example",
+
+			expectedCheckError: ErrDisposable,
+		},
+		{
+			name: "File replaces the built-in disposable domains",
+
+			inputConfig: &Config{DisposableFile: "testdata/disposable.txt"},
+			inputEmail:  "testuser@mailinator.com",
+		},
+		{
+			name: "Missing file",
+
+			inputConfig: &Config{DisposableFile: "testdata/missing.txt"},
+
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testPolicy, err := NewPolicy(tc.inputConfig, nil)
+			assert.Equal(t, tc.expectedError, err != nil)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedCheckError, testPolicy.Check(t.Context(), tc.inputEmail))
+		})
+	}
+}
+
+func TestPolicy_SetDisposableDomains(t *testing.T) {
+	t.Parallel()
+
+	testPolicy, err := NewPolicy(&Config{}, nil)
+	assert.Nil(t, err)
+
+	testPolicy.SetDisposableDomains([]string{"throwaway.example"})
+
+	assert.Equal(t, ErrDisposable, testPolicy.Check(t.Context(), "testuser@throwaway.example"))
+	assert.Nil(t, testPolicy.Check(t.Context(), "testuser@mailinator.com"))
+}
+
+func TestParseDomains(t *testing.T) {
+	t.Parallel()
+
+	domains, err := ParseDomains(strings.NewReader("# comment\n\nExample.COM.\n  spam.example # trailing comment\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"example.com", "spam.example"}, domains)
+}
@@ -38,6 +38,9 @@ func CreateAPI() api.Engine {
 	// initialize sql db client
 	dbClient := CreateSQLDBAndMigration()
 
+	// usernames and email addresses are only unique once every canonical form is set
+	EnsureNormalizedIdentifiers(dbClient)
+
 	// initialize other dependencies
 	jwtGenerator, jwtValidator := CreateJWTProviders()
 	app := gin.New()
@@ -45,6 +48,34 @@ func CreateAPI() api.Engine {
 	// new relic client
 	nrClient := CreateNewRelicClient()
 
+	// upstream identity providers for federated login
+	oidcProviders := CreateOIDCProviders(cfg)
+
+	// outgoing email transport
+	mailer := CreateMailer()
+
+	// alerts about logins from new devices
+	notifier := CreateNotifier(mailer)
+
+	// relying party for passkey login
+	webAuthn := CreateWebAuthn(cfg)
+
+	// screening of new passwords against breached ones
+	breachChecker := CreateBreachChecker()
+
+	// disposable, denied and undeliverable email domains
+	emailPolicy := CreateEmailPolicy()
+
+	// challenges for registrations and logins that look scripted
+	botGuard := CreateBotGuard(redisClient)
+
+	// reserved and blocked usernames
+	ActivateUsernamePolicy(dbClient)
+
+	// password hashing, upgrading legacy hashes on login
+	passwordHashing, legacyReportInterval := CreatePasswordHashing()
+	ReportLegacyPasswordHashes(dbClient, nrClient, passwordHashing, legacyReportInterval)
+
 	apiEngine := api.New(&api.EngineOpts{
 		Engine:      app,
 		Cfg:         cfg,
@@ -52,10 +83,17 @@ func CreateAPI() api.Engine {
 		SqlDB:       dbClient,
 		// Initialize other dependencies
 		RandomCodeGen:   utils.NewCodeGenerator(),
-		PasswordHashing: utils.NewPasswordHashing(),
+		PasswordHashing: passwordHashing,
 		JWTGenerator:    jwtGenerator,
 		JWTValidator:    jwtValidator,
 		NrClient:        nrClient,
+		OIDCProviders:   oidcProviders,
+		Mailer:          mailer,
+		Notifier:        notifier,
+		WebAuthn:        webAuthn,
+		BreachChecker:   breachChecker,
+		BotGuard:        botGuard,
+		EmailPolicy:     emailPolicy,
 	})
 
 	return apiEngine
@@ -0,0 +1,23 @@
+package infrastructure
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+)
+
+// CreateBotGuard initializes the bot protection from the BOT_PROTECTION_* environment variables.
+// Parameters:
+//   - redisClient: The Redis client keeping the risk signals
+//
+// Returns:
+//   - botprotection.Guard: The guard, or a guard letting every request through when no provider is configured
+func CreateBotGuard(redisClient *redis.Client) botprotection.Guard {
+	cfg, err := botprotection.NewConfig()
+	common.HandlerError(err)
+
+	guard, err := botprotection.New(cfg, redisClient)
+	common.HandlerError(err)
+
+	return guard
+}
@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+)
+
+// CreateBreachChecker initializes the breached password screening from the BREACHED_PASSWORD_* environment variables.
+// A range file is loaded once, here.
+// Returns:
+//   - breach.Checker: The checker, or a checker letting every password through when no range source is configured
+func CreateBreachChecker() breach.Checker {
+	cfg, err := breach.NewConfig()
+	common.HandlerError(err)
+
+	checker, err := breach.New(cfg)
+	common.HandlerError(err)
+
+	return checker
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
 	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/sqldb"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
 
 	"gorm.io/gorm"
 )
@@ -18,6 +19,7 @@ func CreateRedisCon() *redis.Client {
 }
 
 // CreateSQLDBAndMigration initializes the SQL database client and performs migrations.
+// Queries of the client are scoped to the tenant of their context.
 // It returns the initialized GORM DB instance.
 // Returns:
 //   - *gorm.DB: A pointer to the initialized GORM DB instance
@@ -25,6 +27,9 @@ func CreateSQLDBAndMigration() *gorm.DB {
 	dbClient, err := sqldb.NewClient("")
 	common.HandlerError(err)
 
+	err = tenant.RegisterCallbacks(dbClient)
+	common.HandlerError(err)
+
 	err = MigrateDB(dbClient)
 	common.HandlerError(err)
 
@@ -32,6 +37,7 @@ func CreateSQLDBAndMigration() *gorm.DB {
 }
 
 // CreateSQLDB initializes and returns a GORM DB client without performing migrations.
+// Queries of the client are scoped to the tenant of their context.
 // It returns the initialized GORM DB instance.
 // Returns:
 //   - *gorm.DB: A pointer to the initialized GORM DB instance
@@ -39,6 +45,9 @@ func CreateSQLDB() *gorm.DB {
 	dbClient, err := sqldb.NewClient("")
 	common.HandlerError(err)
 
+	err = tenant.RegisterCallbacks(dbClient)
+	common.HandlerError(err)
+
 	return dbClient
 }
 
@@ -0,0 +1,28 @@
+package infrastructure
+
+import (
+	"context"
+	"net"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+)
+
+// CreateEmailPolicy initializes the email policy from the EMAIL_POLICY_* environment variables and keeps its
+// disposable domains in sync with the configured file, if any.
+//
+// Returns:
+//   - emailpolicy.Checker: The email policy
+func CreateEmailPolicy() emailpolicy.Checker {
+	cfg, err := emailpolicy.NewConfig()
+	common.HandlerError(err)
+
+	policy, err := emailpolicy.NewPolicy(cfg, net.DefaultResolver)
+	common.HandlerError(err)
+
+	if cfg.DisposableFile != "" {
+		go emailpolicy.Run(context.Background(), policy, cfg.DisposableFile, cfg.RefreshInterval)
+	}
+
+	return policy
+}
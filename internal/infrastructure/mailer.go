@@ -0,0 +1,16 @@
+package infrastructure
+
+import (
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+// CreateMailer initializes the outgoing email transport from the SMTP_* environment variables.
+// Returns:
+//   - mailer.Mailer: The SMTP mailer, or a logging mailer when no SMTP host is configured
+func CreateMailer() mailer.Mailer {
+	cfg, err := mailer.NewConfig()
+	common.HandlerError(err)
+
+	return mailer.New(cfg)
+}
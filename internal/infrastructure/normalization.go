@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/logger"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	normalizationService "github.com/vukieuhaihoa/user-service/internal/app/service/normalization"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// RunNormalizationScan prints the users of a tenant whose usernames or email addresses share a canonical form.
+// Every tenant is scanned.
+// With backfill, it also writes the canonical forms of the users outside of a collision and of the username history.
+// The process exits with status 1 while collisions remain.
+func RunNormalizationScan(backfill bool) {
+	logger.SetLogLevel()
+
+	dbClient := CreateSQLDB()
+	svc := normalizationService.NewNormalizationService(userRepository.NewUserRepository(dbClient))
+
+	report, err := svc.Scan(tenant.WithAllTenants(context.Background()), backfill)
+	common.HandlerError(err)
+
+	for _, collision := range report.Collisions {
+		fmt.Printf("%s %q is shared in tenant %q by:\n", collision.Field, collision.Normalized, collision.TenantID)
+		for i, id := range collision.UserIDs {
+			fmt.Printf("  %s\t%s\n", id, collision.Values[i])
+		}
+	}
+	fmt.Printf("%d users scanned, %d collisions\n", report.Users, len(report.Collisions))
+	if backfill {
+		fmt.Printf("%d users and %d username changes backfilled\n", report.UsersBackfilled, report.ChangesBackfilled)
+	}
+
+	if len(report.Collisions) > 0 {
+		os.Exit(1)
+	}
+}
+
+// EnsureNormalizedIdentifiers stops the service while users or username changes of any tenant lack their canonical
+// forms. The unique indexes only cover the canonical forms that are set, so usernames and email addresses would not
+// be unique until the normalize-identifiers command backfilled them and the collisions it reports were resolved.
+// Parameters:
+//   - db: The database holding the users
+func EnsureNormalizedIdentifiers(db *gorm.DB) {
+	missing, err := userRepository.NewUserRepository(db).CountMissingNormalizedIdentifiers(tenant.WithAllTenants(context.Background()))
+	common.HandlerError(err)
+
+	if missing > 0 {
+		common.HandlerError(fmt.Errorf(
+			"%d users or username changes have no canonical identifiers, run normalize-identifiers -backfill and resolve the collisions it reports",
+			missing,
+		))
+	}
+}
@@ -0,0 +1,16 @@
+package infrastructure
+
+import (
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+)
+
+// CreateNotifier initializes the transport alerting users about security relevant activity.
+// Parameters:
+//   - m: The outgoing email transport
+//
+// Returns:
+//   - notifier.Notifier: The notifier emailing users
+func CreateNotifier(m mailer.Mailer) notifier.Notifier {
+	return notifier.NewMailNotifier(m)
+}
@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+)
+
+// oidcHTTPTimeout bounds every request made to an upstream identity provider.
+const oidcHTTPTimeout = 10 * time.Second
+
+// CreateOIDCProviders initializes the OpenID Connect providers enabled in the API configuration.
+// Parameters:
+//   - cfg: The API configuration listing the enabled provider names
+//
+// Returns:
+//   - map[string]identity.Provider: The providers keyed by name
+func CreateOIDCProviders(cfg *api.Config) map[string]identity.Provider {
+	httpClient := &http.Client{Timeout: oidcHTTPTimeout}
+
+	providers := make(map[string]identity.Provider, len(cfg.OIDCProviders))
+	for _, name := range cfg.OIDCProviders {
+		providerCfg, err := identity.NewProviderConfig(name)
+		common.HandlerError(err)
+
+		providers[name] = identity.NewOIDCProvider(providerCfg, httpClient)
+	}
+
+	return providers
+}
@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/logger"
+	outboxRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/outbox"
+	outboxService "github.com/vukieuhaihoa/user-service/internal/app/service/outbox"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// RunOutboxRelay relays the user domain events of every tenant from the outbox to Redis Streams
+// until the process receives SIGINT or SIGTERM.
+func RunOutboxRelay() {
+	logger.SetLogLevel()
+
+	cfg, err := outboxService.NewConfig()
+	common.HandlerError(err)
+
+	redisClient := CreateRedisCon()
+	dbClient := CreateSQLDB()
+
+	svc := outboxService.NewOutboxService(outboxRepository.NewOutboxRepository(dbClient, redisClient), cfg)
+
+	ctx, stop := signal.NotifyContext(tenant.WithAllTenants(context.Background()), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	outboxService.Run(ctx, svc, cfg.PollInterval)
+}
@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/passwordhash"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// CreatePasswordHashing initializes the password hashing registry from the PASSWORD_HASH_* environment variables.
+// Returns:
+//   - passwordhash.PasswordHashing: The registry hashing with the configured algorithm
+//   - time.Duration: How often the legacy password hashes are counted
+func CreatePasswordHashing() (passwordhash.PasswordHashing, time.Duration) {
+	cfg, err := passwordhash.NewConfig()
+	common.HandlerError(err)
+
+	passwordHashing, err := passwordhash.New(cfg)
+	common.HandlerError(err)
+
+	return passwordHashing, cfg.LegacyReportInterval
+}
+
+// ReportLegacyPasswordHashes records the number of users of every tenant still on a legacy password hash as a New
+// Relic metric, every interval, in the background.
+// Parameters:
+//   - db: The database holding the users
+//   - nrClient: The New Relic application recording the metric
+//   - passwordHashing: The registry giving the preferred algorithm
+//   - interval: How often the hashes are counted
+func ReportLegacyPasswordHashes(db *gorm.DB, nrClient *newrelic.Application, passwordHashing passwordhash.PasswordHashing, interval time.Duration) {
+	record := func(count int64) {
+		nrClient.RecordCustomMetric(userService.LegacyPasswordHashesMetric, float64(count))
+	}
+
+	go userService.ReportLegacyPasswordHashes(tenant.WithAllTenants(context.Background()), userRepository.NewUserRepository(db), passwordHashing, record, interval)
+}
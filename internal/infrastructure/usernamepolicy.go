@@ -0,0 +1,27 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	usernamePolicyRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/usernamepolicy"
+	usernamePolicyService "github.com/vukieuhaihoa/user-service/internal/app/service/usernamepolicy"
+	"gorm.io/gorm"
+)
+
+// ActivateUsernamePolicy builds the username policy from the environment, makes it the one
+// checked by the username_policy validator and keeps its runtime lists in sync with the database.
+// Parameters:
+//   - db: The database holding the reserved and blocked entries
+func ActivateUsernamePolicy(db *gorm.DB) {
+	cfg, err := usernamePolicyService.NewConfig()
+	common.HandlerError(err)
+
+	policy, err := usernamePolicyService.NewPolicy(cfg)
+	common.HandlerError(err)
+
+	usernamePolicyService.Activate(policy)
+
+	svc := usernamePolicyService.NewUsernamePolicyService(usernamePolicyRepository.NewUsernamePolicyRepository(db), policy)
+	go usernamePolicyService.Run(context.Background(), svc, cfg.RefreshInterval)
+}
@@ -0,0 +1,36 @@
+package infrastructure
+
+import (
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/service/passkey"
+)
+
+// CreateWebAuthn initializes the WebAuthn relying party used for passkeys.
+// Ceremonies time out at the server after the same delay their session is kept for.
+// Parameters:
+//   - cfg: The API configuration holding the relying party ID, name and origins
+//
+// Returns:
+//   - *webauthn.WebAuthn: The configured relying party
+func CreateWebAuthn(cfg *api.Config) *webauthn.WebAuthn {
+	timeout := webauthn.TimeoutConfig{
+		Enforce:    true,
+		Timeout:    passkey.SessionExpiration,
+		TimeoutUVD: passkey.SessionExpiration,
+	}
+
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthnRPID,
+		RPDisplayName: cfg.WebAuthnRPDisplayName,
+		RPOrigins:     cfg.WebAuthnRPOrigins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	})
+	common.HandlerError(err)
+
+	return webAuthn
+}
@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"os/signal"
+	"syscall"
+
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/logger"
+	webhookRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook"
+	webhookService "github.com/vukieuhaihoa/user-service/internal/app/service/webhook"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+)
+
+// RunWebhookWorker delivers the user domain events of every tenant to the webhook subscriptions
+// until the process receives SIGINT or SIGTERM.
+func RunWebhookWorker() {
+	logger.SetLogLevel()
+
+	cfg, err := webhookService.NewConfig()
+	common.HandlerError(err)
+
+	redisClient := CreateRedisCon()
+	dbClient := CreateSQLDB()
+
+	webhookRepo := webhookRepository.NewWebhookRepository(dbClient, redisClient)
+	worker := webhookService.NewWebhookWorker(webhookRepo, &http.Client{Timeout: cfg.Timeout}, cfg)
+
+	ctx, stop := signal.NotifyContext(tenant.WithAllTenants(context.Background()), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	webhookService.Run(ctx, worker, cfg.PollInterval)
+}
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// logMailer writes messages to the log instead of sending them.
+type logMailer struct{}
+
+// NewLogMailer creates a mailer that only logs messages.
+// Messages may contain login links, so it must not be used in production.
+//
+// Returns:
+//   - Mailer: A new logging mailer instance
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+// Send logs the message.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - msg: The message to log.
+//
+// Returns:
+//   - error: Always nil.
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	log.Info().
+		Str("operation", "Mailer_Send").
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Body).
+		Msg("email not sent, no SMTP host configured")
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package mailer provides the outgoing email transport of the service.
+// It sends plain-text messages through an SMTP relay, or only logs them when
+// no relay is configured, which is convenient for local development.
+package mailer
+
+import (
+	"context"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer defines the contract for sending emails.
+//
+//go:generate mockery --name=Mailer --filename=mailer.go --output=./mocks
+type Mailer interface {
+	// Send delivers a message to its recipient.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - msg: The message to deliver.
+	//
+	// Returns:
+	//   - error: An error if the message cannot be handed to the transport, otherwise nil.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Config holds the SMTP relay settings, read from SMTP_* environment variables.
+// An empty host selects the logging mailer.
+type Config struct {
+	Host     string `envconfig:"HOST" default:""`
+	Port     int    `envconfig:"PORT" default:"587"`
+	Username string `envconfig:"USERNAME" default:""`
+	Password string `envconfig:"PASSWORD" default:""`
+	From     string `envconfig:"FROM" default:"no-reply@localhost"`
+}
+
+// NewConfig loads the SMTP configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("SMTP", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// New returns the SMTP mailer when a relay host is configured, otherwise the logging mailer.
+//
+// Parameters:
+//   - cfg: The SMTP configuration
+//
+// Returns:
+//   - Mailer: The selected mailer
+func New(cfg *Config) Mailer {
+	if cfg.Host == "" {
+		return NewLogMailer()
+	}
+
+	return NewSMTPMailer(cfg)
+}
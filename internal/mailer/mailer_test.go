@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputCfg *Config
+
+		expectedOutput Mailer
+	}{
+		{
+			name:           "no SMTP host selects the logging mailer",
+			inputCfg:       &Config{},
+			expectedOutput: &logMailer{},
+		},
+		{
+			name:           "SMTP host selects the SMTP mailer",
+			inputCfg:       &Config{Host: "smtp.example.com", Port: 587},
+			expectedOutput: &smtpMailer{cfg: &Config{Host: "smtp.example.com", Port: 587}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedOutput, New(tc.inputCfg))
+		})
+	}
+}
+
+func TestLogMailer_Send(t *testing.T) {
+	t.Parallel()
+
+	err := NewLogMailer().Send(t.Context(), &Message{To: "testuser001@example.com", Subject: "Hello", Body: "Hi"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// headerSanitizer strips line breaks so a header value can never start a new header.
+var headerSanitizer = strings.NewReplacer("\r", "", "\n", "")
+
+// smtpMailer sends messages through an SMTP relay.
+type smtpMailer struct {
+	cfg *Config
+}
+
+// NewSMTPMailer creates a mailer sending through the configured SMTP relay.
+// PLAIN authentication is used when a username is set; net/smtp only allows it over TLS or to localhost.
+//
+// Parameters:
+//   - cfg: The SMTP configuration
+//
+// Returns:
+//   - Mailer: A new SMTP mailer instance
+func NewSMTPMailer(cfg *Config) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+// Send delivers a message through the SMTP relay.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - msg: The message to deliver.
+//
+// Returns:
+//   - error: An error if the relay rejects the message, otherwise nil.
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	s := newrelic.FromContext(ctx).StartSegment("Mailer_Send")
+	defer s.End()
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	return smtp.SendMail(addr, auth, m.cfg.From, []string{msg.To}, m.buildMessage(msg))
+}
+
+// buildMessage renders the message headers and body in RFC 5322 format.
+func (m *smtpMailer) buildMessage(msg *Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerSanitizer.Replace(m.cfg.From))
+	fmt.Fprintf(&b, "To: %s\r\n", headerSanitizer.Replace(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerSanitizer.Replace(msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
@@ -0,0 +1,105 @@
+package mailer
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startFakeSMTPServer accepts a single SMTP session and sends the received DATA to the returned channel.
+func startFakeSMTPServer(t *testing.T) (string, int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start fake SMTP server: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					dataLine, err := r.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				received <- data.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+
+	return host, portNum, received
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	t.Parallel()
+
+	host, port, received := startFakeSMTPServer(t)
+
+	m := NewSMTPMailer(&Config{Host: host, Port: port, From: "no-reply@example.com"})
+
+	err := m.Send(t.Context(), &Message{
+		To:      "testuser001@example.com",
+		Subject: "Hello\r\nBcc: victim@example.com",
+		Body:    "line one\nline two",
+	})
+	assert.NoError(t, err)
+
+	data := <-received
+	assert.Contains(t, data, "From: no-reply@example.com\r\n")
+	assert.Contains(t, data, "To: testuser001@example.com\r\n")
+	assert.Contains(t, data, "Subject: HelloBcc: victim@example.com\r\n")
+	assert.Contains(t, data, "\r\n\r\nline one\r\nline two")
+}
+
+func TestSMTPMailer_Send_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve a port: %v", err)
+	}
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	portNum, _ := strconv.Atoi(port)
+	m := NewSMTPMailer(&Config{Host: host, Port: portNum, From: "no-reply@example.com"})
+
+	err = m.Send(t.Context(), &Message{To: "testuser001@example.com", Subject: "Hello", Body: "Hi"})
+	assert.Error(t, err)
+}
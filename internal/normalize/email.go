@@ -0,0 +1,62 @@
+package normalize
+
+import "strings"
+
+// emailProvider holds the addressing rules of a mail provider whose mailboxes are reachable under several addresses.
+type emailProvider struct {
+	// domain is the domain all the domains of the provider are canonicalized to, or "" to keep it.
+	domain string
+	// ignoreDots reports whether dots in the local part are ignored.
+	ignoreDots bool
+	// subaddressing reports whether a "+tag" suffix of the local part reaches the same mailbox.
+	subaddressing bool
+}
+
+// emailProviders lists the rules of the common providers by domain.
+// Other domains only get the normalization shared by all addresses, as their rules are unknown.
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true, subaddressing: true},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, subaddressing: true},
+	"outlook.com":    {subaddressing: true},
+	"hotmail.com":    {subaddressing: true},
+	"live.com":       {subaddressing: true},
+	"icloud.com":     {subaddressing: true},
+	"me.com":         {subaddressing: true},
+	"proton.me":      {subaddressing: true},
+	"protonmail.com": {subaddressing: true},
+}
+
+// Email returns the canonical form of an email address: trimmed, Unicode NFKC normalized and case folded, with a
+// trailing dot of the domain dropped. For the known providers, the local part is also reduced to the mailbox it
+// reaches, e.g. "John.Doe+news@googlemail.com" becomes "johndoe@gmail.com".
+//
+// Parameters:
+//   - email: The email address as entered.
+//
+// Returns:
+//   - string: The canonical form of the email address.
+func Email(email string) string {
+	email = fold(strings.TrimSpace(email))
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], strings.TrimSuffix(email[at+1:], ".")
+
+	if provider, ok := emailProviders[domain]; ok {
+		if provider.subaddressing {
+			if tag := strings.IndexByte(local, '+'); tag >= 0 {
+				local = local[:tag]
+			}
+		}
+		if provider.ignoreDots {
+			local = strings.ReplaceAll(local, ".", "")
+		}
+		if provider.domain != "" {
+			domain = provider.domain
+		}
+	}
+
+	return local + "@" + domain
+}
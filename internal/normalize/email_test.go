@@ -0,0 +1,91 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		input string
+
+		expected string
+	}{
+		{
+			name: "lowercase address is kept",
+
+			input: "alice@example.com",
+
+			expected: "alice@example.com",
+		},
+		{
+			name: "case is folded and spaces are trimmed",
+
+			input: " Alice@Example.COM ",
+
+			expected: "alice@example.com",
+		},
+		{
+			name: "trailing dot of the domain is dropped",
+
+			input: "alice@example.com.",
+
+			expected: "alice@example.com",
+		},
+		{
+			name: "dots and tag are dropped for gmail",
+
+			input: "John.Doe+news@gmail.com",
+
+			expected: "johndoe@gmail.com",
+		},
+		{
+			name: "googlemail is gmail",
+
+			input: "john.doe@googlemail.com",
+
+			expected: "johndoe@gmail.com",
+		},
+		{
+			name: "tag is dropped for outlook but dots are kept",
+
+			input: "john.doe+shop@outlook.com",
+
+			expected: "john.doe@outlook.com",
+		},
+		{
+			name: "tag and dots are kept for unknown domains",
+
+			input: "john.doe+shop@example.com",
+
+			expected: "john.doe+shop@example.com",
+		},
+		{
+			name: "compatibility characters are normalized",
+
+			input: "ａｌｉｃｅ@example.com",
+
+			expected: "alice@example.com",
+		},
+		{
+			name: "address without domain is only folded",
+
+			input: "Alice",
+
+			expected: "alice",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, Email(tc.input))
+		})
+	}
+}
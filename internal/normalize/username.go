@@ -0,0 +1,27 @@
+// Package normalize derives the canonical forms of the usernames and email addresses used to tell whether two
+// accounts collide. The canonical forms are only compared and indexed; users keep the spelling they chose.
+package normalize
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Username returns the canonical form of a username: trimmed, Unicode NFKC normalized and case folded, so that
+// "Alice", " alice " and "ａｌｉｃｅ" all collide.
+//
+// Parameters:
+//   - username: The username as entered.
+//
+// Returns:
+//   - string: The canonical form of the username.
+func Username(username string) string {
+	return fold(strings.TrimSpace(username))
+}
+
+// fold applies NFKC normalization and case folding, normalizing again as folding can produce non-normalized text.
+func fold(s string) string {
+	return norm.NFKC.String(cases.Fold().String(norm.NFKC.String(s)))
+}
@@ -0,0 +1,70 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsername(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		input string
+
+		expected string
+	}{
+		{
+			name: "lowercase username is kept",
+
+			input: "alice",
+
+			expected: "alice",
+		},
+		{
+			name: "case is folded",
+
+			input: "Alice",
+
+			expected: "alice",
+		},
+		{
+			name: "surrounding spaces are trimmed",
+
+			input: "  testuser001 \t",
+
+			expected: "testuser001",
+		},
+		{
+			name: "compatibility characters are normalized",
+
+			input: "Ａｌｉｃｅ",
+
+			expected: "alice",
+		},
+		{
+			name: "composed and decomposed accents collide",
+
+			input: "Jose\u0301",
+
+			expected: "jos\u00e9",
+		},
+		{
+			name: "special casing is folded",
+
+			input: "Straße",
+
+			expected: "strasse",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, Username(tc.input))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+// mailNotifier alerts users by email.
+type mailNotifier struct {
+	mailer mailer.Mailer
+}
+
+// NewMailNotifier creates a notifier sending alerts to the email address of the user.
+//
+// Parameters:
+//   - m: The mailer delivering the alerts
+//
+// Returns:
+//   - Notifier: A new email notifier instance
+func NewMailNotifier(m mailer.Mailer) Notifier {
+	return &mailNotifier{
+		mailer: m,
+	}
+}
+
+// NotifyNewDevice emails a user about a login from a new device.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user who logged in.
+//   - event: The successful login from the new device.
+//
+// Returns:
+//   - error: An error if the email cannot be sent, otherwise nil.
+func (n *mailNotifier) NotifyNewDevice(ctx context.Context, user *model.User, event *model.LoginEvent) error {
+	return n.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "New login to your account",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nYour account was just logged in to from a device we have not seen before.\n\nTime: %s\nIP address: %s\nDevice: %s\n\nIf this was you, you can ignore this email. Otherwise, revoke the session from your account settings and change your password.\n",
+			user.DisplayName, event.CreatedAt.UTC().Format(time.RFC1123), event.IPAddress, event.UserAgent,
+		),
+	})
+}
+
+// NotifyEmailChange emails a user, at the address being replaced, about a requested change of their email address.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values and cancellation.
+//   - user: The user whose email address changes, still holding the current address.
+//   - change: The requested email change.
+//   - cancelLink: The link cancelling the change.
+//
+// Returns:
+//   - error: An error if the email cannot be sent, otherwise nil.
+func (n *mailNotifier) NotifyEmailChange(ctx context.Context, user *model.User, change *model.EmailChange, cancelLink string) error {
+	return n.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nA change of the email address of your account to %s was requested. It applies once confirmed from that address.\n\nIf this was not you, cancel the change with the link below, even after it was confirmed, until %s. Then change your password.\n\n%s\n",
+			user.DisplayName, change.NewEmail, change.CancelExpiresAt.UTC().Format(time.RFC1123), cancelLink,
+		),
+	})
+}
@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+	mockMailer "github.com/vukieuhaihoa/user-service/internal/mailer/mocks"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestMailNotifier_NotifyNewDevice(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{DisplayName: "Test User 001", Email: "testuser001@example.com"}
+	event := &model.LoginEvent{
+		Base:      model.Base{CreatedAt: fixture.TestTime},
+		IPAddress: "203.0.113.10",
+		UserAgent: "curl/8.4.0",
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockMailer func(t *testing.T) *mockMailer.Mailer
+
+		expectedError error
+	}{
+		{
+			name: "Email the user about the new device",
+
+			setupMockMailer: func(t *testing.T) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.To == "testuser001@example.com" &&
+						msg.Subject == "New login to your account" &&
+						strings.Contains(msg.Body, "Hi Test User 001,") &&
+						strings.Contains(msg.Body, "Time: Sun, 01 Jan 2023 00:00:00 UTC") &&
+						strings.Contains(msg.Body, "IP address: 203.0.113.10") &&
+						strings.Contains(msg.Body, "Device: curl/8.4.0")
+				})).Return(nil)
+				return mailerMock
+			},
+		},
+		{
+			name: "Notify failed - mailer error",
+
+			setupMockMailer: func(t *testing.T) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", mock.Anything, mock.Anything).Return(assert.AnError)
+				return mailerMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testNotifier := NewMailNotifier(tc.setupMockMailer(t))
+
+			err := testNotifier.NotifyNewDevice(t.Context(), user, event)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestMailNotifier_NotifyEmailChange(t *testing.T) {
+	t.Parallel()
+
+	user := &model.User{DisplayName: "Test User 001", Email: "testuser001@example.com"}
+	change := &model.EmailChange{
+		NewEmail:        "testuser001new@example.com",
+		CancelExpiresAt: fixture.TestTime,
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMockMailer func(t *testing.T) *mockMailer.Mailer
+
+		expectedError error
+	}{
+		{
+			name: "Email the old address about the change",
+
+			setupMockMailer: func(t *testing.T) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.To == "testuser001@example.com" &&
+						msg.Subject == "Your email address is being changed" &&
+						strings.Contains(msg.Body, "Hi Test User 001,") &&
+						strings.Contains(msg.Body, "to testuser001new@example.com") &&
+						strings.Contains(msg.Body, "until Sun, 01 Jan 2023 00:00:00 UTC") &&
+						strings.Contains(msg.Body, "https://example.com/email-change/cancel?token=abc")
+				})).Return(nil)
+				return mailerMock
+			},
+		},
+		{
+			name: "Notify failed - mailer error",
+
+			setupMockMailer: func(t *testing.T) *mockMailer.Mailer {
+				mailerMock := mockMailer.NewMailer(t)
+				mailerMock.On("Send", mock.Anything, mock.Anything).Return(assert.AnError)
+				return mailerMock
+			},
+
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testNotifier := NewMailNotifier(tc.setupMockMailer(t))
+
+			err := testNotifier.NotifyEmailChange(t.Context(), user, change, "https://example.com/email-change/cancel?token=abc")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
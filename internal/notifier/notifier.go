@@ -0,0 +1,39 @@
+// Package notifier alerts users about security relevant activity on their account.
+// The transport is pluggable: the service depends on the Notifier interface only,
+// and the default implementation sends an email through the configured mailer.
+package notifier
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+)
+
+// Notifier defines the contract for alerting users.
+//
+//go:generate mockery --name=Notifier --filename=notifier.go --output=./mocks
+type Notifier interface {
+	// NotifyNewDevice tells a user their account was logged in to from a device never seen before.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user who logged in.
+	//   - event: The successful login from the new device.
+	//
+	// Returns:
+	//   - error: An error if the alert cannot be delivered, otherwise nil.
+	NotifyNewDevice(ctx context.Context, user *model.User, event *model.LoginEvent) error
+
+	// NotifyEmailChange tells a user a change of their email address was requested, at the address being replaced.
+	// The notice carries the link cancelling the change, which also reverts it once confirmed.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - user: The user whose email address changes, still holding the current address.
+	//   - change: The requested email change.
+	//   - cancelLink: The link cancelling the change.
+	//
+	// Returns:
+	//   - error: An error if the notice cannot be delivered, otherwise nil.
+	NotifyEmailChange(ctx context.Context, user *model.User, change *model.EmailChange, cancelLink string) error
+}
@@ -0,0 +1,132 @@
+package passwordhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// Argon2Params are the cost parameters of Argon2id.
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// argon2idHasher hashes passwords with Argon2id.
+// Hashes use the PHC string format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>,
+// salt and key being unpadded standard base64.
+type argon2idHasher struct {
+	params Argon2Params
+}
+
+// NewArgon2id creates an Argon2id hasher.
+//
+// Parameters:
+//   - params: The cost parameters of new hashes
+//
+// Returns:
+//   - Hasher: A new Argon2id hasher
+//   - error: ErrInvalidParameters if a parameter is 0 or the memory is below 8 KiB per lane
+func NewArgon2id(params Argon2Params) (Hasher, error) {
+	if params.Iterations == 0 || params.Parallelism == 0 || params.Memory < 8*uint32(params.Parallelism) {
+		return nil, ErrInvalidParameters
+	}
+
+	return &argon2idHasher{params: params}, nil
+}
+
+// Prefix returns the prefix of Argon2id hashes.
+func (a *argon2idHasher) Prefix() string {
+	return argon2idPrefix
+}
+
+// Recognizes reports whether a hash is an Argon2id hash.
+func (a *argon2idHasher) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, argon2idPrefix)
+}
+
+// Hash hashes a password with Argon2id, a random salt and the parameters of the hasher.
+func (a *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, a.params.Memory, a.params.Iterations, a.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify checks a password against an Argon2id hash, with the parameters stored in the hash.
+func (a *argon2idHasher) Verify(hashedPassword, password string) bool {
+	decoded, ok := decodeArgon2id(hashedPassword)
+	if !ok {
+		return false
+	}
+
+	key := argon2.IDKey([]byte(password), decoded.salt, decoded.params.Iterations, decoded.params.Memory, decoded.params.Parallelism, uint32(len(decoded.key)))
+
+	return subtle.ConstantTimeCompare(key, decoded.key) == 1
+}
+
+// NeedsRehash reports whether an Argon2id hash was made with other parameters than those of the hasher.
+func (a *argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	decoded, ok := decodeArgon2id(hashedPassword)
+	if !ok {
+		return false
+	}
+
+	return decoded.params != a.params || len(decoded.salt) != argon2SaltLength || len(decoded.key) != argon2KeyLength
+}
+
+// argon2idHash is a decoded Argon2id hash.
+type argon2idHash struct {
+	params Argon2Params
+	salt   []byte
+	key    []byte
+}
+
+// decodeArgon2id parses an Argon2id hash of the current version.
+func decodeArgon2id(hashedPassword string) (*argon2idHash, bool) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, false
+	}
+
+	decoded := &argon2idHash{}
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &decoded.params.Memory, &decoded.params.Iterations, &decoded.params.Parallelism)
+	if err != nil || decoded.params.Iterations == 0 || decoded.params.Parallelism == 0 {
+		return nil, false
+	}
+
+	decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, false
+	}
+
+	decoded.key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(decoded.key) == 0 {
+		return nil, false
+	}
+
+	return decoded, true
+}
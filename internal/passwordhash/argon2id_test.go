@@ -0,0 +1,49 @@
+package passwordhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgon2id(t *testing.T) {
+	t.Parallel()
+
+	testHasher, err := NewArgon2id(Argon2Params{Memory: 64, Iterations: 2, Parallelism: 1})
+	assert.Nil(t, err)
+
+	hash, err := testHasher.Hash("my_SECURE_password123@")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=64,t=2,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`, hash)
+	assert.True(t, testHasher.Recognizes(hash))
+	assert.True(t, testHasher.Verify(hash, "my_SECURE_password123@"))
+	assert.False(t, testHasher.Verify(hash, "wrong_password"))
+	assert.False(t, testHasher.NeedsRehash(hash))
+
+	// Every hash has its own salt
+	otherHash, err := testHasher.Hash("my_SECURE_password123@")
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	// Hashes made with other parameters are verified with their own, then upgraded
+	weakerHasher, err := NewArgon2id(Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1})
+	assert.Nil(t, err)
+	weakerHash, err := weakerHasher.Hash("my_SECURE_password123@")
+	assert.Nil(t, err)
+	assert.True(t, testHasher.Verify(weakerHash, "my_SECURE_password123@"))
+	assert.True(t, testHasher.NeedsRehash(weakerHash))
+
+	for _, malformed := range []string{
+		"$argon2id$v=19$m=64,t=2,p=1$c2FsdA",
+		"$argon2id$v=16$m=64,t=2,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=2,p=1$!!!$a2V5",
+		"$argon2i$v=19$m=64,t=2,p=1$c2FsdA$a2V5",
+	} {
+		assert.False(t, testHasher.Verify(malformed, "my_SECURE_password123@"), malformed)
+		assert.False(t, testHasher.NeedsRehash(malformed), malformed)
+	}
+
+	_, err = NewArgon2id(Argon2Params{Memory: 4, Iterations: 1, Parallelism: 1})
+	assert.Equal(t, ErrInvalidParameters, err)
+}
@@ -0,0 +1,69 @@
+package passwordhash
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptPrefix is the prefix shared by the bcrypt hash versions.
+const bcryptPrefix = "$2"
+
+// bcryptHasher hashes passwords with bcrypt.
+type bcryptHasher struct {
+	cost int
+}
+
+// NewBcrypt creates a bcrypt hasher.
+//
+// Parameters:
+//   - cost: The bcrypt cost of new hashes
+//
+// Returns:
+//   - Hasher: A new bcrypt hasher
+//   - error: ErrInvalidParameters if the cost is out of the bcrypt range
+func NewBcrypt(cost int) (Hasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, ErrInvalidParameters
+	}
+
+	return &bcryptHasher{cost: cost}, nil
+}
+
+// Prefix returns the prefix of bcrypt hashes.
+func (b *bcryptHasher) Prefix() string {
+	return bcryptPrefix
+}
+
+// Recognizes reports whether a hash is a bcrypt hash, of any of the $2a$, $2b$ and $2y$ versions.
+func (b *bcryptHasher) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2a$") ||
+		strings.HasPrefix(hashedPassword, "$2b$") ||
+		strings.HasPrefix(hashedPassword, "$2y$")
+}
+
+// Hash hashes a password with bcrypt at the cost of the hasher.
+func (b *bcryptHasher) Hash(password string) (string, error) {
+	hashBytes, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashBytes), nil
+}
+
+// Verify checks a password against a bcrypt hash.
+func (b *bcryptHasher) Verify(hashedPassword, password string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return err == nil
+}
+
+// NeedsRehash reports whether a bcrypt hash has a lower cost than the hasher.
+func (b *bcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false
+	}
+
+	return cost < b.cost
+}
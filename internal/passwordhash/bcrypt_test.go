@@ -0,0 +1,38 @@
+package passwordhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcrypt(t *testing.T) {
+	t.Parallel()
+
+	testHasher, err := NewBcrypt(bcrypt.MinCost + 1)
+	assert.Nil(t, err)
+
+	hash, err := testHasher.Hash("my_SECURE_password123@")
+	assert.Nil(t, err)
+	assert.True(t, testHasher.Recognizes(hash))
+	assert.True(t, testHasher.Verify(hash, "my_SECURE_password123@"))
+	assert.False(t, testHasher.Verify(hash, "wrong_password"))
+	assert.False(t, testHasher.NeedsRehash(hash))
+
+	// Hashes of a lower cost are upgraded, those of a higher cost are kept
+	cheaperHash, err := bcrypt.GenerateFromPassword([]byte("my_SECURE_password123@"), bcrypt.MinCost)
+	assert.Nil(t, err)
+	assert.True(t, testHasher.Verify(string(cheaperHash), "my_SECURE_password123@"))
+	assert.True(t, testHasher.NeedsRehash(string(cheaperHash)))
+
+	costlierHash, err := bcrypt.GenerateFromPassword([]byte("my_SECURE_password123@"), bcrypt.MinCost+2)
+	assert.Nil(t, err)
+	assert.False(t, testHasher.NeedsRehash(string(costlierHash)))
+
+	assert.True(t, testHasher.Recognizes("$2y$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e"))
+	assert.False(t, testHasher.Recognizes("$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5"))
+
+	_, err = NewBcrypt(bcrypt.MaxCost + 1)
+	assert.Equal(t, ErrInvalidParameters, err)
+}
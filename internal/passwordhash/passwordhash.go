@@ -0,0 +1,167 @@
+// Package passwordhash hashes and verifies passwords with several algorithms.
+// A registry hashes new passwords with the preferred algorithm and verifies a stored hash
+// with the algorithm recognized from its format, so hashes made with a former algorithm or
+// weaker parameters keep working until they are re-hashed on the next successful login.
+package passwordhash
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const (
+	// AlgorithmBcrypt hashes passwords with bcrypt.
+	AlgorithmBcrypt = "bcrypt"
+
+	// AlgorithmArgon2id hashes passwords with Argon2id.
+	AlgorithmArgon2id = "argon2id"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported password hash algorithm")
+	ErrInvalidParameters    = errors.New("invalid password hash parameters")
+)
+
+// Hasher hashes and verifies passwords with one algorithm.
+type Hasher interface {
+	// Prefix returns the prefix of the hashes of the algorithm, e.g. "$argon2id$".
+	Prefix() string
+
+	// Recognizes reports whether a hash was produced by the algorithm.
+	//
+	// Parameters:
+	//   - hashedPassword: The stored hash
+	//
+	// Returns:
+	//   - bool: true if the hash has the format of the algorithm
+	Recognizes(hashedPassword string) bool
+
+	// Hash hashes a password with the parameters of the hasher.
+	//
+	// Parameters:
+	//   - password: The plain text password
+	//
+	// Returns:
+	//   - string: The encoded hash, carrying the parameters it was made with
+	//   - error: An error if hashing fails, otherwise nil
+	Hash(password string) (string, error)
+
+	// Verify checks a password against a hash of the algorithm.
+	//
+	// Parameters:
+	//   - hashedPassword: The stored hash
+	//   - password: The plain text password
+	//
+	// Returns:
+	//   - bool: true if the password matches the hash
+	Verify(hashedPassword, password string) bool
+
+	// NeedsRehash reports whether a hash of the algorithm was made with other parameters than those of the hasher.
+	//
+	// Parameters:
+	//   - hashedPassword: The stored hash
+	//
+	// Returns:
+	//   - bool: true if the hash should be replaced
+	NeedsRehash(hashedPassword string) bool
+}
+
+// PasswordHashing hashes passwords with the preferred algorithm and verifies them with any registered one.
+// It extends utils.PasswordHashing with the checks needed to upgrade stored hashes.
+//
+//go:generate mockery --name=PasswordHashing --filename=password_hashing.go --output=./mocks
+type PasswordHashing interface {
+	// Hash hashes a password with the preferred algorithm.
+	//
+	// Parameters:
+	//   - password: The plain text password
+	//
+	// Returns:
+	//   - string: The encoded hash
+	//   - error: utils.ErrCannotGenerateHash if hashing fails, otherwise nil
+	Hash(password string) (string, error)
+
+	// CompareHashAndPassword verifies a password with the algorithm recognized from the hash.
+	//
+	// Parameters:
+	//   - hashedPassword: The stored hash
+	//   - password: The plain text password
+	//
+	// Returns:
+	//   - bool: true if the password matches, false if it does not or the hash format is unknown
+	CompareHashAndPassword(hashedPassword, password string) bool
+
+	// NeedsRehash reports whether a hash should be replaced by a hash of the preferred algorithm and parameters.
+	// Hashes of an unknown format cannot be verified and are never reported.
+	//
+	// Parameters:
+	//   - hashedPassword: The stored hash
+	//
+	// Returns:
+	//   - bool: true if the password should be hashed again once verified
+	NeedsRehash(hashedPassword string) bool
+
+	// PreferredPrefix returns the prefix of the hashes of the preferred algorithm.
+	// Stored hashes without it are legacy hashes.
+	PreferredPrefix() string
+}
+
+// Config holds the password hashing settings, read from PASSWORD_HASH_* environment variables.
+type Config struct {
+	Algorithm            string        `envconfig:"ALGORITHM" default:"argon2id"`
+	BcryptCost           int           `envconfig:"BCRYPT_COST" default:"10"`
+	Argon2Memory         uint32        `envconfig:"ARGON2_MEMORY" default:"65536"` // KiB
+	Argon2Iterations     uint32        `envconfig:"ARGON2_ITERATIONS" default:"3"`
+	Argon2Parallelism    uint8         `envconfig:"ARGON2_PARALLELISM" default:"4"`
+	LegacyReportInterval time.Duration `envconfig:"LEGACY_REPORT_INTERVAL" default:"1h"`
+}
+
+// NewConfig loads the password hashing configuration from the environment.
+//
+// Returns:
+//   - *Config: The loaded configuration
+//   - error: An error if a variable cannot be parsed, otherwise nil
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	err := envconfig.Process("PASSWORD_HASH", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// New builds a registry preferring the configured algorithm and verifying both bcrypt and Argon2id hashes.
+//
+// Parameters:
+//   - cfg: The password hashing configuration
+//
+// Returns:
+//   - PasswordHashing: The registry
+//   - error: ErrUnsupportedAlgorithm or ErrInvalidParameters if the configuration is invalid
+func New(cfg *Config) (PasswordHashing, error) {
+	bcryptHasher, err := NewBcrypt(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	argon2idHasher, err := NewArgon2id(Argon2Params{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmArgon2id:
+		return NewRegistry(argon2idHasher, bcryptHasher), nil
+	case AlgorithmBcrypt:
+		return NewRegistry(bcryptHasher, argon2idHasher), nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
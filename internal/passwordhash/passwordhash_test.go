@@ -0,0 +1,70 @@
+package passwordhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputConfig *Config
+
+		expectedPrefix string
+		expectedError  error
+	}{
+		{
+			name: "Argon2id preferred",
+
+			inputConfig: &Config{Algorithm: AlgorithmArgon2id, BcryptCost: 10, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1},
+
+			expectedPrefix: "$argon2id$",
+		},
+		{
+			name: "Bcrypt preferred",
+
+			inputConfig: &Config{Algorithm: AlgorithmBcrypt, BcryptCost: 10, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1},
+
+			expectedPrefix: "$2",
+		},
+		{
+			name: "Unsupported algorithm",
+
+			inputConfig: &Config{Algorithm: "scrypt", BcryptCost: 10, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1},
+
+			expectedError: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "Invalid bcrypt cost",
+
+			inputConfig: &Config{Algorithm: AlgorithmArgon2id, BcryptCost: 40, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1},
+
+			expectedError: ErrInvalidParameters,
+		},
+		{
+			name: "Invalid Argon2id parameters",
+
+			inputConfig: &Config{Algorithm: AlgorithmArgon2id, BcryptCost: 10, Argon2Memory: 64, Argon2Iterations: 0, Argon2Parallelism: 1},
+
+			expectedError: ErrInvalidParameters,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testHashing, err := New(tc.inputConfig)
+			assert.Equal(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expectedPrefix, testHashing.PreferredPrefix())
+		})
+	}
+}
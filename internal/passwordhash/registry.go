@@ -0,0 +1,95 @@
+package passwordhash
+
+import (
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+)
+
+// registry hashes with the preferred hasher and verifies with the hasher recognizing a hash.
+type registry struct {
+	preferred Hasher
+	hashers   []Hasher
+}
+
+// NewRegistry creates a registry hashing new passwords with the preferred hasher.
+//
+// Parameters:
+//   - preferred: The hasher of new passwords
+//   - legacy: The hashers of formerly stored hashes, which are only verified
+//
+// Returns:
+//   - PasswordHashing: A new registry instance
+func NewRegistry(preferred Hasher, legacy ...Hasher) PasswordHashing {
+	return &registry{
+		preferred: preferred,
+		hashers:   append([]Hasher{preferred}, legacy...),
+	}
+}
+
+// Hash hashes a password with the preferred hasher.
+//
+// Parameters:
+//   - password: The plain text password
+//
+// Returns:
+//   - string: The encoded hash
+//   - error: utils.ErrCannotGenerateHash if hashing fails, otherwise nil
+func (r *registry) Hash(password string) (string, error) {
+	hashedPassword, err := r.preferred.Hash(password)
+	if err != nil {
+		return "", utils.ErrCannotGenerateHash
+	}
+
+	return hashedPassword, nil
+}
+
+// CompareHashAndPassword verifies a password with the hasher recognizing the hash.
+//
+// Parameters:
+//   - hashedPassword: The stored hash
+//   - password: The plain text password
+//
+// Returns:
+//   - bool: true if the password matches, false if it does not or the hash format is unknown
+func (r *registry) CompareHashAndPassword(hashedPassword, password string) bool {
+	hasher := r.hasherOf(hashedPassword)
+	if hasher == nil {
+		return false
+	}
+
+	return hasher.Verify(hashedPassword, password)
+}
+
+// NeedsRehash reports whether a hash was made by another hasher than the preferred one, or with other parameters.
+//
+// Parameters:
+//   - hashedPassword: The stored hash
+//
+// Returns:
+//   - bool: true if the password should be hashed again once verified
+func (r *registry) NeedsRehash(hashedPassword string) bool {
+	hasher := r.hasherOf(hashedPassword)
+	if hasher == nil {
+		return false
+	}
+	if hasher != r.preferred {
+		return true
+	}
+
+	return hasher.NeedsRehash(hashedPassword)
+}
+
+// PreferredPrefix returns the prefix of the hashes of the preferred hasher.
+func (r *registry) PreferredPrefix() string {
+	return r.preferred.Prefix()
+}
+
+// hasherOf returns the hasher recognizing a hash, or nil if none does.
+func (r *registry) hasherOf(hashedPassword string) Hasher {
+	for _, hasher := range r.hashers {
+		if hasher.Recognizes(hashedPassword) {
+			return hasher
+		}
+	}
+
+	return nil
+}
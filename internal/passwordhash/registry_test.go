@@ -0,0 +1,82 @@
+package passwordhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	argon2idHasher, err := NewArgon2id(Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1})
+	assert.Nil(t, err)
+	bcryptHasher, err := NewBcrypt(bcrypt.MinCost)
+	assert.Nil(t, err)
+
+	legacyHash, err := bcryptHasher.Hash("my_SECURE_password123@")
+	assert.Nil(t, err)
+
+	testRegistry := NewRegistry(argon2idHasher, bcryptHasher)
+
+	preferredHash, err := testRegistry.Hash("my_SECURE_password123@")
+	assert.Nil(t, err)
+	assert.True(t, argon2idHasher.Recognizes(preferredHash))
+
+	testCases := []struct {
+		name string
+
+		inputHash     string
+		inputPassword string
+
+		expectedMatch       bool
+		expectedNeedsRehash bool
+	}{
+		{
+			name: "Preferred hash",
+
+			inputHash:     preferredHash,
+			inputPassword: "my_SECURE_password123@",
+
+			expectedMatch: true,
+		},
+		{
+			name: "Legacy hash",
+
+			inputHash:     legacyHash,
+			inputPassword: "my_SECURE_password123@",
+
+			expectedMatch:       true,
+			expectedNeedsRehash: true,
+		},
+		{
+			name: "Wrong password",
+
+			inputHash:     legacyHash,
+			inputPassword: "wrong_password",
+
+			expectedNeedsRehash: true,
+		},
+		{
+			name: "Unknown format",
+
+			inputHash:     "$scrypt$ln=16,r=8,p=1$c2FsdA$a2V5",
+			inputPassword: "my_SECURE_password123@",
+		},
+		{
+			name: "No password",
+
+			inputPassword: "my_SECURE_password123@",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedMatch, testRegistry.CompareHashAndPassword(tc.inputHash, tc.inputPassword))
+			assert.Equal(t, tc.expectedNeedsRehash, testRegistry.NeedsRehash(tc.inputHash))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package ratepolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// increaseScript increments counters, KEYS, and sets the expiration of the ones it creates, ARGV in milliseconds,
+// returning their counts. Running as a script, the counters of a request are increased at once.
+var increaseScript = redis.NewScript(`
+local counts = {}
+for i, key in ipairs(KEYS) do
+	counts[i] = redis.call("INCR", key)
+	if counts[i] == 1 then
+		redis.call("PEXPIRE", key, ARGV[i])
+	end
+end
+return counts
+`)
+
+// CounterStore keeps the request counters of the policies.
+type CounterStore interface {
+	// Increase increments counters at once, setting the expiration of the ones it creates.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values and cancellation.
+	//   - keys: The keys of the counters.
+	//   - ttls: The expiration of each counter, for a counter created by the increment.
+	//
+	// Returns:
+	//   - []int: The counts of the counters after the increment, in the order of the keys.
+	//   - error: An error if the counters cannot be increased, otherwise nil.
+	Increase(ctx context.Context, keys []string, ttls []time.Duration) ([]int, error)
+}
+
+// redisCounterStore is the Redis implementation of the CounterStore interface.
+type redisCounterStore struct {
+	redisClient *redis.Client
+}
+
+// NewRedisCounterStore creates a counter store keeping the counters in Redis, shared by all instances.
+//
+// Parameters:
+//   - redisClient: The Redis client.
+//
+// Returns:
+//   - CounterStore: A new counter store instance.
+func NewRedisCounterStore(redisClient *redis.Client) CounterStore {
+	return &redisCounterStore{
+		redisClient: redisClient,
+	}
+}
+
+// Increase increments counters at once, setting the expiration of the ones it creates.
+func (r *redisCounterStore) Increase(ctx context.Context, keys []string, ttls []time.Duration) ([]int, error) {
+	args := make([]any, 0, len(ttls))
+	for _, ttl := range ttls {
+		args = append(args, max(ttl.Milliseconds(), 1))
+	}
+
+	counts, err := increaseScript.Run(ctx, r.redisClient, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]int, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, int(count))
+	}
+
+	return result, nil
+}
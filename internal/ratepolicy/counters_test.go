@@ -0,0 +1,80 @@
+package ratepolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+)
+
+func TestRedisCounterStore_Increase(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context) *redis.Client
+
+		expectedCounts []int
+		expectedTTLs   []time.Duration
+		expectedError  error
+	}{
+		{
+			name: "Create the counters with their expiration",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+
+			expectedCounts: []int{1, 1},
+			expectedTTLs:   []time.Duration{time.Minute, time.Second},
+		},
+		{
+			name: "Increase existing counters without extending them",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Set(ctx, "counter-minute", 4, 30*time.Second)
+				return redisClient
+			},
+
+			expectedCounts: []int{5, 1},
+			expectedTTLs:   []time.Duration{30 * time.Second, time.Second},
+		},
+		{
+			name: "Closed Redis client",
+
+			setupRedis: func(ctx context.Context) *redis.Client {
+				redisClient := redisPkg.InitMockRedis(t)
+				redisClient.Close()
+				return redisClient
+			},
+
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := tc.setupRedis(ctx)
+			keys := []string{"counter-minute", "counter-second"}
+
+			counts, err := NewRedisCounterStore(redisClient).Increase(ctx, keys, []time.Duration{time.Minute, time.Second})
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedCounts, counts)
+			if err != nil {
+				return
+			}
+
+			for i, key := range keys {
+				assert.Equal(t, tc.expectedTTLs[i], redisClient.TTL(ctx, key).Val(), key)
+			}
+		})
+	}
+}
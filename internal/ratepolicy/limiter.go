@@ -0,0 +1,285 @@
+package ratepolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog/log"
+	middleware "github.com/vukieuhaihoa/bookmark-libs/middlewares"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/normalize"
+)
+
+const (
+	// CounterKeyFormat formats the keys of the counters from the route, the key, the client, the window in seconds
+	// and the Unix time the window starts at.
+	CounterKeyFormat = "rate_limit:%s:%s:%s:%d:%d"
+
+	// maxUsernameBodySize is the size of the request bodies read for a username, larger ones being counted by IP
+	// address.
+	maxUsernameBodySize = 64 << 10
+)
+
+var (
+	// DefaultIPPolicy is the fallback of the public routes, counting the requests of an IP address across all of them.
+	DefaultIPPolicy = Policy{
+		Route:  AnyRoute,
+		Key:    KeyIP,
+		Limit:  middleware.IPRateLimitMaxCount,
+		Window: middleware.IPRateLimitInterval,
+	}
+
+	// DefaultUserIDPolicy is the fallback of the authenticated routes, counting the requests of a user across all of them.
+	DefaultUserIDPolicy = Policy{
+		Route:  AnyRoute,
+		Key:    KeyUserID,
+		Limit:  middleware.UserIDRateLimitMaxCount,
+		Window: middleware.UserIDRateLimitInterval,
+	}
+)
+
+// Limiter limits the request rate of routes with a table of policies.
+type Limiter interface {
+	// Limit returns a Gin middleware limiting the requests of a route with its policies and the fallback policies.
+	// The counters of the request are increased at once, and the request is accepted if every count fits its
+	// policy, otherwise it is rejected with a 429 Too Many Requests status and a Retry-After header; both carry the
+	// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of the most restrictive policy. Rejected
+	// requests count as well. Counters that cannot be increased are logged and let the request through.
+	//
+	// Parameters:
+	//   - fallback: The policies shared by all the routes, in addition to their own.
+	//
+	// Returns:
+	//   - gin.HandlerFunc: The Gin middleware handler function for rate limiting.
+	Limit(fallback ...Policy) gin.HandlerFunc
+}
+
+// limiter is the concrete implementation of the Limiter interface.
+type limiter struct {
+	store  CounterStore
+	routes map[string]Policies
+	now    func() time.Time
+}
+
+// NewLimiter creates a new limiter.
+//
+// Parameters:
+//   - store: The counter store keeping the counters.
+//   - policies: The policies of the routes.
+//
+// Returns:
+//   - Limiter: A new limiter instance.
+func NewLimiter(store CounterStore, policies Policies) Limiter {
+	routes := make(map[string]Policies)
+	for _, policy := range policies {
+		routes[policy.Route] = append(routes[policy.Route], policy)
+	}
+
+	return &limiter{
+		store:  store,
+		routes: routes,
+		now:    time.Now,
+	}
+}
+
+// CounterKey returns the key of the counter of a policy for a client in the window containing a time.
+//
+// Parameters:
+//   - policy: The policy.
+//   - client: The IP address, the canonical username or the user ID of the client, according to the key of the policy.
+//   - at: A time within the window.
+//
+// Returns:
+//   - string: The key of the counter in the counter store.
+func CounterKey(policy Policy, client string, at time.Time) string {
+	return fmt.Sprintf(CounterKeyFormat, policy.Route, policy.Key, client,
+		int64(policy.Window/time.Second), at.Truncate(policy.Window).Unix())
+}
+
+// counter is the state of a policy for the client of a request.
+type counter struct {
+	key   string
+	limit int
+	count int
+	reset time.Time
+}
+
+// remaining returns the number of requests the client can still make within the window.
+func (c *counter) remaining() int {
+	return max(c.limit-c.count, 0)
+}
+
+// Limit returns a Gin middleware limiting the requests of a route with its policies and the fallback policies.
+func (l *limiter) Limit(fallback ...Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := l.routes[c.Request.Method+" "+c.FullPath()]
+		policies := append(append(Policies{}, route...), fallback...)
+		if len(policies) == 0 {
+			c.Next()
+			return
+		}
+
+		now := l.now()
+		counters := l.counters(c, policies, now)
+		l.increase(c, counters, now)
+
+		// The request is rejected by the exceeded policy which resets last
+		var exceeded *counter
+		for _, ctr := range counters {
+			if ctr.count > ctr.limit && (exceeded == nil || ctr.reset.After(exceeded.reset)) {
+				exceeded = ctr
+			}
+		}
+		if exceeded != nil {
+			nrTx := newrelic.FromContext(c)
+			nrTx.Application().RecordCustomEvent("RateLimitExceeded", map[string]interface{}{
+				"client": c.ClientIP(),
+				"path":   c.FullPath(),
+			})
+
+			setHeaders(c, exceeded, now)
+			c.Header("Retry-After", strconv.Itoa(secondsUntil(exceeded.reset, now)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests. Please try again later."})
+			return
+		}
+
+		// The headers describe the policy with the fewest requests left
+		var tightest *counter
+		for _, ctr := range counters {
+			if tightest == nil || ctr.remaining() < tightest.remaining() {
+				tightest = ctr
+			}
+		}
+		setHeaders(c, tightest, now)
+
+		c.Next()
+	}
+}
+
+// counters returns the counters of the policies for the client of a request, a policy with a burst adding a
+// counter of one second. Policies sharing a counter share its state so that a request counts once.
+func (l *limiter) counters(c *gin.Context, policies Policies, now time.Time) []*counter {
+	var counters []*counter
+	byKey := make(map[string]*counter)
+	for _, policy := range policies {
+		key, client := l.identify(c, policy.Key)
+		policy.Key = key
+
+		windows := []Policy{policy}
+		if policy.Burst > 0 {
+			windows = append(windows, Policy{Route: policy.Route, Key: policy.Key, Limit: policy.Burst, Window: time.Second})
+		}
+
+		for _, window := range windows {
+			counterKey := CounterKey(window, client, now)
+			if ctr, ok := byKey[counterKey]; ok {
+				ctr.limit = min(ctr.limit, window.Limit)
+				continue
+			}
+
+			ctr := &counter{
+				key:   counterKey,
+				limit: window.Limit,
+				reset: now.Truncate(window.Window).Add(window.Window),
+			}
+			byKey[counterKey] = ctr
+			counters = append(counters, ctr)
+		}
+	}
+
+	return counters
+}
+
+// increase counts the request in its counters at once, each counter expiring when its window resets. When the
+// counters cannot be increased, the request is counted as the first of every window.
+func (l *limiter) increase(c *gin.Context, counters []*counter, now time.Time) {
+	keys := make([]string, 0, len(counters))
+	ttls := make([]time.Duration, 0, len(counters))
+	for _, ctr := range counters {
+		keys = append(keys, ctr.key)
+		ttls = append(ttls, ctr.reset.Sub(now))
+	}
+
+	counts, err := l.store.Increase(c, keys, ttls)
+	if err != nil {
+		log.Error().Err(err).Strs("keys", keys).Msg("Failed to increase rate limits")
+	}
+	for i, ctr := range counters {
+		ctr.count = 1
+		if err == nil {
+			ctr.count = counts[i]
+		}
+	}
+}
+
+// identify returns what the client of a request is counted by for a key, falling back to its IP address when
+// the request carries no username or token.
+//
+// Returns:
+//   - string: The key the client is counted by.
+//   - string: The client.
+func (l *limiter) identify(c *gin.Context, key string) (string, string) {
+	switch key {
+	case KeyUsername:
+		if username := usernameFromBody(c); username != "" {
+			return KeyUsername, normalize.Username(username)
+		}
+	case KeyUserID:
+		if userID, err := utils.GetUserIDFromJWTClaims(c); err == nil {
+			return KeyUserID, userID
+		}
+	}
+
+	return KeyIP, c.ClientIP()
+}
+
+// usernameFromBody reads the "username" field of a JSON request body, leaving the body for the handler.
+// Bodies larger than maxUsernameBodySize are not parsed, so that a client cannot make the limiter buffer them.
+func usernameFromBody(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxUsernameBodySize+1))
+	c.Request.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(body), c.Request.Body),
+		Closer: c.Request.Body,
+	}
+	if err != nil || len(body) > maxUsernameBodySize {
+		return ""
+	}
+
+	var payload struct {
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+
+	return payload.Username
+}
+
+// readCloser closes the original body of a request whose read part is put back in front of the rest.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// setHeaders sets the RateLimit-* headers describing a counter.
+func setHeaders(c *gin.Context, ctr *counter, now time.Time) {
+	c.Header("RateLimit-Limit", strconv.Itoa(ctr.limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(ctr.remaining()))
+	c.Header("RateLimit-Reset", strconv.Itoa(secondsUntil(ctr.reset, now)))
+}
+
+// secondsUntil returns the number of seconds until a time, rounded up.
+func secondsUntil(t, now time.Time) int {
+	return int((t.Sub(now) + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,315 @@
+package ratepolicy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+)
+
+// limiterTestNow is 30 seconds into a minute, so minute windows reset in 30 seconds.
+var limiterTestNow = time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC)
+
+var limiterTestPolicies = Policies{
+	{Route: "POST /login", Key: KeyIP, Limit: 10, Window: time.Minute},
+	{Route: "POST /login", Key: KeyUsername, Limit: 3, Window: time.Minute, Burst: 2},
+	{Route: "GET /self", Key: KeyUserID, Limit: 5, Window: time.Hour},
+}
+
+func TestLimiter_Limit(t *testing.T) {
+	t.Parallel()
+
+	loginPolicy := limiterTestPolicies[1]
+	burstPolicy := Policy{Route: loginPolicy.Route, Key: KeyUsername, Limit: 2, Window: time.Second}
+
+	testCases := []struct {
+		name string
+
+		setupRedis func(ctx context.Context, redisClient *redis.Client)
+
+		inputMethod string
+		inputTarget string
+		inputBody   string
+		inputUserID string
+
+		expectedStatus  int
+		expectedHeaders map[string]string
+		expectedCounts  map[string]int
+	}{
+		{
+			name: "First request reports the tightest policy",
+
+			inputMethod: http.MethodPost,
+			inputTarget: "/login",
+			inputBody:   `{"username":"Alice","password":"secret"}`,
+
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": "2", "RateLimit-Remaining": "1", "RateLimit-Reset": "1"},
+			expectedCounts: map[string]int{
+				CounterKey(limiterTestPolicies[0], "192.0.2.1", limiterTestNow): 1,
+				CounterKey(loginPolicy, "alice", limiterTestNow):                1,
+				CounterKey(burstPolicy, "alice", limiterTestNow):                1,
+				CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow):        1,
+			},
+		},
+		{
+			name: "Username exhausted from another address",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, CounterKey(loginPolicy, "alice", limiterTestNow), 3, time.Minute)
+			},
+
+			inputMethod: http.MethodPost,
+			inputTarget: "/login",
+			inputBody:   `{"username":" ALICE ","password":"secret"}`,
+
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "3", "RateLimit-Remaining": "0", "RateLimit-Reset": "30", "Retry-After": "30",
+			},
+			expectedCounts: map[string]int{
+				// The rejected request counts too
+				CounterKey(limiterTestPolicies[0], "192.0.2.1", limiterTestNow): 1,
+				CounterKey(loginPolicy, "alice", limiterTestNow):                4,
+			},
+		},
+		{
+			name: "Burst exhausted",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, CounterKey(burstPolicy, "alice", limiterTestNow), 2, time.Second)
+			},
+
+			inputMethod: http.MethodPost,
+			inputTarget: "/login",
+			inputBody:   `{"username":"alice","password":"secret"}`,
+
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "2", "RateLimit-Remaining": "0", "RateLimit-Reset": "1", "Retry-After": "1",
+			},
+		},
+		{
+			name: "Request without a username counted by address",
+
+			inputMethod: http.MethodPost,
+			inputTarget: "/login",
+			inputBody:   `not json`,
+
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": "2", "RateLimit-Remaining": "1", "RateLimit-Reset": "1"},
+			expectedCounts: map[string]int{
+				// The IP policy and the username policy falling back to the address share the minute counter
+				CounterKey(Policy{Route: "POST /login", Key: KeyIP, Window: time.Minute}, "192.0.2.1", limiterTestNow): 1,
+				CounterKey(Policy{Route: "POST /login", Key: KeyIP, Window: time.Second}, "192.0.2.1", limiterTestNow): 1,
+			},
+		},
+		{
+			name: "Request with a body too large counted by address",
+
+			inputMethod: http.MethodPost,
+			inputTarget: "/login",
+			inputBody:   `{"username":"alice","password":"` + strings.Repeat("a", maxUsernameBodySize) + `"}`,
+
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": "2", "RateLimit-Remaining": "1", "RateLimit-Reset": "1"},
+			expectedCounts: map[string]int{
+				CounterKey(Policy{Route: "POST /login", Key: KeyIP, Window: time.Minute}, "192.0.2.1", limiterTestNow): 1,
+				CounterKey(Policy{Route: "POST /login", Key: KeyIP, Window: time.Second}, "192.0.2.1", limiterTestNow): 1,
+				CounterKey(loginPolicy, "alice", limiterTestNow):                                                       0,
+			},
+		},
+		{
+			name: "Authenticated route counted by user",
+
+			inputMethod: http.MethodGet,
+			inputTarget: "/self",
+			inputUserID: "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": "5", "RateLimit-Remaining": "4", "RateLimit-Reset": "3570"},
+			expectedCounts: map[string]int{
+				CounterKey(limiterTestPolicies[2], "4d9326d6-980c-4c62-9709-dbc70a82cbfe", limiterTestNow): 1,
+			},
+		},
+		{
+			name: "Route policies apply along with the fallback",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow), 100, time.Minute)
+			},
+
+			inputMethod: http.MethodPost,
+			inputTarget: "/login",
+			inputBody:   `{"username":"alice","password":"secret"}`,
+
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "100", "RateLimit-Remaining": "0", "RateLimit-Reset": "30", "Retry-After": "30",
+			},
+		},
+		{
+			name: "Route without policies uses the fallback",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow), 41, time.Minute)
+			},
+
+			inputMethod: http.MethodGet,
+			inputTarget: "/other",
+
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "100", "RateLimit-Remaining": "58", "RateLimit-Reset": "30",
+			},
+			expectedCounts: map[string]int{
+				CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow): 42,
+			},
+		},
+		{
+			name: "Fallback exhausted",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow), 100, time.Minute)
+			},
+
+			inputMethod: http.MethodGet,
+			inputTarget: "/other",
+
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "100", "RateLimit-Remaining": "0", "RateLimit-Reset": "30", "Retry-After": "30",
+			},
+		},
+		{
+			name: "Counters of a previous window are ignored",
+
+			setupRedis: func(ctx context.Context, redisClient *redis.Client) {
+				redisClient.Set(ctx, CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow.Add(-time.Minute)), 100, time.Minute)
+			},
+
+			inputMethod: http.MethodGet,
+			inputTarget: "/other",
+
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Remaining": "99"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			redisClient := redisPkg.InitMockRedis(t)
+			if tc.setupRedis != nil {
+				tc.setupRedis(ctx, redisClient)
+			}
+
+			testLimiter := NewLimiter(NewRedisCounterStore(redisClient), limiterTestPolicies).(*limiter)
+			testLimiter.now = func() time.Time { return limiterTestNow }
+
+			rec := serveLimited(testLimiter, tc.inputMethod, tc.inputTarget, tc.inputBody, tc.inputUserID)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			for header, value := range tc.expectedHeaders {
+				assert.Equal(t, value, rec.Header().Get(header), header)
+			}
+			if tc.expectedStatus == http.StatusTooManyRequests {
+				assert.Equal(t, `{"error":"Too many requests. Please try again later."}`, rec.Body.String())
+			} else {
+				// The handler still reads the body
+				assert.Equal(t, tc.inputBody, rec.Body.String())
+				assert.Empty(t, rec.Header().Get("Retry-After"))
+			}
+
+			for key, expectedCount := range tc.expectedCounts {
+				count, err := redisClient.Get(ctx, key).Int()
+				if expectedCount == 0 {
+					assert.ErrorIs(t, err, redis.Nil, key)
+					continue
+				}
+				assert.NoError(t, err, key)
+				assert.Equal(t, expectedCount, count, key)
+			}
+		})
+	}
+}
+
+func TestLimiter_Limit_StoreError(t *testing.T) {
+	t.Parallel()
+
+	redisClient := redisPkg.InitMockRedis(t)
+	redisClient.Close()
+
+	rec := serveLimited(NewLimiter(NewRedisCounterStore(redisClient), nil), http.MethodGet, "/other", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "99", rec.Header().Get("RateLimit-Remaining"))
+}
+
+func TestLimiter_Limit_ConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	redisClient := redisPkg.InitMockRedis(t)
+	testLimiter := NewLimiter(NewRedisCounterStore(redisClient), nil).(*limiter)
+	testLimiter.now = func() time.Time { return limiterTestNow }
+
+	// Requests racing for the last slots of the budget cannot all take them
+	redisClient.Set(ctx, CounterKey(DefaultIPPolicy, "192.0.2.1", limiterTestNow), 95, time.Minute)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serveLimited(testLimiter, http.MethodGet, "/other", "", "").Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	accepted := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			accepted++
+		}
+	}
+	assert.Equal(t, 5, accepted)
+}
+
+// serveLimited serves a request through a limiter in front of handlers echoing the request body.
+func serveLimited(testLimiter Limiter, method, target, body, userID string) *httptest.ResponseRecorder {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Set("claims", jwt.MapClaims{"sub": userID})
+		}
+	})
+	engine.Use(testLimiter.Limit(DefaultIPPolicy))
+
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	engine.POST("/login", echo)
+	engine.GET("/self", echo)
+	engine.GET("/other", echo)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
@@ -0,0 +1,129 @@
+// Package ratepolicy limits the request rate of routes with a table of policies.
+// A policy counts the requests of a route per client, identified by its IP address, the username in the
+// request body or the user ID of its token, within fixed windows aligned to the clock. Counters are kept
+// in the rate limit repository shared by all instances, and every limited response tells the client its
+// remaining budget through RateLimit-* headers.
+package ratepolicy
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// KeyIP counts the requests of a client IP address.
+	KeyIP = "ip"
+
+	// KeyUsername counts the requests for the username in the JSON request body, e.g. the login attempts
+	// against one account from any address. Requests without a username are counted by IP address.
+	KeyUsername = "username"
+
+	// KeyUserID counts the requests of the authenticated user. Requests without a token are counted by IP address.
+	KeyUserID = "user_id"
+
+	// AnyRoute is the route of the fallback policies shared by all the routes of a group without their own policies.
+	AnyRoute = "*"
+)
+
+var ErrInvalidPolicy = errors.New("invalid rate limit policy")
+
+// Policy limits the requests of a route per client.
+type Policy struct {
+	// Route is the method and the registered path of the route, e.g. "POST /v1/users/login".
+	Route string
+	// Key is what clients are told apart by: KeyIP, KeyUsername or KeyUserID.
+	Key string
+	// Limit is the number of requests accepted within a window.
+	Limit int
+	// Window is the duration of the windows, a whole number of seconds; windows start at multiples of it.
+	Window time.Duration
+	// Burst is the number of requests accepted within one second; 0 does not bound them.
+	Burst int
+}
+
+// Policies is a table of policies. It is read from a list of policies separated by ";", each formatted as
+// "METHOD /path key limit/window [burst]", e.g. "POST /v1/users/login username 5/1m 2".
+type Policies []Policy
+
+// Parse reads a table of policies.
+//
+// Parameters:
+//   - value: The policies separated by ";"; blank entries are ignored
+//
+// Returns:
+//   - Policies: The parsed policies
+//   - error: ErrInvalidPolicy if an entry is malformed, otherwise nil
+func Parse(value string) (Policies, error) {
+	var policies Policies
+	for _, entry := range strings.Split(value, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+
+		policy, err := parsePolicy(fields)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// Decode reads a table of policies from an environment variable.
+func (p *Policies) Decode(value string) error {
+	policies, err := Parse(value)
+	if err != nil {
+		return err
+	}
+
+	*p = policies
+	return nil
+}
+
+// parsePolicy reads the fields of one policy.
+func parsePolicy(fields []string) (Policy, error) {
+	if len(fields) != 4 && len(fields) != 5 {
+		return Policy{}, ErrInvalidPolicy
+	}
+
+	method, path, key := fields[0], fields[1], fields[2]
+	if method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+		return Policy{}, ErrInvalidPolicy
+	}
+	if key != KeyIP && key != KeyUsername && key != KeyUserID {
+		return Policy{}, ErrInvalidPolicy
+	}
+
+	limitValue, windowValue, ok := strings.Cut(fields[3], "/")
+	if !ok {
+		return Policy{}, ErrInvalidPolicy
+	}
+	limit, err := strconv.Atoi(limitValue)
+	if err != nil || limit <= 0 {
+		return Policy{}, ErrInvalidPolicy
+	}
+	window, err := time.ParseDuration(windowValue)
+	if err != nil || window < time.Second || window%time.Second != 0 {
+		return Policy{}, ErrInvalidPolicy
+	}
+
+	burst := 0
+	if len(fields) == 5 {
+		burst, err = strconv.Atoi(fields[4])
+		if err != nil || burst <= 0 {
+			return Policy{}, ErrInvalidPolicy
+		}
+	}
+
+	return Policy{
+		Route:  method + " " + path,
+		Key:    key,
+		Limit:  limit,
+		Window: window,
+		Burst:  burst,
+	}, nil
+}
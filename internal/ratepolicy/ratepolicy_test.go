@@ -0,0 +1,125 @@
+package ratepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputValue string
+
+		expectedPolicies Policies
+		expectedError    error
+	}{
+		{
+			name: "Empty table",
+
+			inputValue: " ",
+		},
+		{
+			name: "Several policies",
+
+			inputValue: "POST /v1/users/login ip 20/1m; POST /v1/users/login username 5/1m 2;GET /v1/self/info user_id 10/1s;",
+
+			expectedPolicies: Policies{
+				{Route: "POST /v1/users/login", Key: KeyIP, Limit: 20, Window: time.Minute},
+				{Route: "POST /v1/users/login", Key: KeyUsername, Limit: 5, Window: time.Minute, Burst: 2},
+				{Route: "GET /v1/self/info", Key: KeyUserID, Limit: 10, Window: time.Second},
+			},
+		},
+		{
+			name: "Route parameters",
+
+			inputValue: "DELETE /v1/self/sessions/:id user_id 5/1h",
+
+			expectedPolicies: Policies{
+				{Route: "DELETE /v1/self/sessions/:id", Key: KeyUserID, Limit: 5, Window: time.Hour},
+			},
+		},
+		{
+			name: "Missing window",
+
+			inputValue: "POST /v1/users/login ip 20",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Missing path",
+
+			inputValue: "POST ip 20/1m",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Lower case method",
+
+			inputValue: "post /v1/users/login ip 20/1m",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Unknown key",
+
+			inputValue: "POST /v1/users/login email 20/1m",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Zero limit",
+
+			inputValue: "POST /v1/users/login ip 0/1m",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Window shorter than a second",
+
+			inputValue: "POST /v1/users/login ip 20/500ms",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Window not in whole seconds",
+
+			inputValue: "POST /v1/users/login ip 20/1500ms",
+
+			expectedError: ErrInvalidPolicy,
+		},
+		{
+			name: "Invalid burst",
+
+			inputValue: "POST /v1/users/login ip 20/1m 0",
+
+			expectedError: ErrInvalidPolicy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			policies, err := Parse(tc.inputValue)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedPolicies, policies)
+		})
+	}
+}
+
+func TestPolicies_Decode(t *testing.T) {
+	t.Parallel()
+
+	var policies Policies
+	err := policies.Decode("POST /v1/users/register ip 5/1h")
+	assert.NoError(t, err)
+	assert.Equal(t, Policies{{Route: "POST /v1/users/register", Key: KeyIP, Limit: 5, Window: time.Hour}}, policies)
+
+	err = policies.Decode("POST /v1/users/register ip")
+	assert.Equal(t, ErrInvalidPolicy, err)
+}
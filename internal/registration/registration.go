@@ -0,0 +1,110 @@
+// Package registration decides who may create an account. The registration mode of a deployment either lets
+// anyone sign up, requires an invitation, limits sign-ups to allowed email domains or stops them altogether.
+// An invitation lets its invitee register in any mode but the closed one.
+package registration
+
+import (
+	"errors"
+	"strings"
+)
+
+// Mode is the registration mode of a deployment.
+type Mode string
+
+const (
+	// ModeOpen lets anyone register.
+	ModeOpen Mode = "open"
+
+	// ModeInviteOnly only lets invitees register.
+	ModeInviteOnly Mode = "invite_only"
+
+	// ModeAllowedDomains lets invitees and owners of an email address in an allowed domain register.
+	ModeAllowedDomains Mode = "allowed_domains"
+
+	// ModeClosed lets no one register, invitees included.
+	ModeClosed Mode = "closed"
+)
+
+var (
+	ErrInvalidMode           = errors.New("invalid registration mode")
+	ErrClosed                = errors.New("registration is closed")
+	ErrInvitationRequired    = errors.New("registration requires an invitation")
+	ErrEmailDomainNotAllowed = errors.New("registration is limited to allowed email domains")
+)
+
+// Decode reads a registration mode from an environment variable.
+func (m *Mode) Decode(value string) error {
+	mode := Mode(strings.TrimSpace(value))
+	switch mode {
+	case "":
+		mode = ModeOpen
+	case ModeOpen, ModeInviteOnly, ModeAllowedDomains, ModeClosed:
+	default:
+		return ErrInvalidMode
+	}
+
+	*m = mode
+	return nil
+}
+
+// IsRejected reports whether an error is a refusal of the registration policy.
+func IsRejected(err error) bool {
+	return errors.Is(err, ErrClosed) || errors.Is(err, ErrInvitationRequired) || errors.Is(err, ErrEmailDomainNotAllowed)
+}
+
+// Policy holds the registration rules of a deployment. The zero value lets anyone register.
+//
+// Fields:
+//   - Mode: The registration mode; empty is the same as ModeOpen.
+//   - AllowedDomains: The email domains accepted in ModeAllowedDomains; subdomains of them are accepted too.
+type Policy struct {
+	Mode           Mode
+	AllowedDomains []string
+}
+
+// Check tells whether an account may be created for an email address.
+//
+// Parameters:
+//   - email: The email address of the new account.
+//   - invited: Whether the registration comes with an invitation; its validity is checked by the invitation service.
+//
+// Returns:
+//   - error: ErrClosed if registration is closed, ErrInvitationRequired if an invitation is required and missing,
+//     ErrEmailDomainNotAllowed if the domain of the email is not allowed and there is no invitation, otherwise nil.
+func (p Policy) Check(email string, invited bool) error {
+	switch p.Mode {
+	case ModeClosed:
+		return ErrClosed
+	case ModeInviteOnly:
+		if !invited {
+			return ErrInvitationRequired
+		}
+	case ModeAllowedDomains:
+		if !invited && !p.domainAllowed(email) {
+			return ErrEmailDomainNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// domainAllowed reports whether the domain of an email address is one of the allowed domains or a subdomain of one.
+func (p Policy) domainAllowed(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(strings.TrimSuffix(email[at+1:], "."))
+	for _, allowed := range p.AllowedDomains {
+		allowed = strings.ToLower(strings.Trim(strings.TrimSpace(allowed), "."))
+		if allowed == "" {
+			continue
+		}
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return true
+		}
+	}
+
+	return false
+}
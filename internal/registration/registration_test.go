@@ -0,0 +1,156 @@
+package registration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMode_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputValue string
+
+		expectedMode  Mode
+		expectedError error
+	}{
+		{
+			name:         "Decode an empty value as the open mode",
+			inputValue:   "",
+			expectedMode: ModeOpen,
+		},
+		{
+			name:         "Decode the invite-only mode",
+			inputValue:   "invite_only",
+			expectedMode: ModeInviteOnly,
+		},
+		{
+			name:         "Decode the allowed domains mode",
+			inputValue:   " allowed_domains ",
+			expectedMode: ModeAllowedDomains,
+		},
+		{
+			name:         "Decode the closed mode",
+			inputValue:   "closed",
+			expectedMode: ModeClosed,
+		},
+		{
+			name:          "Decode failed - unknown mode",
+			inputValue:    "invite-only",
+			expectedError: ErrInvalidMode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mode Mode
+			err := mode.Decode(tc.inputValue)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedMode, mode)
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	allowedDomains := []string{"Example.com", " .corp.example.org "}
+
+	testCases := []struct {
+		name string
+
+		policy       Policy
+		inputEmail   string
+		inputInvited bool
+
+		expectedError error
+	}{
+		{
+			name:       "Zero policy lets anyone register",
+			inputEmail: "someone@gmail.com",
+		},
+		{
+			name:       "Open mode lets anyone register",
+			policy:     Policy{Mode: ModeOpen},
+			inputEmail: "someone@gmail.com",
+		},
+		{
+			name:          "Closed mode refuses everyone",
+			policy:        Policy{Mode: ModeClosed},
+			inputEmail:    "someone@example.com",
+			expectedError: ErrClosed,
+		},
+		{
+			name:          "Closed mode refuses invitees",
+			policy:        Policy{Mode: ModeClosed},
+			inputEmail:    "someone@example.com",
+			inputInvited:  true,
+			expectedError: ErrClosed,
+		},
+		{
+			name:          "Invite-only mode refuses registrations without invitation",
+			policy:        Policy{Mode: ModeInviteOnly},
+			inputEmail:    "someone@example.com",
+			expectedError: ErrInvitationRequired,
+		},
+		{
+			name:         "Invite-only mode lets invitees register",
+			policy:       Policy{Mode: ModeInviteOnly},
+			inputEmail:   "someone@example.com",
+			inputInvited: true,
+		},
+		{
+			name:       "Allowed domains mode accepts an allowed domain case-insensitively",
+			policy:     Policy{Mode: ModeAllowedDomains, AllowedDomains: allowedDomains},
+			inputEmail: "someone@EXAMPLE.com",
+		},
+		{
+			name:       "Allowed domains mode accepts a subdomain of an allowed domain",
+			policy:     Policy{Mode: ModeAllowedDomains, AllowedDomains: allowedDomains},
+			inputEmail: "someone@eu.corp.example.org",
+		},
+		{
+			name:          "Allowed domains mode refuses a domain merely ending like an allowed one",
+			policy:        Policy{Mode: ModeAllowedDomains, AllowedDomains: allowedDomains},
+			inputEmail:    "someone@notexample.com",
+			expectedError: ErrEmailDomainNotAllowed,
+		},
+		{
+			name:          "Allowed domains mode refuses the parent of an allowed domain",
+			policy:        Policy{Mode: ModeAllowedDomains, AllowedDomains: allowedDomains},
+			inputEmail:    "someone@example.org",
+			expectedError: ErrEmailDomainNotAllowed,
+		},
+		{
+			name:         "Allowed domains mode lets invitees of another domain register",
+			policy:       Policy{Mode: ModeAllowedDomains, AllowedDomains: allowedDomains},
+			inputEmail:   "someone@gmail.com",
+			inputInvited: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.Check(tc.inputEmail, tc.inputInvited)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestIsRejected(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsRejected(ErrClosed))
+	assert.True(t, IsRejected(ErrInvitationRequired))
+	assert.True(t, IsRejected(ErrEmailDomainNotAllowed))
+	assert.False(t, IsRejected(ErrInvalidMode))
+	assert.False(t, IsRejected(errors.New("other")))
+}
@@ -0,0 +1,89 @@
+package tenant
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Field is the name of the struct field that makes a model tenant-owned.
+const Field = "TenantID"
+
+// RegisterCallbacks scopes the GORM queries, updates and deletions of the tenant-owned models to the tenant of
+// their context, and stamps it on the tenant-owned rows created without one.
+// Raw SQL is not scoped.
+//
+// Parameters:
+//   - db: The GORM database the callbacks are registered on.
+//
+// Returns:
+//   - error: An error if a callback cannot be registered, otherwise nil.
+func RegisterCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:assign", assign); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:scope", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:scope", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenant:scope", scope); err != nil {
+		return err
+	}
+
+	return callbacks.Row().Before("gorm:row").Register("tenant:scope", scope)
+}
+
+// tenantField returns the tenant field of the model of a statement, or nil if the model is not tenant-owned.
+func tenantField(db *gorm.DB) *schema.Field {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil
+	}
+
+	return db.Statement.Schema.LookUpField(Field)
+}
+
+// scope restricts a statement on a tenant-owned model to the rows of the tenant of its context.
+func scope(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil || isAllTenants(db.Statement.Context) {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName},
+			Value:  FromContext(db.Statement.Context),
+		},
+	}})
+}
+
+// assign stamps the tenant of the context on the tenant-owned rows being created without one.
+func assign(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	tenantID := FromContext(ctx)
+	set := func(rv reflect.Value) {
+		if _, isZero := field.ValueOf(ctx, rv); isZero {
+			db.AddError(field.Set(ctx, rv, tenantID))
+		}
+	}
+
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			set(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		set(rv)
+	}
+}
@@ -0,0 +1,120 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/sqldb"
+	"gorm.io/gorm"
+)
+
+// testOwned is a tenant-owned model.
+type testOwned struct {
+	ID       string `gorm:"primaryKey"`
+	TenantID string
+	Name     string
+}
+
+// testShared is a model shared by every tenant.
+type testShared struct {
+	ID   string `gorm:"primaryKey"`
+	Name string
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db := sqldb.InitMockDB(t)
+	if !assert.Nil(t, RegisterCallbacks(db)) || !assert.Nil(t, db.AutoMigrate(&testOwned{}, &testShared{})) {
+		t.FailNow()
+	}
+
+	brandA := NewContext(context.Background(), "brand_a")
+	assert.Nil(t, db.Create(&testOwned{ID: "default-1", Name: "one"}).Error)
+	assert.Nil(t, db.WithContext(brandA).Create([]*testOwned{{ID: "brand-a-1", Name: "one"}, {ID: "brand-a-2", Name: "two"}}).Error)
+	assert.Nil(t, db.WithContext(brandA).Create(&testShared{ID: "shared-1", Name: "one"}).Error)
+
+	return db
+}
+
+func TestCallbacks_Create(t *testing.T) {
+	t.Parallel()
+
+	db := setupTestDB(t)
+
+	var tenants []string
+	err := db.WithContext(WithAllTenants(t.Context())).Model(&testOwned{}).Order("id").Pluck("tenant_id", &tenants).Error
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"brand_a", "brand_a", Default}, tenants)
+}
+
+func TestCallbacks_Scope(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputCtx func(ctx context.Context) context.Context
+
+		expectedIDs     []string
+		expectedUpdated int64
+	}{
+		{
+			name: "Scope to the default tenant without a tenant in the context",
+
+			inputCtx: func(ctx context.Context) context.Context {
+				return ctx
+			},
+
+			expectedIDs:     []string{"default-1"},
+			expectedUpdated: 1,
+		},
+		{
+			name: "Scope to the tenant of the context",
+
+			inputCtx: func(ctx context.Context) context.Context {
+				return NewContext(ctx, "brand_a")
+			},
+
+			expectedIDs:     []string{"brand-a-1", "brand-a-2"},
+			expectedUpdated: 1,
+		},
+		{
+			name: "Lift the scope for every tenant",
+
+			inputCtx: WithAllTenants,
+
+			expectedIDs:     []string{"brand-a-1", "brand-a-2", "default-1"},
+			expectedUpdated: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := setupTestDB(t).WithContext(tc.inputCtx(t.Context()))
+
+			var ids []string
+			err := db.Model(&testOwned{}).Order("id").Pluck("id", &ids).Error
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedIDs, ids)
+
+			// Rows of another tenant can be neither updated nor deleted
+			res := db.Model(&testOwned{}).Where("name = ?", "one").Update("name", "updated")
+			assert.Nil(t, res.Error)
+			assert.Equal(t, tc.expectedUpdated, res.RowsAffected)
+
+			res = db.Where("name = ?", "updated").Delete(&testOwned{})
+			assert.Nil(t, res.Error)
+			assert.Equal(t, tc.expectedUpdated, res.RowsAffected)
+
+			var count int64
+			assert.Nil(t, db.WithContext(WithAllTenants(t.Context())).Model(&testOwned{}).Count(&count).Error)
+			assert.Equal(t, 3-tc.expectedUpdated, count)
+
+			// Shared models are not scoped
+			assert.Nil(t, db.Model(&testShared{}).Count(&count).Error)
+			assert.Equal(t, int64(1), count)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package tenant
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/common"
+)
+
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// Resolver assigns requests to tenants.
+type Resolver struct {
+	hosts       map[string]string
+	tenants     map[string]bool
+	trustHeader bool
+}
+
+// NewResolver creates a resolver assigning requests to tenants by hostname, then to Default.
+//
+// Parameters:
+//   - hosts: The tenants of the hostnames, e.g. {"brand-a.example.com": "brand_a"}; ports are ignored.
+//   - trustHeader: Whether the Header of a request chooses its tenant over the hostname. It must only be set behind
+//     a proxy that sets or strips the header, as any client can send it.
+//
+// Returns:
+//   - *Resolver: A new resolver instance.
+func NewResolver(hosts map[string]string, trustHeader bool) *Resolver {
+	r := &Resolver{
+		hosts:       make(map[string]string, len(hosts)),
+		tenants:     map[string]bool{Default: true},
+		trustHeader: trustHeader,
+	}
+	for host, tenantID := range hosts {
+		r.hosts[strings.ToLower(strings.TrimSpace(host))] = strings.TrimSpace(tenantID)
+		r.tenants[strings.TrimSpace(tenantID)] = true
+	}
+
+	return r
+}
+
+// Resolve returns the tenant of a request.
+// A trusted header must name Default or a tenant of the hostnames; a hostname without a tenant belongs to Default.
+//
+// Parameters:
+//   - req: The HTTP request.
+//
+// Returns:
+//   - string: The ID of the tenant.
+//   - error: ErrUnknownTenant if the header names an unknown tenant, otherwise nil.
+func (r *Resolver) Resolve(req *http.Request) (string, error) {
+	if r.trustHeader {
+		if tenantID := strings.TrimSpace(req.Header.Get(Header)); tenantID != "" {
+			if !r.tenants[tenantID] {
+				return "", ErrUnknownTenant
+			}
+			return tenantID, nil
+		}
+	}
+
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if tenantID, ok := r.hosts[strings.ToLower(host)]; ok {
+		return tenantID, nil
+	}
+
+	return Default, nil
+}
+
+// Middleware returns a Gin middleware storing the tenant of the request in its context.
+// Requests naming an unknown tenant are rejected with a 400 Bad Request status.
+//
+// Returns:
+//   - gin.HandlerFunc: The Gin middleware handler function.
+func (r *Resolver) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, err := r.Resolve(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, common.Message{
+				Message: err.Error(),
+			})
+			return
+		}
+
+		c.Set(ContextKey, tenantID)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
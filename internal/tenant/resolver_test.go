@@ -0,0 +1,158 @@
+package tenant
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	hosts := map[string]string{"brand-a.example.com": "brand_a", " Brand-B.example.com ": "brand_b"}
+
+	testCases := []struct {
+		name string
+
+		inputTrustHeader bool
+		inputHost        string
+		inputHeader      string
+
+		expectedTenantID string
+		expectedError    error
+	}{
+		{
+			name: "Resolve the tenant of the hostname",
+
+			inputHost: "brand-a.example.com",
+
+			expectedTenantID: "brand_a",
+		},
+		{
+			name: "Resolve the tenant of the hostname ignoring the port and the case",
+
+			inputHost: "BRAND-B.example.com:8080",
+
+			expectedTenantID: "brand_b",
+		},
+		{
+			name: "Resolve an unknown hostname to the default tenant",
+
+			inputHost: "localhost:8080",
+
+			expectedTenantID: Default,
+		},
+		{
+			name: "Ignore the header unless trusted",
+
+			inputHost:   "brand-a.example.com",
+			inputHeader: "brand_b",
+
+			expectedTenantID: "brand_a",
+		},
+		{
+			name: "Resolve the tenant of the trusted header",
+
+			inputTrustHeader: true,
+			inputHost:        "brand-a.example.com",
+			inputHeader:      "brand_b",
+
+			expectedTenantID: "brand_b",
+		},
+		{
+			name: "Resolve the hostname without a trusted header",
+
+			inputTrustHeader: true,
+			inputHost:        "brand-a.example.com",
+
+			expectedTenantID: "brand_a",
+		},
+		{
+			name: "Resolve failed - unknown tenant in the trusted header",
+
+			inputTrustHeader: true,
+			inputHost:        "brand-a.example.com",
+			inputHeader:      "brand_c",
+
+			expectedError: ErrUnknownTenant,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/self/info", nil)
+			req.Host = tc.inputHost
+			if tc.inputHeader != "" {
+				req.Header.Set(Header, tc.inputHeader)
+			}
+
+			tenantID, err := NewResolver(hosts, tc.inputTrustHeader).Resolve(req)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedTenantID, tenantID)
+		})
+	}
+}
+
+func TestResolver_Middleware(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputHeader string
+
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			name: "Store the tenant in the context",
+
+			inputHeader: "brand_a",
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: "brand_a",
+		},
+		{
+			name: "Store the default tenant in the context",
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: Default,
+		},
+		{
+			name: "Reject an unknown tenant",
+
+			inputHeader: "brand_c",
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"unknown tenant"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			engine := gin.New()
+			engine.Use(NewResolver(map[string]string{"brand-a.example.com": "brand_a"}, true).Middleware())
+			engine.GET("/tenant", func(c *gin.Context) {
+				// The handlers pass the gin.Context down as the context
+				c.String(http.StatusOK, FromContext(c))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+			if tc.inputHeader != "" {
+				req.Header.Set(Header, tc.inputHeader)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package tenant isolates the brands hosted by one deployment. Every request is resolved to a tenant, from its
+// hostname or a trusted header, which is carried in the context down to the repositories. GORM callbacks then
+// scope the queries of the tenant-owned models to the tenant of the context, and stamp it on the rows they create.
+package tenant
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// Default is the tenant of the requests no hostname or header assigns, and of the rows created before tenants.
+	Default = "default"
+
+	// Header is the request header naming the tenant, when the deployment trusts it.
+	Header = "X-Tenant-ID"
+
+	// ContextKey is the key the tenant is stored under in a context. It is a string so the middleware can store
+	// the tenant in the keys of the gin.Context, which the handlers pass down as the context.
+	ContextKey = "tenant_id"
+
+	// Claim is the JWT claim naming the tenant a token was issued in.
+	Claim = "tenant_id"
+)
+
+// allTenantsKey marks a context whose queries span every tenant.
+type allTenantsKey struct{}
+
+// NewContext returns a copy of the context carrying the tenant.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - tenantID: The ID of the tenant.
+//
+// Returns:
+//   - context.Context: The context carrying the tenant.
+func NewContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, ContextKey, tenantID)
+}
+
+// FromContext returns the tenant carried by the context, or Default if it carries none.
+//
+// Parameters:
+//   - ctx: The context of the request.
+//
+// Returns:
+//   - string: The ID of the tenant.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return Default
+	}
+
+	tenantID, ok := ctx.Value(ContextKey).(string)
+	if !ok || tenantID == "" {
+		return Default
+	}
+
+	return tenantID
+}
+
+// FromClaims returns the tenant a token was issued in, or Default for the tokens issued before tokens carried one.
+//
+// Parameters:
+//   - claims: The claims of the token.
+//
+// Returns:
+//   - string: The ID of the tenant.
+func FromClaims(claims jwt.MapClaims) string {
+	tenantID, ok := claims[Claim].(string)
+	if !ok || tenantID == "" {
+		return Default
+	}
+
+	return tenantID
+}
+
+// WithAllTenants returns a copy of the context whose queries are not scoped to a tenant.
+// It is meant for maintenance jobs walking through the rows of every tenant, never for requests.
+//
+// Parameters:
+//   - ctx: The parent context.
+//
+// Returns:
+//   - context.Context: The context lifting the tenant scope.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsKey{}, true)
+}
+
+// isAllTenants reports whether the context lifts the tenant scope.
+func isAllTenants(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	allTenants, _ := ctx.Value(allTenantsKey{}).(bool)
+	return allTenants
+}
@@ -0,0 +1,80 @@
+package fixture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// ActiveAccessToken is the plaintext of the active personal access token of testuser001.
+	ActiveAccessToken = "bmpat_activeToken0000000000000000000000000001"
+
+	// ExpiredAccessToken is the plaintext of the expired personal access token of testuser001.
+	ExpiredAccessToken = "bmpat_expiredToken000000000000000000000000002"
+)
+
+// AccessTokenCommonTestDB extends the common user data with personal access tokens.
+type AccessTokenCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// Migrate migrates the database schema for the AccessTokenCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (a *AccessTokenCommonTestDB) Migrate() error {
+	return a.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.PersonalAccessToken{})
+}
+
+// GenerateData populates the test database with common users, an active and an expired token of testuser001.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (a *AccessTokenCommonTestDB) GenerateData() error {
+	if err := a.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := a.db.Session(&gorm.Session{})
+
+	expiredAt := TestTime.Add(24 * time.Hour)
+	tokens := []*model.PersonalAccessToken{
+		{
+			Base: model.Base{
+				ID:        "7a3c9e1f-5b2d-4f6a-8c0e-2d4f6a8c0e13",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:      "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			Name:        "ci-script",
+			TokenPrefix: ActiveAccessToken[:12],
+			TokenHash:   hashAccessToken(ActiveAccessToken),
+			Scopes:      []string{"profile:read"},
+		},
+		{
+			Base: model.Base{
+				ID:        "8b4d0f2a-6c3e-4a7b-9d1f-3e5a7b9d1f24",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:      "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			Name:        "old-script",
+			TokenPrefix: ExpiredAccessToken[:12],
+			TokenHash:   hashAccessToken(ExpiredAccessToken),
+			Scopes:      []string{"profile:read", "profile:write"},
+			ExpiresAt:   &expiredAt,
+		},
+	}
+
+	return db.CreateInBatches(tokens, 10).Error
+}
+
+// hashAccessToken hashes a token the same way the access token service does.
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
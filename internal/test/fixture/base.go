@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/sqldb"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
 	"gorm.io/gorm"
 )
 
@@ -63,8 +64,12 @@ func (b *base) DB() *gorm.DB {
 // Returns:
 //   - *gorm.DB: A gorm.DB instance connected to the initialized test database
 func NewFixture(t *testing.T, fix Fixture) *gorm.DB {
-	// step 1: create test database
-	fix.SetupDB(sqldb.InitMockDB(t))
+	// step 1: create test database, scoped to tenants as the real one
+	db := sqldb.InitMockDB(t)
+	if err := tenant.RegisterCallbacks(db); err != nil {
+		t.Fatalf("Failed to register tenant callbacks: %v", err)
+	}
+	fix.SetupDB(db)
 
 	// step 2: migrate schema
 	err := fix.Migrate()
@@ -0,0 +1,35 @@
+package fixture
+
+import (
+	"context"
+
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+)
+
+// SolvedChallengeToken is the only solution accepted by the challenge verifier of the tests.
+const SolvedChallengeToken = "solved-challenge"
+
+// challengeVerifier issues a fixed challenge and accepts SolvedChallengeToken.
+type challengeVerifier struct{}
+
+// NewChallengeVerifier creates a bot protection verifier for tests, standing in for a CAPTCHA provider.
+//
+// Returns:
+//   - botprotection.Verifier: The verifier
+func NewChallengeVerifier() botprotection.Verifier {
+	return &challengeVerifier{}
+}
+
+// Issue returns a fixed challenge.
+func (c *challengeVerifier) Issue(ctx context.Context) (*botprotection.Challenge, error) {
+	return &botprotection.Challenge{Provider: "test"}, nil
+}
+
+// Verify accepts SolvedChallengeToken only.
+func (c *challengeVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token != SolvedChallengeToken {
+		return botprotection.ErrChallengeFailed
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package fixture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// PendingEmailChangeConfirmToken confirms the pending email change of testuser001.
+	PendingEmailChangeConfirmToken = "pendingConfirmToken00000000000001"
+	// PendingEmailChangeCancelToken cancels the pending email change of testuser001.
+	PendingEmailChangeCancelToken = "pendingCancelToken000000000000001"
+
+	// ExpiredEmailChangeConfirmToken confirms the expired email change of Bob.
+	ExpiredEmailChangeConfirmToken = "expiredConfirmToken00000000000002"
+	// ExpiredEmailChangeCancelToken cancels the expired email change of Bob.
+	ExpiredEmailChangeCancelToken = "expiredCancelToken000000000000002"
+
+	// ConfirmedEmailChangeConfirmToken confirmed the email change of Charlie from charlie.old@example.com.
+	ConfirmedEmailChangeConfirmToken = "confirmedConfirmToken000000000003"
+	// ConfirmedEmailChangeCancelToken reverts the confirmed email change of Charlie.
+	ConfirmedEmailChangeCancelToken = "confirmedCancelToken0000000000003"
+)
+
+// EmailChangeFarFuture is the expiry of the fixture email changes whose links still work.
+var EmailChangeFarFuture = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// EmailChangeCommonTestDB extends the common user data with email changes.
+type EmailChangeCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// GenerateData populates the test database with common users, a pending email change of testuser001,
+// an expired email change of Bob and a confirmed email change of Charlie that can still be cancelled.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (e *EmailChangeCommonTestDB) GenerateData() error {
+	if err := e.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := e.db.Session(&gorm.Session{})
+
+	confirmedAt := TestTime.Add(time.Hour)
+	changes := []*model.EmailChange{
+		{
+			Base: model.Base{
+				ID:        "c0e1d2f3-0001-4a5b-8c6d-7e8f9a0b1c01",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:           "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			OldEmail:         "testuser001@example.com",
+			NewEmail:         "testuser001new@example.com",
+			ConfirmTokenHash: hashEmailChangeToken(PendingEmailChangeConfirmToken),
+			CancelTokenHash:  hashEmailChangeToken(PendingEmailChangeCancelToken),
+			Status:           model.EmailChangePending,
+			ExpiresAt:        EmailChangeFarFuture,
+			CancelExpiresAt:  EmailChangeFarFuture,
+		},
+		{
+			Base: model.Base{
+				ID:        "c0e1d2f3-0002-4a5b-8c6d-7e8f9a0b1c02",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:           "123e4567-e89b-12d3-a456-eb6b9e546001",
+			OldEmail:         "bob@example.com",
+			NewEmail:         "bob.new@example.com",
+			ConfirmTokenHash: hashEmailChangeToken(ExpiredEmailChangeConfirmToken),
+			CancelTokenHash:  hashEmailChangeToken(ExpiredEmailChangeCancelToken),
+			Status:           model.EmailChangePending,
+			ExpiresAt:        TestTime.Add(24 * time.Hour),
+			CancelExpiresAt:  TestTime.Add(72 * time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "c0e1d2f3-0003-4a5b-8c6d-7e8f9a0b1c03",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:           "987e6543-e21b-12d3-a456-eb6b9e546002",
+			OldEmail:         "charlie.old@example.com",
+			NewEmail:         "charlie@example.com",
+			ConfirmTokenHash: hashEmailChangeToken(ConfirmedEmailChangeConfirmToken),
+			CancelTokenHash:  hashEmailChangeToken(ConfirmedEmailChangeCancelToken),
+			Status:           model.EmailChangeConfirmed,
+			ExpiresAt:        EmailChangeFarFuture,
+			CancelExpiresAt:  EmailChangeFarFuture,
+			ConfirmedAt:      &confirmedAt,
+		},
+	}
+
+	return db.CreateInBatches(changes, 10).Error
+}
+
+// hashEmailChangeToken hashes a token the same way the email change service does.
+func hashEmailChangeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,73 @@
+package fixture
+
+import (
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// IdentityCommonTestDB extends the common user data with linked external identities.
+type IdentityCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// Migrate migrates the database schema for the IdentityCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (i *IdentityCommonTestDB) Migrate() error {
+	return i.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.UserIdentity{}, &model.UserPasskey{})
+}
+
+// GenerateData populates the test database with common users, a federated-only user
+// without a password, and their linked identities.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (i *IdentityCommonTestDB) GenerateData() error {
+	if err := i.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := i.db.Session(&gorm.Session{})
+
+	federatedUser := &model.User{
+		Base: model.Base{
+			ID:        "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+			CreatedAt: TestTime,
+			UpdatedAt: TestTime,
+		},
+		DisplayName: "Federated User 1",
+		Username:    "federated001",
+		Email:       "federated001@example.com",
+	}
+	if err := db.Create(federatedUser).Error; err != nil {
+		return err
+	}
+
+	identities := []*model.UserIdentity{
+		{
+			Base: model.Base{
+				ID:        "5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			Provider: "mockidp",
+			Subject:  "mockidp-subject-001",
+			Email:    "testuser001@example.com",
+		},
+		{
+			Base: model.Base{
+				ID:        "6c1f7b3f-3e2d-4d4f-8b62-1d9f8e3e2b11",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:   "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+			Provider: "mockidp",
+			Subject:  "mockidp-subject-federated001",
+			Email:    "federated001@example.com",
+		},
+	}
+
+	return db.CreateInBatches(identities, 10).Error
+}
@@ -0,0 +1,88 @@
+package fixture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// PendingInvitationToken registers invitee@example.com as an admin.
+	PendingInvitationToken = "pendingInvitationToken0000000001"
+	// ExpiredInvitationToken registered expired@example.com until the invitation expired.
+	ExpiredInvitationToken = "expiredInvitationToken0000000002"
+	// AcceptedInvitationToken registered Charlie.
+	AcceptedInvitationToken = "acceptedInvitationToken000000003"
+)
+
+// InvitationCommonTestDB extends the common user data with invitations.
+type InvitationCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// GenerateData populates the test database with common users, a pending invitation of invitee@example.com with
+// the admin role, an expired invitation of expired@example.com and the invitation Charlie accepted.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (i *InvitationCommonTestDB) GenerateData() error {
+	if err := i.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := i.db.Session(&gorm.Session{})
+
+	acceptedAt := TestTime.Add(time.Hour)
+	charlieID := "987e6543-e21b-12d3-a456-eb6b9e546002"
+	invitations := []*model.Invitation{
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01",
+				CreatedAt: TestTime.Add(2 * time.Hour),
+				UpdatedAt: TestTime.Add(2 * time.Hour),
+			},
+			Email:     "invitee@example.com",
+			Role:      model.RoleAdmin,
+			TokenHash: hashInvitationToken(PendingInvitationToken),
+			Status:    model.InvitationPending,
+			ExpiresAt: EmailChangeFarFuture,
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a02",
+				CreatedAt: TestTime.Add(time.Hour),
+				UpdatedAt: TestTime.Add(time.Hour),
+			},
+			Email:     "expired@example.com",
+			Role:      model.RoleMember,
+			TokenHash: hashInvitationToken(ExpiredInvitationToken),
+			Status:    model.InvitationPending,
+			ExpiresAt: TestTime.Add(7 * 24 * time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a03",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			Email:      "charlie@example.com",
+			Role:       model.RoleMember,
+			TokenHash:  hashInvitationToken(AcceptedInvitationToken),
+			Status:     model.InvitationAccepted,
+			ExpiresAt:  EmailChangeFarFuture,
+			AcceptedAt: &acceptedAt,
+			UserID:     &charlieID,
+		},
+	}
+
+	return db.CreateInBatches(invitations, 10).Error
+}
+
+// hashInvitationToken hashes a token the same way the invitation service does.
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package fixture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// The device testuser001 has already logged in from successfully.
+const (
+	KnownDeviceIPAddress = "203.0.113.10"
+	KnownDeviceUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
+// LoginHistoryCommonTestDB extends the common user data with login attempts.
+type LoginHistoryCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// Migrate migrates the database schema for the LoginHistoryCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (l *LoginHistoryCommonTestDB) Migrate() error {
+	return l.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.LoginEvent{})
+}
+
+// GenerateData populates the test database with common users, two successful logins of testuser001
+// from the known device, a failed attempt from another device, and a login of testuser000.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (l *LoginHistoryCommonTestDB) GenerateData() error {
+	if err := l.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := l.db.Session(&gorm.Session{})
+
+	events := []*model.LoginEvent{
+		{
+			Base: model.Base{
+				ID:        "b2c3d4e5-0001-4f5a-9b0c-1d2e3f4a5b61",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:            "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			IPAddress:         KnownDeviceIPAddress,
+			UserAgent:         KnownDeviceUserAgent,
+			DeviceFingerprint: deviceFingerprint(KnownDeviceIPAddress, KnownDeviceUserAgent),
+			Success:           true,
+		},
+		{
+			Base: model.Base{
+				ID:        "b2c3d4e5-0002-4f5a-9b0c-1d2e3f4a5b62",
+				CreatedAt: TestTime.Add(time.Hour),
+				UpdatedAt: TestTime.Add(time.Hour),
+			},
+			UserID:            "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			IPAddress:         "198.51.100.20",
+			UserAgent:         "curl/8.4.0",
+			DeviceFingerprint: deviceFingerprint("198.51.100.20", "curl/8.4.0"),
+			Success:           false,
+		},
+		{
+			Base: model.Base{
+				ID:        "b2c3d4e5-0003-4f5a-9b0c-1d2e3f4a5b63",
+				CreatedAt: TestTime.Add(2 * time.Hour),
+				UpdatedAt: TestTime.Add(2 * time.Hour),
+			},
+			UserID:            "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			IPAddress:         KnownDeviceIPAddress,
+			UserAgent:         KnownDeviceUserAgent,
+			DeviceFingerprint: deviceFingerprint(KnownDeviceIPAddress, KnownDeviceUserAgent),
+			Success:           true,
+		},
+		{
+			Base: model.Base{
+				ID:        "b2c3d4e5-0004-4f5a-9b0c-1d2e3f4a5b64",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:            "de305d54-75b4-431b-adb2-eb6b9e546000",
+			IPAddress:         "192.0.2.40",
+			UserAgent:         "curl/8.4.0",
+			DeviceFingerprint: deviceFingerprint("192.0.2.40", "curl/8.4.0"),
+			Success:           true,
+		},
+	}
+
+	return db.CreateInBatches(events, 10).Error
+}
+
+// deviceFingerprint fingerprints a device the same way the login history service does.
+func deviceFingerprint(ipAddress, userAgent string) string {
+	sum := sha256.Sum256([]byte(ipAddress + "\n" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,39 @@
+package fixture
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vukieuhaihoa/user-service/internal/mailer"
+)
+
+// RecordingMailer is a mailer keeping the sent messages in memory instead of delivering them.
+type RecordingMailer struct {
+	mu       sync.Mutex
+	messages []*mailer.Message
+}
+
+// NewRecordingMailer creates an empty recording mailer.
+//
+// Returns:
+//   - *RecordingMailer: The recording mailer
+func NewRecordingMailer() *RecordingMailer {
+	return &RecordingMailer{}
+}
+
+// Send records the message.
+func (r *RecordingMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (r *RecordingMailer) Messages() []*mailer.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*mailer.Message(nil), r.messages...)
+}
@@ -0,0 +1,230 @@
+package fixture
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	MockOIDCClientID     = "mock-client-id"
+	MockOIDCClientSecret = "mock-client-secret"
+
+	mockOIDCKeyID = "mock-key-1"
+)
+
+// MockOIDCUser is the user the mock provider authenticates on the next authorization request.
+type MockOIDCUser struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// mockAuthorization is an authorization code issued by the mock provider and not redeemed yet.
+type mockAuthorization struct {
+	user          MockOIDCUser
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+}
+
+// MockOIDCProvider is a minimal OpenID Connect provider served by httptest.
+// It implements discovery, the authorization endpoint (without any login page),
+// the token endpoint with PKCE and client authentication, and a JWKS endpoint.
+type MockOIDCProvider struct {
+	Server *httptest.Server
+
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	user  MockOIDCUser
+	codes map[string]mockAuthorization
+}
+
+// NewMockOIDCProvider starts a mock OpenID Connect provider that is shut down when the test ends.
+//
+// Parameters:
+//   - t: The testing object used for reporting errors and cleanup
+//
+// Returns:
+//   - *MockOIDCProvider: The running mock provider
+func NewMockOIDCProvider(t *testing.T) *MockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate mock provider key: %v", err)
+	}
+
+	m := &MockOIDCProvider{
+		key:   key,
+		codes: map[string]mockAuthorization{},
+	}
+
+	router := gin.New()
+	router.GET("/.well-known/openid-configuration", m.discovery)
+	router.GET("/authorize", m.authorize)
+	router.POST("/token", m.token)
+	router.GET("/jwks", m.jwks)
+
+	m.Server = httptest.NewServer(router)
+	t.Cleanup(m.Server.Close)
+
+	return m
+}
+
+// Issuer returns the issuer identifier of the mock provider.
+func (m *MockOIDCProvider) Issuer() string {
+	return m.Server.URL
+}
+
+// SetUser sets the user authenticated by the next authorization request.
+func (m *MockOIDCProvider) SetUser(user MockOIDCUser) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.user = user
+}
+
+// Authorize plays the user agent against an authorization URL and returns the
+// callback URL the provider redirects to, carrying the code and the state.
+//
+// Parameters:
+//   - t: The testing object used for reporting errors
+//   - authURL: The authorization URL returned by the service under test
+//
+// Returns:
+//   - *url.URL: The callback URL with the code and state query parameters
+func (m *MockOIDCProvider) Authorize(t *testing.T, authURL string) *url.URL {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("Failed to call mock authorization endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	callbackURL, err := resp.Location()
+	if err != nil {
+		t.Fatalf("Mock authorization endpoint did not redirect: %v", err)
+	}
+
+	return callbackURL
+}
+
+func (m *MockOIDCProvider) discovery(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"issuer":                 m.Issuer(),
+		"authorization_endpoint": m.Issuer() + "/authorize",
+		"token_endpoint":         m.Issuer() + "/token",
+		"jwks_uri":               m.Issuer() + "/jwks",
+	})
+}
+
+func (m *MockOIDCProvider) authorize(c *gin.Context) {
+	if c.Query("client_id") != MockOIDCClientID || c.Query("code_challenge_method") != "S256" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	code := uuid.New().String()
+	m.codes[code] = mockAuthorization{
+		user:          m.user,
+		nonce:         c.Query("nonce"),
+		redirectURI:   c.Query("redirect_uri"),
+		codeChallenge: c.Query("code_challenge"),
+	}
+	m.mu.Unlock()
+
+	redirect, err := url.Parse(c.Query("redirect_uri"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request"})
+		return
+	}
+
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", c.Query("state"))
+	redirect.RawQuery = query.Encode()
+
+	c.Redirect(http.StatusFound, redirect.String())
+}
+
+func (m *MockOIDCProvider) token(c *gin.Context) {
+	m.mu.Lock()
+	authorization, ok := m.codes[c.PostForm("code")]
+	delete(m.codes, c.PostForm("code"))
+	m.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(c.PostForm("code_verifier")))
+	switch {
+	case !ok,
+		c.PostForm("grant_type") != "authorization_code",
+		c.PostForm("redirect_uri") != authorization.redirectURI,
+		base64.RawURLEncoding.EncodeToString(sum[:]) != authorization.codeChallenge:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_grant"})
+		return
+	case c.PostForm("client_id") != MockOIDCClientID,
+		c.PostForm("client_secret") != MockOIDCClientSecret:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid_client"})
+		return
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                m.Issuer(),
+		"aud":                MockOIDCClientID,
+		"sub":                authorization.user.Subject,
+		"email":              authorization.user.Email,
+		"email_verified":     authorization.user.EmailVerified,
+		"name":               authorization.user.Name,
+		"preferred_username": authorization.user.PreferredUsername,
+		"nonce":              authorization.nonce,
+		"iat":                time.Now().Unix(),
+		"exp":                time.Now().Add(time.Minute).Unix(),
+	})
+	idToken.Header["kid"] = mockOIDCKeyID
+
+	signed, err := idToken.SignedString(m.key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": "mock-access-token",
+		"token_type":   "Bearer",
+		"id_token":     signed,
+	})
+}
+
+func (m *MockOIDCProvider) jwks(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"keys": []gin.H{
+			{
+				"kid": mockOIDCKeyID,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			},
+		},
+	})
+}
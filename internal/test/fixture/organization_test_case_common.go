@@ -0,0 +1,105 @@
+package fixture
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// AcmeOrganizationID is owned by Alice, administered by Bob, and Charlie is a member.
+	AcmeOrganizationID = "0a9e1f00-0001-4c5d-9e8f-1a2b3c4d5e01"
+	// GlobexOrganizationID is owned by Bob, its only member.
+	GlobexOrganizationID = "0a9e1f00-0002-4c5d-9e8f-1a2b3c4d5e02"
+
+	// PendingOrganizationInvitationToken adds testuser001@example.com to Acme as a member.
+	PendingOrganizationInvitationToken = "pendingOrgInvitationToken0000001"
+	// ExpiredOrganizationInvitationToken added testuser001@example.com to Globex until the invitation expired.
+	ExpiredOrganizationInvitationToken = "expiredOrgInvitationToken0000002"
+)
+
+// OrganizationCommonTestDB extends the common user data with organizations.
+type OrganizationCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// GenerateData populates the test database with common users, the Acme and Globex organizations, a pending
+// invitation of testuser001@example.com to Acme and an expired one to Globex.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (o *OrganizationCommonTestDB) GenerateData() error {
+	if err := o.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := o.db.Session(&gorm.Session{})
+
+	organizations := []*model.Organization{
+		{
+			Base: model.Base{ID: AcmeOrganizationID, CreatedAt: TestTime, UpdatedAt: TestTime},
+			Name: "Acme",
+		},
+		{
+			Base: model.Base{ID: GlobexOrganizationID, CreatedAt: TestTime.Add(time.Hour), UpdatedAt: TestTime.Add(time.Hour)},
+			Name: "Globex",
+		},
+	}
+	if err := db.CreateInBatches(organizations, 10).Error; err != nil {
+		return err
+	}
+
+	memberships := []*model.Membership{
+		{
+			Base:           model.Base{ID: "0b8d2e00-0001-4c5d-9e8f-1a2b3c4d5e01", CreatedAt: TestTime, UpdatedAt: TestTime},
+			OrganizationID: AcmeOrganizationID,
+			UserID:         "de305d54-75b4-431b-adb2-eb6b9e546000",
+			Role:           model.OrganizationOwner,
+		},
+		{
+			Base:           model.Base{ID: "0b8d2e00-0002-4c5d-9e8f-1a2b3c4d5e02", CreatedAt: TestTime.Add(time.Minute), UpdatedAt: TestTime.Add(time.Minute)},
+			OrganizationID: AcmeOrganizationID,
+			UserID:         "123e4567-e89b-12d3-a456-eb6b9e546001",
+			Role:           model.OrganizationAdmin,
+		},
+		{
+			Base:           model.Base{ID: "0b8d2e00-0003-4c5d-9e8f-1a2b3c4d5e03", CreatedAt: TestTime.Add(2 * time.Minute), UpdatedAt: TestTime.Add(2 * time.Minute)},
+			OrganizationID: AcmeOrganizationID,
+			UserID:         "987e6543-e21b-12d3-a456-eb6b9e546002",
+			Role:           model.OrganizationMember,
+		},
+		{
+			Base:           model.Base{ID: "0b8d2e00-0004-4c5d-9e8f-1a2b3c4d5e04", CreatedAt: TestTime.Add(time.Hour), UpdatedAt: TestTime.Add(time.Hour)},
+			OrganizationID: GlobexOrganizationID,
+			UserID:         "123e4567-e89b-12d3-a456-eb6b9e546001",
+			Role:           model.OrganizationOwner,
+		},
+	}
+	if err := db.CreateInBatches(memberships, 10).Error; err != nil {
+		return err
+	}
+
+	invitations := []*model.OrganizationInvitation{
+		{
+			Base:           model.Base{ID: "0c7f3d00-0001-4c5d-9e8f-1a2b3c4d5e01", CreatedAt: TestTime, UpdatedAt: TestTime},
+			OrganizationID: AcmeOrganizationID,
+			Email:          "testuser001@example.com",
+			Role:           model.OrganizationMember,
+			TokenHash:      hashInvitationToken(PendingOrganizationInvitationToken),
+			Status:         model.InvitationPending,
+			ExpiresAt:      EmailChangeFarFuture,
+		},
+		{
+			Base:           model.Base{ID: "0c7f3d00-0002-4c5d-9e8f-1a2b3c4d5e02", CreatedAt: TestTime, UpdatedAt: TestTime},
+			OrganizationID: GlobexOrganizationID,
+			Email:          "testuser001@example.com",
+			Role:           model.OrganizationAdmin,
+			TokenHash:      hashInvitationToken(ExpiredOrganizationInvitationToken),
+			Status:         model.InvitationPending,
+			ExpiresAt:      TestTime.Add(7 * 24 * time.Hour),
+		},
+	}
+
+	return db.CreateInBatches(invitations, 10).Error
+}
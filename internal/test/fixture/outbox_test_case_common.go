@@ -0,0 +1,92 @@
+package fixture
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// OutboxCommonTestDB extends the common user data with outbox events in every delivery state.
+type OutboxCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// Migrate migrates the database schema for the OutboxCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (o *OutboxCommonTestDB) Migrate() error {
+	return o.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.LoginEvent{})
+}
+
+// GenerateData populates the test database with common users, two pending events of testuser001
+// (the second one waiting for a retry), a published event and a dead-lettered event.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (o *OutboxCommonTestDB) GenerateData() error {
+	if err := o.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := o.db.Session(&gorm.Session{})
+
+	publishedAt := TestTime.Add(time.Minute)
+	events := []*model.OutboxEvent{
+		{
+			Base: model.Base{
+				ID:        "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71",
+				CreatedAt: TestTime.Add(time.Hour),
+				UpdatedAt: TestTime.Add(time.Hour),
+			},
+			AggregateID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			EventType:     "user.created",
+			Payload:       `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","username":"testuser001"}`,
+			NextAttemptAt: TestTime.Add(time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72",
+				CreatedAt: TestTime.Add(2 * time.Hour),
+				UpdatedAt: TestTime.Add(2 * time.Hour),
+			},
+			AggregateID:   "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			EventType:     "user.updated",
+			Payload:       `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","username":"testuser001"}`,
+			Attempts:      2,
+			LastError:     "connection refused",
+			NextAttemptAt: TestTime.Add(3 * time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "c3d4e5f6-0003-4a5b-8c9d-2e3f4a5b6c73",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			AggregateID:   "de305d54-75b4-431b-adb2-eb6b9e546000",
+			EventType:     "user.created",
+			Payload:       `{"id":"de305d54-75b4-431b-adb2-eb6b9e546000","username":"Alice"}`,
+			NextAttemptAt: TestTime,
+			StreamID:      "1672531260000-0",
+			PublishedAt:   &publishedAt,
+		},
+		{
+			Base: model.Base{
+				ID:        "c3d4e5f6-0004-4a5b-8c9d-2e3f4a5b6c74",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			AggregateID:    "123e4567-e89b-12d3-a456-eb6b9e546001",
+			EventType:      "user.created",
+			Payload:        `{"id":"123e4567-e89b-12d3-a456-eb6b9e546001","username":"Bob"}`,
+			Attempts:       10,
+			LastError:      "connection refused",
+			NextAttemptAt:  TestTime,
+			StreamID:       "1672531260000-1",
+			DeadLetteredAt: &publishedAt,
+		},
+	}
+
+	return db.CreateInBatches(events, 10).Error
+}
@@ -0,0 +1,49 @@
+package fixture
+
+import (
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// PasskeyCommonTestDB extends the common user data with registered passkeys.
+type PasskeyCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// Migrate migrates the database schema for the PasskeyCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (p *PasskeyCommonTestDB) Migrate() error {
+	return p.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.UserPasskey{})
+}
+
+// GenerateData populates the test database with common users and a passkey registered by testuser001.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (p *PasskeyCommonTestDB) GenerateData() error {
+	if err := p.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := p.db.Session(&gorm.Session{})
+
+	passkeys := []*model.UserPasskey{
+		{
+			Base: model.Base{
+				ID:        "2f8d6c1a-7b3e-4a5d-9c0f-3e1b2a4d5c6e",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:          "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			CredentialID:    []byte("credential-001"),
+			PublicKey:       []byte("public-key-001"),
+			AttestationType: "none",
+			Transports:      []string{"internal"},
+			SignCount:       1,
+		},
+	}
+
+	return db.CreateInBatches(passkeys, 10).Error
+}
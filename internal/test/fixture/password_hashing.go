@@ -0,0 +1,30 @@
+package fixture
+
+import (
+	"testing"
+
+	"github.com/vukieuhaihoa/user-service/internal/passwordhash"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// NewPasswordHashing creates a password hashing registry preferring Argon2id, as the service does, with
+// parameters cheap enough for tests. The bcrypt hashes of the fixtures are verified and upgraded on login.
+//
+// Parameters:
+//   - t: The test the registry is created for
+//
+// Returns:
+//   - passwordhash.PasswordHashing: The registry
+func NewPasswordHashing(t *testing.T) passwordhash.PasswordHashing {
+	argon2idHasher, err := passwordhash.NewArgon2id(passwordhash.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bcryptHasher, err := passwordhash.NewBcrypt(bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return passwordhash.NewRegistry(argon2idHasher, bcryptHasher)
+}
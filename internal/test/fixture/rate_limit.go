@@ -0,0 +1,18 @@
+package fixture
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vukieuhaihoa/user-service/internal/ratepolicy"
+)
+
+// ExhaustRateLimit uses up the budget of a rate limit policy for a client in the current and the next window,
+// so that the next request is rejected even if a window ends in between.
+func ExhaustRateLimit(ctx context.Context, redisClient *redis.Client, policy ratepolicy.Policy, client string) {
+	now := time.Now()
+	for _, at := range []time.Time{now, now.Add(policy.Window)} {
+		redisClient.Set(ctx, ratepolicy.CounterKey(policy, client, at), policy.Limit, 2*policy.Window)
+	}
+}
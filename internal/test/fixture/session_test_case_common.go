@@ -0,0 +1,94 @@
+package fixture
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// SessionFarFuture is the expiry of the fixture sessions that are still active.
+var SessionFarFuture = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// SessionCommonTestDB extends the common user data with login sessions.
+type SessionCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// Migrate migrates the database schema for the SessionCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (s *SessionCommonTestDB) Migrate() error {
+	return s.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.LoginEvent{})
+}
+
+// GenerateData populates the test database with common users, two active and one expired session of testuser001,
+// and an active session of testuser000.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (s *SessionCommonTestDB) GenerateData() error {
+	if err := s.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := s.db.Session(&gorm.Session{})
+
+	sessions := []*model.UserSession{
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:     "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			DeviceName: "Chrome on macOS",
+			IPAddress:  "203.0.113.10",
+			UserAgent:  "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			LastSeenAt: TestTime.Add(2 * time.Hour),
+			ExpiresAt:  SessionFarFuture,
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a52",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:     "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			DeviceName: "Firefox on Windows",
+			IPAddress:  "198.51.100.20",
+			UserAgent:  "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0",
+			LastSeenAt: TestTime.Add(time.Hour),
+			ExpiresAt:  SessionFarFuture,
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a53",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:     "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			DeviceName: "Safari on iOS",
+			IPAddress:  "192.0.2.30",
+			UserAgent:  "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1",
+			LastSeenAt: TestTime,
+			ExpiresAt:  TestTime.Add(24 * time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0004-4e5f-8a9b-0c1d2e3f4a54",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:     "de305d54-75b4-431b-adb2-eb6b9e546000",
+			DeviceName: "curl",
+			IPAddress:  "192.0.2.40",
+			UserAgent:  "curl/8.4.0",
+			LastSeenAt: TestTime,
+			ExpiresAt:  SessionFarFuture,
+		},
+	}
+
+	return db.CreateInBatches(sessions, 10).Error
+}
@@ -14,7 +14,7 @@ type UserCommonTestDB struct {
 // Returns:
 //   - error: An error if migration fails, otherwise nil
 func (u *UserCommonTestDB) Migrate() error {
-	return u.db.AutoMigrate(&model.User{})
+	return u.db.AutoMigrate(&model.User{}, &model.UsernameChange{}, &model.OutboxEvent{}, &model.UserSession{}, &model.LoginEvent{}, &model.EmailChange{}, &model.PasswordHistory{}, &model.Invitation{}, &model.Organization{}, &model.Membership{}, &model.OrganizationInvitation{})
 }
 
 // GenerateData populates the test database with common user test data.
@@ -31,10 +31,12 @@ func (u *UserCommonTestDB) GenerateData() error {
 				CreatedAt: TestTime,
 				UpdatedAt: TestTime,
 			},
-			DisplayName: "Alice",
-			Username:    "Alice",
-			Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
-			Email:       "alice@example.com",
+			DisplayName:       "Alice",
+			Username:          "Alice",
+			Password:          "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+			Email:             "alice@example.com",
+			PasswordChangedAt: &TestTime,
+			EmailVerifiedAt:   &TestTime,
 		},
 		{
 			Base: model.Base{
@@ -42,10 +44,11 @@ func (u *UserCommonTestDB) GenerateData() error {
 				CreatedAt: TestTime,
 				UpdatedAt: TestTime,
 			},
-			DisplayName: "Bob",
-			Username:    "Bob",
-			Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
-			Email:       "bob@example.com",
+			DisplayName:       "Bob",
+			Username:          "Bob",
+			Password:          "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+			Email:             "bob@example.com",
+			PasswordChangedAt: &TestTime,
 		},
 		{
 			Base: model.Base{
@@ -53,10 +56,11 @@ func (u *UserCommonTestDB) GenerateData() error {
 				CreatedAt: TestTime,
 				UpdatedAt: TestTime,
 			},
-			DisplayName: "Charlie",
-			Username:    "Charlie",
-			Password:    "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
-			Email:       "charlie@example.com",
+			DisplayName:       "Charlie",
+			Username:          "Charlie",
+			Password:          "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e",
+			Email:             "charlie@example.com",
+			PasswordChangedAt: &TestTime,
 		},
 		{
 			Base: model.Base{
@@ -64,10 +68,11 @@ func (u *UserCommonTestDB) GenerateData() error {
 				CreatedAt: TestTime,
 				UpdatedAt: TestTime,
 			},
-			DisplayName: "Test User 1",
-			Username:    "testuser001",
-			Password:    "$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu",
-			Email:       "testuser001@example.com",
+			DisplayName:       "Test User 1",
+			Username:          "testuser001",
+			Password:          "$2a$10$hhuB9rZrp5ikmRb5yAF9hev6AE2tC404jhtP.bdOjme9lECJClzFu",
+			Email:             "testuser001@example.com",
+			PasswordChangedAt: &TestTime,
 		},
 	}
 
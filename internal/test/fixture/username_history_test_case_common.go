@@ -0,0 +1,64 @@
+package fixture
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// UsernameHistoryCommonTestDB extends the common user data with username changes.
+type UsernameHistoryCommonTestDB struct {
+	UserCommonTestDB
+}
+
+// GenerateData populates the test database with common users, a change of Bob from "Bobby" whose old username is
+// still held, and two changes of Charlie, from "Charles" to "Chuck" then to "Charlie", whose holds are over.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (u *UsernameHistoryCommonTestDB) GenerateData() error {
+	if err := u.UserCommonTestDB.GenerateData(); err != nil {
+		return err
+	}
+
+	db := u.db.Session(&gorm.Session{})
+
+	changes := []*model.UsernameChange{
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a01",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:      "123e4567-e89b-12d3-a456-eb6b9e546001",
+			OldUsername: "Bobby",
+			NewUsername: "Bob",
+			HeldUntil:   TestTime.AddDate(100, 0, 0),
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a02",
+				CreatedAt: TestTime.Add(-24 * time.Hour),
+				UpdatedAt: TestTime.Add(-24 * time.Hour),
+			},
+			UserID:      "987e6543-e21b-12d3-a456-eb6b9e546002",
+			OldUsername: "Charles",
+			NewUsername: "Chuck",
+			HeldUntil:   TestTime,
+		},
+		{
+			Base: model.Base{
+				ID:        "a1b2c3d4-0003-4e5f-8a9b-0c1d2e3f4a03",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			UserID:      "987e6543-e21b-12d3-a456-eb6b9e546002",
+			OldUsername: "Chuck",
+			NewUsername: "Charlie",
+			HeldUntil:   TestTime.Add(24 * time.Hour),
+		},
+	}
+
+	return db.CreateInBatches(changes, 10).Error
+}
@@ -0,0 +1,62 @@
+package fixture
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// UsernamePolicyCommonTestDB holds one username policy entry of every kind.
+type UsernamePolicyCommonTestDB struct {
+	base
+}
+
+// Migrate migrates the database schema for the UsernamePolicyCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (u *UsernamePolicyCommonTestDB) Migrate() error {
+	return u.db.AutoMigrate(&model.UsernamePolicyEntry{})
+}
+
+// GenerateData populates the test database with the reserved username "acme", the blocked word "scam" and the
+// blocked pattern "^guest[0-9]+$", created in that order.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (u *UsernamePolicyCommonTestDB) GenerateData() error {
+	db := u.db.Session(&gorm.Session{})
+
+	entries := []*model.UsernamePolicyEntry{
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0001-4c5d-8e9f-4a5b6c7d8e91",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			Kind:  model.UsernamePolicyReserved,
+			Value: "acme",
+		},
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92",
+				CreatedAt: TestTime.Add(time.Minute),
+				UpdatedAt: TestTime.Add(time.Minute),
+			},
+			Kind:  model.UsernamePolicyBlockedWord,
+			Value: "scam",
+		},
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0003-4c5d-8e9f-4a5b6c7d8e93",
+				CreatedAt: TestTime.Add(2 * time.Minute),
+				UpdatedAt: TestTime.Add(2 * time.Minute),
+			},
+			Kind:  model.UsernamePolicyBlockedPattern,
+			Value: "^guest[0-9]+$",
+		},
+	}
+
+	return db.Create(entries).Error
+}
@@ -0,0 +1,212 @@
+package fixture
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+const (
+	// MockWebAuthnRPID is the relying party ID the software authenticator is used with in tests.
+	MockWebAuthnRPID = "localhost"
+	// MockWebAuthnOrigin is the origin the software authenticator reports in its client data.
+	MockWebAuthnOrigin = "http://localhost:8080"
+
+	authenticatorFlagUserPresent            = 0x01
+	authenticatorFlagUserVerified           = 0x04
+	authenticatorFlagAttestedCredentialData = 0x40
+)
+
+// SoftwareAuthenticator is a platform authenticator implemented in software, so WebAuthn
+// ceremonies can be tested without hardware. It holds a single discoverable ES256 credential,
+// produces "none" attestations on registration and signed assertions on login, and plays the
+// browser part by building the client data itself.
+type SoftwareAuthenticator struct {
+	Origin string
+
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+// NewSoftwareAuthenticator creates a software authenticator reporting MockWebAuthnOrigin.
+//
+// Parameters:
+//   - t: The testing object used for reporting errors
+//
+// Returns:
+//   - *SoftwareAuthenticator: The authenticator, without any credential until Register is called
+func NewSoftwareAuthenticator(t *testing.T) *SoftwareAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate authenticator key: %v", err)
+	}
+
+	credentialID := make([]byte, 32)
+	if _, err := rand.Read(credentialID); err != nil {
+		t.Fatalf("Failed to generate credential ID: %v", err)
+	}
+
+	return &SoftwareAuthenticator{
+		Origin:       MockWebAuthnOrigin,
+		key:          key,
+		credentialID: credentialID,
+	}
+}
+
+// CredentialID returns the ID of the credential held by the authenticator.
+func (a *SoftwareAuthenticator) CredentialID() []byte {
+	return a.credentialID
+}
+
+// Register answers registration options the way navigator.credentials.create() would.
+//
+// Parameters:
+//   - t: The testing object used for reporting errors
+//   - options: The registration options returned by the relying party
+//
+// Returns:
+//   - []byte: The JSON encoded PublicKeyCredential to send back to the relying party
+func (a *SoftwareAuthenticator) Register(t *testing.T, options *protocol.CredentialCreation) []byte {
+	a.userHandle = decodeUserHandle(t, options.Response.User.ID)
+
+	clientDataJSON := a.clientData(t, protocol.CreateCeremony, options.Response.Challenge)
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		YCoord: a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode credential public key: %v", err)
+	}
+
+	authData := a.authenticatorData(options.Response.RelyingParty.ID, authenticatorFlagAttestedCredentialData)
+	authData = append(authData, make([]byte, 16)...) // zero AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, publicKey...)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode attestation object: %v", err)
+	}
+
+	return a.marshalCredential(t, map[string]any{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(attestationObject),
+		"transports":        []string{"internal"},
+	})
+}
+
+// Assert answers login options the way navigator.credentials.get() would, signing
+// the challenge with the registered credential and returning the user handle.
+//
+// Parameters:
+//   - t: The testing object used for reporting errors
+//   - options: The login options returned by the relying party
+//
+// Returns:
+//   - []byte: The JSON encoded PublicKeyCredential to send back to the relying party
+func (a *SoftwareAuthenticator) Assert(t *testing.T, options *protocol.CredentialAssertion) []byte {
+	if a.userHandle == nil {
+		t.Fatalf("Software authenticator has no registered credential")
+	}
+
+	a.signCount++
+
+	clientDataJSON := a.clientData(t, protocol.AssertCeremony, options.Response.Challenge)
+	authData := a.authenticatorData(options.Response.RelyingPartyID, 0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatalf("Failed to sign assertion: %v", err)
+	}
+
+	return a.marshalCredential(t, map[string]any{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(signature),
+		"userHandle":        base64.RawURLEncoding.EncodeToString(a.userHandle),
+	})
+}
+
+func (a *SoftwareAuthenticator) clientData(t *testing.T, ceremony protocol.CeremonyType, challenge protocol.URLEncodedBase64) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":        ceremony,
+		"challenge":   challenge.String(),
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode client data: %v", err)
+	}
+
+	return data
+}
+
+func (a *SoftwareAuthenticator) authenticatorData(rpID string, flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags|authenticatorFlagUserPresent|authenticatorFlagUserVerified)
+	return binary.BigEndian.AppendUint32(data, a.signCount)
+}
+
+func (a *SoftwareAuthenticator) marshalCredential(t *testing.T, response map[string]any) []byte {
+	id := base64.RawURLEncoding.EncodeToString(a.credentialID)
+
+	data, err := json.Marshal(map[string]any{
+		"id":                      id,
+		"rawId":                   id,
+		"type":                    "public-key",
+		"authenticatorAttachment": "platform",
+		"response":                response,
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode credential: %v", err)
+	}
+
+	return data
+}
+
+// decodeUserHandle reads the user handle from registration options, which is raw bytes
+// when the options come straight from the library and base64url text once sent as JSON.
+func decodeUserHandle(t *testing.T, id any) []byte {
+	switch v := id.(type) {
+	case protocol.URLEncodedBase64:
+		return v
+	case []byte:
+		return v
+	case string:
+		decoded, err := base64.RawURLEncoding.DecodeString(v)
+		if err != nil {
+			t.Fatalf("Failed to decode user handle: %v", err)
+		}
+		return decoded
+	default:
+		t.Fatalf("Unexpected user handle type %T", id)
+		return nil
+	}
+}
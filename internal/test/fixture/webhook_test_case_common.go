@@ -0,0 +1,127 @@
+package fixture
+
+import (
+	"time"
+
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// WebhookCommonTestDB holds two webhook subscriptions and deliveries of the first one in every state.
+type WebhookCommonTestDB struct {
+	base
+}
+
+// Migrate migrates the database schema for the WebhookCommonTestDB fixture.
+//
+// Returns:
+//   - error: An error if migration fails, otherwise nil
+func (w *WebhookCommonTestDB) Migrate() error {
+	return w.db.AutoMigrate(&model.WebhookSubscription{}, &model.WebhookDelivery{})
+}
+
+// GenerateData populates the test database with a subscription to user.created and user.updated events,
+// a subscription to user.deleted events, and deliveries of the first one: a succeeded one, a due one,
+// a failed one and one waiting for a retry.
+//
+// Returns:
+//   - error: An error if data generation fails, otherwise nil
+func (w *WebhookCommonTestDB) GenerateData() error {
+	db := w.db.Session(&gorm.Session{})
+
+	subscriptions := []*model.WebhookSubscription{
+		{
+			Base: model.Base{
+				ID:        "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime,
+			},
+			URL:        "https://partner.example.com/hooks",
+			Secret:     "whsec_partnersecret0000000000000000000000",
+			EventTypes: []string{"user.created", "user.updated"},
+		},
+		{
+			Base: model.Base{
+				ID:        "d4e5f6a7-0002-4b5c-9d0e-3f4a5b6c7d82",
+				CreatedAt: TestTime.Add(time.Hour),
+				UpdatedAt: TestTime.Add(time.Hour),
+			},
+			URL:        "https://crm.example.com/webhooks",
+			Secret:     "whsec_crmsecret0000000000000000000000000",
+			EventTypes: []string{"user.deleted"},
+		},
+	}
+	if err := db.Create(subscriptions).Error; err != nil {
+		return err
+	}
+
+	deliveredAt := TestTime.Add(time.Minute)
+	deliveries := []*model.WebhookDelivery{
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0001-4c5d-8e9f-4a5b6c7d8e91",
+				CreatedAt: TestTime,
+				UpdatedAt: TestTime.Add(time.Minute),
+			},
+			SubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			EventID:        "c3d4e5f6-0003-4a5b-8c9d-2e3f4a5b6c73",
+			EventType:      "user.created",
+			Payload:        `{"id":"de305d54-75b4-431b-adb2-eb6b9e546000","username":"Alice"}`,
+			OccurredAt:     TestTime,
+			Status:         model.WebhookDeliverySucceeded,
+			Attempts:       1,
+			LastStatusCode: 200,
+			NextAttemptAt:  TestTime,
+			DeliveredAt:    &deliveredAt,
+		},
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0002-4c5d-8e9f-4a5b6c7d8e92",
+				CreatedAt: TestTime.Add(time.Hour),
+				UpdatedAt: TestTime.Add(time.Hour),
+			},
+			SubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			EventID:        "c3d4e5f6-0001-4a5b-8c9d-2e3f4a5b6c71",
+			EventType:      "user.created",
+			Payload:        `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","username":"testuser001"}`,
+			OccurredAt:     TestTime.Add(time.Hour),
+			Status:         model.WebhookDeliveryPending,
+			NextAttemptAt:  TestTime.Add(time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0003-4c5d-8e9f-4a5b6c7d8e93",
+				CreatedAt: TestTime.Add(2 * time.Hour),
+				UpdatedAt: TestTime.Add(5 * time.Hour),
+			},
+			SubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			EventID:        "c3d4e5f6-0002-4a5b-8c9d-2e3f4a5b6c72",
+			EventType:      "user.updated",
+			Payload:        `{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","username":"testuser001"}`,
+			OccurredAt:     TestTime.Add(2 * time.Hour),
+			Status:         model.WebhookDeliveryFailed,
+			Attempts:       8,
+			LastStatusCode: 500,
+			LastError:      "unexpected status code 500",
+			NextAttemptAt:  TestTime.Add(5 * time.Hour),
+		},
+		{
+			Base: model.Base{
+				ID:        "e5f6a7b8-0004-4c5d-8e9f-4a5b6c7d8e94",
+				CreatedAt: TestTime.Add(3 * time.Hour),
+				UpdatedAt: TestTime.Add(3 * time.Hour),
+			},
+			SubscriptionID: "d4e5f6a7-0001-4b5c-9d0e-3f4a5b6c7d81",
+			EventID:        "c3d4e5f6-0005-4a5b-8c9d-2e3f4a5b6c75",
+			EventType:      "user.updated",
+			Payload:        `{"id":"de305d54-75b4-431b-adb2-eb6b9e546000","username":"Alice"}`,
+			OccurredAt:     TestTime.Add(3 * time.Hour),
+			Status:         model.WebhookDeliveryPending,
+			Attempts:       2,
+			LastError:      "context deadline exceeded",
+			NextAttemptAt:  TestTime.Add(4 * time.Hour),
+		},
+	}
+
+	return db.CreateInBatches(deliveries, 10).Error
+}
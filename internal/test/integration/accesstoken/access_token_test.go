@@ -0,0 +1,207 @@
+package accesstoken
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+// newTestAPI builds the API where "valid_jwt_token" is a login token of testuser001.
+func newTestAPI(t *testing.T, db *gorm.DB) api.Engine {
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": testUserID}, nil).Maybe()
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil).Maybe()
+
+	return api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    jwtValidator,
+	})
+}
+
+// send sends a request authenticated with the given bearer token.
+func send(apiEngine api.Engine, method, path, token string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+func TestAccessTokenEndpoint_CreateUseAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+	apiEngine := newTestAPI(t, db)
+
+	// Mint a token with a login token
+	createRec := send(apiEngine, http.MethodPost, "/v1/self/tokens", "valid_jwt_token",
+		strings.NewReader(`{"name":"backup script","scopes":["profile:read"]}`))
+	assert.Equal(t, http.StatusCreated, createRec.Code)
+	assert.NotContains(t, createRec.Body.String(), "token_hash")
+
+	createResp := struct {
+		Data struct {
+			ID          string `json:"id"`
+			Token       string `json:"token"`
+			TokenPrefix string `json:"token_prefix"`
+		} `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(createRec.Body.Bytes(), &createResp))
+	assert.True(t, strings.HasPrefix(createResp.Data.Token, "bmpat_"))
+	assert.True(t, strings.HasPrefix(createResp.Data.Token, createResp.Data.TokenPrefix))
+
+	// Only the hash is stored
+	stored := &model.PersonalAccessToken{}
+	assert.NoError(t, db.Where("id = ?", createResp.Data.ID).First(stored).Error)
+	assert.NotEqual(t, createResp.Data.Token, stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, createResp.Data.Token)
+	assert.Nil(t, stored.LastUsedAt)
+
+	// The token authenticates requests and its use is tracked
+	profileRec := send(apiEngine, http.MethodGet, "/v1/self/info", createResp.Data.Token, nil)
+	assert.Equal(t, http.StatusOK, profileRec.Code)
+	assert.Contains(t, profileRec.Body.String(), `"username":"testuser001"`)
+
+	assert.NoError(t, db.Where("id = ?", createResp.Data.ID).First(stored).Error)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	// Revoked tokens are rejected from the next request on
+	revokeRec := send(apiEngine, http.MethodDelete, "/v1/self/tokens/"+createResp.Data.ID, "valid_jwt_token", nil)
+	assert.Equal(t, http.StatusOK, revokeRec.Code)
+
+	profileRec = send(apiEngine, http.MethodGet, "/v1/self/info", createResp.Data.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, profileRec.Code)
+}
+
+func TestAccessTokenEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		method string
+		path   string
+		token  string
+		body   string
+
+		expectedStatusCode      int
+		expectedMessageResponse string
+	}{
+		{
+			name: "list tokens without their values",
+
+			method: http.MethodGet,
+			path:   "/v1/self/tokens",
+			token:  "valid_jwt_token",
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"name":"ci-script","token_prefix":"bmpat_active","scopes":["profile:read"]`,
+		},
+		{
+			name: "create failed - unsupported scope",
+
+			method: http.MethodPost,
+			path:   "/v1/self/tokens",
+			token:  "valid_jwt_token",
+			body:   `{"name":"backup script","scopes":["admin"]}`,
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `"message":"unsupported token scope"`,
+		},
+		{
+			name: "access token granted the scope reads the profile",
+
+			method: http.MethodGet,
+			path:   "/v1/self/info",
+			token:  fixture.ActiveAccessToken,
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"username":"testuser001"`,
+		},
+		{
+			name: "access token missing the scope cannot update the profile",
+
+			method: http.MethodPut,
+			path:   "/v1/self/info",
+			token:  fixture.ActiveAccessToken,
+			body:   `{"display_name":"Updated","email":"updated@example.com"}`,
+
+			expectedStatusCode:      http.StatusForbidden,
+			expectedMessageResponse: `"message":"access token is missing the profile:write scope"`,
+		},
+		{
+			name: "access token cannot manage tokens",
+
+			method: http.MethodPost,
+			path:   "/v1/self/tokens",
+			token:  fixture.ActiveAccessToken,
+			body:   `{"name":"backup script","scopes":["profile:read"]}`,
+
+			expectedStatusCode:      http.StatusForbidden,
+			expectedMessageResponse: `"message":"access tokens cannot manage account credentials"`,
+		},
+		{
+			name: "expired access token is rejected",
+
+			method: http.MethodGet,
+			path:   "/v1/self/info",
+			token:  fixture.ExpiredAccessToken,
+
+			expectedStatusCode:      http.StatusUnauthorized,
+			expectedMessageResponse: `"message":"Invalid token"`,
+		},
+		{
+			name: "revoke failed - token of another user",
+
+			method: http.MethodDelete,
+			path:   "/v1/self/tokens/00000000-0000-0000-0000-000000000000",
+			token:  "valid_jwt_token",
+
+			expectedStatusCode:      http.StatusNotFound,
+			expectedMessageResponse: `"message":"token not found"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.AccessTokenCommonTestDB{})
+			apiEngine := newTestAPI(t, db)
+
+			respRec := send(apiEngine, tc.method, tc.path, tc.token, strings.NewReader(tc.body))
+
+			assert.Equal(t, tc.expectedStatusCode, respRec.Code)
+			assert.Contains(t, respRec.Body.String(), tc.expectedMessageResponse)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package emailchange
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const (
+	testUserID     = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	testConfirmURL = "https://app.example.com/email-change/confirm"
+	testCancelURL  = "https://app.example.com/email-change/cancel"
+)
+
+var (
+	confirmLinkPattern = regexp.MustCompile(regexp.QuoteMeta(testConfirmURL) + `\?token=\S+`)
+	cancelLinkPattern  = regexp.MustCompile(regexp.QuoteMeta(testCancelURL) + `\?token=\S+`)
+)
+
+// newTestAPI builds the API with a recording mailer, authenticating valid_jwt_token as testuser001.
+func newTestAPI(t *testing.T, db *gorm.DB) (api.Engine, *fixture.RecordingMailer) {
+	recorder := fixture.NewRecordingMailer()
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": testUserID}, nil).Maybe()
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:           "bookmark_service",
+			InstanceID:            "test_instance_id_1",
+			EmailChangeConfirmURL: testConfirmURL,
+			EmailChangeCancelURL:  testCancelURL,
+		},
+		RedisClient:   redisPkg.InitMockRedis(t),
+		SqlDB:         db,
+		RandomCodeGen: utils.NewCodeGenerator(),
+		JWTValidator:  jwtValidator,
+		Mailer:        recorder,
+		Notifier:      notifier.NewMailNotifier(recorder),
+	})
+
+	return apiEngine, recorder
+}
+
+// serve sends a request to the API and returns the response.
+func serve(apiEngine api.Engine, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+// tokenFromMail extracts the token of the link matching pattern in an email body.
+func tokenFromMail(t *testing.T, pattern *regexp.Regexp, body string) string {
+	link, err := url.Parse(pattern.FindString(body))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("No email change link in email: %q", body)
+	}
+	return link.Query().Get("token")
+}
+
+// emailOf returns the current email address of a user.
+func emailOf(t *testing.T, db *gorm.DB, userID string) string {
+	user := &model.User{}
+	if err := db.Where("id = ?", userID).First(user).Error; err != nil {
+		t.Fatalf("Failed to read user %s: %v", userID, err)
+	}
+	return user.Email
+}
+
+func TestEmailChangeEndpoint_ConfirmAndRevert(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	apiEngine, recorder := newTestAPI(t, db)
+	auth := map[string]string{"Authorization": "Bearer valid_jwt_token", "If-Match": `"1"`}
+
+	// Changing the email only records a pending change
+	respRec := serve(apiEngine, http.MethodPut, "/v1/self/info", `{"display_name":"Test User 1","email":"testuser001new@example.com"}`, auth)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, `{"message":"Edit current user successfully! Confirm the new email address from the link sent to it"}`, respRec.Body.String())
+	assert.Equal(t, "testuser001@example.com", emailOf(t, db, testUserID))
+
+	messages := recorder.Messages()
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "testuser001new@example.com", messages[0].To)
+	assert.Equal(t, "testuser001@example.com", messages[1].To)
+	confirmToken := tokenFromMail(t, confirmLinkPattern, messages[0].Body)
+	cancelToken := tokenFromMail(t, cancelLinkPattern, messages[1].Body)
+
+	// The old address cannot confirm with its cancel token
+	respRec = serve(apiEngine, http.MethodPost, "/v1/users/email-change/confirm", `{"token":"`+cancelToken+`"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+
+	respRec = serve(apiEngine, http.MethodPost, "/v1/users/email-change/confirm", `{"token":"`+confirmToken+`"}`, nil)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, `{"message":"Email changed successfully!"}`, respRec.Body.String())
+	assert.Equal(t, "testuser001new@example.com", emailOf(t, db, testUserID))
+
+	// A confirmation link works only once
+	respRec = serve(apiEngine, http.MethodPost, "/v1/users/email-change/confirm", `{"token":"`+confirmToken+`"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"invalid or expired email change link"}`, respRec.Body.String())
+
+	// The old address can still revert the change
+	respRec = serve(apiEngine, http.MethodPost, "/v1/users/email-change/cancel", `{"token":"`+cancelToken+`"}`, nil)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, `{"message":"Email change cancelled successfully!"}`, respRec.Body.String())
+	assert.Equal(t, "testuser001@example.com", emailOf(t, db, testUserID))
+}
+
+func TestEmailChangeEndpoint_NewRequestSupersedesPending(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+	apiEngine, _ := newTestAPI(t, db)
+
+	respRec := serve(apiEngine, http.MethodPatch, "/v1/self/info", `{"email":"testuser001other@example.com"}`, map[string]string{
+		"Authorization": "Bearer valid_jwt_token",
+		"If-Match":      `"1"`,
+	})
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	respRec = serve(apiEngine, http.MethodPost, "/v1/users/email-change/confirm", `{"token":"`+fixture.PendingEmailChangeConfirmToken+`"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, "testuser001@example.com", emailOf(t, db, testUserID))
+}
+
+func TestEmailChangeEndpoint_Links(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		path  string
+		token string
+
+		expectedCode     int
+		expectedResponse string
+		expectedUserID   string
+		expectedEmail    string
+	}{
+		{
+			name: "confirm pending change",
+
+			path:  "/v1/users/email-change/confirm",
+			token: fixture.PendingEmailChangeConfirmToken,
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Email changed successfully!"}`,
+			expectedUserID:   testUserID,
+			expectedEmail:    "testuser001new@example.com",
+		},
+		{
+			name: "cancel pending change",
+
+			path:  "/v1/users/email-change/cancel",
+			token: fixture.PendingEmailChangeCancelToken,
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Email change cancelled successfully!"}`,
+			expectedUserID:   testUserID,
+			expectedEmail:    "testuser001@example.com",
+		},
+		{
+			name: "confirm expired change",
+
+			path:  "/v1/users/email-change/confirm",
+			token: fixture.ExpiredEmailChangeConfirmToken,
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired email change link"}`,
+			expectedUserID:   "123e4567-e89b-12d3-a456-eb6b9e546001",
+			expectedEmail:    "bob@example.com",
+		},
+		{
+			name: "cancel after the window",
+
+			path:  "/v1/users/email-change/cancel",
+			token: fixture.ExpiredEmailChangeCancelToken,
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired email change link"}`,
+			expectedUserID:   "123e4567-e89b-12d3-a456-eb6b9e546001",
+			expectedEmail:    "bob@example.com",
+		},
+		{
+			name: "revert confirmed change",
+
+			path:  "/v1/users/email-change/cancel",
+			token: fixture.ConfirmedEmailChangeCancelToken,
+
+			expectedCode:     http.StatusOK,
+			expectedResponse: `{"message":"Email change cancelled successfully!"}`,
+			expectedUserID:   "987e6543-e21b-12d3-a456-eb6b9e546002",
+			expectedEmail:    "charlie.old@example.com",
+		},
+		{
+			name: "unknown token",
+
+			path:  "/v1/users/email-change/confirm",
+			token: "unknownToken",
+
+			expectedCode:     http.StatusBadRequest,
+			expectedResponse: `{"message":"invalid or expired email change link"}`,
+			expectedUserID:   testUserID,
+			expectedEmail:    "testuser001@example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.EmailChangeCommonTestDB{})
+			apiEngine, _ := newTestAPI(t, db)
+
+			respRec := serve(apiEngine, http.MethodPost, tc.path, `{"token":"`+tc.token+`"}`, nil)
+			assert.Equal(t, tc.expectedCode, respRec.Code)
+			assert.Equal(t, tc.expectedResponse, respRec.Body.String())
+			assert.Equal(t, tc.expectedEmail, emailOf(t, db, tc.expectedUserID))
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package identity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	identityService "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+const testRedirectURL = "http://localhost:8080/v1/users/login/oidc/mockidp/callback"
+
+func TestIdentityEndpoint_OIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		providerUser fixture.MockOIDCUser
+
+		setupMockJWTGenerator func(t *testing.T) *mocks.JWTGenerator
+
+		tamperCallback func(query map[string][]string)
+
+		expectedStatusCode      int
+		expectedMessageResponse string
+	}{
+		{
+			name: "login with an already linked identity",
+
+			providerUser: fixture.MockOIDCUser{
+				Subject: "mockidp-subject-001",
+				Email:   "testuser001@example.com",
+			},
+
+			setupMockJWTGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				jwtGen := mocks.NewJWTGenerator(t)
+				jwtGen.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					return claims["sub"] == "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+				})).Return("mocked_jwt_token", nil)
+				return jwtGen
+			},
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `{"data":"mocked_jwt_token","message":"Logged in successfully!"}`,
+		},
+		{
+			name: "login links a new subject to the user owning the verified email",
+
+			providerUser: fixture.MockOIDCUser{
+				Subject:       "mockidp-subject-002",
+				Email:         "alice@example.com",
+				EmailVerified: true,
+			},
+
+			setupMockJWTGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				jwtGen := mocks.NewJWTGenerator(t)
+				jwtGen.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+					return claims["sub"] == "de305d54-75b4-431b-adb2-eb6b9e546000"
+				})).Return("mocked_jwt_token", nil)
+				return jwtGen
+			},
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `{"data":"mocked_jwt_token","message":"Logged in successfully!"}`,
+		},
+		{
+			name: "login provisions a new user",
+
+			providerUser: fixture.MockOIDCUser{
+				Subject:           "mockidp-subject-003",
+				Email:             "newcomer@example.com",
+				EmailVerified:     true,
+				PreferredUsername: "newcomer",
+			},
+
+			setupMockJWTGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				jwtGen := mocks.NewJWTGenerator(t)
+				jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+				return jwtGen
+			},
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `{"data":"mocked_jwt_token","message":"Logged in successfully!"}`,
+		},
+		{
+			name: "login failed - unverified email owned by another user",
+
+			providerUser: fixture.MockOIDCUser{
+				Subject: "mockidp-subject-004",
+				Email:   "bob@example.com",
+			},
+
+			setupMockJWTGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				return mocks.NewJWTGenerator(t)
+			},
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `{"message":"an account with this email already exists, log in and link the identity through POST /v1/self/identities/:provider"}`,
+		},
+		{
+			name: "login failed - verified email owned by a user who never verified it",
+
+			providerUser: fixture.MockOIDCUser{
+				Subject:       "mockidp-subject-005",
+				Email:         "bob@example.com",
+				EmailVerified: true,
+			},
+
+			setupMockJWTGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				return mocks.NewJWTGenerator(t)
+			},
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `{"message":"an account with this email already exists, log in and link the identity through POST /v1/self/identities/:provider"}`,
+		},
+		{
+			name: "login failed - unknown state",
+
+			providerUser: fixture.MockOIDCUser{
+				Subject: "mockidp-subject-001",
+			},
+
+			setupMockJWTGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				return mocks.NewJWTGenerator(t)
+			},
+
+			tamperCallback: func(query map[string][]string) {
+				query["state"] = []string{"forged-state"}
+			},
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `{"message":"invalid or expired login state"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			redisClient := redisPkg.InitMockRedis(t)
+
+			idp := fixture.NewMockOIDCProvider(t)
+			idp.SetUser(tc.providerUser)
+
+			apiEngine := api.New(&api.EngineOpts{
+				Engine: gin.New(),
+				Cfg: &api.Config{
+					ServiceName: "bookmark_service",
+					InstanceID:  "test_instance_id_1",
+				},
+				RedisClient:     redisClient,
+				SqlDB:           db,
+				RandomCodeGen:   utils.NewCodeGenerator(),
+				PasswordHashing: fixture.NewPasswordHashing(t),
+				JWTGenerator:    tc.setupMockJWTGenerator(t),
+				JWTValidator:    nil,
+				OIDCProviders:   map[string]identityService.Provider{"mockidp": newTestProvider(idp)},
+			})
+
+			// Start the flow and follow the redirect to the provider
+			startReq := httptest.NewRequest(http.MethodGet, "/v1/users/login/oidc/mockidp", nil)
+			startRec := httptest.NewRecorder()
+			apiEngine.ServeHTTP(startRec, startReq)
+			assert.Equal(t, http.StatusFound, startRec.Code)
+
+			callbackURL := idp.Authorize(t, startRec.Header().Get("Location"))
+			query := callbackURL.Query()
+			if tc.tamperCallback != nil {
+				tc.tamperCallback(query)
+			}
+
+			// Complete the flow on the callback endpoint
+			callbackReq := httptest.NewRequest(http.MethodGet, callbackURL.Path+"?"+query.Encode(), nil)
+			callbackRec := httptest.NewRecorder()
+			apiEngine.ServeHTTP(callbackRec, callbackReq)
+
+			assert.Equal(t, tc.expectedStatusCode, callbackRec.Code)
+			assert.Equal(t, tc.expectedMessageResponse, callbackRec.Body.String())
+		})
+	}
+}
+
+func TestIdentityEndpoint_OIDCLogin_ProvisionedUserLookup(t *testing.T) {
+	t.Parallel()
+
+	idp := fixture.NewMockOIDCProvider(t)
+	idp.SetUser(fixture.MockOIDCUser{
+		Subject:           "mockidp-subject-003",
+		Email:             "newcomer@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "newcomer",
+	})
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+			// The cache remembers misses, which the provisioning must drop
+			UserCacheTTL:         time.Minute,
+			UserCacheNegativeTTL: time.Minute,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.IdentityCommonTestDB{}),
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		OIDCProviders:   map[string]identityService.Provider{"mockidp": newTestProvider(idp)},
+	})
+
+	lookup := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/by-username/newcomer", nil)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// The username is unknown yet
+	respRec := lookup()
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+
+	startReq := httptest.NewRequest(http.MethodGet, "/v1/users/login/oidc/mockidp", nil)
+	startRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(startRec, startReq)
+	assert.Equal(t, http.StatusFound, startRec.Code)
+
+	callbackURL := idp.Authorize(t, startRec.Header().Get("Location"))
+	callbackReq := httptest.NewRequest(http.MethodGet, callbackURL.Path+"?"+callbackURL.RawQuery, nil)
+	callbackRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(callbackRec, callbackReq)
+	assert.Equal(t, http.StatusOK, callbackRec.Code)
+
+	// The provisioned user is found
+	respRec = lookup()
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"username":"newcomer"`)
+}
+
+func TestIdentityEndpoint_OIDCLogin_UnknownProvider(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:   redisPkg.InitMockRedis(t),
+		SqlDB:         db,
+		RandomCodeGen: utils.NewCodeGenerator(),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/login/oidc/unknown", nil)
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+	assert.Equal(t, `{"message":"unknown identity provider"}`, respRec.Body.String())
+}
+
+// newTestProvider configures the OIDC client against the mock provider.
+func newTestProvider(idp *fixture.MockOIDCProvider) identityService.Provider {
+	return identityService.NewOIDCProvider(&identityService.ProviderConfig{
+		Issuer:       idp.Issuer(),
+		ClientID:     fixture.MockOIDCClientID,
+		ClientSecret: fixture.MockOIDCClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+	}, idp.Server.Client())
+}
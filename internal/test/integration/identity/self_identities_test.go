@@ -0,0 +1,203 @@
+package identity
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	identityService "github.com/vukieuhaihoa/user-service/internal/app/service/identity"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const (
+	testUserID          = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	testFederatedUserID = "9c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f"
+)
+
+func TestIdentityEndpoint_SelfIdentities(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		claims jwt.MapClaims
+
+		setupTestHTTP func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder
+
+		expectedStatusCode      int
+		expectedMessageResponse string
+		expectedIdentityCount   int64
+	}{
+		{
+			name: "list linked identities",
+
+			claims: jwt.MapClaims{"sub": testUserID},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, "/v1/self/identities", nil)
+				req.Header.Set("Authorization", "Bearer valid_jwt_token")
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"subject":"mockidp-subject-001"`,
+			expectedIdentityCount:   1,
+		},
+		{
+			name: "link a new identity after a recent login",
+
+			claims: jwt.MapClaims{"sub": testUserID, "iat": float64(time.Now().Unix())},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder {
+				idp.SetUser(fixture.MockOIDCUser{Subject: "mockidp-subject-002", Email: "another@example.com"})
+
+				req := httptest.NewRequest(http.MethodPost, "/v1/self/identities/mockidp", nil)
+				req.Header.Set("Authorization", "Bearer valid_jwt_token")
+				startRec := httptest.NewRecorder()
+				api.ServeHTTP(startRec, req)
+				assert.Equal(t, http.StatusOK, startRec.Code)
+
+				startResp := struct {
+					Data string `json:"data"`
+				}{}
+				assert.NoError(t, json.Unmarshal(startRec.Body.Bytes(), &startResp))
+
+				// The provider calls back the public login callback, which links instead of logging in
+				callbackURL := idp.Authorize(t, startResp.Data)
+				callbackReq := httptest.NewRequest(http.MethodGet, callbackURL.RequestURI(), nil)
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, callbackReq)
+				return respRec
+			},
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"message":"Logged in successfully!"`,
+			expectedIdentityCount:   2,
+		},
+		{
+			name: "link failed - login is too old",
+
+			claims: jwt.MapClaims{"sub": testUserID, "iat": float64(time.Now().Add(-time.Hour).Unix())},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodPost, "/v1/self/identities/mockidp", nil)
+				req.Header.Set("Authorization", "Bearer valid_jwt_token")
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+
+			expectedStatusCode:      http.StatusForbidden,
+			expectedMessageResponse: `"message":"recent login required, please log in again"`,
+			expectedIdentityCount:   1,
+		},
+		{
+			name: "unlink an identity of a user with a password",
+
+			claims: jwt.MapClaims{"sub": testUserID},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodDelete, "/v1/self/identities/5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00", nil)
+				req.Header.Set("Authorization", "Bearer valid_jwt_token")
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"message":"Identity unlinked successfully!"`,
+			expectedIdentityCount:   0,
+		},
+		{
+			name: "unlink failed - last remaining credential",
+
+			claims: jwt.MapClaims{"sub": testFederatedUserID},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodDelete, "/v1/self/identities/6c1f7b3f-3e2d-4d4f-8b62-1d9f8e3e2b11", nil)
+				req.Header.Set("Authorization", "Bearer valid_jwt_token")
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+
+			expectedStatusCode:      http.StatusConflict,
+			expectedMessageResponse: `"message":"cannot remove the last remaining login method"`,
+			expectedIdentityCount:   1,
+		},
+		{
+			name: "unlink failed - identity of another user",
+
+			claims: jwt.MapClaims{"sub": testFederatedUserID},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, idp *fixture.MockOIDCProvider) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodDelete, "/v1/self/identities/5b0f6a2e-2d1c-4c3e-9a51-0c8f7e2d1a00", nil)
+				req.Header.Set("Authorization", "Bearer valid_jwt_token")
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+
+			expectedStatusCode:      http.StatusNotFound,
+			expectedMessageResponse: `"message":"identity not found"`,
+			expectedIdentityCount:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.IdentityCommonTestDB{})
+			idp := fixture.NewMockOIDCProvider(t)
+
+			jwtValidator := mocks.NewJWTValidator(t)
+			jwtValidator.On("ValidateToken", "valid_jwt_token").Return(tc.claims, nil)
+
+			jwtGen := mocks.NewJWTGenerator(t)
+			jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil).Maybe()
+
+			apiEngine := api.New(&api.EngineOpts{
+				Engine: gin.New(),
+				Cfg: &api.Config{
+					ServiceName: "bookmark_service",
+					InstanceID:  "test_instance_id_1",
+				},
+				RedisClient:     redisPkg.InitMockRedis(t),
+				SqlDB:           db,
+				RandomCodeGen:   utils.NewCodeGenerator(),
+				PasswordHashing: fixture.NewPasswordHashing(t),
+				JWTGenerator:    jwtGen,
+				JWTValidator:    jwtValidator,
+				OIDCProviders:   map[string]identityService.Provider{"mockidp": newTestProvider(idp)},
+			})
+
+			respRec := tc.setupTestHTTP(t, apiEngine, idp)
+
+			assert.Equal(t, tc.expectedStatusCode, respRec.Code)
+			assert.Contains(t, respRec.Body.String(), tc.expectedMessageResponse)
+			assert.Equal(t, tc.expectedIdentityCount, countIdentities(db, tc.claims["sub"].(string)))
+		})
+	}
+}
+
+// countIdentities returns how many identities are linked to a user.
+func countIdentities(db *gorm.DB, userID string) int64 {
+	var count int64
+	db.Model(&model.UserIdentity{}).Where("user_id = ?", userID).Count(&count)
+	return count
+}
@@ -0,0 +1,171 @@
+package loginhistory
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+// newTestAPI builds the API where "valid_jwt_token" is a login token of testuser001,
+// alerting users through the given recording mailer.
+func newTestAPI(t *testing.T, db *gorm.DB, mailer *fixture.RecordingMailer) api.Engine {
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": testUserID}, nil).Maybe()
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil).Maybe()
+
+	return api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    jwtValidator,
+		Mailer:          mailer,
+		Notifier:        notifier.NewMailNotifier(mailer),
+	})
+}
+
+// login logs testuser001 in from the given device.
+func login(apiEngine api.Engine, password, ipAddress, userAgent string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login",
+		strings.NewReader(`{"username":"testuser001","password":"`+password+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.RemoteAddr = ipAddress + ":40000"
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+type loginEventResponse struct {
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+	Success   bool   `json:"success"`
+	MFAUsed   bool   `json:"mfa_used"`
+}
+
+// listHistory returns the login history of testuser001.
+func listHistory(t *testing.T, apiEngine api.Engine) []loginEventResponse {
+	req := httptest.NewRequest(http.MethodGet, "/v1/self/login-history", nil)
+	req.Header.Set("Authorization", "Bearer valid_jwt_token")
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.NotContains(t, respRec.Body.String(), "fingerprint")
+
+	resp := struct {
+		Data []loginEventResponse `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(respRec.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestLoginHistoryEndpoint_ListHistory(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+	apiEngine := newTestAPI(t, db, fixture.NewRecordingMailer())
+
+	history := listHistory(t, apiEngine)
+	assert.Equal(t, []loginEventResponse{
+		{IPAddress: fixture.KnownDeviceIPAddress, UserAgent: fixture.KnownDeviceUserAgent, Success: true},
+		{IPAddress: "198.51.100.20", UserAgent: "curl/8.4.0", Success: false},
+		{IPAddress: fixture.KnownDeviceIPAddress, UserAgent: fixture.KnownDeviceUserAgent, Success: true},
+	}, history)
+}
+
+func TestLoginHistoryEndpoint_Login(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		inputPassword  string
+		inputIPAddress string
+		inputUserAgent string
+
+		expectedStatusCode int
+		expectedEvent      loginEventResponse
+		expectedAlerts     int
+	}{
+		{
+			name: "login from a known device is recorded without alert",
+
+			inputPassword:  "my_SECURE_password123@",
+			inputIPAddress: fixture.KnownDeviceIPAddress,
+			inputUserAgent: fixture.KnownDeviceUserAgent,
+
+			expectedStatusCode: http.StatusOK,
+			expectedEvent:      loginEventResponse{IPAddress: fixture.KnownDeviceIPAddress, UserAgent: fixture.KnownDeviceUserAgent, Success: true},
+		},
+		{
+			name: "login from a new device is recorded and alerted",
+
+			inputPassword:  "my_SECURE_password123@",
+			inputIPAddress: "192.0.2.99",
+			inputUserAgent: fixture.KnownDeviceUserAgent,
+
+			expectedStatusCode: http.StatusOK,
+			expectedEvent:      loginEventResponse{IPAddress: "192.0.2.99", UserAgent: fixture.KnownDeviceUserAgent, Success: true},
+			expectedAlerts:     1,
+		},
+		{
+			name: "failed login from a new device is recorded without alert",
+
+			inputPassword:  "wrong_password",
+			inputIPAddress: "192.0.2.99",
+			inputUserAgent: "curl/8.4.0",
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedEvent:      loginEventResponse{IPAddress: "192.0.2.99", UserAgent: "curl/8.4.0", Success: false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.LoginHistoryCommonTestDB{})
+			mailer := fixture.NewRecordingMailer()
+			apiEngine := newTestAPI(t, db, mailer)
+
+			respRec := login(apiEngine, tc.inputPassword, tc.inputIPAddress, tc.inputUserAgent)
+			assert.Equal(t, tc.expectedStatusCode, respRec.Code)
+
+			history := listHistory(t, apiEngine)
+			assert.Len(t, history, 4)
+			assert.Equal(t, tc.expectedEvent, history[0])
+
+			messages := mailer.Messages()
+			assert.Len(t, messages, tc.expectedAlerts)
+			for _, msg := range messages {
+				assert.Equal(t, "testuser001@example.com", msg.To)
+				assert.Equal(t, "New login to your account", msg.Subject)
+				assert.Contains(t, msg.Body, "IP address: "+tc.inputIPAddress)
+			}
+		})
+	}
+}
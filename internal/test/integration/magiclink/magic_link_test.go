@@ -0,0 +1,185 @@
+package magiclink
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	magicLinkHandler "github.com/vukieuhaihoa/user-service/internal/app/handler/magiclink"
+	magicLinkService "github.com/vukieuhaihoa/user-service/internal/app/service/magiclink"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+const testLinkURL = "https://app.example.com/login/magic-link"
+
+var linkPattern = regexp.MustCompile(regexp.QuoteMeta(testLinkURL) + `\?token=\S+`)
+
+// newTestAPI builds the API with a recording mailer.
+func newTestAPI(t *testing.T, jwtGen *mocks.JWTGenerator) (api.Engine, *fixture.RecordingMailer) {
+	recorder := fixture.NewRecordingMailer()
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:     "bookmark_service",
+			InstanceID:      "test_instance_id_1",
+			MagicLinkSecret: "test-secret",
+			MagicLinkURL:    testLinkURL,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		Mailer:          recorder,
+	})
+
+	return apiEngine, recorder
+}
+
+// requestLink calls the request endpoint and returns the response.
+func requestLink(apiEngine api.Engine, email string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login/magic-link", strings.NewReader(`{"email":"`+email+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+// verifyLink calls the verify endpoint with an optional nonce cookie and returns the response.
+func verifyLink(apiEngine api.Engine, token string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login/magic-link/verify", strings.NewReader(`{"token":"`+token+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+// tokenFromMail extracts the token of the login link in an email body.
+func tokenFromMail(t *testing.T, body string) string {
+	link, err := url.Parse(linkPattern.FindString(body))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("No login link in email: %q", body)
+	}
+	return link.Query().Get("token")
+}
+
+// nonceCookie returns the nonce cookie set by a response.
+func nonceCookie(t *testing.T, respRec *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range respRec.Result().Cookies() {
+		if cookie.Name == magicLinkHandler.NonceCookieName {
+			return cookie
+		}
+	}
+	t.Fatalf("No nonce cookie in response")
+	return nil
+}
+
+func TestMagicLinkEndpoint_Login(t *testing.T) {
+	t.Parallel()
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil).Once()
+
+	apiEngine, recorder := newTestAPI(t, jwtGen)
+
+	requestRec := requestLink(apiEngine, "testuser001@example.com")
+	assert.Equal(t, http.StatusAccepted, requestRec.Code)
+
+	messages := recorder.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "testuser001@example.com", messages[0].To)
+
+	token := tokenFromMail(t, messages[0].Body)
+	cookie := nonceCookie(t, requestRec)
+
+	// another device does not hold the nonce cookie
+	otherDeviceRec := verifyLink(apiEngine, token, &http.Cookie{Name: magicLinkHandler.NonceCookieName, Value: "other-device"})
+	assert.Equal(t, http.StatusBadRequest, otherDeviceRec.Code)
+	assert.Equal(t, `{"message":"invalid or expired login link"}`, otherDeviceRec.Body.String())
+
+	// the failed attempt does not consume the link, so the requesting device can still use it
+	verifyRec := verifyLink(apiEngine, token, cookie)
+	assert.Equal(t, http.StatusOK, verifyRec.Code)
+	assert.Equal(t, `{"data":"mocked_jwt_token","message":"Logged in successfully!"}`, verifyRec.Body.String())
+
+	// a link works only once
+	replayRec := verifyLink(apiEngine, token, cookie)
+	assert.Equal(t, http.StatusBadRequest, replayRec.Code)
+}
+
+func TestMagicLinkEndpoint_Request(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		email    string
+		attempts int
+
+		expectedStatusCode      int
+		expectedMessageResponse string
+		expectedSentMessages    int
+	}{
+		{
+			name: "unknown email gets the same response without an email",
+
+			email:    "nobody@example.com",
+			attempts: 1,
+
+			expectedStatusCode:      http.StatusAccepted,
+			expectedMessageResponse: `{"message":"If the email belongs to an account, a login link has been sent"}`,
+			expectedSentMessages:    0,
+		},
+		{
+			name: "email is throttled after too many requests",
+
+			email:    "testuser001@example.com",
+			attempts: magicLinkService.RequestLimit + 1,
+
+			expectedStatusCode:      http.StatusTooManyRequests,
+			expectedMessageResponse: `{"message":"too many login links requested, please try again later"}`,
+			expectedSentMessages:    magicLinkService.RequestLimit,
+		},
+		{
+			name: "invalid email",
+
+			email:    "not-an-email",
+			attempts: 1,
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `{"message":"Invalid input fields","details":["Email is invalid (email)"]}`,
+			expectedSentMessages:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiEngine, recorder := newTestAPI(t, mocks.NewJWTGenerator(t))
+
+			var respRec *httptest.ResponseRecorder
+			for range tc.attempts {
+				respRec = requestLink(apiEngine, tc.email)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, respRec.Code)
+			assert.Equal(t, tc.expectedMessageResponse, respRec.Body.String())
+			assert.Len(t, recorder.Messages(), tc.expectedSentMessages)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package passkey
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+// newTestAPI builds the API with a relying party accepting the software authenticator.
+func newTestAPI(t *testing.T, db *gorm.DB, claims jwt.MapClaims) api.Engine {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          fixture.MockWebAuthnRPID,
+		RPDisplayName: "User Service",
+		RPOrigins:     []string{fixture.MockWebAuthnOrigin},
+	})
+	assert.Nil(t, err)
+
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").Return(claims, nil).Maybe()
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil).Maybe()
+
+	return api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    jwtValidator,
+		WebAuthn:        webAuthn,
+	})
+}
+
+// post sends a JSON request, authenticated with the mocked token when authorized is set.
+func post(apiEngine api.Engine, path string, body any, authorized bool) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	if authorized {
+		req.Header.Set("Authorization", "Bearer valid_jwt_token")
+	}
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+// verifyBody builds the body of a verify endpoint.
+func verifyBody(sessionID string, credential []byte) map[string]any {
+	return map[string]any{
+		"session_id": sessionID,
+		"credential": json.RawMessage(credential),
+	}
+}
+
+// registerPasskey registers the software authenticator for the logged in user and returns the response.
+func registerPasskey(t *testing.T, apiEngine api.Engine, authenticator *fixture.SoftwareAuthenticator) *httptest.ResponseRecorder {
+	optionsRec := post(apiEngine, "/v1/self/passkeys/registration/options", nil, true)
+	assert.Equal(t, http.StatusOK, optionsRec.Code)
+
+	optionsResp := struct {
+		Data struct {
+			SessionID string                      `json:"session_id"`
+			Options   protocol.CredentialCreation `json:"options"`
+		} `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(optionsRec.Body.Bytes(), &optionsResp))
+
+	credential := authenticator.Register(t, &optionsResp.Data.Options)
+	return post(apiEngine, "/v1/self/passkeys/registration/verify", verifyBody(optionsResp.Data.SessionID, credential), true)
+}
+
+// beginLogin starts a passkey login and returns the session ID and the signed assertion.
+func beginLogin(t *testing.T, apiEngine api.Engine, authenticator *fixture.SoftwareAuthenticator) (string, []byte) {
+	optionsRec := post(apiEngine, "/v1/users/login/passkey/options", nil, false)
+	assert.Equal(t, http.StatusOK, optionsRec.Code)
+
+	optionsResp := struct {
+		Data struct {
+			SessionID string                       `json:"session_id"`
+			Options   protocol.CredentialAssertion `json:"options"`
+		} `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(optionsRec.Body.Bytes(), &optionsResp))
+
+	return optionsResp.Data.SessionID, authenticator.Assert(t, &optionsResp.Data.Options)
+}
+
+func TestPasskeyEndpoint_RegisterAndLogin(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+	apiEngine := newTestAPI(t, db, jwt.MapClaims{"sub": testUserID, "iat": float64(time.Now().Unix())})
+	authenticator := fixture.NewSoftwareAuthenticator(t)
+
+	registerRec := registerPasskey(t, apiEngine, authenticator)
+	assert.Equal(t, http.StatusCreated, registerRec.Code)
+	assert.Contains(t, registerRec.Body.String(), `"message":"Passkey registered successfully!"`)
+
+	stored := &model.UserPasskey{}
+	assert.NoError(t, db.Where("credential_id = ?", authenticator.CredentialID()).First(stored).Error)
+	assert.Equal(t, testUserID, stored.UserID)
+	assert.Equal(t, []string{"internal"}, stored.Transports)
+
+	// the same authenticator cannot be registered twice
+	duplicateRec := registerPasskey(t, apiEngine, authenticator)
+	assert.Equal(t, http.StatusConflict, duplicateRec.Code)
+
+	sessionID, assertion := beginLogin(t, apiEngine, authenticator)
+	loginRec := post(apiEngine, "/v1/users/login/passkey/verify", verifyBody(sessionID, assertion), false)
+	assert.Equal(t, http.StatusOK, loginRec.Code)
+	assert.Equal(t, `{"data":"mocked_jwt_token","message":"Logged in successfully!"}`, loginRec.Body.String())
+
+	assert.NoError(t, db.Where("id = ?", stored.ID).First(stored).Error)
+	assert.Equal(t, uint32(1), stored.SignCount)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	// a login challenge can only be answered once
+	replayRec := post(apiEngine, "/v1/users/login/passkey/verify", verifyBody(sessionID, assertion), false)
+	assert.Equal(t, http.StatusBadRequest, replayRec.Code)
+	assert.Equal(t, `{"message":"invalid or expired passkey session"}`, replayRec.Body.String())
+}
+
+func TestPasskeyEndpoint_Failures(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		claims jwt.MapClaims
+
+		setupTestHTTP func(t *testing.T, api api.Engine, authenticator *fixture.SoftwareAuthenticator) *httptest.ResponseRecorder
+
+		expectedStatusCode      int
+		expectedMessageResponse string
+		expectedPasskeyCount    int64
+	}{
+		{
+			name: "register failed - login is too old",
+
+			claims: jwt.MapClaims{"sub": testUserID, "iat": float64(time.Now().Add(-time.Hour).Unix())},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, authenticator *fixture.SoftwareAuthenticator) *httptest.ResponseRecorder {
+				return post(api, "/v1/self/passkeys/registration/options", nil, true)
+			},
+
+			expectedStatusCode:      http.StatusForbidden,
+			expectedMessageResponse: `{"message":"recent login required, please log in again"}`,
+			expectedPasskeyCount:    1,
+		},
+		{
+			name: "register failed - unknown session",
+
+			claims: jwt.MapClaims{"sub": testUserID, "iat": float64(time.Now().Unix())},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, authenticator *fixture.SoftwareAuthenticator) *httptest.ResponseRecorder {
+				return post(api, "/v1/self/passkeys/registration/verify", verifyBody("unknown-session", []byte(`{}`)), true)
+			},
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `{"message":"invalid or expired passkey session"}`,
+			expectedPasskeyCount:    1,
+		},
+		{
+			name: "login failed - passkey not registered",
+
+			claims: jwt.MapClaims{"sub": testUserID, "iat": float64(time.Now().Unix())},
+
+			setupTestHTTP: func(t *testing.T, api api.Engine, authenticator *fixture.SoftwareAuthenticator) *httptest.ResponseRecorder {
+				// the authenticator created the credential but the registration was never verified
+				optionsRec := post(api, "/v1/self/passkeys/registration/options", nil, true)
+				optionsResp := struct {
+					Data struct {
+						Options protocol.CredentialCreation `json:"options"`
+					} `json:"data"`
+				}{}
+				assert.NoError(t, json.Unmarshal(optionsRec.Body.Bytes(), &optionsResp))
+				authenticator.Register(t, &optionsResp.Data.Options)
+
+				sessionID, assertion := beginLogin(t, api, authenticator)
+				return post(api, "/v1/users/login/passkey/verify", verifyBody(sessionID, assertion), false)
+			},
+
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `{"message":"passkey verification failed"}`,
+			expectedPasskeyCount:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.PasskeyCommonTestDB{})
+			apiEngine := newTestAPI(t, db, tc.claims)
+
+			respRec := tc.setupTestHTTP(t, apiEngine, fixture.NewSoftwareAuthenticator(t))
+
+			assert.Equal(t, tc.expectedStatusCode, respRec.Code)
+			assert.Equal(t, tc.expectedMessageResponse, respRec.Body.String())
+			assert.Equal(t, tc.expectedPasskeyCount, countPasskeys(db, testUserID))
+		})
+	}
+}
+
+// countPasskeys returns how many passkeys a user has registered.
+func countPasskeys(db *gorm.DB, userID string) int64 {
+	var count int64
+	db.Model(&model.UserPasskey{}).Where("user_id = ?", userID).Count(&count)
+	return count
+}
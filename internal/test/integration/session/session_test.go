@@ -0,0 +1,203 @@
+package session
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const (
+	testUserID       = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	currentSessionID = "a1b2c3d4-0001-4e5f-8a9b-0c1d2e3f4a51"
+)
+
+// newTestAPI builds the API where "valid_jwt_token" is a login token of testuser001 bound to the Chrome session,
+// and "mocked_jwt_token" carries the claims of the last token issued on login.
+func newTestAPI(t *testing.T, db *gorm.DB) api.Engine {
+	var (
+		mu     sync.Mutex
+		issued jwt.MapClaims
+	)
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		issued = args.Get(0).(jwt.MapClaims)
+	}).Return("mocked_jwt_token", nil).Maybe()
+
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").
+		Return(jwt.MapClaims{"sub": testUserID, "sid": currentSessionID}, nil).Maybe()
+	jwtValidator.On("ValidateToken", "mocked_jwt_token").Return(func(string) jwt.MapClaims {
+		mu.Lock()
+		defer mu.Unlock()
+		return issued
+	}, nil).Maybe()
+
+	return api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    jwtValidator,
+	})
+}
+
+// send sends a request authenticated with the given bearer token.
+func send(apiEngine api.Engine, method, path, token string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+type sessionResponse struct {
+	ID         string `json:"id"`
+	DeviceName string `json:"device_name"`
+	IPAddress  string `json:"ip_address"`
+	Current    bool   `json:"current"`
+}
+
+func listSessions(t *testing.T, apiEngine api.Engine, token string) []sessionResponse {
+	rec := send(apiEngine, http.MethodGet, "/v1/self/sessions", token, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	resp := struct {
+		Data []sessionResponse `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+func TestSessionEndpoint_LoginListAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+	apiEngine := newTestAPI(t, db)
+
+	// Logging in opens a session for the calling device
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login",
+		strings.NewReader(`{"username":"testuser001","password":"my_SECURE_password123@"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "curl/8.4.0")
+	req.RemoteAddr = "192.0.2.99:40000"
+	loginRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(loginRec, req)
+	assert.Equal(t, http.StatusOK, loginRec.Code)
+
+	sessions := listSessions(t, apiEngine, "mocked_jwt_token")
+	assert.Len(t, sessions, 3)
+	assert.Equal(t, "curl", sessions[0].DeviceName)
+	assert.Equal(t, "192.0.2.99", sessions[0].IPAddress)
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+	assert.False(t, sessions[2].Current)
+
+	// Revoking the new session signs its token out from the next request on
+	revokeRec := send(apiEngine, http.MethodDelete, "/v1/self/sessions/"+sessions[0].ID, "valid_jwt_token", nil)
+	assert.Equal(t, http.StatusOK, revokeRec.Code)
+
+	profileRec := send(apiEngine, http.MethodGet, "/v1/self/info", "mocked_jwt_token", nil)
+	assert.Equal(t, http.StatusUnauthorized, profileRec.Code)
+
+	profileRec = send(apiEngine, http.MethodGet, "/v1/self/info", "valid_jwt_token", nil)
+	assert.Equal(t, http.StatusOK, profileRec.Code)
+}
+
+func TestSessionEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		method string
+		path   string
+
+		expectedStatusCode      int
+		expectedMessageResponse string
+	}{
+		{
+			name: "list active sessions marking the current one",
+
+			method: http.MethodGet,
+			path:   "/v1/self/sessions",
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"device_name":"Chrome on macOS","ip_address":"203.0.113.10"`,
+		},
+		{
+			name: "revoke another session of the user",
+
+			method: http.MethodDelete,
+			path:   "/v1/self/sessions/a1b2c3d4-0002-4e5f-8a9b-0c1d2e3f4a52",
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMessageResponse: `"message":"Session revoked successfully!"`,
+		},
+		{
+			name: "revoke failed - session of another user",
+
+			method: http.MethodDelete,
+			path:   "/v1/self/sessions/a1b2c3d4-0004-4e5f-8a9b-0c1d2e3f4a54",
+
+			expectedStatusCode:      http.StatusNotFound,
+			expectedMessageResponse: `"message":"session not found"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+			apiEngine := newTestAPI(t, db)
+
+			respRec := send(apiEngine, tc.method, tc.path, "valid_jwt_token", nil)
+
+			assert.Equal(t, tc.expectedStatusCode, respRec.Code)
+			assert.Contains(t, respRec.Body.String(), tc.expectedMessageResponse)
+		})
+	}
+}
+
+func TestSessionEndpoint_RevokeCurrentSession(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.SessionCommonTestDB{})
+	apiEngine := newTestAPI(t, db)
+
+	sessions := listSessions(t, apiEngine, "valid_jwt_token")
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, currentSessionID, sessions[0].ID)
+	assert.True(t, sessions[0].Current)
+
+	revokeRec := send(apiEngine, http.MethodDelete, "/v1/self/sessions/"+currentSessionID, "valid_jwt_token", nil)
+	assert.Equal(t, http.StatusOK, revokeRec.Code)
+
+	listRec := send(apiEngine, http.MethodGet, "/v1/self/sessions", "valid_jwt_token", nil)
+	assert.Equal(t, http.StatusUnauthorized, listRec.Code)
+}
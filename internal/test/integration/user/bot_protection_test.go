@@ -0,0 +1,86 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/ratelimit"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/botprotection"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_BotProtection(t *testing.T) {
+	t.Parallel()
+
+	redisClient := redisPkg.InitMockRedis(t)
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisClient,
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		BotGuard: botprotection.NewGuard(fixture.NewChallengeVerifier(), ratelimit.NewRedisRepo(redisClient), botprotection.Thresholds{
+			FailedLogins:  1,
+			Registrations: 1,
+			Window:        time.Minute,
+		}),
+	})
+
+	serve := func(target, body, challengeToken string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if challengeToken != "" {
+			req.Header.Set("X-Challenge-Token", challengeToken)
+		}
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+	login := func(password, challengeToken string) *httptest.ResponseRecorder {
+		return serve("/v1/users/login", `{"username":"testuser001","password":"`+password+`"}`, challengeToken)
+	}
+	register := func(username, challengeToken string) *httptest.ResponseRecorder {
+		return serve("/v1/users/register", `{"username":"`+username+`","password":"my_SECURE_password123@","display_name":"Bot Test","email":"`+username+`@example.com"}`, challengeToken)
+	}
+
+	// A failed login makes the next logins of the username need a challenge
+	respRec := login("wrong_password", "")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+
+	respRec = login("my_SECURE_password123@", "")
+	assert.Equal(t, http.StatusForbidden, respRec.Code)
+	assert.Equal(t, `{"message":"challenge required, send its solution in the X-Challenge-Token header","challenge":{"provider":"test"}}`, respRec.Body.String())
+
+	respRec = login("my_SECURE_password123@", "wrong-solution")
+	assert.Equal(t, http.StatusForbidden, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"challenge failed, solve the new one"`)
+
+	respRec = login("my_SECURE_password123@", fixture.SolvedChallengeToken)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	// The registrations of an address need a challenge past the first one
+	respRec = register("botcheck001", "")
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+
+	respRec = register("botcheck002", "")
+	assert.Equal(t, http.StatusForbidden, respRec.Code)
+
+	respRec = register("botcheck002", fixture.SolvedChallengeToken)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+}
@@ -2,7 +2,6 @@ package user
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,13 +10,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
-	middleware "github.com/vukieuhaihoa/bookmark-libs/middlewares"
 	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
-	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
 	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/breach"
+	"github.com/vukieuhaihoa/user-service/internal/ratepolicy"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 )
 
+// breachedPasswords is a corpus of breached passwords holding "Password123!".
+const breachedPasswords = "49EFEF5F70D47ADC2DB2EB397FBEF5F7BC560E29:24601\n"
+
 func TestUserEndpoint_CreateUser(t *testing.T) {
 	t.Parallel()
 
@@ -63,8 +65,7 @@ func TestUserEndpoint_CreateUser(t *testing.T) {
 			name: "register failed - rate limit exceeded",
 
 			setupMockRedis: func(ctx context.Context, redisClient *redis.Client) *redis.Client {
-				key := fmt.Sprintf(middleware.RateLimitKeyFormat, "192.0.2.1")
-				redisClient.Set(ctx, key, middleware.IPRateLimitMaxCount, middleware.IPRateLimitInterval)
+				fixture.ExhaustRateLimit(ctx, redisClient, ratepolicy.DefaultIPPolicy, "192.0.2.1")
 				return redisClient
 			},
 
@@ -93,6 +94,20 @@ func TestUserEndpoint_CreateUser(t *testing.T) {
 			expectedStatusCode:      http.StatusBadRequest,
 			expectedMessageResponse: `"message":"username or email already exists"`,
 		},
+		{
+			name: "register failed - breached password",
+
+			setupTestHTTP: func(api api.Engine) *httptest.ResponseRecorder {
+				// Setup HTTP request and recorder
+				req := httptest.NewRequest("POST", "/v1/users/register", strings.NewReader(`{"username":"testuser","password":"Password123!","display_name":"Test User","email":"testuser@example.com"}`))
+				req.Header.Set("Content-Type", "application/json")
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+			expectedStatusCode:      http.StatusBadRequest,
+			expectedMessageResponse: `"message":"password has appeared in a data breach, choose another one"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -110,6 +125,10 @@ func TestUserEndpoint_CreateUser(t *testing.T) {
 			// init mock db and migrate
 			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
 
+			// screen passwords against the small corpus
+			breachSource, err := breach.NewFileSource(strings.NewReader(breachedPasswords))
+			assert.Nil(t, err)
+
 			// Initialize API engine
 			apiEngine := api.New(&api.EngineOpts{
 				Engine: gin.New(),
@@ -120,9 +139,10 @@ func TestUserEndpoint_CreateUser(t *testing.T) {
 				RedisClient:     redisClient,
 				SqlDB:           db,
 				RandomCodeGen:   nil,
-				PasswordHashing: utils.NewPasswordHashing(),
+				PasswordHashing: fixture.NewPasswordHashing(t),
 				JWTGenerator:    nil,
 				JWTValidator:    nil,
+				BreachChecker:   breach.NewChecker(breachSource, breach.ActionReject),
 			})
 
 			// Setup test HTTP request
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/emailpolicy"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_EmailPolicy(t *testing.T) {
+	t.Parallel()
+
+	emailPolicy, err := emailpolicy.NewPolicy(&emailpolicy.Config{DeniedDomains: []string{"blocked.example"}}, nil)
+	assert.Nil(t, err)
+
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe"}, nil)
+	mailer := fixture.NewRecordingMailer()
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTValidator:    jwtValidator,
+		Mailer:          mailer,
+		Notifier:        notifier.NewMailNotifier(mailer),
+		EmailPolicy:     emailPolicy,
+	})
+
+	register := func(email string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(`{"username":"policycheck001","password":"my_SECURE_password123@","display_name":"Policy Test","email":"`+email+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+	updateEmail := func(email string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/v1/self/info", strings.NewReader(`{"display_name":"Test User 1","email":"`+email+`"}`))
+		req.Header.Set("Authorization", "Bearer valid_jwt_token")
+		req.Header.Set("If-Match", `"1"`)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// Registrations with a disposable address are rejected
+	respRec := register("policycheck001@mailinator.com")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"disposable email addresses are not accepted"}`, respRec.Body.String())
+
+	respRec = register("policycheck001@example.com")
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+
+	// Email changes to a denied domain are rejected before anything is written
+	respRec = updateEmail("testuser001@blocked.example")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"email domain is not accepted"}`, respRec.Body.String())
+	assert.Empty(t, mailer.Messages())
+
+	respRec = updateEmail("testuser001@example.org")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+}
@@ -2,7 +2,6 @@ package user
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,10 +10,10 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
-	middleware "github.com/vukieuhaihoa/bookmark-libs/middlewares"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
 	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
 	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/ratepolicy"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 )
 
@@ -121,8 +120,7 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 			name: "rate limit exceeded",
 
 			setupMockRedis: func(ctx context.Context, redisClient *redis.Client) *redis.Client {
-				key := fmt.Sprintf(middleware.RateLimitKeyFormat, "4d9326d6-980c-4c62-9709-dbc70a82cbfe")
-				redisClient.Set(ctx, key, middleware.UserIDRateLimitMaxCount, middleware.UserIDRateLimitInterval)
+				fixture.ExhaustRateLimit(ctx, redisClient, ratepolicy.DefaultUserIDPolicy, "4d9326d6-980c-4c62-9709-dbc70a82cbfe")
 				return redisClient
 			},
 
@@ -0,0 +1,129 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/registration"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+var invitationLinkPattern = regexp.MustCompile(`https://app\.example\.com/register\?\S+`)
+
+func TestUserEndpoint_InviteOnlyRegistration(t *testing.T) {
+	t.Parallel()
+
+	mailer := fixture.NewRecordingMailer()
+
+	// The invited user logs in with the role the invitation granted
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.MatchedBy(func(claims jwt.MapClaims) bool {
+		return claims[userService.RoleClaim] == model.RoleAdmin
+	})).Return("mocked_jwt_token", nil).Once()
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:      "bookmark_service",
+			InstanceID:       "test_instance_id_1",
+			AdminAPIKey:      "test-admin-key",
+			RegistrationMode: registration.ModeInviteOnly,
+			InvitationURL:    "https://app.example.com/register",
+			InvitationTTL:    time.Hour,
+			// The cache remembers misses, which the invited registration must drop
+			UserCacheTTL:         time.Minute,
+			UserCacheNegativeTTL: time.Minute,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTValidator:    mocks.NewJWTValidator(t),
+		JWTGenerator:    jwtGen,
+		Mailer:          mailer,
+		Notifier:        notifier.NewMailNotifier(mailer),
+	})
+
+	register := func(email, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(`{"username":"invitee001","password":"my_SECURE_password123@","display_name":"Invitee","email":"`+email+`","invitation_token":"`+token+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	lookup := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/by-username/invitee001", nil)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// The username is unknown yet
+	respRec := lookup()
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+
+	// Registrations without an invitation are refused
+	respRec = register("invitee001@example.com", "")
+	assert.Equal(t, http.StatusForbidden, respRec.Code)
+	assert.Equal(t, `{"message":"registration requires an invitation"}`, respRec.Body.String())
+
+	// An admin invites the address, which receives the link
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/invitations", strings.NewReader(`{"email":"invitee001@example.com","role":"admin"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Key", "test-admin-key")
+	respRec = httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+	assert.NotContains(t, respRec.Body.String(), "token")
+
+	messages := mailer.Messages()
+	if !assert.Len(t, messages, 1) {
+		return
+	}
+	assert.Equal(t, "invitee001@example.com", messages[0].To)
+	link, err := url.Parse(invitationLinkPattern.FindString(messages[0].Body))
+	assert.Nil(t, err)
+	token := link.Query().Get("token")
+	assert.NotEmpty(t, token)
+
+	// The invitation only registers the invited address
+	respRec = register("someone.else@example.com", token)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"invitation was sent to another email address"}`, respRec.Body.String())
+
+	respRec = register("invitee001@example.com", token)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"role":"admin"`)
+
+	respRec = lookup()
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"username":"invitee001"`)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(`{"username":"invitee001","password":"my_SECURE_password123@"}`))
+	req.Header.Set("Content-Type", "application/json")
+	respRec = httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	// The invitation registers one user only
+	respRec = register("invitee001@example.com", token)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"invalid or expired invitation"}`, respRec.Body.String())
+}
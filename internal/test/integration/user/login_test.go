@@ -2,7 +2,6 @@ package user
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,11 +11,11 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	middleware "github.com/vukieuhaihoa/bookmark-libs/middlewares"
 	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
 	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
-	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
 	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/ratepolicy"
 	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
 )
 
@@ -95,8 +94,7 @@ func TestUserEndpoint_Login(t *testing.T) {
 			name: "user login failed - rate limit exceeded",
 
 			setupMockRedis: func(ctx context.Context, redisClient *redis.Client) *redis.Client {
-				key := fmt.Sprintf(middleware.RateLimitKeyFormat, "192.0.2.1")
-				redisClient.Set(ctx, key, middleware.IPRateLimitMaxCount, middleware.IPRateLimitInterval)
+				fixture.ExhaustRateLimit(ctx, redisClient, ratepolicy.DefaultIPPolicy, "192.0.2.1")
 				return redisClient
 			},
 
@@ -140,7 +138,7 @@ func TestUserEndpoint_Login(t *testing.T) {
 				RedisClient:     redisClient,
 				SqlDB:           db,
 				RandomCodeGen:   nil,
-				PasswordHashing: utils.NewPasswordHashing(),
+				PasswordHashing: fixture.NewPasswordHashing(t),
 				JWTGenerator:    jwtGen,
 				JWTValidator:    nil,
 			})
@@ -154,3 +152,98 @@ func TestUserEndpoint_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestUserEndpoint_LoginUpgradesPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+	})
+
+	login := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/users/login", strings.NewReader(`{"username":"testuser001","password":"my_SECURE_password123@"}`))
+		req.Header.Set("Content-Type", "application/json")
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+	storedUser := func() *model.User {
+		user := &model.User{}
+		assert.Nil(t, db.Where("username = ?", "testuser001").First(user).Error)
+		return user
+	}
+
+	// The bcrypt hash of the fixture is replaced by an Argon2id hash of the same password
+	assert.Equal(t, http.StatusOK, login().Code)
+	user := storedUser()
+	assert.True(t, strings.HasPrefix(user.Password, "$argon2id$"))
+	assert.Equal(t, 1, user.Version)
+	assert.Equal(t, fixture.TestTime, *user.PasswordChangedAt)
+
+	// The upgraded hash verifies the password and is kept as is
+	assert.Equal(t, http.StatusOK, login().Code)
+	assert.Equal(t, user.Password, storedUser().Password)
+}
+
+func TestUserEndpoint_LoginRateLimitPolicies(t *testing.T) {
+	t.Parallel()
+
+	policies, err := ratepolicy.Parse("POST /v1/users/login ip 10/1h; POST /v1/users/login username 2/1h")
+	assert.Nil(t, err)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:       "bookmark_service",
+			InstanceID:        "test_instance_id_1",
+			RateLimitPolicies: policies,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+	})
+
+	login := func(remoteAddr, username string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/users/login", strings.NewReader(`{"username":"`+username+`","password":"wrong_password"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// The headers describe the username policy, which has fewer requests left
+	respRec := login("192.0.2.1:1234", "testuser001")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, "2", respRec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", respRec.Header().Get("RateLimit-Remaining"))
+	assert.NotEmpty(t, respRec.Header().Get("RateLimit-Reset"))
+
+	// Attempts against the username are counted from any address, whatever its spelling
+	respRec = login("198.51.100.7:1234", "TestUser001")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, "0", respRec.Header().Get("RateLimit-Remaining"))
+
+	respRec = login("203.0.113.9:1234", "testuser001")
+	assert.Equal(t, http.StatusTooManyRequests, respRec.Code)
+	assert.Equal(t, `{"error":"Too many requests. Please try again later."}`, respRec.Body.String())
+	assert.NotEmpty(t, respRec.Header().Get("Retry-After"))
+	assert.Equal(t, respRec.Header().Get("RateLimit-Reset"), respRec.Header().Get("Retry-After"))
+
+	// Other usernames are still accepted from the same address
+	respRec = login("203.0.113.9:1234", "testuser002")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, "1", respRec.Header().Get("RateLimit-Remaining"))
+}
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+var organizationInvitationLinkPattern = regexp.MustCompile(`https://app\.example\.com/organizations/join\?\S+`)
+
+func TestUserEndpoint_OrganizationInvitation(t *testing.T) {
+	t.Parallel()
+
+	mailer := fixture.NewRecordingMailer()
+
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "alice_token").Return(jwt.MapClaims{"sub": "de305d54-75b4-431b-adb2-eb6b9e546000"}, nil)
+	jwtValidator.On("ValidateToken", "charlie_token").Return(jwt.MapClaims{"sub": "987e6543-e21b-12d3-a456-eb6b9e546002"}, nil)
+	jwtValidator.On("ValidateToken", "testuser001_token").Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe"}, nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:               "bookmark_service",
+			InstanceID:                "test_instance_id_1",
+			OrganizationInvitationURL: "https://app.example.com/organizations/join",
+			OrganizationInvitationTTL: time.Hour,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.OrganizationCommonTestDB{}),
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTValidator:    jwtValidator,
+		Mailer:          mailer,
+		Notifier:        notifier.NewMailNotifier(mailer),
+	})
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	acmePath := "/v1/organizations/" + fixture.AcmeOrganizationID
+
+	// Non-members cannot tell the organization exists
+	respRec := send(http.MethodGet, acmePath, "testuser001_token", "")
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+	assert.Equal(t, `{"message":"organization not found"}`, respRec.Body.String())
+
+	// Members cannot invite
+	respRec = send(http.MethodPost, acmePath+"/invitations", "charlie_token", `{"email":"testuser001@example.com"}`)
+	assert.Equal(t, http.StatusForbidden, respRec.Code)
+
+	// The owner invites the address, which receives the link
+	respRec = send(http.MethodPost, acmePath+"/invitations", "alice_token", `{"email":"testuser001@example.com","role":"admin"}`)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+	assert.NotContains(t, respRec.Body.String(), "token")
+
+	messages := mailer.Messages()
+	if !assert.Len(t, messages, 1) {
+		return
+	}
+	assert.Equal(t, "testuser001@example.com", messages[0].To)
+	link, err := url.Parse(organizationInvitationLinkPattern.FindString(messages[0].Body))
+	assert.Nil(t, err)
+	token := link.Query().Get("token")
+	assert.NotEmpty(t, token)
+
+	// The invitation joins the invited user only
+	respRec = send(http.MethodPost, "/v1/organizations/invitations/accept", "charlie_token", `{"token":"`+token+`"}`)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"invitation was sent to another email address"}`, respRec.Body.String())
+
+	respRec = send(http.MethodPost, "/v1/organizations/invitations/accept", "testuser001_token", `{"token":"`+token+`"}`)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"role":"admin"`)
+
+	respRec = send(http.MethodPost, "/v1/organizations/invitations/accept", "testuser001_token", `{"token":"`+token+`"}`)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"invalid or expired invitation"}`, respRec.Body.String())
+
+	// The new admin sees the organization and its members
+	respRec = send(http.MethodGet, acmePath+"/members", "testuser001_token", "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"user_id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","role":"admin"`)
+}
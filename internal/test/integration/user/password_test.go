@@ -0,0 +1,103 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	userService "github.com/vukieuhaihoa/user-service/internal/app/service/user"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.MatchedBy(userService.IsPasswordChangeToken)).Return("restricted_token", nil)
+	jwtGen.On("GenerateToken", mock.Anything).Return("testuser001_token", nil)
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "restricted_token").
+		Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe", userService.PasswordChangeClaim: true}, nil)
+	jwtValidator.On("ValidateToken", "testuser001_token").Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe"}, nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:         "bookmark_service",
+			InstanceID:          "test_instance_id_1",
+			PasswordHistorySize: 2,
+			PasswordMaxAge:      24 * time.Hour,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    jwtValidator,
+	})
+
+	serve := func(method, target, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+	login := func(password string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/v1/users/login", "", `{"username":"testuser001","password":"`+password+`"}`)
+	}
+	changePassword := func(token, currentPassword, newPassword string) *httptest.ResponseRecorder {
+		return serve(http.MethodPut, "/v1/self/password", token, `{"current_password":"`+currentPassword+`","new_password":"`+newPassword+`"}`)
+	}
+
+	// The password of the fixture user is older than the max age
+	respRec := login("my_SECURE_password123@")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, `{"data":"restricted_token","password_change_required":true,"message":"Password expired, change it with this token to continue"}`, respRec.Body.String())
+
+	// The restricted token only changes the password
+	respRec = serve(http.MethodGet, "/v1/self/info", "restricted_token", "")
+	assert.Equal(t, http.StatusForbidden, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"password expired, change it through PUT /v1/self/password"`)
+
+	respRec = changePassword("restricted_token", "wrong_password", "my_NEW_password456@")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"current password is incorrect"`)
+
+	respRec = changePassword("restricted_token", "my_SECURE_password123@", "my_SECURE_password123@")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"password was used recently, choose another one"`)
+
+	respRec = changePassword("restricted_token", "my_SECURE_password123@", "my_NEW_password456@")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"Password changed successfully!"`)
+
+	// The new password is fresh, so the login issues a regular token
+	respRec = login("my_NEW_password456@")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, `{"data":"testuser001_token","message":"Logged in successfully!"}`, respRec.Body.String())
+
+	// The previous password is kept in the history
+	respRec = changePassword("testuser001_token", "my_NEW_password456@", "my_SECURE_password123@")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"password was used recently, choose another one"`)
+
+	respRec = changePassword("testuser001_token", "my_NEW_password456@", "my_THIRD_password789@")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	// Only the last two passwords are remembered
+	respRec = changePassword("testuser001_token", "my_THIRD_password789@", "my_SECURE_password123@")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+}
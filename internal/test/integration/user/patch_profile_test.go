@@ -0,0 +1,128 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/notifier"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_PatchProfile(t *testing.T) {
+	t.Parallel()
+
+	const userID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+	testCases := []struct {
+		name string
+
+		inputBody    string
+		inputIfMatch string
+
+		expectedCode        int
+		expectedResponse    string
+		expectedDisplayName string
+		expectedEmail       string
+		expectedVersion     int
+	}{
+		{
+			name: "patch only the display name",
+
+			inputBody:    `{"display_name":"Test User 1 Patched"}`,
+			inputIfMatch: `"1"`,
+
+			expectedCode:        http.StatusOK,
+			expectedResponse:    `"message":"Edit current user successfully!"`,
+			expectedDisplayName: "Test User 1 Patched",
+			expectedEmail:       "testuser001@example.com",
+			expectedVersion:     2,
+		},
+		{
+			name: "patch only the email",
+
+			inputBody:    `{"email":"testuser001patched@example.com"}`,
+			inputIfMatch: `"1"`,
+
+			expectedCode:        http.StatusOK,
+			expectedResponse:    `"message":"Edit current user successfully! Confirm the new email address from the link sent to it"`,
+			expectedDisplayName: "Test User 1",
+			expectedEmail:       "testuser001@example.com",
+			expectedVersion:     1,
+		},
+		{
+			name: "patch a field that cannot be updated",
+
+			inputBody:    `{"username":"someoneelse"}`,
+			inputIfMatch: `"1"`,
+
+			expectedCode:        http.StatusBadRequest,
+			expectedResponse:    `"details":["username cannot be updated"]`,
+			expectedDisplayName: "Test User 1",
+			expectedEmail:       "testuser001@example.com",
+			expectedVersion:     1,
+		},
+		{
+			name: "patch an outdated version",
+
+			inputBody:    `{"display_name":"Test User 1 Patched"}`,
+			inputIfMatch: `"5"`,
+
+			expectedCode:        http.StatusPreconditionFailed,
+			expectedResponse:    `"message":"profile was modified since it was read, fetch it again and retry"`,
+			expectedDisplayName: "Test User 1",
+			expectedEmail:       "testuser001@example.com",
+			expectedVersion:     1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			mailer := fixture.NewRecordingMailer()
+			jwtValidator := mocks.NewJWTValidator(t)
+			jwtValidator.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": userID}, nil)
+
+			apiEngine := api.New(&api.EngineOpts{
+				Engine: gin.New(),
+				Cfg: &api.Config{
+					ServiceName: "bookmark_service",
+					InstanceID:  "test_instance_id_1",
+				},
+				RedisClient:   redisPkg.InitMockRedis(t),
+				SqlDB:         db,
+				RandomCodeGen: utils.NewCodeGenerator(),
+				JWTValidator:  jwtValidator,
+				Mailer:        mailer,
+				Notifier:      notifier.NewMailNotifier(mailer),
+			})
+
+			req := httptest.NewRequest(http.MethodPatch, "/v1/self/info", strings.NewReader(tc.inputBody))
+			req.Header.Set("Authorization", "Bearer valid_jwt_token")
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			req.Header.Set("If-Match", tc.inputIfMatch)
+			respRec := httptest.NewRecorder()
+			apiEngine.ServeHTTP(respRec, req)
+
+			assert.Equal(t, tc.expectedCode, respRec.Code)
+			assert.Contains(t, respRec.Body.String(), tc.expectedResponse)
+
+			user := &model.User{}
+			assert.Nil(t, db.Where("id = ?", userID).First(user).Error)
+			assert.Equal(t, tc.expectedDisplayName, user.DisplayName)
+			assert.Equal(t, tc.expectedEmail, user.Email)
+			assert.Equal(t, tc.expectedVersion, user.Version)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	userRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/user"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_ProfileCache(t *testing.T) {
+	t.Parallel()
+
+	const userID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+	ctx := t.Context()
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	redisClient := redisPkg.InitMockRedis(t)
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "valid_jwt_token").Return(jwt.MapClaims{"sub": userID}, nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:          "bookmark_service",
+			InstanceID:           "test_instance_id_1",
+			UserCacheTTL:         time.Minute,
+			UserCacheNegativeTTL: time.Second,
+		},
+		RedisClient:  redisClient,
+		SqlDB:        db,
+		JWTValidator: jwtValidator,
+	})
+
+	request := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/v1/self/info", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer valid_jwt_token")
+		req.Header.Set("If-Match", `"1"`)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// The first read caches the profile
+	respRec := request(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"display_name":"Test User 1"`)
+	cacheKey := fmt.Sprintf(userRepository.UserByIDCacheKeyFormat, tenant.Default, userID)
+	assert.Equal(t, int64(1), redisClient.Exists(ctx, cacheKey).Val())
+
+	// The update drops the cached profile, so the next read sees it
+	respRec = request(http.MethodPut, `{"display_name":"Test User 1 Updated","email":"testuser001@example.com"}`)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, int64(0), redisClient.Exists(ctx, cacheKey).Val())
+
+	respRec = request(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"display_name":"Test User 1 Updated"`)
+	assert.Contains(t, respRec.Body.String(), `"email":"testuser001@example.com"`)
+	assert.Equal(t, `"2"`, respRec.Header().Get("ETag"))
+}
+
+func TestUserEndpoint_LoginWithUserCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	redisClient := redisPkg.InitMockRedis(t)
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:          "bookmark_service",
+			InstanceID:           "test_instance_id_1",
+			UserCacheTTL:         time.Minute,
+			UserCacheNegativeTTL: time.Second,
+		},
+		RedisClient:     redisClient,
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    mocks.NewJWTValidator(t),
+	})
+
+	// A lookup caches the user, without the password hash
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/by-username/testuser001", nil)
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	cached, err := redisClient.Get(ctx, fmt.Sprintf(userRepository.UserByUsernameCacheKeyFormat, tenant.Default, "testuser001")).Result()
+	assert.Nil(t, err)
+	assert.Contains(t, cached, `"username":"testuser001"`)
+	assert.NotContains(t, cached, "$2a$")
+
+	// The login still checks the password against the database
+	req = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(`{"username":"testuser001","password":"my_SECURE_password123@"}`))
+	req.Header.Set("Content-Type", "application/json")
+	respRec = httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"Logged in successfully!"`)
+}
@@ -0,0 +1,102 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_TenantIsolation(t *testing.T) {
+	t.Parallel()
+
+	jwtGen := mocks.NewJWTGenerator(t)
+	jwtGen.On("GenerateToken", mock.Anything).Return("mocked_jwt_token", nil)
+
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "brand_a_jwt_token").Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe", "tenant_id": "brand_a"}, nil)
+	jwtValidator.On("ValidateToken", "default_jwt_token").Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe"}, nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:       "bookmark_service",
+			InstanceID:        "test_instance_id_1",
+			TenantHosts:       map[string]string{"brand-a.example.com": "brand_a"},
+			TenantTrustHeader: true,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    jwtGen,
+		JWTValidator:    jwtValidator,
+	})
+
+	send := func(host, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Host = host
+		req.Header.Set("Content-Type", "application/json")
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// The username and email address of a user of the default tenant are free in another tenant
+	register := `{"username":"testuser001","password":"brand_A_password456#","display_name":"Brand A User","email":"testuser001@example.com"}`
+	respRec := send("brand-a.example.com", "/v1/users/register", register)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+
+	respRec = send("brand-a.example.com", "/v1/users/register", register)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"username or email already exists"}`, respRec.Body.String())
+
+	// Each tenant logs its own user in
+	respRec = send("brand-a.example.com", "/v1/users/login", `{"username":"testuser001","password":"brand_A_password456#"}`)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	respRec = send("localhost", "/v1/users/login", `{"username":"testuser001","password":"brand_A_password456#"}`)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"invalid username or password"`)
+
+	respRec = send("localhost", "/v1/users/login", `{"username":"testuser001","password":"my_SECURE_password123@"}`)
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	// Unknown tenants are rejected
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(`{"username":"testuser001","password":"my_SECURE_password123@"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", "brand_b")
+	respRec = httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Equal(t, `{"message":"unknown tenant"}`, respRec.Body.String())
+
+	// A token is only accepted in the tenant it was issued in
+	getProfile := func(host, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/self/info", nil)
+		req.Host = host
+		req.Header.Set("Authorization", "Bearer "+token)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	respRec = getProfile("localhost", "default_jwt_token")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	respRec = getProfile("localhost", "brand_a_jwt_token")
+	assert.Equal(t, http.StatusUnauthorized, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"Invalid token"`)
+
+	respRec = getProfile("brand-a.example.com", "default_jwt_token")
+	assert.Equal(t, http.StatusUnauthorized, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"Invalid token"`)
+}
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+func TestUserEndpoint_ChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.UsernameHistoryCommonTestDB{})
+	jwtValidator := mocks.NewJWTValidator(t)
+	jwtValidator.On("ValidateToken", "testuser001_token").Return(jwt.MapClaims{"sub": "4d9326d6-980c-4c62-9709-dbc70a82cbfe"}, nil)
+	jwtValidator.On("ValidateToken", "alice_token").Return(jwt.MapClaims{"sub": "de305d54-75b4-431b-adb2-eb6b9e546000"}, nil)
+
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName:          "bookmark_service",
+			InstanceID:           "test_instance_id_1",
+			UserCacheTTL:         time.Minute,
+			UserCacheNegativeTTL: time.Minute,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTValidator:    jwtValidator,
+	})
+
+	changeUsername := func(token, ifMatch, username string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/v1/self/username", strings.NewReader(`{"username":"`+username+`"}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("If-Match", ifMatch)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+	lookup := func(username string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users/by-username/"+username, nil)
+		respRec := httptest.NewRecorder()
+		apiEngine.ServeHTTP(respRec, req)
+		return respRec
+	}
+
+	// The lookup of the current username caches it, including a miss on the new one
+	respRec := lookup("testuser001")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, http.StatusNotFound, lookup("testuser001renamed").Code)
+
+	respRec = changeUsername("testuser001_token", `"1"`, "testuser001renamed")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"Username changed successfully!"`)
+	assert.Equal(t, `"2"`, respRec.Header().Get("ETag"))
+
+	// The old username redirects to the new one
+	respRec = lookup("testuser001")
+	assert.Equal(t, http.StatusTemporaryRedirect, respRec.Code)
+	assert.Equal(t, "/v1/users/by-username/testuser001renamed", respRec.Header().Get("Location"))
+
+	respRec = lookup("testuser001renamed")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Equal(t, `{"data":{"id":"4d9326d6-980c-4c62-9709-dbc70a82cbfe","username":"testuser001renamed","display_name":"Test User 1"},"message":"User retrieved successfully!"}`, respRec.Body.String())
+
+	// A second change must wait for the cooldown
+	respRec = changeUsername("testuser001_token", `"2"`, "testuser001again")
+	assert.Equal(t, http.StatusTooManyRequests, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"the username can only be changed once every 30 days"`)
+
+	// The old username is held, for another user changing to it as for a registration
+	respRec = changeUsername("alice_token", `"1"`, "testuser001")
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"username already exists"`)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(`{"username":"testuser001","password":"my_SECURE_password123@","display_name":"Impostor","email":"impostor@example.com"}`))
+	respRec = httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"message":"username or email already exists"`)
+
+	// A username whose hold is over can be claimed, and then resolves to its new holder
+	respRec = changeUsername("alice_token", `"1"`, "Chuck")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	respRec = lookup("Chuck")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), `"id":"de305d54-75b4-431b-adb2-eb6b9e546000"`)
+
+	respRec = lookup("Alice")
+	assert.Equal(t, http.StatusTemporaryRedirect, respRec.Code)
+	assert.Equal(t, "/v1/users/by-username/Chuck", respRec.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusNotFound, lookup("Nobody").Code)
+}
@@ -0,0 +1,101 @@
+package usernamepolicy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+)
+
+const testAdminKey = "test-admin-key"
+
+// adminRequest sends a request to the admin API.
+func adminRequest(apiEngine api.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Key", testAdminKey)
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+// register sends a registration request for the given username.
+func register(apiEngine api.Engine, username string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(`{"username":"`+username+`","password":"my_SECURE_password123@","display_name":"Brand User","email":"`+username+`@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+func TestUsernamePolicy_ManageEntriesAtRuntime(t *testing.T) {
+	t.Parallel()
+
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	assert.NoError(t, db.AutoMigrate(&model.UsernamePolicyEntry{}))
+	apiEngine := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+			AdminAPIKey: testAdminKey,
+		},
+		RedisClient:     redisPkg.InitMockRedis(t),
+		SqlDB:           db,
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    mocks.NewJWTGenerator(t),
+		JWTValidator:    mocks.NewJWTValidator(t),
+	})
+
+	// An admin reserves the name of a brand
+	respRec := adminRequest(apiEngine, http.MethodPost, "/v1/admin/username-policy/entries", `{"kind":"reserved","value":"Brandname"}`)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+	created := struct {
+		Data struct {
+			ID    string `json:"id"`
+			Value string `json:"value"`
+		} `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(respRec.Body.Bytes(), &created))
+	assert.Equal(t, "brandname", created.Data.Value)
+
+	// Reserving it again conflicts
+	respRec = adminRequest(apiEngine, http.MethodPost, "/v1/admin/username-policy/entries", `{"kind":"reserved","value":"BRANDNAME"}`)
+	assert.Equal(t, http.StatusConflict, respRec.Code)
+
+	// An invalid pattern is rejected
+	respRec = adminRequest(apiEngine, http.MethodPost, "/v1/admin/username-policy/entries", `{"kind":"blocked_pattern","value":"^(brand"}`)
+	assert.Equal(t, http.StatusBadRequest, respRec.Code)
+
+	// The name, its other spellings and its look-alikes can no longer be registered
+	for _, username := range []string{"brandname", "Brand_Name", "bran.dname", "brandnarne"} {
+		respRec = register(apiEngine, username)
+		assert.Equal(t, http.StatusBadRequest, respRec.Code, username)
+		assert.Contains(t, respRec.Body.String(), "Username is invalid (username_policy)", username)
+	}
+
+	// The entry is listed
+	respRec = adminRequest(apiEngine, http.MethodGet, "/v1/admin/username-policy/entries", "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), created.Data.ID)
+
+	// Once the entry is deleted, the name can be registered
+	respRec = adminRequest(apiEngine, http.MethodDelete, "/v1/admin/username-policy/entries/"+created.Data.ID, "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	respRec = adminRequest(apiEngine, http.MethodDelete, "/v1/admin/username-policy/entries/"+created.Data.ID, "")
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+
+	respRec = register(apiEngine, "brandname")
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+}
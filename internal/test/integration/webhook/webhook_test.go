@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/jwtutils/mocks"
+	redisPkg "github.com/vukieuhaihoa/bookmark-libs/pkg/redis"
+	"github.com/vukieuhaihoa/bookmark-libs/pkg/utils"
+	"github.com/vukieuhaihoa/user-service/internal/api"
+	"github.com/vukieuhaihoa/user-service/internal/app/model"
+	outboxRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/outbox"
+	webhookRepository "github.com/vukieuhaihoa/user-service/internal/app/repository/webhook"
+	outboxService "github.com/vukieuhaihoa/user-service/internal/app/service/outbox"
+	webhookService "github.com/vukieuhaihoa/user-service/internal/app/service/webhook"
+	"github.com/vukieuhaihoa/user-service/internal/tenant"
+	"github.com/vukieuhaihoa/user-service/internal/test/fixture"
+	"gorm.io/gorm"
+)
+
+const (
+	testAdminKey = "test-admin-key"
+
+	// brandAHost is the hostname of the brand_a tenant; requests to other hostnames are of the default tenant.
+	brandAHost = "brand-a.example.com"
+)
+
+// receiver is a partner endpoint recording the deliveries it receives.
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(http.StatusOK)
+}
+
+// newTestAPI builds the API with the admin API enabled under testAdminKey.
+func newTestAPI(t *testing.T, db *gorm.DB, redisClient *redis.Client) api.Engine {
+	return api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			ServiceName: "bookmark_service",
+			InstanceID:  "test_instance_id_1",
+			AdminAPIKey: testAdminKey,
+			TenantHosts: map[string]string{brandAHost: "brand_a"},
+		},
+		RedisClient:     redisClient,
+		SqlDB:           db,
+		RandomCodeGen:   utils.NewCodeGenerator(),
+		PasswordHashing: fixture.NewPasswordHashing(t),
+		JWTGenerator:    mocks.NewJWTGenerator(t),
+		JWTValidator:    mocks.NewJWTValidator(t),
+	})
+}
+
+// adminRequest sends a request to the admin API of the tenant of a hostname.
+func adminRequest(apiEngine api.Engine, host, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = host
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Key", testAdminKey)
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	return respRec
+}
+
+type deliveryResponse struct {
+	ID       string `json:"id"`
+	EventID  string `json:"event_id"`
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts"`
+}
+
+// listDeliveries returns the delivery log of a subscription of the tenant of a hostname.
+func listDeliveries(t *testing.T, apiEngine api.Engine, host, subscriptionID string) []deliveryResponse {
+	respRec := adminRequest(apiEngine, host, http.MethodGet, "/v1/admin/webhooks/"+subscriptionID+"/deliveries", "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+
+	resp := struct {
+		Data []deliveryResponse `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(respRec.Body.Bytes(), &resp))
+	return resp.Data
+}
+
+// subscribe subscribes an endpoint to new users in the tenant of a hostname, returning the ID and the secret of
+// the subscription.
+func subscribe(t *testing.T, apiEngine api.Engine, host, url string) (string, string) {
+	respRec := adminRequest(apiEngine, host, http.MethodPost, "/v1/admin/webhooks",
+		`{"url":"`+url+`","event_types":["user.created"]}`)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+
+	created := struct {
+		Data struct {
+			ID     string `json:"id"`
+			Secret string `json:"secret"`
+		} `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(respRec.Body.Bytes(), &created))
+	return created.Data.ID, created.Data.Secret
+}
+
+// register registers a user in the tenant of a hostname.
+func register(t *testing.T, apiEngine api.Engine, host, username string) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/register", strings.NewReader(`{"username":"`+username+`","password":"my_SECURE_password123@","display_name":"Partner User","email":"`+username+`@example.com"}`))
+	req.Host = host
+	req.Header.Set("Content-Type", "application/json")
+	respRec := httptest.NewRecorder()
+	apiEngine.ServeHTTP(respRec, req)
+	assert.Equal(t, http.StatusCreated, respRec.Code)
+}
+
+func TestWebhook_DeliverUserLifecycleEvents(t *testing.T) {
+	t.Parallel()
+
+	// The background jobs run across every tenant
+	ctx := tenant.WithAllTenants(t.Context())
+	db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+	assert.NoError(t, db.AutoMigrate(&model.WebhookSubscription{}, &model.WebhookDelivery{}))
+	redisClient := redisPkg.InitMockRedis(t)
+	apiEngine := newTestAPI(t, db, redisClient)
+
+	partner := &receiver{}
+	server := httptest.NewServer(partner)
+	t.Cleanup(server.Close)
+
+	relay := outboxService.NewOutboxService(outboxRepository.NewOutboxRepository(db, redisClient), &outboxService.Config{
+		Stream:      "user-events",
+		BatchSize:   100,
+		MaxAttempts: 10,
+	})
+	worker := webhookService.NewWebhookWorker(webhookRepository.NewWebhookRepository(db, redisClient), server.Client(), &webhookService.Config{
+		Stream:          "user-events",
+		Group:           "webhooks",
+		Consumer:        "webhook-worker",
+		BatchSize:       100,
+		MaxAttempts:     8,
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: time.Minute,
+	})
+
+	// The admins of two tenants subscribe their partner endpoints to new users
+	subscriptionID, secret := subscribe(t, apiEngine, brandAHost, server.URL+"/hooks")
+	assert.True(t, strings.HasPrefix(secret, webhookService.SecretPrefix))
+
+	otherPartner := &receiver{}
+	otherServer := httptest.NewServer(otherPartner)
+	t.Cleanup(otherServer.Close)
+	otherSubscriptionID, _ := subscribe(t, apiEngine, "example.com", otherServer.URL+"/hooks")
+
+	// The secret is never shown again, and the subscriptions of other tenants are not shown at all
+	respRec := adminRequest(apiEngine, brandAHost, http.MethodGet, "/v1/admin/webhooks", "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	assert.Contains(t, respRec.Body.String(), subscriptionID)
+	assert.NotContains(t, respRec.Body.String(), secret)
+	assert.NotContains(t, respRec.Body.String(), otherSubscriptionID)
+
+	respRec = adminRequest(apiEngine, brandAHost, http.MethodDelete, "/v1/admin/webhooks/"+otherSubscriptionID, "")
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+
+	// A user of a brand registers, the event goes through the outbox, the stream and the worker, which creates its
+	// consumer group on its first run and still reads the events published before
+	register(t, apiEngine, brandAHost, "partneruser")
+
+	relayed, err := relay.RelayPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+
+	dispatched, err := worker.DispatchEvents(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+
+	delivered, err := worker.DeliverPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	// Only the partner of the brand receives a signed user.created event
+	assert.Len(t, partner.requests, 1)
+	assert.Empty(t, otherPartner.requests)
+	delivery := partner.requests[0]
+	body := partner.bodies[0]
+	timestamp, err := strconv.ParseInt(delivery.Header.Get(webhookService.HeaderTimestamp), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, webhookService.Sign(secret, timestamp, body), delivery.Header.Get(webhookService.HeaderSignature))
+	assert.Equal(t, "user.created", delivery.Header.Get(webhookService.HeaderEventType))
+
+	event := struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			TenantID string `json:"tenant_id"`
+		} `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(body, &event))
+	assert.Equal(t, delivery.Header.Get(webhookService.HeaderEventID), event.ID)
+	assert.Equal(t, "user.created", event.Type)
+	assert.Equal(t, "partneruser", event.Data.Username)
+	assert.Empty(t, event.Data.Password)
+	assert.Equal(t, "brand_a", event.Data.TenantID)
+
+	// The delivery log records the success
+	deliveries := listDeliveries(t, apiEngine, brandAHost, subscriptionID)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, event.ID, deliveries[0].EventID)
+	assert.Equal(t, model.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+
+	// Replaying the delivery sends the same event again
+	respRec = adminRequest(apiEngine, brandAHost, http.MethodPost, "/v1/admin/webhooks/"+subscriptionID+"/deliveries/"+deliveries[0].ID+"/replay", "")
+	assert.Equal(t, http.StatusAccepted, respRec.Code)
+
+	delivered, err = worker.DeliverPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, partner.requests, 2)
+	assert.Equal(t, event.ID, partner.requests[1].Header.Get(webhookService.HeaderEventID))
+
+	// A user of the default tenant is only delivered to its partner
+	register(t, apiEngine, "example.com", "otheruser")
+
+	relayed, err = relay.RelayPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+	dispatched, err = worker.DispatchEvents(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+	delivered, err = worker.DeliverPending(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, partner.requests, 2)
+	assert.Len(t, otherPartner.requests, 1)
+	assert.Contains(t, string(otherPartner.bodies[0]), `"username":"otheruser"`)
+
+	// Deleting the subscription stops the deliveries
+	respRec = adminRequest(apiEngine, brandAHost, http.MethodDelete, "/v1/admin/webhooks/"+subscriptionID, "")
+	assert.Equal(t, http.StatusOK, respRec.Code)
+	respRec = adminRequest(apiEngine, brandAHost, http.MethodGet, "/v1/admin/webhooks/"+subscriptionID+"/deliveries", "")
+	assert.Equal(t, http.StatusNotFound, respRec.Code)
+}
+
+func TestWebhook_AdminAPIRequiresKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+
+		configuredKey string
+		presentedKey  string
+
+		expectedCode int
+	}{
+		{
+			name:          "Valid key",
+			configuredKey: testAdminKey,
+			presentedKey:  testAdminKey,
+
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Missing key",
+			configuredKey: testAdminKey,
+
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "Wrong key",
+			configuredKey: testAdminKey,
+			presentedKey:  "guessed-key",
+
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Admin API disabled",
+			presentedKey: "",
+
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := fixture.NewFixture(t, &fixture.WebhookCommonTestDB{})
+			apiEngine := api.New(&api.EngineOpts{
+				Engine: gin.New(),
+				Cfg: &api.Config{
+					ServiceName: "bookmark_service",
+					InstanceID:  "test_instance_id_1",
+					AdminAPIKey: tc.configuredKey,
+				},
+				RedisClient:   redisPkg.InitMockRedis(t),
+				SqlDB:         db,
+				RandomCodeGen: utils.NewCodeGenerator(),
+				JWTGenerator:  mocks.NewJWTGenerator(t),
+				JWTValidator:  mocks.NewJWTValidator(t),
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/admin/webhooks", nil)
+			if tc.presentedKey != "" {
+				req.Header.Set("X-Admin-Key", tc.presentedKey)
+			}
+			respRec := httptest.NewRecorder()
+			apiEngine.ServeHTTP(respRec, req)
+
+			assert.Equal(t, tc.expectedCode, respRec.Code)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+CREATE TABLE user_identities (
+  id            varchar(36),
+  user_id       varchar(36)     NOT NULL,
+  provider      varchar(64)     NOT NULL,
+  subject       varchar(255)    NOT NULL,
+  email         varchar(2048),
+  created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT user_identities_pk PRIMARY KEY (id),
+  CONSTRAINT user_identities_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT user_identities_provider_subject_unique UNIQUE (provider, subject)
+);
+
+CREATE INDEX user_identities_user_id_idx ON user_identities (user_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS user_passkeys;
\ No newline at end of file
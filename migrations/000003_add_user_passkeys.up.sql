@@ -0,0 +1,21 @@
+CREATE TABLE user_passkeys (
+  id               varchar(36),
+  user_id          varchar(36)     NOT NULL,
+  credential_id    bytea           NOT NULL,
+  public_key       bytea           NOT NULL,
+  attestation_type varchar(64)     NOT NULL DEFAULT '',
+  transports       text            NOT NULL DEFAULT '[]',
+  aaguid           bytea,
+  sign_count       bigint          NOT NULL DEFAULT 0,
+  backup_eligible  boolean         NOT NULL DEFAULT false,
+  backup_state     boolean         NOT NULL DEFAULT false,
+  last_used_at     TIMESTAMP WITH TIME ZONE,
+  created_at       TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at       TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT user_passkeys_pk PRIMARY KEY (id),
+  CONSTRAINT user_passkeys_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT user_passkeys_credential_id_unique UNIQUE (credential_id)
+);
+
+CREATE INDEX user_passkeys_user_id_idx ON user_passkeys (user_id);
@@ -0,0 +1,18 @@
+CREATE TABLE personal_access_tokens (
+  id            varchar(36),
+  user_id       varchar(36)     NOT NULL,
+  name          varchar(100)    NOT NULL,
+  token_prefix  varchar(16)     NOT NULL,
+  token_hash    varchar(64)     NOT NULL,
+  scopes        text            NOT NULL DEFAULT '[]',
+  expires_at    TIMESTAMP WITH TIME ZONE,
+  last_used_at  TIMESTAMP WITH TIME ZONE,
+  created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT personal_access_tokens_pk PRIMARY KEY (id),
+  CONSTRAINT personal_access_tokens_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT personal_access_tokens_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX personal_access_tokens_user_id_idx ON personal_access_tokens (user_id);
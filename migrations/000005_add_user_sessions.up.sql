@@ -0,0 +1,16 @@
+CREATE TABLE user_sessions (
+  id            varchar(36),
+  user_id       varchar(36)     NOT NULL,
+  device_name   varchar(100)    NOT NULL,
+  ip_address    varchar(45)     NOT NULL,
+  user_agent    varchar(512)    NOT NULL DEFAULT '',
+  last_seen_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+  expires_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT user_sessions_pk PRIMARY KEY (id),
+  CONSTRAINT user_sessions_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX user_sessions_user_id_idx ON user_sessions (user_id);
@@ -0,0 +1,17 @@
+CREATE TABLE login_history (
+  id                  varchar(36),
+  user_id             varchar(36)     NOT NULL,
+  ip_address          varchar(45)     NOT NULL,
+  user_agent          varchar(512)    NOT NULL DEFAULT '',
+  device_fingerprint  varchar(64)     NOT NULL,
+  success             boolean         NOT NULL,
+  mfa_used            boolean         NOT NULL DEFAULT false,
+  created_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT login_history_pk PRIMARY KEY (id),
+  CONSTRAINT login_history_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX login_history_user_id_created_at_idx ON login_history (user_id, created_at DESC);
+CREATE INDEX login_history_user_id_device_fingerprint_idx ON login_history (user_id, device_fingerprint);
@@ -0,0 +1,19 @@
+CREATE TABLE outbox_events (
+  id                varchar(36),
+  aggregate_id      varchar(36)     NOT NULL,
+  event_type        varchar(64)     NOT NULL,
+  payload           text            NOT NULL,
+  attempts          integer         NOT NULL DEFAULT 0,
+  last_error        text            NOT NULL DEFAULT '',
+  next_attempt_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  stream_id         varchar(64)     NOT NULL DEFAULT '',
+  published_at      TIMESTAMP WITH TIME ZONE,
+  dead_lettered_at  TIMESTAMP WITH TIME ZONE,
+  created_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT outbox_events_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (created_at, id)
+  WHERE published_at IS NULL AND dead_lettered_at IS NULL;
@@ -0,0 +1,35 @@
+CREATE TABLE webhook_subscriptions (
+  id           varchar(36),
+  url          varchar(2048)   NOT NULL,
+  secret       varchar(64)     NOT NULL,
+  event_types  text            NOT NULL,
+  created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT webhook_subscriptions_pk PRIMARY KEY (id)
+);
+
+CREATE TABLE webhook_deliveries (
+  id                varchar(36),
+  subscription_id   varchar(36)     NOT NULL,
+  event_id          varchar(36)     NOT NULL,
+  event_type        varchar(64)     NOT NULL,
+  payload           text            NOT NULL,
+  occurred_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+  status            varchar(16)     NOT NULL,
+  attempts          integer         NOT NULL DEFAULT 0,
+  last_status_code  integer         NOT NULL DEFAULT 0,
+  last_error        text            NOT NULL DEFAULT '',
+  next_attempt_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  delivered_at      TIMESTAMP WITH TIME ZONE,
+  created_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT webhook_deliveries_pk PRIMARY KEY (id),
+  CONSTRAINT webhook_deliveries_subscription_fk FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+  CONSTRAINT webhook_deliveries_subscription_event_unique UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_subscription_id_created_at_idx ON webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at)
+  WHERE status = 'pending';
@@ -0,0 +1,22 @@
+CREATE TABLE email_changes (
+  id                  varchar(36),
+  user_id             varchar(36)     NOT NULL,
+  old_email           varchar(2048)   NOT NULL,
+  new_email           varchar(2048)   NOT NULL,
+  confirm_token_hash  varchar(64)     NOT NULL,
+  cancel_token_hash   varchar(64)     NOT NULL,
+  status              varchar(16)     NOT NULL,
+  expires_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+  cancel_expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+  confirmed_at        TIMESTAMP WITH TIME ZONE,
+  cancelled_at        TIMESTAMP WITH TIME ZONE,
+  created_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT email_changes_pk PRIMARY KEY (id),
+  CONSTRAINT email_changes_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT email_changes_confirm_token_hash_unique UNIQUE (confirm_token_hash),
+  CONSTRAINT email_changes_cancel_token_hash_unique UNIQUE (cancel_token_hash)
+);
+
+CREATE INDEX email_changes_user_id_idx ON email_changes (user_id);
@@ -0,0 +1,15 @@
+CREATE TABLE username_history (
+  id            varchar(36),
+  user_id       varchar(36)     NOT NULL,
+  old_username  varchar(255)    NOT NULL,
+  new_username  varchar(255)    NOT NULL,
+  held_until    TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT username_history_pk PRIMARY KEY (id),
+  CONSTRAINT username_history_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX username_history_user_id_idx ON username_history (user_id, created_at);
+CREATE INDEX username_history_old_username_idx ON username_history (old_username, created_at);
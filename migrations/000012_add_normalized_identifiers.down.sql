@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS username_history_old_username_normalized_idx;
+ALTER TABLE username_history DROP COLUMN IF EXISTS old_username_normalized;
+
+DROP INDEX IF EXISTS users_email_normalized_unique;
+DROP INDEX IF EXISTS users_username_normalized_unique;
+ALTER TABLE users DROP COLUMN IF EXISTS email_normalized;
+ALTER TABLE users DROP COLUMN IF EXISTS username_normalized;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN username_normalized varchar(255);
+ALTER TABLE users ADD COLUMN email_normalized varchar(2048);
+
+CREATE UNIQUE INDEX users_username_normalized_unique ON users (username_normalized);
+CREATE UNIQUE INDEX users_email_normalized_unique ON users (email_normalized);
+
+ALTER TABLE username_history ADD COLUMN old_username_normalized varchar(255);
+
+CREATE INDEX username_history_old_username_normalized_idx ON username_history (old_username_normalized, created_at);
@@ -0,0 +1,10 @@
+CREATE TABLE username_policy_entries (
+  id          varchar(36),
+  kind        varchar(32)     NOT NULL,
+  value       varchar(255)    NOT NULL,
+  created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT username_policy_entries_pk PRIMARY KEY (id),
+  CONSTRAINT username_policy_entries_kind_value_unique UNIQUE (kind, value)
+);
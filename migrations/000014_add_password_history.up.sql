@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users SET password_changed_at = created_at WHERE password <> '';
+
+CREATE TABLE password_history (
+  id            varchar(36),
+  user_id       varchar(36)     NOT NULL,
+  password_hash varchar(255)    NOT NULL,
+  created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT password_history_pk PRIMARY KEY (id),
+  CONSTRAINT password_history_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX password_history_user_id_idx ON password_history (user_id, created_at);
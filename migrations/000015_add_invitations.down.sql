@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS invitations;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
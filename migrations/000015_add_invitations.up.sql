@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN role varchar(32) NOT NULL DEFAULT 'member';
+
+CREATE TABLE invitations (
+  id          varchar(36),
+  email       varchar(2048)   NOT NULL,
+  role        varchar(32)     NOT NULL,
+  token_hash  varchar(64)     NOT NULL,
+  status      varchar(16)     NOT NULL,
+  expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+  accepted_at TIMESTAMP WITH TIME ZONE,
+  user_id     varchar(36),
+  created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT invitations_pk PRIMARY KEY (id),
+  CONSTRAINT invitations_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL,
+  CONSTRAINT invitations_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX invitations_email_idx ON invitations (email);
@@ -0,0 +1,48 @@
+CREATE TABLE organizations (
+  id          varchar(36),
+  name        varchar(255)    NOT NULL,
+  created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT organizations_pk PRIMARY KEY (id)
+);
+
+CREATE TABLE memberships (
+  id              varchar(36),
+  organization_id varchar(36)     NOT NULL,
+  user_id         varchar(36)     NOT NULL,
+  role            varchar(16)     NOT NULL,
+  created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT memberships_pk PRIMARY KEY (id),
+  CONSTRAINT memberships_organization_fk FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE,
+  CONSTRAINT memberships_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT memberships_organization_user_unique UNIQUE (organization_id, user_id)
+);
+
+CREATE INDEX memberships_user_id_idx ON memberships (user_id);
+
+-- An organization has exactly one owner
+CREATE UNIQUE INDEX memberships_organization_owner_unique ON memberships (organization_id) WHERE role = 'owner';
+
+CREATE TABLE organization_invitations (
+  id              varchar(36),
+  organization_id varchar(36)     NOT NULL,
+  email           varchar(2048)   NOT NULL,
+  role            varchar(16)     NOT NULL,
+  token_hash      varchar(64)     NOT NULL,
+  status          varchar(16)     NOT NULL,
+  expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+  accepted_at     TIMESTAMP WITH TIME ZONE,
+  user_id         varchar(36),
+  created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+  CONSTRAINT organization_invitations_pk PRIMARY KEY (id),
+  CONSTRAINT organization_invitations_organization_fk FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE,
+  CONSTRAINT organization_invitations_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL,
+  CONSTRAINT organization_invitations_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX organization_invitations_organization_id_idx ON organization_invitations (organization_id);
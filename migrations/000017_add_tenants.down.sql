@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS username_history_tenant_old_username_normalized_idx;
+CREATE INDEX IF NOT EXISTS username_history_old_username_normalized_idx ON username_history (old_username_normalized, created_at);
+ALTER TABLE username_history DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS users_tenant_email_normalized_unique;
+DROP INDEX IF EXISTS users_tenant_username_normalized_unique;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_email_unique;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_username_unique;
+
+-- Fails if several tenants share a username or an email address
+CREATE UNIQUE INDEX users_email_normalized_unique ON users (email_normalized);
+CREATE UNIQUE INDEX users_username_normalized_unique ON users (username_normalized);
+ALTER TABLE users ADD CONSTRAINT users_email_unique UNIQUE (email);
+ALTER TABLE users ADD CONSTRAINT users_username_unique UNIQUE (username);
+
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+-- Usernames and email addresses are unique per tenant
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_unique;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_unique;
+DROP INDEX IF EXISTS users_username_normalized_unique;
+DROP INDEX IF EXISTS users_email_normalized_unique;
+
+ALTER TABLE users ADD CONSTRAINT users_tenant_username_unique UNIQUE (tenant_id, username);
+ALTER TABLE users ADD CONSTRAINT users_tenant_email_unique UNIQUE (tenant_id, email);
+CREATE UNIQUE INDEX users_tenant_username_normalized_unique ON users (tenant_id, username_normalized);
+CREATE UNIQUE INDEX users_tenant_email_normalized_unique ON users (tenant_id, email_normalized);
+
+-- Usernames are held within the tenant of the user who changed away from them
+ALTER TABLE username_history ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+UPDATE username_history SET tenant_id = users.tenant_id FROM users WHERE users.id = username_history.user_id;
+
+DROP INDEX IF EXISTS username_history_old_username_normalized_idx;
+CREATE INDEX username_history_tenant_old_username_normalized_idx ON username_history (tenant_id, old_username_normalized, created_at);
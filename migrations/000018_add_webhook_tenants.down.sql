@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS webhook_subscriptions_tenant_id_idx;
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhook_subscriptions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,12 @@
+-- Events carry the tenant of their change, and are only delivered to the subscriptions of that tenant
+ALTER TABLE outbox_events ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+UPDATE outbox_events SET tenant_id = users.tenant_id FROM users WHERE users.id = outbox_events.aggregate_id;
+
+ALTER TABLE webhook_subscriptions ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_deliveries ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+UPDATE webhook_deliveries SET tenant_id = webhook_subscriptions.tenant_id
+  FROM webhook_subscriptions WHERE webhook_subscriptions.id = webhook_deliveries.subscription_id;
+
+CREATE INDEX webhook_subscriptions_tenant_id_idx ON webhook_subscriptions (tenant_id, created_at);
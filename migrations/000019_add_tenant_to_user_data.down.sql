@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS invitations_tenant_id_email_idx;
+DROP INDEX IF EXISTS organizations_tenant_id_idx;
+
+ALTER TABLE organization_invitations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE memberships DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE organizations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE invitations DROP COLUMN IF EXISTS tenant_id;
+
+-- Fails if a provider subject is linked in several tenants
+ALTER TABLE user_identities DROP CONSTRAINT IF EXISTS user_identities_tenant_provider_subject_unique;
+ALTER TABLE user_identities ADD CONSTRAINT user_identities_provider_subject_unique UNIQUE (provider, subject);
+
+ALTER TABLE password_history DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE email_changes DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE login_history DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE personal_access_tokens DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_passkeys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_identities DROP COLUMN IF EXISTS tenant_id;
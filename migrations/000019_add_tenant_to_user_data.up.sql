@@ -0,0 +1,40 @@
+-- The data of a user belongs to the tenant of the user
+ALTER TABLE user_identities ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE user_passkeys ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE personal_access_tokens ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE user_sessions ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE login_history ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE email_changes ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE password_history ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+UPDATE user_identities SET tenant_id = users.tenant_id FROM users WHERE users.id = user_identities.user_id;
+UPDATE user_passkeys SET tenant_id = users.tenant_id FROM users WHERE users.id = user_passkeys.user_id;
+UPDATE personal_access_tokens SET tenant_id = users.tenant_id FROM users WHERE users.id = personal_access_tokens.user_id;
+UPDATE user_sessions SET tenant_id = users.tenant_id FROM users WHERE users.id = user_sessions.user_id;
+UPDATE login_history SET tenant_id = users.tenant_id FROM users WHERE users.id = login_history.user_id;
+UPDATE email_changes SET tenant_id = users.tenant_id FROM users WHERE users.id = email_changes.user_id;
+UPDATE password_history SET tenant_id = users.tenant_id FROM users WHERE users.id = password_history.user_id;
+
+-- A provider subject can be linked once in every tenant
+ALTER TABLE user_identities DROP CONSTRAINT IF EXISTS user_identities_provider_subject_unique;
+ALTER TABLE user_identities ADD CONSTRAINT user_identities_tenant_provider_subject_unique UNIQUE (tenant_id, provider, subject);
+
+-- Invitations register users in a tenant; the accepted ones belong to the tenant of their user
+ALTER TABLE invitations ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+UPDATE invitations SET tenant_id = users.tenant_id FROM users WHERE users.id = invitations.user_id;
+
+-- Organizations belong to the tenant of their owner
+ALTER TABLE organizations ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE memberships ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE organization_invitations ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+UPDATE organizations SET tenant_id = users.tenant_id
+  FROM memberships JOIN users ON users.id = memberships.user_id
+  WHERE memberships.organization_id = organizations.id AND memberships.role = 'owner';
+UPDATE memberships SET tenant_id = organizations.tenant_id FROM organizations WHERE organizations.id = memberships.organization_id;
+UPDATE organization_invitations SET tenant_id = organizations.tenant_id
+  FROM organizations WHERE organizations.id = organization_invitations.organization_id;
+
+CREATE INDEX organizations_tenant_id_idx ON organizations (tenant_id);
+CREATE INDEX invitations_tenant_id_email_idx ON invitations (tenant_id, email);
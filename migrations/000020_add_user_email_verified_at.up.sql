@@ -0,0 +1,2 @@
+-- Users have not proved they own their email address until they use a link sent to it
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;